@@ -9,6 +9,8 @@ package shortener
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	unsafe "unsafe"
 )
@@ -21,12 +23,21 @@ const (
 )
 
 type URLShortenRequest struct {
-	state                  protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Url         *string                `protobuf:"bytes,1,opt,name=url"`
-	XXX_raceDetectHookData protoimpl.RaceDetectHookData
-	XXX_presence           [1]uint32
-	unknownFields          protoimpl.UnknownFields
-	sizeCache              protoimpl.SizeCache
+	state                   protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Url          *string                `protobuf:"bytes,1,opt,name=url"`
+	xxx_hidden_Alias        *string                `protobuf:"bytes,2,opt,name=alias"`
+	xxx_hidden_ExpiresAt    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt"`
+	xxx_hidden_Ttl          *durationpb.Duration   `protobuf:"bytes,4,opt,name=ttl"`
+	xxx_hidden_MaxClicks    int32                  `protobuf:"varint,5,opt,name=max_clicks,json=maxClicks"`
+	xxx_hidden_Password     *string                `protobuf:"bytes,6,opt,name=password"`
+	xxx_hidden_IdStyle      *string                `protobuf:"bytes,7,opt,name=id_style,json=idStyle"`
+	xxx_hidden_Tags         []string               `protobuf:"bytes,8,rep,name=tags"`
+	xxx_hidden_RedirectType int32                  `protobuf:"varint,9,opt,name=redirect_type,json=redirectType"`
+	xxx_hidden_Passthrough  bool                   `protobuf:"varint,10,opt,name=passthrough"`
+	XXX_raceDetectHookData  protoimpl.RaceDetectHookData
+	XXX_presence            [1]uint32
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
 }
 
 func (x *URLShortenRequest) Reset() {
@@ -64,9 +75,123 @@ func (x *URLShortenRequest) GetUrl() string {
 	return ""
 }
 
+func (x *URLShortenRequest) GetAlias() string {
+	if x != nil {
+		if x.xxx_hidden_Alias != nil {
+			return *x.xxx_hidden_Alias
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *URLShortenRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_ExpiresAt
+	}
+	return nil
+}
+
+func (x *URLShortenRequest) GetTtl() *durationpb.Duration {
+	if x != nil {
+		return x.xxx_hidden_Ttl
+	}
+	return nil
+}
+
+func (x *URLShortenRequest) GetMaxClicks() int32 {
+	if x != nil {
+		return x.xxx_hidden_MaxClicks
+	}
+	return 0
+}
+
+func (x *URLShortenRequest) GetPassword() string {
+	if x != nil {
+		if x.xxx_hidden_Password != nil {
+			return *x.xxx_hidden_Password
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *URLShortenRequest) GetIdStyle() string {
+	if x != nil {
+		if x.xxx_hidden_IdStyle != nil {
+			return *x.xxx_hidden_IdStyle
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *URLShortenRequest) GetTags() []string {
+	if x != nil {
+		return x.xxx_hidden_Tags
+	}
+	return nil
+}
+
+func (x *URLShortenRequest) GetRedirectType() int32 {
+	if x != nil {
+		return x.xxx_hidden_RedirectType
+	}
+	return 0
+}
+
+func (x *URLShortenRequest) GetPassthrough() bool {
+	if x != nil {
+		return x.xxx_hidden_Passthrough
+	}
+	return false
+}
+
 func (x *URLShortenRequest) SetUrl(v string) {
 	x.xxx_hidden_Url = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 10)
+}
+
+func (x *URLShortenRequest) SetAlias(v string) {
+	x.xxx_hidden_Alias = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 10)
+}
+
+func (x *URLShortenRequest) SetExpiresAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_ExpiresAt = v
+}
+
+func (x *URLShortenRequest) SetTtl(v *durationpb.Duration) {
+	x.xxx_hidden_Ttl = v
+}
+
+func (x *URLShortenRequest) SetMaxClicks(v int32) {
+	x.xxx_hidden_MaxClicks = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 10)
+}
+
+func (x *URLShortenRequest) SetPassword(v string) {
+	x.xxx_hidden_Password = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 10)
+}
+
+func (x *URLShortenRequest) SetIdStyle(v string) {
+	x.xxx_hidden_IdStyle = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 10)
+}
+
+func (x *URLShortenRequest) SetTags(v []string) {
+	x.xxx_hidden_Tags = v
+}
+
+func (x *URLShortenRequest) SetRedirectType(v int32) {
+	x.xxx_hidden_RedirectType = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 8, 10)
+}
+
+func (x *URLShortenRequest) SetPassthrough(v bool) {
+	x.xxx_hidden_Passthrough = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 9, 10)
 }
 
 func (x *URLShortenRequest) HasUrl() bool {
@@ -76,15 +201,118 @@ func (x *URLShortenRequest) HasUrl() bool {
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
 }
 
+func (x *URLShortenRequest) HasAlias() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *URLShortenRequest) HasExpiresAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_ExpiresAt != nil
+}
+
+func (x *URLShortenRequest) HasTtl() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Ttl != nil
+}
+
+func (x *URLShortenRequest) HasMaxClicks() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *URLShortenRequest) HasPassword() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
+func (x *URLShortenRequest) HasIdStyle() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 6)
+}
+
+func (x *URLShortenRequest) HasRedirectType() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 8)
+}
+
+func (x *URLShortenRequest) HasPassthrough() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 9)
+}
+
 func (x *URLShortenRequest) ClearUrl() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
 	x.xxx_hidden_Url = nil
 }
 
+func (x *URLShortenRequest) ClearAlias() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Alias = nil
+}
+
+func (x *URLShortenRequest) ClearExpiresAt() {
+	x.xxx_hidden_ExpiresAt = nil
+}
+
+func (x *URLShortenRequest) ClearTtl() {
+	x.xxx_hidden_Ttl = nil
+}
+
+func (x *URLShortenRequest) ClearMaxClicks() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_MaxClicks = 0
+}
+
+func (x *URLShortenRequest) ClearPassword() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_Password = nil
+}
+
+func (x *URLShortenRequest) ClearIdStyle() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 6)
+	x.xxx_hidden_IdStyle = nil
+}
+
+func (x *URLShortenRequest) ClearRedirectType() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 8)
+	x.xxx_hidden_RedirectType = 0
+}
+
+func (x *URLShortenRequest) ClearPassthrough() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 9)
+	x.xxx_hidden_Passthrough = false
+}
+
 type URLShortenRequest_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	Url *string
+	Url          *string
+	Alias        *string
+	ExpiresAt    *timestamppb.Timestamp
+	Ttl          *durationpb.Duration
+	MaxClicks    *int32
+	Password     *string
+	IdStyle      *string
+	Tags         []string
+	RedirectType *int32
+	Passthrough  *bool
 }
 
 func (b0 URLShortenRequest_builder) Build() *URLShortenRequest {
@@ -92,9 +320,36 @@ func (b0 URLShortenRequest_builder) Build() *URLShortenRequest {
 	b, x := &b0, m0
 	_, _ = b, x
 	if b.Url != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 10)
 		x.xxx_hidden_Url = b.Url
 	}
+	if b.Alias != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 10)
+		x.xxx_hidden_Alias = b.Alias
+	}
+	x.xxx_hidden_ExpiresAt = b.ExpiresAt
+	x.xxx_hidden_Ttl = b.Ttl
+	if b.MaxClicks != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 10)
+		x.xxx_hidden_MaxClicks = *b.MaxClicks
+	}
+	if b.Password != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 10)
+		x.xxx_hidden_Password = b.Password
+	}
+	if b.IdStyle != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 10)
+		x.xxx_hidden_IdStyle = b.IdStyle
+	}
+	x.xxx_hidden_Tags = b.Tags
+	if b.RedirectType != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 8, 10)
+		x.xxx_hidden_RedirectType = *b.RedirectType
+	}
+	if b.Passthrough != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 9, 10)
+		x.xxx_hidden_Passthrough = *b.Passthrough
+	}
 	return m0
 }
 
@@ -162,44 +417,3590 @@ func (x *URLShortenResponse) ClearResult() {
 type URLShortenResponse_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	Result *string
+	Result *string
+}
+
+func (b0 URLShortenResponse_builder) Build() *URLShortenResponse {
+	m0 := &URLShortenResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Result != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		x.xxx_hidden_Result = b.Result
+	}
+	return m0
+}
+
+type URLExpandRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Id          *string                `protobuf:"bytes,1,opt,name=id"`
+	xxx_hidden_Password    *string                `protobuf:"bytes,2,opt,name=password"`
+	xxx_hidden_Path        *string                `protobuf:"bytes,3,opt,name=path"`
+	xxx_hidden_Query       *string                `protobuf:"bytes,4,opt,name=query"`
+	xxx_hidden_Headers     map[string]string      `protobuf:"bytes,5,rep,name=headers" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	xxx_hidden_Variant     *string                `protobuf:"bytes,6,opt,name=variant"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *URLExpandRequest) Reset() {
+	*x = URLExpandRequest{}
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *URLExpandRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*URLExpandRequest) ProtoMessage() {}
+
+func (x *URLExpandRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *URLExpandRequest) GetId() string {
+	if x != nil {
+		if x.xxx_hidden_Id != nil {
+			return *x.xxx_hidden_Id
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *URLExpandRequest) GetPassword() string {
+	if x != nil {
+		if x.xxx_hidden_Password != nil {
+			return *x.xxx_hidden_Password
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *URLExpandRequest) GetPath() string {
+	if x != nil {
+		if x.xxx_hidden_Path != nil {
+			return *x.xxx_hidden_Path
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *URLExpandRequest) GetQuery() string {
+	if x != nil {
+		if x.xxx_hidden_Query != nil {
+			return *x.xxx_hidden_Query
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *URLExpandRequest) GetHeaders() map[string]string {
+	if x != nil {
+		return x.xxx_hidden_Headers
+	}
+	return nil
+}
+
+func (x *URLExpandRequest) GetVariant() string {
+	if x != nil {
+		if x.xxx_hidden_Variant != nil {
+			return *x.xxx_hidden_Variant
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *URLExpandRequest) SetId(v string) {
+	x.xxx_hidden_Id = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 6)
+}
+
+func (x *URLExpandRequest) SetPassword(v string) {
+	x.xxx_hidden_Password = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 6)
+}
+
+func (x *URLExpandRequest) SetPath(v string) {
+	x.xxx_hidden_Path = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 6)
+}
+
+func (x *URLExpandRequest) SetQuery(v string) {
+	x.xxx_hidden_Query = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 6)
+}
+
+func (x *URLExpandRequest) SetHeaders(v map[string]string) {
+	x.xxx_hidden_Headers = v
+}
+
+func (x *URLExpandRequest) SetVariant(v string) {
+	x.xxx_hidden_Variant = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 6)
+}
+
+func (x *URLExpandRequest) HasId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *URLExpandRequest) HasPassword() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *URLExpandRequest) HasPath() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *URLExpandRequest) HasQuery() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *URLExpandRequest) HasVariant() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
+func (x *URLExpandRequest) ClearId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Id = nil
+}
+
+func (x *URLExpandRequest) ClearPassword() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Password = nil
+}
+
+func (x *URLExpandRequest) ClearPath() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Path = nil
+}
+
+func (x *URLExpandRequest) ClearQuery() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_Query = nil
+}
+
+func (x *URLExpandRequest) ClearVariant() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_Variant = nil
+}
+
+type URLExpandRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Id       *string
+	Password *string
+	Path     *string
+	Query    *string
+	Headers  map[string]string
+	Variant  *string
+}
+
+func (b0 URLExpandRequest_builder) Build() *URLExpandRequest {
+	m0 := &URLExpandRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Id != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 6)
+		x.xxx_hidden_Id = b.Id
+	}
+	if b.Password != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 6)
+		x.xxx_hidden_Password = b.Password
+	}
+	if b.Path != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 6)
+		x.xxx_hidden_Path = b.Path
+	}
+	if b.Query != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 6)
+		x.xxx_hidden_Query = b.Query
+	}
+	x.xxx_hidden_Headers = b.Headers
+	if b.Variant != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 6)
+		x.xxx_hidden_Variant = b.Variant
+	}
+	return m0
+}
+
+type URLExpandResponse struct {
+	state                   protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Result       *string                `protobuf:"bytes,1,opt,name=result"`
+	xxx_hidden_RedirectType int32                  `protobuf:"varint,2,opt,name=redirect_type,json=redirectType"`
+	xxx_hidden_Variant      *string                `protobuf:"bytes,3,opt,name=variant"`
+	xxx_hidden_Sticky       bool                   `protobuf:"varint,4,opt,name=sticky"`
+	XXX_raceDetectHookData  protoimpl.RaceDetectHookData
+	XXX_presence            [1]uint32
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *URLExpandResponse) Reset() {
+	*x = URLExpandResponse{}
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *URLExpandResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*URLExpandResponse) ProtoMessage() {}
+
+func (x *URLExpandResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *URLExpandResponse) GetResult() string {
+	if x != nil {
+		if x.xxx_hidden_Result != nil {
+			return *x.xxx_hidden_Result
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *URLExpandResponse) GetRedirectType() int32 {
+	if x != nil {
+		return x.xxx_hidden_RedirectType
+	}
+	return 0
+}
+
+func (x *URLExpandResponse) GetVariant() string {
+	if x != nil {
+		if x.xxx_hidden_Variant != nil {
+			return *x.xxx_hidden_Variant
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *URLExpandResponse) GetSticky() bool {
+	if x != nil {
+		return x.xxx_hidden_Sticky
+	}
+	return false
+}
+
+func (x *URLExpandResponse) SetResult(v string) {
+	x.xxx_hidden_Result = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 4)
+}
+
+func (x *URLExpandResponse) SetRedirectType(v int32) {
+	x.xxx_hidden_RedirectType = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 4)
+}
+
+func (x *URLExpandResponse) SetVariant(v string) {
+	x.xxx_hidden_Variant = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 4)
+}
+
+func (x *URLExpandResponse) SetSticky(v bool) {
+	x.xxx_hidden_Sticky = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 4)
+}
+
+func (x *URLExpandResponse) HasResult() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *URLExpandResponse) HasRedirectType() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *URLExpandResponse) HasVariant() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *URLExpandResponse) HasSticky() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *URLExpandResponse) ClearResult() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Result = nil
+}
+
+func (x *URLExpandResponse) ClearRedirectType() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_RedirectType = 0
+}
+
+func (x *URLExpandResponse) ClearVariant() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Variant = nil
+}
+
+func (x *URLExpandResponse) ClearSticky() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_Sticky = false
+}
+
+type URLExpandResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Result       *string
+	RedirectType *int32
+	Variant      *string
+	Sticky       *bool
+}
+
+func (b0 URLExpandResponse_builder) Build() *URLExpandResponse {
+	m0 := &URLExpandResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Result != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 4)
+		x.xxx_hidden_Result = b.Result
+	}
+	if b.RedirectType != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 4)
+		x.xxx_hidden_RedirectType = *b.RedirectType
+	}
+	if b.Variant != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 4)
+		x.xxx_hidden_Variant = b.Variant
+	}
+	if b.Sticky != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 4)
+		x.xxx_hidden_Sticky = *b.Sticky
+	}
+	return m0
+}
+
+type URLInspectRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Id          *string                `protobuf:"bytes,1,opt,name=id"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *URLInspectRequest) Reset() {
+	*x = URLInspectRequest{}
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *URLInspectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*URLInspectRequest) ProtoMessage() {}
+
+func (x *URLInspectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *URLInspectRequest) GetId() string {
+	if x != nil {
+		if x.xxx_hidden_Id != nil {
+			return *x.xxx_hidden_Id
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *URLInspectRequest) SetId(v string) {
+	x.xxx_hidden_Id = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+}
+
+func (x *URLInspectRequest) HasId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *URLInspectRequest) ClearId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Id = nil
+}
+
+type URLInspectRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Id *string
+}
+
+func (b0 URLInspectRequest_builder) Build() *URLInspectRequest {
+	m0 := &URLInspectRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Id != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		x.xxx_hidden_Id = b.Id
+	}
+	return m0
+}
+
+type URLInspectResponse struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_ShortUrl    *string                `protobuf:"bytes,1,opt,name=short_url,json=shortUrl"`
+	xxx_hidden_OriginalUrl *string                `protobuf:"bytes,2,opt,name=original_url,json=originalUrl"`
+	xxx_hidden_CreatedAt   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt"`
+	xxx_hidden_ExpiresAt   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt"`
+	xxx_hidden_Protected   bool                   `protobuf:"varint,5,opt,name=protected"`
+	xxx_hidden_Safety      *string                `protobuf:"bytes,6,opt,name=safety"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *URLInspectResponse) Reset() {
+	*x = URLInspectResponse{}
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *URLInspectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*URLInspectResponse) ProtoMessage() {}
+
+func (x *URLInspectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *URLInspectResponse) GetShortUrl() string {
+	if x != nil {
+		if x.xxx_hidden_ShortUrl != nil {
+			return *x.xxx_hidden_ShortUrl
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *URLInspectResponse) GetOriginalUrl() string {
+	if x != nil {
+		if x.xxx_hidden_OriginalUrl != nil {
+			return *x.xxx_hidden_OriginalUrl
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *URLInspectResponse) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_CreatedAt
+	}
+	return nil
+}
+
+func (x *URLInspectResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_ExpiresAt
+	}
+	return nil
+}
+
+func (x *URLInspectResponse) GetProtected() bool {
+	if x != nil {
+		return x.xxx_hidden_Protected
+	}
+	return false
+}
+
+func (x *URLInspectResponse) GetSafety() string {
+	if x != nil {
+		if x.xxx_hidden_Safety != nil {
+			return *x.xxx_hidden_Safety
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *URLInspectResponse) SetShortUrl(v string) {
+	x.xxx_hidden_ShortUrl = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 6)
+}
+
+func (x *URLInspectResponse) SetOriginalUrl(v string) {
+	x.xxx_hidden_OriginalUrl = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 6)
+}
+
+func (x *URLInspectResponse) SetCreatedAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_CreatedAt = v
+}
+
+func (x *URLInspectResponse) SetExpiresAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_ExpiresAt = v
+}
+
+func (x *URLInspectResponse) SetProtected(v bool) {
+	x.xxx_hidden_Protected = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 6)
+}
+
+func (x *URLInspectResponse) SetSafety(v string) {
+	x.xxx_hidden_Safety = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 6)
+}
+
+func (x *URLInspectResponse) HasShortUrl() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *URLInspectResponse) HasOriginalUrl() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *URLInspectResponse) HasCreatedAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_CreatedAt != nil
+}
+
+func (x *URLInspectResponse) HasExpiresAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_ExpiresAt != nil
+}
+
+func (x *URLInspectResponse) HasProtected() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *URLInspectResponse) HasSafety() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
+func (x *URLInspectResponse) ClearShortUrl() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_ShortUrl = nil
+}
+
+func (x *URLInspectResponse) ClearOriginalUrl() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_OriginalUrl = nil
+}
+
+func (x *URLInspectResponse) ClearCreatedAt() {
+	x.xxx_hidden_CreatedAt = nil
+}
+
+func (x *URLInspectResponse) ClearExpiresAt() {
+	x.xxx_hidden_ExpiresAt = nil
+}
+
+func (x *URLInspectResponse) ClearProtected() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_Protected = false
+}
+
+func (x *URLInspectResponse) ClearSafety() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_Safety = nil
+}
+
+type URLInspectResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	ShortUrl    *string
+	OriginalUrl *string
+	CreatedAt   *timestamppb.Timestamp
+	ExpiresAt   *timestamppb.Timestamp
+	Protected   *bool
+	Safety      *string
+}
+
+func (b0 URLInspectResponse_builder) Build() *URLInspectResponse {
+	m0 := &URLInspectResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.ShortUrl != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 6)
+		x.xxx_hidden_ShortUrl = b.ShortUrl
+	}
+	if b.OriginalUrl != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 6)
+		x.xxx_hidden_OriginalUrl = b.OriginalUrl
+	}
+	x.xxx_hidden_CreatedAt = b.CreatedAt
+	x.xxx_hidden_ExpiresAt = b.ExpiresAt
+	if b.Protected != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 6)
+		x.xxx_hidden_Protected = *b.Protected
+	}
+	if b.Safety != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 6)
+		x.xxx_hidden_Safety = b.Safety
+	}
+	return m0
+}
+
+type QRCodeRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Id          *string                `protobuf:"bytes,1,opt,name=id"`
+	xxx_hidden_Format      *string                `protobuf:"bytes,2,opt,name=format"`
+	xxx_hidden_Size        int32                  `protobuf:"varint,3,opt,name=size"`
+	xxx_hidden_Level       *string                `protobuf:"bytes,4,opt,name=level"`
+	xxx_hidden_Margin      int32                  `protobuf:"varint,5,opt,name=margin"`
+	xxx_hidden_Foreground  *string                `protobuf:"bytes,6,opt,name=foreground"`
+	xxx_hidden_Background  *string                `protobuf:"bytes,7,opt,name=background"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *QRCodeRequest) Reset() {
+	*x = QRCodeRequest{}
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QRCodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QRCodeRequest) ProtoMessage() {}
+
+func (x *QRCodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *QRCodeRequest) GetId() string {
+	if x != nil {
+		if x.xxx_hidden_Id != nil {
+			return *x.xxx_hidden_Id
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *QRCodeRequest) GetFormat() string {
+	if x != nil {
+		if x.xxx_hidden_Format != nil {
+			return *x.xxx_hidden_Format
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *QRCodeRequest) GetSize() int32 {
+	if x != nil {
+		return x.xxx_hidden_Size
+	}
+	return 0
+}
+
+func (x *QRCodeRequest) GetLevel() string {
+	if x != nil {
+		if x.xxx_hidden_Level != nil {
+			return *x.xxx_hidden_Level
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *QRCodeRequest) GetMargin() int32 {
+	if x != nil {
+		return x.xxx_hidden_Margin
+	}
+	return 0
+}
+
+func (x *QRCodeRequest) GetForeground() string {
+	if x != nil {
+		if x.xxx_hidden_Foreground != nil {
+			return *x.xxx_hidden_Foreground
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *QRCodeRequest) GetBackground() string {
+	if x != nil {
+		if x.xxx_hidden_Background != nil {
+			return *x.xxx_hidden_Background
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *QRCodeRequest) SetId(v string) {
+	x.xxx_hidden_Id = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 7)
+}
+
+func (x *QRCodeRequest) SetFormat(v string) {
+	x.xxx_hidden_Format = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 7)
+}
+
+func (x *QRCodeRequest) SetSize(v int32) {
+	x.xxx_hidden_Size = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 7)
+}
+
+func (x *QRCodeRequest) SetLevel(v string) {
+	x.xxx_hidden_Level = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 7)
+}
+
+func (x *QRCodeRequest) SetMargin(v int32) {
+	x.xxx_hidden_Margin = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 7)
+}
+
+func (x *QRCodeRequest) SetForeground(v string) {
+	x.xxx_hidden_Foreground = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 7)
+}
+
+func (x *QRCodeRequest) SetBackground(v string) {
+	x.xxx_hidden_Background = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 7)
+}
+
+func (x *QRCodeRequest) HasId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *QRCodeRequest) HasFormat() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *QRCodeRequest) HasSize() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *QRCodeRequest) HasLevel() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *QRCodeRequest) HasMargin() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *QRCodeRequest) HasForeground() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
+func (x *QRCodeRequest) HasBackground() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 6)
+}
+
+func (x *QRCodeRequest) ClearId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Id = nil
+}
+
+func (x *QRCodeRequest) ClearFormat() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Format = nil
+}
+
+func (x *QRCodeRequest) ClearSize() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Size = 0
+}
+
+func (x *QRCodeRequest) ClearLevel() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_Level = nil
+}
+
+func (x *QRCodeRequest) ClearMargin() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_Margin = 0
+}
+
+func (x *QRCodeRequest) ClearForeground() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_Foreground = nil
+}
+
+func (x *QRCodeRequest) ClearBackground() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 6)
+	x.xxx_hidden_Background = nil
+}
+
+type QRCodeRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Id         *string
+	Format     *string
+	Size       *int32
+	Level      *string
+	Margin     *int32
+	Foreground *string
+	Background *string
+}
+
+func (b0 QRCodeRequest_builder) Build() *QRCodeRequest {
+	m0 := &QRCodeRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Id != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 7)
+		x.xxx_hidden_Id = b.Id
+	}
+	if b.Format != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 7)
+		x.xxx_hidden_Format = b.Format
+	}
+	if b.Size != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 7)
+		x.xxx_hidden_Size = *b.Size
+	}
+	if b.Level != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 7)
+		x.xxx_hidden_Level = b.Level
+	}
+	if b.Margin != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 7)
+		x.xxx_hidden_Margin = *b.Margin
+	}
+	if b.Foreground != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 7)
+		x.xxx_hidden_Foreground = b.Foreground
+	}
+	if b.Background != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 7)
+		x.xxx_hidden_Background = b.Background
+	}
+	return m0
+}
+
+type QRCodeResponse struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Data        []byte                 `protobuf:"bytes,1,opt,name=data"`
+	xxx_hidden_ContentType *string                `protobuf:"bytes,2,opt,name=content_type,json=contentType"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *QRCodeResponse) Reset() {
+	*x = QRCodeResponse{}
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QRCodeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QRCodeResponse) ProtoMessage() {}
+
+func (x *QRCodeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *QRCodeResponse) GetData() []byte {
+	if x != nil {
+		return x.xxx_hidden_Data
+	}
+	return nil
+}
+
+func (x *QRCodeResponse) GetContentType() string {
+	if x != nil {
+		if x.xxx_hidden_ContentType != nil {
+			return *x.xxx_hidden_ContentType
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *QRCodeResponse) SetData(v []byte) {
+	if v == nil {
+		v = []byte{}
+	}
+	x.xxx_hidden_Data = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *QRCodeResponse) SetContentType(v string) {
+	x.xxx_hidden_ContentType = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *QRCodeResponse) HasData() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *QRCodeResponse) HasContentType() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *QRCodeResponse) ClearData() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Data = nil
+}
+
+func (x *QRCodeResponse) ClearContentType() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_ContentType = nil
+}
+
+type QRCodeResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Data        []byte
+	ContentType *string
+}
+
+func (b0 QRCodeResponse_builder) Build() *QRCodeResponse {
+	m0 := &QRCodeResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Data != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_Data = b.Data
+	}
+	if b.ContentType != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_ContentType = b.ContentType
+	}
+	return m0
+}
+
+type UserURLsRequest struct {
+	state          protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Tag []string               `protobuf:"bytes,1,rep,name=tag"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *UserURLsRequest) Reset() {
+	*x = UserURLsRequest{}
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserURLsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserURLsRequest) ProtoMessage() {}
+
+func (x *UserURLsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *UserURLsRequest) GetTag() []string {
+	if x != nil {
+		return x.xxx_hidden_Tag
+	}
+	return nil
+}
+
+func (x *UserURLsRequest) SetTag(v []string) {
+	x.xxx_hidden_Tag = v
+}
+
+type UserURLsRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Tag []string
+}
+
+func (b0 UserURLsRequest_builder) Build() *UserURLsRequest {
+	m0 := &UserURLsRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Tag = b.Tag
+	return m0
+}
+
+type UserURLsResponse struct {
+	state          protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Url *[]*URLData            `protobuf:"bytes,1,rep,name=url"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *UserURLsResponse) Reset() {
+	*x = UserURLsResponse{}
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserURLsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserURLsResponse) ProtoMessage() {}
+
+func (x *UserURLsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *UserURLsResponse) GetUrl() []*URLData {
+	if x != nil {
+		if x.xxx_hidden_Url != nil {
+			return *x.xxx_hidden_Url
+		}
+	}
+	return nil
+}
+
+func (x *UserURLsResponse) SetUrl(v []*URLData) {
+	x.xxx_hidden_Url = &v
+}
+
+type UserURLsResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Url []*URLData
+}
+
+func (b0 UserURLsResponse_builder) Build() *UserURLsResponse {
+	m0 := &UserURLsResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Url = &b.Url
+	return m0
+}
+
+type URLUpdateRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Id          *string                `protobuf:"bytes,1,opt,name=id"`
+	xxx_hidden_Url         *string                `protobuf:"bytes,2,opt,name=url"`
+	xxx_hidden_Tags        *TagList               `protobuf:"bytes,3,opt,name=tags"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *URLUpdateRequest) Reset() {
+	*x = URLUpdateRequest{}
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *URLUpdateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*URLUpdateRequest) ProtoMessage() {}
+
+func (x *URLUpdateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *URLUpdateRequest) GetId() string {
+	if x != nil {
+		if x.xxx_hidden_Id != nil {
+			return *x.xxx_hidden_Id
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *URLUpdateRequest) GetUrl() string {
+	if x != nil {
+		if x.xxx_hidden_Url != nil {
+			return *x.xxx_hidden_Url
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *URLUpdateRequest) GetTags() *TagList {
+	if x != nil {
+		return x.xxx_hidden_Tags
+	}
+	return nil
+}
+
+func (x *URLUpdateRequest) SetId(v string) {
+	x.xxx_hidden_Id = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 3)
+}
+
+func (x *URLUpdateRequest) SetUrl(v string) {
+	x.xxx_hidden_Url = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 3)
+}
+
+func (x *URLUpdateRequest) SetTags(v *TagList) {
+	x.xxx_hidden_Tags = v
+}
+
+func (x *URLUpdateRequest) HasId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *URLUpdateRequest) HasUrl() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *URLUpdateRequest) HasTags() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Tags != nil
+}
+
+func (x *URLUpdateRequest) ClearId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Id = nil
+}
+
+func (x *URLUpdateRequest) ClearUrl() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Url = nil
+}
+
+func (x *URLUpdateRequest) ClearTags() {
+	x.xxx_hidden_Tags = nil
+}
+
+type URLUpdateRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Id   *string
+	Url  *string
+	Tags *TagList
+}
+
+func (b0 URLUpdateRequest_builder) Build() *URLUpdateRequest {
+	m0 := &URLUpdateRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Id != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 3)
+		x.xxx_hidden_Id = b.Id
+	}
+	if b.Url != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 3)
+		x.xxx_hidden_Url = b.Url
+	}
+	x.xxx_hidden_Tags = b.Tags
+	return m0
+}
+
+type TagList struct {
+	state          protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Tag []string               `protobuf:"bytes,1,rep,name=tag"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *TagList) Reset() {
+	*x = TagList{}
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TagList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TagList) ProtoMessage() {}
+
+func (x *TagList) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *TagList) GetTag() []string {
+	if x != nil {
+		return x.xxx_hidden_Tag
+	}
+	return nil
+}
+
+func (x *TagList) SetTag(v []string) {
+	x.xxx_hidden_Tag = v
+}
+
+type TagList_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Tag []string
+}
+
+func (b0 TagList_builder) Build() *TagList {
+	m0 := &TagList{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Tag = b.Tag
+	return m0
+}
+
+type URLUpdateResponse struct {
+	state             protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Result *URLData               `protobuf:"bytes,1,opt,name=result"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *URLUpdateResponse) Reset() {
+	*x = URLUpdateResponse{}
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *URLUpdateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*URLUpdateResponse) ProtoMessage() {}
+
+func (x *URLUpdateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *URLUpdateResponse) GetResult() *URLData {
+	if x != nil {
+		return x.xxx_hidden_Result
+	}
+	return nil
+}
+
+func (x *URLUpdateResponse) SetResult(v *URLData) {
+	x.xxx_hidden_Result = v
+}
+
+func (x *URLUpdateResponse) HasResult() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Result != nil
+}
+
+func (x *URLUpdateResponse) ClearResult() {
+	x.xxx_hidden_Result = nil
+}
+
+type URLUpdateResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Result *URLData
+}
+
+func (b0 URLUpdateResponse_builder) Build() *URLUpdateResponse {
+	m0 := &URLUpdateResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Result = b.Result
+	return m0
+}
+
+type UserTagsRequest struct {
+	state         protoimpl.MessageState `protogen:"opaque.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserTagsRequest) Reset() {
+	*x = UserTagsRequest{}
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserTagsRequest) ProtoMessage() {}
+
+func (x *UserTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+type UserTagsRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+}
+
+func (b0 UserTagsRequest_builder) Build() *UserTagsRequest {
+	m0 := &UserTagsRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	return m0
+}
+
+type UserTagsResponse struct {
+	state          protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Tag *[]*TagCount           `protobuf:"bytes,1,rep,name=tag"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *UserTagsResponse) Reset() {
+	*x = UserTagsResponse{}
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserTagsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserTagsResponse) ProtoMessage() {}
+
+func (x *UserTagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *UserTagsResponse) GetTag() []*TagCount {
+	if x != nil {
+		if x.xxx_hidden_Tag != nil {
+			return *x.xxx_hidden_Tag
+		}
+	}
+	return nil
+}
+
+func (x *UserTagsResponse) SetTag(v []*TagCount) {
+	x.xxx_hidden_Tag = &v
+}
+
+type UserTagsResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Tag []*TagCount
+}
+
+func (b0 UserTagsResponse_builder) Build() *UserTagsResponse {
+	m0 := &UserTagsResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Tag = &b.Tag
+	return m0
+}
+
+type TagCount struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Tag         *string                `protobuf:"bytes,1,opt,name=tag"`
+	xxx_hidden_Count       int32                  `protobuf:"varint,2,opt,name=count"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *TagCount) Reset() {
+	*x = TagCount{}
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TagCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TagCount) ProtoMessage() {}
+
+func (x *TagCount) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *TagCount) GetTag() string {
+	if x != nil {
+		if x.xxx_hidden_Tag != nil {
+			return *x.xxx_hidden_Tag
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *TagCount) GetCount() int32 {
+	if x != nil {
+		return x.xxx_hidden_Count
+	}
+	return 0
+}
+
+func (x *TagCount) SetTag(v string) {
+	x.xxx_hidden_Tag = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *TagCount) SetCount(v int32) {
+	x.xxx_hidden_Count = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *TagCount) HasTag() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *TagCount) HasCount() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *TagCount) ClearTag() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Tag = nil
+}
+
+func (x *TagCount) ClearCount() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Count = 0
+}
+
+type TagCount_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Tag   *string
+	Count *int32
+}
+
+func (b0 TagCount_builder) Build() *TagCount {
+	m0 := &TagCount{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Tag != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_Tag = b.Tag
+	}
+	if b.Count != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_Count = *b.Count
+	}
+	return m0
+}
+
+type URLRestoreRequest struct {
+	state         protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Id []string               `protobuf:"bytes,1,rep,name=id"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *URLRestoreRequest) Reset() {
+	*x = URLRestoreRequest{}
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *URLRestoreRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*URLRestoreRequest) ProtoMessage() {}
+
+func (x *URLRestoreRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *URLRestoreRequest) GetId() []string {
+	if x != nil {
+		return x.xxx_hidden_Id
+	}
+	return nil
+}
+
+func (x *URLRestoreRequest) SetId(v []string) {
+	x.xxx_hidden_Id = v
+}
+
+type URLRestoreRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Id []string
+}
+
+func (b0 URLRestoreRequest_builder) Build() *URLRestoreRequest {
+	m0 := &URLRestoreRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Id = b.Id
+	return m0
+}
+
+type URLRestoreResponse struct {
+	state             protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Result *[]*URLRestoreResult   `protobuf:"bytes,1,rep,name=result"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *URLRestoreResponse) Reset() {
+	*x = URLRestoreResponse{}
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *URLRestoreResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*URLRestoreResponse) ProtoMessage() {}
+
+func (x *URLRestoreResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *URLRestoreResponse) GetResult() []*URLRestoreResult {
+	if x != nil {
+		if x.xxx_hidden_Result != nil {
+			return *x.xxx_hidden_Result
+		}
+	}
+	return nil
+}
+
+func (x *URLRestoreResponse) SetResult(v []*URLRestoreResult) {
+	x.xxx_hidden_Result = &v
+}
+
+type URLRestoreResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Result []*URLRestoreResult
+}
+
+func (b0 URLRestoreResponse_builder) Build() *URLRestoreResponse {
+	m0 := &URLRestoreResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Result = &b.Result
+	return m0
+}
+
+type URLRestoreResult struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_ShortUrl    *string                `protobuf:"bytes,1,opt,name=short_url,json=shortUrl"`
+	xxx_hidden_OriginalUrl *string                `protobuf:"bytes,2,opt,name=original_url,json=originalUrl"`
+	xxx_hidden_Status      *string                `protobuf:"bytes,3,opt,name=status"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *URLRestoreResult) Reset() {
+	*x = URLRestoreResult{}
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *URLRestoreResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*URLRestoreResult) ProtoMessage() {}
+
+func (x *URLRestoreResult) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *URLRestoreResult) GetShortUrl() string {
+	if x != nil {
+		if x.xxx_hidden_ShortUrl != nil {
+			return *x.xxx_hidden_ShortUrl
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *URLRestoreResult) GetOriginalUrl() string {
+	if x != nil {
+		if x.xxx_hidden_OriginalUrl != nil {
+			return *x.xxx_hidden_OriginalUrl
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *URLRestoreResult) GetStatus() string {
+	if x != nil {
+		if x.xxx_hidden_Status != nil {
+			return *x.xxx_hidden_Status
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *URLRestoreResult) SetShortUrl(v string) {
+	x.xxx_hidden_ShortUrl = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 3)
+}
+
+func (x *URLRestoreResult) SetOriginalUrl(v string) {
+	x.xxx_hidden_OriginalUrl = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 3)
+}
+
+func (x *URLRestoreResult) SetStatus(v string) {
+	x.xxx_hidden_Status = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 3)
+}
+
+func (x *URLRestoreResult) HasShortUrl() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *URLRestoreResult) HasOriginalUrl() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *URLRestoreResult) HasStatus() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *URLRestoreResult) ClearShortUrl() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_ShortUrl = nil
+}
+
+func (x *URLRestoreResult) ClearOriginalUrl() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_OriginalUrl = nil
+}
+
+func (x *URLRestoreResult) ClearStatus() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Status = nil
+}
+
+type URLRestoreResult_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	ShortUrl    *string
+	OriginalUrl *string
+	Status      *string
+}
+
+func (b0 URLRestoreResult_builder) Build() *URLRestoreResult {
+	m0 := &URLRestoreResult{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.ShortUrl != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 3)
+		x.xxx_hidden_ShortUrl = b.ShortUrl
+	}
+	if b.OriginalUrl != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 3)
+		x.xxx_hidden_OriginalUrl = b.OriginalUrl
+	}
+	if b.Status != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 3)
+		x.xxx_hidden_Status = b.Status
+	}
+	return m0
+}
+
+type TargetingRulesRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Id          *string                `protobuf:"bytes,1,opt,name=id"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *TargetingRulesRequest) Reset() {
+	*x = TargetingRulesRequest{}
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TargetingRulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TargetingRulesRequest) ProtoMessage() {}
+
+func (x *TargetingRulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *TargetingRulesRequest) GetId() string {
+	if x != nil {
+		if x.xxx_hidden_Id != nil {
+			return *x.xxx_hidden_Id
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *TargetingRulesRequest) SetId(v string) {
+	x.xxx_hidden_Id = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+}
+
+func (x *TargetingRulesRequest) HasId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *TargetingRulesRequest) ClearId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Id = nil
+}
+
+type TargetingRulesRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Id *string
+}
+
+func (b0 TargetingRulesRequest_builder) Build() *TargetingRulesRequest {
+	m0 := &TargetingRulesRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Id != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		x.xxx_hidden_Id = b.Id
+	}
+	return m0
+}
+
+type SetTargetingRulesRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Id          *string                `protobuf:"bytes,1,opt,name=id"`
+	xxx_hidden_Rule        *[]*TargetingRule      `protobuf:"bytes,2,rep,name=rule"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *SetTargetingRulesRequest) Reset() {
+	*x = SetTargetingRulesRequest{}
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetTargetingRulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTargetingRulesRequest) ProtoMessage() {}
+
+func (x *SetTargetingRulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *SetTargetingRulesRequest) GetId() string {
+	if x != nil {
+		if x.xxx_hidden_Id != nil {
+			return *x.xxx_hidden_Id
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *SetTargetingRulesRequest) GetRule() []*TargetingRule {
+	if x != nil {
+		if x.xxx_hidden_Rule != nil {
+			return *x.xxx_hidden_Rule
+		}
+	}
+	return nil
+}
+
+func (x *SetTargetingRulesRequest) SetId(v string) {
+	x.xxx_hidden_Id = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *SetTargetingRulesRequest) SetRule(v []*TargetingRule) {
+	x.xxx_hidden_Rule = &v
+}
+
+func (x *SetTargetingRulesRequest) HasId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *SetTargetingRulesRequest) ClearId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Id = nil
+}
+
+type SetTargetingRulesRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Id   *string
+	Rule []*TargetingRule
+}
+
+func (b0 SetTargetingRulesRequest_builder) Build() *SetTargetingRulesRequest {
+	m0 := &SetTargetingRulesRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Id != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_Id = b.Id
+	}
+	x.xxx_hidden_Rule = &b.Rule
+	return m0
+}
+
+type TargetingRulesResponse struct {
+	state           protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Rule *[]*TargetingRule      `protobuf:"bytes,1,rep,name=rule"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TargetingRulesResponse) Reset() {
+	*x = TargetingRulesResponse{}
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TargetingRulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TargetingRulesResponse) ProtoMessage() {}
+
+func (x *TargetingRulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *TargetingRulesResponse) GetRule() []*TargetingRule {
+	if x != nil {
+		if x.xxx_hidden_Rule != nil {
+			return *x.xxx_hidden_Rule
+		}
+	}
+	return nil
+}
+
+func (x *TargetingRulesResponse) SetRule(v []*TargetingRule) {
+	x.xxx_hidden_Rule = &v
+}
+
+type TargetingRulesResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Rule []*TargetingRule
+}
+
+func (b0 TargetingRulesResponse_builder) Build() *TargetingRulesResponse {
+	m0 := &TargetingRulesResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Rule = &b.Rule
+	return m0
+}
+
+type TargetingRule struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Browser     *string                `protobuf:"bytes,1,opt,name=browser"`
+	xxx_hidden_Os          *string                `protobuf:"bytes,2,opt,name=os"`
+	xxx_hidden_Language    *string                `protobuf:"bytes,3,opt,name=language"`
+	xxx_hidden_Header      *string                `protobuf:"bytes,4,opt,name=header"`
+	xxx_hidden_HeaderValue *string                `protobuf:"bytes,5,opt,name=header_value,json=headerValue"`
+	xxx_hidden_Url         *string                `protobuf:"bytes,6,opt,name=url"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *TargetingRule) Reset() {
+	*x = TargetingRule{}
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TargetingRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TargetingRule) ProtoMessage() {}
+
+func (x *TargetingRule) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *TargetingRule) GetBrowser() string {
+	if x != nil {
+		if x.xxx_hidden_Browser != nil {
+			return *x.xxx_hidden_Browser
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *TargetingRule) GetOs() string {
+	if x != nil {
+		if x.xxx_hidden_Os != nil {
+			return *x.xxx_hidden_Os
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *TargetingRule) GetLanguage() string {
+	if x != nil {
+		if x.xxx_hidden_Language != nil {
+			return *x.xxx_hidden_Language
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *TargetingRule) GetHeader() string {
+	if x != nil {
+		if x.xxx_hidden_Header != nil {
+			return *x.xxx_hidden_Header
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *TargetingRule) GetHeaderValue() string {
+	if x != nil {
+		if x.xxx_hidden_HeaderValue != nil {
+			return *x.xxx_hidden_HeaderValue
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *TargetingRule) GetUrl() string {
+	if x != nil {
+		if x.xxx_hidden_Url != nil {
+			return *x.xxx_hidden_Url
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *TargetingRule) SetBrowser(v string) {
+	x.xxx_hidden_Browser = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 6)
+}
+
+func (x *TargetingRule) SetOs(v string) {
+	x.xxx_hidden_Os = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 6)
+}
+
+func (x *TargetingRule) SetLanguage(v string) {
+	x.xxx_hidden_Language = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 6)
+}
+
+func (x *TargetingRule) SetHeader(v string) {
+	x.xxx_hidden_Header = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 6)
+}
+
+func (x *TargetingRule) SetHeaderValue(v string) {
+	x.xxx_hidden_HeaderValue = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 6)
+}
+
+func (x *TargetingRule) SetUrl(v string) {
+	x.xxx_hidden_Url = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 6)
+}
+
+func (x *TargetingRule) HasBrowser() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *TargetingRule) HasOs() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *TargetingRule) HasLanguage() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *TargetingRule) HasHeader() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *TargetingRule) HasHeaderValue() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *TargetingRule) HasUrl() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
+func (x *TargetingRule) ClearBrowser() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Browser = nil
+}
+
+func (x *TargetingRule) ClearOs() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Os = nil
+}
+
+func (x *TargetingRule) ClearLanguage() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Language = nil
+}
+
+func (x *TargetingRule) ClearHeader() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_Header = nil
+}
+
+func (x *TargetingRule) ClearHeaderValue() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_HeaderValue = nil
+}
+
+func (x *TargetingRule) ClearUrl() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_Url = nil
+}
+
+type TargetingRule_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Browser     *string
+	Os          *string
+	Language    *string
+	Header      *string
+	HeaderValue *string
+	Url         *string
+}
+
+func (b0 TargetingRule_builder) Build() *TargetingRule {
+	m0 := &TargetingRule{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Browser != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 6)
+		x.xxx_hidden_Browser = b.Browser
+	}
+	if b.Os != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 6)
+		x.xxx_hidden_Os = b.Os
+	}
+	if b.Language != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 6)
+		x.xxx_hidden_Language = b.Language
+	}
+	if b.Header != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 6)
+		x.xxx_hidden_Header = b.Header
+	}
+	if b.HeaderValue != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 6)
+		x.xxx_hidden_HeaderValue = b.HeaderValue
+	}
+	if b.Url != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 6)
+		x.xxx_hidden_Url = b.Url
+	}
+	return m0
+}
+
+type SplitRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Id          *string                `protobuf:"bytes,1,opt,name=id"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *SplitRequest) Reset() {
+	*x = SplitRequest{}
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SplitRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SplitRequest) ProtoMessage() {}
+
+func (x *SplitRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *SplitRequest) GetId() string {
+	if x != nil {
+		if x.xxx_hidden_Id != nil {
+			return *x.xxx_hidden_Id
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *SplitRequest) SetId(v string) {
+	x.xxx_hidden_Id = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+}
+
+func (x *SplitRequest) HasId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *SplitRequest) ClearId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Id = nil
+}
+
+type SplitRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Id *string
+}
+
+func (b0 SplitRequest_builder) Build() *SplitRequest {
+	m0 := &SplitRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Id != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		x.xxx_hidden_Id = b.Id
+	}
+	return m0
+}
+
+type SetSplitRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Id          *string                `protobuf:"bytes,1,opt,name=id"`
+	xxx_hidden_Sticky      bool                   `protobuf:"varint,2,opt,name=sticky"`
+	xxx_hidden_Variant     *[]*SplitVariant       `protobuf:"bytes,3,rep,name=variant"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *SetSplitRequest) Reset() {
+	*x = SetSplitRequest{}
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetSplitRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetSplitRequest) ProtoMessage() {}
+
+func (x *SetSplitRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *SetSplitRequest) GetId() string {
+	if x != nil {
+		if x.xxx_hidden_Id != nil {
+			return *x.xxx_hidden_Id
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *SetSplitRequest) GetSticky() bool {
+	if x != nil {
+		return x.xxx_hidden_Sticky
+	}
+	return false
+}
+
+func (x *SetSplitRequest) GetVariant() []*SplitVariant {
+	if x != nil {
+		if x.xxx_hidden_Variant != nil {
+			return *x.xxx_hidden_Variant
+		}
+	}
+	return nil
+}
+
+func (x *SetSplitRequest) SetId(v string) {
+	x.xxx_hidden_Id = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 3)
+}
+
+func (x *SetSplitRequest) SetSticky(v bool) {
+	x.xxx_hidden_Sticky = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 3)
+}
+
+func (x *SetSplitRequest) SetVariant(v []*SplitVariant) {
+	x.xxx_hidden_Variant = &v
+}
+
+func (x *SetSplitRequest) HasId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *SetSplitRequest) HasSticky() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *SetSplitRequest) ClearId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Id = nil
+}
+
+func (x *SetSplitRequest) ClearSticky() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Sticky = false
+}
+
+type SetSplitRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Id      *string
+	Sticky  *bool
+	Variant []*SplitVariant
+}
+
+func (b0 SetSplitRequest_builder) Build() *SetSplitRequest {
+	m0 := &SetSplitRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Id != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 3)
+		x.xxx_hidden_Id = b.Id
+	}
+	if b.Sticky != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 3)
+		x.xxx_hidden_Sticky = *b.Sticky
+	}
+	x.xxx_hidden_Variant = &b.Variant
+	return m0
+}
+
+type SplitResponse struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Sticky      bool                   `protobuf:"varint,1,opt,name=sticky"`
+	xxx_hidden_Variant     *[]*SplitVariant       `protobuf:"bytes,2,rep,name=variant"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *SplitResponse) Reset() {
+	*x = SplitResponse{}
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SplitResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SplitResponse) ProtoMessage() {}
+
+func (x *SplitResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *SplitResponse) GetSticky() bool {
+	if x != nil {
+		return x.xxx_hidden_Sticky
+	}
+	return false
+}
+
+func (x *SplitResponse) GetVariant() []*SplitVariant {
+	if x != nil {
+		if x.xxx_hidden_Variant != nil {
+			return *x.xxx_hidden_Variant
+		}
+	}
+	return nil
+}
+
+func (x *SplitResponse) SetSticky(v bool) {
+	x.xxx_hidden_Sticky = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *SplitResponse) SetVariant(v []*SplitVariant) {
+	x.xxx_hidden_Variant = &v
+}
+
+func (x *SplitResponse) HasSticky() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *SplitResponse) ClearSticky() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Sticky = false
+}
+
+type SplitResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Sticky  *bool
+	Variant []*SplitVariant
+}
+
+func (b0 SplitResponse_builder) Build() *SplitResponse {
+	m0 := &SplitResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Sticky != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_Sticky = *b.Sticky
+	}
+	x.xxx_hidden_Variant = &b.Variant
+	return m0
+}
+
+type SplitVariant struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Name        *string                `protobuf:"bytes,1,opt,name=name"`
+	xxx_hidden_Url         *string                `protobuf:"bytes,2,opt,name=url"`
+	xxx_hidden_Weight      int32                  `protobuf:"varint,3,opt,name=weight"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *SplitVariant) Reset() {
+	*x = SplitVariant{}
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SplitVariant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SplitVariant) ProtoMessage() {}
+
+func (x *SplitVariant) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *SplitVariant) GetName() string {
+	if x != nil {
+		if x.xxx_hidden_Name != nil {
+			return *x.xxx_hidden_Name
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *SplitVariant) GetUrl() string {
+	if x != nil {
+		if x.xxx_hidden_Url != nil {
+			return *x.xxx_hidden_Url
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *SplitVariant) GetWeight() int32 {
+	if x != nil {
+		return x.xxx_hidden_Weight
+	}
+	return 0
+}
+
+func (x *SplitVariant) SetName(v string) {
+	x.xxx_hidden_Name = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 3)
+}
+
+func (x *SplitVariant) SetUrl(v string) {
+	x.xxx_hidden_Url = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 3)
+}
+
+func (x *SplitVariant) SetWeight(v int32) {
+	x.xxx_hidden_Weight = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 3)
+}
+
+func (x *SplitVariant) HasName() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *SplitVariant) HasUrl() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *SplitVariant) HasWeight() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *SplitVariant) ClearName() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Name = nil
+}
+
+func (x *SplitVariant) ClearUrl() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Url = nil
+}
+
+func (x *SplitVariant) ClearWeight() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Weight = 0
+}
+
+type SplitVariant_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Name   *string
+	Url    *string
+	Weight *int32
+}
+
+func (b0 SplitVariant_builder) Build() *SplitVariant {
+	m0 := &SplitVariant{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Name != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 3)
+		x.xxx_hidden_Name = b.Name
+	}
+	if b.Url != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 3)
+		x.xxx_hidden_Url = b.Url
+	}
+	if b.Weight != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 3)
+		x.xxx_hidden_Weight = *b.Weight
+	}
+	return m0
+}
+
+type CollectionsRequest struct {
+	state         protoimpl.MessageState `protogen:"opaque.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CollectionsRequest) Reset() {
+	*x = CollectionsRequest{}
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CollectionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CollectionsRequest) ProtoMessage() {}
+
+func (x *CollectionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+type CollectionsRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+}
+
+func (b0 CollectionsRequest_builder) Build() *CollectionsRequest {
+	m0 := &CollectionsRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	return m0
+}
+
+type CollectionsResponse struct {
+	state                 protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Collection *[]*CollectionData     `protobuf:"bytes,1,rep,name=collection"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *CollectionsResponse) Reset() {
+	*x = CollectionsResponse{}
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CollectionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CollectionsResponse) ProtoMessage() {}
+
+func (x *CollectionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *CollectionsResponse) GetCollection() []*CollectionData {
+	if x != nil {
+		if x.xxx_hidden_Collection != nil {
+			return *x.xxx_hidden_Collection
+		}
+	}
+	return nil
+}
+
+func (x *CollectionsResponse) SetCollection(v []*CollectionData) {
+	x.xxx_hidden_Collection = &v
+}
+
+type CollectionsResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Collection []*CollectionData
+}
+
+func (b0 CollectionsResponse_builder) Build() *CollectionsResponse {
+	m0 := &CollectionsResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Collection = &b.Collection
+	return m0
+}
+
+type CollectionData struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Id          *string                `protobuf:"bytes,1,opt,name=id"`
+	xxx_hidden_Name        *string                `protobuf:"bytes,2,opt,name=name"`
+	xxx_hidden_CreatedAt   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt"`
+	xxx_hidden_Count       int32                  `protobuf:"varint,4,opt,name=count"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *CollectionData) Reset() {
+	*x = CollectionData{}
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CollectionData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CollectionData) ProtoMessage() {}
+
+func (x *CollectionData) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *CollectionData) GetId() string {
+	if x != nil {
+		if x.xxx_hidden_Id != nil {
+			return *x.xxx_hidden_Id
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *CollectionData) GetName() string {
+	if x != nil {
+		if x.xxx_hidden_Name != nil {
+			return *x.xxx_hidden_Name
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *CollectionData) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_CreatedAt
+	}
+	return nil
+}
+
+func (x *CollectionData) GetCount() int32 {
+	if x != nil {
+		return x.xxx_hidden_Count
+	}
+	return 0
+}
+
+func (x *CollectionData) SetId(v string) {
+	x.xxx_hidden_Id = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 4)
+}
+
+func (x *CollectionData) SetName(v string) {
+	x.xxx_hidden_Name = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 4)
+}
+
+func (x *CollectionData) SetCreatedAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_CreatedAt = v
+}
+
+func (x *CollectionData) SetCount(v int32) {
+	x.xxx_hidden_Count = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 4)
+}
+
+func (x *CollectionData) HasId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *CollectionData) HasName() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *CollectionData) HasCreatedAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_CreatedAt != nil
+}
+
+func (x *CollectionData) HasCount() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *CollectionData) ClearId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Id = nil
+}
+
+func (x *CollectionData) ClearName() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Name = nil
+}
+
+func (x *CollectionData) ClearCreatedAt() {
+	x.xxx_hidden_CreatedAt = nil
+}
+
+func (x *CollectionData) ClearCount() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_Count = 0
+}
+
+type CollectionData_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Id        *string
+	Name      *string
+	CreatedAt *timestamppb.Timestamp
+	Count     *int32
+}
+
+func (b0 CollectionData_builder) Build() *CollectionData {
+	m0 := &CollectionData{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Id != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 4)
+		x.xxx_hidden_Id = b.Id
+	}
+	if b.Name != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 4)
+		x.xxx_hidden_Name = b.Name
+	}
+	x.xxx_hidden_CreatedAt = b.CreatedAt
+	if b.Count != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 4)
+		x.xxx_hidden_Count = *b.Count
+	}
+	return m0
+}
+
+type CollectionRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Id          *string                `protobuf:"bytes,1,opt,name=id"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *CollectionRequest) Reset() {
+	*x = CollectionRequest{}
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CollectionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CollectionRequest) ProtoMessage() {}
+
+func (x *CollectionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *CollectionRequest) GetId() string {
+	if x != nil {
+		if x.xxx_hidden_Id != nil {
+			return *x.xxx_hidden_Id
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *CollectionRequest) SetId(v string) {
+	x.xxx_hidden_Id = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+}
+
+func (x *CollectionRequest) HasId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *CollectionRequest) ClearId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Id = nil
+}
+
+type CollectionRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Id *string
+}
+
+func (b0 CollectionRequest_builder) Build() *CollectionRequest {
+	m0 := &CollectionRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Id != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		x.xxx_hidden_Id = b.Id
+	}
+	return m0
+}
+
+type CreateCollectionRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Name        *string                `protobuf:"bytes,1,opt,name=name"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *CreateCollectionRequest) Reset() {
+	*x = CreateCollectionRequest{}
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCollectionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCollectionRequest) ProtoMessage() {}
+
+func (x *CreateCollectionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *CreateCollectionRequest) GetName() string {
+	if x != nil {
+		if x.xxx_hidden_Name != nil {
+			return *x.xxx_hidden_Name
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *CreateCollectionRequest) SetName(v string) {
+	x.xxx_hidden_Name = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+}
+
+func (x *CreateCollectionRequest) HasName() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *CreateCollectionRequest) ClearName() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Name = nil
+}
+
+type CreateCollectionRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Name *string
+}
+
+func (b0 CreateCollectionRequest_builder) Build() *CreateCollectionRequest {
+	m0 := &CreateCollectionRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Name != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		x.xxx_hidden_Name = b.Name
+	}
+	return m0
+}
+
+type RenameCollectionRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Id          *string                `protobuf:"bytes,1,opt,name=id"`
+	xxx_hidden_Name        *string                `protobuf:"bytes,2,opt,name=name"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *RenameCollectionRequest) Reset() {
+	*x = RenameCollectionRequest{}
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenameCollectionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenameCollectionRequest) ProtoMessage() {}
+
+func (x *RenameCollectionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *RenameCollectionRequest) GetId() string {
+	if x != nil {
+		if x.xxx_hidden_Id != nil {
+			return *x.xxx_hidden_Id
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *RenameCollectionRequest) GetName() string {
+	if x != nil {
+		if x.xxx_hidden_Name != nil {
+			return *x.xxx_hidden_Name
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *RenameCollectionRequest) SetId(v string) {
+	x.xxx_hidden_Id = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *RenameCollectionRequest) SetName(v string) {
+	x.xxx_hidden_Name = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *RenameCollectionRequest) HasId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *RenameCollectionRequest) HasName() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *RenameCollectionRequest) ClearId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Id = nil
+}
+
+func (x *RenameCollectionRequest) ClearName() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Name = nil
+}
+
+type RenameCollectionRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Id   *string
+	Name *string
+}
+
+func (b0 RenameCollectionRequest_builder) Build() *RenameCollectionRequest {
+	m0 := &RenameCollectionRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Id != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_Id = b.Id
+	}
+	if b.Name != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_Name = b.Name
+	}
+	return m0
+}
+
+type DeleteCollectionResponse struct {
+	state         protoimpl.MessageState `protogen:"opaque.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCollectionResponse) Reset() {
+	*x = DeleteCollectionResponse{}
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCollectionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCollectionResponse) ProtoMessage() {}
+
+func (x *DeleteCollectionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+type DeleteCollectionResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+}
+
+func (b0 DeleteCollectionResponse_builder) Build() *DeleteCollectionResponse {
+	m0 := &DeleteCollectionResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	return m0
+}
+
+type MoveToCollectionRequest struct {
+	state                   protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_CollectionId *string                `protobuf:"bytes,1,opt,name=collection_id,json=collectionId"`
+	xxx_hidden_ShortUrl     []string               `protobuf:"bytes,2,rep,name=short_url,json=shortUrl"`
+	XXX_raceDetectHookData  protoimpl.RaceDetectHookData
+	XXX_presence            [1]uint32
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *MoveToCollectionRequest) Reset() {
+	*x = MoveToCollectionRequest{}
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveToCollectionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveToCollectionRequest) ProtoMessage() {}
+
+func (x *MoveToCollectionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *MoveToCollectionRequest) GetCollectionId() string {
+	if x != nil {
+		if x.xxx_hidden_CollectionId != nil {
+			return *x.xxx_hidden_CollectionId
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *MoveToCollectionRequest) GetShortUrl() []string {
+	if x != nil {
+		return x.xxx_hidden_ShortUrl
+	}
+	return nil
+}
+
+func (x *MoveToCollectionRequest) SetCollectionId(v string) {
+	x.xxx_hidden_CollectionId = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *MoveToCollectionRequest) SetShortUrl(v []string) {
+	x.xxx_hidden_ShortUrl = v
+}
+
+func (x *MoveToCollectionRequest) HasCollectionId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *MoveToCollectionRequest) ClearCollectionId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_CollectionId = nil
+}
+
+type MoveToCollectionRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	CollectionId *string
+	ShortUrl     []string
+}
+
+func (b0 MoveToCollectionRequest_builder) Build() *MoveToCollectionRequest {
+	m0 := &MoveToCollectionRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.CollectionId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_CollectionId = b.CollectionId
+	}
+	x.xxx_hidden_ShortUrl = b.ShortUrl
+	return m0
+}
+
+type MoveToCollectionResponse struct {
+	state         protoimpl.MessageState `protogen:"opaque.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveToCollectionResponse) Reset() {
+	*x = MoveToCollectionResponse{}
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveToCollectionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveToCollectionResponse) ProtoMessage() {}
+
+func (x *MoveToCollectionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+type MoveToCollectionResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
 }
 
-func (b0 URLShortenResponse_builder) Build() *URLShortenResponse {
-	m0 := &URLShortenResponse{}
+func (b0 MoveToCollectionResponse_builder) Build() *MoveToCollectionResponse {
+	m0 := &MoveToCollectionResponse{}
 	b, x := &b0, m0
 	_, _ = b, x
-	if b.Result != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
-		x.xxx_hidden_Result = b.Result
-	}
 	return m0
 }
 
-type URLExpandRequest struct {
+type DeleteCollectionURLsResponse struct {
 	state                  protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Id          *string                `protobuf:"bytes,1,opt,name=id"`
+	xxx_hidden_Deleted     int32                  `protobuf:"varint,1,opt,name=deleted"`
 	XXX_raceDetectHookData protoimpl.RaceDetectHookData
 	XXX_presence           [1]uint32
 	unknownFields          protoimpl.UnknownFields
 	sizeCache              protoimpl.SizeCache
 }
 
-func (x *URLExpandRequest) Reset() {
-	*x = URLExpandRequest{}
-	mi := &file_api_proto_shortener_shortener_proto_msgTypes[2]
+func (x *DeleteCollectionURLsResponse) Reset() {
+	*x = DeleteCollectionURLsResponse{}
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *URLExpandRequest) String() string {
+func (x *DeleteCollectionURLsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*URLExpandRequest) ProtoMessage() {}
+func (*DeleteCollectionURLsResponse) ProtoMessage() {}
 
-func (x *URLExpandRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_shortener_shortener_proto_msgTypes[2]
+func (x *DeleteCollectionURLsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -210,74 +4011,72 @@ func (x *URLExpandRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-func (x *URLExpandRequest) GetId() string {
+func (x *DeleteCollectionURLsResponse) GetDeleted() int32 {
 	if x != nil {
-		if x.xxx_hidden_Id != nil {
-			return *x.xxx_hidden_Id
-		}
-		return ""
+		return x.xxx_hidden_Deleted
 	}
-	return ""
+	return 0
 }
 
-func (x *URLExpandRequest) SetId(v string) {
-	x.xxx_hidden_Id = &v
+func (x *DeleteCollectionURLsResponse) SetDeleted(v int32) {
+	x.xxx_hidden_Deleted = v
 	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
 }
 
-func (x *URLExpandRequest) HasId() bool {
+func (x *DeleteCollectionURLsResponse) HasDeleted() bool {
 	if x == nil {
 		return false
 	}
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
 }
 
-func (x *URLExpandRequest) ClearId() {
+func (x *DeleteCollectionURLsResponse) ClearDeleted() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
-	x.xxx_hidden_Id = nil
+	x.xxx_hidden_Deleted = 0
 }
 
-type URLExpandRequest_builder struct {
+type DeleteCollectionURLsResponse_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	Id *string
+	Deleted *int32
 }
 
-func (b0 URLExpandRequest_builder) Build() *URLExpandRequest {
-	m0 := &URLExpandRequest{}
+func (b0 DeleteCollectionURLsResponse_builder) Build() *DeleteCollectionURLsResponse {
+	m0 := &DeleteCollectionURLsResponse{}
 	b, x := &b0, m0
 	_, _ = b, x
-	if b.Id != nil {
+	if b.Deleted != nil {
 		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
-		x.xxx_hidden_Id = b.Id
+		x.xxx_hidden_Deleted = *b.Deleted
 	}
 	return m0
 }
 
-type URLExpandResponse struct {
+type ExportCollectionRequest struct {
 	state                  protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Result      *string                `protobuf:"bytes,1,opt,name=result"`
+	xxx_hidden_Id          *string                `protobuf:"bytes,1,opt,name=id"`
+	xxx_hidden_Format      *string                `protobuf:"bytes,2,opt,name=format"`
 	XXX_raceDetectHookData protoimpl.RaceDetectHookData
 	XXX_presence           [1]uint32
 	unknownFields          protoimpl.UnknownFields
 	sizeCache              protoimpl.SizeCache
 }
 
-func (x *URLExpandResponse) Reset() {
-	*x = URLExpandResponse{}
-	mi := &file_api_proto_shortener_shortener_proto_msgTypes[3]
+func (x *ExportCollectionRequest) Reset() {
+	*x = ExportCollectionRequest{}
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *URLExpandResponse) String() string {
+func (x *ExportCollectionRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*URLExpandResponse) ProtoMessage() {}
+func (*ExportCollectionRequest) ProtoMessage() {}
 
-func (x *URLExpandResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_shortener_shortener_proto_msgTypes[3]
+func (x *ExportCollectionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -288,71 +4087,108 @@ func (x *URLExpandResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-func (x *URLExpandResponse) GetResult() string {
+func (x *ExportCollectionRequest) GetId() string {
 	if x != nil {
-		if x.xxx_hidden_Result != nil {
-			return *x.xxx_hidden_Result
+		if x.xxx_hidden_Id != nil {
+			return *x.xxx_hidden_Id
 		}
 		return ""
 	}
 	return ""
 }
 
-func (x *URLExpandResponse) SetResult(v string) {
-	x.xxx_hidden_Result = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+func (x *ExportCollectionRequest) GetFormat() string {
+	if x != nil {
+		if x.xxx_hidden_Format != nil {
+			return *x.xxx_hidden_Format
+		}
+		return ""
+	}
+	return ""
 }
 
-func (x *URLExpandResponse) HasResult() bool {
+func (x *ExportCollectionRequest) SetId(v string) {
+	x.xxx_hidden_Id = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *ExportCollectionRequest) SetFormat(v string) {
+	x.xxx_hidden_Format = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *ExportCollectionRequest) HasId() bool {
 	if x == nil {
 		return false
 	}
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
 }
 
-func (x *URLExpandResponse) ClearResult() {
+func (x *ExportCollectionRequest) HasFormat() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *ExportCollectionRequest) ClearId() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
-	x.xxx_hidden_Result = nil
+	x.xxx_hidden_Id = nil
 }
 
-type URLExpandResponse_builder struct {
+func (x *ExportCollectionRequest) ClearFormat() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Format = nil
+}
+
+type ExportCollectionRequest_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	Result *string
+	Id     *string
+	Format *string
 }
 
-func (b0 URLExpandResponse_builder) Build() *URLExpandResponse {
-	m0 := &URLExpandResponse{}
+func (b0 ExportCollectionRequest_builder) Build() *ExportCollectionRequest {
+	m0 := &ExportCollectionRequest{}
 	b, x := &b0, m0
 	_, _ = b, x
-	if b.Result != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
-		x.xxx_hidden_Result = b.Result
+	if b.Id != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_Id = b.Id
+	}
+	if b.Format != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_Format = b.Format
 	}
 	return m0
 }
 
-type UserURLsRequest struct {
-	state         protoimpl.MessageState `protogen:"opaque.v1"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type ExportCollectionResponse struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Data        []byte                 `protobuf:"bytes,1,opt,name=data"`
+	xxx_hidden_ContentType *string                `protobuf:"bytes,2,opt,name=content_type,json=contentType"`
+	xxx_hidden_FileName    *string                `protobuf:"bytes,3,opt,name=file_name,json=fileName"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
 }
 
-func (x *UserURLsRequest) Reset() {
-	*x = UserURLsRequest{}
-	mi := &file_api_proto_shortener_shortener_proto_msgTypes[4]
+func (x *ExportCollectionResponse) Reset() {
+	*x = ExportCollectionResponse{}
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UserURLsRequest) String() string {
+func (x *ExportCollectionResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UserURLsRequest) ProtoMessage() {}
+func (*ExportCollectionResponse) ProtoMessage() {}
 
-func (x *UserURLsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_shortener_shortener_proto_msgTypes[4]
+func (x *ExportCollectionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -363,90 +4199,131 @@ func (x *UserURLsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-type UserURLsRequest_builder struct {
-	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+func (x *ExportCollectionResponse) GetData() []byte {
+	if x != nil {
+		return x.xxx_hidden_Data
+	}
+	return nil
+}
 
+func (x *ExportCollectionResponse) GetContentType() string {
+	if x != nil {
+		if x.xxx_hidden_ContentType != nil {
+			return *x.xxx_hidden_ContentType
+		}
+		return ""
+	}
+	return ""
 }
 
-func (b0 UserURLsRequest_builder) Build() *UserURLsRequest {
-	m0 := &UserURLsRequest{}
-	b, x := &b0, m0
-	_, _ = b, x
-	return m0
+func (x *ExportCollectionResponse) GetFileName() string {
+	if x != nil {
+		if x.xxx_hidden_FileName != nil {
+			return *x.xxx_hidden_FileName
+		}
+		return ""
+	}
+	return ""
 }
 
-type UserURLsResponse struct {
-	state          protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Url *[]*URLData            `protobuf:"bytes,1,rep,name=url"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+func (x *ExportCollectionResponse) SetData(v []byte) {
+	if v == nil {
+		v = []byte{}
+	}
+	x.xxx_hidden_Data = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 3)
 }
 
-func (x *UserURLsResponse) Reset() {
-	*x = UserURLsResponse{}
-	mi := &file_api_proto_shortener_shortener_proto_msgTypes[5]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *ExportCollectionResponse) SetContentType(v string) {
+	x.xxx_hidden_ContentType = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 3)
 }
 
-func (x *UserURLsResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *ExportCollectionResponse) SetFileName(v string) {
+	x.xxx_hidden_FileName = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 3)
 }
 
-func (*UserURLsResponse) ProtoMessage() {}
+func (x *ExportCollectionResponse) HasData() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
 
-func (x *UserURLsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_shortener_shortener_proto_msgTypes[5]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *ExportCollectionResponse) HasContentType() bool {
+	if x == nil {
+		return false
 	}
-	return mi.MessageOf(x)
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
 }
 
-func (x *UserURLsResponse) GetUrl() []*URLData {
-	if x != nil {
-		if x.xxx_hidden_Url != nil {
-			return *x.xxx_hidden_Url
-		}
+func (x *ExportCollectionResponse) HasFileName() bool {
+	if x == nil {
+		return false
 	}
-	return nil
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
 }
 
-func (x *UserURLsResponse) SetUrl(v []*URLData) {
-	x.xxx_hidden_Url = &v
+func (x *ExportCollectionResponse) ClearData() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Data = nil
 }
 
-type UserURLsResponse_builder struct {
+func (x *ExportCollectionResponse) ClearContentType() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_ContentType = nil
+}
+
+func (x *ExportCollectionResponse) ClearFileName() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_FileName = nil
+}
+
+type ExportCollectionResponse_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	Url []*URLData
+	Data        []byte
+	ContentType *string
+	FileName    *string
 }
 
-func (b0 UserURLsResponse_builder) Build() *UserURLsResponse {
-	m0 := &UserURLsResponse{}
+func (b0 ExportCollectionResponse_builder) Build() *ExportCollectionResponse {
+	m0 := &ExportCollectionResponse{}
 	b, x := &b0, m0
 	_, _ = b, x
-	x.xxx_hidden_Url = &b.Url
+	if b.Data != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 3)
+		x.xxx_hidden_Data = b.Data
+	}
+	if b.ContentType != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 3)
+		x.xxx_hidden_ContentType = b.ContentType
+	}
+	if b.FileName != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 3)
+		x.xxx_hidden_FileName = b.FileName
+	}
 	return m0
 }
 
 type URLData struct {
-	state                  protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_ShortUrl    *string                `protobuf:"bytes,1,opt,name=short_url,json=shortUrl"`
-	xxx_hidden_OriginalUrl *string                `protobuf:"bytes,2,opt,name=original_url,json=originalUrl"`
-	XXX_raceDetectHookData protoimpl.RaceDetectHookData
-	XXX_presence           [1]uint32
-	unknownFields          protoimpl.UnknownFields
-	sizeCache              protoimpl.SizeCache
+	state                   protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_ShortUrl     *string                `protobuf:"bytes,1,opt,name=short_url,json=shortUrl"`
+	xxx_hidden_OriginalUrl  *string                `protobuf:"bytes,2,opt,name=original_url,json=originalUrl"`
+	xxx_hidden_ExpiresAt    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt"`
+	xxx_hidden_Tags         []string               `protobuf:"bytes,4,rep,name=tags"`
+	xxx_hidden_RedirectType int32                  `protobuf:"varint,5,opt,name=redirect_type,json=redirectType"`
+	xxx_hidden_Passthrough  bool                   `protobuf:"varint,6,opt,name=passthrough"`
+	XXX_raceDetectHookData  protoimpl.RaceDetectHookData
+	XXX_presence            [1]uint32
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
 }
 
 func (x *URLData) Reset() {
 	*x = URLData{}
-	mi := &file_api_proto_shortener_shortener_proto_msgTypes[6]
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -458,7 +4335,7 @@ func (x *URLData) String() string {
 func (*URLData) ProtoMessage() {}
 
 func (x *URLData) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_shortener_shortener_proto_msgTypes[6]
+	mi := &file_api_proto_shortener_shortener_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -489,14 +4366,60 @@ func (x *URLData) GetOriginalUrl() string {
 	return ""
 }
 
+func (x *URLData) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_ExpiresAt
+	}
+	return nil
+}
+
+func (x *URLData) GetTags() []string {
+	if x != nil {
+		return x.xxx_hidden_Tags
+	}
+	return nil
+}
+
+func (x *URLData) GetRedirectType() int32 {
+	if x != nil {
+		return x.xxx_hidden_RedirectType
+	}
+	return 0
+}
+
+func (x *URLData) GetPassthrough() bool {
+	if x != nil {
+		return x.xxx_hidden_Passthrough
+	}
+	return false
+}
+
 func (x *URLData) SetShortUrl(v string) {
 	x.xxx_hidden_ShortUrl = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 6)
 }
 
 func (x *URLData) SetOriginalUrl(v string) {
 	x.xxx_hidden_OriginalUrl = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 6)
+}
+
+func (x *URLData) SetExpiresAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_ExpiresAt = v
+}
+
+func (x *URLData) SetTags(v []string) {
+	x.xxx_hidden_Tags = v
+}
+
+func (x *URLData) SetRedirectType(v int32) {
+	x.xxx_hidden_RedirectType = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 6)
+}
+
+func (x *URLData) SetPassthrough(v bool) {
+	x.xxx_hidden_Passthrough = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 6)
 }
 
 func (x *URLData) HasShortUrl() bool {
@@ -513,6 +4436,27 @@ func (x *URLData) HasOriginalUrl() bool {
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
 }
 
+func (x *URLData) HasExpiresAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_ExpiresAt != nil
+}
+
+func (x *URLData) HasRedirectType() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *URLData) HasPassthrough() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
 func (x *URLData) ClearShortUrl() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
 	x.xxx_hidden_ShortUrl = nil
@@ -523,11 +4467,29 @@ func (x *URLData) ClearOriginalUrl() {
 	x.xxx_hidden_OriginalUrl = nil
 }
 
+func (x *URLData) ClearExpiresAt() {
+	x.xxx_hidden_ExpiresAt = nil
+}
+
+func (x *URLData) ClearRedirectType() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_RedirectType = 0
+}
+
+func (x *URLData) ClearPassthrough() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_Passthrough = false
+}
+
 type URLData_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	ShortUrl    *string
-	OriginalUrl *string
+	ShortUrl     *string
+	OriginalUrl  *string
+	ExpiresAt    *timestamppb.Timestamp
+	Tags         []string
+	RedirectType *int32
+	Passthrough  *bool
 }
 
 func (b0 URLData_builder) Build() *URLData {
@@ -535,13 +4497,23 @@ func (b0 URLData_builder) Build() *URLData {
 	b, x := &b0, m0
 	_, _ = b, x
 	if b.ShortUrl != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 6)
 		x.xxx_hidden_ShortUrl = b.ShortUrl
 	}
 	if b.OriginalUrl != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 6)
 		x.xxx_hidden_OriginalUrl = b.OriginalUrl
 	}
+	x.xxx_hidden_ExpiresAt = b.ExpiresAt
+	x.xxx_hidden_Tags = b.Tags
+	if b.RedirectType != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 6)
+		x.xxx_hidden_RedirectType = *b.RedirectType
+	}
+	if b.Passthrough != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 6)
+		x.xxx_hidden_Passthrough = *b.Passthrough
+	}
 	return m0
 }
 
@@ -549,50 +4521,293 @@ var File_api_proto_shortener_shortener_proto protoreflect.FileDescriptor
 
 const file_api_proto_shortener_shortener_proto_rawDesc = "" +
 	"\n" +
-	"#api/proto/shortener/shortener.proto\x12 alexstorchak.shortener.shortener\"%\n" +
+	"#api/proto/shortener/shortener.proto\x12 alexstorchak.shortener.shortener\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xd4\x02\n" +
 	"\x11URLShortenRequest\x12\x10\n" +
-	"\x03url\x18\x01 \x01(\tR\x03url\",\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x14\n" +
+	"\x05alias\x18\x02 \x01(\tR\x05alias\x129\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12+\n" +
+	"\x03ttl\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\x03ttl\x12\x1d\n" +
+	"\n" +
+	"max_clicks\x18\x05 \x01(\x05R\tmaxClicks\x12\x1a\n" +
+	"\bpassword\x18\x06 \x01(\tR\bpassword\x12\x19\n" +
+	"\bid_style\x18\a \x01(\tR\aidStyle\x12\x12\n" +
+	"\x04tags\x18\b \x03(\tR\x04tags\x12#\n" +
+	"\rredirect_type\x18\t \x01(\x05R\fredirectType\x12 \n" +
+	"\vpassthrough\x18\n" +
+	" \x01(\bR\vpassthrough\",\n" +
 	"\x12URLShortenResponse\x12\x16\n" +
-	"\x06result\x18\x01 \x01(\tR\x06result\"\"\n" +
+	"\x06result\x18\x01 \x01(\tR\x06result\"\x99\x02\n" +
 	"\x10URLExpandRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"+\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x12\n" +
+	"\x04path\x18\x03 \x01(\tR\x04path\x12\x14\n" +
+	"\x05query\x18\x04 \x01(\tR\x05query\x12Y\n" +
+	"\aheaders\x18\x05 \x03(\v2?.alexstorchak.shortener.shortener.URLExpandRequest.HeadersEntryR\aheaders\x12\x18\n" +
+	"\avariant\x18\x06 \x01(\tR\avariant\x1a:\n" +
+	"\fHeadersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x82\x01\n" +
 	"\x11URLExpandResponse\x12\x16\n" +
-	"\x06result\x18\x01 \x01(\tR\x06result\"\x11\n" +
-	"\x0fUserURLsRequest\"O\n" +
+	"\x06result\x18\x01 \x01(\tR\x06result\x12#\n" +
+	"\rredirect_type\x18\x02 \x01(\x05R\fredirectType\x12\x18\n" +
+	"\avariant\x18\x03 \x01(\tR\avariant\x12\x16\n" +
+	"\x06sticky\x18\x04 \x01(\bR\x06sticky\"#\n" +
+	"\x11URLInspectRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x80\x02\n" +
+	"\x12URLInspectResponse\x12\x1b\n" +
+	"\tshort_url\x18\x01 \x01(\tR\bshortUrl\x12!\n" +
+	"\foriginal_url\x18\x02 \x01(\tR\voriginalUrl\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x1c\n" +
+	"\tprotected\x18\x05 \x01(\bR\tprotected\x12\x16\n" +
+	"\x06safety\x18\x06 \x01(\tR\x06safety\"\xb9\x01\n" +
+	"\rQRCodeRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06format\x18\x02 \x01(\tR\x06format\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\x05R\x04size\x12\x14\n" +
+	"\x05level\x18\x04 \x01(\tR\x05level\x12\x16\n" +
+	"\x06margin\x18\x05 \x01(\x05R\x06margin\x12\x1e\n" +
+	"\n" +
+	"foreground\x18\x06 \x01(\tR\n" +
+	"foreground\x12\x1e\n" +
+	"\n" +
+	"background\x18\a \x01(\tR\n" +
+	"background\"G\n" +
+	"\x0eQRCodeResponse\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\"#\n" +
+	"\x0fUserURLsRequest\x12\x10\n" +
+	"\x03tag\x18\x01 \x03(\tR\x03tag\"O\n" +
 	"\x10UserURLsResponse\x12;\n" +
-	"\x03url\x18\x01 \x03(\v2).alexstorchak.shortener.shortener.URLDataR\x03url\"I\n" +
+	"\x03url\x18\x01 \x03(\v2).alexstorchak.shortener.shortener.URLDataR\x03url\"s\n" +
+	"\x10URLUpdateRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12=\n" +
+	"\x04tags\x18\x03 \x01(\v2).alexstorchak.shortener.shortener.TagListR\x04tags\"\x1b\n" +
+	"\aTagList\x12\x10\n" +
+	"\x03tag\x18\x01 \x03(\tR\x03tag\"V\n" +
+	"\x11URLUpdateResponse\x12A\n" +
+	"\x06result\x18\x01 \x01(\v2).alexstorchak.shortener.shortener.URLDataR\x06result\"\x11\n" +
+	"\x0fUserTagsRequest\"P\n" +
+	"\x10UserTagsResponse\x12<\n" +
+	"\x03tag\x18\x01 \x03(\v2*.alexstorchak.shortener.shortener.TagCountR\x03tag\"2\n" +
+	"\bTagCount\x12\x10\n" +
+	"\x03tag\x18\x01 \x01(\tR\x03tag\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\"#\n" +
+	"\x11URLRestoreRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x03(\tR\x02id\"`\n" +
+	"\x12URLRestoreResponse\x12J\n" +
+	"\x06result\x18\x01 \x03(\v22.alexstorchak.shortener.shortener.URLRestoreResultR\x06result\"j\n" +
+	"\x10URLRestoreResult\x12\x1b\n" +
+	"\tshort_url\x18\x01 \x01(\tR\bshortUrl\x12!\n" +
+	"\foriginal_url\x18\x02 \x01(\tR\voriginalUrl\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\"'\n" +
+	"\x15TargetingRulesRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"o\n" +
+	"\x18SetTargetingRulesRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12C\n" +
+	"\x04rule\x18\x02 \x03(\v2/.alexstorchak.shortener.shortener.TargetingRuleR\x04rule\"]\n" +
+	"\x16TargetingRulesResponse\x12C\n" +
+	"\x04rule\x18\x01 \x03(\v2/.alexstorchak.shortener.shortener.TargetingRuleR\x04rule\"\xa2\x01\n" +
+	"\rTargetingRule\x12\x18\n" +
+	"\abrowser\x18\x01 \x01(\tR\abrowser\x12\x0e\n" +
+	"\x02os\x18\x02 \x01(\tR\x02os\x12\x1a\n" +
+	"\blanguage\x18\x03 \x01(\tR\blanguage\x12\x16\n" +
+	"\x06header\x18\x04 \x01(\tR\x06header\x12!\n" +
+	"\fheader_value\x18\x05 \x01(\tR\vheaderValue\x12\x10\n" +
+	"\x03url\x18\x06 \x01(\tR\x03url\"\x1e\n" +
+	"\fSplitRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x83\x01\n" +
+	"\x0fSetSplitRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06sticky\x18\x02 \x01(\bR\x06sticky\x12H\n" +
+	"\avariant\x18\x03 \x03(\v2..alexstorchak.shortener.shortener.SplitVariantR\avariant\"q\n" +
+	"\rSplitResponse\x12\x16\n" +
+	"\x06sticky\x18\x01 \x01(\bR\x06sticky\x12H\n" +
+	"\avariant\x18\x02 \x03(\v2..alexstorchak.shortener.shortener.SplitVariantR\avariant\"L\n" +
+	"\fSplitVariant\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x16\n" +
+	"\x06weight\x18\x03 \x01(\x05R\x06weight\"\x14\n" +
+	"\x12CollectionsRequest\"g\n" +
+	"\x13CollectionsResponse\x12P\n" +
+	"\n" +
+	"collection\x18\x01 \x03(\v20.alexstorchak.shortener.shortener.CollectionDataR\n" +
+	"collection\"\x85\x01\n" +
+	"\x0eCollectionData\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x14\n" +
+	"\x05count\x18\x04 \x01(\x05R\x05count\"#\n" +
+	"\x11CollectionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"-\n" +
+	"\x17CreateCollectionRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"=\n" +
+	"\x17RenameCollectionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"\x1a\n" +
+	"\x18DeleteCollectionResponse\"[\n" +
+	"\x17MoveToCollectionRequest\x12#\n" +
+	"\rcollection_id\x18\x01 \x01(\tR\fcollectionId\x12\x1b\n" +
+	"\tshort_url\x18\x02 \x03(\tR\bshortUrl\"\x1a\n" +
+	"\x18MoveToCollectionResponse\"8\n" +
+	"\x1cDeleteCollectionURLsResponse\x12\x18\n" +
+	"\adeleted\x18\x01 \x01(\x05R\adeleted\"A\n" +
+	"\x17ExportCollectionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06format\x18\x02 \x01(\tR\x06format\"n\n" +
+	"\x18ExportCollectionResponse\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12\x1b\n" +
+	"\tfile_name\x18\x03 \x01(\tR\bfileName\"\xdf\x01\n" +
 	"\aURLData\x12\x1b\n" +
 	"\tshort_url\x18\x01 \x01(\tR\bshortUrl\x12!\n" +
-	"\foriginal_url\x18\x02 \x01(\tR\voriginalUrl2\xf8\x02\n" +
+	"\foriginal_url\x18\x02 \x01(\tR\voriginalUrl\x129\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x12\n" +
+	"\x04tags\x18\x04 \x03(\tR\x04tags\x12#\n" +
+	"\rredirect_type\x18\x05 \x01(\x05R\fredirectType\x12 \n" +
+	"\vpassthrough\x18\x06 \x01(\bR\vpassthrough2\xdf\x14\n" +
 	"\x10ShortenerService\x12w\n" +
 	"\n" +
 	"ShortenURL\x123.alexstorchak.shortener.shortener.URLShortenRequest\x1a4.alexstorchak.shortener.shortener.URLShortenResponse\x12t\n" +
-	"\tExpandURL\x122.alexstorchak.shortener.shortener.URLExpandRequest\x1a3.alexstorchak.shortener.shortener.URLExpandResponse\x12u\n" +
-	"\fListUserURLs\x121.alexstorchak.shortener.shortener.UserURLsRequest\x1a2.alexstorchak.shortener.shortener.UserURLsResponseB8Z6github.com/alex-storchak/shortener/api/proto/shortenerb\beditionsp\xe8\a"
+	"\tExpandURL\x122.alexstorchak.shortener.shortener.URLExpandRequest\x1a3.alexstorchak.shortener.shortener.URLExpandResponse\x12w\n" +
+	"\n" +
+	"InspectURL\x123.alexstorchak.shortener.shortener.URLInspectRequest\x1a4.alexstorchak.shortener.shortener.URLInspectResponse\x12n\n" +
+	"\tGetQRCode\x12/.alexstorchak.shortener.shortener.QRCodeRequest\x1a0.alexstorchak.shortener.shortener.QRCodeResponse\x12u\n" +
+	"\fListUserURLs\x121.alexstorchak.shortener.shortener.UserURLsRequest\x1a2.alexstorchak.shortener.shortener.UserURLsResponse\x12t\n" +
+	"\tUpdateURL\x122.alexstorchak.shortener.shortener.URLUpdateRequest\x1a3.alexstorchak.shortener.shortener.URLUpdateResponse\x12v\n" +
+	"\rListTrashURLs\x121.alexstorchak.shortener.shortener.UserURLsRequest\x1a2.alexstorchak.shortener.shortener.UserURLsResponse\x12x\n" +
+	"\vRestoreURLs\x123.alexstorchak.shortener.shortener.URLRestoreRequest\x1a4.alexstorchak.shortener.shortener.URLRestoreResponse\x12u\n" +
+	"\fListUserTags\x121.alexstorchak.shortener.shortener.UserTagsRequest\x1a2.alexstorchak.shortener.shortener.UserTagsResponse\x12\x86\x01\n" +
+	"\x11GetTargetingRules\x127.alexstorchak.shortener.shortener.TargetingRulesRequest\x1a8.alexstorchak.shortener.shortener.TargetingRulesResponse\x12\x89\x01\n" +
+	"\x11SetTargetingRules\x12:.alexstorchak.shortener.shortener.SetTargetingRulesRequest\x1a8.alexstorchak.shortener.shortener.TargetingRulesResponse\x12k\n" +
+	"\bGetSplit\x12..alexstorchak.shortener.shortener.SplitRequest\x1a/.alexstorchak.shortener.shortener.SplitResponse\x12n\n" +
+	"\bSetSplit\x121.alexstorchak.shortener.shortener.SetSplitRequest\x1a/.alexstorchak.shortener.shortener.SplitResponse\x12~\n" +
+	"\x0fListCollections\x124.alexstorchak.shortener.shortener.CollectionsRequest\x1a5.alexstorchak.shortener.shortener.CollectionsResponse\x12\x7f\n" +
+	"\x10CreateCollection\x129.alexstorchak.shortener.shortener.CreateCollectionRequest\x1a0.alexstorchak.shortener.shortener.CollectionData\x12\x7f\n" +
+	"\x10RenameCollection\x129.alexstorchak.shortener.shortener.RenameCollectionRequest\x1a0.alexstorchak.shortener.shortener.CollectionData\x12\x83\x01\n" +
+	"\x10DeleteCollection\x123.alexstorchak.shortener.shortener.CollectionRequest\x1a:.alexstorchak.shortener.shortener.DeleteCollectionResponse\x12\x89\x01\n" +
+	"\x10MoveToCollection\x129.alexstorchak.shortener.shortener.MoveToCollectionRequest\x1a:.alexstorchak.shortener.shortener.MoveToCollectionResponse\x12}\n" +
+	"\x12ListCollectionURLs\x123.alexstorchak.shortener.shortener.CollectionRequest\x1a2.alexstorchak.shortener.shortener.UserURLsResponse\x12\x8b\x01\n" +
+	"\x14DeleteCollectionURLs\x123.alexstorchak.shortener.shortener.CollectionRequest\x1a>.alexstorchak.shortener.shortener.DeleteCollectionURLsResponse\x12\x89\x01\n" +
+	"\x10ExportCollection\x129.alexstorchak.shortener.shortener.ExportCollectionRequest\x1a:.alexstorchak.shortener.shortener.ExportCollectionResponseB8Z6github.com/alex-storchak/shortener/api/proto/shortenerb\beditionsp\xe8\a"
 
-var file_api_proto_shortener_shortener_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_api_proto_shortener_shortener_proto_msgTypes = make([]protoimpl.MessageInfo, 41)
 var file_api_proto_shortener_shortener_proto_goTypes = []any{
-	(*URLShortenRequest)(nil),  // 0: alexstorchak.shortener.shortener.URLShortenRequest
-	(*URLShortenResponse)(nil), // 1: alexstorchak.shortener.shortener.URLShortenResponse
-	(*URLExpandRequest)(nil),   // 2: alexstorchak.shortener.shortener.URLExpandRequest
-	(*URLExpandResponse)(nil),  // 3: alexstorchak.shortener.shortener.URLExpandResponse
-	(*UserURLsRequest)(nil),    // 4: alexstorchak.shortener.shortener.UserURLsRequest
-	(*UserURLsResponse)(nil),   // 5: alexstorchak.shortener.shortener.UserURLsResponse
-	(*URLData)(nil),            // 6: alexstorchak.shortener.shortener.URLData
+	(*URLShortenRequest)(nil),            // 0: alexstorchak.shortener.shortener.URLShortenRequest
+	(*URLShortenResponse)(nil),           // 1: alexstorchak.shortener.shortener.URLShortenResponse
+	(*URLExpandRequest)(nil),             // 2: alexstorchak.shortener.shortener.URLExpandRequest
+	(*URLExpandResponse)(nil),            // 3: alexstorchak.shortener.shortener.URLExpandResponse
+	(*URLInspectRequest)(nil),            // 4: alexstorchak.shortener.shortener.URLInspectRequest
+	(*URLInspectResponse)(nil),           // 5: alexstorchak.shortener.shortener.URLInspectResponse
+	(*QRCodeRequest)(nil),                // 6: alexstorchak.shortener.shortener.QRCodeRequest
+	(*QRCodeResponse)(nil),               // 7: alexstorchak.shortener.shortener.QRCodeResponse
+	(*UserURLsRequest)(nil),              // 8: alexstorchak.shortener.shortener.UserURLsRequest
+	(*UserURLsResponse)(nil),             // 9: alexstorchak.shortener.shortener.UserURLsResponse
+	(*URLUpdateRequest)(nil),             // 10: alexstorchak.shortener.shortener.URLUpdateRequest
+	(*TagList)(nil),                      // 11: alexstorchak.shortener.shortener.TagList
+	(*URLUpdateResponse)(nil),            // 12: alexstorchak.shortener.shortener.URLUpdateResponse
+	(*UserTagsRequest)(nil),              // 13: alexstorchak.shortener.shortener.UserTagsRequest
+	(*UserTagsResponse)(nil),             // 14: alexstorchak.shortener.shortener.UserTagsResponse
+	(*TagCount)(nil),                     // 15: alexstorchak.shortener.shortener.TagCount
+	(*URLRestoreRequest)(nil),            // 16: alexstorchak.shortener.shortener.URLRestoreRequest
+	(*URLRestoreResponse)(nil),           // 17: alexstorchak.shortener.shortener.URLRestoreResponse
+	(*URLRestoreResult)(nil),             // 18: alexstorchak.shortener.shortener.URLRestoreResult
+	(*TargetingRulesRequest)(nil),        // 19: alexstorchak.shortener.shortener.TargetingRulesRequest
+	(*SetTargetingRulesRequest)(nil),     // 20: alexstorchak.shortener.shortener.SetTargetingRulesRequest
+	(*TargetingRulesResponse)(nil),       // 21: alexstorchak.shortener.shortener.TargetingRulesResponse
+	(*TargetingRule)(nil),                // 22: alexstorchak.shortener.shortener.TargetingRule
+	(*SplitRequest)(nil),                 // 23: alexstorchak.shortener.shortener.SplitRequest
+	(*SetSplitRequest)(nil),              // 24: alexstorchak.shortener.shortener.SetSplitRequest
+	(*SplitResponse)(nil),                // 25: alexstorchak.shortener.shortener.SplitResponse
+	(*SplitVariant)(nil),                 // 26: alexstorchak.shortener.shortener.SplitVariant
+	(*CollectionsRequest)(nil),           // 27: alexstorchak.shortener.shortener.CollectionsRequest
+	(*CollectionsResponse)(nil),          // 28: alexstorchak.shortener.shortener.CollectionsResponse
+	(*CollectionData)(nil),               // 29: alexstorchak.shortener.shortener.CollectionData
+	(*CollectionRequest)(nil),            // 30: alexstorchak.shortener.shortener.CollectionRequest
+	(*CreateCollectionRequest)(nil),      // 31: alexstorchak.shortener.shortener.CreateCollectionRequest
+	(*RenameCollectionRequest)(nil),      // 32: alexstorchak.shortener.shortener.RenameCollectionRequest
+	(*DeleteCollectionResponse)(nil),     // 33: alexstorchak.shortener.shortener.DeleteCollectionResponse
+	(*MoveToCollectionRequest)(nil),      // 34: alexstorchak.shortener.shortener.MoveToCollectionRequest
+	(*MoveToCollectionResponse)(nil),     // 35: alexstorchak.shortener.shortener.MoveToCollectionResponse
+	(*DeleteCollectionURLsResponse)(nil), // 36: alexstorchak.shortener.shortener.DeleteCollectionURLsResponse
+	(*ExportCollectionRequest)(nil),      // 37: alexstorchak.shortener.shortener.ExportCollectionRequest
+	(*ExportCollectionResponse)(nil),     // 38: alexstorchak.shortener.shortener.ExportCollectionResponse
+	(*URLData)(nil),                      // 39: alexstorchak.shortener.shortener.URLData
+	nil,                                  // 40: alexstorchak.shortener.shortener.URLExpandRequest.HeadersEntry
+	(*timestamppb.Timestamp)(nil),        // 41: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),          // 42: google.protobuf.Duration
 }
 var file_api_proto_shortener_shortener_proto_depIdxs = []int32{
-	6, // 0: alexstorchak.shortener.shortener.UserURLsResponse.url:type_name -> alexstorchak.shortener.shortener.URLData
-	0, // 1: alexstorchak.shortener.shortener.ShortenerService.ShortenURL:input_type -> alexstorchak.shortener.shortener.URLShortenRequest
-	2, // 2: alexstorchak.shortener.shortener.ShortenerService.ExpandURL:input_type -> alexstorchak.shortener.shortener.URLExpandRequest
-	4, // 3: alexstorchak.shortener.shortener.ShortenerService.ListUserURLs:input_type -> alexstorchak.shortener.shortener.UserURLsRequest
-	1, // 4: alexstorchak.shortener.shortener.ShortenerService.ShortenURL:output_type -> alexstorchak.shortener.shortener.URLShortenResponse
-	3, // 5: alexstorchak.shortener.shortener.ShortenerService.ExpandURL:output_type -> alexstorchak.shortener.shortener.URLExpandResponse
-	5, // 6: alexstorchak.shortener.shortener.ShortenerService.ListUserURLs:output_type -> alexstorchak.shortener.shortener.UserURLsResponse
-	4, // [4:7] is the sub-list for method output_type
-	1, // [1:4] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	41, // 0: alexstorchak.shortener.shortener.URLShortenRequest.expires_at:type_name -> google.protobuf.Timestamp
+	42, // 1: alexstorchak.shortener.shortener.URLShortenRequest.ttl:type_name -> google.protobuf.Duration
+	40, // 2: alexstorchak.shortener.shortener.URLExpandRequest.headers:type_name -> alexstorchak.shortener.shortener.URLExpandRequest.HeadersEntry
+	41, // 3: alexstorchak.shortener.shortener.URLInspectResponse.created_at:type_name -> google.protobuf.Timestamp
+	41, // 4: alexstorchak.shortener.shortener.URLInspectResponse.expires_at:type_name -> google.protobuf.Timestamp
+	39, // 5: alexstorchak.shortener.shortener.UserURLsResponse.url:type_name -> alexstorchak.shortener.shortener.URLData
+	11, // 6: alexstorchak.shortener.shortener.URLUpdateRequest.tags:type_name -> alexstorchak.shortener.shortener.TagList
+	39, // 7: alexstorchak.shortener.shortener.URLUpdateResponse.result:type_name -> alexstorchak.shortener.shortener.URLData
+	15, // 8: alexstorchak.shortener.shortener.UserTagsResponse.tag:type_name -> alexstorchak.shortener.shortener.TagCount
+	18, // 9: alexstorchak.shortener.shortener.URLRestoreResponse.result:type_name -> alexstorchak.shortener.shortener.URLRestoreResult
+	22, // 10: alexstorchak.shortener.shortener.SetTargetingRulesRequest.rule:type_name -> alexstorchak.shortener.shortener.TargetingRule
+	22, // 11: alexstorchak.shortener.shortener.TargetingRulesResponse.rule:type_name -> alexstorchak.shortener.shortener.TargetingRule
+	26, // 12: alexstorchak.shortener.shortener.SetSplitRequest.variant:type_name -> alexstorchak.shortener.shortener.SplitVariant
+	26, // 13: alexstorchak.shortener.shortener.SplitResponse.variant:type_name -> alexstorchak.shortener.shortener.SplitVariant
+	29, // 14: alexstorchak.shortener.shortener.CollectionsResponse.collection:type_name -> alexstorchak.shortener.shortener.CollectionData
+	41, // 15: alexstorchak.shortener.shortener.CollectionData.created_at:type_name -> google.protobuf.Timestamp
+	41, // 16: alexstorchak.shortener.shortener.URLData.expires_at:type_name -> google.protobuf.Timestamp
+	0,  // 17: alexstorchak.shortener.shortener.ShortenerService.ShortenURL:input_type -> alexstorchak.shortener.shortener.URLShortenRequest
+	2,  // 18: alexstorchak.shortener.shortener.ShortenerService.ExpandURL:input_type -> alexstorchak.shortener.shortener.URLExpandRequest
+	4,  // 19: alexstorchak.shortener.shortener.ShortenerService.InspectURL:input_type -> alexstorchak.shortener.shortener.URLInspectRequest
+	6,  // 20: alexstorchak.shortener.shortener.ShortenerService.GetQRCode:input_type -> alexstorchak.shortener.shortener.QRCodeRequest
+	8,  // 21: alexstorchak.shortener.shortener.ShortenerService.ListUserURLs:input_type -> alexstorchak.shortener.shortener.UserURLsRequest
+	10, // 22: alexstorchak.shortener.shortener.ShortenerService.UpdateURL:input_type -> alexstorchak.shortener.shortener.URLUpdateRequest
+	8,  // 23: alexstorchak.shortener.shortener.ShortenerService.ListTrashURLs:input_type -> alexstorchak.shortener.shortener.UserURLsRequest
+	16, // 24: alexstorchak.shortener.shortener.ShortenerService.RestoreURLs:input_type -> alexstorchak.shortener.shortener.URLRestoreRequest
+	13, // 25: alexstorchak.shortener.shortener.ShortenerService.ListUserTags:input_type -> alexstorchak.shortener.shortener.UserTagsRequest
+	19, // 26: alexstorchak.shortener.shortener.ShortenerService.GetTargetingRules:input_type -> alexstorchak.shortener.shortener.TargetingRulesRequest
+	20, // 27: alexstorchak.shortener.shortener.ShortenerService.SetTargetingRules:input_type -> alexstorchak.shortener.shortener.SetTargetingRulesRequest
+	23, // 28: alexstorchak.shortener.shortener.ShortenerService.GetSplit:input_type -> alexstorchak.shortener.shortener.SplitRequest
+	24, // 29: alexstorchak.shortener.shortener.ShortenerService.SetSplit:input_type -> alexstorchak.shortener.shortener.SetSplitRequest
+	27, // 30: alexstorchak.shortener.shortener.ShortenerService.ListCollections:input_type -> alexstorchak.shortener.shortener.CollectionsRequest
+	31, // 31: alexstorchak.shortener.shortener.ShortenerService.CreateCollection:input_type -> alexstorchak.shortener.shortener.CreateCollectionRequest
+	32, // 32: alexstorchak.shortener.shortener.ShortenerService.RenameCollection:input_type -> alexstorchak.shortener.shortener.RenameCollectionRequest
+	30, // 33: alexstorchak.shortener.shortener.ShortenerService.DeleteCollection:input_type -> alexstorchak.shortener.shortener.CollectionRequest
+	34, // 34: alexstorchak.shortener.shortener.ShortenerService.MoveToCollection:input_type -> alexstorchak.shortener.shortener.MoveToCollectionRequest
+	30, // 35: alexstorchak.shortener.shortener.ShortenerService.ListCollectionURLs:input_type -> alexstorchak.shortener.shortener.CollectionRequest
+	30, // 36: alexstorchak.shortener.shortener.ShortenerService.DeleteCollectionURLs:input_type -> alexstorchak.shortener.shortener.CollectionRequest
+	37, // 37: alexstorchak.shortener.shortener.ShortenerService.ExportCollection:input_type -> alexstorchak.shortener.shortener.ExportCollectionRequest
+	1,  // 38: alexstorchak.shortener.shortener.ShortenerService.ShortenURL:output_type -> alexstorchak.shortener.shortener.URLShortenResponse
+	3,  // 39: alexstorchak.shortener.shortener.ShortenerService.ExpandURL:output_type -> alexstorchak.shortener.shortener.URLExpandResponse
+	5,  // 40: alexstorchak.shortener.shortener.ShortenerService.InspectURL:output_type -> alexstorchak.shortener.shortener.URLInspectResponse
+	7,  // 41: alexstorchak.shortener.shortener.ShortenerService.GetQRCode:output_type -> alexstorchak.shortener.shortener.QRCodeResponse
+	9,  // 42: alexstorchak.shortener.shortener.ShortenerService.ListUserURLs:output_type -> alexstorchak.shortener.shortener.UserURLsResponse
+	12, // 43: alexstorchak.shortener.shortener.ShortenerService.UpdateURL:output_type -> alexstorchak.shortener.shortener.URLUpdateResponse
+	9,  // 44: alexstorchak.shortener.shortener.ShortenerService.ListTrashURLs:output_type -> alexstorchak.shortener.shortener.UserURLsResponse
+	17, // 45: alexstorchak.shortener.shortener.ShortenerService.RestoreURLs:output_type -> alexstorchak.shortener.shortener.URLRestoreResponse
+	14, // 46: alexstorchak.shortener.shortener.ShortenerService.ListUserTags:output_type -> alexstorchak.shortener.shortener.UserTagsResponse
+	21, // 47: alexstorchak.shortener.shortener.ShortenerService.GetTargetingRules:output_type -> alexstorchak.shortener.shortener.TargetingRulesResponse
+	21, // 48: alexstorchak.shortener.shortener.ShortenerService.SetTargetingRules:output_type -> alexstorchak.shortener.shortener.TargetingRulesResponse
+	25, // 49: alexstorchak.shortener.shortener.ShortenerService.GetSplit:output_type -> alexstorchak.shortener.shortener.SplitResponse
+	25, // 50: alexstorchak.shortener.shortener.ShortenerService.SetSplit:output_type -> alexstorchak.shortener.shortener.SplitResponse
+	28, // 51: alexstorchak.shortener.shortener.ShortenerService.ListCollections:output_type -> alexstorchak.shortener.shortener.CollectionsResponse
+	29, // 52: alexstorchak.shortener.shortener.ShortenerService.CreateCollection:output_type -> alexstorchak.shortener.shortener.CollectionData
+	29, // 53: alexstorchak.shortener.shortener.ShortenerService.RenameCollection:output_type -> alexstorchak.shortener.shortener.CollectionData
+	33, // 54: alexstorchak.shortener.shortener.ShortenerService.DeleteCollection:output_type -> alexstorchak.shortener.shortener.DeleteCollectionResponse
+	35, // 55: alexstorchak.shortener.shortener.ShortenerService.MoveToCollection:output_type -> alexstorchak.shortener.shortener.MoveToCollectionResponse
+	9,  // 56: alexstorchak.shortener.shortener.ShortenerService.ListCollectionURLs:output_type -> alexstorchak.shortener.shortener.UserURLsResponse
+	36, // 57: alexstorchak.shortener.shortener.ShortenerService.DeleteCollectionURLs:output_type -> alexstorchak.shortener.shortener.DeleteCollectionURLsResponse
+	38, // 58: alexstorchak.shortener.shortener.ShortenerService.ExportCollection:output_type -> alexstorchak.shortener.shortener.ExportCollectionResponse
+	38, // [38:59] is the sub-list for method output_type
+	17, // [17:38] is the sub-list for method input_type
+	17, // [17:17] is the sub-list for extension type_name
+	17, // [17:17] is the sub-list for extension extendee
+	0,  // [0:17] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_shortener_shortener_proto_init() }
@@ -606,7 +4821,7 @@ func file_api_proto_shortener_shortener_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_shortener_shortener_proto_rawDesc), len(file_api_proto_shortener_shortener_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   7,
+			NumMessages:   41,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,9 +19,27 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ShortenerService_ShortenURL_FullMethodName   = "/alexstorchak.shortener.shortener.ShortenerService/ShortenURL"
-	ShortenerService_ExpandURL_FullMethodName    = "/alexstorchak.shortener.shortener.ShortenerService/ExpandURL"
-	ShortenerService_ListUserURLs_FullMethodName = "/alexstorchak.shortener.shortener.ShortenerService/ListUserURLs"
+	ShortenerService_ShortenURL_FullMethodName           = "/alexstorchak.shortener.shortener.ShortenerService/ShortenURL"
+	ShortenerService_ExpandURL_FullMethodName            = "/alexstorchak.shortener.shortener.ShortenerService/ExpandURL"
+	ShortenerService_InspectURL_FullMethodName           = "/alexstorchak.shortener.shortener.ShortenerService/InspectURL"
+	ShortenerService_GetQRCode_FullMethodName            = "/alexstorchak.shortener.shortener.ShortenerService/GetQRCode"
+	ShortenerService_ListUserURLs_FullMethodName         = "/alexstorchak.shortener.shortener.ShortenerService/ListUserURLs"
+	ShortenerService_UpdateURL_FullMethodName            = "/alexstorchak.shortener.shortener.ShortenerService/UpdateURL"
+	ShortenerService_ListTrashURLs_FullMethodName        = "/alexstorchak.shortener.shortener.ShortenerService/ListTrashURLs"
+	ShortenerService_RestoreURLs_FullMethodName          = "/alexstorchak.shortener.shortener.ShortenerService/RestoreURLs"
+	ShortenerService_ListUserTags_FullMethodName         = "/alexstorchak.shortener.shortener.ShortenerService/ListUserTags"
+	ShortenerService_GetTargetingRules_FullMethodName    = "/alexstorchak.shortener.shortener.ShortenerService/GetTargetingRules"
+	ShortenerService_SetTargetingRules_FullMethodName    = "/alexstorchak.shortener.shortener.ShortenerService/SetTargetingRules"
+	ShortenerService_GetSplit_FullMethodName             = "/alexstorchak.shortener.shortener.ShortenerService/GetSplit"
+	ShortenerService_SetSplit_FullMethodName             = "/alexstorchak.shortener.shortener.ShortenerService/SetSplit"
+	ShortenerService_ListCollections_FullMethodName      = "/alexstorchak.shortener.shortener.ShortenerService/ListCollections"
+	ShortenerService_CreateCollection_FullMethodName     = "/alexstorchak.shortener.shortener.ShortenerService/CreateCollection"
+	ShortenerService_RenameCollection_FullMethodName     = "/alexstorchak.shortener.shortener.ShortenerService/RenameCollection"
+	ShortenerService_DeleteCollection_FullMethodName     = "/alexstorchak.shortener.shortener.ShortenerService/DeleteCollection"
+	ShortenerService_MoveToCollection_FullMethodName     = "/alexstorchak.shortener.shortener.ShortenerService/MoveToCollection"
+	ShortenerService_ListCollectionURLs_FullMethodName   = "/alexstorchak.shortener.shortener.ShortenerService/ListCollectionURLs"
+	ShortenerService_DeleteCollectionURLs_FullMethodName = "/alexstorchak.shortener.shortener.ShortenerService/DeleteCollectionURLs"
+	ShortenerService_ExportCollection_FullMethodName     = "/alexstorchak.shortener.shortener.ShortenerService/ExportCollection"
 )
 
 // ShortenerServiceClient is the client API for ShortenerService service.
@@ -30,7 +48,25 @@ const (
 type ShortenerServiceClient interface {
 	ShortenURL(ctx context.Context, in *URLShortenRequest, opts ...grpc.CallOption) (*URLShortenResponse, error)
 	ExpandURL(ctx context.Context, in *URLExpandRequest, opts ...grpc.CallOption) (*URLExpandResponse, error)
+	InspectURL(ctx context.Context, in *URLInspectRequest, opts ...grpc.CallOption) (*URLInspectResponse, error)
+	GetQRCode(ctx context.Context, in *QRCodeRequest, opts ...grpc.CallOption) (*QRCodeResponse, error)
 	ListUserURLs(ctx context.Context, in *UserURLsRequest, opts ...grpc.CallOption) (*UserURLsResponse, error)
+	UpdateURL(ctx context.Context, in *URLUpdateRequest, opts ...grpc.CallOption) (*URLUpdateResponse, error)
+	ListTrashURLs(ctx context.Context, in *UserURLsRequest, opts ...grpc.CallOption) (*UserURLsResponse, error)
+	RestoreURLs(ctx context.Context, in *URLRestoreRequest, opts ...grpc.CallOption) (*URLRestoreResponse, error)
+	ListUserTags(ctx context.Context, in *UserTagsRequest, opts ...grpc.CallOption) (*UserTagsResponse, error)
+	GetTargetingRules(ctx context.Context, in *TargetingRulesRequest, opts ...grpc.CallOption) (*TargetingRulesResponse, error)
+	SetTargetingRules(ctx context.Context, in *SetTargetingRulesRequest, opts ...grpc.CallOption) (*TargetingRulesResponse, error)
+	GetSplit(ctx context.Context, in *SplitRequest, opts ...grpc.CallOption) (*SplitResponse, error)
+	SetSplit(ctx context.Context, in *SetSplitRequest, opts ...grpc.CallOption) (*SplitResponse, error)
+	ListCollections(ctx context.Context, in *CollectionsRequest, opts ...grpc.CallOption) (*CollectionsResponse, error)
+	CreateCollection(ctx context.Context, in *CreateCollectionRequest, opts ...grpc.CallOption) (*CollectionData, error)
+	RenameCollection(ctx context.Context, in *RenameCollectionRequest, opts ...grpc.CallOption) (*CollectionData, error)
+	DeleteCollection(ctx context.Context, in *CollectionRequest, opts ...grpc.CallOption) (*DeleteCollectionResponse, error)
+	MoveToCollection(ctx context.Context, in *MoveToCollectionRequest, opts ...grpc.CallOption) (*MoveToCollectionResponse, error)
+	ListCollectionURLs(ctx context.Context, in *CollectionRequest, opts ...grpc.CallOption) (*UserURLsResponse, error)
+	DeleteCollectionURLs(ctx context.Context, in *CollectionRequest, opts ...grpc.CallOption) (*DeleteCollectionURLsResponse, error)
+	ExportCollection(ctx context.Context, in *ExportCollectionRequest, opts ...grpc.CallOption) (*ExportCollectionResponse, error)
 }
 
 type shortenerServiceClient struct {
@@ -61,6 +97,26 @@ func (c *shortenerServiceClient) ExpandURL(ctx context.Context, in *URLExpandReq
 	return out, nil
 }
 
+func (c *shortenerServiceClient) InspectURL(ctx context.Context, in *URLInspectRequest, opts ...grpc.CallOption) (*URLInspectResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(URLInspectResponse)
+	err := c.cc.Invoke(ctx, ShortenerService_InspectURL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortenerServiceClient) GetQRCode(ctx context.Context, in *QRCodeRequest, opts ...grpc.CallOption) (*QRCodeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QRCodeResponse)
+	err := c.cc.Invoke(ctx, ShortenerService_GetQRCode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *shortenerServiceClient) ListUserURLs(ctx context.Context, in *UserURLsRequest, opts ...grpc.CallOption) (*UserURLsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(UserURLsResponse)
@@ -71,13 +127,191 @@ func (c *shortenerServiceClient) ListUserURLs(ctx context.Context, in *UserURLsR
 	return out, nil
 }
 
+func (c *shortenerServiceClient) UpdateURL(ctx context.Context, in *URLUpdateRequest, opts ...grpc.CallOption) (*URLUpdateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(URLUpdateResponse)
+	err := c.cc.Invoke(ctx, ShortenerService_UpdateURL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortenerServiceClient) ListTrashURLs(ctx context.Context, in *UserURLsRequest, opts ...grpc.CallOption) (*UserURLsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserURLsResponse)
+	err := c.cc.Invoke(ctx, ShortenerService_ListTrashURLs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortenerServiceClient) RestoreURLs(ctx context.Context, in *URLRestoreRequest, opts ...grpc.CallOption) (*URLRestoreResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(URLRestoreResponse)
+	err := c.cc.Invoke(ctx, ShortenerService_RestoreURLs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortenerServiceClient) ListUserTags(ctx context.Context, in *UserTagsRequest, opts ...grpc.CallOption) (*UserTagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserTagsResponse)
+	err := c.cc.Invoke(ctx, ShortenerService_ListUserTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortenerServiceClient) GetTargetingRules(ctx context.Context, in *TargetingRulesRequest, opts ...grpc.CallOption) (*TargetingRulesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TargetingRulesResponse)
+	err := c.cc.Invoke(ctx, ShortenerService_GetTargetingRules_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortenerServiceClient) SetTargetingRules(ctx context.Context, in *SetTargetingRulesRequest, opts ...grpc.CallOption) (*TargetingRulesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TargetingRulesResponse)
+	err := c.cc.Invoke(ctx, ShortenerService_SetTargetingRules_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortenerServiceClient) GetSplit(ctx context.Context, in *SplitRequest, opts ...grpc.CallOption) (*SplitResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SplitResponse)
+	err := c.cc.Invoke(ctx, ShortenerService_GetSplit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortenerServiceClient) SetSplit(ctx context.Context, in *SetSplitRequest, opts ...grpc.CallOption) (*SplitResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SplitResponse)
+	err := c.cc.Invoke(ctx, ShortenerService_SetSplit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortenerServiceClient) ListCollections(ctx context.Context, in *CollectionsRequest, opts ...grpc.CallOption) (*CollectionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CollectionsResponse)
+	err := c.cc.Invoke(ctx, ShortenerService_ListCollections_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortenerServiceClient) CreateCollection(ctx context.Context, in *CreateCollectionRequest, opts ...grpc.CallOption) (*CollectionData, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CollectionData)
+	err := c.cc.Invoke(ctx, ShortenerService_CreateCollection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortenerServiceClient) RenameCollection(ctx context.Context, in *RenameCollectionRequest, opts ...grpc.CallOption) (*CollectionData, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CollectionData)
+	err := c.cc.Invoke(ctx, ShortenerService_RenameCollection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortenerServiceClient) DeleteCollection(ctx context.Context, in *CollectionRequest, opts ...grpc.CallOption) (*DeleteCollectionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteCollectionResponse)
+	err := c.cc.Invoke(ctx, ShortenerService_DeleteCollection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortenerServiceClient) MoveToCollection(ctx context.Context, in *MoveToCollectionRequest, opts ...grpc.CallOption) (*MoveToCollectionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MoveToCollectionResponse)
+	err := c.cc.Invoke(ctx, ShortenerService_MoveToCollection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortenerServiceClient) ListCollectionURLs(ctx context.Context, in *CollectionRequest, opts ...grpc.CallOption) (*UserURLsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserURLsResponse)
+	err := c.cc.Invoke(ctx, ShortenerService_ListCollectionURLs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortenerServiceClient) DeleteCollectionURLs(ctx context.Context, in *CollectionRequest, opts ...grpc.CallOption) (*DeleteCollectionURLsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteCollectionURLsResponse)
+	err := c.cc.Invoke(ctx, ShortenerService_DeleteCollectionURLs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortenerServiceClient) ExportCollection(ctx context.Context, in *ExportCollectionRequest, opts ...grpc.CallOption) (*ExportCollectionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportCollectionResponse)
+	err := c.cc.Invoke(ctx, ShortenerService_ExportCollection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ShortenerServiceServer is the server API for ShortenerService service.
 // All implementations must embed UnimplementedShortenerServiceServer
 // for forward compatibility.
 type ShortenerServiceServer interface {
 	ShortenURL(context.Context, *URLShortenRequest) (*URLShortenResponse, error)
 	ExpandURL(context.Context, *URLExpandRequest) (*URLExpandResponse, error)
+	InspectURL(context.Context, *URLInspectRequest) (*URLInspectResponse, error)
+	GetQRCode(context.Context, *QRCodeRequest) (*QRCodeResponse, error)
 	ListUserURLs(context.Context, *UserURLsRequest) (*UserURLsResponse, error)
+	UpdateURL(context.Context, *URLUpdateRequest) (*URLUpdateResponse, error)
+	ListTrashURLs(context.Context, *UserURLsRequest) (*UserURLsResponse, error)
+	RestoreURLs(context.Context, *URLRestoreRequest) (*URLRestoreResponse, error)
+	ListUserTags(context.Context, *UserTagsRequest) (*UserTagsResponse, error)
+	GetTargetingRules(context.Context, *TargetingRulesRequest) (*TargetingRulesResponse, error)
+	SetTargetingRules(context.Context, *SetTargetingRulesRequest) (*TargetingRulesResponse, error)
+	GetSplit(context.Context, *SplitRequest) (*SplitResponse, error)
+	SetSplit(context.Context, *SetSplitRequest) (*SplitResponse, error)
+	ListCollections(context.Context, *CollectionsRequest) (*CollectionsResponse, error)
+	CreateCollection(context.Context, *CreateCollectionRequest) (*CollectionData, error)
+	RenameCollection(context.Context, *RenameCollectionRequest) (*CollectionData, error)
+	DeleteCollection(context.Context, *CollectionRequest) (*DeleteCollectionResponse, error)
+	MoveToCollection(context.Context, *MoveToCollectionRequest) (*MoveToCollectionResponse, error)
+	ListCollectionURLs(context.Context, *CollectionRequest) (*UserURLsResponse, error)
+	DeleteCollectionURLs(context.Context, *CollectionRequest) (*DeleteCollectionURLsResponse, error)
+	ExportCollection(context.Context, *ExportCollectionRequest) (*ExportCollectionResponse, error)
 	mustEmbedUnimplementedShortenerServiceServer()
 }
 
@@ -94,9 +328,63 @@ func (UnimplementedShortenerServiceServer) ShortenURL(context.Context, *URLShort
 func (UnimplementedShortenerServiceServer) ExpandURL(context.Context, *URLExpandRequest) (*URLExpandResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ExpandURL not implemented")
 }
+func (UnimplementedShortenerServiceServer) InspectURL(context.Context, *URLInspectRequest) (*URLInspectResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method InspectURL not implemented")
+}
+func (UnimplementedShortenerServiceServer) GetQRCode(context.Context, *QRCodeRequest) (*QRCodeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetQRCode not implemented")
+}
 func (UnimplementedShortenerServiceServer) ListUserURLs(context.Context, *UserURLsRequest) (*UserURLsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListUserURLs not implemented")
 }
+func (UnimplementedShortenerServiceServer) UpdateURL(context.Context, *URLUpdateRequest) (*URLUpdateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateURL not implemented")
+}
+func (UnimplementedShortenerServiceServer) ListTrashURLs(context.Context, *UserURLsRequest) (*UserURLsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListTrashURLs not implemented")
+}
+func (UnimplementedShortenerServiceServer) RestoreURLs(context.Context, *URLRestoreRequest) (*URLRestoreResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RestoreURLs not implemented")
+}
+func (UnimplementedShortenerServiceServer) ListUserTags(context.Context, *UserTagsRequest) (*UserTagsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListUserTags not implemented")
+}
+func (UnimplementedShortenerServiceServer) GetTargetingRules(context.Context, *TargetingRulesRequest) (*TargetingRulesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTargetingRules not implemented")
+}
+func (UnimplementedShortenerServiceServer) SetTargetingRules(context.Context, *SetTargetingRulesRequest) (*TargetingRulesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetTargetingRules not implemented")
+}
+func (UnimplementedShortenerServiceServer) GetSplit(context.Context, *SplitRequest) (*SplitResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetSplit not implemented")
+}
+func (UnimplementedShortenerServiceServer) SetSplit(context.Context, *SetSplitRequest) (*SplitResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetSplit not implemented")
+}
+func (UnimplementedShortenerServiceServer) ListCollections(context.Context, *CollectionsRequest) (*CollectionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListCollections not implemented")
+}
+func (UnimplementedShortenerServiceServer) CreateCollection(context.Context, *CreateCollectionRequest) (*CollectionData, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateCollection not implemented")
+}
+func (UnimplementedShortenerServiceServer) RenameCollection(context.Context, *RenameCollectionRequest) (*CollectionData, error) {
+	return nil, status.Error(codes.Unimplemented, "method RenameCollection not implemented")
+}
+func (UnimplementedShortenerServiceServer) DeleteCollection(context.Context, *CollectionRequest) (*DeleteCollectionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteCollection not implemented")
+}
+func (UnimplementedShortenerServiceServer) MoveToCollection(context.Context, *MoveToCollectionRequest) (*MoveToCollectionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method MoveToCollection not implemented")
+}
+func (UnimplementedShortenerServiceServer) ListCollectionURLs(context.Context, *CollectionRequest) (*UserURLsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListCollectionURLs not implemented")
+}
+func (UnimplementedShortenerServiceServer) DeleteCollectionURLs(context.Context, *CollectionRequest) (*DeleteCollectionURLsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteCollectionURLs not implemented")
+}
+func (UnimplementedShortenerServiceServer) ExportCollection(context.Context, *ExportCollectionRequest) (*ExportCollectionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ExportCollection not implemented")
+}
 func (UnimplementedShortenerServiceServer) mustEmbedUnimplementedShortenerServiceServer() {}
 func (UnimplementedShortenerServiceServer) testEmbeddedByValue()                          {}
 
@@ -154,6 +442,42 @@ func _ShortenerService_ExpandURL_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ShortenerService_InspectURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(URLInspectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortenerServiceServer).InspectURL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortenerService_InspectURL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortenerServiceServer).InspectURL(ctx, req.(*URLInspectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortenerService_GetQRCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QRCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortenerServiceServer).GetQRCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortenerService_GetQRCode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortenerServiceServer).GetQRCode(ctx, req.(*QRCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ShortenerService_ListUserURLs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UserURLsRequest)
 	if err := dec(in); err != nil {
@@ -172,6 +496,294 @@ func _ShortenerService_ListUserURLs_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ShortenerService_UpdateURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(URLUpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortenerServiceServer).UpdateURL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortenerService_UpdateURL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortenerServiceServer).UpdateURL(ctx, req.(*URLUpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortenerService_ListTrashURLs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserURLsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortenerServiceServer).ListTrashURLs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortenerService_ListTrashURLs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortenerServiceServer).ListTrashURLs(ctx, req.(*UserURLsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortenerService_RestoreURLs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(URLRestoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortenerServiceServer).RestoreURLs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortenerService_RestoreURLs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortenerServiceServer).RestoreURLs(ctx, req.(*URLRestoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortenerService_ListUserTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortenerServiceServer).ListUserTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortenerService_ListUserTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortenerServiceServer).ListUserTags(ctx, req.(*UserTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortenerService_GetTargetingRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TargetingRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortenerServiceServer).GetTargetingRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortenerService_GetTargetingRules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortenerServiceServer).GetTargetingRules(ctx, req.(*TargetingRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortenerService_SetTargetingRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetTargetingRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortenerServiceServer).SetTargetingRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortenerService_SetTargetingRules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortenerServiceServer).SetTargetingRules(ctx, req.(*SetTargetingRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortenerService_GetSplit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SplitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortenerServiceServer).GetSplit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortenerService_GetSplit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortenerServiceServer).GetSplit(ctx, req.(*SplitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortenerService_SetSplit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetSplitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortenerServiceServer).SetSplit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortenerService_SetSplit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortenerServiceServer).SetSplit(ctx, req.(*SetSplitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortenerService_ListCollections_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CollectionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortenerServiceServer).ListCollections(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortenerService_ListCollections_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortenerServiceServer).ListCollections(ctx, req.(*CollectionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortenerService_CreateCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortenerServiceServer).CreateCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortenerService_CreateCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortenerServiceServer).CreateCollection(ctx, req.(*CreateCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortenerService_RenameCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenameCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortenerServiceServer).RenameCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortenerService_RenameCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortenerServiceServer).RenameCollection(ctx, req.(*RenameCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortenerService_DeleteCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortenerServiceServer).DeleteCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortenerService_DeleteCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortenerServiceServer).DeleteCollection(ctx, req.(*CollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortenerService_MoveToCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveToCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortenerServiceServer).MoveToCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortenerService_MoveToCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortenerServiceServer).MoveToCollection(ctx, req.(*MoveToCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortenerService_ListCollectionURLs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortenerServiceServer).ListCollectionURLs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortenerService_ListCollectionURLs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortenerServiceServer).ListCollectionURLs(ctx, req.(*CollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortenerService_DeleteCollectionURLs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortenerServiceServer).DeleteCollectionURLs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortenerService_DeleteCollectionURLs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortenerServiceServer).DeleteCollectionURLs(ctx, req.(*CollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortenerService_ExportCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortenerServiceServer).ExportCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortenerService_ExportCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortenerServiceServer).ExportCollection(ctx, req.(*ExportCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ShortenerService_ServiceDesc is the grpc.ServiceDesc for ShortenerService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -187,10 +799,82 @@ var ShortenerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ExpandURL",
 			Handler:    _ShortenerService_ExpandURL_Handler,
 		},
+		{
+			MethodName: "InspectURL",
+			Handler:    _ShortenerService_InspectURL_Handler,
+		},
+		{
+			MethodName: "GetQRCode",
+			Handler:    _ShortenerService_GetQRCode_Handler,
+		},
 		{
 			MethodName: "ListUserURLs",
 			Handler:    _ShortenerService_ListUserURLs_Handler,
 		},
+		{
+			MethodName: "UpdateURL",
+			Handler:    _ShortenerService_UpdateURL_Handler,
+		},
+		{
+			MethodName: "ListTrashURLs",
+			Handler:    _ShortenerService_ListTrashURLs_Handler,
+		},
+		{
+			MethodName: "RestoreURLs",
+			Handler:    _ShortenerService_RestoreURLs_Handler,
+		},
+		{
+			MethodName: "ListUserTags",
+			Handler:    _ShortenerService_ListUserTags_Handler,
+		},
+		{
+			MethodName: "GetTargetingRules",
+			Handler:    _ShortenerService_GetTargetingRules_Handler,
+		},
+		{
+			MethodName: "SetTargetingRules",
+			Handler:    _ShortenerService_SetTargetingRules_Handler,
+		},
+		{
+			MethodName: "GetSplit",
+			Handler:    _ShortenerService_GetSplit_Handler,
+		},
+		{
+			MethodName: "SetSplit",
+			Handler:    _ShortenerService_SetSplit_Handler,
+		},
+		{
+			MethodName: "ListCollections",
+			Handler:    _ShortenerService_ListCollections_Handler,
+		},
+		{
+			MethodName: "CreateCollection",
+			Handler:    _ShortenerService_CreateCollection_Handler,
+		},
+		{
+			MethodName: "RenameCollection",
+			Handler:    _ShortenerService_RenameCollection_Handler,
+		},
+		{
+			MethodName: "DeleteCollection",
+			Handler:    _ShortenerService_DeleteCollection_Handler,
+		},
+		{
+			MethodName: "MoveToCollection",
+			Handler:    _ShortenerService_MoveToCollection_Handler,
+		},
+		{
+			MethodName: "ListCollectionURLs",
+			Handler:    _ShortenerService_ListCollectionURLs_Handler,
+		},
+		{
+			MethodName: "DeleteCollectionURLs",
+			Handler:    _ShortenerService_DeleteCollectionURLs_Handler,
+		},
+		{
+			MethodName: "ExportCollection",
+			Handler:    _ShortenerService_ExportCollection_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/proto/shortener/shortener.proto",
@@ -12,7 +12,6 @@ import (
 	"testing"
 
 	"github.com/go-chi/chi/v5"
-	"github.com/teris-io/shortid"
 	"go.uber.org/zap"
 
 	"github.com/alex-storchak/shortener/internal/audit"
@@ -52,21 +51,32 @@ func createTestApp() http.Handler {
 	}
 
 	storage := repository.NewMemoryURLStorage(zl)
-	generator, err := shortid.New(1, shortid.DefaultABC, 1)
+	generator, err := service.NewShortIDGenerator(storage, config.DefIDMaxAttempts)
 	if err != nil {
 		zl.Error("failed to init generator", zap.Error(err))
 	}
-	shortener := service.NewShortener(generator, storage, zl)
+	attempts := service.NewPasswordAttemptLimiter(config.DefPasswordMaxAttempts, config.DefPasswordAttemptsWindow)
+	canon := service.NewURLCanonicalizer(config.DefCanonicalizeURLs, config.DefCanonicalSortQuery)
+	validator, err := service.NewURLValidator(config.DefAllowedSchemes, nil, nil)
+	if err != nil {
+		zl.Error("failed to init url validator", zap.Error(err))
+	}
+	shortener := service.NewShortener(generator, nil, storage, attempts, canon, validator, nil, nil, config.DefRedirectType, zl)
 
 	var observers []audit.Observer
 	auditPublisher := audit.NewEventManager(observers, cfg.Audit, zl)
 	ub := service.NewURLBuilder(cfg.Handler.BaseURL)
 	shortenProc := processor.NewShorten(shortener, zl, ub, auditPublisher)
-	expandProc := processor.NewExpand(shortener, zl, auditPublisher)
+	utmTemplates := service.NewUTMTemplates(repository.NewMemoryUTMTemplateStorage(zl), storage, zl)
+	targeting := service.NewTargeting(repository.NewMemoryTargetingRuleStorage(zl), storage, shortener, zl)
+	splitter := service.NewSplitter(repository.NewMemorySplitStorage(zl), storage, shortener, zl)
+	expandProc := processor.NewExpand(
+		shortener, zl, auditPublisher, targeting, splitter, utmTemplates, config.DefRedirectCacheMaxAge,
+	)
 	pingProc := processor.NewPing(shortener, zl)
 	apiShortenProc := processor.NewAPIShorten(shortener, zl, ub, auditPublisher)
-	apiShortenBatchProc := processor.NewAPIShortenBatch(shortener, zl, ub)
-	apiUserURLsProc := processor.NewAPIUserURLs(shortener, zl, ub)
+	apiShortenBatchProc := processor.NewAPIShortenBatch(shortener, zl, ub, auditPublisher)
+	apiUserURLsProc := processor.NewAPIUserURLs(shortener, zl, ub, auditPublisher)
 
 	userStorage := repository.NewMemoryUserStorage(zl)
 	authService := service.NewAuthService(zl, userStorage, &cfg.Auth)
@@ -0,0 +1,22 @@
+// Command recanonicalize brings original URLs already kept in the storage to the canonical form.
+// It accepts the same flags, environment variables and config file as the shortener server.
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/alex-storchak/shortener/internal/app"
+)
+
+func main() {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM, syscall.SIGINT, syscall.SIGQUIT)
+	defer cancel()
+
+	if err := app.Recanonicalize(ctx, os.Args, os.LookupEnv); err != nil {
+		log.Fatalf("failed to recanonicalize urls: %v", err)
+	}
+}
@@ -0,0 +1,67 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/alex-storchak/shortener/internal/config"
+	"github.com/alex-storchak/shortener/internal/repository/factory"
+	"github.com/alex-storchak/shortener/internal/service"
+)
+
+// Recanonicalize brings original URLs already kept in the configured storage to the canonical form
+// used by the shortener for new links. It is meant to be run once after canonicalization is enabled
+// or its settings are changed. URLs whose canonical form is already taken by another link are left as is.
+//
+// Parameters:
+//   - ctx: context for cancellation
+//   - args: command line arguments, parsed the same way as by Run
+//   - lookupEnv: environment variables lookup function
+//
+// Returns:
+//   - error: nil on success, or error if canonicalization is disabled or storage operation fails
+func Recanonicalize(
+	ctx context.Context,
+	args []string,
+	lookupEnv func(string) (string, bool),
+) error {
+	cfg, err := config.Load(args[1:], lookupEnv)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	if !cfg.Shortener.CanonicalizeURLs {
+		return errors.New("url canonicalization is disabled in config")
+	}
+
+	zl, err := initLogger(cfg)
+	if err != nil {
+		return fmt.Errorf("init logger: %w", err)
+	}
+	//nolint:errcheck // there isn't any good strategy to log error
+	defer zl.Sync()
+
+	sf, err := factory.NewStorageFactory(cfg, zl)
+	if err != nil {
+		return fmt.Errorf("init storage factory: %w", err)
+	}
+	storage, err := sf.MakeURLStorage()
+	if err != nil {
+		return fmt.Errorf("make url storage: %w", err)
+	}
+	defer func() {
+		if err := storage.Close(); err != nil {
+			zl.Error("failed to close storage", zap.Error(err))
+		}
+	}()
+
+	canon := service.NewURLCanonicalizer(cfg.Shortener.CanonicalizeURLs, cfg.Shortener.CanonicalSortQuery)
+	rewritten, skipped, err := storage.RewriteOrigURLs(ctx, canon.Canonicalize)
+	zl.Info("original urls recanonicalized", zap.Int("rewritten", rewritten), zap.Int("skipped", skipped))
+	if err != nil {
+		return fmt.Errorf("rewrite original urls: %w", err)
+	}
+	return nil
+}
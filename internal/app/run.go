@@ -6,9 +6,8 @@ import (
 
 	"go.uber.org/zap"
 
-	"github.com/teris-io/shortid"
-
 	"github.com/alex-storchak/shortener/internal/audit"
+	"github.com/alex-storchak/shortener/internal/blocklist"
 	"github.com/alex-storchak/shortener/internal/config"
 	"github.com/alex-storchak/shortener/internal/handler"
 	"github.com/alex-storchak/shortener/internal/handler/processor"
@@ -42,7 +41,24 @@ func Run(
 		return fmt.Errorf("make url storage: %w", err)
 	}
 
-	shortener, err := initShortener(storage, zl)
+	sweeper := service.NewExpirySweeper(storage, cfg.Shortener.ExpirySweepInterval, zl)
+	sweeper.Start()
+
+	purger := service.NewPurger(
+		storage,
+		cfg.Shortener.PurgeRetention,
+		cfg.Shortener.PurgeInterval,
+		cfg.Shortener.PurgeBatchSize,
+		zl,
+	)
+
+	bl, err := blocklist.New(cfg.Blocklist, zl)
+	if err != nil {
+		return fmt.Errorf("init blocklist: %w", err)
+	}
+	bl.Start()
+
+	shortener, err := initShortener(cfg, storage, bl, zl)
 	if err != nil {
 		return fmt.Errorf("init shortener: %w", err)
 	}
@@ -53,10 +69,11 @@ func Run(
 	}
 	em := audit.NewEventManager(ao, cfg.Audit, zl)
 
-	deps, err := initServerDeps(cfg, shortener, sf, zl, em)
+	deps, err := initServerDeps(cfg, shortener, storage, shortener, purger, sf, zl, em)
 	if err != nil {
 		return fmt.Errorf("init server dependencies: %w", err)
 	}
+	purger.Start()
 	router := handler.NewRouter(deps)
 
 	httpServer, err := handler.Serve(cfg.Server, zl, router)
@@ -97,6 +114,9 @@ func Run(
 	zl.Info("http server closed")
 
 	em.Close(shutdownCtx)
+	sweeper.Close()
+	purger.Close()
+	bl.Close()
 
 	if err := storage.Close(); err != nil {
 		zl.Error("failed to close storage", zap.Error(err))
@@ -117,18 +137,56 @@ func initLogger(cfg *config.Config) (*zap.Logger, error) {
 	return zl, nil
 }
 
-func initShortener(s repository.URLStorage, zl *zap.Logger) (*service.Shortener, error) {
-	g, err := shortid.New(1, shortid.DefaultABC, 1)
+func initShortener(
+	cfg *config.Config,
+	s repository.URLStorage,
+	b service.URLBlocker,
+	zl *zap.Logger,
+) (*service.Shortener, error) {
+	g, err := service.NewIDGenerator(cfg.Shortener, s)
+	if err != nil {
+		return nil, fmt.Errorf("instantiate id generator: %w", err)
+	}
+	wg, err := service.NewWordIDGenerator(
+		s,
+		cfg.Shortener.WordIDCount,
+		cfg.Shortener.WordIDSeparator,
+		cfg.Shortener.WordIDDigits,
+		cfg.Shortener.IDMaxAttempts,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("instantiate word id generator: %w", err)
+	}
+	var cs *service.IDChecksum
+	if cfg.Shortener.IDChecksum {
+		cs = service.NewIDChecksum()
+		g = g.WithChecksum(cs)
+		wg = wg.WithChecksum(cs)
+	}
+	styles := map[string]service.IDGenerator{service.IDStyleWords: wg}
+	al := service.NewPasswordAttemptLimiter(cfg.Shortener.PasswordMaxAttempts, cfg.Shortener.PasswordAttemptsWindow)
+	canon := service.NewURLCanonicalizer(cfg.Shortener.CanonicalizeURLs, cfg.Shortener.CanonicalSortQuery)
+	v, err := service.NewURLValidator(
+		cfg.Shortener.AllowedSchemes,
+		cfg.Shortener.AllowedDomains,
+		cfg.Shortener.DeniedDomains,
+	)
 	if err != nil {
-		return nil, fmt.Errorf("instantiate shortid generator: %w", err)
+		return nil, fmt.Errorf("instantiate url validator: %w", err)
+	}
+	if err := service.ValidateRedirectType(cfg.Shortener.RedirectType); err != nil {
+		return nil, fmt.Errorf("validate default redirect type: %w", err)
 	}
 	zl.Info("shortener initialized")
-	return service.NewShortener(g, s, zl), nil
+	return service.NewShortener(g, styles, s, al, canon, v, b, cs, cfg.Shortener.RedirectType, zl), nil
 }
 
 func initServerDeps(
 	cfg *config.Config,
 	sh service.PingableURLShortener,
+	urls service.ShortIDLookup,
+	dest service.DestinationChecker,
+	purger *service.Purger,
 	sf factory.StorageFactory,
 	zl *zap.Logger,
 	ep processor.AuditEventPublisher,
@@ -139,19 +197,50 @@ func initServerDeps(
 	}
 	as := service.NewAuthService(zl, us, &cfg.Auth)
 	um := repository.NewUserManager(zl, us)
+	ts, err := sf.MakeUTMTemplateStorage()
+	if err != nil {
+		return nil, fmt.Errorf("make utm template storage: %w", err)
+	}
+	utm := service.NewUTMTemplates(ts, urls, zl)
+	rs, err := sf.MakeTargetingRuleStorage()
+	if err != nil {
+		return nil, fmt.Errorf("make targeting rule storage: %w", err)
+	}
+	purger.AddCleaners(rs)
+	targeting := service.NewTargeting(rs, urls, dest, zl)
+	ps, err := sf.MakeSplitStorage()
+	if err != nil {
+		return nil, fmt.Errorf("make split test storage: %w", err)
+	}
+	purger.AddCleaners(ps)
+	splitter := service.NewSplitter(ps, urls, dest, zl)
 	ub := service.NewURLBuilder(cfg.Handler.BaseURL)
+	cs, err := sf.MakeCollectionStorage()
+	if err != nil {
+		return nil, fmt.Errorf("make collection storage: %w", err)
+	}
+	purger.AddCleaners(cs)
+	collections := service.NewCollections(cs, sh, ub, zl)
+	qr := service.NewQRCodes(urls, ub, cfg.Shortener.QRCacheSize, zl)
 	hDeps := handler.ServerDeps{
 		Logger:              zl,
 		Config:              cfg,
 		HTTPUserResolver:    service.NewAuthUserResolver(as, um, &cfg.Auth),
 		GRPCUserResolver:    service.NewAuthUserResolver(as, um, &cfg.Auth),
 		ShortenProc:         processor.NewShorten(sh, zl, ub, ep),
-		ExpandProc:          processor.NewExpand(sh, zl, ep),
+		ExpandProc:          processor.NewExpand(sh, zl, ep, targeting, splitter, utm, cfg.Shortener.RedirectCacheMaxAge),
+		InspectProc:         processor.NewInspect(sh, zl, ub),
+		QRCodeProc:          processor.NewQRCode(qr, zl),
 		PingProc:            processor.NewPing(sh, zl),
 		APIShortenProc:      processor.NewAPIShorten(sh, zl, ub, ep),
-		APIShortenBatchProc: processor.NewAPIShortenBatch(sh, zl, ub),
-		APIUserURLsProc:     processor.NewAPIUserURLs(sh, zl, ub),
-		APIInternalProc:     processor.NewAPIInternal(us, sh),
+		APIShortenBatchProc: processor.NewAPIShortenBatch(sh, zl, ub, ep),
+		APIImportProc:       processor.NewAPIShortenImport(service.NewURLImporter(sh, zl), zl, ub, ep),
+		APIUserURLsProc:     processor.NewAPIUserURLs(sh, zl, ub, ep),
+		APIUserUTMProc:      processor.NewAPIUserUTMTemplates(utm, zl),
+		APIUserTargetProc:   processor.NewAPIUserTargeting(targeting, zl),
+		APIUserSplitProc:    processor.NewAPIUserSplit(splitter, zl),
+		APIUserCollProc:     processor.NewAPIUserCollections(collections, zl, ub),
+		APIInternalProc:     processor.NewAPIInternal(us, sh, purger),
 	}
 	return &hDeps, nil
 }
@@ -0,0 +1,151 @@
+package blocklist
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/alex-storchak/shortener/internal/config"
+)
+
+// fileState identifies a version of the blocklist file.
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// Blocklist matches URLs against rules loaded from the blocklist file.
+// Rules are swapped atomically on reload, so matching never waits for the file to be read.
+// A nil blocklist or a blocklist without a file blocks nothing.
+type Blocklist struct {
+	path     string
+	interval time.Duration
+	rules    atomic.Pointer[rules]
+	state    fileState
+	logger   *zap.Logger
+	closed   chan struct{}
+	wg       sync.WaitGroup
+	once     sync.Once
+}
+
+// New creates a new Blocklist and loads the rules from the configured file.
+//
+// Parameters:
+//   - cfg: blocklist configuration
+//   - l: structured logger for logging operations
+//
+// Returns:
+//   - *Blocklist: blocklist with loaded rules, not watching the file yet
+//   - error: nil on success, or error if the file can't be read or parsed
+func New(cfg config.Blocklist, l *zap.Logger) (*Blocklist, error) {
+	b := &Blocklist{
+		path:     cfg.File,
+		interval: cfg.ReloadInterval,
+		logger:   l,
+		closed:   make(chan struct{}),
+	}
+	b.rules.Store(&rules{})
+	if b.path == "" {
+		return b, nil
+	}
+	if err := b.load(); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// load reads the rules from the file and replaces the current ones.
+// The current rules are kept if the file can't be read or parsed.
+func (b *Blocklist) load() error {
+	f, err := os.Open(b.path)
+	if err != nil {
+		return fmt.Errorf("open blocklist file: %w", err)
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			b.logger.Error("failed to close blocklist file", zap.Error(err))
+		}
+	}()
+
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("stat blocklist file: %w", err)
+	}
+	rs, err := parseRules(f)
+	if err != nil {
+		return fmt.Errorf("parse blocklist file `%s`: %w", b.path, err)
+	}
+	b.rules.Store(rs)
+	b.state = fileState{modTime: info.ModTime(), size: info.Size()}
+	b.logger.Info("blocklist loaded", zap.String("file", b.path), zap.Int("rules", rs.size()))
+	return nil
+}
+
+// Start launches the background goroutine reloading the rules when the file changes.
+// It is a no-op if no file is configured or the reload interval is non-positive.
+func (b *Blocklist) Start() {
+	if b.path == "" || b.interval <= 0 {
+		b.logger.Info("blocklist reloading is disabled")
+		return
+	}
+	b.wg.Add(1)
+	go b.run()
+}
+
+// run checks the file for changes on every tick until the blocklist is closed.
+func (b *Blocklist) run() {
+	defer b.wg.Done()
+	ticker := time.NewTicker(b.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			b.reload()
+		case <-b.closed:
+			return
+		}
+	}
+}
+
+// reload loads the rules again if the modification time or the size of the file has changed.
+func (b *Blocklist) reload() {
+	info, err := os.Stat(b.path)
+	if err != nil {
+		b.logger.Error("failed to stat blocklist file", zap.Error(err))
+		return
+	}
+	if info.ModTime().Equal(b.state.modTime) && info.Size() == b.state.size {
+		return
+	}
+	if err := b.load(); err != nil {
+		b.logger.Error("failed to reload blocklist, keeping previous rules", zap.Error(err))
+	}
+}
+
+// Match checks the URL against the blocklist rules.
+//
+// Parameters:
+//   - url: original URL to check
+//
+// Returns:
+//   - string: description of the rule blocking the URL, empty if the URL isn't blocked
+//   - bool: true if the URL is blocked
+func (b *Blocklist) Match(url string) (string, bool) {
+	if b == nil {
+		return "", false
+	}
+	return b.rules.Load().match(url)
+}
+
+// Close stops watching the file.
+func (b *Blocklist) Close() {
+	b.once.Do(func() {
+		close(b.closed)
+		b.wg.Wait()
+		b.logger.Info("blocklist closed")
+	})
+}
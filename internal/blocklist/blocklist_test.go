@@ -0,0 +1,106 @@
+package blocklist
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/alex-storchak/shortener/internal/config"
+)
+
+func TestParseRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{name: "plain list with comments", content: "# comment\n\nevil.example\nbad.example.\n"},
+		{name: "hosts-file format", content: "127.0.0.1 localhost\n0.0.0.0 evil.example other.example # trackers\n::1 ip6-localhost\n"},
+		{name: "prefixes and regexes", content: "https://example.com/phishing/\nre:^https?://[^/]*paypa1\\.\n"},
+		{name: "hosts-file line without ip", content: "evil.example other.example\n", wantErr: true},
+		{name: "malformed regex", content: "re:[a-\n", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseRules(strings.NewReader(tt.content))
+			if tt.wantErr {
+				assert.ErrorContains(t, err, "line 1")
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestBlocklist_Match(t *testing.T) {
+	content := `# malicious hosts
+0.0.0.0 evil.example
+127.0.0.1 localhost
+Phish.Example
+https://example.com/phishing/
+re:^https?://[^/]*paypa1\.
+`
+	path := filepath.Join(t.TempDir(), "blocklist.txt")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	b, err := New(config.Blocklist{File: path}, zap.NewNop())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		url         string
+		wantBlocked bool
+	}{
+		{name: "blocked host", url: "https://evil.example/login", wantBlocked: true},
+		{name: "blocked subdomain", url: "http://a.b.evil.example", wantBlocked: true},
+		{name: "blocked host in other case", url: "https://PHISH.example:8443/", wantBlocked: true},
+		{name: "host suffix without dot isn't blocked", url: "https://notevil.example"},
+		{name: "blocked prefix", url: "HTTPS://example.com/phishing/page", wantBlocked: true},
+		{name: "other path of prefix host", url: "https://example.com/safe"},
+		{name: "blocked regex", url: "https://www.paypa1.com/", wantBlocked: true},
+		{name: "ignored loopback host", url: "http://localhost/"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, blocked := b.Match(tt.url)
+			assert.Equal(t, tt.wantBlocked, blocked)
+			assert.Equal(t, tt.wantBlocked, rule != "")
+		})
+	}
+}
+
+func TestBlocklist_MatchNil(t *testing.T) {
+	var b *Blocklist
+	_, blocked := b.Match("https://evil.example")
+	assert.False(t, blocked)
+
+	b, err := New(config.Blocklist{}, zap.NewNop())
+	require.NoError(t, err)
+	_, blocked = b.Match("https://evil.example")
+	assert.False(t, blocked)
+}
+
+func TestBlocklist_Reload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "blocklist.txt")
+	require.NoError(t, os.WriteFile(path, []byte("evil.example\n"), 0o600))
+	b, err := New(config.Blocklist{File: path, ReloadInterval: 10 * time.Millisecond}, zap.NewNop())
+	require.NoError(t, err)
+	b.Start()
+	defer b.Close()
+
+	require.NoError(t, os.WriteFile(path, []byte("evil.example\nworse.example\n"), 0o600))
+	assert.Eventually(t, func() bool {
+		_, blocked := b.Match("https://worse.example")
+		return blocked
+	}, time.Second, 10*time.Millisecond)
+
+	require.NoError(t, os.WriteFile(path, []byte("re:[a-\n"), 0o600))
+	time.Sleep(50 * time.Millisecond)
+	_, blocked := b.Match("https://worse.example")
+	assert.True(t, blocked, "previous rules must be kept when the file is malformed")
+}
@@ -0,0 +1,24 @@
+// Package blocklist provides a blocklist of malicious original URLs loaded from a local file.
+// The file is reloaded whenever it changes, so new entries take effect without a restart.
+//
+// File format:
+//
+// Every line of the file holds one rule; empty lines and lines starting with `#` are ignored.
+//   - Host rules block the host and all of its subdomains. They are written either in plain-list
+//     format (`evil.example`) or in hosts-file format (`0.0.0.0 evil.example other.example`),
+//     where loopback names like `localhost` are skipped and trailing `#` comments are allowed
+//   - Prefix rules block every URL starting with the prefix, compared case-insensitively.
+//     A line containing `://` is a prefix rule (`https://example.com/phishing/`)
+//   - Regex rules block every URL matching the regular expression. They are written after
+//     the `re:` marker (`re:^https?://[^/]*paypa1\.`) and are matched as is, so comments aren't allowed
+//
+// Usage:
+//
+//	bl, err := blocklist.New(cfg.Blocklist, logger)
+//	bl.Start()
+//	defer bl.Close()
+//
+//	if rule, blocked := bl.Match("https://evil.example/login"); blocked {
+//	    // reject the URL
+//	}
+package blocklist
@@ -0,0 +1,128 @@
+package blocklist
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// regexMarker marks the lines holding regex rules.
+const regexMarker = "re:"
+
+// ignoredHosts contains loopback names common in hosts files, which must never be blocked.
+var ignoredHosts = map[string]struct{}{
+	"localhost":             {},
+	"localhost.localdomain": {},
+	"local":                 {},
+	"broadcasthost":         {},
+	"ip6-localhost":         {},
+	"ip6-loopback":          {},
+	"0.0.0.0":               {},
+}
+
+// rules is an immutable set of blocklist rules parsed from a single version of the file.
+type rules struct {
+	hosts    map[string]struct{}
+	prefixes []string
+	regexes  []*regexp.Regexp
+}
+
+// parseRules reads blocklist rules from r.
+//
+// Parameters:
+//   - r: reader of the blocklist file content
+//
+// Returns:
+//   - *rules: parsed rules
+//   - error: nil on success, or error with the number of the first malformed line
+func parseRules(r io.Reader) (*rules, error) {
+	rs := &rules{hosts: make(map[string]struct{})}
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if err := rs.add(line); err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("scan blocklist: %w", err)
+	}
+	return rs, nil
+}
+
+// add parses a single non-empty line and adds its rules.
+func (rs *rules) add(line string) error {
+	if expr, ok := strings.CutPrefix(line, regexMarker); ok {
+		re, err := regexp.Compile(strings.TrimSpace(expr))
+		if err != nil {
+			return fmt.Errorf("compile regex: %w", err)
+		}
+		rs.regexes = append(rs.regexes, re)
+		return nil
+	}
+
+	if i := strings.Index(line, "#"); i >= 0 {
+		line = strings.TrimSpace(line[:i])
+	}
+	fields := strings.Fields(line)
+	if len(fields) == 1 && strings.Contains(fields[0], "://") {
+		rs.prefixes = append(rs.prefixes, strings.ToLower(fields[0]))
+		return nil
+	}
+	if len(fields) > 1 {
+		if net.ParseIP(fields[0]) == nil {
+			return fmt.Errorf("expected ip address at the start of hosts-file line, got `%s`", fields[0])
+		}
+		fields = fields[1:]
+	}
+	for _, h := range fields {
+		h = strings.TrimSuffix(strings.ToLower(h), ".")
+		if _, ignored := ignoredHosts[h]; !ignored {
+			rs.hosts[h] = struct{}{}
+		}
+	}
+	return nil
+}
+
+// match returns the rule blocking the URL, if any.
+// Hosts are checked first, then prefixes, then regexes.
+func (rs *rules) match(rawURL string) (string, bool) {
+	if u, err := url.Parse(rawURL); err == nil {
+		host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+		for host != "" {
+			if _, ok := rs.hosts[host]; ok {
+				return "host " + host, true
+			}
+			_, parent, found := strings.Cut(host, ".")
+			if !found {
+				break
+			}
+			host = parent
+		}
+	}
+
+	lower := strings.ToLower(rawURL)
+	for _, p := range rs.prefixes {
+		if strings.HasPrefix(lower, p) {
+			return "prefix " + p, true
+		}
+	}
+	for _, re := range rs.regexes {
+		if re.MatchString(rawURL) {
+			return "regex " + re.String(), true
+		}
+	}
+	return "", false
+}
+
+// size returns the total amount of rules.
+func (rs *rules) size() int {
+	return len(rs.hosts) + len(rs.prefixes) + len(rs.regexes)
+}
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"slices"
 	"time"
 )
 
@@ -101,16 +102,84 @@ func (a *Audit) Reset() {
 	a.HTTPTimeout = DefAuditHTTPTimeout
 }
 
+// Shortener contains configuration for short links lifecycle settings.
+type Shortener struct {
+	ExpirySweepInterval    time.Duration `env:"EXPIRY_SWEEP_INTERVAL"`    // Interval between sweeps of expired links (0 disables sweeping)
+	PasswordMaxAttempts    int           `env:"PASSWORD_MAX_ATTEMPTS"`    // Allowed failed password attempts per protected link within the window (0 disables limiting)
+	PasswordAttemptsWindow time.Duration `env:"PASSWORD_ATTEMPTS_WINDOW"` // Window of failed password attempts counting
+	PurgeRetention         time.Duration `env:"PURGE_RETENTION"`          // Time deleted links are kept before being purged (0 disables purging)
+	PurgeInterval          time.Duration `env:"PURGE_INTERVAL"`           // Interval between purges of deleted links
+	PurgeBatchSize         int           `env:"PURGE_BATCH_SIZE"`         // Maximum amount of links removed from the database in a single statement
+	CanonicalizeURLs       bool          `env:"CANONICALIZE_URLS"`        // Bring original URLs to canonical form before deduplication
+	CanonicalSortQuery     bool          `env:"CANONICAL_SORT_QUERY"`     // Sort query parameters of canonical URLs by name
+	AllowedSchemes         []string      `env:"ALLOWED_SCHEMES"`          // Schemes allowed in original URLs
+	AllowedDomains         []string      `env:"ALLOWED_DOMAINS"`          // Domain patterns allowed in original URLs (empty allows any domain that is not denied)
+	DeniedDomains          []string      `env:"DENIED_DOMAINS"`           // Domain patterns rejected in original URLs
+	IDStrategy             string        `env:"ID_STRATEGY"`              // Short ID generation strategy (shortid, random, sequential, hash, snowflake)
+	IDLength               int           `env:"ID_LENGTH"`                // Length of random and hash short IDs
+	IDAlphabet             string        `env:"ID_ALPHABET"`              // Alphabet of random short IDs
+	IDMaxAttempts          int           `env:"ID_MAX_ATTEMPTS"`          // Maximum amount of short ID candidates checked for collisions
+	IDNodeID               int           `env:"ID_NODE_ID"`               // Node ID of the replica for snowflake short IDs (0-1023), unique per replica
+	WordIDCount            int           `env:"WORD_ID_COUNT"`            // Amount of words in word-based short IDs
+	WordIDSeparator        string        `env:"WORD_ID_SEPARATOR"`        // Separator of words in word-based short IDs: `-`, `_` or empty
+	WordIDDigits           int           `env:"WORD_ID_DIGITS"`           // Amount of digits appended to word-based short IDs; 0 disables the number
+	IDChecksum             bool          `env:"ID_CHECKSUM"`              // Append a check character to short IDs and reject mistyped ones without storage lookups
+	RedirectType           int           `env:"REDIRECT_TYPE"`            // HTTP status code of redirects of links created without a requested one (301, 302, 307 or 308)
+	RedirectCacheMaxAge    time.Duration `env:"REDIRECT_CACHE_MAX_AGE"`   // Time clients may cache permanent redirects (0 forbids caching)
+	QRCacheSize            int           `env:"QR_CACHE_SIZE"`            // Maximum amount of rendered QR code images kept in memory (0 disables caching)
+}
+
+// Reset set all fields of Shortener to default values
+func (s *Shortener) Reset() {
+	s.ExpirySweepInterval = DefExpirySweepInterval
+	s.PasswordMaxAttempts = DefPasswordMaxAttempts
+	s.PasswordAttemptsWindow = DefPasswordAttemptsWindow
+	s.PurgeRetention = DefPurgeRetention
+	s.PurgeInterval = DefPurgeInterval
+	s.PurgeBatchSize = DefPurgeBatchSize
+	s.CanonicalizeURLs = DefCanonicalizeURLs
+	s.CanonicalSortQuery = DefCanonicalSortQuery
+	s.AllowedSchemes = slices.Clone(DefAllowedSchemes)
+	s.AllowedDomains = nil
+	s.DeniedDomains = nil
+	s.IDStrategy = DefIDStrategy
+	s.IDLength = DefIDLength
+	s.IDAlphabet = DefIDAlphabet
+	s.IDMaxAttempts = DefIDMaxAttempts
+	s.IDNodeID = DefIDNodeID
+	s.WordIDCount = DefWordIDCount
+	s.WordIDSeparator = DefWordIDSeparator
+	s.WordIDDigits = DefWordIDDigits
+	s.IDChecksum = DefIDChecksum
+	s.RedirectType = DefRedirectType
+	s.RedirectCacheMaxAge = DefRedirectCacheMaxAge
+	s.QRCacheSize = DefQRCacheSize
+}
+
+// Blocklist contains configuration for the blocklist of malicious URLs.
+type Blocklist struct {
+	File           string        `env:"BLOCKLIST_FILE"`            // Path to blocklist file (empty = disabled)
+	ReloadInterval time.Duration `env:"BLOCKLIST_RELOAD_INTERVAL"` // Interval between checks of blocklist file changes (0 disables reloading)
+}
+
+// Reset set all fields of Blocklist to default values
+func (b *Blocklist) Reset() {
+	b.File = DefBlocklistFile
+	b.ReloadInterval = DefBlocklistReloadInterval
+}
+
 // Config represents the complete application configuration.
 // It aggregates all configuration sections into a single structure.
 type Config struct {
-	Server  Server  // HTTP server configuration
-	Handler Handler // HTTP handler configuration
-	Logger  Logger  // Logging configuration
-	Repo    Repo    // Repository/storage configuration
-	DB      DB      // Database configuration
-	Auth    Auth    // Authentication configuration
-	Audit   Audit   // Audit system configuration
+	Server    Server    // HTTP server configuration
+	Handler   Handler   // HTTP handler configuration
+	Logger    Logger    // Logging configuration
+	Repo      Repo      // Repository/storage configuration
+	DB        DB        // Database configuration
+	Auth      Auth      // Authentication configuration
+	Audit     Audit     // Audit system configuration
+	Shortener Shortener // Short links lifecycle configuration
+	Blocklist Blocklist // Blocklist of malicious URLs configuration
 }
 
 // Reset set all fields of Config to default values.
@@ -122,6 +191,8 @@ func (c *Config) Reset() {
 	c.DB.Reset()
 	c.Auth.Reset()
 	c.Audit.Reset()
+	c.Shortener.Reset()
+	c.Blocklist.Reset()
 }
 
 // JSONConfig is a plain structure of config from JSON file
@@ -160,4 +231,33 @@ type JSONConfig struct {
 	AuditEventChanSize    *int           `json:"audit_event_chan_size"`
 	AuditHTTPWorkersCount *int           `json:"audit_http_workers_count"`
 	AuditHTTPTimeout      *time.Duration `json:"audit_http_timeout"`
+
+	// Shortener
+	ExpirySweepInterval    *time.Duration `json:"expiry_sweep_interval"`
+	PasswordMaxAttempts    *int           `json:"password_max_attempts"`
+	PasswordAttemptsWindow *time.Duration `json:"password_attempts_window"`
+	PurgeRetention         *time.Duration `json:"purge_retention"`
+	PurgeInterval          *time.Duration `json:"purge_interval"`
+	PurgeBatchSize         *int           `json:"purge_batch_size"`
+	CanonicalizeURLs       *bool          `json:"canonicalize_urls"`
+	CanonicalSortQuery     *bool          `json:"canonical_sort_query"`
+	AllowedSchemes         []string       `json:"allowed_schemes"`
+	AllowedDomains         []string       `json:"allowed_domains"`
+	DeniedDomains          []string       `json:"denied_domains"`
+	IDStrategy             *string        `json:"id_strategy"`
+	IDLength               *int           `json:"id_length"`
+	IDAlphabet             *string        `json:"id_alphabet"`
+	IDMaxAttempts          *int           `json:"id_max_attempts"`
+	IDNodeID               *int           `json:"id_node_id"`
+	WordIDCount            *int           `json:"word_id_count"`
+	WordIDSeparator        *string        `json:"word_id_separator"`
+	WordIDDigits           *int           `json:"word_id_digits"`
+	IDChecksum             *bool          `json:"id_checksum"`
+	RedirectType           *int           `json:"redirect_type"`
+	RedirectCacheMaxAge    *time.Duration `json:"redirect_cache_max_age"`
+	QRCacheSize            *int           `json:"qr_cache_size"`
+
+	// Blocklist
+	BlocklistFile           *string        `json:"blocklist_file"`
+	BlocklistReloadInterval *time.Duration `json:"blocklist_reload_interval"`
 }
@@ -114,6 +114,33 @@ func (s *ConfigTestSuite) TestParseConfig() {
 		HTTPWorkersCount: DefAuditHTTPWorkersCount,
 		HTTPTimeout:      DefAuditHTTPTimeout,
 	}
+	defShortenerCfg := Shortener{
+		ExpirySweepInterval:    DefExpirySweepInterval,
+		PasswordMaxAttempts:    DefPasswordMaxAttempts,
+		PasswordAttemptsWindow: DefPasswordAttemptsWindow,
+		PurgeRetention:         DefPurgeRetention,
+		PurgeInterval:          DefPurgeInterval,
+		PurgeBatchSize:         DefPurgeBatchSize,
+		CanonicalizeURLs:       DefCanonicalizeURLs,
+		CanonicalSortQuery:     DefCanonicalSortQuery,
+		AllowedSchemes:         DefAllowedSchemes,
+		IDStrategy:             DefIDStrategy,
+		IDLength:               DefIDLength,
+		IDAlphabet:             DefIDAlphabet,
+		IDMaxAttempts:          DefIDMaxAttempts,
+		IDNodeID:               DefIDNodeID,
+		WordIDCount:            DefWordIDCount,
+		WordIDSeparator:        DefWordIDSeparator,
+		WordIDDigits:           DefWordIDDigits,
+		IDChecksum:             DefIDChecksum,
+		RedirectType:           DefRedirectType,
+		RedirectCacheMaxAge:    DefRedirectCacheMaxAge,
+		QRCacheSize:            DefQRCacheSize,
+	}
+	defBlocklistCfg := Blocklist{
+		File:           DefBlocklistFile,
+		ReloadInterval: DefBlocklistReloadInterval,
+	}
 
 	tests := []struct {
 		name  string
@@ -126,13 +153,15 @@ func (s *ConfigTestSuite) TestParseConfig() {
 			flags: []string{},
 			envs:  map[string]string{},
 			want: &Config{
-				Server:  defServerCfg,
-				Handler: defHandlerCfg,
-				Logger:  defLoggerCfg,
-				Repo:    defRepoCfg,
-				DB:      defDBCfg,
-				Auth:    defAuthCfg,
-				Audit:   defAuditCfg,
+				Server:    defServerCfg,
+				Handler:   defHandlerCfg,
+				Logger:    defLoggerCfg,
+				Repo:      defRepoCfg,
+				DB:        defDBCfg,
+				Auth:      defAuthCfg,
+				Audit:     defAuditCfg,
+				Shortener: defShortenerCfg,
+				Blocklist: defBlocklistCfg,
 			},
 		},
 		{
@@ -164,8 +193,10 @@ func (s *ConfigTestSuite) TestParseConfig() {
 					DSN:            "postgres:flagsDSN",
 					MigrationsPath: DefMigrationsPath,
 				},
-				Auth:  defAuthCfg,
-				Audit: defAuditCfg,
+				Auth:      defAuthCfg,
+				Audit:     defAuditCfg,
+				Shortener: defShortenerCfg,
+				Blocklist: defBlocklistCfg,
 			},
 		},
 		{
@@ -183,12 +214,14 @@ func (s *ConfigTestSuite) TestParseConfig() {
 					SSLKeyPath:               DefSSLKeyPath,
 					ShutdownWaitSecsDuration: DefShutdownWaitSecsDuration,
 				},
-				Handler: defHandlerCfg,
-				Logger:  defLoggerCfg,
-				Repo:    defRepoCfg,
-				DB:      defDBCfg,
-				Auth:    defAuthCfg,
-				Audit:   defAuditCfg,
+				Handler:   defHandlerCfg,
+				Logger:    defLoggerCfg,
+				Repo:      defRepoCfg,
+				DB:        defDBCfg,
+				Auth:      defAuthCfg,
+				Audit:     defAuditCfg,
+				Shortener: defShortenerCfg,
+				Blocklist: defBlocklistCfg,
 			},
 		},
 		{
@@ -202,11 +235,13 @@ func (s *ConfigTestSuite) TestParseConfig() {
 				Handler: Handler{
 					BaseURL: "http://example.com:1111",
 				},
-				Logger: defLoggerCfg,
-				Repo:   defRepoCfg,
-				DB:     defDBCfg,
-				Auth:   defAuthCfg,
-				Audit:  defAuditCfg,
+				Logger:    defLoggerCfg,
+				Repo:      defRepoCfg,
+				DB:        defDBCfg,
+				Auth:      defAuthCfg,
+				Audit:     defAuditCfg,
+				Shortener: defShortenerCfg,
+				Blocklist: defBlocklistCfg,
 			},
 		},
 		{
@@ -233,9 +268,11 @@ func (s *ConfigTestSuite) TestParseConfig() {
 				Repo: Repo{
 					FileStoragePath: "./data/some_file.json",
 				},
-				DB:    defDBCfg,
-				Auth:  defAuthCfg,
-				Audit: defAuditCfg,
+				DB:        defDBCfg,
+				Auth:      defAuthCfg,
+				Audit:     defAuditCfg,
+				Shortener: defShortenerCfg,
+				Blocklist: defBlocklistCfg,
 			},
 		},
 		{
@@ -266,9 +303,11 @@ func (s *ConfigTestSuite) TestParseConfig() {
 				Repo: Repo{
 					FileStoragePath: "./data/some_another_file.json",
 				},
-				DB:    defDBCfg,
-				Auth:  defAuthCfg,
-				Audit: defAuditCfg,
+				DB:        defDBCfg,
+				Auth:      defAuthCfg,
+				Audit:     defAuditCfg,
+				Shortener: defShortenerCfg,
+				Blocklist: defBlocklistCfg,
 			},
 		},
 		{
@@ -291,11 +330,13 @@ func (s *ConfigTestSuite) TestParseConfig() {
 				Handler: Handler{
 					BaseURL: "http://flags-example.com:1111",
 				},
-				Logger: defLoggerCfg,
-				Repo:   defRepoCfg,
-				DB:     defDBCfg,
-				Auth:   defAuthCfg,
-				Audit:  defAuditCfg,
+				Logger:    defLoggerCfg,
+				Repo:      defRepoCfg,
+				DB:        defDBCfg,
+				Auth:      defAuthCfg,
+				Audit:     defAuditCfg,
+				Shortener: defShortenerCfg,
+				Blocklist: defBlocklistCfg,
 			},
 		},
 		{
@@ -318,11 +359,13 @@ func (s *ConfigTestSuite) TestParseConfig() {
 				Handler: Handler{
 					BaseURL: DefBaseURL,
 				},
-				Logger: defLoggerCfg,
-				Repo:   defRepoCfg,
-				DB:     defDBCfg,
-				Auth:   defAuthCfg,
-				Audit:  defAuditCfg,
+				Logger:    defLoggerCfg,
+				Repo:      defRepoCfg,
+				DB:        defDBCfg,
+				Auth:      defAuthCfg,
+				Audit:     defAuditCfg,
+				Shortener: defShortenerCfg,
+				Blocklist: defBlocklistCfg,
 			},
 		},
 		{
@@ -339,10 +382,12 @@ func (s *ConfigTestSuite) TestParseConfig() {
 				Logger: Logger{
 					LogLevel: "debug",
 				},
-				Repo:  defRepoCfg,
-				DB:    defDBCfg,
-				Auth:  defAuthCfg,
-				Audit: defAuditCfg,
+				Repo:      defRepoCfg,
+				DB:        defDBCfg,
+				Auth:      defAuthCfg,
+				Audit:     defAuditCfg,
+				Shortener: defShortenerCfg,
+				Blocklist: defBlocklistCfg,
 			},
 		},
 		{
@@ -357,10 +402,12 @@ func (s *ConfigTestSuite) TestParseConfig() {
 				Logger: Logger{
 					LogLevel: "debug",
 				},
-				Repo:  defRepoCfg,
-				DB:    defDBCfg,
-				Auth:  defAuthCfg,
-				Audit: defAuditCfg,
+				Repo:      defRepoCfg,
+				DB:        defDBCfg,
+				Auth:      defAuthCfg,
+				Audit:     defAuditCfg,
+				Shortener: defShortenerCfg,
+				Blocklist: defBlocklistCfg,
 			},
 		},
 		{
@@ -375,10 +422,12 @@ func (s *ConfigTestSuite) TestParseConfig() {
 				Logger: Logger{
 					LogLevel: "error",
 				},
-				Repo:  defRepoCfg,
-				DB:    defDBCfg,
-				Auth:  defAuthCfg,
-				Audit: defAuditCfg,
+				Repo:      defRepoCfg,
+				DB:        defDBCfg,
+				Auth:      defAuthCfg,
+				Audit:     defAuditCfg,
+				Shortener: defShortenerCfg,
+				Blocklist: defBlocklistCfg,
 			},
 		},
 		{
@@ -396,9 +445,11 @@ func (s *ConfigTestSuite) TestParseConfig() {
 				Repo: Repo{
 					FileStoragePath: "./data/env_file.json",
 				},
-				DB:    defDBCfg,
-				Auth:  defAuthCfg,
-				Audit: defAuditCfg,
+				DB:        defDBCfg,
+				Auth:      defAuthCfg,
+				Audit:     defAuditCfg,
+				Shortener: defShortenerCfg,
+				Blocklist: defBlocklistCfg,
 			},
 		},
 		{
@@ -414,9 +465,11 @@ func (s *ConfigTestSuite) TestParseConfig() {
 				Repo: Repo{
 					FileStoragePath: "./data/env_file.json",
 				},
-				DB:    defDBCfg,
-				Auth:  defAuthCfg,
-				Audit: defAuditCfg,
+				DB:        defDBCfg,
+				Auth:      defAuthCfg,
+				Audit:     defAuditCfg,
+				Shortener: defShortenerCfg,
+				Blocklist: defBlocklistCfg,
 			},
 		},
 		{
@@ -432,9 +485,11 @@ func (s *ConfigTestSuite) TestParseConfig() {
 				Repo: Repo{
 					FileStoragePath: "./data/flags_file.json",
 				},
-				DB:    defDBCfg,
-				Auth:  defAuthCfg,
-				Audit: defAuditCfg,
+				DB:        defDBCfg,
+				Auth:      defAuthCfg,
+				Audit:     defAuditCfg,
+				Shortener: defShortenerCfg,
+				Blocklist: defBlocklistCfg,
 			},
 		},
 		{
@@ -454,8 +509,10 @@ func (s *ConfigTestSuite) TestParseConfig() {
 					DSN:            "postgres:envDSN",
 					MigrationsPath: DefMigrationsPath,
 				},
-				Auth:  defAuthCfg,
-				Audit: defAuditCfg,
+				Auth:      defAuthCfg,
+				Audit:     defAuditCfg,
+				Shortener: defShortenerCfg,
+				Blocklist: defBlocklistCfg,
 			},
 		},
 		{
@@ -473,8 +530,10 @@ func (s *ConfigTestSuite) TestParseConfig() {
 					DSN:            "postgres:envDSN",
 					MigrationsPath: DefMigrationsPath,
 				},
-				Auth:  defAuthCfg,
-				Audit: defAuditCfg,
+				Auth:      defAuthCfg,
+				Audit:     defAuditCfg,
+				Shortener: defShortenerCfg,
+				Blocklist: defBlocklistCfg,
 			},
 		},
 		{
@@ -492,8 +551,10 @@ func (s *ConfigTestSuite) TestParseConfig() {
 					DSN:            "postgres:flagsDSN",
 					MigrationsPath: DefMigrationsPath,
 				},
-				Auth:  defAuthCfg,
-				Audit: defAuditCfg,
+				Auth:      defAuthCfg,
+				Audit:     defAuditCfg,
+				Shortener: defShortenerCfg,
+				Blocklist: defBlocklistCfg,
 			},
 		},
 	}
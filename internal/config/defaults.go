@@ -71,3 +71,58 @@ const (
 	// DefAuditHTTPTimeout - Default audit HTTP request timeout
 	DefAuditHTTPTimeout = 3 * time.Second
 )
+
+// Shortener defaults
+const (
+	// DefExpirySweepInterval - Default interval between sweeps of expired links
+	DefExpirySweepInterval = time.Minute
+	// DefPasswordMaxAttempts - Default amount of failed password attempts per protected link within the window
+	DefPasswordMaxAttempts = 5
+	// DefPasswordAttemptsWindow - Default window of failed password attempts counting
+	DefPasswordAttemptsWindow = 5 * time.Minute
+	// DefPurgeRetention - Default time deleted links are kept before being purged
+	DefPurgeRetention = 30 * 24 * time.Hour
+	// DefPurgeInterval - Default interval between purges of deleted links
+	DefPurgeInterval = time.Hour
+	// DefPurgeBatchSize - Default maximum amount of links removed from the database in a single statement
+	DefPurgeBatchSize = 1000
+	// DefCanonicalizeURLs - Default flag of original URLs canonicalization
+	DefCanonicalizeURLs = true
+	// DefCanonicalSortQuery - Default flag of query parameters sorting in canonical URLs
+	DefCanonicalSortQuery = false
+	// DefIDStrategy - Default short ID generation strategy
+	DefIDStrategy = "shortid"
+	// DefIDLength - Default length of random and hash short IDs
+	DefIDLength = 8
+	// DefIDAlphabet - Default alphabet of random short IDs (base62)
+	DefIDAlphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	// DefIDMaxAttempts - Default maximum amount of short ID candidates checked for collisions
+	DefIDMaxAttempts = 10
+	// DefIDNodeID - Default node ID of the replica for snowflake short IDs
+	DefIDNodeID = 0
+	// DefWordIDCount - Default amount of words in word-based short IDs
+	DefWordIDCount = 2
+	// DefWordIDSeparator - Default separator of words in word-based short IDs
+	DefWordIDSeparator = "-"
+	// DefWordIDDigits - Default amount of digits appended to word-based short IDs
+	DefWordIDDigits = 2
+	// DefIDChecksum - Default flag of check characters in short IDs
+	DefIDChecksum = false
+	// DefRedirectType - Default HTTP status code of link redirects (307 Temporary Redirect)
+	DefRedirectType = 307
+	// DefRedirectCacheMaxAge - Default time clients may cache permanent redirects
+	DefRedirectCacheMaxAge = 24 * time.Hour
+	// DefQRCacheSize - Default maximum amount of rendered QR code images kept in memory
+	DefQRCacheSize = 1000
+)
+
+// Blocklist defaults
+const (
+	// DefBlocklistFile - Default blocklist file path (empty = disabled)
+	DefBlocklistFile = ""
+	// DefBlocklistReloadInterval - Default interval between checks of blocklist file changes
+	DefBlocklistReloadInterval = 30 * time.Second
+)
+
+// DefAllowedSchemes - Default schemes allowed in original URLs
+var DefAllowedSchemes = []string{"http", "https"}
@@ -14,6 +14,7 @@
 //   - Storage/DB options (file path, database DSN)
 //   - Authentication (JWT, cookies)
 //   - Audit system (file logging, remote server)
+//   - Short links lifecycle (expired links sweeping, password attempts limiting)
 //
 // Usage:
 //
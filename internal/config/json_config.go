@@ -108,4 +108,83 @@ func applyJSONConfig(cfg *Config, jc *JSONConfig) {
 	if jc.AuditHTTPTimeout != nil {
 		cfg.Audit.HTTPTimeout = *jc.AuditHTTPTimeout
 	}
+
+	// Shortener
+	if jc.ExpirySweepInterval != nil {
+		cfg.Shortener.ExpirySweepInterval = *jc.ExpirySweepInterval
+	}
+	if jc.PasswordMaxAttempts != nil {
+		cfg.Shortener.PasswordMaxAttempts = *jc.PasswordMaxAttempts
+	}
+	if jc.PasswordAttemptsWindow != nil {
+		cfg.Shortener.PasswordAttemptsWindow = *jc.PasswordAttemptsWindow
+	}
+	if jc.PurgeRetention != nil {
+		cfg.Shortener.PurgeRetention = *jc.PurgeRetention
+	}
+	if jc.PurgeInterval != nil {
+		cfg.Shortener.PurgeInterval = *jc.PurgeInterval
+	}
+	if jc.PurgeBatchSize != nil {
+		cfg.Shortener.PurgeBatchSize = *jc.PurgeBatchSize
+	}
+	if jc.CanonicalizeURLs != nil {
+		cfg.Shortener.CanonicalizeURLs = *jc.CanonicalizeURLs
+	}
+	if jc.CanonicalSortQuery != nil {
+		cfg.Shortener.CanonicalSortQuery = *jc.CanonicalSortQuery
+	}
+	if jc.AllowedSchemes != nil {
+		cfg.Shortener.AllowedSchemes = jc.AllowedSchemes
+	}
+	if jc.AllowedDomains != nil {
+		cfg.Shortener.AllowedDomains = jc.AllowedDomains
+	}
+	if jc.DeniedDomains != nil {
+		cfg.Shortener.DeniedDomains = jc.DeniedDomains
+	}
+	if jc.IDStrategy != nil {
+		cfg.Shortener.IDStrategy = *jc.IDStrategy
+	}
+	if jc.IDLength != nil {
+		cfg.Shortener.IDLength = *jc.IDLength
+	}
+	if jc.IDAlphabet != nil {
+		cfg.Shortener.IDAlphabet = *jc.IDAlphabet
+	}
+	if jc.IDMaxAttempts != nil {
+		cfg.Shortener.IDMaxAttempts = *jc.IDMaxAttempts
+	}
+	if jc.IDNodeID != nil {
+		cfg.Shortener.IDNodeID = *jc.IDNodeID
+	}
+	if jc.WordIDCount != nil {
+		cfg.Shortener.WordIDCount = *jc.WordIDCount
+	}
+	if jc.WordIDSeparator != nil {
+		cfg.Shortener.WordIDSeparator = *jc.WordIDSeparator
+	}
+	if jc.WordIDDigits != nil {
+		cfg.Shortener.WordIDDigits = *jc.WordIDDigits
+	}
+	if jc.IDChecksum != nil {
+		cfg.Shortener.IDChecksum = *jc.IDChecksum
+	}
+	if jc.RedirectType != nil {
+		cfg.Shortener.RedirectType = *jc.RedirectType
+	}
+	if jc.RedirectCacheMaxAge != nil {
+		cfg.Shortener.RedirectCacheMaxAge = *jc.RedirectCacheMaxAge
+	}
+	if jc.QRCacheSize != nil {
+		cfg.Shortener.QRCacheSize = *jc.QRCacheSize
+	}
+
+	// Blocklist
+	if jc.BlocklistFile != nil {
+		cfg.Blocklist.File = *jc.BlocklistFile
+	}
+	if jc.BlocklistReloadInterval != nil {
+		cfg.Blocklist.ReloadInterval = *jc.BlocklistReloadInterval
+	}
 }
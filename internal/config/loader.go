@@ -76,9 +76,56 @@ func parseFlags(cfg *Config) {
 	flag.IntVar(&cfg.Audit.HTTPWorkersCount, "audit-http-workers-count", cfg.Audit.HTTPWorkersCount, "audit http workers count")
 	flag.DurationVar(&cfg.Audit.HTTPTimeout, "audit-http-timeout", cfg.Audit.HTTPTimeout, "audit http timeout")
 
+	flag.DurationVar(&cfg.Shortener.ExpirySweepInterval, "expiry-sweep-interval", cfg.Shortener.ExpirySweepInterval, "interval between sweeps of expired links")
+	flag.IntVar(&cfg.Shortener.PasswordMaxAttempts, "password-max-attempts", cfg.Shortener.PasswordMaxAttempts, "failed password attempts allowed per protected link within the window")
+	flag.DurationVar(&cfg.Shortener.PasswordAttemptsWindow, "password-attempts-window", cfg.Shortener.PasswordAttemptsWindow, "window of failed password attempts counting")
+	flag.DurationVar(&cfg.Shortener.PurgeRetention, "purge-retention", cfg.Shortener.PurgeRetention, "time deleted links are kept before being purged")
+	flag.DurationVar(&cfg.Shortener.PurgeInterval, "purge-interval", cfg.Shortener.PurgeInterval, "interval between purges of deleted links")
+	flag.IntVar(&cfg.Shortener.PurgeBatchSize, "purge-batch-size", cfg.Shortener.PurgeBatchSize, "maximum amount of links removed from the database in a single statement")
+	flag.BoolVar(&cfg.Shortener.CanonicalizeURLs, "canonicalize-urls", cfg.Shortener.CanonicalizeURLs, "bring original URLs to canonical form before deduplication")
+	flag.BoolVar(&cfg.Shortener.CanonicalSortQuery, "canonical-sort-query", cfg.Shortener.CanonicalSortQuery, "sort query parameters of canonical URLs by name")
+	listVar(&cfg.Shortener.AllowedSchemes, "allowed-schemes", "comma-separated schemes allowed in original URLs")
+	listVar(&cfg.Shortener.AllowedDomains, "allowed-domains", "comma-separated domain patterns allowed in original URLs, e.g. \"*.example.com\"")
+	listVar(&cfg.Shortener.DeniedDomains, "denied-domains", "comma-separated domain patterns rejected in original URLs")
+	flag.StringVar(&cfg.Shortener.IDStrategy, "id-strategy", cfg.Shortener.IDStrategy, "short ID generation strategy: shortid, random, sequential, hash or snowflake")
+	flag.IntVar(&cfg.Shortener.IDLength, "id-length", cfg.Shortener.IDLength, "length of random and hash short IDs")
+	flag.StringVar(&cfg.Shortener.IDAlphabet, "id-alphabet", cfg.Shortener.IDAlphabet, "alphabet of random short IDs")
+	flag.IntVar(&cfg.Shortener.IDMaxAttempts, "id-max-attempts", cfg.Shortener.IDMaxAttempts, "maximum amount of short ID candidates checked for collisions")
+	flag.IntVar(&cfg.Shortener.IDNodeID, "id-node-id", cfg.Shortener.IDNodeID, "node ID of the replica for snowflake short IDs, unique per replica")
+	flag.IntVar(&cfg.Shortener.WordIDCount, "word-id-count", cfg.Shortener.WordIDCount, "amount of words in word-based short IDs")
+	flag.StringVar(&cfg.Shortener.WordIDSeparator, "word-id-separator", cfg.Shortener.WordIDSeparator, "separator of words in word-based short IDs: -, _ or empty")
+	flag.IntVar(&cfg.Shortener.WordIDDigits, "word-id-digits", cfg.Shortener.WordIDDigits, "amount of digits appended to word-based short IDs, 0 disables the number")
+	flag.BoolVar(&cfg.Shortener.IDChecksum, "id-checksum", cfg.Shortener.IDChecksum, "append a check character to short IDs and reject mistyped ones")
+	flag.IntVar(&cfg.Shortener.RedirectType, "redirect-type", cfg.Shortener.RedirectType, "HTTP status code of redirects of links created without a requested one: 301, 302, 307 or 308")
+	flag.DurationVar(&cfg.Shortener.RedirectCacheMaxAge, "redirect-cache-max-age", cfg.Shortener.RedirectCacheMaxAge, "time clients may cache permanent redirects, 0 forbids caching")
+	flag.IntVar(&cfg.Shortener.QRCacheSize, "qr-cache-size", cfg.Shortener.QRCacheSize, "maximum amount of rendered QR code images kept in memory, 0 disables caching")
+
+	flag.StringVar(&cfg.Blocklist.File, "blocklist-file", cfg.Blocklist.File, "blocklist file path of malicious hosts, URL prefixes and regexes")
+	flag.DurationVar(&cfg.Blocklist.ReloadInterval, "blocklist-reload-interval", cfg.Blocklist.ReloadInterval, "interval between checks of blocklist file changes")
+
 	flag.Parse()
 }
 
+// listVar defines a flag with a comma-separated list value.
+// Empty items are skipped, so an empty value sets an empty list.
+//
+// Parameters:
+//   - p: Pointer to the list to set
+//   - name: Flag name
+//   - usage: Flag description
+func listVar(p *[]string, name, usage string) {
+	flag.Func(name, usage, func(s string) error {
+		list := make([]string, 0)
+		for _, item := range strings.Split(s, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+		*p = list
+		return nil
+	})
+}
+
 // parseEnv parses environment variables and overrides any previously set flag values.
 // Environment variables have the highest precedence in the configuration hierarchy.
 //
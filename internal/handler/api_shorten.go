@@ -23,8 +23,11 @@ type APIShortenProcessor interface {
 //
 // Returns:
 // - 400 Bad Request for invalid content type or malformed JSON
-// - 400 Bad Request for empty input URL
+// - 400 Bad Request for empty input URL, invalid alias, ID style or expiration (reason in the body)
 // - 409 Conflict when URL already exists (returns existing short URL)
+// - 409 Conflict when requested alias is already taken (plain text error in the body)
+// - 409 Conflict when alias is requested for URL that already exists (plain text error in the body)
+// - 451 Unavailable For Legal Reasons when URL matches the blocklist
 // - 201 Created for successful shortening
 // - 500 Internal Server Error for processing failures
 func HandleAPIShorten(p APIShortenProcessor, l *zap.Logger) http.HandlerFunc {
@@ -43,8 +46,17 @@ func HandleAPIShorten(p APIShortenProcessor, l *zap.Logger) http.HandlerFunc {
 		}
 
 		resBody, err := p.Process(r.Context(), req)
-		if errors.Is(err, service.ErrEmptyInputURL) {
-			w.WriteHeader(http.StatusBadRequest)
+		if errors.Is(err, service.ErrEmptyInputURL) || isValidationError(err) {
+			writeBadRequest(w, err)
+			return
+		} else if errors.Is(err, service.ErrURLBlocked) {
+			writeBlocked(w)
+			return
+		} else if errors.Is(err, service.ErrAliasTaken) {
+			http.Error(w, service.ErrAliasTaken.Error(), http.StatusConflict)
+			return
+		} else if errors.Is(err, service.ErrAliasURLExists) {
+			http.Error(w, service.ErrAliasURLExists.Error(), http.StatusConflict)
 			return
 		} else if errors.Is(err, service.ErrURLAlreadyExists) {
 			if err = codec.EasyJSONEncode(w, http.StatusConflict, resBody); err != nil {
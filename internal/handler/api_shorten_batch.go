@@ -26,7 +26,10 @@ type APIShortenBatchProcessor interface {
 //   - Validates that Content-Type is 'application/json'
 //   - Processes the batch shortening request
 //   - Returns appropriate HTTP status codes:
-//   - 400 Bad Request for invalid content type, malformed JSON, or empty input
+//   - 400 Bad Request for invalid content type, malformed JSON, empty input, invalid alias, ID style or expiration
+//   - 409 Conflict when any requested alias is already taken
+//   - 409 Conflict when any alias is requested for URL that already exists
+//   - 451 Unavailable For Legal Reasons when any URL matches the blocklist
 //   - 201 Created with BatchShortenResponse for successful processing
 //   - 500 Internal Server Error for internal processing failures
 //
@@ -52,8 +55,19 @@ func HandleAPIShortenBatch(p APIShortenBatchProcessor, l *zap.Logger) http.Handl
 		}
 
 		respItems, err := p.Process(r.Context(), req)
-		if errors.Is(err, service.ErrEmptyInputURL) || errors.Is(err, service.ErrEmptyInputBatch) {
-			w.WriteHeader(http.StatusBadRequest)
+		if errors.Is(err, service.ErrEmptyInputURL) ||
+			errors.Is(err, service.ErrEmptyInputBatch) ||
+			isValidationError(err) {
+			writeBadRequest(w, err)
+			return
+		} else if errors.Is(err, service.ErrURLBlocked) {
+			writeBlocked(w)
+			return
+		} else if errors.Is(err, service.ErrAliasTaken) {
+			http.Error(w, service.ErrAliasTaken.Error(), http.StatusConflict)
+			return
+		} else if errors.Is(err, service.ErrAliasURLExists) {
+			http.Error(w, service.ErrAliasURLExists.Error(), http.StatusConflict)
 			return
 		} else if err != nil {
 			l.Error("failed to shorten batch", zap.Error(err))
@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/alex-storchak/shortener/internal/model"
+	"github.com/alex-storchak/shortener/internal/service"
+)
+
+// APIShortenImportProcessor defines the interface for processing streaming bulk imports of links.
+type APIShortenImportProcessor interface {
+	Process(ctx context.Context, format string, r io.Reader, emit func([]model.ImportResultItem) error) error
+}
+
+// importFormats maps media types of bulk import request bodies to import formats.
+var importFormats = map[string]string{
+	"text/csv":             service.ImportFormatCSV,
+	"application/x-ndjson": service.ImportFormatNDJSON,
+	"application/ndjson":   service.ImportFormatNDJSON,
+}
+
+// HandleAPIShortenImport creates an HTTP handler for the bulk import API endpoint.
+// It handles POST requests to '/api/shorten/import' with a CSV ('text/csv') or NDJSON ('application/x-ndjson') body.
+// The body is read while the response is written: the outcome of every row is streamed
+// as a line of an NDJSON response as soon as the chunk of the row is stored.
+//
+// The handler:
+//   - Picks the import format by the Content-Type of the request
+//   - Streams an ImportResultItem per row with its status: created, exists or failed
+//   - Returns appropriate HTTP status codes before the first row is stored:
+//   - 400 Bad Request for unsupported content type, empty input, CSV header without 'original_url' column
+//     or too long NDJSON lines
+//   - 200 OK with the NDJSON stream of row outcomes
+//   - 500 Internal Server Error for internal processing failures
+//   - Ends the stream with a failed item without row number if the import fails after streaming started
+//
+// Parameters:
+//   - p: Processor implementing the bulk import business logic
+//   - l: Logger for error logging and debugging
+//
+// Returns:
+//   - HTTP handler function for the bulk import endpoint
+func HandleAPIShortenImport(p APIShortenImportProcessor, l *zap.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		format, ok := importFormats[mediaType]
+		if err != nil || !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		rc := http.NewResponseController(w)
+		if err := rc.EnableFullDuplex(); err != nil {
+			l.Debug("enable full duplex for import", zap.Error(err))
+		}
+
+		started := false
+		err = p.Process(r.Context(), format, r.Body, func(items []model.ImportResultItem) error {
+			if !started {
+				w.Header().Set("Content-Type", "application/x-ndjson")
+				w.WriteHeader(http.StatusOK)
+				started = true
+			}
+			return writeImportResults(w, rc, items)
+		})
+		if err == nil {
+			return
+		}
+
+		if !started {
+			if errors.Is(err, service.ErrEmptyInputBatch) || isValidationError(err) {
+				writeBadRequest(w, err)
+				return
+			}
+			l.Error("failed to import urls", zap.Error(err))
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		// The status is already sent, so the failure is reported as the last line of the stream.
+		item := model.ImportResultItem{Status: string(model.URLImportStatusFailed), Error: "internal error"}
+		var vErr *service.ValidationError
+		if errors.As(err, &vErr) {
+			item.Error = vErr.Error()
+		} else {
+			l.Error("failed to import urls", zap.Error(err))
+		}
+		if err := writeImportResults(w, rc, []model.ImportResultItem{item}); err != nil {
+			l.Debug("write import failure", zap.Error(err))
+		}
+	}
+}
+
+// writeImportResults writes outcomes of rows as NDJSON lines and flushes them to the client.
+func writeImportResults(w http.ResponseWriter, rc *http.ResponseController, items []model.ImportResultItem) error {
+	var buf bytes.Buffer
+	for _, item := range items {
+		b, err := item.MarshalJSON()
+		if err != nil {
+			return fmt.Errorf("marshal import result: %w", err)
+		}
+		buf.Write(b)
+		buf.WriteByte('\n')
+	}
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("write import results: %w", err)
+	}
+	if err := rc.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return fmt.Errorf("flush import results: %w", err)
+	}
+	return nil
+}
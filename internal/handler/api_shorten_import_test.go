@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/alex-storchak/shortener/internal/model"
+	"github.com/alex-storchak/shortener/internal/service"
+)
+
+type ShortenImportSrvStub struct {
+	chunks    [][]model.ImportResultItem
+	importErr error
+	gotFormat string
+}
+
+func (s *ShortenImportSrvStub) Process(
+	_ context.Context,
+	format string,
+	_ io.Reader,
+	emit func([]model.ImportResultItem) error,
+) error {
+	s.gotFormat = format
+	for _, c := range s.chunks {
+		if err := emit(c); err != nil {
+			return err
+		}
+	}
+	return s.importErr
+}
+
+func TestAPIShortenImport(t *testing.T) {
+	chunks := [][]model.ImportResultItem{
+		{{Row: 1, OrigURL: "https://a.com", ShortURL: "https://example.com/a1", Status: "created"}},
+		{{Row: 2, OrigURL: "https://b.com", Status: "failed", Error: "invalid import: bad row"}},
+	}
+	tests := []struct {
+		name        string
+		contentType string
+		chunks      [][]model.ImportResultItem
+		importErr   error
+		wantCode    int
+		wantFormat  string
+		wantBody    string
+	}{
+		{
+			name:        "wrong Content-Type returns 400 (Bad Request)",
+			contentType: "application/json",
+			wantCode:    http.StatusBadRequest,
+		},
+		{
+			name:        "streams outcomes of csv rows",
+			contentType: "text/csv; charset=utf-8",
+			chunks:      chunks,
+			wantCode:    http.StatusOK,
+			wantFormat:  service.ImportFormatCSV,
+			wantBody: `{"row":1,"original_url":"https://a.com","short_url":"https://example.com/a1","status":"created"}` + "\n" +
+				`{"row":2,"original_url":"https://b.com","status":"failed","error":"invalid import: bad row"}` + "\n",
+		},
+		{
+			name:        "returns 400 (Bad Request) when empty input provided",
+			contentType: "application/x-ndjson",
+			importErr:   service.ErrEmptyInputBatch,
+			wantCode:    http.StatusBadRequest,
+			wantFormat:  service.ImportFormatNDJSON,
+		},
+		{
+			name:        "returns 400 (Bad Request) when input is malformed",
+			contentType: "text/csv",
+			importErr:   service.NewValidationError(service.ErrInvalidImport, "no original_url column"),
+			wantCode:    http.StatusBadRequest,
+			wantFormat:  service.ImportFormatCSV,
+		},
+		{
+			name:        "returns 500 (Internal Server Error) when random error on import",
+			contentType: "application/ndjson",
+			importErr:   errors.New("random error"),
+			wantCode:    http.StatusInternalServerError,
+			wantFormat:  service.ImportFormatNDJSON,
+		},
+		{
+			name:        "ends the stream with a failed item when import fails after streaming started",
+			contentType: "text/csv",
+			chunks:      chunks[:1],
+			importErr:   errors.New("random error"),
+			wantCode:    http.StatusOK,
+			wantFormat:  service.ImportFormatCSV,
+			wantBody: `{"row":1,"original_url":"https://a.com","short_url":"https://example.com/a1","status":"created"}` + "\n" +
+				`{"status":"failed","error":"internal error"}` + "\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := &ShortenImportSrvStub{chunks: tt.chunks, importErr: tt.importErr}
+			h := HandleAPIShortenImport(srv, zap.NewNop())
+
+			request := httptest.NewRequest(http.MethodPost, "/api/shorten/import", strings.NewReader("original_url\nhttps://a.com\n"))
+			request.Header.Set("Content-Type", tt.contentType)
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, request)
+			res := w.Result()
+			defer res.Body.Close()
+
+			require.Equal(t, tt.wantCode, res.StatusCode)
+			assert.Equal(t, tt.wantFormat, srv.gotFormat)
+			if tt.wantBody == "" {
+				return
+			}
+			assert.Equal(t, "application/x-ndjson", res.Header.Get("Content-Type"))
+			assert.True(t, w.Flushed)
+			body, err := io.ReadAll(res.Body)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantBody, string(body))
+		})
+	}
+}
@@ -81,6 +81,48 @@ func TestAPIShorten(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name:   "returns 400 (Bad Request) when alias is invalid",
+			method: http.MethodPost,
+			setupMockProc: func(m *mocks.MockAPIShortenProcessor) {
+				m.EXPECT().
+					Process(mock.Anything, mock.AnythingOfType("model.ShortenRequest")).
+					Return(nil, service.NewValidationError(service.ErrInvalidAlias, "reserved")).
+					Once()
+			},
+			want: want{
+				code: http.StatusBadRequest,
+			},
+			wantErr: true,
+		},
+		{
+			name:   "returns 409 (Conflict) when alias is already taken",
+			method: http.MethodPost,
+			setupMockProc: func(m *mocks.MockAPIShortenProcessor) {
+				m.EXPECT().
+					Process(mock.Anything, mock.AnythingOfType("model.ShortenRequest")).
+					Return(nil, service.ErrAliasTaken).
+					Once()
+			},
+			want: want{
+				code: http.StatusConflict,
+			},
+			wantErr: true,
+		},
+		{
+			name:   "returns 409 (Conflict) without short url when alias is requested for existing url",
+			method: http.MethodPost,
+			setupMockProc: func(m *mocks.MockAPIShortenProcessor) {
+				m.EXPECT().
+					Process(mock.Anything, mock.AnythingOfType("model.ShortenRequest")).
+					Return(nil, service.ErrAliasURLExists).
+					Once()
+			},
+			want: want{
+				code: http.StatusConflict,
+			},
+			wantErr: true,
+		},
 		{
 			name:   "returns 500 (Internal Server Error) when random error on shorten url",
 			method: http.MethodPost,
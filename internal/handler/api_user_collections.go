@@ -0,0 +1,361 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/alex-storchak/shortener/internal/codec"
+	"github.com/alex-storchak/shortener/internal/model"
+	"github.com/alex-storchak/shortener/internal/repository"
+	"github.com/alex-storchak/shortener/internal/service"
+)
+
+// APIUserCollectionsProcessor defines the interface for processing collection management operations.
+// It provides methods for listing, creating, renaming and deleting collections of user's links,
+// moving links between them, and listing, bulk deleting and exporting the links of a collection.
+type APIUserCollectionsProcessor interface {
+	ProcessGet(ctx context.Context) (model.UserCollectionsResponse, error)
+	ProcessCreate(ctx context.Context, req model.UserCollectionRequest) (*model.UserCollection, error)
+	ProcessRename(ctx context.Context, id string, req model.UserCollectionRequest) (*model.UserCollection, error)
+	ProcessDelete(ctx context.Context, id string) error
+	ProcessMove(ctx context.Context, req model.UserCollectionMoveRequest) error
+	ProcessGetURLs(ctx context.Context, id string) (model.UserURLsGetResponse, error)
+	ProcessDeleteURLs(ctx context.Context, id string) (*model.UserCollectionDeleteResponse, error)
+	ProcessExport(ctx context.Context, id, format string) (*model.CollectionExport, error)
+}
+
+// HandleGetUserCollections creates an HTTP handler for retrieving collections of the authenticated user.
+// It handles GET requests to '/api/user/collections' endpoint.
+//
+// The handler:
+//   - Retrieves collections sorted by name with the amount of live links in each
+//   - Returns appropriate HTTP status codes:
+//   - 200 OK with model.UserCollectionsResponse when collections are found
+//   - 204 No Content when user has no collections
+//   - 500 Internal Server Error for processing failures
+//
+// Parameters:
+//   - p: Processor implementing the collections retrieval logic
+//   - l: Logger for logging operations
+//
+// Returns:
+//   - HTTP handler function for the get user collections endpoint
+func HandleGetUserCollections(p APIUserCollectionsProcessor, l *zap.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		respItems, err := p.ProcessGet(r.Context())
+		if err != nil {
+			l.Error("error getting user collections", zap.Error(err))
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		} else if len(respItems) == 0 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		if err = codec.EasyJSONEncode(w, http.StatusOK, &respItems); err != nil {
+			l.Error("encode json response", zap.Error(err))
+			return
+		}
+	}
+}
+
+// HandleCreateUserCollection creates an HTTP handler for creating a collection of user's links.
+// It handles POST requests to '/api/user/collections' endpoint with JSON body containing the name.
+//
+// The handler:
+//   - Creates an empty collection
+//   - Returns appropriate HTTP status codes:
+//   - 201 Created with model.UserCollection for successful creation
+//   - 400 Bad Request for malformed JSON, malformed name or too many collections
+//   - 409 Conflict if the user already has a collection with the name
+//   - 500 Internal Server Error for processing failures
+//
+// Parameters:
+//   - p: Processor implementing the collection creation logic
+//   - l: Logger for logging operations
+//
+// Returns:
+//   - HTTP handler function for the create user collection endpoint
+func HandleCreateUserCollection(p APIUserCollectionsProcessor, l *zap.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req model.UserCollectionRequest
+		if err := codec.EasyJSONDecode(r, &req); err != nil {
+			l.Debug("decode json request", zap.Error(err))
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		c, err := p.ProcessCreate(r.Context(), req)
+		if isValidationError(err) {
+			writeBadRequest(w, err)
+			return
+		} else if errors.Is(err, service.ErrCollectionNameTaken) {
+			http.Error(w, service.ErrCollectionNameTaken.Error(), http.StatusConflict)
+			return
+		} else if err != nil {
+			l.Error("error creating user collection", zap.Error(err))
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if err = codec.EasyJSONEncode(w, http.StatusCreated, c); err != nil {
+			l.Error("encode json response", zap.Error(err))
+			return
+		}
+	}
+}
+
+// HandleRenameUserCollection creates an HTTP handler for renaming a collection of user's links.
+// It handles PATCH requests to '/api/user/collections/{id}' endpoint with JSON body containing the new name.
+//
+// The handler:
+//   - Renames the collection owned by the authenticated user
+//   - Returns appropriate HTTP status codes:
+//   - 200 OK with model.UserCollection for successful renaming
+//   - 400 Bad Request for malformed JSON or malformed name
+//   - 404 Not Found if the user has no such collection
+//   - 409 Conflict if the user already has another collection with the name
+//   - 500 Internal Server Error for processing failures
+//
+// Parameters:
+//   - p: Processor implementing the collection renaming logic
+//   - l: Logger for logging operations
+//
+// Returns:
+//   - HTTP handler function for the rename user collection endpoint
+func HandleRenameUserCollection(p APIUserCollectionsProcessor, l *zap.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req model.UserCollectionRequest
+		if err := codec.EasyJSONDecode(r, &req); err != nil {
+			l.Debug("decode json request", zap.Error(err))
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		c, err := p.ProcessRename(r.Context(), chi.URLParam(r, CollectionIDParam), req)
+		var nfErr *repository.DataNotFoundError
+		if isValidationError(err) {
+			writeBadRequest(w, err)
+			return
+		} else if errors.As(err, &nfErr) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		} else if errors.Is(err, service.ErrCollectionNameTaken) {
+			http.Error(w, service.ErrCollectionNameTaken.Error(), http.StatusConflict)
+			return
+		} else if err != nil {
+			l.Error("error renaming user collection", zap.Error(err))
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if err = codec.EasyJSONEncode(w, http.StatusOK, c); err != nil {
+			l.Error("encode json response", zap.Error(err))
+			return
+		}
+	}
+}
+
+// HandleDeleteUserCollection creates an HTTP handler for deleting a collection of user's links.
+// It handles DELETE requests to '/api/user/collections/{id}' endpoint.
+//
+// The handler:
+//   - Removes the collection; its links are kept and belong to no collection
+//   - Returns appropriate HTTP status codes:
+//   - 204 No Content for successful deletion
+//   - 404 Not Found if the user has no such collection
+//   - 500 Internal Server Error for processing failures
+//
+// Parameters:
+//   - p: Processor implementing the collection deletion logic
+//   - l: Logger for logging operations
+//
+// Returns:
+//   - HTTP handler function for the delete user collection endpoint
+func HandleDeleteUserCollection(p APIUserCollectionsProcessor, l *zap.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		err := p.ProcessDelete(r.Context(), chi.URLParam(r, CollectionIDParam))
+		var nfErr *repository.DataNotFoundError
+		if errors.As(err, &nfErr) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		} else if err != nil {
+			l.Error("error deleting user collection", zap.Error(err))
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// HandleMoveUserCollectionURLs creates an HTTP handler for moving user's links between collections.
+// It handles POST requests to '/api/user/collections/move' endpoint with JSON body containing
+// the target collection ID and the short IDs of the links; an empty collection ID takes the links
+// out of any collection.
+//
+// The handler:
+//   - Moves the links, taking them out of the collections they belonged to
+//   - Returns appropriate HTTP status codes:
+//   - 204 No Content for successful moving
+//   - 400 Bad Request for malformed JSON, no links or too many links
+//   - 404 Not Found if the user has no such collection or no live link with any of the short IDs
+//   - 500 Internal Server Error for processing failures
+//
+// Parameters:
+//   - p: Processor implementing the links moving logic
+//   - l: Logger for logging operations
+//
+// Returns:
+//   - HTTP handler function for the move user collection URLs endpoint
+func HandleMoveUserCollectionURLs(p APIUserCollectionsProcessor, l *zap.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req model.UserCollectionMoveRequest
+		if err := codec.EasyJSONDecode(r, &req); err != nil {
+			l.Debug("decode json request", zap.Error(err))
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		err := p.ProcessMove(r.Context(), req)
+		var nfErr *repository.DataNotFoundError
+		if isValidationError(err) {
+			writeBadRequest(w, err)
+			return
+		} else if errors.As(err, &nfErr) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		} else if err != nil {
+			l.Error("error moving user urls to collection", zap.Error(err))
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// HandleGetUserCollectionURLs creates an HTTP handler for retrieving the links in a collection of the user.
+// It handles GET requests to '/api/user/collections/{id}/urls' endpoint.
+//
+// The handler:
+//   - Retrieves the live links in the collection owned by the authenticated user
+//   - Returns appropriate HTTP status codes:
+//   - 200 OK with model.UserURLsGetResponse when the collection has links
+//   - 204 No Content when the collection is empty
+//   - 404 Not Found if the user has no such collection
+//   - 500 Internal Server Error for processing failures
+//
+// Parameters:
+//   - p: Processor implementing the collection links retrieval logic
+//   - l: Logger for logging operations
+//
+// Returns:
+//   - HTTP handler function for the get user collection URLs endpoint
+func HandleGetUserCollectionURLs(p APIUserCollectionsProcessor, l *zap.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		respItems, err := p.ProcessGetURLs(r.Context(), chi.URLParam(r, CollectionIDParam))
+		var nfErr *repository.DataNotFoundError
+		if errors.As(err, &nfErr) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		} else if err != nil {
+			l.Error("error getting user collection urls", zap.Error(err))
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		} else if len(respItems) == 0 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		if err = codec.EasyJSONEncode(w, http.StatusOK, &respItems); err != nil {
+			l.Error("encode json response", zap.Error(err))
+			return
+		}
+	}
+}
+
+// HandleDeleteUserCollectionURLs creates an HTTP handler for bulk deletion of the links in a collection.
+// It handles DELETE requests to '/api/user/collections/{id}/urls' endpoint.
+//
+// The handler:
+//   - Soft-deletes all live links in the collection owned by the authenticated user; the collection is kept
+//     and restored links come back into it
+//   - Returns appropriate HTTP status codes:
+//   - 200 OK with model.UserCollectionDeleteResponse for successful deletion
+//   - 404 Not Found if the user has no such collection
+//   - 500 Internal Server Error for processing failures
+//
+// Parameters:
+//   - p: Processor implementing the collection links deletion logic
+//   - l: Logger for logging operations
+//
+// Returns:
+//   - HTTP handler function for the delete user collection URLs endpoint
+func HandleDeleteUserCollectionURLs(p APIUserCollectionsProcessor, l *zap.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		resp, err := p.ProcessDeleteURLs(r.Context(), chi.URLParam(r, CollectionIDParam))
+		var nfErr *repository.DataNotFoundError
+		if errors.As(err, &nfErr) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		} else if err != nil {
+			l.Error("error deleting user collection urls", zap.Error(err))
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if err = codec.EasyJSONEncode(w, http.StatusOK, resp); err != nil {
+			l.Error("encode json response", zap.Error(err))
+			return
+		}
+	}
+}
+
+// HandleExportUserCollection creates an HTTP handler for exporting the links in a collection to a file.
+// It handles GET requests to '/api/user/collections/{id}/export' endpoint with optional 'format'
+// query parameter: 'csv' (default) or 'json'.
+//
+// The handler:
+//   - Renders the live links in the collection owned by the authenticated user
+//   - Returns appropriate HTTP status codes:
+//   - 200 OK with the file and a Content-Disposition header suggesting its name
+//   - 400 Bad Request for an unknown format
+//   - 404 Not Found if the user has no such collection
+//   - 500 Internal Server Error for processing failures
+//
+// Parameters:
+//   - p: Processor implementing the collection export logic
+//   - l: Logger for logging operations
+//
+// Returns:
+//   - HTTP handler function for the export user collection endpoint
+func HandleExportUserCollection(p APIUserCollectionsProcessor, l *zap.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		exp, err := p.ProcessExport(r.Context(), chi.URLParam(r, CollectionIDParam), r.URL.Query().Get("format"))
+		var nfErr *repository.DataNotFoundError
+		if isValidationError(err) {
+			writeBadRequest(w, err)
+			return
+		} else if errors.As(err, &nfErr) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		} else if err != nil {
+			l.Error("error exporting user collection", zap.Error(err))
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", exp.ContentType)
+		w.Header().Set("Content-Length", strconv.Itoa(len(exp.Data)))
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": exp.FileName}))
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write(exp.Data); err != nil {
+			l.Debug("write collection export", zap.Error(err))
+		}
+	}
+}
@@ -0,0 +1,312 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/alex-storchak/shortener/internal/model"
+	repo "github.com/alex-storchak/shortener/internal/repository"
+	"github.com/alex-storchak/shortener/internal/service"
+)
+
+type userCollectionsSrvStub struct {
+	err      error
+	gotID    string
+	gotFmt   string
+	urls     model.UserURLsGetResponse
+	exported *model.CollectionExport
+}
+
+func (s *userCollectionsSrvStub) ProcessGet(_ context.Context) (model.UserCollectionsResponse, error) {
+	return nil, s.err
+}
+
+func (s *userCollectionsSrvStub) ProcessCreate(_ context.Context, req model.UserCollectionRequest) (*model.UserCollection, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &model.UserCollection{ID: "c1", Name: req.Name, CreatedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)}, nil
+}
+
+func (s *userCollectionsSrvStub) ProcessRename(
+	_ context.Context,
+	id string,
+	req model.UserCollectionRequest,
+) (*model.UserCollection, error) {
+	s.gotID = id
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &model.UserCollection{ID: id, Name: req.Name, Count: 2}, nil
+}
+
+func (s *userCollectionsSrvStub) ProcessDelete(_ context.Context, id string) error {
+	s.gotID = id
+	return s.err
+}
+
+func (s *userCollectionsSrvStub) ProcessMove(_ context.Context, _ model.UserCollectionMoveRequest) error {
+	return s.err
+}
+
+func (s *userCollectionsSrvStub) ProcessGetURLs(_ context.Context, id string) (model.UserURLsGetResponse, error) {
+	s.gotID = id
+	return s.urls, s.err
+}
+
+func (s *userCollectionsSrvStub) ProcessDeleteURLs(_ context.Context, id string) (*model.UserCollectionDeleteResponse, error) {
+	s.gotID = id
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &model.UserCollectionDeleteResponse{Deleted: 3}, nil
+}
+
+func (s *userCollectionsSrvStub) ProcessExport(_ context.Context, id, format string) (*model.CollectionExport, error) {
+	s.gotID, s.gotFmt = id, format
+	return s.exported, s.err
+}
+
+func newUserCollectionsMux(srv APIUserCollectionsProcessor) *chi.Mux {
+	mux := chi.NewRouter()
+	mux.Route("/api/user/collections", func(mux chi.Router) {
+		mux.Get("/", HandleGetUserCollections(srv, zap.NewNop()))
+		mux.Post("/", HandleCreateUserCollection(srv, zap.NewNop()))
+		mux.Post("/move", HandleMoveUserCollectionURLs(srv, zap.NewNop()))
+		mux.Patch("/{id:[a-zA-Z0-9-]+}", HandleRenameUserCollection(srv, zap.NewNop()))
+		mux.Delete("/{id:[a-zA-Z0-9-]+}", HandleDeleteUserCollection(srv, zap.NewNop()))
+		mux.Get("/{id:[a-zA-Z0-9-]+}/urls", HandleGetUserCollectionURLs(srv, zap.NewNop()))
+		mux.Delete("/{id:[a-zA-Z0-9-]+}/urls", HandleDeleteUserCollectionURLs(srv, zap.NewNop()))
+		mux.Get("/{id:[a-zA-Z0-9-]+}/export", HandleExportUserCollection(srv, zap.NewNop()))
+	})
+	return mux
+}
+
+func TestUserCollections(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		target   string
+		body     string
+		srv      *userCollectionsSrvStub
+		wantCode int
+		wantBody string
+		wantID   string
+	}{
+		{
+			name:     "no collections return 204 (No Content)",
+			method:   http.MethodGet,
+			target:   "/api/user/collections",
+			srv:      &userCollectionsSrvStub{},
+			wantCode: http.StatusNoContent,
+		},
+		{
+			name:     "created collection returns 201 (Created) with json",
+			method:   http.MethodPost,
+			target:   "/api/user/collections",
+			body:     `{"name":"Work"}`,
+			srv:      &userCollectionsSrvStub{},
+			wantCode: http.StatusCreated,
+			wantBody: `{"id":"c1","name":"Work","created_at":"2026-01-02T03:04:05Z","count":0}`,
+		},
+		{
+			name:     "malformed json returns 400 (Bad Request)",
+			method:   http.MethodPost,
+			target:   "/api/user/collections",
+			body:     `{"name":`,
+			srv:      &userCollectionsSrvStub{},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "invalid name returns 400 (Bad Request)",
+			method:   http.MethodPost,
+			target:   "/api/user/collections",
+			body:     `{"name":" "}`,
+			srv:      &userCollectionsSrvStub{err: service.NewValidationError(service.ErrInvalidCollection, "name can't be empty")},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "taken name returns 409 (Conflict)",
+			method:   http.MethodPost,
+			target:   "/api/user/collections",
+			body:     `{"name":"Work"}`,
+			srv:      &userCollectionsSrvStub{err: service.ErrCollectionNameTaken},
+			wantCode: http.StatusConflict,
+		},
+		{
+			name:     "renamed collection returns 200 (OK) with json",
+			method:   http.MethodPatch,
+			target:   "/api/user/collections/c1",
+			body:     `{"name":"Blog"}`,
+			srv:      &userCollectionsSrvStub{},
+			wantCode: http.StatusOK,
+			wantBody: `{"id":"c1","name":"Blog","created_at":"0001-01-01T00:00:00Z","count":2}`,
+			wantID:   "c1",
+		},
+		{
+			name:     "renaming collection of another user returns 404 (Not Found)",
+			method:   http.MethodPatch,
+			target:   "/api/user/collections/c1",
+			body:     `{"name":"Blog"}`,
+			srv:      &userCollectionsSrvStub{err: repo.NewDataNotFoundError(nil)},
+			wantCode: http.StatusNotFound,
+			wantID:   "c1",
+		},
+		{
+			name:     "deleted collection returns 204 (No Content)",
+			method:   http.MethodDelete,
+			target:   "/api/user/collections/c1",
+			srv:      &userCollectionsSrvStub{},
+			wantCode: http.StatusNoContent,
+			wantID:   "c1",
+		},
+		{
+			name:     "moved links return 204 (No Content)",
+			method:   http.MethodPost,
+			target:   "/api/user/collections/move",
+			body:     `{"collection_id":"c1","short_urls":["abcde"]}`,
+			srv:      &userCollectionsSrvStub{},
+			wantCode: http.StatusNoContent,
+		},
+		{
+			name:     "moving link of another user returns 404 (Not Found)",
+			method:   http.MethodPost,
+			target:   "/api/user/collections/move",
+			body:     `{"collection_id":"c1","short_urls":["abcde"]}`,
+			srv:      &userCollectionsSrvStub{err: repo.NewDataNotFoundError(nil)},
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "moving no links returns 400 (Bad Request)",
+			method:   http.MethodPost,
+			target:   "/api/user/collections/move",
+			body:     `{"collection_id":"c1"}`,
+			srv:      &userCollectionsSrvStub{err: service.NewValidationError(service.ErrInvalidCollection, "no links to move")},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:   "links of collection return 200 (OK) with json",
+			method: http.MethodGet,
+			target: "/api/user/collections/c1/urls",
+			srv: &userCollectionsSrvStub{urls: model.UserURLsGetResponse{
+				{ShortURL: "http://localhost:8080/abcde", OrigURL: "http://a.com"},
+			}},
+			wantCode: http.StatusOK,
+			wantBody: `[{"short_url":"http://localhost:8080/abcde","original_url":"http://a.com"}]`,
+			wantID:   "c1",
+		},
+		{
+			name:     "empty collection returns 204 (No Content)",
+			method:   http.MethodGet,
+			target:   "/api/user/collections/c1/urls",
+			srv:      &userCollectionsSrvStub{},
+			wantCode: http.StatusNoContent,
+			wantID:   "c1",
+		},
+		{
+			name:     "bulk deletion returns 200 (OK) with amount of deleted links",
+			method:   http.MethodDelete,
+			target:   "/api/user/collections/c1/urls",
+			srv:      &userCollectionsSrvStub{},
+			wantCode: http.StatusOK,
+			wantBody: `{"deleted":3}`,
+			wantID:   "c1",
+		},
+		{
+			name:     "bulk deletion in collection of another user returns 404 (Not Found)",
+			method:   http.MethodDelete,
+			target:   "/api/user/collections/c1/urls",
+			srv:      &userCollectionsSrvStub{err: repo.NewDataNotFoundError(nil)},
+			wantCode: http.StatusNotFound,
+			wantID:   "c1",
+		},
+		{
+			name:     "random error returns 500 (Internal Server Error)",
+			method:   http.MethodDelete,
+			target:   "/api/user/collections/c1/urls",
+			srv:      &userCollectionsSrvStub{err: errors.New("random error")},
+			wantCode: http.StatusInternalServerError,
+			wantID:   "c1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			newUserCollectionsMux(tt.srv).ServeHTTP(w, httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body)))
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, tt.wantCode, res.StatusCode)
+			assert.Equal(t, tt.wantID, tt.srv.gotID)
+			if tt.wantBody != "" {
+				body, err := io.ReadAll(res.Body)
+				require.NoError(t, err)
+				assert.JSONEq(t, tt.wantBody, string(body))
+			}
+		})
+	}
+}
+
+func TestExportUserCollection(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		err      error
+		wantCode int
+	}{
+		{
+			name:     "export returns 200 (OK) with file",
+			query:    "?format=csv",
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "unknown format returns 400 (Bad Request)",
+			query:    "?format=xml",
+			err:      service.NewValidationError(service.ErrInvalidCollection, "export format `xml` must be csv or json"),
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "collection of another user returns 404 (Not Found)",
+			err:      repo.NewDataNotFoundError(nil),
+			wantCode: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := &userCollectionsSrvStub{err: tt.err}
+			if tt.err == nil {
+				srv.exported = &model.CollectionExport{
+					Data: []byte("short_url\n"), ContentType: "text/csv; charset=utf-8", FileName: "My links.csv",
+				}
+			}
+			w := httptest.NewRecorder()
+			newUserCollectionsMux(srv).ServeHTTP(w,
+				httptest.NewRequest(http.MethodGet, "/api/user/collections/c1/export"+tt.query, nil))
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, tt.wantCode, res.StatusCode)
+			assert.Equal(t, "c1", srv.gotID)
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+			assert.Equal(t, "csv", srv.gotFmt)
+			assert.Equal(t, "text/csv; charset=utf-8", res.Header.Get("Content-Type"))
+			assert.Equal(t, `attachment; filename="My links.csv"`, res.Header.Get("Content-Disposition"))
+			body, err := io.ReadAll(res.Body)
+			require.NoError(t, err)
+			assert.Equal(t, "short_url\n", string(body))
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/alex-storchak/shortener/internal/codec"
+	"github.com/alex-storchak/shortener/internal/model"
+	"github.com/alex-storchak/shortener/internal/repository"
+	"github.com/alex-storchak/shortener/internal/service"
+)
+
+// APIUserSplitProcessor defines the interface for processing split test management operations.
+// It provides methods for retrieving and replacing A/B split tests of user's short URLs.
+type APIUserSplitProcessor interface {
+	ProcessGet(ctx context.Context, shortID string) (*model.UserSplit, error)
+	ProcessSet(ctx context.Context, shortID string, req *model.UserSplit) (*model.UserSplit, error)
+}
+
+// HandleGetUserURLSplit creates an HTTP handler for retrieving the A/B split test of user's short URL.
+// It handles GET requests to '/api/user/urls/{id}/split' endpoint.
+//
+// The handler:
+//   - Retrieves the split test of the short URL owned by the authenticated user
+//   - Returns appropriate HTTP status codes:
+//   - 200 OK with model.UserSplit when the short URL has a split test
+//   - 204 No Content when the short URL has no split test
+//   - 404 Not Found if the user has no such short URL
+//   - 410 Gone if the short URL is deleted, expired or has no follows left
+//   - 500 Internal Server Error for processing failures
+//
+// Parameters:
+//   - p: Processor implementing the split test retrieval logic
+//   - l: Logger for logging operations
+//
+// Returns:
+//   - HTTP handler function for the get user URL split endpoint
+func HandleGetUserURLSplit(p APIUserSplitProcessor, l *zap.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		shortID := chi.URLParam(r, ShortIDParam)
+		split, err := p.ProcessGet(r.Context(), shortID)
+		var nfErr *repository.DataNotFoundError
+		if errors.As(err, &nfErr) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		} else if isGoneError(err) {
+			w.WriteHeader(http.StatusGone)
+			return
+		} else if err != nil {
+			l.Error("error getting user url split test", zap.Error(err))
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		} else if len(split.Variants) == 0 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		if err = codec.EasyJSONEncode(w, http.StatusOK, split); err != nil {
+			l.Error("encode json response", zap.Error(err))
+			return
+		}
+	}
+}
+
+// HandleSetUserURLSplit creates an HTTP handler for replacing the A/B split test of user's short URL.
+// It handles PUT requests to '/api/user/urls/{id}/split' endpoint with JSON body containing
+// the weighted variants of the destination; every follow of the short URL not matching its targeting rules
+// is sent to a variant picked at random by weight, or to the variant assigned before for sticky split tests.
+//
+// The handler:
+//   - Replaces the split test of the short URL owned by the authenticated user; no variants remove it
+//   - Returns appropriate HTTP status codes:
+//   - 200 OK with model.UserSplit for successful storing
+//   - 204 No Content when the split test is removed
+//   - 400 Bad Request for malformed JSON, too few or too many variants, malformed or duplicate names,
+//     weights out of range or invalid URLs
+//   - 404 Not Found if the user has no such short URL
+//   - 410 Gone if the short URL is deleted, expired or has no follows left
+//   - 451 Unavailable For Legal Reasons if the URL of a variant matches the blocklist
+//   - 500 Internal Server Error for processing failures
+//
+// Parameters:
+//   - p: Processor implementing the split test storing logic
+//   - l: Logger for logging operations
+//
+// Returns:
+//   - HTTP handler function for the set user URL split endpoint
+func HandleSetUserURLSplit(p APIUserSplitProcessor, l *zap.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req model.UserSplit
+		if err := codec.EasyJSONDecode(r, &req); err != nil {
+			l.Debug("decode json request", zap.Error(err))
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		shortID := chi.URLParam(r, ShortIDParam)
+		split, err := p.ProcessSet(r.Context(), shortID, &req)
+		var nfErr *repository.DataNotFoundError
+		if isValidationError(err) {
+			writeBadRequest(w, err)
+			return
+		} else if errors.As(err, &nfErr) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		} else if isGoneError(err) {
+			w.WriteHeader(http.StatusGone)
+			return
+		} else if errors.Is(err, service.ErrURLBlocked) {
+			writeBlocked(w)
+			return
+		} else if err != nil {
+			l.Error("error setting user url split test", zap.Error(err))
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		} else if len(split.Variants) == 0 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		if err = codec.EasyJSONEncode(w, http.StatusOK, split); err != nil {
+			l.Error("encode json response", zap.Error(err))
+			return
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/alex-storchak/shortener/internal/model"
+	repo "github.com/alex-storchak/shortener/internal/repository"
+	"github.com/alex-storchak/shortener/internal/service"
+)
+
+type userSplitSrvStub struct {
+	split  *model.UserSplit
+	getErr error
+	setErr error
+	gotID  string
+}
+
+func (s *userSplitSrvStub) ProcessGet(_ context.Context, shortID string) (*model.UserSplit, error) {
+	s.gotID = shortID
+	if s.getErr != nil {
+		return nil, s.getErr
+	}
+	if s.split == nil {
+		return &model.UserSplit{}, nil
+	}
+	return s.split, nil
+}
+
+func (s *userSplitSrvStub) ProcessSet(_ context.Context, shortID string, req *model.UserSplit) (*model.UserSplit, error) {
+	s.gotID = shortID
+	if s.setErr != nil {
+		return nil, s.setErr
+	}
+	return req, nil
+}
+
+func TestGetUserURLSplit(t *testing.T) {
+	tests := []struct {
+		name     string
+		split    *model.UserSplit
+		getErr   error
+		wantCode int
+		wantBody string
+	}{
+		{
+			name: "split test returns 200 (OK) with json",
+			split: &model.UserSplit{Sticky: true, Variants: []model.UserSplitVariant{
+				{Name: "a", URL: "https://example.com/a", Weight: 70},
+				{Name: "b", URL: "https://example.com/b", Weight: 30},
+			}},
+			wantCode: http.StatusOK,
+			wantBody: `{"sticky":true,"variants":[{"name":"a","url":"https://example.com/a","weight":70},{"name":"b","url":"https://example.com/b","weight":30}]}`,
+		},
+		{
+			name:     "no split test returns 204 (No Content)",
+			wantCode: http.StatusNoContent,
+		},
+		{
+			name:     "short url of another user returns 404 (Not Found)",
+			getErr:   repo.NewDataNotFoundError(nil),
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "deleted short url returns 410 (Gone)",
+			getErr:   repo.ErrDataDeleted,
+			wantCode: http.StatusGone,
+		},
+		{
+			name:     "random error returns 500 (Internal Server Error)",
+			getErr:   errors.New("random error"),
+			wantCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := &userSplitSrvStub{split: tt.split, getErr: tt.getErr}
+			mux := chi.NewRouter()
+			mux.Get("/api/user/urls/{id}/split", HandleGetUserURLSplit(srv, zap.NewNop()))
+
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/user/urls/abcde/split", nil))
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, tt.wantCode, res.StatusCode)
+			assert.Equal(t, "abcde", srv.gotID)
+			if tt.wantBody != "" {
+				body, err := io.ReadAll(res.Body)
+				require.NoError(t, err)
+				assert.JSONEq(t, tt.wantBody, string(body))
+			}
+		})
+	}
+}
+
+func TestSetUserURLSplit(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		setErr   error
+		wantCode int
+		wantBody string
+	}{
+		{
+			name:     "stored split test returns 200 (OK) with json",
+			body:     `{"variants":[{"url":"https://example.com/a","weight":1},{"url":"https://example.com/b","weight":1}]}`,
+			wantCode: http.StatusOK,
+			wantBody: `{"variants":[{"url":"https://example.com/a","weight":1},{"url":"https://example.com/b","weight":1}]}`,
+		},
+		{
+			name:     "removed split test returns 204 (No Content)",
+			body:     `{"variants":[]}`,
+			wantCode: http.StatusNoContent,
+		},
+		{
+			name:     "malformed json returns 400 (Bad Request)",
+			body:     `[{"url":"https://example.com/a"}]`,
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "invalid variant returns 400 (Bad Request)",
+			body:     `{"variants":[{"url":"https://example.com/a","weight":0}]}`,
+			setErr:   service.NewValidationError(service.ErrInvalidSplit, "from 2 to 10 variants are required"),
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "short url of another user returns 404 (Not Found)",
+			body:     `{"variants":[{"url":"https://example.com/a","weight":1},{"url":"https://example.com/b","weight":1}]}`,
+			setErr:   repo.NewDataNotFoundError(nil),
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "expired short url returns 410 (Gone)",
+			body:     `{"variants":[{"url":"https://example.com/a","weight":1},{"url":"https://example.com/b","weight":1}]}`,
+			setErr:   repo.ErrDataExpired,
+			wantCode: http.StatusGone,
+		},
+		{
+			name:     "blocked variant url returns 451 (Unavailable For Legal Reasons)",
+			body:     `{"variants":[{"url":"https://example.com/a","weight":1},{"url":"https://evil.com","weight":1}]}`,
+			setErr:   &service.BlockedURLError{OrigURL: "https://evil.com", Rule: "evil.com"},
+			wantCode: http.StatusUnavailableForLegalReasons,
+		},
+		{
+			name:     "random error returns 500 (Internal Server Error)",
+			body:     `{"variants":[{"url":"https://example.com/a","weight":1},{"url":"https://example.com/b","weight":1}]}`,
+			setErr:   errors.New("random error"),
+			wantCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := &userSplitSrvStub{setErr: tt.setErr}
+			mux := chi.NewRouter()
+			mux.Put("/api/user/urls/{id}/split", HandleSetUserURLSplit(srv, zap.NewNop()))
+
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/api/user/urls/abcde/split", strings.NewReader(tt.body)))
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, tt.wantCode, res.StatusCode)
+			if tt.wantBody != "" {
+				body, err := io.ReadAll(res.Body)
+				require.NoError(t, err)
+				assert.JSONEq(t, tt.wantBody, string(body))
+			}
+		})
+	}
+}
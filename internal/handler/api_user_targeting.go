@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/alex-storchak/shortener/internal/codec"
+	"github.com/alex-storchak/shortener/internal/model"
+	"github.com/alex-storchak/shortener/internal/repository"
+	"github.com/alex-storchak/shortener/internal/service"
+)
+
+// APIUserTargetingProcessor defines the interface for processing targeting rule management operations.
+// It provides methods for retrieving and replacing targeting rules of user's short URLs.
+type APIUserTargetingProcessor interface {
+	ProcessGet(ctx context.Context, shortID string) (model.UserTargetingRules, error)
+	ProcessSet(ctx context.Context, shortID string, req model.UserTargetingRules) (model.UserTargetingRules, error)
+}
+
+// HandleGetUserURLRules creates an HTTP handler for retrieving targeting rules of user's short URL.
+// It handles GET requests to '/api/user/urls/{id}/rules' endpoint.
+//
+// The handler:
+//   - Retrieves the rules of the short URL owned by the authenticated user in evaluation order
+//   - Returns appropriate HTTP status codes:
+//   - 200 OK with model.UserTargetingRules when the short URL has rules
+//   - 204 No Content when the short URL has no rules
+//   - 404 Not Found if the user has no such short URL
+//   - 410 Gone if the short URL is deleted, expired or has no follows left
+//   - 500 Internal Server Error for processing failures
+//
+// Parameters:
+//   - p: Processor implementing the targeting rules retrieval logic
+//   - l: Logger for logging operations
+//
+// Returns:
+//   - HTTP handler function for the get user URL rules endpoint
+func HandleGetUserURLRules(p APIUserTargetingProcessor, l *zap.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		shortID := chi.URLParam(r, ShortIDParam)
+		rules, err := p.ProcessGet(r.Context(), shortID)
+		var nfErr *repository.DataNotFoundError
+		if errors.As(err, &nfErr) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		} else if isGoneError(err) {
+			w.WriteHeader(http.StatusGone)
+			return
+		} else if err != nil {
+			l.Error("error getting user url rules", zap.Error(err))
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		} else if len(rules) == 0 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		if err = codec.EasyJSONEncode(w, http.StatusOK, &rules); err != nil {
+			l.Error("encode json response", zap.Error(err))
+			return
+		}
+	}
+}
+
+// HandleSetUserURLRules creates an HTTP handler for replacing targeting rules of user's short URL.
+// It handles PUT requests to '/api/user/urls/{id}/rules' endpoint with JSON body containing
+// the ordered list of rules; the first rule matching the User-Agent family, the operating system,
+// the most preferred language of Accept-Language and a header of a follow picks its destination.
+//
+// The handler:
+//   - Replaces the rules of the short URL owned by the authenticated user; an empty list removes them
+//   - Returns appropriate HTTP status codes:
+//   - 200 OK with model.UserTargetingRules for successful storing
+//   - 204 No Content when the rules are removed
+//   - 400 Bad Request for malformed JSON, too many rules, rules without conditions, malformed conditions
+//     or invalid URLs
+//   - 404 Not Found if the user has no such short URL
+//   - 410 Gone if the short URL is deleted, expired or has no follows left
+//   - 451 Unavailable For Legal Reasons if the URL of a rule matches the blocklist
+//   - 500 Internal Server Error for processing failures
+//
+// Parameters:
+//   - p: Processor implementing the targeting rules storing logic
+//   - l: Logger for logging operations
+//
+// Returns:
+//   - HTTP handler function for the set user URL rules endpoint
+func HandleSetUserURLRules(p APIUserTargetingProcessor, l *zap.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req model.UserTargetingRules
+		if err := codec.EasyJSONDecode(r, &req); err != nil {
+			l.Debug("decode json request", zap.Error(err))
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		shortID := chi.URLParam(r, ShortIDParam)
+		rules, err := p.ProcessSet(r.Context(), shortID, req)
+		var nfErr *repository.DataNotFoundError
+		if isValidationError(err) {
+			writeBadRequest(w, err)
+			return
+		} else if errors.As(err, &nfErr) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		} else if isGoneError(err) {
+			w.WriteHeader(http.StatusGone)
+			return
+		} else if errors.Is(err, service.ErrURLBlocked) {
+			writeBlocked(w)
+			return
+		} else if err != nil {
+			l.Error("error setting user url rules", zap.Error(err))
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		} else if len(rules) == 0 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		if err = codec.EasyJSONEncode(w, http.StatusOK, &rules); err != nil {
+			l.Error("encode json response", zap.Error(err))
+			return
+		}
+	}
+}
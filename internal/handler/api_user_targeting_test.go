@@ -0,0 +1,179 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/alex-storchak/shortener/internal/model"
+	repo "github.com/alex-storchak/shortener/internal/repository"
+	"github.com/alex-storchak/shortener/internal/service"
+)
+
+type userTargetingSrvStub struct {
+	rules  model.UserTargetingRules
+	getErr error
+	setErr error
+	gotID  string
+}
+
+func (s *userTargetingSrvStub) ProcessGet(_ context.Context, shortID string) (model.UserTargetingRules, error) {
+	s.gotID = shortID
+	return s.rules, s.getErr
+}
+
+func (s *userTargetingSrvStub) ProcessSet(
+	_ context.Context,
+	shortID string,
+	req model.UserTargetingRules,
+) (model.UserTargetingRules, error) {
+	s.gotID = shortID
+	if s.setErr != nil {
+		return nil, s.setErr
+	}
+	return req, nil
+}
+
+func TestGetUserURLRules(t *testing.T) {
+	tests := []struct {
+		name     string
+		rules    model.UserTargetingRules
+		getErr   error
+		wantCode int
+		wantBody string
+	}{
+		{
+			name: "rules return 200 (OK) with json",
+			rules: model.UserTargetingRules{
+				{OS: "ios", URL: "https://apps.apple.com/app"},
+				{OS: "android", Language: "de", URL: "https://play.google.com/app"},
+			},
+			wantCode: http.StatusOK,
+			wantBody: `[{"os":"ios","url":"https://apps.apple.com/app"},{"os":"android","language":"de","url":"https://play.google.com/app"}]`,
+		},
+		{
+			name:     "no rules return 204 (No Content)",
+			wantCode: http.StatusNoContent,
+		},
+		{
+			name:     "short url of another user returns 404 (Not Found)",
+			getErr:   repo.NewDataNotFoundError(nil),
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "deleted short url returns 410 (Gone)",
+			getErr:   repo.ErrDataDeleted,
+			wantCode: http.StatusGone,
+		},
+		{
+			name:     "random error returns 500 (Internal Server Error)",
+			getErr:   errors.New("random error"),
+			wantCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := &userTargetingSrvStub{rules: tt.rules, getErr: tt.getErr}
+			mux := chi.NewRouter()
+			mux.Get("/api/user/urls/{id}/rules", HandleGetUserURLRules(srv, zap.NewNop()))
+
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/user/urls/abcde/rules", nil))
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, tt.wantCode, res.StatusCode)
+			assert.Equal(t, "abcde", srv.gotID)
+			if tt.wantBody != "" {
+				body, err := io.ReadAll(res.Body)
+				require.NoError(t, err)
+				assert.JSONEq(t, tt.wantBody, string(body))
+			}
+		})
+	}
+}
+
+func TestSetUserURLRules(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		setErr   error
+		wantCode int
+		wantBody string
+	}{
+		{
+			name:     "stored rules return 200 (OK) with json",
+			body:     `[{"os":"ios","url":"https://apps.apple.com/app"}]`,
+			wantCode: http.StatusOK,
+			wantBody: `[{"os":"ios","url":"https://apps.apple.com/app"}]`,
+		},
+		{
+			name:     "removed rules return 204 (No Content)",
+			body:     `[]`,
+			wantCode: http.StatusNoContent,
+		},
+		{
+			name:     "malformed json returns 400 (Bad Request)",
+			body:     `{"os":"ios"}`,
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "invalid rule returns 400 (Bad Request)",
+			body:     `[{"url":"https://a.com"}]`,
+			setErr:   service.NewValidationError(service.ErrInvalidTargetingRule, "rule 1: at least one condition must be set"),
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "short url of another user returns 404 (Not Found)",
+			body:     `[{"os":"ios","url":"https://apps.apple.com/app"}]`,
+			setErr:   repo.NewDataNotFoundError(nil),
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "expired short url returns 410 (Gone)",
+			body:     `[{"os":"ios","url":"https://apps.apple.com/app"}]`,
+			setErr:   repo.ErrDataExpired,
+			wantCode: http.StatusGone,
+		},
+		{
+			name:     "blocked rule url returns 451 (Unavailable For Legal Reasons)",
+			body:     `[{"os":"ios","url":"https://evil.com"}]`,
+			setErr:   &service.BlockedURLError{OrigURL: "https://evil.com", Rule: "evil.com"},
+			wantCode: http.StatusUnavailableForLegalReasons,
+		},
+		{
+			name:     "random error returns 500 (Internal Server Error)",
+			body:     `[{"os":"ios","url":"https://apps.apple.com/app"}]`,
+			setErr:   errors.New("random error"),
+			wantCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := &userTargetingSrvStub{setErr: tt.setErr}
+			mux := chi.NewRouter()
+			mux.Put("/api/user/urls/{id}/rules", HandleSetUserURLRules(srv, zap.NewNop()))
+
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/api/user/urls/abcde/rules", strings.NewReader(tt.body)))
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, tt.wantCode, res.StatusCode)
+			if tt.wantBody != "" {
+				body, err := io.ReadAll(res.Body)
+				require.NoError(t, err)
+				assert.JSONEq(t, tt.wantBody, string(body))
+			}
+		})
+	}
+}
@@ -2,29 +2,46 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"net/http"
 
+	"github.com/go-chi/chi/v5"
 	"go.uber.org/zap"
 
 	"github.com/alex-storchak/shortener/internal/codec"
 	"github.com/alex-storchak/shortener/internal/model"
+	"github.com/alex-storchak/shortener/internal/repository"
+	"github.com/alex-storchak/shortener/internal/service"
 )
 
 // APIUserURLsProcessor defines the interface for processing user URL management operations.
-// It provides methods for retrieving user's URLs and batch deletion of URLs.
+// It provides methods for retrieving user's URLs and tags, changing their destination and tags,
+// managing their version history, batch deletion of URLs and their restoration.
 type APIUserURLsProcessor interface {
-	ProcessGet(ctx context.Context) (model.UserURLsGetResponse, error)
+	ProcessGet(ctx context.Context, tags []string) (model.UserURLsGetResponse, error)
+	ProcessGetTags(ctx context.Context) (model.UserTagsResponse, error)
+	ProcessUpdate(ctx context.Context, shortID string, req model.UserURLUpdateRequest) (*model.UserURLsGetResponseItem, error)
+	ProcessGetHistory(ctx context.Context, shortID string) (model.UserURLHistoryResponse, error)
+	ProcessRestoreVersion(
+		ctx context.Context,
+		shortID string,
+		req model.UserURLRestoreVersionRequest,
+	) (*model.UserURLsGetResponseItem, error)
 	ProcessDelete(ctx context.Context, shortIDs model.UserURLsDelRequest) error
+	ProcessGetTrash(ctx context.Context) (model.UserURLsGetResponse, error)
+	ProcessRestore(ctx context.Context, shortIDs model.UserURLsRestoreRequest) (model.UserURLsRestoreResponse, error)
 }
 
-// HandleGetUserURLs creates an HTTP handler for retrieving all URLs shortened by the authenticated user.
-// It handles GET requests to '/api/user/urls' endpoint.
+// HandleGetUserURLs creates an HTTP handler for retrieving URLs shortened by the authenticated user.
+// It handles GET requests to '/api/user/urls' endpoint. Repeated 'tag' query parameters
+// keep only URLs carrying all the given tags.
 //
 // The handler:
-//   - Retrieves all URLs belonging to the authenticated user
+//   - Retrieves URLs belonging to the authenticated user
 //   - Returns appropriate HTTP status codes:
 //   - 200 OK with model.UserURLsGetResponse when URLs are found
-//   - 204 No Content when user has no URLs
+//   - 204 No Content when user has no matching URLs
+//   - 400 Bad Request for invalid tags
 //   - 500 Internal Server Error for processing failures
 //
 // Parameters:
@@ -35,8 +52,11 @@ type APIUserURLsProcessor interface {
 //   - HTTP handler function for the get user URLs endpoint
 func HandleGetUserURLs(p APIUserURLsProcessor, l *zap.Logger) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		respItems, err := p.ProcessGet(r.Context())
-		if err != nil {
+		respItems, err := p.ProcessGet(r.Context(), r.URL.Query()["tag"])
+		if isValidationError(err) {
+			writeBadRequest(w, err)
+			return
+		} else if err != nil {
 			l.Error("error getting user urls", zap.Error(err))
 			w.WriteHeader(http.StatusInternalServerError)
 			return
@@ -52,6 +72,41 @@ func HandleGetUserURLs(p APIUserURLsProcessor, l *zap.Logger) http.HandlerFunc {
 	}
 }
 
+// HandleGetUserTags creates an HTTP handler for retrieving the tags of the authenticated user's URLs.
+// It handles GET requests to '/api/user/tags' endpoint.
+//
+// The handler:
+//   - Retrieves tags of the user's URLs with the amount of URLs labeled with each
+//   - Returns appropriate HTTP status codes:
+//   - 200 OK with model.UserTagsResponse when tags are found
+//   - 204 No Content when user has no tags
+//   - 500 Internal Server Error for processing failures
+//
+// Parameters:
+//   - p: Processor implementing the user tags retrieval logic
+//   - l: Logger for logging operations
+//
+// Returns:
+//   - HTTP handler function for the get user tags endpoint
+func HandleGetUserTags(p APIUserURLsProcessor, l *zap.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		respItems, err := p.ProcessGetTags(r.Context())
+		if err != nil {
+			l.Error("error getting user tags", zap.Error(err))
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		} else if len(respItems) == 0 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		if err = codec.EasyJSONEncode(w, http.StatusOK, &respItems); err != nil {
+			l.Error("encode json response", zap.Error(err))
+			return
+		}
+	}
+}
+
 // HandleDeleteUserURLs creates an HTTP handler for batch deletion of user's URLs.
 // It handles DELETE requests to '/api/user/urls' endpoint
 // with JSON body containing short IDs to delete.
@@ -89,3 +144,226 @@ func HandleDeleteUserURLs(p APIUserURLsProcessor, l *zap.Logger) http.HandlerFun
 		w.WriteHeader(http.StatusAccepted)
 	}
 }
+
+// HandleGetUserTrash creates an HTTP handler for retrieving soft-deleted URLs of the authenticated user.
+// It handles GET requests to '/api/user/urls/trash' endpoint.
+//
+// The handler:
+//   - Retrieves all deleted URLs belonging to the authenticated user
+//   - Returns appropriate HTTP status codes:
+//   - 200 OK with model.UserURLsGetResponse when deleted URLs are found
+//   - 204 No Content when the trash is empty
+//   - 500 Internal Server Error for processing failures
+//
+// Parameters:
+//   - p: Processor implementing the user trash retrieval logic
+//   - l: Logger for logging operations
+//
+// Returns:
+//   - HTTP handler function for the user trash endpoint
+func HandleGetUserTrash(p APIUserURLsProcessor, l *zap.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		respItems, err := p.ProcessGetTrash(r.Context())
+		if err != nil {
+			l.Error("error getting user trash", zap.Error(err))
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		} else if len(respItems) == 0 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		if err = codec.EasyJSONEncode(w, http.StatusOK, &respItems); err != nil {
+			l.Error("encode json response", zap.Error(err))
+			return
+		}
+	}
+}
+
+// HandleRestoreUserURLs creates an HTTP handler for batch restoration of user's soft-deleted URLs.
+// It handles POST requests to '/api/user/urls/restore' endpoint
+// with JSON body containing short IDs to restore.
+//
+// The handler:
+//   - Restores the requested URLs synchronously and reports the outcome for each of them
+//   - Returns appropriate HTTP status codes:
+//   - 200 OK with model.UserURLsRestoreResponse
+//   - 400 Bad Request for malformed JSON or empty list
+//   - 500 Internal Server Error for processing failures
+//
+// Parameters:
+//   - p: Processor implementing the restoration logic
+//   - l: Logger for logging operations
+//
+// Returns:
+//   - HTTP handler function for the restore user URLs endpoint
+func HandleRestoreUserURLs(p APIUserURLsProcessor, l *zap.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var shortIDs model.UserURLsRestoreRequest
+		if err := codec.EasyJSONDecode(r, &shortIDs); err != nil {
+			l.Debug("decode json request", zap.Error(err))
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		resp, err := p.ProcessRestore(r.Context(), shortIDs)
+		if errors.Is(err, service.ErrEmptyInputBatch) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		} else if err != nil {
+			l.Error("error restoring user urls", zap.Error(err))
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if err = codec.EasyJSONEncode(w, http.StatusOK, &resp); err != nil {
+			l.Error("encode json response", zap.Error(err))
+			return
+		}
+	}
+}
+
+// HandleUpdateUserURL creates an HTTP handler for changing the destination and tags of user's short URL.
+// It handles PATCH requests to '/api/user/urls/{id}' endpoint
+// with JSON body containing the new original URL and/or the new tags.
+//
+// The handler:
+//   - Changes the original URL and/or replaces the tags of the short URL owned by the authenticated user
+//   - Returns appropriate HTTP status codes:
+//   - 200 OK with model.UserURLsGetResponseItem for successful update
+//   - 400 Bad Request for malformed JSON, empty URL or invalid tags
+//   - 404 Not Found if the user has no such short URL
+//   - 409 Conflict if the new URL is already shortened
+//   - 410 Gone if the short URL is deleted, expired or has no follows left
+//   - 451 Unavailable For Legal Reasons if the new URL matches the blocklist
+//   - 500 Internal Server Error for processing failures
+//
+// Parameters:
+//   - p: Processor implementing the user URL update logic
+//   - l: Logger for logging operations
+//
+// Returns:
+//   - HTTP handler function for the update user URL endpoint
+func HandleUpdateUserURL(p APIUserURLsProcessor, l *zap.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req model.UserURLUpdateRequest
+		if err := codec.EasyJSONDecode(r, &req); err != nil {
+			l.Debug("decode json request", zap.Error(err))
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		shortID := chi.URLParam(r, ShortIDParam)
+		item, err := p.ProcessUpdate(r.Context(), shortID, req)
+		writeUpdatedUserURL(w, item, err, l)
+	}
+}
+
+// HandleGetUserURLHistory creates an HTTP handler for retrieving the version history of user's short URL.
+// It handles GET requests to '/api/user/urls/{id}/history' endpoint.
+//
+// The handler:
+//   - Retrieves all versions of the short URL owned by the authenticated user, oldest first
+//   - Returns appropriate HTTP status codes:
+//   - 200 OK with model.UserURLHistoryResponse
+//   - 404 Not Found if the user has no such short URL
+//   - 500 Internal Server Error for processing failures
+//
+// Parameters:
+//   - p: Processor implementing the user URL history logic
+//   - l: Logger for logging operations
+//
+// Returns:
+//   - HTTP handler function for the user URL history endpoint
+func HandleGetUserURLHistory(p APIUserURLsProcessor, l *zap.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		shortID := chi.URLParam(r, ShortIDParam)
+		history, err := p.ProcessGetHistory(r.Context(), shortID)
+		var nfErr *repository.DataNotFoundError
+		if errors.As(err, &nfErr) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		} else if err != nil {
+			l.Error("error getting user url history", zap.Error(err))
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if err = codec.EasyJSONEncode(w, http.StatusOK, &history); err != nil {
+			l.Error("encode json response", zap.Error(err))
+			return
+		}
+	}
+}
+
+// HandleRestoreUserURLVersion creates an HTTP handler for restoring a previous version of user's short URL.
+// It handles POST requests to '/api/user/urls/{id}/history/restore' endpoint
+// with JSON body containing the number of the version to restore.
+//
+// The handler:
+//   - Points the short URL back at the original URL of the requested version
+//   - Returns appropriate HTTP status codes:
+//   - 200 OK with model.UserURLsGetResponseItem for successful restoration
+//   - 400 Bad Request for malformed JSON
+//   - 404 Not Found if the user has no such short URL or the version doesn't exist
+//   - 409 Conflict if the restored URL is already shortened
+//   - 410 Gone if the short URL is deleted, expired or has no follows left
+//   - 451 Unavailable For Legal Reasons if the restored URL matches the blocklist
+//   - 500 Internal Server Error for processing failures
+//
+// Parameters:
+//   - p: Processor implementing the version restoration logic
+//   - l: Logger for logging operations
+//
+// Returns:
+//   - HTTP handler function for the restore user URL version endpoint
+func HandleRestoreUserURLVersion(p APIUserURLsProcessor, l *zap.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req model.UserURLRestoreVersionRequest
+		if err := codec.EasyJSONDecode(r, &req); err != nil {
+			l.Debug("decode json request", zap.Error(err))
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		shortID := chi.URLParam(r, ShortIDParam)
+		item, err := p.ProcessRestoreVersion(r.Context(), shortID, req)
+		writeUpdatedUserURL(w, item, err, l)
+	}
+}
+
+// writeUpdatedUserURL writes the result of changing the destination of user's short URL.
+//
+// Parameters:
+//   - w: HTTP response writer
+//   - item: updated short URL, if there is no error
+//   - err: error of the update
+//   - l: Logger for logging operations
+func writeUpdatedUserURL(w http.ResponseWriter, item *model.UserURLsGetResponseItem, err error, l *zap.Logger) {
+	var nfErr *repository.DataNotFoundError
+	if errors.Is(err, service.ErrEmptyInputURL) || isValidationError(err) {
+		writeBadRequest(w, err)
+		return
+	} else if errors.As(err, &nfErr) || errors.Is(err, service.ErrHistoryVersionNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	} else if errors.Is(err, service.ErrURLAlreadyExists) {
+		w.WriteHeader(http.StatusConflict)
+		return
+	} else if isGoneError(err) {
+		w.WriteHeader(http.StatusGone)
+		return
+	} else if errors.Is(err, service.ErrURLBlocked) {
+		writeBlocked(w)
+		return
+	} else if err != nil {
+		l.Error("error updating user url", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if err = codec.EasyJSONEncode(w, http.StatusOK, item); err != nil {
+		l.Error("encode json response", zap.Error(err))
+		return
+	}
+}
@@ -0,0 +1,397 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/alex-storchak/shortener/internal/model"
+	repo "github.com/alex-storchak/shortener/internal/repository"
+	"github.com/alex-storchak/shortener/internal/service"
+)
+
+type userURLsSrvStub struct {
+	updateErr  error
+	historyErr error
+}
+
+func (s *userURLsSrvStub) ProcessGet(_ context.Context, tags []string) (model.UserURLsGetResponse, error) {
+	resp := model.UserURLsGetResponse{
+		{ShortURL: "http://localhost:8080/one", OrigURL: "https://one.com", Tags: []string{"promo"}},
+		{ShortURL: "http://localhost:8080/two", OrigURL: "https://two.com"},
+	}
+	if len(tags) == 0 {
+		return resp, nil
+	}
+	normalized, err := service.NormalizeTags(tags)
+	if err != nil {
+		return nil, err
+	}
+	if len(normalized) == 1 && normalized[0] == "promo" {
+		return resp[:1], nil
+	}
+	return nil, nil
+}
+
+func (s *userURLsSrvStub) ProcessGetTags(_ context.Context) (model.UserTagsResponse, error) {
+	return model.UserTagsResponse{{Tag: "promo", Count: 1}}, nil
+}
+
+func (s *userURLsSrvStub) ProcessUpdate(
+	_ context.Context,
+	shortID string,
+	req model.UserURLUpdateRequest,
+) (*model.UserURLsGetResponseItem, error) {
+	if s.updateErr != nil {
+		return nil, s.updateErr
+	}
+	if req.OrigURL == "" {
+		return nil, service.ErrEmptyInputURL
+	}
+	return &model.UserURLsGetResponseItem{
+		ShortURL: "http://localhost:8080/" + shortID,
+		OrigURL:  req.OrigURL,
+	}, nil
+}
+
+func (s *userURLsSrvStub) ProcessGetHistory(_ context.Context, _ string) (model.UserURLHistoryResponse, error) {
+	if s.historyErr != nil {
+		return nil, s.historyErr
+	}
+	return model.UserURLHistoryResponse{
+		{Version: 1, Action: "create", OrigURL: "https://first.com", ChangedAt: time.Unix(0, 0).UTC()},
+	}, nil
+}
+
+func (s *userURLsSrvStub) ProcessRestoreVersion(
+	_ context.Context,
+	shortID string,
+	req model.UserURLRestoreVersionRequest,
+) (*model.UserURLsGetResponseItem, error) {
+	if s.updateErr != nil {
+		return nil, s.updateErr
+	}
+	if req.Version != 1 {
+		return nil, service.ErrHistoryVersionNotFound
+	}
+	return &model.UserURLsGetResponseItem{
+		ShortURL: "http://localhost:8080/" + shortID,
+		OrigURL:  "https://first.com",
+	}, nil
+}
+
+func (s *userURLsSrvStub) ProcessDelete(_ context.Context, _ model.UserURLsDelRequest) error {
+	return nil
+}
+
+func (s *userURLsSrvStub) ProcessGetTrash(_ context.Context) (model.UserURLsGetResponse, error) {
+	return nil, nil
+}
+
+func (s *userURLsSrvStub) ProcessRestore(
+	_ context.Context,
+	shortIDs model.UserURLsRestoreRequest,
+) (model.UserURLsRestoreResponse, error) {
+	if len(shortIDs) == 0 {
+		return nil, service.ErrEmptyInputBatch
+	}
+	resp := make(model.UserURLsRestoreResponse, len(shortIDs))
+	for i, id := range shortIDs {
+		resp[i] = model.UserURLsRestoreResponseItem{ShortURL: "http://localhost:8080/" + id, Status: "restored"}
+	}
+	return resp, nil
+}
+
+func TestGetUserURLs_TagFilter(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		wantCode int
+		wantBody string
+	}{
+		{
+			name:     "no filter returns all urls",
+			wantCode: http.StatusOK,
+			wantBody: `[{"short_url":"http://localhost:8080/one","original_url":"https://one.com","tags":["promo"]},` +
+				`{"short_url":"http://localhost:8080/two","original_url":"https://two.com"}]`,
+		},
+		{
+			name:     "tag filter returns labeled urls",
+			query:    "?tag=Promo",
+			wantCode: http.StatusOK,
+			wantBody: `[{"short_url":"http://localhost:8080/one","original_url":"https://one.com","tags":["promo"]}]`,
+		},
+		{
+			name:     "tags matching nothing return 204 (No Content)",
+			query:    "?tag=promo&tag=q1",
+			wantCode: http.StatusNoContent,
+		},
+		{
+			name:     "invalid tag returns 400 (Bad Request)",
+			query:    "?tag=no%20spaces",
+			wantCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "/api/user/urls"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			HandleGetUserURLs(&userURLsSrvStub{}, zap.NewNop())(w, request)
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, tt.wantCode, res.StatusCode)
+			if tt.wantBody != "" {
+				body, err := io.ReadAll(res.Body)
+				require.NoError(t, err)
+				assert.JSONEq(t, tt.wantBody, string(body))
+			}
+		})
+	}
+}
+
+func TestGetUserTags(t *testing.T) {
+	request := httptest.NewRequest(http.MethodGet, "/api/user/tags", nil)
+	w := httptest.NewRecorder()
+
+	HandleGetUserTags(&userURLsSrvStub{}, zap.NewNop())(w, request)
+	res := w.Result()
+	defer res.Body.Close()
+
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"tag":"promo","count":1}]`, string(body))
+}
+
+func TestUpdateUserURL(t *testing.T) {
+	type want struct {
+		code int
+		body string
+	}
+	tests := []struct {
+		name      string
+		body      string
+		updateErr error
+		want      want
+	}{
+		{
+			name: "updated short url returns 200 (OK) with json",
+			body: `{"original_url":"https://new.com"}`,
+			want: want{
+				code: http.StatusOK,
+				body: `{"short_url":"http://localhost:8080/abcde","original_url":"https://new.com"}`,
+			},
+		},
+		{
+			name: "malformed json returns 400 (Bad Request)",
+			body: `{"original_url":`,
+			want: want{code: http.StatusBadRequest},
+		},
+		{
+			name: "empty url returns 400 (Bad Request)",
+			body: `{"original_url":""}`,
+			want: want{code: http.StatusBadRequest},
+		},
+		{
+			name:      "short url of another user returns 404 (Not Found)",
+			body:      `{"original_url":"https://new.com"}`,
+			updateErr: repo.NewDataNotFoundError(nil),
+			want:      want{code: http.StatusNotFound},
+		},
+		{
+			name:      "already shortened url returns 409 (Conflict)",
+			body:      `{"original_url":"https://existing.com"}`,
+			updateErr: service.ErrURLAlreadyExists,
+			want:      want{code: http.StatusConflict},
+		},
+		{
+			name:      "deleted short url returns 410 (Gone)",
+			body:      `{"original_url":"https://new.com"}`,
+			updateErr: repo.ErrDataDeleted,
+			want:      want{code: http.StatusGone},
+		},
+		{
+			name:      "random error returns 500 (Internal Server Error)",
+			body:      `{"original_url":"https://new.com"}`,
+			updateErr: errors.New("random error"),
+			want:      want{code: http.StatusInternalServerError},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := &userURLsSrvStub{updateErr: tt.updateErr}
+			mux := chi.NewRouter()
+			mux.Patch("/api/user/urls/{id}", HandleUpdateUserURL(srv, zap.NewNop()))
+
+			request := httptest.NewRequest(http.MethodPatch, "/api/user/urls/abcde", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+
+			mux.ServeHTTP(w, request)
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, tt.want.code, res.StatusCode)
+			if tt.want.body != "" {
+				body, err := io.ReadAll(res.Body)
+				require.NoError(t, err)
+				assert.JSONEq(t, tt.want.body, string(body))
+			}
+		})
+	}
+}
+
+func TestGetUserURLHistory(t *testing.T) {
+	tests := []struct {
+		name       string
+		historyErr error
+		wantCode   int
+		wantBody   string
+	}{
+		{
+			name:     "history of user's short url returns 200 (OK) with json",
+			wantCode: http.StatusOK,
+			wantBody: `[{"version":1,"action":"create","original_url":"https://first.com","changed_at":"1970-01-01T00:00:00Z"}]`,
+		},
+		{
+			name:       "short url of another user returns 404 (Not Found)",
+			historyErr: repo.NewDataNotFoundError(nil),
+			wantCode:   http.StatusNotFound,
+		},
+		{
+			name:       "random error returns 500 (Internal Server Error)",
+			historyErr: errors.New("random error"),
+			wantCode:   http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := &userURLsSrvStub{historyErr: tt.historyErr}
+			mux := chi.NewRouter()
+			mux.Get("/api/user/urls/{id}/history", HandleGetUserURLHistory(srv, zap.NewNop()))
+
+			request := httptest.NewRequest(http.MethodGet, "/api/user/urls/abcde/history", nil)
+			w := httptest.NewRecorder()
+
+			mux.ServeHTTP(w, request)
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, tt.wantCode, res.StatusCode)
+			if tt.wantBody != "" {
+				body, err := io.ReadAll(res.Body)
+				require.NoError(t, err)
+				assert.JSONEq(t, tt.wantBody, string(body))
+			}
+		})
+	}
+}
+
+func TestRestoreUserURLVersion(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		updateErr error
+		wantCode  int
+	}{
+		{
+			name:     "restored version returns 200 (OK)",
+			body:     `{"version":1}`,
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "missing version returns 404 (Not Found)",
+			body:     `{"version":7}`,
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "malformed json returns 400 (Bad Request)",
+			body:     `{"version":`,
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:      "already shortened url returns 409 (Conflict)",
+			body:      `{"version":1}`,
+			updateErr: service.ErrURLAlreadyExists,
+			wantCode:  http.StatusConflict,
+		},
+		{
+			name:      "deleted short url returns 410 (Gone)",
+			body:      `{"version":1}`,
+			updateErr: repo.ErrDataDeleted,
+			wantCode:  http.StatusGone,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := &userURLsSrvStub{updateErr: tt.updateErr}
+			mux := chi.NewRouter()
+			mux.Post("/api/user/urls/{id}/history/restore", HandleRestoreUserURLVersion(srv, zap.NewNop()))
+
+			request := httptest.NewRequest(http.MethodPost, "/api/user/urls/abcde/history/restore", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+
+			mux.ServeHTTP(w, request)
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, tt.wantCode, res.StatusCode)
+		})
+	}
+}
+
+func TestRestoreUserURLs(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		wantCode int
+		wantBody string
+	}{
+		{
+			name:     "restored urls return 200 (OK) with outcomes",
+			body:     `["abcde"]`,
+			wantCode: http.StatusOK,
+			wantBody: `[{"short_url":"http://localhost:8080/abcde","status":"restored"}]`,
+		},
+		{
+			name:     "empty list returns 400 (Bad Request)",
+			body:     `[]`,
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "malformed json returns 400 (Bad Request)",
+			body:     `[`,
+			wantCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := HandleRestoreUserURLs(&userURLsSrvStub{}, zap.NewNop())
+
+			request := httptest.NewRequest(http.MethodPost, "/api/user/urls/restore", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, request)
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, tt.wantCode, res.StatusCode)
+			if tt.wantBody != "" {
+				body, err := io.ReadAll(res.Body)
+				require.NoError(t, err)
+				assert.JSONEq(t, tt.wantBody, string(body))
+			}
+		})
+	}
+}
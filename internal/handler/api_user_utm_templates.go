@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/alex-storchak/shortener/internal/codec"
+	"github.com/alex-storchak/shortener/internal/model"
+	"github.com/alex-storchak/shortener/internal/repository"
+)
+
+// APIUserUTMTemplatesProcessor defines the interface for processing UTM template management operations.
+// It provides methods for retrieving, creating or replacing and deleting UTM templates of user's links.
+type APIUserUTMTemplatesProcessor interface {
+	ProcessGet(ctx context.Context) (model.UserUTMTemplatesResponse, error)
+	ProcessSet(ctx context.Context, req model.UserUTMTemplate) (*model.UserUTMTemplate, error)
+	ProcessDelete(ctx context.Context, req model.UserUTMTemplate) error
+}
+
+// HandleGetUserUTMTemplates creates an HTTP handler for retrieving UTM templates of the authenticated user.
+// It handles GET requests to '/api/user/utm-templates' endpoint.
+//
+// The handler:
+//   - Retrieves link templates sorted by short ID, followed by tag templates sorted by tag
+//   - Returns appropriate HTTP status codes:
+//   - 200 OK with model.UserUTMTemplatesResponse when templates are found
+//   - 204 No Content when user has no templates
+//   - 500 Internal Server Error for processing failures
+//
+// Parameters:
+//   - p: Processor implementing the UTM templates retrieval logic
+//   - l: Logger for logging operations
+//
+// Returns:
+//   - HTTP handler function for the get user UTM templates endpoint
+func HandleGetUserUTMTemplates(p APIUserUTMTemplatesProcessor, l *zap.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		respItems, err := p.ProcessGet(r.Context())
+		if err != nil {
+			l.Error("error getting user utm templates", zap.Error(err))
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		} else if len(respItems) == 0 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		if err = codec.EasyJSONEncode(w, http.StatusOK, &respItems); err != nil {
+			l.Error("encode json response", zap.Error(err))
+			return
+		}
+	}
+}
+
+// HandleSetUserUTMTemplate creates an HTTP handler for creating or replacing a UTM template of user's links.
+// It handles PUT requests to '/api/user/utm-templates' endpoint with JSON body containing
+// either a short ID or a tag and the query parameters to append to destinations on redirect.
+//
+// The handler:
+//   - Stores the template, replacing the one bound to the same link or tag
+//   - Returns appropriate HTTP status codes:
+//   - 200 OK with model.UserUTMTemplate for successful storing
+//   - 400 Bad Request for malformed JSON, no single target, invalid tag or malformed parameters
+//   - 404 Not Found if the user has no such short URL
+//   - 410 Gone if the short URL is deleted, expired or has no follows left
+//   - 500 Internal Server Error for processing failures
+//
+// Parameters:
+//   - p: Processor implementing the UTM template storing logic
+//   - l: Logger for logging operations
+//
+// Returns:
+//   - HTTP handler function for the set user UTM template endpoint
+func HandleSetUserUTMTemplate(p APIUserUTMTemplatesProcessor, l *zap.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req model.UserUTMTemplate
+		if err := codec.EasyJSONDecode(r, &req); err != nil {
+			l.Debug("decode json request", zap.Error(err))
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		item, err := p.ProcessSet(r.Context(), req)
+		var nfErr *repository.DataNotFoundError
+		if isValidationError(err) {
+			writeBadRequest(w, err)
+			return
+		} else if errors.As(err, &nfErr) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		} else if isGoneError(err) {
+			w.WriteHeader(http.StatusGone)
+			return
+		} else if err != nil {
+			l.Error("error setting user utm template", zap.Error(err))
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if err = codec.EasyJSONEncode(w, http.StatusOK, item); err != nil {
+			l.Error("encode json response", zap.Error(err))
+			return
+		}
+	}
+}
+
+// HandleDeleteUserUTMTemplate creates an HTTP handler for deleting a UTM template of user's links.
+// It handles DELETE requests to '/api/user/utm-templates' endpoint with JSON body containing
+// either the short ID or the tag the template is bound to.
+//
+// The handler:
+//   - Removes the template
+//   - Returns appropriate HTTP status codes:
+//   - 204 No Content for successful deletion
+//   - 400 Bad Request for malformed JSON, no single target or invalid tag
+//   - 404 Not Found if the user has no such template
+//   - 500 Internal Server Error for processing failures
+//
+// Parameters:
+//   - p: Processor implementing the UTM template deletion logic
+//   - l: Logger for logging operations
+//
+// Returns:
+//   - HTTP handler function for the delete user UTM template endpoint
+func HandleDeleteUserUTMTemplate(p APIUserUTMTemplatesProcessor, l *zap.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req model.UserUTMTemplate
+		if err := codec.EasyJSONDecode(r, &req); err != nil {
+			l.Debug("decode json request", zap.Error(err))
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		err := p.ProcessDelete(r.Context(), req)
+		var nfErr *repository.DataNotFoundError
+		if isValidationError(err) {
+			writeBadRequest(w, err)
+			return
+		} else if errors.As(err, &nfErr) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		} else if err != nil {
+			l.Error("error deleting user utm template", zap.Error(err))
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,187 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/alex-storchak/shortener/internal/model"
+	repo "github.com/alex-storchak/shortener/internal/repository"
+	"github.com/alex-storchak/shortener/internal/service"
+)
+
+type userUTMSrvStub struct {
+	templates model.UserUTMTemplatesResponse
+	setErr    error
+	deleteErr error
+}
+
+func (s *userUTMSrvStub) ProcessGet(_ context.Context) (model.UserUTMTemplatesResponse, error) {
+	return s.templates, nil
+}
+
+func (s *userUTMSrvStub) ProcessSet(_ context.Context, req model.UserUTMTemplate) (*model.UserUTMTemplate, error) {
+	if s.setErr != nil {
+		return nil, s.setErr
+	}
+	return &req, nil
+}
+
+func (s *userUTMSrvStub) ProcessDelete(_ context.Context, _ model.UserUTMTemplate) error {
+	return s.deleteErr
+}
+
+func TestGetUserUTMTemplates(t *testing.T) {
+	tests := []struct {
+		name      string
+		templates model.UserUTMTemplatesResponse
+		wantCode  int
+		wantBody  string
+	}{
+		{
+			name: "templates return 200 (OK) with json",
+			templates: model.UserUTMTemplatesResponse{
+				{ShortID: "abcde", Params: map[string]string{"utm_source": "mail"}},
+				{Tag: "promo", Params: map[string]string{"utm_campaign": "{tag}"}},
+			},
+			wantCode: http.StatusOK,
+			wantBody: `[{"short_id":"abcde","params":{"utm_source":"mail"}},{"tag":"promo","params":{"utm_campaign":"{tag}"}}]`,
+		},
+		{
+			name:     "no templates return 204 (No Content)",
+			wantCode: http.StatusNoContent,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "/api/user/utm-templates", nil)
+			w := httptest.NewRecorder()
+
+			HandleGetUserUTMTemplates(&userUTMSrvStub{templates: tt.templates}, zap.NewNop())(w, request)
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, tt.wantCode, res.StatusCode)
+			if tt.wantBody != "" {
+				body, err := io.ReadAll(res.Body)
+				require.NoError(t, err)
+				assert.JSONEq(t, tt.wantBody, string(body))
+			}
+		})
+	}
+}
+
+func TestSetUserUTMTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		setErr   error
+		wantCode int
+		wantBody string
+	}{
+		{
+			name:     "stored template returns 200 (OK) with json",
+			body:     `{"tag":"promo","params":{"utm_campaign":"{tag}"}}`,
+			wantCode: http.StatusOK,
+			wantBody: `{"tag":"promo","params":{"utm_campaign":"{tag}"}}`,
+		},
+		{
+			name:     "malformed json returns 400 (Bad Request)",
+			body:     `{"tag":`,
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "invalid template returns 400 (Bad Request)",
+			body:     `{"params":{"utm_source":"mail"}}`,
+			setErr:   service.NewValidationError(service.ErrInvalidUTMTemplate, "exactly one of short_id and tag must be set"),
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "short url of another user returns 404 (Not Found)",
+			body:     `{"short_id":"abcde","params":{"utm_source":"mail"}}`,
+			setErr:   repo.NewDataNotFoundError(nil),
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "deleted short url returns 410 (Gone)",
+			body:     `{"short_id":"abcde","params":{"utm_source":"mail"}}`,
+			setErr:   repo.ErrDataDeleted,
+			wantCode: http.StatusGone,
+		},
+		{
+			name:     "random error returns 500 (Internal Server Error)",
+			body:     `{"short_id":"abcde","params":{"utm_source":"mail"}}`,
+			setErr:   errors.New("random error"),
+			wantCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodPut, "/api/user/utm-templates", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+
+			HandleSetUserUTMTemplate(&userUTMSrvStub{setErr: tt.setErr}, zap.NewNop())(w, request)
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, tt.wantCode, res.StatusCode)
+			if tt.wantBody != "" {
+				body, err := io.ReadAll(res.Body)
+				require.NoError(t, err)
+				assert.JSONEq(t, tt.wantBody, string(body))
+			}
+		})
+	}
+}
+
+func TestDeleteUserUTMTemplate(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		deleteErr error
+		wantCode  int
+	}{
+		{
+			name:     "deleted template returns 204 (No Content)",
+			body:     `{"tag":"promo"}`,
+			wantCode: http.StatusNoContent,
+		},
+		{
+			name:     "malformed json returns 400 (Bad Request)",
+			body:     `[`,
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:      "missing template returns 404 (Not Found)",
+			body:      `{"short_id":"abcde"}`,
+			deleteErr: repo.NewDataNotFoundError(nil),
+			wantCode:  http.StatusNotFound,
+		},
+		{
+			name:      "random error returns 500 (Internal Server Error)",
+			body:      `{"tag":"promo"}`,
+			deleteErr: errors.New("random error"),
+			wantCode:  http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodDelete, "/api/user/utm-templates", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+
+			HandleDeleteUserUTMTemplate(&userUTMSrvStub{deleteErr: tt.deleteErr}, zap.NewNop())(w, request)
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, tt.wantCode, res.StatusCode)
+		})
+	}
+}
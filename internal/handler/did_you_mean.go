@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"html/template"
+	"net/http"
+
+	"go.uber.org/zap"
+)
+
+// didYouMeanTmpl renders the page listing corrections of a mistyped short ID.
+// Links are relative, so they resolve next to the requested short URL.
+var didYouMeanTmpl = template.Must(template.New("did_you_mean").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+<title>Link not found</title>
+</head>
+<body>
+<h1>This link doesn't exist</h1>
+<p>The short link <code>{{.ShortID}}</code> seems to be mistyped.</p>
+{{if .Suggestions}}<p>Did you mean:</p>
+<ul>
+{{range .Suggestions}}<li><a href="{{.}}">{{.}}</a></li>
+{{end}}</ul>
+{{end}}</body>
+</html>
+`))
+
+// didYouMeanData holds the values rendered by didYouMeanTmpl.
+type didYouMeanData struct {
+	ShortID     string   // Requested short ID
+	Suggestions []string // Short IDs the user probably meant
+}
+
+// writeDidYouMean responds with 404 Not Found and the page listing corrections of the mistyped short ID.
+//
+// Parameters:
+//   - w: HTTP response writer
+//   - shortID: requested short ID
+//   - suggestions: short IDs the user probably meant
+//   - l: logger for logging rendering failures
+func writeDidYouMean(w http.ResponseWriter, shortID string, suggestions []string, l *zap.Logger) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusNotFound)
+	if err := didYouMeanTmpl.Execute(w, didYouMeanData{ShortID: shortID, Suggestions: suggestions}); err != nil {
+		l.Error("render did you mean page", zap.Error(err))
+	}
+}
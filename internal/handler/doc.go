@@ -10,13 +10,40 @@
 //
 // Endpoints:
 //   - POST /                   - Shorten URL (text/plain)
-//   - GET  /{id}               - Expand short URL to original
+//   - GET  /{id}               - Redirect to original URL, to the URL of the first matching targeting rule
+//     or to a weighted split test variant, with the link redirect status
+//   - GET  /{id}/*             - Redirect forwarding path suffix and query to original URL of passthrough links, /preview and /qr suffixes included
+//   - GET  /{id}/preview, /{id}+ - HTML page with destination, creation date and safety status, without redirecting; only /{id}+ for passthrough links
+//   - GET  /{id}/qr            - PNG or SVG QR code of short URL with size, error correction level, margin and colors
 //   - GET  /ping               - Health check
 //   - POST /api/shorten        - Shorten URL (JSON API)
 //   - POST /api/shorten/batch  - Batch URL shortening
-//   - GET  /api/user/urls      - Get user's URLs
+//   - POST /api/shorten/import - Streaming bulk import of links from CSV or NDJSON with per-row NDJSON outcomes
+//   - GET  /api/expand/{id}    - Destination, creation date and safety status of short URL (JSON API)
+//   - GET  /api/user/urls      - Get user's URLs, optionally filtered by tags
 //   - DELETE /api/user/urls    - Delete user's URLs
-//   - GET  /api/internal/stats - Get amount of URLs and users in storage
+//   - GET  /api/user/urls/trash - Get user's deleted URLs
+//   - POST /api/user/urls/restore - Restore user's deleted URLs
+//   - PATCH /api/user/urls/{id} - Change destination and tags of user's short URL
+//   - GET  /api/user/urls/{id}/history - Get version history of user's short URL
+//   - POST /api/user/urls/{id}/history/restore - Restore a version of user's short URL
+//   - GET  /api/user/urls/{id}/rules - Get targeting rules of user's short URL
+//   - PUT  /api/user/urls/{id}/rules - Replace targeting rules of user's short URL by device, language and header
+//   - GET  /api/user/urls/{id}/split - Get A/B split test of user's short URL
+//   - PUT  /api/user/urls/{id}/split - Replace weighted A/B split test variants of user's short URL
+//   - GET  /api/user/tags      - Get tags of user's URLs with their counts
+//   - GET  /api/user/utm-templates - Get UTM templates of user's links and tags
+//   - PUT  /api/user/utm-templates - Create or replace a UTM template of a link or a tag
+//   - DELETE /api/user/utm-templates - Delete a UTM template of a link or a tag
+//   - GET  /api/user/collections - Get user's named collections of links with their sizes
+//   - POST /api/user/collections - Create a collection
+//   - POST /api/user/collections/move - Move user's links into a collection or out of any collection
+//   - PATCH /api/user/collections/{id} - Rename a collection
+//   - DELETE /api/user/collections/{id} - Delete a collection, keeping its links
+//   - GET  /api/user/collections/{id}/urls - Get links in a collection
+//   - DELETE /api/user/collections/{id}/urls - Delete all links in a collection
+//   - GET  /api/user/collections/{id}/export - Download links in a collection as CSV or JSON
+//   - GET  /api/internal/stats - Get amount of URLs, users and purged URLs in storage
 //
 // Middleware:
 //   - Request logging with structured logging
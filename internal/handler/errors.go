@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/alex-storchak/shortener/internal/service"
+)
+
+// writeBadRequest responds with 400 Bad Request. If err is a service validation error,
+// its reason is written to the plain text body, otherwise the body is left empty.
+//
+// Parameters:
+//   - w: HTTP response writer
+//   - err: error that caused the request rejection
+func writeBadRequest(w http.ResponseWriter, err error) {
+	var vErr *service.ValidationError
+	if errors.As(err, &vErr) {
+		http.Error(w, vErr.Error(), http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusBadRequest)
+}
+
+// writeBlocked responds with 451 Unavailable For Legal Reasons to requests rejected by the blocklist.
+//
+// Parameters:
+//   - w: HTTP response writer
+func writeBlocked(w http.ResponseWriter) {
+	http.Error(w, service.ErrURLBlocked.Error(), http.StatusUnavailableForLegalReasons)
+}
+
+// isValidationError reports whether err is caused by rejected user input.
+func isValidationError(err error) bool {
+	var vErr *service.ValidationError
+	return errors.As(err, &vErr)
+}
@@ -20,14 +20,49 @@ type mockAPIUserURLsProcessor struct {
 	delErr  error
 }
 
-func (m *mockAPIUserURLsProcessor) ProcessGet(_ context.Context) (model.UserURLsGetResponse, error) {
+func (m *mockAPIUserURLsProcessor) ProcessGet(_ context.Context, _ []string) (model.UserURLsGetResponse, error) {
 	return m.getResp, m.getErr
 }
 
+func (m *mockAPIUserURLsProcessor) ProcessGetTags(_ context.Context) (model.UserTagsResponse, error) {
+	return nil, nil
+}
+
+func (m *mockAPIUserURLsProcessor) ProcessUpdate(
+	_ context.Context,
+	_ string,
+	_ model.UserURLUpdateRequest,
+) (*model.UserURLsGetResponseItem, error) {
+	return nil, nil
+}
+
+func (m *mockAPIUserURLsProcessor) ProcessGetHistory(_ context.Context, _ string) (model.UserURLHistoryResponse, error) {
+	return nil, nil
+}
+
+func (m *mockAPIUserURLsProcessor) ProcessRestoreVersion(
+	_ context.Context,
+	_ string,
+	_ model.UserURLRestoreVersionRequest,
+) (*model.UserURLsGetResponseItem, error) {
+	return nil, nil
+}
+
 func (m *mockAPIUserURLsProcessor) ProcessDelete(_ context.Context, _ model.UserURLsDelRequest) error {
 	return m.delErr
 }
 
+func (m *mockAPIUserURLsProcessor) ProcessGetTrash(_ context.Context) (model.UserURLsGetResponse, error) {
+	return nil, nil
+}
+
+func (m *mockAPIUserURLsProcessor) ProcessRestore(
+	_ context.Context,
+	_ model.UserURLsRestoreRequest,
+) (model.UserURLsRestoreResponse, error) {
+	return nil, nil
+}
+
 // This example demonstrates a successful response from the handler created by
 // HandleGetUserURLs when the user has URLs.
 // The handler returns status 200 (OK) and a JSON body with the list of URLs.
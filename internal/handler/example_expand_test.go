@@ -11,6 +11,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/alex-storchak/shortener/internal/handler"
+	"github.com/alex-storchak/shortener/internal/model"
 	"github.com/alex-storchak/shortener/internal/repository"
 )
 
@@ -20,8 +21,11 @@ type mockExpandProcessor struct {
 	err     error
 }
 
-func (m *mockExpandProcessor) Process(_ context.Context, _ string) (string, error) {
-	return m.origURL, m.err
+func (m *mockExpandProcessor) Process(_ context.Context, _ model.ExpandRequest) (*model.ExpandResult, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &model.ExpandResult{OrigURL: m.origURL, RedirectType: http.StatusTemporaryRedirect}, nil
 }
 
 // This example demonstrates a successful response from the handler created by
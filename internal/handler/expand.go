@@ -3,29 +3,50 @@ package handler
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"go.uber.org/zap"
 
+	"github.com/alex-storchak/shortener/internal/model"
 	"github.com/alex-storchak/shortener/internal/repository"
+	"github.com/alex-storchak/shortener/internal/service"
+)
+
+// Cookie keeping clients of sticky split tests on the variant assigned on their first follow.
+// It is scoped to the path of the short URL, so every link keeps its own assignment.
+const (
+	splitCookieName   = "split_variant"
+	splitCookieMaxAge = 30 * 24 * time.Hour
 )
 
 // ExpandProcessor defines the interface for processing URL expansion requests.
 // Implementations handle the business logic of converting short URLs back to original URLs.
 type ExpandProcessor interface {
-	Process(ctx context.Context, shortID string) (origURL string, err error)
+	Process(ctx context.Context, req model.ExpandRequest) (*model.ExpandResult, error)
 }
 
 // HandleExpand creates an HTTP handler for expanding short URLs to their original URLs.
 // It handles GET requests to '/{shortID}' endpoint where shortID is the URL parameter.
+// Requests to '/{shortID}/*' and their query strings are forwarded to links with passthrough enabled.
+// Clients matching a targeting rule of the link by their headers are redirected to the URL of the rule,
+// and other clients of links with a split test are redirected to a variant picked by weight;
+// the variant of sticky split tests is kept in a cookie scoped to the short URL.
 //
 // The handler:
 //   - Processes the expansion request to retrieve the original URL
 //   - Returns appropriate HTTP status codes:
-//   - 307 Temporary Redirect with Location header for successful expansion
-//   - 404 Not Found when short ID doesn't exist
-//   - 410 Gone when the URL has been deleted
+//   - 301, 302, 307 or 308 redirect chosen for the link with Location and Cache-Control headers
+//     for successful expansion; only permanent redirects may be cached
+//   - 200 OK with an HTML password form when the URL is password-protected
+//   - 400 Bad Request when the forwarded path suffix or query is malformed
+//   - 404 Not Found when short ID doesn't exist or the path has a suffix but the link has no passthrough
+//   - 404 Not Found with an HTML page of suggested corrections when the check character of short ID is wrong
+//   - 410 Gone when the URL has been deleted, has expired or has no follows left
+//   - 451 Unavailable For Legal Reasons when the original URL matches the blocklist
 //   - 500 Internal Server Error for processing failures
 //
 // Parameters:
@@ -36,23 +57,146 @@ type ExpandProcessor interface {
 //   - HTTP handler function for the expand endpoint
 func HandleExpand(p ExpandProcessor, l *zap.Logger) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		shortID := chi.URLParam(r, ShortIDParam)
+		res, err := p.Process(r.Context(), newExpandRequest(r))
+		if errors.Is(err, service.ErrPasswordRequired) {
+			writePasswordForm(w, http.StatusOK, "", l)
+			return
+		} else if err != nil {
+			writeExpandError(w, err, l)
+			return
+		}
 
-		origURL, err := p.Process(r.Context(), shortID)
-		var nfErr *repository.DataNotFoundError
-		if errors.As(err, &nfErr) {
-			w.WriteHeader(http.StatusNotFound)
+		setSplitCookie(w, r, res)
+		w.Header().Set("Cache-Control", cacheControl(res.CacheMaxAge))
+		w.Header().Set("Location", res.OrigURL)
+		w.WriteHeader(res.RedirectType)
+	}
+}
+
+// HandleExpandProtected creates an HTTP handler for the password form of protected short URLs.
+// It handles POST requests to '/{shortID}' and '/{shortID}/*' endpoints with the 'password' form field.
+//
+// The handler:
+//   - Processes the expansion request with the submitted password
+//   - Returns appropriate HTTP status codes:
+//   - 303 See Other with Location header when the password is correct
+//   - 400 Bad Request for malformed form data or a malformed forwarded path suffix or query
+//   - 403 Forbidden with the password form when the password is missing or wrong
+//   - 429 Too Many Requests with the password form when failed attempts for the URL exceed the limit
+//   - 404 Not Found when short ID doesn't exist
+//   - 404 Not Found with an HTML page of suggested corrections when the check character of short ID is wrong
+//   - 410 Gone when the URL has been deleted, has expired or has no follows left
+//   - 451 Unavailable For Legal Reasons when the original URL matches the blocklist
+//   - 500 Internal Server Error for processing failures
+//
+// Parameters:
+//   - p: Processor implementing the URL expansion logic
+//   - l: Logger for logging operations
+//
+// Returns:
+//   - HTTP handler function for the protected expand endpoint
+func HandleExpandProtected(p ExpandProcessor, l *zap.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			l.Debug("parse password form", zap.Error(err))
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		req := newExpandRequest(r)
+		req.Password = r.PostForm.Get(passwordFieldName)
+
+		res, err := p.Process(r.Context(), req)
+		if errors.Is(err, service.ErrPasswordRequired) {
+			writePasswordForm(w, http.StatusForbidden, "Enter the password.", l)
+			return
+		} else if errors.Is(err, service.ErrWrongPassword) {
+			writePasswordForm(w, http.StatusForbidden, "Wrong password, try again.", l)
 			return
-		} else if errors.Is(err, repository.ErrDataDeleted) {
-			w.WriteHeader(http.StatusGone)
+		} else if errors.Is(err, service.ErrTooManyAttempts) {
+			writePasswordForm(w, http.StatusTooManyRequests, "Too many attempts, try again later.", l)
 			return
 		} else if err != nil {
-			l.Error("failed to expand short url", zap.Error(err))
-			w.WriteHeader(http.StatusInternalServerError)
+			writeExpandError(w, err, l)
 			return
 		}
 
-		w.Header().Set("Location", origURL)
-		w.WriteHeader(http.StatusTemporaryRedirect)
+		setSplitCookie(w, r, res)
+		w.Header().Set("Cache-Control", cacheControl(0))
+		w.Header().Set("Location", res.OrigURL)
+		w.WriteHeader(http.StatusSeeOther)
+	}
+}
+
+// newExpandRequest builds the expand request from the short ID, the escaped path after it, the raw query,
+// the headers matched by targeting rules and the split test variant assigned to the client before.
+func newExpandRequest(r *http.Request) model.ExpandRequest {
+	shortID := chi.URLParam(r, ShortIDParam)
+	req := model.ExpandRequest{
+		ShortID:  shortID,
+		Path:     strings.TrimPrefix(r.URL.EscapedPath(), "/"+shortID),
+		RawQuery: r.URL.RawQuery,
+		Header:   r.Header,
+	}
+	if c, err := r.Cookie(splitCookieName); err == nil {
+		req.Variant = c.Value
+	}
+	return req
+}
+
+// setSplitCookie keeps the client on the picked variant of a sticky split test in later follows of the link.
+func setSplitCookie(w http.ResponseWriter, r *http.Request, res *model.ExpandResult) {
+	if !res.Sticky || res.Variant == "" {
+		return
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     splitCookieName,
+		Value:    res.Variant,
+		Path:     "/" + chi.URLParam(r, ShortIDParam),
+		MaxAge:   int(splitCookieMaxAge / time.Second),
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// cacheControl returns the Cache-Control header value allowing clients to cache a redirect for maxAge.
+// Redirects with zero maxAge must not be cached, so every follow reaches the server.
+func cacheControl(maxAge time.Duration) string {
+	secs := int64(maxAge / time.Second)
+	if secs <= 0 {
+		return "no-store"
 	}
+	return fmt.Sprintf("public, max-age=%d", secs)
+}
+
+// writeExpandError responds with the status code matching the expansion error.
+func writeExpandError(w http.ResponseWriter, err error, l *zap.Logger) {
+	var (
+		nfErr *repository.DataNotFoundError
+		csErr *service.ChecksumError
+	)
+	if errors.As(err, &csErr) {
+		writeDidYouMean(w, csErr.ShortID, csErr.Suggestions, l)
+		return
+	} else if isValidationError(err) {
+		writeBadRequest(w, err)
+		return
+	} else if errors.As(err, &nfErr) {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	} else if isGoneError(err) {
+		w.WriteHeader(http.StatusGone)
+		return
+	} else if errors.Is(err, service.ErrURLBlocked) {
+		writeBlocked(w)
+		return
+	}
+	l.Error("failed to expand short url", zap.Error(err))
+	w.WriteHeader(http.StatusInternalServerError)
+}
+
+// isGoneError reports whether err means that the short URL existed but can't be followed anymore.
+func isGoneError(err error) bool {
+	return errors.Is(err, repository.ErrDataDeleted) ||
+		errors.Is(err, repository.ErrDataExpired) ||
+		errors.Is(err, repository.ErrClicksExhausted)
 }
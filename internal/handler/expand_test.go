@@ -3,25 +3,60 @@ package handler
 import (
 	"context"
 	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 
+	"github.com/alex-storchak/shortener/internal/model"
 	repo "github.com/alex-storchak/shortener/internal/repository"
+	"github.com/alex-storchak/shortener/internal/service"
 )
 
 type ShortURLSrvStub struct {
 	expandError error
 }
 
-func (s *ShortURLSrvStub) Process(_ context.Context, _ string) (origURL string, err error) {
+func (s *ShortURLSrvStub) Process(_ context.Context, _ model.ExpandRequest) (*model.ExpandResult, error) {
 	if s.expandError != nil {
-		return "", s.expandError
+		return nil, s.expandError
 	}
-	return "https://existing.com", nil
+	return &model.ExpandResult{OrigURL: "https://existing.com", RedirectType: http.StatusTemporaryRedirect}, nil
+}
+
+type redirectSrvStub struct {
+	result model.ExpandResult
+	got    model.ExpandRequest
+}
+
+func (s *redirectSrvStub) Process(_ context.Context, req model.ExpandRequest) (*model.ExpandResult, error) {
+	s.got = req
+	return &s.result, nil
+}
+
+type protectedURLSrvStub struct {
+	password    string
+	expandError error
+}
+
+func (s *protectedURLSrvStub) Process(_ context.Context, req model.ExpandRequest) (*model.ExpandResult, error) {
+	if s.expandError != nil {
+		return nil, s.expandError
+	}
+	if req.Password == "" {
+		return nil, service.ErrPasswordRequired
+	}
+	if req.Password != s.password {
+		return nil, service.ErrWrongPassword
+	}
+	return &model.ExpandResult{OrigURL: "https://internal.example.com", RedirectType: http.StatusPermanentRedirect}, nil
 }
 
 func TestExpand(t *testing.T) {
@@ -57,6 +92,56 @@ func TestExpand(t *testing.T) {
 			wantErr:     true,
 			expandError: repo.NewDataNotFoundError(nil),
 		},
+		{
+			name:   "expired short url returns 410 (Gone)",
+			method: http.MethodGet,
+			path:   "/expired",
+			want: want{
+				code: http.StatusGone,
+			},
+			wantErr:     true,
+			expandError: repo.ErrDataExpired,
+		},
+		{
+			name:   "exhausted one-time short url returns 410 (Gone)",
+			method: http.MethodGet,
+			path:   "/one-time",
+			want: want{
+				code: http.StatusGone,
+			},
+			wantErr:     true,
+			expandError: repo.ErrClicksExhausted,
+		},
+		{
+			name:   "blocked short url returns 451 (Unavailable For Legal Reasons)",
+			method: http.MethodGet,
+			path:   "/blocked",
+			want: want{
+				code: http.StatusUnavailableForLegalReasons,
+			},
+			wantErr:     true,
+			expandError: &service.BlockedURLError{OrigURL: "https://evil.example", Rule: "host evil.example"},
+		},
+		{
+			name:   "mistyped short url returns 404 (Not Found) with suggestions",
+			method: http.MethodGet,
+			path:   "/abcdx",
+			want: want{
+				code: http.StatusNotFound,
+			},
+			wantErr:     true,
+			expandError: &service.ChecksumError{ShortID: "abcdx", Suggestions: []string{"abcde"}},
+		},
+		{
+			name:   "protected short url returns 200 (OK) with password form",
+			method: http.MethodGet,
+			path:   "/protected",
+			want: want{
+				code: http.StatusOK,
+			},
+			wantErr:     true,
+			expandError: service.ErrPasswordRequired,
+		},
 		{
 			name:   "returns 500 (Internal Server Error) when random error on expand happens",
 			method: http.MethodGet,
@@ -91,3 +176,246 @@ func TestExpand(t *testing.T) {
 		})
 	}
 }
+
+func TestExpand_RedirectType(t *testing.T) {
+	tests := []struct {
+		name             string
+		result           model.ExpandResult
+		wantCode         int
+		wantCacheControl string
+	}{
+		{
+			name:             "permanent link returns 301 (Moved Permanently) cacheable by clients",
+			result:           model.ExpandResult{RedirectType: http.StatusMovedPermanently, CacheMaxAge: 24 * time.Hour},
+			wantCode:         http.StatusMovedPermanently,
+			wantCacheControl: "public, max-age=86400",
+		},
+		{
+			name:             "permanent link returns 308 (Permanent Redirect) cacheable by clients",
+			result:           model.ExpandResult{RedirectType: http.StatusPermanentRedirect, CacheMaxAge: time.Minute},
+			wantCode:         http.StatusPermanentRedirect,
+			wantCacheControl: "public, max-age=60",
+		},
+		{
+			name:             "tracking link returns 302 (Found) never cached",
+			result:           model.ExpandResult{RedirectType: http.StatusFound},
+			wantCode:         http.StatusFound,
+			wantCacheControl: "no-store",
+		},
+		{
+			name:             "temporary link returns 307 (Temporary Redirect) never cached",
+			result:           model.ExpandResult{RedirectType: http.StatusTemporaryRedirect},
+			wantCode:         http.StatusTemporaryRedirect,
+			wantCacheControl: "no-store",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.result.OrigURL = "https://existing.com"
+			h := HandleExpand(&redirectSrvStub{result: tt.result}, zap.NewNop())
+
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/abcde", nil))
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, tt.wantCode, res.StatusCode)
+			assert.Equal(t, "https://existing.com", res.Header.Get("Location"))
+			assert.Equal(t, tt.wantCacheControl, res.Header.Get("Cache-Control"))
+		})
+	}
+}
+
+func TestExpand_Passthrough(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		want   model.ExpandRequest
+	}{
+		{
+			name:   "short url without suffix",
+			target: "/abcde",
+			want:   model.ExpandRequest{ShortID: "abcde"},
+		},
+		{
+			name:   "suffix and query are forwarded raw",
+			target: "/abcde/docs/a%20b%2Fc/?utm_source=mail&q=a%26b",
+			want:   model.ExpandRequest{ShortID: "abcde", Path: "/docs/a%20b%2Fc/", RawQuery: "utm_source=mail&q=a%26b"},
+		},
+		{
+			name:   "bare trailing slash is a suffix",
+			target: "/abcde/",
+			want:   model.ExpandRequest{ShortID: "abcde", Path: "/"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := &redirectSrvStub{result: model.ExpandResult{OrigURL: "https://existing.com", RedirectType: http.StatusFound}}
+			mux := chi.NewRouter()
+			mux.Get("/{id:[a-zA-Z0-9_-]+}", HandleExpand(srv, zap.NewNop()))
+			mux.Get("/{id:[a-zA-Z0-9_-]+}/*", HandleExpand(srv, zap.NewNop()))
+
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			req.Header.Set("User-Agent", "curl/8.0")
+			tt.want.Header = http.Header{"User-Agent": {"curl/8.0"}}
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, http.StatusFound, res.StatusCode)
+			assert.Equal(t, tt.want, srv.got)
+		})
+	}
+
+	srv := &ShortURLSrvStub{service.NewValidationError(service.ErrInvalidPassthrough, "path segment `..` is not allowed")}
+	w := httptest.NewRecorder()
+	HandleExpand(srv, zap.NewNop()).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/abcde/x", nil))
+	res := w.Result()
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+}
+
+func TestExpand_SplitCookie(t *testing.T) {
+	tests := []struct {
+		name       string
+		result     model.ExpandResult
+		wantCookie bool
+	}{
+		{
+			name:       "sticky variant is kept in cookie scoped to short url",
+			result:     model.ExpandResult{OrigURL: "https://example.com/b", RedirectType: http.StatusFound, Variant: "b", Sticky: true},
+			wantCookie: true,
+		},
+		{
+			name:   "non-sticky variant sets no cookie",
+			result: model.ExpandResult{OrigURL: "https://example.com/b", RedirectType: http.StatusFound, Variant: "b"},
+		},
+		{
+			name:   "link without split test sets no cookie",
+			result: model.ExpandResult{OrigURL: "https://existing.com", RedirectType: http.StatusFound},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := &redirectSrvStub{result: tt.result}
+			mux := chi.NewRouter()
+			mux.Get("/{id:[a-zA-Z0-9_-]+}", HandleExpand(srv, zap.NewNop()))
+
+			req := httptest.NewRequest(http.MethodGet, "/abcde", nil)
+			req.AddCookie(&http.Cookie{Name: splitCookieName, Value: "a"})
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, http.StatusFound, res.StatusCode)
+			assert.Equal(t, "a", srv.got.Variant)
+			if !tt.wantCookie {
+				assert.Empty(t, res.Cookies())
+				return
+			}
+			require.Len(t, res.Cookies(), 1)
+			c := res.Cookies()[0]
+			assert.Equal(t, splitCookieName, c.Name)
+			assert.Equal(t, "b", c.Value)
+			assert.Equal(t, "/abcde", c.Path)
+			assert.True(t, c.HttpOnly)
+			assert.Positive(t, c.MaxAge)
+		})
+	}
+}
+
+func TestExpand_DidYouMean(t *testing.T) {
+	srv := &ShortURLSrvStub{&service.ChecksumError{ShortID: "abcdx", Suggestions: []string{"abcde", "bacdx"}}}
+	h := HandleExpand(srv, zap.NewNop())
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/abcdx", nil))
+	res := w.Result()
+	defer res.Body.Close()
+
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	assert.Equal(t, "text/html; charset=utf-8", res.Header.Get("Content-Type"))
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `<a href="abcde">abcde</a>`)
+	assert.Contains(t, string(body), `<a href="bacdx">bacdx</a>`)
+}
+
+func TestExpandProtected(t *testing.T) {
+	type want struct {
+		code     int
+		location string
+		hasForm  bool
+	}
+	tests := []struct {
+		name        string
+		body        string
+		expandError error
+		want        want
+	}{
+		{
+			name: "correct password returns 303 (See Other)",
+			body: "password=secret",
+			want: want{
+				code:     http.StatusSeeOther,
+				location: "https://internal.example.com",
+			},
+		},
+		{
+			name: "wrong password returns 403 (Forbidden) with password form",
+			body: "password=guess",
+			want: want{
+				code:    http.StatusForbidden,
+				hasForm: true,
+			},
+		},
+		{
+			name: "empty password returns 403 (Forbidden) with password form",
+			body: "",
+			want: want{
+				code:    http.StatusForbidden,
+				hasForm: true,
+			},
+		},
+		{
+			name:        "too many attempts returns 429 (Too Many Requests) with password form",
+			body:        "password=secret",
+			expandError: service.ErrTooManyAttempts,
+			want: want{
+				code:    http.StatusTooManyRequests,
+				hasForm: true,
+			},
+		},
+		{
+			name:        "expired short url returns 410 (Gone)",
+			body:        "password=secret",
+			expandError: repo.ErrDataExpired,
+			want: want{
+				code: http.StatusGone,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := &protectedURLSrvStub{password: "secret", expandError: tt.expandError}
+
+			h := HandleExpandProtected(srv, zap.NewNop())
+
+			request := httptest.NewRequest(http.MethodPost, "/protected", strings.NewReader(tt.body))
+			request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, request)
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, tt.want.code, res.StatusCode)
+			assert.Equal(t, tt.want.location, res.Header.Get("Location"))
+			body, err := io.ReadAll(res.Body)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want.hasForm, strings.Contains(string(body), `name="password"`))
+		})
+	}
+}
@@ -3,11 +3,15 @@ package handler
 import (
 	"context"
 	"errors"
+	"net/http"
+	"strings"
+	"time"
 
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	pb "github.com/alex-storchak/shortener/api/proto/shortener"
 	"github.com/alex-storchak/shortener/internal/model"
@@ -20,7 +24,12 @@ type GRPCShortenerServer struct {
 	logger       *zap.Logger
 	shortenProc  APIShortenProcessor
 	expandProc   ExpandProcessor
+	inspectProc  InspectProcessor
+	qrCodeProc   QRCodeProcessor
 	userURLsProc APIUserURLsProcessor
+	targetProc   APIUserTargetingProcessor
+	splitProc    APIUserSplitProcessor
+	collProc     APIUserCollectionsProcessor
 }
 
 func NewGRPCShortenerServer(deps *ServerDeps) *GRPCShortenerServer {
@@ -28,20 +37,52 @@ func NewGRPCShortenerServer(deps *ServerDeps) *GRPCShortenerServer {
 		logger:       deps.Logger,
 		shortenProc:  deps.APIShortenProc,
 		expandProc:   deps.ExpandProc,
+		inspectProc:  deps.InspectProc,
+		qrCodeProc:   deps.QRCodeProc,
 		userURLsProc: deps.APIUserURLsProc,
+		targetProc:   deps.APIUserTargetProc,
+		splitProc:    deps.APIUserSplitProc,
+		collProc:     deps.APIUserCollProc,
 	}
 	return &server
 }
 
 func (s *GRPCShortenerServer) ShortenURL(ctx context.Context, req *pb.URLShortenRequest) (*pb.URLShortenResponse, error) {
 	r := model.ShortenRequest{
-		OrigURL: req.GetUrl(),
+		OrigURL:      req.GetUrl(),
+		Alias:        req.GetAlias(),
+		IDStyle:      req.GetIdStyle(),
+		MaxClicks:    int(req.GetMaxClicks()),
+		Password:     req.GetPassword(),
+		Tags:         req.GetTags(),
+		RedirectType: int(req.GetRedirectType()),
+		Passthrough:  req.GetPassthrough(),
+	}
+	if req.HasExpiresAt() {
+		expiresAt := req.GetExpiresAt().AsTime()
+		r.ExpiresAt = &expiresAt
+	}
+	if req.HasTtl() {
+		// TTL is counted in whole seconds, so a shorter one would silently mean no expiration.
+		r.TTL = int64(req.GetTtl().AsDuration() / time.Second)
+		if r.TTL <= 0 {
+			return nil, status.Error(codes.InvalidArgument, "ttl must be at least one second")
+		}
 	}
 	result, err := s.shortenProc.Process(ctx, r)
+	var vErr *service.ValidationError
 	if errors.Is(err, service.ErrEmptyInputURL) {
 		return nil, status.Error(codes.InvalidArgument, "empty input url")
+	} else if errors.As(err, &vErr) {
+		return nil, status.Error(codes.InvalidArgument, vErr.Error())
+	} else if errors.Is(err, service.ErrAliasTaken) {
+		return nil, status.Error(codes.AlreadyExists, "alias already taken")
+	} else if errors.Is(err, service.ErrAliasURLExists) {
+		return nil, status.Error(codes.AlreadyExists, "url already exists, alias not created")
 	} else if errors.Is(err, service.ErrURLAlreadyExists) {
 		return nil, status.Error(codes.AlreadyExists, "url already exists")
+	} else if errors.Is(err, service.ErrURLBlocked) {
+		return nil, status.Error(codes.PermissionDenied, "url is blocked")
 	} else if err != nil {
 		s.logger.Error("failed to shorten", zap.Error(err))
 		return nil, status.Error(codes.Internal, "internal error")
@@ -55,44 +96,544 @@ func (s *GRPCShortenerServer) ShortenURL(ctx context.Context, req *pb.URLShorten
 }
 
 func (s *GRPCShortenerServer) ExpandURL(ctx context.Context, req *pb.URLExpandRequest) (*pb.URLExpandResponse, error) {
-	shortID := req.GetId()
+	r := model.ExpandRequest{
+		ShortID:  req.GetId(),
+		Password: req.GetPassword(),
+		Path:     req.GetPath(),
+		RawQuery: req.GetQuery(),
+		Variant:  req.GetVariant(),
+	}
+	if len(req.GetHeaders()) > 0 {
+		r.Header = make(http.Header, len(req.GetHeaders()))
+		for name, value := range req.GetHeaders() {
+			r.Header.Set(name, value)
+		}
+	}
 
-	origURL, err := s.expandProc.Process(ctx, shortID)
-	var nfErr *repository.DataNotFoundError
-	if errors.As(err, &nfErr) {
+	result, err := s.expandProc.Process(ctx, r)
+	var (
+		nfErr *repository.DataNotFoundError
+		csErr *service.ChecksumError
+		vErr  *service.ValidationError
+	)
+	if errors.As(err, &csErr) {
+		return nil, status.Errorf(codes.NotFound, "url not found, did you mean: %s", strings.Join(csErr.Suggestions, ", "))
+	} else if errors.As(err, &vErr) {
+		return nil, status.Error(codes.InvalidArgument, vErr.Error())
+	} else if errors.As(err, &nfErr) {
 		return nil, status.Error(codes.NotFound, "url not found")
 	} else if errors.Is(err, repository.ErrDataDeleted) {
 		return nil, status.Error(codes.FailedPrecondition, "data is already deleted")
+	} else if errors.Is(err, repository.ErrDataExpired) {
+		return nil, status.Error(codes.FailedPrecondition, "url is expired")
+	} else if errors.Is(err, repository.ErrClicksExhausted) {
+		return nil, status.Error(codes.FailedPrecondition, "url clicks limit is exhausted")
+	} else if errors.Is(err, service.ErrPasswordRequired) {
+		return nil, status.Error(codes.PermissionDenied, "password required")
+	} else if errors.Is(err, service.ErrWrongPassword) {
+		return nil, status.Error(codes.PermissionDenied, "wrong password")
+	} else if errors.Is(err, service.ErrTooManyAttempts) {
+		return nil, status.Error(codes.ResourceExhausted, "too many password attempts")
+	} else if errors.Is(err, service.ErrURLBlocked) {
+		return nil, status.Error(codes.PermissionDenied, "url is blocked")
 	} else if err != nil {
 		s.logger.Error("failed to expand short url", zap.Error(err))
 		return nil, status.Error(codes.Internal, "internal error")
 	}
 
 	res := pb.URLExpandResponse_builder{
-		Result: proto.String(origURL),
+		Result:       proto.String(result.OrigURL),
+		RedirectType: proto.Int32(int32(result.RedirectType)),
+		Variant:      proto.String(result.Variant),
+		Sticky:       proto.Bool(result.Sticky),
 	}.Build()
 	return res, nil
 }
 
-func (s *GRPCShortenerServer) ListUserURLs(ctx context.Context, _ *pb.UserURLsRequest) (*pb.UserURLsResponse, error) {
-	respItems, err := s.userURLsProc.ProcessGet(ctx)
-	if err != nil {
+func (s *GRPCShortenerServer) InspectURL(ctx context.Context, req *pb.URLInspectRequest) (*pb.URLInspectResponse, error) {
+	result, err := s.inspectProc.Process(ctx, req.GetId())
+	var (
+		nfErr *repository.DataNotFoundError
+		csErr *service.ChecksumError
+	)
+	if errors.As(err, &csErr) {
+		return nil, status.Errorf(codes.NotFound, "url not found, did you mean: %s", strings.Join(csErr.Suggestions, ", "))
+	} else if errors.As(err, &nfErr) {
+		return nil, status.Error(codes.NotFound, "url not found")
+	} else if errors.Is(err, repository.ErrDataDeleted) {
+		return nil, status.Error(codes.FailedPrecondition, "data is already deleted")
+	} else if errors.Is(err, repository.ErrDataExpired) {
+		return nil, status.Error(codes.FailedPrecondition, "url is expired")
+	} else if errors.Is(err, repository.ErrClicksExhausted) {
+		return nil, status.Error(codes.FailedPrecondition, "url clicks limit is exhausted")
+	} else if err != nil {
+		s.logger.Error("failed to inspect short url", zap.Error(err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	b := pb.URLInspectResponse_builder{
+		ShortUrl:    proto.String(result.ShortURL),
+		OriginalUrl: proto.String(result.OrigURL),
+		Protected:   proto.Bool(result.Protected),
+		Safety:      proto.String(result.Safety),
+	}
+	if result.CreatedAt != nil {
+		b.CreatedAt = timestamppb.New(*result.CreatedAt)
+	}
+	if result.ExpiresAt != nil {
+		b.ExpiresAt = timestamppb.New(*result.ExpiresAt)
+	}
+	return b.Build(), nil
+}
+
+func (s *GRPCShortenerServer) GetQRCode(ctx context.Context, req *pb.QRCodeRequest) (*pb.QRCodeResponse, error) {
+	opts := model.QROptions{
+		Format:     req.GetFormat(),
+		Size:       int(req.GetSize()),
+		Level:      req.GetLevel(),
+		Foreground: req.GetForeground(),
+		Background: req.GetBackground(),
+	}
+	if req.HasMargin() {
+		margin := int(req.GetMargin())
+		opts.Margin = &margin
+	}
+
+	code, err := s.qrCodeProc.Process(ctx, req.GetId(), opts)
+	var (
+		nfErr *repository.DataNotFoundError
+		vErr  *service.ValidationError
+	)
+	if errors.As(err, &vErr) {
+		return nil, status.Error(codes.InvalidArgument, vErr.Error())
+	} else if errors.As(err, &nfErr) {
+		return nil, status.Error(codes.NotFound, "url not found")
+	} else if errors.Is(err, repository.ErrDataDeleted) {
+		return nil, status.Error(codes.FailedPrecondition, "data is already deleted")
+	} else if errors.Is(err, repository.ErrDataExpired) {
+		return nil, status.Error(codes.FailedPrecondition, "url is expired")
+	} else if errors.Is(err, repository.ErrClicksExhausted) {
+		return nil, status.Error(codes.FailedPrecondition, "url clicks limit is exhausted")
+	} else if err != nil {
+		s.logger.Error("failed to generate qr code", zap.Error(err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	res := pb.QRCodeResponse_builder{
+		Data:        code.Data,
+		ContentType: proto.String(code.ContentType),
+	}.Build()
+	return res, nil
+}
+
+func (s *GRPCShortenerServer) ListUserURLs(ctx context.Context, req *pb.UserURLsRequest) (*pb.UserURLsResponse, error) {
+	respItems, err := s.userURLsProc.ProcessGet(ctx, req.GetTag())
+	var vErr *service.ValidationError
+	if errors.As(err, &vErr) {
+		return nil, status.Error(codes.InvalidArgument, vErr.Error())
+	} else if err != nil {
 		s.logger.Error("error getting user urls", zap.Error(err))
 		return nil, status.Error(codes.Internal, "internal error")
 	}
 
 	urlDataList := make([]*pb.URLData, 0, len(respItems))
 	for _, item := range respItems {
-		data := pb.URLData_builder{
+		urlDataList = append(urlDataList, buildURLData(item))
+	}
+
+	res := pb.UserURLsResponse_builder{
+		Url: urlDataList,
+	}.Build()
+
+	return res, nil
+}
+
+func (s *GRPCShortenerServer) ListUserTags(ctx context.Context, _ *pb.UserTagsRequest) (*pb.UserTagsResponse, error) {
+	respItems, err := s.userURLsProc.ProcessGetTags(ctx)
+	if err != nil {
+		s.logger.Error("error getting user tags", zap.Error(err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	tags := make([]*pb.TagCount, 0, len(respItems))
+	for _, item := range respItems {
+		t := pb.TagCount_builder{
+			Tag:   proto.String(item.Tag),
+			Count: proto.Int32(int32(item.Count)),
+		}.Build()
+		tags = append(tags, t)
+	}
+
+	res := pb.UserTagsResponse_builder{
+		Tag: tags,
+	}.Build()
+
+	return res, nil
+}
+
+func (s *GRPCShortenerServer) ListTrashURLs(ctx context.Context, _ *pb.UserURLsRequest) (*pb.UserURLsResponse, error) {
+	respItems, err := s.userURLsProc.ProcessGetTrash(ctx)
+	if err != nil {
+		s.logger.Error("error getting user trash", zap.Error(err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	urlDataList := make([]*pb.URLData, 0, len(respItems))
+	for _, item := range respItems {
+		urlDataList = append(urlDataList, buildURLData(item))
+	}
+
+	res := pb.UserURLsResponse_builder{
+		Url: urlDataList,
+	}.Build()
+
+	return res, nil
+}
+
+func (s *GRPCShortenerServer) RestoreURLs(ctx context.Context, req *pb.URLRestoreRequest) (*pb.URLRestoreResponse, error) {
+	respItems, err := s.userURLsProc.ProcessRestore(ctx, req.GetId())
+	if errors.Is(err, service.ErrEmptyInputBatch) {
+		return nil, status.Error(codes.InvalidArgument, "empty input batch")
+	} else if err != nil {
+		s.logger.Error("error restoring user urls", zap.Error(err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	results := make([]*pb.URLRestoreResult, 0, len(respItems))
+	for _, item := range respItems {
+		r := pb.URLRestoreResult_builder{
 			ShortUrl:    proto.String(item.ShortURL),
 			OriginalUrl: proto.String(item.OrigURL),
+			Status:      proto.String(item.Status),
 		}.Build()
-		urlDataList = append(urlDataList, data)
+		results = append(results, r)
+	}
+
+	res := pb.URLRestoreResponse_builder{
+		Result: results,
+	}.Build()
+
+	return res, nil
+}
+
+func (s *GRPCShortenerServer) UpdateURL(ctx context.Context, req *pb.URLUpdateRequest) (*pb.URLUpdateResponse, error) {
+	r := model.UserURLUpdateRequest{
+		OrigURL: req.GetUrl(),
+	}
+	if req.HasTags() {
+		tags := req.GetTags().GetTag()
+		r.Tags = &tags
+	}
+
+	item, err := s.userURLsProc.ProcessUpdate(ctx, req.GetId(), r)
+	var (
+		nfErr *repository.DataNotFoundError
+		vErr  *service.ValidationError
+	)
+	if errors.Is(err, service.ErrEmptyInputURL) {
+		return nil, status.Error(codes.InvalidArgument, "empty input url")
+	} else if errors.As(err, &vErr) {
+		return nil, status.Error(codes.InvalidArgument, vErr.Error())
+	} else if errors.As(err, &nfErr) {
+		return nil, status.Error(codes.NotFound, "url not found")
+	} else if errors.Is(err, service.ErrURLAlreadyExists) {
+		return nil, status.Error(codes.AlreadyExists, "url already exists")
+	} else if errors.Is(err, repository.ErrDataDeleted) {
+		return nil, status.Error(codes.FailedPrecondition, "data is already deleted")
+	} else if errors.Is(err, repository.ErrDataExpired) {
+		return nil, status.Error(codes.FailedPrecondition, "url is expired")
+	} else if errors.Is(err, repository.ErrClicksExhausted) {
+		return nil, status.Error(codes.FailedPrecondition, "url clicks limit is exhausted")
+	} else if errors.Is(err, service.ErrURLBlocked) {
+		return nil, status.Error(codes.PermissionDenied, "url is blocked")
+	} else if err != nil {
+		s.logger.Error("failed to update user url", zap.Error(err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	res := pb.URLUpdateResponse_builder{
+		Result: buildURLData(*item),
+	}.Build()
+	return res, nil
+}
+
+func (s *GRPCShortenerServer) GetTargetingRules(
+	ctx context.Context,
+	req *pb.TargetingRulesRequest,
+) (*pb.TargetingRulesResponse, error) {
+	rules, err := s.targetProc.ProcessGet(ctx, req.GetId())
+	if err != nil {
+		return nil, s.linkSettingsError(err, "error getting targeting rules")
+	}
+	return buildTargetingRulesResponse(rules), nil
+}
+
+func (s *GRPCShortenerServer) SetTargetingRules(
+	ctx context.Context,
+	req *pb.SetTargetingRulesRequest,
+) (*pb.TargetingRulesResponse, error) {
+	r := make(model.UserTargetingRules, 0, len(req.GetRule()))
+	for _, rule := range req.GetRule() {
+		r = append(r, model.UserTargetingRule{
+			Browser:     rule.GetBrowser(),
+			OS:          rule.GetOs(),
+			Language:    rule.GetLanguage(),
+			Header:      rule.GetHeader(),
+			HeaderValue: rule.GetHeaderValue(),
+			URL:         rule.GetUrl(),
+		})
+	}
+
+	rules, err := s.targetProc.ProcessSet(ctx, req.GetId(), r)
+	if err != nil {
+		return nil, s.linkSettingsError(err, "error setting targeting rules")
+	}
+	return buildTargetingRulesResponse(rules), nil
+}
+
+func (s *GRPCShortenerServer) GetSplit(ctx context.Context, req *pb.SplitRequest) (*pb.SplitResponse, error) {
+	split, err := s.splitProc.ProcessGet(ctx, req.GetId())
+	if err != nil {
+		return nil, s.linkSettingsError(err, "error getting split test")
+	}
+	return buildSplitResponse(split), nil
+}
+
+func (s *GRPCShortenerServer) SetSplit(ctx context.Context, req *pb.SetSplitRequest) (*pb.SplitResponse, error) {
+	r := &model.UserSplit{
+		Sticky:   req.GetSticky(),
+		Variants: make([]model.UserSplitVariant, 0, len(req.GetVariant())),
+	}
+	for _, v := range req.GetVariant() {
+		r.Variants = append(r.Variants, model.UserSplitVariant{
+			Name:   v.GetName(),
+			URL:    v.GetUrl(),
+			Weight: int(v.GetWeight()),
+		})
+	}
+
+	split, err := s.splitProc.ProcessSet(ctx, req.GetId(), r)
+	if err != nil {
+		return nil, s.linkSettingsError(err, "error setting split test")
+	}
+	return buildSplitResponse(split), nil
+}
+
+func (s *GRPCShortenerServer) ListCollections(
+	ctx context.Context,
+	_ *pb.CollectionsRequest,
+) (*pb.CollectionsResponse, error) {
+	respItems, err := s.collProc.ProcessGet(ctx)
+	if err != nil {
+		s.logger.Error("error getting user collections", zap.Error(err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	list := make([]*pb.CollectionData, 0, len(respItems))
+	for _, item := range respItems {
+		list = append(list, buildCollectionData(item))
+	}
+
+	res := pb.CollectionsResponse_builder{
+		Collection: list,
+	}.Build()
+	return res, nil
+}
+
+func (s *GRPCShortenerServer) CreateCollection(
+	ctx context.Context,
+	req *pb.CreateCollectionRequest,
+) (*pb.CollectionData, error) {
+	item, err := s.collProc.ProcessCreate(ctx, model.UserCollectionRequest{Name: req.GetName()})
+	if err != nil {
+		return nil, s.collectionError(err, "error creating user collection")
+	}
+	return buildCollectionData(*item), nil
+}
+
+func (s *GRPCShortenerServer) RenameCollection(
+	ctx context.Context,
+	req *pb.RenameCollectionRequest,
+) (*pb.CollectionData, error) {
+	item, err := s.collProc.ProcessRename(ctx, req.GetId(), model.UserCollectionRequest{Name: req.GetName()})
+	if err != nil {
+		return nil, s.collectionError(err, "error renaming user collection")
+	}
+	return buildCollectionData(*item), nil
+}
+
+func (s *GRPCShortenerServer) DeleteCollection(
+	ctx context.Context,
+	req *pb.CollectionRequest,
+) (*pb.DeleteCollectionResponse, error) {
+	if err := s.collProc.ProcessDelete(ctx, req.GetId()); err != nil {
+		return nil, s.collectionError(err, "error deleting user collection")
+	}
+	return &pb.DeleteCollectionResponse{}, nil
+}
+
+func (s *GRPCShortenerServer) MoveToCollection(
+	ctx context.Context,
+	req *pb.MoveToCollectionRequest,
+) (*pb.MoveToCollectionResponse, error) {
+	r := model.UserCollectionMoveRequest{
+		CollectionID: req.GetCollectionId(),
+		ShortURLs:    req.GetShortUrl(),
+	}
+	if err := s.collProc.ProcessMove(ctx, r); err != nil {
+		return nil, s.collectionError(err, "error moving user urls to collection")
+	}
+	return &pb.MoveToCollectionResponse{}, nil
+}
+
+func (s *GRPCShortenerServer) ListCollectionURLs(
+	ctx context.Context,
+	req *pb.CollectionRequest,
+) (*pb.UserURLsResponse, error) {
+	respItems, err := s.collProc.ProcessGetURLs(ctx, req.GetId())
+	if err != nil {
+		return nil, s.collectionError(err, "error getting user collection urls")
+	}
+
+	urlDataList := make([]*pb.URLData, 0, len(respItems))
+	for _, item := range respItems {
+		urlDataList = append(urlDataList, buildURLData(item))
 	}
 
 	res := pb.UserURLsResponse_builder{
 		Url: urlDataList,
 	}.Build()
+	return res, nil
+}
+
+func (s *GRPCShortenerServer) DeleteCollectionURLs(
+	ctx context.Context,
+	req *pb.CollectionRequest,
+) (*pb.DeleteCollectionURLsResponse, error) {
+	resp, err := s.collProc.ProcessDeleteURLs(ctx, req.GetId())
+	if err != nil {
+		return nil, s.collectionError(err, "error deleting user collection urls")
+	}
 
+	res := pb.DeleteCollectionURLsResponse_builder{
+		Deleted: proto.Int32(int32(resp.Deleted)),
+	}.Build()
 	return res, nil
 }
+
+func (s *GRPCShortenerServer) ExportCollection(
+	ctx context.Context,
+	req *pb.ExportCollectionRequest,
+) (*pb.ExportCollectionResponse, error) {
+	exp, err := s.collProc.ProcessExport(ctx, req.GetId(), req.GetFormat())
+	if err != nil {
+		return nil, s.collectionError(err, "error exporting user collection")
+	}
+
+	res := pb.ExportCollectionResponse_builder{
+		Data:        exp.Data,
+		ContentType: proto.String(exp.ContentType),
+		FileName:    proto.String(exp.FileName),
+	}.Build()
+	return res, nil
+}
+
+// collectionError converts an error of management of collections of user's links to its gRPC status.
+func (s *GRPCShortenerServer) collectionError(err error, msg string) error {
+	var (
+		nfErr *repository.DataNotFoundError
+		vErr  *service.ValidationError
+	)
+	if errors.As(err, &vErr) {
+		return status.Error(codes.InvalidArgument, vErr.Error())
+	} else if errors.As(err, &nfErr) {
+		return status.Error(codes.NotFound, "collection or url not found")
+	} else if errors.Is(err, service.ErrCollectionNameTaken) {
+		return status.Error(codes.AlreadyExists, "collection name already taken")
+	}
+	s.logger.Error(msg, zap.Error(err))
+	return status.Error(codes.Internal, "internal error")
+}
+
+// linkSettingsError converts an error of management of targeting rules or split tests of a short URL
+// to its gRPC status.
+func (s *GRPCShortenerServer) linkSettingsError(err error, msg string) error {
+	var (
+		nfErr *repository.DataNotFoundError
+		vErr  *service.ValidationError
+	)
+	if errors.As(err, &vErr) {
+		return status.Error(codes.InvalidArgument, vErr.Error())
+	} else if errors.As(err, &nfErr) {
+		return status.Error(codes.NotFound, "url not found")
+	} else if errors.Is(err, repository.ErrDataDeleted) {
+		return status.Error(codes.FailedPrecondition, "data is already deleted")
+	} else if errors.Is(err, repository.ErrDataExpired) {
+		return status.Error(codes.FailedPrecondition, "url is expired")
+	} else if errors.Is(err, repository.ErrClicksExhausted) {
+		return status.Error(codes.FailedPrecondition, "url clicks limit is exhausted")
+	} else if errors.Is(err, service.ErrURLBlocked) {
+		return status.Error(codes.PermissionDenied, "url is blocked")
+	}
+	s.logger.Error(msg, zap.Error(err))
+	return status.Error(codes.Internal, "internal error")
+}
+
+// buildTargetingRulesResponse converts targeting rules of a short URL to their protobuf representation.
+func buildTargetingRulesResponse(rules model.UserTargetingRules) *pb.TargetingRulesResponse {
+	list := make([]*pb.TargetingRule, 0, len(rules))
+	for _, r := range rules {
+		list = append(list, pb.TargetingRule_builder{
+			Browser:     proto.String(r.Browser),
+			Os:          proto.String(r.OS),
+			Language:    proto.String(r.Language),
+			Header:      proto.String(r.Header),
+			HeaderValue: proto.String(r.HeaderValue),
+			Url:         proto.String(r.URL),
+		}.Build())
+	}
+	return pb.TargetingRulesResponse_builder{
+		Rule: list,
+	}.Build()
+}
+
+// buildSplitResponse converts a split test of a short URL to its protobuf representation.
+func buildSplitResponse(split *model.UserSplit) *pb.SplitResponse {
+	list := make([]*pb.SplitVariant, 0, len(split.Variants))
+	for _, v := range split.Variants {
+		list = append(list, pb.SplitVariant_builder{
+			Name:   proto.String(v.Name),
+			Url:    proto.String(v.URL),
+			Weight: proto.Int32(int32(v.Weight)),
+		}.Build())
+	}
+	return pb.SplitResponse_builder{
+		Sticky:  proto.Bool(split.Sticky),
+		Variant: list,
+	}.Build()
+}
+
+// buildCollectionData converts a user collection to its protobuf representation.
+func buildCollectionData(item model.UserCollection) *pb.CollectionData {
+	return pb.CollectionData_builder{
+		Id:        proto.String(item.ID),
+		Name:      proto.String(item.Name),
+		CreatedAt: timestamppb.New(item.CreatedAt),
+		Count:     proto.Int32(int32(item.Count)),
+	}.Build()
+}
+
+// buildURLData converts a user URL response item to its protobuf representation.
+func buildURLData(item model.UserURLsGetResponseItem) *pb.URLData {
+	b := pb.URLData_builder{
+		ShortUrl:     proto.String(item.ShortURL),
+		OriginalUrl:  proto.String(item.OrigURL),
+		Tags:         item.Tags,
+		RedirectType: proto.Int32(int32(item.RedirectType)),
+		Passthrough:  proto.Bool(item.Passthrough),
+	}
+	if item.ExpiresAt != nil {
+		b.ExpiresAt = timestamppb.New(*item.ExpiresAt)
+	}
+	return b.Build()
+}
@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"html/template"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/alex-storchak/shortener/internal/codec"
+	"github.com/alex-storchak/shortener/internal/model"
+	"github.com/alex-storchak/shortener/internal/repository"
+	"github.com/alex-storchak/shortener/internal/service"
+)
+
+// previewTmpl renders the page showing the details of a short URL without following it.
+// The link to continue points to the short URL itself, so following it is counted as usual;
+// it is not offered for blocked destinations.
+var previewTmpl = template.Must(template.New("preview").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+<title>Link preview</title>
+</head>
+<body>
+<h1>Where does this link go?</h1>
+<p>The short link <code>{{.ShortURL}}</code> leads to:</p>
+{{if .Protected}}<p>The destination is hidden because the link is password-protected.</p>
+{{else}}<p><code>{{.OrigURL}}</code></p>
+{{end}}<dl>
+{{with .CreatedAt}}<dt>Created</dt><dd>{{.UTC.Format "2006-01-02 15:04 MST"}}</dd>
+{{end}}{{with .ExpiresAt}}<dt>Expires</dt><dd>{{.UTC.Format "2006-01-02 15:04 MST"}}</dd>
+{{end}}<dt>Safety</dt><dd>{{if eq .Safety "blocked"}}Blocked: the destination matches the blocklist{{else}}Safe{{end}}</dd>
+</dl>
+{{if ne .Safety "blocked"}}<p><a href="{{.ShortURL}}" rel="nofollow">Continue to the destination</a></p>
+{{end}}</body>
+</html>
+`))
+
+// InspectProcessor defines the interface for processing short URL inspection requests.
+// Implementations retrieve the details of short URLs without following them.
+type InspectProcessor interface {
+	Process(ctx context.Context, shortID string) (*model.InspectResponse, error)
+}
+
+// HandlePreview creates an HTTP handler for the preview page of short URLs.
+// It handles GET requests to '/{shortID}/preview' and '/{shortID}+' endpoints, rendering an HTML page
+// with the destination, the creation date and the safety status of the link instead of redirecting.
+// The preview neither counts as a click nor is recorded as a follow; the destination of password-protected
+// links is not disclosed. If forward is given, requests to links with passthrough enabled are served by forward
+// instead, so that the '/preview' path suffix reaches the destination as before the route existed;
+// the preview of such links stays available at '/{shortID}+'.
+//
+// The handler:
+//   - Processes the inspection request to retrieve the details of the URL
+//   - Returns appropriate HTTP status codes:
+//   - 200 OK with the HTML preview page
+//   - 404 Not Found when short ID doesn't exist
+//   - 404 Not Found with an HTML page of suggested corrections when the check character of short ID is wrong
+//   - 410 Gone when the URL has been deleted, has expired or has no follows left
+//   - 500 Internal Server Error for processing failures
+//
+// Parameters:
+//   - p: Processor implementing the URL inspection logic
+//   - forward: handler redirecting requests of passthrough links to the destination with the path suffix;
+//     nil to render the preview of every link
+//   - l: Logger for logging operations
+//
+// Returns:
+//   - HTTP handler function for the preview endpoint
+func HandlePreview(p InspectProcessor, forward http.Handler, l *zap.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, err := p.Process(r.Context(), chi.URLParam(r, ShortIDParam))
+		if err != nil {
+			writeExpandError(w, err, l)
+			return
+		}
+		if forward != nil && res.Passthrough {
+			forward.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(http.StatusOK)
+		if err := previewTmpl.Execute(w, res); err != nil {
+			l.Error("render preview page", zap.Error(err))
+		}
+	}
+}
+
+// HandleAPIExpand creates an HTTP handler for inspecting short URLs via the JSON API.
+// It handles GET requests to '/api/expand/{id}' endpoint, the JSON counterpart of the preview page.
+// The inspection neither counts as a click nor is recorded as a follow.
+//
+// The handler:
+//   - Processes the inspection request to retrieve the details of the URL
+//   - Returns appropriate HTTP status codes:
+//   - 200 OK with model.InspectResponse
+//   - 404 Not Found when short ID doesn't exist or its check character is wrong
+//   - 410 Gone when the URL has been deleted, has expired or has no follows left
+//   - 500 Internal Server Error for processing failures
+//
+// Parameters:
+//   - p: Processor implementing the URL inspection logic
+//   - l: Logger for logging operations
+//
+// Returns:
+//   - HTTP handler function for the API expand endpoint
+func HandleAPIExpand(p InspectProcessor, l *zap.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, err := p.Process(r.Context(), chi.URLParam(r, ShortIDParam))
+		var (
+			nfErr *repository.DataNotFoundError
+			csErr *service.ChecksumError
+		)
+		if errors.As(err, &nfErr) || errors.As(err, &csErr) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		} else if isGoneError(err) {
+			w.WriteHeader(http.StatusGone)
+			return
+		} else if err != nil {
+			l.Error("error inspecting short url", zap.Error(err))
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if err = codec.EasyJSONEncode(w, http.StatusOK, res); err != nil {
+			l.Error("encode json response", zap.Error(err))
+			return
+		}
+	}
+}
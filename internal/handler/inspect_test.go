@@ -0,0 +1,275 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/alex-storchak/shortener/internal/model"
+	repo "github.com/alex-storchak/shortener/internal/repository"
+	"github.com/alex-storchak/shortener/internal/service"
+)
+
+type inspectSrvStub struct {
+	result *model.InspectResponse
+	err    error
+	gotID  string
+}
+
+func (s *inspectSrvStub) Process(_ context.Context, shortID string) (*model.InspectResponse, error) {
+	s.gotID = shortID
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.result, nil
+}
+
+// stubExpandProc fails the test if a preview is routed to the expand handler.
+type stubExpandProc struct {
+	t *testing.T
+}
+
+func (s stubExpandProc) Process(context.Context, model.ExpandRequest) (*model.ExpandResult, error) {
+	s.t.Error("preview must not follow the link")
+	return nil, errors.New("followed")
+}
+
+func TestPreview(t *testing.T) {
+	createdAt := time.Date(2026, 3, 14, 9, 26, 0, 0, time.UTC)
+	tests := []struct {
+		name         string
+		target       string
+		result       *model.InspectResponse
+		err          error
+		wantCode     int
+		wantContains []string
+		wantMissing  []string
+	}{
+		{
+			name:   "preview path returns 200 (OK) with destination",
+			target: "/abcde/preview",
+			result: &model.InspectResponse{
+				ShortURL:  "http://localhost:8080/abcde",
+				OrigURL:   "https://example.com/page",
+				CreatedAt: &createdAt,
+				Safety:    model.URLSafetySafe,
+			},
+			wantCode: http.StatusOK,
+			wantContains: []string{
+				"https://example.com/page", "2026-03-14 09:26 UTC", "Safe",
+				`<a href="http://localhost:8080/abcde"`,
+			},
+		},
+		{
+			name:   "plus suffix returns 200 (OK) with destination",
+			target: "/abcde+",
+			result: &model.InspectResponse{
+				ShortURL: "http://localhost:8080/abcde",
+				OrigURL:  "https://example.com/page",
+				Safety:   model.URLSafetySafe,
+			},
+			wantCode:     http.StatusOK,
+			wantContains: []string{"https://example.com/page"},
+			wantMissing:  []string{"Created"},
+		},
+		{
+			name:   "protected link hides destination",
+			target: "/abcde+",
+			result: &model.InspectResponse{
+				ShortURL:  "http://localhost:8080/abcde",
+				Protected: true,
+				Safety:    model.URLSafetySafe,
+			},
+			wantCode:     http.StatusOK,
+			wantContains: []string{"password-protected"},
+		},
+		{
+			name:   "blocked link has no link to continue",
+			target: "/abcde/preview",
+			result: &model.InspectResponse{
+				ShortURL: "http://localhost:8080/abcde",
+				OrigURL:  "https://evil.com",
+				Safety:   model.URLSafetyBlocked,
+			},
+			wantCode:     http.StatusOK,
+			wantContains: []string{"Blocked"},
+			wantMissing:  []string{"Continue"},
+		},
+		{
+			name:     "not existing short url returns 404 (Not Found)",
+			target:   "/abcde/preview",
+			err:      repo.NewDataNotFoundError(nil),
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:         "wrong check character returns 404 (Not Found) with suggestions",
+			target:       "/abcde+",
+			err:          &service.ChecksumError{ShortID: "abcde", Suggestions: []string{"abcdf"}},
+			wantCode:     http.StatusNotFound,
+			wantContains: []string{`<a href="abcdf">`},
+		},
+		{
+			name:     "expired short url returns 410 (Gone)",
+			target:   "/abcde/preview",
+			err:      repo.ErrDataExpired,
+			wantCode: http.StatusGone,
+		},
+		{
+			name:     "random error returns 500 (Internal Server Error)",
+			target:   "/abcde/preview",
+			err:      errors.New("random error"),
+			wantCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := &inspectSrvStub{result: tt.result, err: tt.err}
+			mux := chi.NewRouter()
+			mux.Get("/{id:[a-zA-Z0-9_-]+}", HandleExpand(stubExpandProc{t}, zap.NewNop()))
+			mux.Get("/{id:[a-zA-Z0-9_-]+}+", HandlePreview(srv, nil, zap.NewNop()))
+			mux.Get("/{id:[a-zA-Z0-9_-]+}/preview", HandlePreview(srv, HandleExpand(stubExpandProc{t}, zap.NewNop()), zap.NewNop()))
+			mux.Get("/{id:[a-zA-Z0-9_-]+}/*", HandleExpand(stubExpandProc{t}, zap.NewNop()))
+
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.target, nil))
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, tt.wantCode, res.StatusCode)
+			assert.Equal(t, "abcde", srv.gotID)
+			body, err := io.ReadAll(res.Body)
+			require.NoError(t, err)
+			for _, s := range tt.wantContains {
+				assert.Contains(t, string(body), s)
+			}
+			for _, s := range tt.wantMissing {
+				assert.NotContains(t, string(body), s)
+			}
+			if tt.wantCode == http.StatusOK {
+				assert.Equal(t, "text/html; charset=utf-8", res.Header.Get("Content-Type"))
+				assert.Equal(t, "no-store", res.Header.Get("Cache-Control"))
+			}
+		})
+	}
+}
+
+// passthroughExpandProc redirects to the destination with the forwarded path suffix and query.
+type passthroughExpandProc struct {
+	got model.ExpandRequest
+}
+
+func (s *passthroughExpandProc) Process(_ context.Context, req model.ExpandRequest) (*model.ExpandResult, error) {
+	s.got = req
+	return &model.ExpandResult{
+		OrigURL:      "https://example.com" + req.Path + "?" + req.RawQuery,
+		RedirectType: http.StatusTemporaryRedirect,
+	}, nil
+}
+
+func TestPreview_Passthrough(t *testing.T) {
+	srv := &inspectSrvStub{result: &model.InspectResponse{
+		ShortURL:    "http://localhost:8080/abcde",
+		OrigURL:     "https://example.com",
+		Safety:      model.URLSafetySafe,
+		Passthrough: true,
+	}}
+	expand := &passthroughExpandProc{}
+	mux := chi.NewRouter()
+	mux.Get("/{id:[a-zA-Z0-9_-]+}+", HandlePreview(srv, nil, zap.NewNop()))
+	mux.Get("/{id:[a-zA-Z0-9_-]+}/preview", HandlePreview(srv, HandleExpand(expand, zap.NewNop()), zap.NewNop()))
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/abcde/preview?page=2", nil))
+	res := w.Result()
+	defer res.Body.Close()
+
+	assert.Equal(t, http.StatusTemporaryRedirect, res.StatusCode)
+	assert.Equal(t, "https://example.com/preview?page=2", res.Header.Get("Location"))
+	assert.Equal(t, "/preview", expand.got.Path)
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/abcde+", nil))
+	assert.Equal(t, http.StatusOK, w.Code, "plus suffix keeps the preview of passthrough links")
+}
+
+func TestAPIExpand(t *testing.T) {
+	expiresAt := time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		result   *model.InspectResponse
+		err      error
+		wantCode int
+		wantBody string
+	}{
+		{
+			name: "existing short url returns 200 (OK) with json",
+			result: &model.InspectResponse{
+				ShortURL:  "http://localhost:8080/abcde",
+				OrigURL:   "https://example.com",
+				ExpiresAt: &expiresAt,
+				Safety:    model.URLSafetySafe,
+			},
+			wantCode: http.StatusOK,
+			wantBody: `{"short_url":"http://localhost:8080/abcde","original_url":"https://example.com","expires_at":"2026-12-31T00:00:00Z","safety":"safe"}`,
+		},
+		{
+			name: "protected short url returns 200 (OK) without original url",
+			result: &model.InspectResponse{
+				ShortURL:  "http://localhost:8080/abcde",
+				Protected: true,
+				Safety:    model.URLSafetyBlocked,
+			},
+			wantCode: http.StatusOK,
+			wantBody: `{"short_url":"http://localhost:8080/abcde","protected":true,"safety":"blocked"}`,
+		},
+		{
+			name:     "not existing short url returns 404 (Not Found)",
+			err:      repo.NewDataNotFoundError(nil),
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "wrong check character returns 404 (Not Found)",
+			err:      &service.ChecksumError{ShortID: "abcde", Suggestions: []string{"abcdf"}},
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "deleted short url returns 410 (Gone)",
+			err:      repo.ErrDataDeleted,
+			wantCode: http.StatusGone,
+		},
+		{
+			name:     "random error returns 500 (Internal Server Error)",
+			err:      errors.New("random error"),
+			wantCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := &inspectSrvStub{result: tt.result, err: tt.err}
+			mux := chi.NewRouter()
+			mux.Get("/api/expand/{id}", HandleAPIExpand(srv, zap.NewNop()))
+
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/expand/abcde", nil))
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, tt.wantCode, res.StatusCode)
+			assert.Equal(t, "abcde", srv.gotID)
+			if tt.wantBody != "" {
+				body, err := io.ReadAll(res.Body)
+				require.NoError(t, err)
+				assert.JSONEq(t, tt.wantBody, string(body))
+			}
+		})
+	}
+}
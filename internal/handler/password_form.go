@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"html/template"
+	"net/http"
+
+	"go.uber.org/zap"
+)
+
+// passwordFieldName is the name of the form field carrying the password of a protected link.
+const passwordFieldName = "password"
+
+// passwordFormTmpl renders the page asking for the password of a protected link.
+// The form is posted back to the same short URL.
+var passwordFormTmpl = template.Must(template.New("password_form").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+<title>Protected link</title>
+</head>
+<body>
+<h1>This link is password-protected</h1>
+{{if .Error}}<p role="alert">{{.Error}}</p>
+{{end}}<form method="post">
+<label for="password">Password</label>
+<input id="password" name="password" type="password" autocomplete="current-password" required autofocus>
+<button type="submit">Open</button>
+</form>
+</body>
+</html>
+`))
+
+// passwordFormData holds the values rendered by passwordFormTmpl.
+type passwordFormData struct {
+	Error string // Message shown above the form; empty on the first visit
+}
+
+// writePasswordForm responds with the password form page.
+//
+// Parameters:
+//   - w: HTTP response writer
+//   - code: HTTP status code of the response
+//   - errMsg: message shown above the form, empty if none
+//   - l: logger for logging rendering failures
+func writePasswordForm(w http.ResponseWriter, code int, errMsg string, l *zap.Logger) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	if err := passwordFormTmpl.Execute(w, passwordFormData{Error: errMsg}); err != nil {
+		l.Error("render password form", zap.Error(err))
+	}
+}
@@ -16,8 +16,9 @@ type Counter interface {
 // APIInternal implements the APIInternalProcessor interface for internal statistics operations.
 // It aggregates counts from multiple sources to provide comprehensive service statistics.
 type APIInternal struct {
-	user Counter
-	url  Counter
+	user   Counter
+	url    Counter
+	purged Counter
 }
 
 // NewAPIInternal creates a new APIInternal statistics processor.
@@ -25,28 +26,30 @@ type APIInternal struct {
 // Parameters:
 //   - user: Counter implementation for user entities
 //   - url: Counter implementation for URL entities
+//   - purged: Counter implementation for purged URL entities
 //
 // Returns:
 //   - *APIInternal: Configured statistics processor instance
-func NewAPIInternal(user Counter, url Counter) *APIInternal {
+func NewAPIInternal(user Counter, url Counter, purged Counter) *APIInternal {
 	return &APIInternal{
-		user: user,
-		url:  url,
+		user:   user,
+		url:    url,
+		purged: purged,
 	}
 }
 
 // Process retrieves and aggregates statistics from all configured counters.
-// It collects user count and URL count, returning them in a unified StatsResponse.
+// It collects user count, URL count and purged URL count, returning them in a unified StatsResponse.
 //
 // Parameters:
 //   - ctx: Context for cancellation and timeouts
 //
 // Returns:
-//   - model.StatsResponse: Structure containing URLsCount and UsersCount fields
+//   - model.StatsResponse: Structure containing URLsCount, UsersCount and PurgedCount fields
 //   - error: Returns an error if any counter operation fails
 //
-// The method returns an empty StatsResponse and an error if either count operation fails.
-// Error messages indicate which specific counter failed (users or URLs).
+// The method returns an empty StatsResponse and an error if any count operation fails.
+// Error messages indicate which specific counter failed (users, URLs or purged URLs).
 func (a *APIInternal) Process(ctx context.Context) (model.StatsResponse, error) {
 	usersCount, err := a.user.Count(ctx)
 	if err != nil {
@@ -56,8 +59,13 @@ func (a *APIInternal) Process(ctx context.Context) (model.StatsResponse, error)
 	if err != nil {
 		return model.StatsResponse{}, fmt.Errorf("count urls: %w", err)
 	}
+	purgedCount, err := a.purged.Count(ctx)
+	if err != nil {
+		return model.StatsResponse{}, fmt.Errorf("count purged urls: %w", err)
+	}
 	return model.StatsResponse{
-		URLsCount:  urlsCount,
-		UsersCount: usersCount,
+		URLsCount:   urlsCount,
+		UsersCount:  usersCount,
+		PurgedCount: purgedCount,
 	}, nil
 }
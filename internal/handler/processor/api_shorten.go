@@ -47,11 +47,11 @@ func NewAPIShorten(s service.URLShortener, l *zap.Logger, ub ShortURLBuilder, ep
 
 // Process handles the URL shortening request for API endpoint.
 // It authenticates the user, shortens the URL, and returns the response.
-// Also publishes audit events for successful shortening operations.
+// Also publishes audit events for successful shortening operations and blocked URLs.
 //
 // Parameters:
 //   - ctx: context for cancellation and timeouts
-//   - req: ShortenRequest containing the original URL to shorten
+//   - req: ShortenRequest containing the original URL to shorten and optional per-link settings
 //
 // Returns:
 //   - *ShortenResponse: response with generated short URL
@@ -67,12 +67,13 @@ func (s *APIShorten) Process(ctx context.Context, req model.ShortenRequest) (*mo
 		return nil, fmt.Errorf("get user uuid from context: %w", err)
 	}
 
-	shortID, err := s.shortener.Shorten(ctx, userUUID, req.OrigURL)
+	shortID, err := s.shortener.Shorten(ctx, userUUID, req.OrigURL, req.Options())
 	if errors.Is(err, service.ErrURLAlreadyExists) {
 		shortURL := s.ub.Build(shortID)
 		resp := &model.ShortenResponse{ShortURL: shortURL}
 		return resp, fmt.Errorf("tried to shorten existing url: %w", err)
 	} else if err != nil {
+		publishBlocked(s.audit, userUUID, err)
 		return nil, fmt.Errorf("shorten url: %w", err)
 	}
 
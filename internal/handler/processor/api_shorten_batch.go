@@ -17,6 +17,7 @@ type APIShortenBatch struct {
 	shortener service.URLShortener
 	logger    *zap.Logger
 	ub        ShortURLBuilder
+	audit     AuditEventPublisher
 }
 
 // NewAPIShortenBatch creates a new APIShortenBatch processor instance.
@@ -25,18 +26,26 @@ type APIShortenBatch struct {
 //   - s: URL shortener service for batch shortening operations
 //   - l: Structured logger for logging operations
 //   - ub: URL builder for constructing complete short URLs
+//   - ep: Audit event publisher for recording blocked URLs
 //
 // Returns: configured APIShortenBatch processor
-func NewAPIShortenBatch(s service.URLShortener, l *zap.Logger, ub ShortURLBuilder) *APIShortenBatch {
+func NewAPIShortenBatch(
+	s service.URLShortener,
+	l *zap.Logger,
+	ub ShortURLBuilder,
+	ep AuditEventPublisher,
+) *APIShortenBatch {
 	return &APIShortenBatch{
 		shortener: s,
 		logger:    l,
 		ub:        ub,
+		audit:     ep,
 	}
 }
 
 // Process handles batch URL shortening requests for multiple URLs.
 // It processes all URLs in a single operation while maintaining request-response correlation.
+// Publishes an audit event if the batch is rejected because of a blocked URL.
 //
 // Parameters:
 //   - ctx: context for cancellation and timeouts
@@ -51,9 +60,10 @@ func (s *APIShortenBatch) Process(ctx context.Context, items model.BatchShortenR
 		return nil, fmt.Errorf("get user uuid from context: %w", err)
 	}
 
-	origURLs := s.buildURLList(items)
-	shortIDs, err := s.shortener.ShortenBatch(ctx, userUUID, origURLs)
+	urls := s.buildURLList(items)
+	shortIDs, err := s.shortener.ShortenBatch(ctx, userUUID, urls)
 	if err != nil {
+		publishBlocked(s.audit, userUUID, err)
 		return nil, fmt.Errorf("shorten batch: %w", err)
 	}
 
@@ -65,19 +75,22 @@ func (s *APIShortenBatch) Process(ctx context.Context, items model.BatchShortenR
 	return resp, nil
 }
 
-// buildURLList creates a list of original URLs from the batch request.
+// buildURLList creates a list of original URLs with their options from the batch request.
 //
 // Parameters:
 //   - reqItems: model.BatchShortenRequest containing URLs with correlation IDs
 //
 // Returns:
-//   - []string: list of original URLs
-func (s *APIShortenBatch) buildURLList(reqItems model.BatchShortenRequest) []string {
-	origURLs := make([]string, len(reqItems))
+//   - model.URLShortenBatch: list of original URLs with their options
+func (s *APIShortenBatch) buildURLList(reqItems model.BatchShortenRequest) model.URLShortenBatch {
+	urls := make(model.URLShortenBatch, len(reqItems))
 	for i, item := range reqItems {
-		origURLs[i] = item.OriginalURL
+		urls[i] = model.URLToShorten{
+			OrigURL: item.OriginalURL,
+			Opts:    item.Options(),
+		}
 	}
-	return origURLs
+	return urls
 }
 
 // buildResponse creates a batch response with shortened URLs and correlation IDs.
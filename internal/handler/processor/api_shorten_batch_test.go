@@ -25,25 +25,60 @@ func (s *stubShortenerBatch) IsReady() error {
 	return nil
 }
 
-func (s *stubShortenerBatch) Shorten(_ context.Context, _ string, _ string) (string, error) {
+func (s *stubShortenerBatch) Shorten(_ context.Context, _, _ string, _ model.ShortenOptions) (string, error) {
 	return "", nil
 }
 
-func (s *stubShortenerBatch) Extract(_ context.Context, _ string) (string, error) {
-	return "", nil
+func (s *stubShortenerBatch) Extract(_ context.Context, _ model.ExpandRequest) (*model.URLStorageRecord, error) {
+	return nil, nil
+}
+func (s *stubShortenerBatch) Inspect(_ context.Context, _ string) (*model.URLInspection, error) {
+	return nil, nil
 }
-func (s *stubShortenerBatch) ShortenBatch(_ context.Context, _ string, _ []string) ([]string, error) {
+func (s *stubShortenerBatch) ShortenBatch(_ context.Context, _ string, _ model.URLShortenBatch) ([]string, error) {
 	return s.retIDs, s.retErr
 }
 
-func (s *stubShortenerBatch) GetUserURLs(_ context.Context, _ string) ([]*model.URLStorageRecord, error) {
+func (s *stubShortenerBatch) ImportBatch(_ context.Context, _ string, _ []model.URLImportRow) ([]model.URLImportResult, error) {
+	return nil, nil
+}
+
+func (s *stubShortenerBatch) GetUserURLs(_ context.Context, _ string, _ []string) ([]*model.URLStorageRecord, error) {
+	return nil, nil
+}
+
+func (s *stubShortenerBatch) GetUserTags(_ context.Context, _ string) ([]model.TagCount, error) {
+	return nil, nil
+}
+
+func (s *stubShortenerBatch) SetTags(_ context.Context, _, _ string, _ []string) (*model.URLStorageRecord, error) {
 	return nil, nil
 }
 
+func (s *stubShortenerBatch) Update(_ context.Context, _, _, _ string) (*model.URLStorageRecord, string, error) {
+	return nil, "", nil
+}
+
+func (s *stubShortenerBatch) GetHistory(_ context.Context, _, _ string) ([]model.URLHistoryRecord, error) {
+	return nil, nil
+}
+
+func (s *stubShortenerBatch) RestoreVersion(_ context.Context, _, _ string, _ int) (*model.URLStorageRecord, string, error) {
+	return nil, "", nil
+}
+
 func (s *stubShortenerBatch) DeleteBatch(_ context.Context, _ model.URLDeleteBatch) error {
 	return nil
 }
 
+func (s *stubShortenerBatch) GetUserTrash(_ context.Context, _ string) ([]*model.URLStorageRecord, error) {
+	return nil, nil
+}
+
+func (s *stubShortenerBatch) RestoreBatch(_ context.Context, _ string, _ []string) ([]model.URLRestoreResult, error) {
+	return nil, nil
+}
+
 func (s *stubShortenerBatch) Count(_ context.Context) (int, error) {
 	return s.retCount, nil
 }
@@ -125,7 +160,7 @@ func TestShortenBatchService_ShortenBatch(t *testing.T) {
 				}
 			}
 
-			srv := NewAPIShortenBatch(shortener, zap.NewNop(), ub)
+			srv := NewAPIShortenBatch(shortener, zap.NewNop(), ub, mocks.NewMockAuditEventPublisher(t))
 			ctx := auth.WithUser(context.Background(), &model.User{UUID: "userUUID"})
 
 			resp, err := srv.Process(ctx, tt.decReq)
@@ -0,0 +1,103 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"go.uber.org/zap"
+
+	"github.com/alex-storchak/shortener/internal/helper/auth"
+	"github.com/alex-storchak/shortener/internal/model"
+)
+
+// URLImporter defines the interface for streaming bulk imports of links.
+type URLImporter interface {
+	Import(ctx context.Context, userUUID, format string, r io.Reader, emit func([]model.URLImportResult) error) error
+}
+
+// APIShortenImport provides streaming bulk import of links from CSV or NDJSON inputs.
+// It handles the business logic for the '/api/shorten/import' endpoint.
+type APIShortenImport struct {
+	importer URLImporter
+	logger   *zap.Logger
+	ub       ShortURLBuilder
+	audit    AuditEventPublisher
+}
+
+// NewAPIShortenImport creates a new APIShortenImport processor instance.
+//
+// Parameters:
+//   - importer: bulk import service
+//   - logger: Structured logger for logging operations
+//   - ub: URL builder for constructing complete short URLs
+//   - ep: Audit event publisher for recording blocked URLs
+//
+// Returns: configured APIShortenImport processor
+func NewAPIShortenImport(
+	importer URLImporter,
+	logger *zap.Logger,
+	ub ShortURLBuilder,
+	ep AuditEventPublisher,
+) *APIShortenImport {
+	return &APIShortenImport{
+		importer: importer,
+		logger:   logger,
+		ub:       ub,
+		audit:    ep,
+	}
+}
+
+// Process imports links from the input on behalf of the authenticated user.
+// Outcomes of rows are passed to emit chunk by chunk, as soon as every chunk is stored.
+// Publishes an audit event for every row rejected because of a blocked URL.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - format: csv or ndjson
+//   - r: input to read rows from
+//   - emit: callback receiving outcomes of rows of every stored chunk; its error stops the import
+//
+// Returns:
+//   - error: nil on success, or service error if the input is malformed or the import fails
+func (p *APIShortenImport) Process(
+	ctx context.Context,
+	format string,
+	r io.Reader,
+	emit func([]model.ImportResultItem) error,
+) error {
+	userUUID, err := auth.GetCtxUserUUID(ctx)
+	if err != nil {
+		return fmt.Errorf("get user uuid from context: %w", err)
+	}
+
+	err = p.importer.Import(ctx, userUUID, format, r, func(results []model.URLImportResult) error {
+		items := make([]model.ImportResultItem, len(results))
+		for i, res := range results {
+			items[i] = p.buildResultItem(res)
+			publishBlocked(p.audit, userUUID, res.Err)
+		}
+		return emit(items)
+	})
+	if err != nil {
+		return fmt.Errorf("import urls: %w", err)
+	}
+	return nil
+}
+
+// buildResultItem converts the outcome of a row to its representation in responses.
+func (p *APIShortenImport) buildResultItem(res model.URLImportResult) model.ImportResultItem {
+	item := model.ImportResultItem{
+		Row:           res.Row,
+		CorrelationID: res.CorrelationID,
+		OrigURL:       res.OrigURL,
+		Status:        string(res.Status),
+	}
+	if res.ShortID != "" {
+		item.ShortURL = p.ub.Build(res.ShortID)
+	}
+	if res.Err != nil {
+		item.Error = res.Err.Error()
+	}
+	return item
+}
@@ -22,26 +22,61 @@ type stubShortenerAPI struct {
 	retCount   int
 }
 
-func (s *stubShortenerAPI) Shorten(_ context.Context, _, _ string) (string, error) {
+func (s *stubShortenerAPI) Shorten(_ context.Context, _, _ string, _ model.ShortenOptions) (string, error) {
 	return s.retShortID, s.retErr
 }
 
-func (s *stubShortenerAPI) Extract(_ context.Context, _ string) (string, error) {
-	return "", nil
+func (s *stubShortenerAPI) Extract(_ context.Context, _ model.ExpandRequest) (*model.URLStorageRecord, error) {
+	return nil, nil
+}
+func (s *stubShortenerAPI) Inspect(_ context.Context, _ string) (*model.URLInspection, error) {
+	return nil, nil
 }
 
-func (s *stubShortenerAPI) ShortenBatch(_ context.Context, _ string, _ []string) ([]string, error) {
+func (s *stubShortenerAPI) ShortenBatch(_ context.Context, _ string, _ model.URLShortenBatch) ([]string, error) {
 	return nil, nil
 }
 
-func (s *stubShortenerAPI) GetUserURLs(_ context.Context, _ string) ([]*model.URLStorageRecord, error) {
+func (s *stubShortenerAPI) ImportBatch(_ context.Context, _ string, _ []model.URLImportRow) ([]model.URLImportResult, error) {
 	return nil, nil
 }
 
+func (s *stubShortenerAPI) GetUserURLs(_ context.Context, _ string, _ []string) ([]*model.URLStorageRecord, error) {
+	return nil, nil
+}
+
+func (s *stubShortenerAPI) GetUserTags(_ context.Context, _ string) ([]model.TagCount, error) {
+	return nil, nil
+}
+
+func (s *stubShortenerAPI) SetTags(_ context.Context, _, _ string, _ []string) (*model.URLStorageRecord, error) {
+	return nil, nil
+}
+
+func (s *stubShortenerAPI) Update(_ context.Context, _, _, _ string) (*model.URLStorageRecord, string, error) {
+	return nil, "", nil
+}
+
+func (s *stubShortenerAPI) GetHistory(_ context.Context, _, _ string) ([]model.URLHistoryRecord, error) {
+	return nil, nil
+}
+
+func (s *stubShortenerAPI) RestoreVersion(_ context.Context, _, _ string, _ int) (*model.URLStorageRecord, string, error) {
+	return nil, "", nil
+}
+
 func (s *stubShortenerAPI) DeleteBatch(_ context.Context, _ model.URLDeleteBatch) error {
 	return nil
 }
 
+func (s *stubShortenerAPI) GetUserTrash(_ context.Context, _ string) ([]*model.URLStorageRecord, error) {
+	return nil, nil
+}
+
+func (s *stubShortenerAPI) RestoreBatch(_ context.Context, _ string, _ []string) ([]model.URLRestoreResult, error) {
+	return nil, nil
+}
+
 func (s *stubShortenerAPI) Count(_ context.Context) (int, error) {
 	return s.retCount, nil
 }
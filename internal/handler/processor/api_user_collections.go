@@ -0,0 +1,236 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/alex-storchak/shortener/internal/helper/auth"
+	"github.com/alex-storchak/shortener/internal/model"
+)
+
+// CollectionManager defines the interface for managing collections of users' links.
+type CollectionManager interface {
+	GetUserCollections(ctx context.Context, userUUID string) ([]model.Collection, error)
+	Create(ctx context.Context, userUUID, name string) (*model.Collection, error)
+	Rename(ctx context.Context, userUUID, id, name string) (*model.Collection, error)
+	Delete(ctx context.Context, userUUID, id string) error
+	Move(ctx context.Context, userUUID, id string, shortIDs []string) error
+	GetURLs(ctx context.Context, userUUID, id string) ([]*model.URLStorageRecord, error)
+	DeleteURLs(ctx context.Context, userUUID, id string) (int, error)
+	Export(ctx context.Context, userUUID, id, format string) (*model.CollectionExport, error)
+}
+
+// APIUserCollections provides management of collections of the authenticated user's links.
+// It handles the business logic for the '/api/user/collections' endpoints.
+type APIUserCollections struct {
+	collections CollectionManager
+	logger      *zap.Logger
+	ub          ShortURLBuilder
+}
+
+// NewAPIUserCollections creates a new APIUserCollections processor instance.
+//
+// Parameters:
+//   - collections: collections service
+//   - logger: Structured logger for logging operations
+//   - ub: URL builder for constructing complete short URLs
+//
+// Returns: configured APIUserCollections processor
+func NewAPIUserCollections(collections CollectionManager, logger *zap.Logger, ub ShortURLBuilder) *APIUserCollections {
+	return &APIUserCollections{
+		collections: collections,
+		logger:      logger,
+		ub:          ub,
+	}
+}
+
+// ProcessGet retrieves collections of the authenticated user.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//
+// Returns:
+//   - model.UserCollectionsResponse: collections with the amount of live links in each, sorted by name
+//   - error: nil on success, or service error if operation fails
+func (p *APIUserCollections) ProcessGet(ctx context.Context) (model.UserCollectionsResponse, error) {
+	userUUID, err := auth.GetCtxUserUUID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get user uuid from context: %w", err)
+	}
+	collections, err := p.collections.GetUserCollections(ctx, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("get user collections: %w", err)
+	}
+
+	resp := make(model.UserCollectionsResponse, len(collections))
+	for i, c := range collections {
+		resp[i] = newUserCollection(&c)
+	}
+	return resp, nil
+}
+
+// ProcessCreate creates an empty collection of the authenticated user.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - req: request with the name of the collection
+//
+// Returns:
+//   - *model.UserCollection: created collection
+//   - error: nil on success, or service error if validation or operation fails
+func (p *APIUserCollections) ProcessCreate(
+	ctx context.Context,
+	req model.UserCollectionRequest,
+) (*model.UserCollection, error) {
+	userUUID, err := auth.GetCtxUserUUID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get user uuid from context: %w", err)
+	}
+	c, err := p.collections.Create(ctx, userUUID, req.Name)
+	if err != nil {
+		return nil, fmt.Errorf("create user collection: %w", err)
+	}
+	resp := newUserCollection(c)
+	return &resp, nil
+}
+
+// ProcessRename renames a collection of the authenticated user.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - id: identifier of the collection
+//   - req: request with the new name of the collection
+//
+// Returns:
+//   - *model.UserCollection: renamed collection
+//   - error: nil on success, or service error if validation or operation fails
+func (p *APIUserCollections) ProcessRename(
+	ctx context.Context,
+	id string,
+	req model.UserCollectionRequest,
+) (*model.UserCollection, error) {
+	userUUID, err := auth.GetCtxUserUUID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get user uuid from context: %w", err)
+	}
+	c, err := p.collections.Rename(ctx, userUUID, id, req.Name)
+	if err != nil {
+		return nil, fmt.Errorf("rename user collection: %w", err)
+	}
+	resp := newUserCollection(c)
+	return &resp, nil
+}
+
+// ProcessDelete removes a collection of the authenticated user, keeping its links.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - id: identifier of the collection
+//
+// Returns:
+//   - error: nil on success, or service error if there is no such collection or operation fails
+func (p *APIUserCollections) ProcessDelete(ctx context.Context, id string) error {
+	userUUID, err := auth.GetCtxUserUUID(ctx)
+	if err != nil {
+		return fmt.Errorf("get user uuid from context: %w", err)
+	}
+	if err := p.collections.Delete(ctx, userUUID, id); err != nil {
+		return fmt.Errorf("delete user collection: %w", err)
+	}
+	return nil
+}
+
+// ProcessMove moves links of the authenticated user into a collection or out of any collection.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - req: request with the target collection and the links to move
+//
+// Returns:
+//   - error: nil on success, or service error if validation or operation fails
+func (p *APIUserCollections) ProcessMove(ctx context.Context, req model.UserCollectionMoveRequest) error {
+	userUUID, err := auth.GetCtxUserUUID(ctx)
+	if err != nil {
+		return fmt.Errorf("get user uuid from context: %w", err)
+	}
+	if err := p.collections.Move(ctx, userUUID, req.CollectionID, req.ShortURLs); err != nil {
+		return fmt.Errorf("move user urls to collection: %w", err)
+	}
+	return nil
+}
+
+// ProcessGetURLs retrieves the live links in a collection of the authenticated user.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - id: identifier of the collection
+//
+// Returns:
+//   - model.UserURLsGetResponse: links in the collection
+//   - error: nil on success, or service error if there is no such collection or operation fails
+func (p *APIUserCollections) ProcessGetURLs(ctx context.Context, id string) (model.UserURLsGetResponse, error) {
+	userUUID, err := auth.GetCtxUserUUID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get user uuid from context: %w", err)
+	}
+	urls, err := p.collections.GetURLs(ctx, userUUID, id)
+	if err != nil {
+		return nil, fmt.Errorf("get user collection urls: %w", err)
+	}
+	return buildUserURLsResponse(p.ub, urls), nil
+}
+
+// ProcessDeleteURLs soft-deletes all live links in a collection of the authenticated user.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - id: identifier of the collection
+//
+// Returns:
+//   - *model.UserCollectionDeleteResponse: amount of deleted links
+//   - error: nil on success, or service error if there is no such collection or operation fails
+func (p *APIUserCollections) ProcessDeleteURLs(ctx context.Context, id string) (*model.UserCollectionDeleteResponse, error) {
+	userUUID, err := auth.GetCtxUserUUID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get user uuid from context: %w", err)
+	}
+	n, err := p.collections.DeleteURLs(ctx, userUUID, id)
+	if err != nil {
+		return nil, fmt.Errorf("delete user collection urls: %w", err)
+	}
+	return &model.UserCollectionDeleteResponse{Deleted: n}, nil
+}
+
+// ProcessExport renders the live links in a collection of the authenticated user into a downloadable file.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - id: identifier of the collection
+//   - format: csv or json; empty selects csv
+//
+// Returns:
+//   - *model.CollectionExport: rendered file with its MIME type and suggested name
+//   - error: nil on success, or service error if validation or operation fails
+func (p *APIUserCollections) ProcessExport(ctx context.Context, id, format string) (*model.CollectionExport, error) {
+	userUUID, err := auth.GetCtxUserUUID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get user uuid from context: %w", err)
+	}
+	exp, err := p.collections.Export(ctx, userUUID, id, format)
+	if err != nil {
+		return nil, fmt.Errorf("export user collection: %w", err)
+	}
+	return exp, nil
+}
+
+// newUserCollection maps a collection to its representation in responses.
+func newUserCollection(c *model.Collection) model.UserCollection {
+	return model.UserCollection{
+		ID:        c.ID,
+		Name:      c.Name,
+		CreatedAt: c.CreatedAt,
+		Count:     len(c.ShortIDs),
+	}
+}
@@ -0,0 +1,101 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/alex-storchak/shortener/internal/helper/auth"
+	"github.com/alex-storchak/shortener/internal/model"
+)
+
+// SplitManager defines the interface for managing A/B split tests of users' links.
+type SplitManager interface {
+	GetSplit(ctx context.Context, userUUID, shortID string) (model.Split, error)
+	SetSplit(ctx context.Context, userUUID string, split model.Split) (model.Split, error)
+}
+
+// APIUserSplit provides management of A/B split tests of the authenticated user's links.
+// It handles the business logic for the '/api/user/urls/{id}/split' endpoints.
+type APIUserSplit struct {
+	splitter SplitManager
+	logger   *zap.Logger
+}
+
+// NewAPIUserSplit creates a new APIUserSplit processor instance.
+//
+// Parameters:
+//   - splitter: split test service
+//   - logger: Structured logger for logging operations
+//
+// Returns: configured APIUserSplit processor
+func NewAPIUserSplit(splitter SplitManager, logger *zap.Logger) *APIUserSplit {
+	return &APIUserSplit{
+		splitter: splitter,
+		logger:   logger,
+	}
+}
+
+// ProcessGet retrieves the split test of a short URL of the authenticated user.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - shortID: short identifier of the URL
+//
+// Returns:
+//   - *model.UserSplit: split test of the URL; without variants if the URL has no split test
+//   - error: nil on success, or service error if URL is not found among user's URLs or operation fails
+func (p *APIUserSplit) ProcessGet(ctx context.Context, shortID string) (*model.UserSplit, error) {
+	userUUID, err := auth.GetCtxUserUUID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get user uuid from context: %w", err)
+	}
+	split, err := p.splitter.GetSplit(ctx, userUUID, shortID)
+	if err != nil {
+		return nil, fmt.Errorf("get split test: %w", err)
+	}
+	return buildUserSplit(split), nil
+}
+
+// ProcessSet replaces the split test of a short URL of the authenticated user.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - shortID: short identifier of the URL
+//   - req: split test of the URL; a split test without variants removes it
+//
+// Returns:
+//   - *model.UserSplit: stored split test with named variants and canonical URLs
+//   - error: nil on success, or service error if validation or operation fails
+func (p *APIUserSplit) ProcessSet(ctx context.Context, shortID string, req *model.UserSplit) (*model.UserSplit, error) {
+	userUUID, err := auth.GetCtxUserUUID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get user uuid from context: %w", err)
+	}
+	split := model.Split{
+		ShortID:  shortID,
+		Sticky:   req.Sticky,
+		Variants: make([]model.SplitVariant, len(req.Variants)),
+	}
+	for i, v := range req.Variants {
+		split.Variants[i] = model.SplitVariant(v)
+	}
+	stored, err := p.splitter.SetSplit(ctx, userUUID, split)
+	if err != nil {
+		return nil, fmt.Errorf("set split test: %w", err)
+	}
+	return buildUserSplit(stored), nil
+}
+
+// buildUserSplit converts a split test to its API representation.
+func buildUserSplit(split model.Split) *model.UserSplit {
+	resp := &model.UserSplit{
+		Sticky:   split.Sticky,
+		Variants: make([]model.UserSplitVariant, len(split.Variants)),
+	}
+	for i, v := range split.Variants {
+		resp.Variants[i] = model.UserSplitVariant(v)
+	}
+	return resp
+}
@@ -0,0 +1,98 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/alex-storchak/shortener/internal/helper/auth"
+	"github.com/alex-storchak/shortener/internal/model"
+)
+
+// TargetingRuleManager defines the interface for managing targeting rules of users' links.
+type TargetingRuleManager interface {
+	GetRules(ctx context.Context, userUUID, shortID string) ([]model.TargetingRule, error)
+	SetRules(ctx context.Context, userUUID, shortID string, rules []model.TargetingRule) ([]model.TargetingRule, error)
+}
+
+// APIUserTargeting provides management of targeting rules of the authenticated user's links.
+// It handles the business logic for the '/api/user/urls/{id}/rules' endpoints.
+type APIUserTargeting struct {
+	targeting TargetingRuleManager
+	logger    *zap.Logger
+}
+
+// NewAPIUserTargeting creates a new APIUserTargeting processor instance.
+//
+// Parameters:
+//   - targeting: targeting service
+//   - logger: Structured logger for logging operations
+//
+// Returns: configured APIUserTargeting processor
+func NewAPIUserTargeting(targeting TargetingRuleManager, logger *zap.Logger) *APIUserTargeting {
+	return &APIUserTargeting{
+		targeting: targeting,
+		logger:    logger,
+	}
+}
+
+// ProcessGet retrieves targeting rules of a short URL of the authenticated user.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - shortID: short identifier of the URL
+//
+// Returns:
+//   - model.UserTargetingRules: rules in evaluation order
+//   - error: nil on success, or service error if URL is not found among user's URLs or operation fails
+func (p *APIUserTargeting) ProcessGet(ctx context.Context, shortID string) (model.UserTargetingRules, error) {
+	userUUID, err := auth.GetCtxUserUUID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get user uuid from context: %w", err)
+	}
+	rules, err := p.targeting.GetRules(ctx, userUUID, shortID)
+	if err != nil {
+		return nil, fmt.Errorf("get targeting rules: %w", err)
+	}
+	return buildUserTargetingRules(rules), nil
+}
+
+// ProcessSet replaces targeting rules of a short URL of the authenticated user.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - shortID: short identifier of the URL
+//   - req: rules in evaluation order; empty list removes the rules
+//
+// Returns:
+//   - model.UserTargetingRules: stored rules with normalized conditions and canonical URLs
+//   - error: nil on success, or service error if validation or operation fails
+func (p *APIUserTargeting) ProcessSet(
+	ctx context.Context,
+	shortID string,
+	req model.UserTargetingRules,
+) (model.UserTargetingRules, error) {
+	userUUID, err := auth.GetCtxUserUUID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get user uuid from context: %w", err)
+	}
+	rules := make([]model.TargetingRule, len(req))
+	for i, r := range req {
+		rules[i] = model.TargetingRule(r)
+	}
+	stored, err := p.targeting.SetRules(ctx, userUUID, shortID, rules)
+	if err != nil {
+		return nil, fmt.Errorf("set targeting rules: %w", err)
+	}
+	return buildUserTargetingRules(stored), nil
+}
+
+// buildUserTargetingRules converts targeting rules to their API representation.
+func buildUserTargetingRules(rules []model.TargetingRule) model.UserTargetingRules {
+	resp := make(model.UserTargetingRules, len(rules))
+	for i, r := range rules {
+		resp[i] = model.UserTargetingRule(r)
+	}
+	return resp
+}
@@ -14,11 +14,13 @@ import (
 )
 
 // APIUserURLs provides user URL management functionality.
-// It handles business logic for user-specific URL operations including retrieval and deletion.
+// It handles business logic for user-specific URL operations including retrieval, update,
+// version history, deletion and restoration.
 type APIUserURLs struct {
 	shortener service.URLShortener
 	logger    *zap.Logger
 	ub        ShortURLBuilder
+	audit     AuditEventPublisher
 }
 
 // NewAPIUserURLs creates a new APIUserURLs processor instance.
@@ -27,34 +29,38 @@ type APIUserURLs struct {
 //   - shortener: URL shortener service for user URL operations
 //   - logger: Structured logger for logging operations
 //   - ub: URL builder for constructing complete short URLs
+//   - ep: Audit event publisher for recording system actions
 //
 // Returns: configured APIUserURLs processor
 func NewAPIUserURLs(
 	shortener service.URLShortener,
 	logger *zap.Logger,
 	ub ShortURLBuilder,
+	ep AuditEventPublisher,
 ) *APIUserURLs {
 	return &APIUserURLs{
 		shortener: shortener,
 		logger:    logger,
 		ub:        ub,
+		audit:     ep,
 	}
 }
 
-// ProcessGet retrieves all URLs shortened by the authenticated user.
+// ProcessGet retrieves URLs shortened by the authenticated user, optionally filtered by tags.
 //
 // Parameters:
 //   - ctx: context for cancellation and timeouts
+//   - tags: tags every returned URL must carry; empty to return all URLs
 //
 // Returns:
 //   - model.UserURLsGetResponse: collection of user's shortened URLs
 //   - error: nil on success, or service error if operation fails
-func (s *APIUserURLs) ProcessGet(ctx context.Context) (model.UserURLsGetResponse, error) {
+func (s *APIUserURLs) ProcessGet(ctx context.Context, tags []string) (model.UserURLsGetResponse, error) {
 	userUUID, err := auth.GetCtxUserUUID(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("get user uuid from context: %w", err)
 	}
-	urls, err := s.shortener.GetUserURLs(ctx, userUUID)
+	urls, err := s.shortener.GetUserURLs(ctx, userUUID, tags)
 	if err != nil {
 		return nil, fmt.Errorf("get user urls from storage: %w", err)
 	}
@@ -67,6 +73,224 @@ func (s *APIUserURLs) ProcessGet(ctx context.Context) (model.UserURLsGetResponse
 	return resp, nil
 }
 
+// ProcessGetTags retrieves the tags of the authenticated user's URLs with the amount of URLs labeled with each.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//
+// Returns:
+//   - model.UserTagsResponse: user's tags with their counts, sorted by tag
+//   - error: nil on success, or service error if operation fails
+func (s *APIUserURLs) ProcessGetTags(ctx context.Context) (model.UserTagsResponse, error) {
+	userUUID, err := auth.GetCtxUserUUID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get user uuid from context: %w", err)
+	}
+	tags, err := s.shortener.GetUserTags(ctx, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("get user tags from storage: %w", err)
+	}
+
+	resp := make(model.UserTagsResponse, len(tags))
+	for i, t := range tags {
+		resp[i] = model.UserTagsResponseItem{Tag: t.Tag, Count: t.Count}
+	}
+	return resp, nil
+}
+
+// ProcessUpdate changes the original URL and/or the tags of the authenticated user's short URL.
+// The original URL is required unless the request only replaces tags. Tags are validated before
+// anything is changed, so a request with invalid tags leaves the URL untouched.
+// Publishes an audit event when the destination is actually changed or the new URL is blocked.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - shortID: short URL identifier to update
+//   - req: request with the new original URL and tags
+//
+// Returns:
+//   - *model.UserURLsGetResponseItem: updated short URL
+//   - error: nil on success, or service error if operation fails
+func (s *APIUserURLs) ProcessUpdate(
+	ctx context.Context,
+	shortID string,
+	req model.UserURLUpdateRequest,
+) (*model.UserURLsGetResponseItem, error) {
+	userUUID, err := auth.GetCtxUserUUID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get user uuid from context: %w", err)
+	}
+	if req.Tags != nil {
+		if _, err = service.NormalizeTags(*req.Tags); err != nil {
+			return nil, err
+		}
+	}
+
+	var updated *model.URLStorageRecord
+	if req.OrigURL != "" || req.Tags == nil {
+		prev, url, err := s.shortener.Update(ctx, userUUID, shortID, req.OrigURL)
+		if err != nil {
+			publishBlocked(s.audit, userUUID, err)
+			return nil, fmt.Errorf("update user url: %w", err)
+		}
+		updated = s.publishUpdate(userUUID, prev, url)
+	}
+	if req.Tags != nil {
+		if updated, err = s.shortener.SetTags(ctx, userUUID, shortID, *req.Tags); err != nil {
+			return nil, fmt.Errorf("set user url tags: %w", err)
+		}
+	}
+
+	return s.buildItem(updated)
+}
+
+// ProcessGetHistory retrieves the version history of the authenticated user's short URL.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - shortID: short URL identifier
+//
+// Returns:
+//   - model.UserURLHistoryResponse: versions of the short URL, oldest first
+//   - error: nil on success, or service error if operation fails
+func (s *APIUserURLs) ProcessGetHistory(ctx context.Context, shortID string) (model.UserURLHistoryResponse, error) {
+	userUUID, err := auth.GetCtxUserUUID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get user uuid from context: %w", err)
+	}
+	history, err := s.shortener.GetHistory(ctx, userUUID, shortID)
+	if err != nil {
+		return nil, fmt.Errorf("get user url history: %w", err)
+	}
+
+	resp := make(model.UserURLHistoryResponse, len(history))
+	for i, h := range history {
+		resp[i] = model.UserURLHistoryResponseItem{
+			Version:   h.Version,
+			Action:    string(h.Action),
+			PrevURL:   h.PrevURL,
+			OrigURL:   h.OrigURL,
+			UserID:    h.UserUUID,
+			ChangedAt: h.TS,
+		}
+	}
+	return resp, nil
+}
+
+// ProcessRestoreVersion points the authenticated user's short URL back at one of its previous versions.
+// Publishes an audit event when the destination is actually changed or the restored URL is blocked.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - shortID: short URL identifier
+//   - req: request with the number of the version to restore
+//
+// Returns:
+//   - *model.UserURLsGetResponseItem: restored short URL
+//   - error: nil on success, or service error if operation fails
+func (s *APIUserURLs) ProcessRestoreVersion(
+	ctx context.Context,
+	shortID string,
+	req model.UserURLRestoreVersionRequest,
+) (*model.UserURLsGetResponseItem, error) {
+	userUUID, err := auth.GetCtxUserUUID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get user uuid from context: %w", err)
+	}
+	prev, url, err := s.shortener.RestoreVersion(ctx, userUUID, shortID, req.Version)
+	if err != nil {
+		publishBlocked(s.audit, userUUID, err)
+		return nil, fmt.Errorf("restore user url version: %w", err)
+	}
+
+	return s.buildItem(s.publishUpdate(userUUID, prev, url))
+}
+
+// publishUpdate publishes the audit event of a changed destination and returns
+// the URL record with the new destination.
+func (s *APIUserURLs) publishUpdate(userUUID string, prev *model.URLStorageRecord, url string) *model.URLStorageRecord {
+	if prev.OrigURL != url {
+		s.audit.Publish(model.AuditEvent{
+			TS:      time.Now().Unix(),
+			Action:  model.AuditActionUpdate,
+			UserID:  userUUID,
+			OrigURL: url,
+			PrevURL: prev.OrigURL,
+		})
+	}
+
+	updated := *prev
+	updated.OrigURL = url
+	return &updated
+}
+
+// buildItem builds the response with the updated short URL.
+func (s *APIUserURLs) buildItem(updated *model.URLStorageRecord) (*model.UserURLsGetResponseItem, error) {
+	resp, err := s.buildResponse([]*model.URLStorageRecord{updated})
+	if err != nil {
+		return nil, fmt.Errorf("build response: %w", err)
+	}
+	return &resp[0], nil
+}
+
+// ProcessGetTrash retrieves all soft-deleted URLs of the authenticated user.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//
+// Returns:
+//   - model.UserURLsGetResponse: collection of user's deleted URLs
+//   - error: nil on success, or service error if operation fails
+func (s *APIUserURLs) ProcessGetTrash(ctx context.Context) (model.UserURLsGetResponse, error) {
+	userUUID, err := auth.GetCtxUserUUID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get user uuid from context: %w", err)
+	}
+	urls, err := s.shortener.GetUserTrash(ctx, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("get user trash from storage: %w", err)
+	}
+
+	resp, err := s.buildResponse(urls)
+	if err != nil {
+		return nil, fmt.Errorf("build response: %w", err)
+	}
+	return resp, nil
+}
+
+// ProcessRestore restores soft-deleted URLs of the authenticated user.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - shortIDs: list of short URL identifiers to restore
+//
+// Returns:
+//   - model.UserURLsRestoreResponse: outcome for every requested URL, in the same order
+//   - error: nil on success, or service error if operation fails
+func (s *APIUserURLs) ProcessRestore(
+	ctx context.Context,
+	shortIDs model.UserURLsRestoreRequest,
+) (model.UserURLsRestoreResponse, error) {
+	userUUID, err := auth.GetCtxUserUUID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get user uuid from context: %w", err)
+	}
+	results, err := s.shortener.RestoreBatch(ctx, userUUID, shortIDs)
+	if err != nil {
+		return nil, fmt.Errorf("restore user urls: %w", err)
+	}
+
+	resp := make(model.UserURLsRestoreResponse, len(results))
+	for i, r := range results {
+		resp[i] = model.UserURLsRestoreResponseItem{
+			ShortURL: s.ub.Build(r.ShortID),
+			OrigURL:  r.OrigURL,
+			Status:   string(r.Status),
+		}
+	}
+	return resp, nil
+}
+
 // buildResponse creates a response with user's shortened URLs.
 //
 // Parameters:
@@ -76,15 +300,26 @@ func (s *APIUserURLs) ProcessGet(ctx context.Context) (model.UserURLsGetResponse
 //   - model.UserURLsGetResponse: Collection of user's shortened URLs
 //   - error: nil on success, or error if response construction fails
 func (s *APIUserURLs) buildResponse(urls []*model.URLStorageRecord) (model.UserURLsGetResponse, error) {
+	return buildUserURLsResponse(s.ub, urls), nil
+}
+
+// buildUserURLsResponse maps URL records to the items of user URLs listings with complete short URLs.
+func buildUserURLsResponse(ub ShortURLBuilder, urls []*model.URLStorageRecord) model.UserURLsGetResponse {
 	resp := make(model.UserURLsGetResponse, len(urls))
 	for i, u := range urls {
-		shortURL := s.ub.Build(u.ShortID)
 		resp[i] = model.UserURLsGetResponseItem{
-			OrigURL:  u.OrigURL,
-			ShortURL: shortURL,
+			OrigURL:      u.OrigURL,
+			ShortURL:     ub.Build(u.ShortID),
+			Tags:         u.Tags,
+			RedirectType: u.Redirect(),
+			Passthrough:  u.Passthrough,
+		}
+		if !u.ExpiresAt.IsZero() {
+			expiresAt := u.ExpiresAt
+			resp[i].ExpiresAt = &expiresAt
 		}
 	}
-	return resp, nil
+	return resp
 }
 
 // ProcessDelete initiates asynchronous batch deletion of user's URLs.
@@ -0,0 +1,69 @@
+package processor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/alex-storchak/shortener/internal/handler/processor/mocks"
+	"github.com/alex-storchak/shortener/internal/helper/auth"
+	"github.com/alex-storchak/shortener/internal/model"
+	"github.com/alex-storchak/shortener/internal/service"
+)
+
+type stubUpdateShortener struct {
+	service.URLShortener
+	prevURL   string
+	storedURL string
+}
+
+func (s *stubUpdateShortener) Update(_ context.Context, _, shortID, _ string) (*model.URLStorageRecord, string, error) {
+	return &model.URLStorageRecord{ShortID: shortID, OrigURL: s.prevURL, UserUUID: "userUUID"}, s.storedURL, nil
+}
+
+func TestAPIUserURLs_ProcessUpdate(t *testing.T) {
+	tests := []struct {
+		name        string
+		reqURL      string
+		prevURL     string
+		storedURL   string
+		wantPublish bool
+	}{
+		{
+			name:        "changed destination is audited with canonical url",
+			reqURL:      "HTTPS://New.com:443",
+			prevURL:     "https://old.com/",
+			storedURL:   "https://new.com/",
+			wantPublish: true,
+		},
+		{
+			name:      "non-canonical spelling of current destination is not audited",
+			reqURL:    "HTTPS://Old.com:443",
+			prevURL:   "https://old.com/",
+			storedURL: "https://old.com/",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ub := mocks.NewMockShortURLBuilder(t)
+			ub.EXPECT().Build("abc").Return("https://short.host/abc").Once()
+			ep := mocks.NewMockAuditEventPublisher(t)
+			if tt.wantPublish {
+				ep.EXPECT().Publish(mock.MatchedBy(func(e model.AuditEvent) bool {
+					return e.OrigURL == tt.storedURL && e.PrevURL == tt.prevURL
+				})).Return().Once()
+			}
+
+			p := NewAPIUserURLs(&stubUpdateShortener{prevURL: tt.prevURL, storedURL: tt.storedURL}, zap.NewNop(), ub, ep)
+			ctx := auth.WithUser(context.Background(), &model.User{UUID: "userUUID"})
+
+			item, err := p.ProcessUpdate(ctx, "abc", model.UserURLUpdateRequest{OrigURL: tt.reqURL})
+			require.NoError(t, err)
+			assert.Equal(t, tt.storedURL, item.OrigURL)
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/alex-storchak/shortener/internal/helper/auth"
+	"github.com/alex-storchak/shortener/internal/model"
+)
+
+// UTMTemplateManager defines the interface for managing UTM templates of users' links.
+type UTMTemplateManager interface {
+	GetUserTemplates(ctx context.Context, userUUID string) ([]model.UTMTemplate, error)
+	Set(ctx context.Context, t model.UTMTemplate) (*model.UTMTemplate, error)
+	Delete(ctx context.Context, userUUID, shortID, tag string) error
+}
+
+// APIUserUTMTemplates provides management of UTM templates of the authenticated user.
+// It handles the business logic for the '/api/user/utm-templates' endpoints.
+type APIUserUTMTemplates struct {
+	templates UTMTemplateManager
+	logger    *zap.Logger
+}
+
+// NewAPIUserUTMTemplates creates a new APIUserUTMTemplates processor instance.
+//
+// Parameters:
+//   - templates: UTM templates service
+//   - logger: Structured logger for logging operations
+//
+// Returns: configured APIUserUTMTemplates processor
+func NewAPIUserUTMTemplates(templates UTMTemplateManager, logger *zap.Logger) *APIUserUTMTemplates {
+	return &APIUserUTMTemplates{
+		templates: templates,
+		logger:    logger,
+	}
+}
+
+// ProcessGet retrieves UTM templates of the authenticated user.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//
+// Returns:
+//   - model.UserUTMTemplatesResponse: link templates sorted by short ID, followed by tag templates sorted by tag
+//   - error: nil on success, or service error if operation fails
+func (p *APIUserUTMTemplates) ProcessGet(ctx context.Context) (model.UserUTMTemplatesResponse, error) {
+	userUUID, err := auth.GetCtxUserUUID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get user uuid from context: %w", err)
+	}
+	templates, err := p.templates.GetUserTemplates(ctx, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("get user utm templates: %w", err)
+	}
+
+	resp := make(model.UserUTMTemplatesResponse, len(templates))
+	for i, t := range templates {
+		resp[i] = model.UserUTMTemplate{ShortID: t.ShortID, Tag: t.Tag, Params: t.Params}
+	}
+	return resp, nil
+}
+
+// ProcessSet creates or replaces the UTM template of the authenticated user bound to a link or a tag.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - req: template with its target and parameters
+//
+// Returns:
+//   - *model.UserUTMTemplate: stored template with the normalized tag
+//   - error: nil on success, or service error if validation or operation fails
+func (p *APIUserUTMTemplates) ProcessSet(ctx context.Context, req model.UserUTMTemplate) (*model.UserUTMTemplate, error) {
+	userUUID, err := auth.GetCtxUserUUID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get user uuid from context: %w", err)
+	}
+	t, err := p.templates.Set(ctx, model.UTMTemplate{
+		UserUUID: userUUID,
+		ShortID:  req.ShortID,
+		Tag:      req.Tag,
+		Params:   req.Params,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("set user utm template: %w", err)
+	}
+	return &model.UserUTMTemplate{ShortID: t.ShortID, Tag: t.Tag, Params: t.Params}, nil
+}
+
+// ProcessDelete removes the UTM template of the authenticated user bound to a link or a tag.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - req: template target; parameters are ignored
+//
+// Returns:
+//   - error: nil on success, or service error if there is no such template or operation fails
+func (p *APIUserUTMTemplates) ProcessDelete(ctx context.Context, req model.UserUTMTemplate) error {
+	userUUID, err := auth.GetCtxUserUUID(ctx)
+	if err != nil {
+		return fmt.Errorf("get user uuid from context: %w", err)
+	}
+	if err := p.templates.Delete(ctx, userUUID, req.ShortID, req.Tag); err != nil {
+		return fmt.Errorf("delete user utm template: %w", err)
+	}
+	return nil
+}
@@ -1,5 +1,6 @@
 // Package processor provides business logic processors for URL shortening operations.
 // It contains implementations that handle the core functionality of shortening URLs,
-// expanding short URLs, batch operations, user URL management, and health checks.
+// expanding and inspecting short URLs, QR codes, batch operations, bulk imports, user URL, UTM template, targeting rule, split test and collection management,
+// and health checks.
 // Processors are used by HTTP handlers to separate business logic from HTTP concerns.
 package processor
@@ -3,6 +3,7 @@ package processor
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"time"
 
 	"go.uber.org/zap"
@@ -12,12 +13,31 @@ import (
 	"github.com/alex-storchak/shortener/internal/service"
 )
 
+// Targeter defines the interface for picking the destination of a follow by targeting rules of the link.
+type Targeter interface {
+	Target(ctx context.Context, r *model.URLStorageRecord, req model.ExpandRequest) (dest string, targeted bool, err error)
+}
+
+// VariantPicker defines the interface for picking the variant of a follow by the split test of the link.
+type VariantPicker interface {
+	Pick(ctx context.Context, r *model.URLStorageRecord, req model.ExpandRequest) (*model.SplitChoice, error)
+}
+
+// UTMApplier defines the interface for appending parameters of UTM templates to link destinations.
+type UTMApplier interface {
+	Apply(ctx context.Context, r *model.URLStorageRecord, now time.Time) (string, error)
+}
+
 // Expand provides URL expansion functionality for retrieving original URLs from short identifiers.
 // It handles the business logic for the '/{shortID}' endpoint.
 type Expand struct {
-	shortener service.URLShortener
-	logger    *zap.Logger
-	audit     AuditEventPublisher
+	shortener   service.URLShortener
+	logger      *zap.Logger
+	audit       AuditEventPublisher
+	targeting   Targeter
+	split       VariantPicker
+	utm         UTMApplier
+	cacheMaxAge time.Duration
 }
 
 // NewExpand creates a new Expand processor instance.
@@ -26,45 +46,168 @@ type Expand struct {
 //   - shortener: URL shortener service for URL extraction
 //   - logger: Structured logger for logging operations
 //   - ep: Audit event publisher for recording URL follow actions
+//   - targeting: Targeting rules picking destinations by the client; nil sends every client to the original URL
+//   - split: Split tests picking variants of links at random by weight; nil sends every client to the original URL
+//   - utm: UTM templates applied to destinations; nil applies no templates
+//   - cacheMaxAge: time clients may cache permanent redirects; zero forbids caching of any redirect
 //
 // Returns: configured Expand processor
-func NewExpand(shortener service.URLShortener, logger *zap.Logger, ep AuditEventPublisher) *Expand {
+func NewExpand(
+	shortener service.URLShortener,
+	logger *zap.Logger,
+	ep AuditEventPublisher,
+	targeting Targeter,
+	split VariantPicker,
+	utm UTMApplier,
+	cacheMaxAge time.Duration,
+) *Expand {
 	return &Expand{
-		shortener: shortener,
-		logger:    logger,
-		audit:     ep,
+		shortener:   shortener,
+		logger:      logger,
+		audit:       ep,
+		targeting:   targeting,
+		split:       split,
+		utm:         utm,
+		cacheMaxAge: cacheMaxAge,
 	}
 }
 
-// Process handles the URL expansion request to retrieve original URL from short ID.
-// Also publishes audit events for successful URL follow actions.
+// Process handles the URL expansion request to retrieve original URL from short ID
+// together with the redirect type of the link and the time clients may cache the redirect.
+// The destination is picked by the targeting rules of the link matching the request headers, and clients
+// matching no rule get a variant of the split test of the link; if rules or the split test can't be evaluated,
+// the client is sent to the original URL. Parameters of the UTM templates of the link owner are appended
+// to the destination; if templates can't be applied, the redirect proceeds without them.
+// Also publishes audit events for successful URL follow actions, recording the picked variant,
+// and follows of blocked URLs.
 //
 // Parameters:
 //   - ctx: context for cancellation and timeouts
-//   - shortID: short identifier to expand
+//   - req: expand request with the short identifier, an optional password, the path suffix and query to forward,
+//     the headers matched by targeting rules and the split test variant assigned to the client before
 //
 // Returns:
-//   - string: original URL associated with the short ID
-//   - string: user UUID for audit purposes (empty if not authenticated)
-//   - error: nil on success, or storage error if URL not found or deleted
-func (s *Expand) Process(ctx context.Context, shortID string) (string, error) {
+//   - *model.ExpandResult: original URL associated with the short ID, the URL of the matching targeting rule
+//     or the URL of the picked split test variant, with the forwarded path suffix and query for passthrough links
+//     and the UTM template parameters, the redirect settings and the picked variant
+//   - error: nil on success, storage error if URL not found or deleted, or service error if the password
+//     of a protected URL is missing or wrong, the URL is blocked or the forwarded suffix or query is malformed
+func (s *Expand) Process(ctx context.Context, req model.ExpandRequest) (*model.ExpandResult, error) {
 	userUUID, err := auth.GetCtxUserUUID(ctx)
 	if err != nil {
 		s.logger.Debug("failed to get user uuid from context", zap.Error(err))
 		userUUID = ""
 	}
 
-	origURL, err := s.shortener.Extract(ctx, shortID)
+	r, err := s.shortener.Extract(ctx, req)
 	if err != nil {
-		return "", fmt.Errorf("extract short url from storage: %w", err)
+		publishBlocked(s.audit, userUUID, err)
+		return nil, fmt.Errorf("extract short url from storage: %w", err)
 	}
 
+	now := time.Now()
+	dest, varies := s.target(ctx, r, req, userUUID)
+	var choice *model.SplitChoice
+	if dest == "" {
+		if choice, varies = s.pick(ctx, r, req, userUUID, varies); choice != nil {
+			dest = choice.URL
+		}
+	}
+	if dest != "" {
+		r.OrigURL = dest
+	}
+	dest = s.applyUTM(ctx, r, now)
+
+	res := &model.ExpandResult{
+		OrigURL:      dest,
+		RedirectType: r.Redirect(),
+		CacheMaxAge:  s.redirectMaxAge(r, varies, now),
+	}
+	if choice != nil {
+		res.Variant = choice.Variant
+		res.Sticky = choice.Sticky
+	}
 	s.audit.Publish(model.AuditEvent{
-		TS:      time.Now().Unix(),
+		TS:      now.Unix(),
 		Action:  model.AuditActionFollow,
 		UserID:  userUUID,
-		OrigURL: origURL,
+		OrigURL: dest,
+		Variant: res.Variant,
 	})
+	return res, nil
+}
 
-	return origURL, nil
+// target returns the destination of the link picked by its targeting rules, empty if no rule matches,
+// and whether the link has rules, so the destination depends on the client. Failing to evaluate the rules
+// is logged and sends the client to the original URL, which was checked on extraction, instead of breaking
+// the redirect; a rule destination blocked after the rule was saved is also audited.
+func (s *Expand) target(
+	ctx context.Context,
+	r *model.URLStorageRecord,
+	req model.ExpandRequest,
+	userUUID string,
+) (string, bool) {
+	if s.targeting == nil {
+		return "", false
+	}
+	dest, targeted, err := s.targeting.Target(ctx, r, req)
+	if err != nil {
+		s.logger.Error("failed to apply targeting rules", zap.String("short_id", r.ShortID), zap.Error(err))
+		publishBlocked(s.audit, userUUID, err)
+		return r.OrigURL, true
+	}
+	return dest, targeted
+}
+
+// pick returns the variant of the split test of the link picked for the client, nil if the link has
+// no split test, and whether the destination depends on the client, given it does by targeting rules.
+// Failing to pick a variant is logged and sends the client to the original URL instead of breaking the redirect;
+// a variant destination blocked after the split test was saved is also audited.
+func (s *Expand) pick(
+	ctx context.Context,
+	r *model.URLStorageRecord,
+	req model.ExpandRequest,
+	userUUID string,
+	varies bool,
+) (*model.SplitChoice, bool) {
+	if s.split == nil {
+		return nil, varies
+	}
+	choice, err := s.split.Pick(ctx, r, req)
+	if err != nil {
+		s.logger.Error("failed to pick split test variant", zap.String("short_id", r.ShortID), zap.Error(err))
+		publishBlocked(s.audit, userUUID, err)
+		return nil, true
+	}
+	return choice, varies || choice != nil
+}
+
+// applyUTM returns the destination of the link with the parameters of its UTM templates.
+// Templates are a marketing addition, so failing to apply them is logged and doesn't break the redirect.
+func (s *Expand) applyUTM(ctx context.Context, r *model.URLStorageRecord, now time.Time) string {
+	if s.utm == nil {
+		return r.OrigURL
+	}
+	dest, err := s.utm.Apply(ctx, r, now)
+	if err != nil {
+		s.logger.Error("failed to apply utm templates", zap.String("short_id", r.ShortID), zap.Error(err))
+		return r.OrigURL
+	}
+	return dest
+}
+
+// redirectMaxAge returns the time clients may cache the redirect of the link.
+// Only permanent redirects are cached, and never for click-limited or protected links or links
+// with destinations depending on the client, since every follow of them has to reach the server.
+// The time never exceeds the link expiration.
+func (s *Expand) redirectMaxAge(r *model.URLStorageRecord, varies bool, now time.Time) time.Duration {
+	permanent := r.Redirect() == http.StatusMovedPermanently || r.Redirect() == http.StatusPermanentRedirect
+	if !permanent || r.MaxClicks > 0 || r.IsProtected() || varies {
+		return 0
+	}
+	maxAge := s.cacheMaxAge
+	if !r.ExpiresAt.IsZero() {
+		maxAge = min(maxAge, r.ExpiresAt.Sub(now))
+	}
+	return max(maxAge, 0)
 }
@@ -3,7 +3,10 @@ package processor
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -13,36 +16,76 @@ import (
 	"github.com/alex-storchak/shortener/internal/handler/processor/mocks"
 	"github.com/alex-storchak/shortener/internal/helper/auth"
 	"github.com/alex-storchak/shortener/internal/model"
+	"github.com/alex-storchak/shortener/internal/service"
 )
 
 type stubExpandShortener struct {
-	retURL   string
-	retErr   error
-	retCount int
+	retURL      string
+	retErr      error
+	retCount    int
+	retRedirect int
 }
 
 func (s *stubExpandShortener) IsReady() error {
 	return nil
 }
 
-func (s *stubExpandShortener) Shorten(_ context.Context, _, _ string) (string, error) {
+func (s *stubExpandShortener) Shorten(_ context.Context, _, _ string, _ model.ShortenOptions) (string, error) {
 	return "", nil
 }
-func (s *stubExpandShortener) Extract(_ context.Context, _ string) (string, error) {
-	return s.retURL, s.retErr
+func (s *stubExpandShortener) Extract(_ context.Context, _ model.ExpandRequest) (*model.URLStorageRecord, error) {
+	if s.retErr != nil {
+		return nil, s.retErr
+	}
+	return &model.URLStorageRecord{OrigURL: s.retURL, RedirectType: s.retRedirect}, nil
+}
+func (s *stubExpandShortener) Inspect(_ context.Context, _ string) (*model.URLInspection, error) {
+	return nil, nil
+}
+func (s *stubExpandShortener) ShortenBatch(_ context.Context, _ string, _ model.URLShortenBatch) ([]string, error) {
+	return nil, nil
+}
+
+func (s *stubExpandShortener) ImportBatch(_ context.Context, _ string, _ []model.URLImportRow) ([]model.URLImportResult, error) {
+	return nil, nil
 }
-func (s *stubExpandShortener) ShortenBatch(_ context.Context, _ string, _ []string) ([]string, error) {
+
+func (s *stubExpandShortener) GetUserURLs(_ context.Context, _ string, _ []string) ([]*model.URLStorageRecord, error) {
+	return nil, nil
+}
+
+func (s *stubExpandShortener) GetUserTags(_ context.Context, _ string) ([]model.TagCount, error) {
 	return nil, nil
 }
 
-func (s *stubExpandShortener) GetUserURLs(_ context.Context, _ string) ([]*model.URLStorageRecord, error) {
+func (s *stubExpandShortener) SetTags(_ context.Context, _, _ string, _ []string) (*model.URLStorageRecord, error) {
 	return nil, nil
 }
 
+func (s *stubExpandShortener) Update(_ context.Context, _, _, _ string) (*model.URLStorageRecord, string, error) {
+	return nil, "", nil
+}
+
+func (s *stubExpandShortener) GetHistory(_ context.Context, _, _ string) ([]model.URLHistoryRecord, error) {
+	return nil, nil
+}
+
+func (s *stubExpandShortener) RestoreVersion(_ context.Context, _, _ string, _ int) (*model.URLStorageRecord, string, error) {
+	return nil, "", nil
+}
+
 func (s *stubExpandShortener) DeleteBatch(_ context.Context, _ model.URLDeleteBatch) error {
 	return nil
 }
 
+func (s *stubExpandShortener) GetUserTrash(_ context.Context, _ string) ([]*model.URLStorageRecord, error) {
+	return nil, nil
+}
+
+func (s *stubExpandShortener) RestoreBatch(_ context.Context, _ string, _ []string) ([]model.URLRestoreResult, error) {
+	return nil, nil
+}
+
 func (s *stubExpandShortener) Count(_ context.Context) (int, error) {
 	return s.retCount, nil
 }
@@ -75,28 +118,291 @@ func TestShortURLService_Expand(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			shortener := &stubExpandShortener{tt.stubOrigURL, tt.stubErr, tt.stubUrlsCount}
+			shortener := &stubExpandShortener{retURL: tt.stubOrigURL, retErr: tt.stubErr, retCount: tt.stubUrlsCount}
 			ep := mocks.NewMockAuditEventPublisher(t)
 			if !tt.wantErr {
 				ep.EXPECT().Publish(mock.AnythingOfType("model.AuditEvent")).Return().Once()
 			}
 
-			srv := NewExpand(shortener, zap.NewNop(), ep)
+			srv := NewExpand(shortener, zap.NewNop(), ep, nil, nil, nil, time.Hour)
 			ctx := auth.WithUser(context.Background(), &model.User{UUID: "userUUID"})
 
-			gotURL, gotErr := srv.Process(ctx, tt.shortID)
+			got, gotErr := srv.Process(ctx, model.ExpandRequest{ShortID: tt.shortID})
 
 			if tt.wantErr {
 				require.Error(t, gotErr)
 				if tt.wantErrIs != nil {
 					require.ErrorIs(t, gotErr, tt.wantErrIs)
 				}
-				assert.Equal(t, "", gotURL)
+				assert.Nil(t, got)
 				return
 			}
 
 			require.NoError(t, gotErr)
-			assert.Equal(t, tt.wantOrigURL, gotURL)
+			assert.Equal(t, tt.wantOrigURL, got.OrigURL)
+			assert.Equal(t, http.StatusTemporaryRedirect, got.RedirectType)
+			assert.Zero(t, got.CacheMaxAge)
+		})
+	}
+}
+
+func TestExpand_RedirectMaxAge(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name   string
+		record model.URLStorageRecord
+		want   time.Duration
+	}{
+		{
+			name:   "permanent redirect is cached",
+			record: model.URLStorageRecord{RedirectType: http.StatusMovedPermanently},
+			want:   time.Hour,
+		},
+		{
+			name:   "permanent redirect is cached until the link expires",
+			record: model.URLStorageRecord{RedirectType: http.StatusPermanentRedirect, ExpiresAt: now.Add(time.Minute)},
+			want:   time.Minute,
+		},
+		{
+			name:   "temporary redirect is not cached",
+			record: model.URLStorageRecord{RedirectType: http.StatusFound},
+			want:   0,
+		},
+		{
+			name:   "legacy link without redirect type is not cached",
+			record: model.URLStorageRecord{},
+			want:   0,
+		},
+		{
+			name:   "click-limited permanent redirect is not cached",
+			record: model.URLStorageRecord{RedirectType: http.StatusMovedPermanently, MaxClicks: 3, ClicksLeft: 3},
+			want:   0,
+		},
+		{
+			name:   "protected permanent redirect is not cached",
+			record: model.URLStorageRecord{RedirectType: http.StatusMovedPermanently, PassHash: "hash"},
+			want:   0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := NewExpand(&stubExpandShortener{}, zap.NewNop(), nil, nil, nil, nil, time.Hour)
+			assert.Equal(t, tt.want, srv.redirectMaxAge(&tt.record, false, now))
+		})
+	}
+}
+
+type stubUTMApplier struct {
+	suffix string
+	err    error
+}
+
+func (s *stubUTMApplier) Apply(_ context.Context, r *model.URLStorageRecord, _ time.Time) (string, error) {
+	if s.err != nil {
+		return "", s.err
+	}
+	return r.OrigURL + s.suffix, nil
+}
+
+func TestExpand_UTM(t *testing.T) {
+	tests := []struct {
+		name string
+		utm  UTMApplier
+		want string
+	}{
+		{
+			name: "appends utm parameters to destination",
+			utm:  &stubUTMApplier{suffix: "?utm_source=mail"},
+			want: "https://existing.com?utm_source=mail",
+		},
+		{
+			name: "redirects without utm parameters if templates can't be applied",
+			utm:  &stubUTMApplier{err: errors.New("storage error")},
+			want: "https://existing.com",
+		},
+		{
+			name: "no templates without applier",
+			want: "https://existing.com",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ep := mocks.NewMockAuditEventPublisher(t)
+			ep.EXPECT().Publish(mock.MatchedBy(func(e model.AuditEvent) bool {
+				return e.OrigURL == tt.want
+			})).Return().Once()
+
+			srv := NewExpand(&stubExpandShortener{retURL: "https://existing.com"}, zap.NewNop(), ep, nil, nil, tt.utm, time.Hour)
+			got, err := srv.Process(context.Background(), model.ExpandRequest{ShortID: "abcde"})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.OrigURL)
+		})
+	}
+}
+
+type stubTargeter struct {
+	dest     string
+	targeted bool
+	err      error
+}
+
+func (s *stubTargeter) Target(_ context.Context, _ *model.URLStorageRecord, _ model.ExpandRequest) (string, bool, error) {
+	if s.err != nil {
+		return "", true, s.err
+	}
+	if s.dest == "" {
+		return "", s.targeted, nil
+	}
+	return s.dest, true, nil
+}
+
+func TestExpand_Targeting(t *testing.T) {
+	tests := []struct {
+		name        string
+		targeting   Targeter
+		want        string
+		wantBlocked string
+		wantCache   bool
+	}{
+		{
+			name:      "redirects to destination of matching rule with utm parameters",
+			targeting: &stubTargeter{dest: "https://apps.apple.com/app"},
+			want:      "https://apps.apple.com/app?utm_source=mail",
+		},
+		{
+			name:      "redirects to original url if no rule matches",
+			targeting: &stubTargeter{targeted: true},
+			want:      "https://existing.com?utm_source=mail",
+		},
+		{
+			name:      "redirects to original url if rules can't be evaluated",
+			targeting: &stubTargeter{err: errors.New("storage error")},
+			want:      "https://existing.com?utm_source=mail",
+		},
+		{
+			name: "redirects to original url and audits blocked destination of matching rule",
+			targeting: &stubTargeter{err: fmt.Errorf("check url of rule 1: %w", &service.BlockedURLError{
+				OrigURL: "https://evil.com", Rule: "evil.com",
+			})},
+			want:        "https://existing.com?utm_source=mail",
+			wantBlocked: "https://evil.com",
+		},
+		{
+			name:      "caches redirect of link without rules",
+			targeting: &stubTargeter{},
+			want:      "https://existing.com?utm_source=mail",
+			wantCache: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ep := mocks.NewMockAuditEventPublisher(t)
+			ep.EXPECT().Publish(mock.MatchedBy(func(e model.AuditEvent) bool {
+				return e.OrigURL == tt.want
+			})).Return().Once()
+			if tt.wantBlocked != "" {
+				ep.EXPECT().Publish(mock.MatchedBy(func(e model.AuditEvent) bool {
+					return e.Action == model.AuditActionBlock && e.OrigURL == tt.wantBlocked
+				})).Return().Once()
+			}
+
+			utm := &stubUTMApplier{suffix: "?utm_source=mail"}
+			sh := &stubExpandShortener{retURL: "https://existing.com", retRedirect: http.StatusMovedPermanently}
+			srv := NewExpand(sh, zap.NewNop(), ep, tt.targeting, nil, utm, time.Hour)
+			got, err := srv.Process(context.Background(), model.ExpandRequest{ShortID: "abcde"})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.OrigURL)
+			if tt.wantCache {
+				assert.Equal(t, time.Hour, got.CacheMaxAge)
+			} else {
+				assert.Zero(t, got.CacheMaxAge)
+			}
+		})
+	}
+}
+
+type stubVariantPicker struct {
+	choice *model.SplitChoice
+	err    error
+}
+
+func (s *stubVariantPicker) Pick(_ context.Context, _ *model.URLStorageRecord, _ model.ExpandRequest) (*model.SplitChoice, error) {
+	return s.choice, s.err
+}
+
+func TestExpand_Split(t *testing.T) {
+	variantB := &model.SplitChoice{Variant: "b", URL: "https://example.com/b", Sticky: true}
+	tests := []struct {
+		name        string
+		targeting   Targeter
+		split       VariantPicker
+		want        string
+		wantVariant string
+		wantSticky  bool
+		wantBlocked string
+		wantCache   bool
+	}{
+		{
+			name:        "redirects to picked variant and records it",
+			targeting:   &stubTargeter{},
+			split:       &stubVariantPicker{choice: variantB},
+			want:        "https://example.com/b",
+			wantVariant: "b",
+			wantSticky:  true,
+		},
+		{
+			name:      "matching targeting rule takes precedence over split test",
+			targeting: &stubTargeter{dest: "https://apps.apple.com/app"},
+			split:     &stubVariantPicker{choice: variantB},
+			want:      "https://apps.apple.com/app",
+		},
+		{
+			name:      "redirects to original url if variant can't be picked",
+			targeting: &stubTargeter{},
+			split:     &stubVariantPicker{err: errors.New("storage error")},
+			want:      "https://existing.com",
+		},
+		{
+			name:      "redirects to original url and audits blocked destination of picked variant",
+			targeting: &stubTargeter{},
+			split: &stubVariantPicker{err: fmt.Errorf("check url of variant `b`: %w", &service.BlockedURLError{
+				OrigURL: "https://evil.com", Rule: "evil.com",
+			})},
+			want:        "https://existing.com",
+			wantBlocked: "https://evil.com",
+		},
+		{
+			name:      "caches redirect of link without split test",
+			targeting: &stubTargeter{},
+			split:     &stubVariantPicker{},
+			want:      "https://existing.com",
+			wantCache: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ep := mocks.NewMockAuditEventPublisher(t)
+			ep.EXPECT().Publish(mock.MatchedBy(func(e model.AuditEvent) bool {
+				return e.OrigURL == tt.want && e.Variant == tt.wantVariant
+			})).Return().Once()
+			if tt.wantBlocked != "" {
+				ep.EXPECT().Publish(mock.MatchedBy(func(e model.AuditEvent) bool {
+					return e.Action == model.AuditActionBlock && e.OrigURL == tt.wantBlocked
+				})).Return().Once()
+			}
+
+			sh := &stubExpandShortener{retURL: "https://existing.com", retRedirect: http.StatusMovedPermanently}
+			srv := NewExpand(sh, zap.NewNop(), ep, tt.targeting, tt.split, nil, time.Hour)
+			got, err := srv.Process(context.Background(), model.ExpandRequest{ShortID: "abcde"})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.OrigURL)
+			assert.Equal(t, tt.wantVariant, got.Variant)
+			assert.Equal(t, tt.wantSticky, got.Sticky)
+			if tt.wantCache {
+				assert.Equal(t, time.Hour, got.CacheMaxAge)
+			} else {
+				assert.Zero(t, got.CacheMaxAge)
+			}
 		})
 	}
 }
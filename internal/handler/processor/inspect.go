@@ -0,0 +1,74 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/alex-storchak/shortener/internal/model"
+)
+
+// URLInspector defines the interface for retrieving the details of short URLs without following them.
+type URLInspector interface {
+	Inspect(ctx context.Context, shortID string) (*model.URLInspection, error)
+}
+
+// Inspect provides inspection of short URLs, showing their destinations without redirecting.
+// It handles the business logic for the '/{shortID}/preview', '/{shortID}+' and '/api/expand/{shortID}' endpoints.
+// Inspection neither publishes follow audit events nor counts as a click.
+type Inspect struct {
+	inspector URLInspector
+	logger    *zap.Logger
+	ub        ShortURLBuilder
+}
+
+// NewInspect creates a new Inspect processor instance.
+//
+// Parameters:
+//   - inspector: URL shortener service for URL inspection
+//   - logger: Structured logger for logging operations
+//   - ub: Short URL builder for constructing the full short URL
+//
+// Returns: configured Inspect processor
+func NewInspect(inspector URLInspector, logger *zap.Logger, ub ShortURLBuilder) *Inspect {
+	return &Inspect{
+		inspector: inspector,
+		logger:    logger,
+		ub:        ub,
+	}
+}
+
+// Process retrieves the details of a short URL: its destination, creation date and safety status.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - shortID: short identifier of the URL
+//
+// Returns:
+//   - *model.InspectResponse: details of the URL; the original URL is omitted for password-protected links
+//   - error: nil on success, storage error if URL not found, deleted, expired or has no follows left,
+//     or service error if the check character of the short ID is wrong
+func (s *Inspect) Process(ctx context.Context, shortID string) (*model.InspectResponse, error) {
+	in, err := s.inspector.Inspect(ctx, shortID)
+	if err != nil {
+		return nil, fmt.Errorf("inspect short url: %w", err)
+	}
+
+	resp := &model.InspectResponse{
+		ShortURL:    s.ub.Build(in.ShortID),
+		OrigURL:     in.OrigURL,
+		Protected:   in.Protected,
+		Safety:      in.Safety,
+		Passthrough: in.Passthrough,
+	}
+	if !in.CreatedAt.IsZero() {
+		createdAt := in.CreatedAt
+		resp.CreatedAt = &createdAt
+	}
+	if !in.ExpiresAt.IsZero() {
+		expiresAt := in.ExpiresAt
+		resp.ExpiresAt = &expiresAt
+	}
+	return resp, nil
+}
@@ -0,0 +1,55 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/alex-storchak/shortener/internal/model"
+)
+
+// QRCodeGenerator defines the interface for rendering QR codes of short URLs.
+type QRCodeGenerator interface {
+	Generate(ctx context.Context, shortID string, opts model.QROptions) (*model.QRCode, error)
+}
+
+// QRCode provides QR code images of short URLs.
+// It handles the business logic for the '/{shortID}/qr' endpoint.
+type QRCode struct {
+	generator QRCodeGenerator
+	logger    *zap.Logger
+}
+
+// NewQRCode creates a new QRCode processor instance.
+//
+// Parameters:
+//   - generator: QR code service rendering and caching images
+//   - logger: Structured logger for logging operations
+//
+// Returns: configured QRCode processor
+func NewQRCode(generator QRCodeGenerator, logger *zap.Logger) *QRCode {
+	return &QRCode{
+		generator: generator,
+		logger:    logger,
+	}
+}
+
+// Process renders the QR code encoding the short URL.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - shortID: short identifier of the URL
+//   - opts: format, size, error correction level, margin and colors of the image
+//
+// Returns:
+//   - *model.QRCode: rendered image with its MIME type
+//   - error: nil on success, storage error if URL not found, deleted, expired or has no follows left,
+//     or service error if the options are invalid
+func (s *QRCode) Process(ctx context.Context, shortID string, opts model.QROptions) (*model.QRCode, error) {
+	code, err := s.generator.Generate(ctx, shortID, opts)
+	if err != nil {
+		return nil, fmt.Errorf("generate qr code: %w", err)
+	}
+	return code, nil
+}
@@ -19,6 +19,22 @@ type AuditEventPublisher interface {
 	Publish(event model.AuditEvent)
 }
 
+// publishBlocked publishes the audit event of an attempt to shorten or follow a URL matching the blocklist.
+// It is a no-op unless err is caused by *service.BlockedURLError.
+func publishBlocked(ep AuditEventPublisher, userUUID string, err error) {
+	var bErr *service.BlockedURLError
+	if !errors.As(err, &bErr) {
+		return
+	}
+	ep.Publish(model.AuditEvent{
+		TS:      time.Now().Unix(),
+		Action:  model.AuditActionBlock,
+		UserID:  userUUID,
+		OrigURL: bErr.OrigURL,
+		Rule:    bErr.Rule,
+	})
+}
+
 // Shorten provides URL shortening functionality for plain text requests.
 // It handles the business logic for the main '/' endpoint with text/plain content.
 type Shorten struct {
@@ -48,7 +64,7 @@ func NewShorten(s service.URLShortener, l *zap.Logger, ub ShortURLBuilder, ep Au
 
 // Process handles the URL shortening request for plain text endpoint.
 // It reads the URL from the request body and returns the shortened version.
-// Also publishes audit events for successful shortening operations.
+// Also publishes audit events for successful shortening operations and blocked URLs.
 //
 // Parameters:
 //   - ctx: context for cancellation and timeouts
@@ -67,11 +83,12 @@ func (s *Shorten) Process(ctx context.Context, body []byte) (string, error) {
 	if err != nil {
 		return "", fmt.Errorf("get user uuid from context: %w", err)
 	}
-	shortID, err := s.shortener.Shorten(ctx, userUUID, origURL)
+	shortID, err := s.shortener.Shorten(ctx, userUUID, origURL, model.ShortenOptions{})
 	if errors.Is(err, service.ErrURLAlreadyExists) {
 		shortURL := s.ub.Build(shortID)
 		return shortURL, fmt.Errorf("tried to shorten existing url: %w", err)
 	} else if err != nil {
+		publishBlocked(s.audit, userUUID, err)
 		return "", fmt.Errorf("shorten url: %w", err)
 	}
 
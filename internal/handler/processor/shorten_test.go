@@ -22,25 +22,60 @@ type stubShortener struct {
 	retCount   int
 }
 
-func (s *stubShortener) Shorten(_ context.Context, _, _ string) (string, error) {
+func (s *stubShortener) Shorten(_ context.Context, _, _ string, _ model.ShortenOptions) (string, error) {
 	return s.retShortID, s.retErr
 }
 
-func (s *stubShortener) Extract(_ context.Context, _ string) (string, error) {
-	return "", nil
+func (s *stubShortener) Extract(_ context.Context, _ model.ExpandRequest) (*model.URLStorageRecord, error) {
+	return nil, nil
+}
+func (s *stubShortener) Inspect(_ context.Context, _ string) (*model.URLInspection, error) {
+	return nil, nil
 }
-func (s *stubShortener) ShortenBatch(_ context.Context, _ string, _ []string) ([]string, error) {
+func (s *stubShortener) ShortenBatch(_ context.Context, _ string, _ model.URLShortenBatch) ([]string, error) {
 	return nil, nil
 }
 
-func (s *stubShortener) GetUserURLs(_ context.Context, _ string) ([]*model.URLStorageRecord, error) {
+func (s *stubShortener) ImportBatch(_ context.Context, _ string, _ []model.URLImportRow) ([]model.URLImportResult, error) {
 	return nil, nil
 }
 
+func (s *stubShortener) GetUserURLs(_ context.Context, _ string, _ []string) ([]*model.URLStorageRecord, error) {
+	return nil, nil
+}
+
+func (s *stubShortener) GetUserTags(_ context.Context, _ string) ([]model.TagCount, error) {
+	return nil, nil
+}
+
+func (s *stubShortener) SetTags(_ context.Context, _, _ string, _ []string) (*model.URLStorageRecord, error) {
+	return nil, nil
+}
+
+func (s *stubShortener) Update(_ context.Context, _, _, _ string) (*model.URLStorageRecord, string, error) {
+	return nil, "", nil
+}
+
+func (s *stubShortener) GetHistory(_ context.Context, _, _ string) ([]model.URLHistoryRecord, error) {
+	return nil, nil
+}
+
+func (s *stubShortener) RestoreVersion(_ context.Context, _, _ string, _ int) (*model.URLStorageRecord, string, error) {
+	return nil, "", nil
+}
+
 func (s *stubShortener) DeleteBatch(_ context.Context, _ model.URLDeleteBatch) error {
 	return nil
 }
 
+func (s *stubShortener) GetUserTrash(_ context.Context, _ string) ([]*model.URLStorageRecord, error) {
+	return nil, nil
+}
+
+func (s *stubShortener) RestoreBatch(_ context.Context, _ string, _ []string) ([]model.URLRestoreResult, error) {
+	return nil, nil
+}
+
 func (s *stubShortener) Count(_ context.Context) (int, error) {
 	return s.retCount, nil
 }
@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/alex-storchak/shortener/internal/model"
+	"github.com/alex-storchak/shortener/internal/repository"
+	"github.com/alex-storchak/shortener/internal/service"
+)
+
+// qrCacheMaxAge is the time in seconds clients may cache QR code images.
+// An image only depends on the short URL and the options, so it never changes.
+const qrCacheMaxAge = 7 * 24 * 60 * 60
+
+// QRCodeProcessor defines the interface for processing QR code requests.
+// Implementations render images encoding short URLs.
+type QRCodeProcessor interface {
+	Process(ctx context.Context, shortID string, opts model.QROptions) (*model.QRCode, error)
+}
+
+// HandleQRCode creates an HTTP handler for QR codes of short URLs.
+// It handles GET requests to '/{shortID}/qr' endpoint with optional query parameters:
+// 'format' (png or svg), 'size' in pixels, 'level' of error correction (L, M, Q or H),
+// 'margin' in modules and 'fg' and 'bg' colors in hex notation, e.g. '?format=svg&fg=1a2b3c'.
+// If forward is given, requests to links with passthrough enabled are served by forward instead, so that
+// the '/qr' path suffix reaches the destination as before the route existed; the options of such requests
+// are not validated, as they belong to the destination too.
+//
+// The handler:
+//   - Renders the QR code encoding the short URL, or serves the image rendered before with the same options
+//   - Returns appropriate HTTP status codes:
+//   - 200 OK with the PNG or SVG image and a Cache-Control header allowing clients to cache it
+//   - 400 Bad Request for malformed or out of range options
+//   - 404 Not Found when short ID doesn't exist
+//   - 410 Gone when the URL has been deleted, has expired or has no follows left
+//   - 500 Internal Server Error for processing failures
+//
+// Parameters:
+//   - p: Processor implementing the QR code rendering logic
+//   - forward: handler redirecting requests of passthrough links to the destination with the path suffix;
+//     nil to render the QR code of every link
+//   - l: Logger for logging operations
+//
+// Returns:
+//   - HTTP handler function for the QR code endpoint
+func HandleQRCode(p QRCodeProcessor, forward http.Handler, l *zap.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		opts, optsErr := newQROptions(r.URL.Query())
+		opts.SkipPassthrough = forward != nil
+
+		code, err := p.Process(r.Context(), chi.URLParam(r, ShortIDParam), opts)
+		var nfErr *repository.DataNotFoundError
+		if errors.Is(err, service.ErrPassthroughLink) {
+			forward.ServeHTTP(w, r)
+			return
+		} else if optsErr != nil {
+			http.Error(w, optsErr.Error(), http.StatusBadRequest)
+			return
+		} else if isValidationError(err) {
+			writeBadRequest(w, err)
+			return
+		} else if errors.As(err, &nfErr) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		} else if isGoneError(err) {
+			w.WriteHeader(http.StatusGone)
+			return
+		} else if err != nil {
+			l.Error("error generating qr code", zap.Error(err))
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", code.ContentType)
+		w.Header().Set("Content-Length", strconv.Itoa(len(code.Data)))
+		w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", qrCacheMaxAge))
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write(code.Data); err != nil {
+			l.Debug("write qr code", zap.Error(err))
+		}
+	}
+}
+
+// newQROptions builds the QR code options from the query parameters.
+// Numbers are parsed here, while their ranges and other options are validated by the service.
+func newQROptions(q url.Values) (model.QROptions, error) {
+	opts := model.QROptions{
+		Format:     q.Get("format"),
+		Level:      q.Get("level"),
+		Foreground: q.Get("fg"),
+		Background: q.Get("bg"),
+	}
+	if s := q.Get("size"); s != "" {
+		size, err := strconv.Atoi(s)
+		if err != nil {
+			return opts, fmt.Errorf("size `%s` must be an integer", s)
+		}
+		opts.Size = size
+	}
+	if s := q.Get("margin"); s != "" {
+		margin, err := strconv.Atoi(s)
+		if err != nil {
+			return opts, fmt.Errorf("margin `%s` must be an integer", s)
+		}
+		opts.Margin = &margin
+	}
+	return opts, nil
+}
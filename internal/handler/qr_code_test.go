@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/alex-storchak/shortener/internal/model"
+	repo "github.com/alex-storchak/shortener/internal/repository"
+	"github.com/alex-storchak/shortener/internal/service"
+)
+
+type qrCodeSrvStub struct {
+	err     error
+	gotID   string
+	gotOpts model.QROptions
+}
+
+func (s *qrCodeSrvStub) Process(_ context.Context, shortID string, opts model.QROptions) (*model.QRCode, error) {
+	s.gotID, s.gotOpts = shortID, opts
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &model.QRCode{Data: []byte("<svg/>"), ContentType: "image/svg+xml"}, nil
+}
+
+func TestQRCode(t *testing.T) {
+	margin := 2
+	tests := []struct {
+		name     string
+		query    string
+		err      error
+		wantCode int
+		wantOpts model.QROptions
+	}{
+		{
+			name:     "default options return 200 (OK) with image",
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "query options are passed to processor",
+			query:    "?format=svg&size=512&level=H&margin=2&fg=1a2b3c&bg=%23fff",
+			wantCode: http.StatusOK,
+			wantOpts: model.QROptions{
+				Format: "svg", Size: 512, Level: "H", Margin: &margin, Foreground: "1a2b3c", Background: "#fff",
+			},
+		},
+		{
+			name:     "malformed size returns 400 (Bad Request)",
+			query:    "?size=big",
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "malformed margin returns 400 (Bad Request)",
+			query:    "?margin=1.5",
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "invalid options return 400 (Bad Request)",
+			query:    "?format=gif",
+			err:      service.NewValidationError(service.ErrInvalidQROptions, "format `gif` must be png or svg"),
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "not existing short url returns 404 (Not Found)",
+			err:      repo.NewDataNotFoundError(nil),
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "deleted short url returns 410 (Gone)",
+			err:      repo.ErrDataDeleted,
+			wantCode: http.StatusGone,
+		},
+		{
+			name:     "random error returns 500 (Internal Server Error)",
+			err:      errors.New("random error"),
+			wantCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := &qrCodeSrvStub{err: tt.err}
+			mux := chi.NewRouter()
+			mux.Get("/{id:[a-zA-Z0-9_-]+}/qr", HandleQRCode(srv, nil, zap.NewNop()))
+			mux.Get("/{id:[a-zA-Z0-9_-]+}/*", HandleExpand(stubExpandProc{t}, zap.NewNop()))
+
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/abcde/qr"+tt.query, nil))
+			res := w.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, tt.wantCode, res.StatusCode)
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+			assert.Equal(t, "abcde", srv.gotID)
+			assert.Equal(t, tt.wantOpts, srv.gotOpts)
+			assert.Equal(t, "image/svg+xml", res.Header.Get("Content-Type"))
+			assert.Equal(t, "public, max-age=604800", res.Header.Get("Cache-Control"))
+			body, err := io.ReadAll(res.Body)
+			require.NoError(t, err)
+			assert.Equal(t, "<svg/>", string(body))
+		})
+	}
+}
+
+func TestQRCode_Passthrough(t *testing.T) {
+	srv := &qrCodeSrvStub{err: service.ErrPassthroughLink}
+	expand := &passthroughExpandProc{}
+	mux := chi.NewRouter()
+	mux.Get("/{id:[a-zA-Z0-9_-]+}/qr", HandleQRCode(srv, HandleExpand(expand, zap.NewNop()), zap.NewNop()))
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/abcde/qr?size=big", nil))
+	res := w.Result()
+	defer res.Body.Close()
+
+	assert.Equal(t, http.StatusTemporaryRedirect, res.StatusCode)
+	assert.Equal(t, "https://example.com/qr?size=big", res.Header.Get("Location"))
+	assert.Equal(t, "/qr", expand.got.Path)
+	assert.True(t, srv.gotOpts.SkipPassthrough, "qr code of passthrough link must not be rendered")
+}
@@ -11,6 +11,10 @@ import (
 // Used in routes like '/{id}' where 'id' is the short URL identifier.
 const ShortIDParam = "id"
 
+// CollectionIDParam defines the URL parameter name for collection identifiers.
+// Used in routes like '/api/user/collections/{id}' where 'id' is the collection identifier.
+const CollectionIDParam = "id"
+
 // addRoutes configures all HTTP routes and middleware for the application.
 // It sets up the complete routing hierarchy including:
 // - Global middleware (logging, compression, recovery)
@@ -40,17 +44,52 @@ func addRoutes(
 
 		mux.Post("/", HandleShorten(h.ShortenProc, h.Logger))
 		mux.Get("/{id:[a-zA-Z0-9_-]+}", HandleExpand(h.ExpandProc, h.Logger))
+		mux.Get("/{id:[a-zA-Z0-9_-]+}+", HandlePreview(h.InspectProc, nil, h.Logger))
+		mux.Get("/{id:[a-zA-Z0-9_-]+}/preview", HandlePreview(h.InspectProc, HandleExpand(h.ExpandProc, h.Logger), h.Logger))
+		mux.Get("/{id:[a-zA-Z0-9_-]+}/qr", HandleQRCode(h.QRCodeProc, HandleExpand(h.ExpandProc, h.Logger), h.Logger))
+		mux.Post("/{id:[a-zA-Z0-9_-]+}", HandleExpandProtected(h.ExpandProc, h.Logger))
+		mux.Get("/{id:[a-zA-Z0-9_-]+}/*", HandleExpand(h.ExpandProc, h.Logger))
+		mux.Post("/{id:[a-zA-Z0-9_-]+}/*", HandleExpandProtected(h.ExpandProc, h.Logger))
 		mux.Get("/ping", HandlePing(h.PingProc, h.Logger))
 
 		mux.Route("/api", func(mux chi.Router) {
 			mux.Route("/shorten", func(mux chi.Router) {
 				mux.Post("/", HandleAPIShorten(h.APIShortenProc, h.Logger))
 				mux.Post("/batch", HandleAPIShortenBatch(h.APIShortenBatchProc, h.Logger))
+				mux.Post("/import", HandleAPIShortenImport(h.APIImportProc, h.Logger))
 			})
+			mux.Get("/expand/{id:[a-zA-Z0-9_-]+}", HandleAPIExpand(h.InspectProc, h.Logger))
 
 			mux.Route("/user/urls", func(mux chi.Router) {
 				mux.Get("/", HandleGetUserURLs(h.APIUserURLsProc, h.Logger))
 				mux.Delete("/", HandleDeleteUserURLs(h.APIUserURLsProc, h.Logger))
+				mux.Get("/trash", HandleGetUserTrash(h.APIUserURLsProc, h.Logger))
+				mux.Post("/restore", HandleRestoreUserURLs(h.APIUserURLsProc, h.Logger))
+				mux.Patch("/{id:[a-zA-Z0-9_-]+}", HandleUpdateUserURL(h.APIUserURLsProc, h.Logger))
+				mux.Get("/{id:[a-zA-Z0-9_-]+}/history", HandleGetUserURLHistory(h.APIUserURLsProc, h.Logger))
+				mux.Post("/{id:[a-zA-Z0-9_-]+}/history/restore", HandleRestoreUserURLVersion(h.APIUserURLsProc, h.Logger))
+				mux.Get("/{id:[a-zA-Z0-9_-]+}/rules", HandleGetUserURLRules(h.APIUserTargetProc, h.Logger))
+				mux.Put("/{id:[a-zA-Z0-9_-]+}/rules", HandleSetUserURLRules(h.APIUserTargetProc, h.Logger))
+				mux.Get("/{id:[a-zA-Z0-9_-]+}/split", HandleGetUserURLSplit(h.APIUserSplitProc, h.Logger))
+				mux.Put("/{id:[a-zA-Z0-9_-]+}/split", HandleSetUserURLSplit(h.APIUserSplitProc, h.Logger))
+			})
+			mux.Get("/user/tags", HandleGetUserTags(h.APIUserURLsProc, h.Logger))
+
+			mux.Route("/user/utm-templates", func(mux chi.Router) {
+				mux.Get("/", HandleGetUserUTMTemplates(h.APIUserUTMProc, h.Logger))
+				mux.Put("/", HandleSetUserUTMTemplate(h.APIUserUTMProc, h.Logger))
+				mux.Delete("/", HandleDeleteUserUTMTemplate(h.APIUserUTMProc, h.Logger))
+			})
+
+			mux.Route("/user/collections", func(mux chi.Router) {
+				mux.Get("/", HandleGetUserCollections(h.APIUserCollProc, h.Logger))
+				mux.Post("/", HandleCreateUserCollection(h.APIUserCollProc, h.Logger))
+				mux.Post("/move", HandleMoveUserCollectionURLs(h.APIUserCollProc, h.Logger))
+				mux.Patch("/{id:[a-zA-Z0-9-]+}", HandleRenameUserCollection(h.APIUserCollProc, h.Logger))
+				mux.Delete("/{id:[a-zA-Z0-9-]+}", HandleDeleteUserCollection(h.APIUserCollProc, h.Logger))
+				mux.Get("/{id:[a-zA-Z0-9-]+}/urls", HandleGetUserCollectionURLs(h.APIUserCollProc, h.Logger))
+				mux.Delete("/{id:[a-zA-Z0-9-]+}/urls", HandleDeleteUserCollectionURLs(h.APIUserCollProc, h.Logger))
+				mux.Get("/{id:[a-zA-Z0-9-]+}/export", HandleExportUserCollection(h.APIUserCollProc, h.Logger))
 			})
 
 			mux.Route("/internal", func(mux chi.Router) {
@@ -10,15 +10,22 @@ import (
 
 // ServerDeps contains dependencies required for HTTP or GRPC server initialization.
 type ServerDeps struct {
-	Logger              *zap.Logger              // Structured logger for logging operations
-	Config              *config.Config           // Application configuration containing server settings and auth configuration
-	HTTPUserResolver    middleware.UserResolver  // Service for resolving and validating user authentication in http requests
-	GRPCUserResolver    interceptor.UserResolver // Service for resolving and validating user authentication in grpc requests
-	ShortenProc         ShortenProcessor         // Processor for plain text URL shortening requests
-	ExpandProc          ExpandProcessor          // Processor for expanding short URLs to original URLs
-	PingProc            PingProcessor            // Processor for health check requests
-	APIShortenProc      APIShortenProcessor      // Processor for JSON API URL shortening requests
-	APIShortenBatchProc APIShortenBatchProcessor // Processor for batch URL shortening operations
-	APIUserURLsProc     APIUserURLsProcessor     // Processor for user-specific URL management operations
-	APIInternalProc     APIInternalProcessor     // Processor for internal stats requests
+	Logger              *zap.Logger                  // Structured logger for logging operations
+	Config              *config.Config               // Application configuration containing server settings and auth configuration
+	HTTPUserResolver    middleware.UserResolver      // Service for resolving and validating user authentication in http requests
+	GRPCUserResolver    interceptor.UserResolver     // Service for resolving and validating user authentication in grpc requests
+	ShortenProc         ShortenProcessor             // Processor for plain text URL shortening requests
+	ExpandProc          ExpandProcessor              // Processor for expanding short URLs to original URLs
+	InspectProc         InspectProcessor             // Processor for inspecting short URLs without following them
+	QRCodeProc          QRCodeProcessor              // Processor for QR code images of short URLs
+	PingProc            PingProcessor                // Processor for health check requests
+	APIShortenProc      APIShortenProcessor          // Processor for JSON API URL shortening requests
+	APIShortenBatchProc APIShortenBatchProcessor     // Processor for batch URL shortening operations
+	APIImportProc       APIShortenImportProcessor    // Processor for streaming bulk imports of links
+	APIUserURLsProc     APIUserURLsProcessor         // Processor for user-specific URL management operations
+	APIUserUTMProc      APIUserUTMTemplatesProcessor // Processor for management of UTM templates of user's links
+	APIUserTargetProc   APIUserTargetingProcessor    // Processor for management of targeting rules of user's short URLs
+	APIUserSplitProc    APIUserSplitProcessor        // Processor for management of A/B split tests of user's short URLs
+	APIUserCollProc     APIUserCollectionsProcessor  // Processor for management of collections of user's links
+	APIInternalProc     APIInternalProcessor         // Processor for internal stats requests
 }
@@ -27,6 +27,7 @@ type ShortenProcessor interface {
 //   - Returns appropriate HTTP status codes:
 //   - 400 Bad Request for empty or invalid input
 //   - 409 Conflict when URL already exists (returns existing short URL)
+//   - 451 Unavailable For Legal Reasons when URL matches the blocklist
 //   - 201 Created for successful shortening
 //   - 500 Internal Server Error for processing failures
 //
@@ -46,8 +47,11 @@ func HandleShorten(p ShortenProcessor, l *zap.Logger) http.HandlerFunc {
 		}
 
 		shortURL, err := p.Process(r.Context(), body)
-		if errors.Is(err, service.ErrEmptyInputURL) {
-			w.WriteHeader(http.StatusBadRequest)
+		if errors.Is(err, service.ErrEmptyInputURL) || isValidationError(err) {
+			writeBadRequest(w, err)
+			return
+		} else if errors.Is(err, service.ErrURLBlocked) {
+			writeBlocked(w)
 			return
 		} else if errors.Is(err, service.ErrURLAlreadyExists) {
 			if err := writeResponse(w, http.StatusConflict, shortURL); err != nil {
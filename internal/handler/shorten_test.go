@@ -59,6 +59,24 @@ func TestShorten(t *testing.T) {
 			wantErr:      true,
 			shortenError: service.ErrEmptyInputURL,
 		},
+		{
+			name:   "POST request returns 400 (Bad Request) when url is not allowed",
+			method: http.MethodPost,
+			want: want{
+				code: http.StatusBadRequest,
+			},
+			wantErr:      true,
+			shortenError: service.NewValidationError(service.ErrInvalidURL, "scheme `javascript` is not allowed"),
+		},
+		{
+			name:   "POST request returns 451 (Unavailable For Legal Reasons) when url is blocked",
+			method: http.MethodPost,
+			want: want{
+				code: http.StatusUnavailableForLegalReasons,
+			},
+			wantErr:      true,
+			shortenError: &service.BlockedURLError{OrigURL: "https://evil.example", Rule: "host evil.example"},
+		},
 		{
 			name:   "POST request returns 409 (Conflict) when URL already exists",
 			method: http.MethodPost,
@@ -7,7 +7,7 @@
 //
 // Key components:
 //   - NewAuth: Authentication middleware that resolves users from JWT tokens
-//   - NewGzip: Compression middleware that handles gzip encoding
+//   - NewGzip: Compression middleware that handles gzip encoding, keeping streamed responses flushable
 //   - NewRequestLogger: Request logging middleware with detailed metrics
 //   - NewTrustedSubnet: Trusted subnet middleware that checks if the client's IP address belongs to a trusted subnet
 //
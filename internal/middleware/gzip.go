@@ -42,6 +42,20 @@ func (c *gzipWriter) WriteHeader(statusCode int) {
 	c.w.WriteHeader(statusCode)
 }
 
+// FlushError writes the compressed data buffered so far to the client, so streamed responses are delivered
+// progressively. It is called by http.ResponseController.
+func (c *gzipWriter) FlushError() error {
+	if err := c.gzw.Flush(); err != nil {
+		return fmt.Errorf("flush gzip writer: %w", err)
+	}
+	return http.NewResponseController(c.w).Flush()
+}
+
+// Unwrap returns the underlying ResponseWriter for http.ResponseController.
+func (c *gzipWriter) Unwrap() http.ResponseWriter {
+	return c.w
+}
+
 // Close closes the gzip writer and flushes any pending data.
 func (c *gzipWriter) Close() error {
 	return c.gzw.Close()
@@ -33,6 +33,11 @@ func (r *loggingResponseWriter) WriteHeader(statusCode int) {
 	r.responseData.httpStatus = statusCode
 }
 
+// Unwrap returns the underlying ResponseWriter, so http.ResponseController can flush streamed responses.
+func (r *loggingResponseWriter) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
 // newLoggingResponseWriter creates a new logging response writer.
 func newLoggingResponseWriter(w http.ResponseWriter, rd *responseData, logger *zap.Logger) *loggingResponseWriter {
 	return &loggingResponseWriter{
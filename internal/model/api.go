@@ -2,10 +2,21 @@ package model
 
 //go:generate easyjson -all ./api.go
 
+import "time"
+
 // ShortenRequest represents the request body for URL shortening operation.
 // Used in `POST /api/shorten` endpoint.
 type ShortenRequest struct {
-	OrigURL string `json:"url"` // Original URL to be shortened
+	OrigURL      string     `json:"url"`                     // Original URL to be shortened
+	Alias        string     `json:"alias,omitempty"`         // Optional custom short identifier
+	IDStyle      string     `json:"id_style,omitempty"`      // Optional style of the generated short identifier ("default" or "words")
+	ExpiresAt    *time.Time `json:"expires_at,omitempty"`    // Optional absolute expiration moment (RFC 3339)
+	TTL          int64      `json:"ttl,omitempty"`           // Optional link lifetime in seconds
+	MaxClicks    int        `json:"max_clicks,omitempty"`    // Optional amount of allowed follows (1 for one-time links)
+	Password     string     `json:"password,omitempty"`      // Optional password required to follow the link
+	Tags         []string   `json:"tags,omitempty"`          // Optional tags labeling the link
+	RedirectType int        `json:"redirect_type,omitempty"` // Optional HTTP status code of the link redirect (301, 302, 307 or 308)
+	Passthrough  bool       `json:"passthrough,omitempty"`   // Optional forwarding of path suffix and query of requests to the original URL
 }
 
 // ShortenResponse represents the response body for URL shortening operations.
@@ -17,8 +28,17 @@ type ShortenResponse struct {
 // BatchShortenRequestItem represents a single item in batch URL shortening request.
 // Contains correlation ID for matching request and response items.
 type BatchShortenRequestItem struct {
-	CorrelationID string `json:"correlation_id"` // Client-provided identifier for request-response correlation
-	OriginalURL   string `json:"original_url"`   // URL to be shortened
+	CorrelationID string     `json:"correlation_id"`          // Client-provided identifier for request-response correlation
+	OriginalURL   string     `json:"original_url"`            // URL to be shortened
+	Alias         string     `json:"alias,omitempty"`         // Optional custom short identifier
+	IDStyle       string     `json:"id_style,omitempty"`      // Optional style of the generated short identifier ("default" or "words")
+	ExpiresAt     *time.Time `json:"expires_at,omitempty"`    // Optional absolute expiration moment (RFC 3339)
+	TTL           int64      `json:"ttl,omitempty"`           // Optional link lifetime in seconds
+	MaxClicks     int        `json:"max_clicks,omitempty"`    // Optional amount of allowed follows (1 for one-time links)
+	Password      string     `json:"password,omitempty"`      // Optional password required to follow the link
+	Tags          []string   `json:"tags,omitempty"`          // Optional tags labeling the link
+	RedirectType  int        `json:"redirect_type,omitempty"` // Optional HTTP status code of the link redirect (301, 302, 307 or 308)
+	Passthrough   bool       `json:"passthrough,omitempty"`   // Optional forwarding of path suffix and query of requests to the original URL
 }
 
 // BatchShortenRequest represents a collection of URLs for batch shortening.
@@ -43,8 +63,12 @@ type BatchShortenResponse []BatchShortenResponseItem
 // UserURLsGetResponseItem represents a single URL record in user URLs response.
 // Contains both short and original URLs for user's shortened URLs.
 type UserURLsGetResponseItem struct {
-	ShortURL string `json:"short_url"`    // Shortened URL identifier
-	OrigURL  string `json:"original_url"` // Original full URL
+	ShortURL     string     `json:"short_url"`               // Shortened URL identifier
+	OrigURL      string     `json:"original_url"`            // Original full URL
+	ExpiresAt    *time.Time `json:"expires_at,omitempty"`    // Expiration moment, if the link expires
+	Tags         []string   `json:"tags,omitempty"`          // Tags labeling the link
+	RedirectType int        `json:"redirect_type,omitempty"` // HTTP status code the link redirects with
+	Passthrough  bool       `json:"passthrough,omitempty"`   // Whether path suffix and query of requests are forwarded to the original URL
 }
 
 // UserURLsGetResponse represents the collection of user's shortened URLs.
@@ -60,9 +84,190 @@ type UserURLsGetResponse []UserURLsGetResponseItem
 //easyjson:json
 type UserURLsDelRequest []string
 
+// UserURLUpdateRequest represents the request body for changing the destination or the tags of user's short URL.
+// Used in `PATCH /api/user/urls/{id}` endpoint.
+type UserURLUpdateRequest struct {
+	OrigURL string    `json:"original_url,omitempty"` // New original URL for the short URL; kept when empty and tags are set
+	Tags    *[]string `json:"tags,omitempty"`         // New tags replacing the current ones; kept when absent, removed when empty
+}
+
+// UserURLHistoryResponseItem represents a single version in short URL history response.
+type UserURLHistoryResponseItem struct {
+	Version   int       `json:"version"`            // Ordinal number of the version, starting from 1
+	Action    string    `json:"action"`             // Type of change: create, update, delete, restore or expire
+	PrevURL   string    `json:"prev_url,omitempty"` // Original URL before the change, if it was changed
+	OrigURL   string    `json:"original_url"`       // Original URL after the change
+	UserID    string    `json:"user_id,omitempty"`  // User who made the change; empty for system changes
+	ChangedAt time.Time `json:"changed_at"`         // Moment of the change
+}
+
+// UserURLHistoryResponse represents the version history of user's short URL, oldest first.
+// Returned by `GET /api/user/urls/{id}/history` endpoint.
+//
+//easyjson:json
+type UserURLHistoryResponse []UserURLHistoryResponseItem
+
+// UserURLRestoreVersionRequest represents the request body for restoring a version of user's short URL.
+// Used in `POST /api/user/urls/{id}/history/restore` endpoint.
+type UserURLRestoreVersionRequest struct {
+	Version int `json:"version"` // Number of the version to restore
+}
+
+// UserURLsRestoreRequest represents the request body for batch restoration of soft-deleted URLs.
+// Contains a list of short URL identifiers to be restored.
+// Used in `POST /api/user/urls/restore` endpoint.
+//
+//easyjson:json
+type UserURLsRestoreRequest []string
+
+// UserURLsRestoreResponseItem represents the outcome of restoring a single soft-deleted URL.
+type UserURLsRestoreResponseItem struct {
+	ShortURL string `json:"short_url"`              // Shortened URL
+	OrigURL  string `json:"original_url,omitempty"` // Original full URL, if the URL is found
+	Status   string `json:"status"`                 // Outcome: restored, not_found, conflict or expired
+}
+
+// UserURLsRestoreResponse represents outcomes of batch restoration of soft-deleted URLs
+// in the order of the request.
+// Returned by `POST /api/user/urls/restore` endpoint.
+//
+//easyjson:json
+type UserURLsRestoreResponse []UserURLsRestoreResponseItem
+
+// InspectResponse represents the details of a short URL shown without following it.
+// Returned by `GET /api/expand/{id}` endpoint.
+//
+//easyjson:json
+type InspectResponse struct {
+	ShortURL    string     `json:"short_url"`              // Full short URL
+	OrigURL     string     `json:"original_url,omitempty"` // Original URL; omitted for password-protected links
+	CreatedAt   *time.Time `json:"created_at,omitempty"`   // Creation moment, if known
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`   // Expiration moment, if the link expires
+	Protected   bool       `json:"protected,omitempty"`    // Whether the link can only be followed with a password
+	Safety      string     `json:"safety"`                 // Safety status of the destination: safe or blocked
+	Passthrough bool       `json:"passthrough,omitempty"`  // Whether the link forwards path suffixes and queries of requests
+}
+
+// UserTagsResponseItem represents a single tag in user tags response.
+type UserTagsResponseItem struct {
+	Tag   string `json:"tag"`   // Tag name
+	Count int    `json:"count"` // Amount of user's short URLs with the tag
+}
+
+// UserTagsResponse represents the tags of user's short URLs with their counts, sorted by tag.
+// Returned by `GET /api/user/tags` endpoint.
+//
+//easyjson:json
+type UserTagsResponse []UserTagsResponseItem
+
+// UserUTMTemplate represents a UTM template of user's links in requests and responses.
+// Exactly one of ShortID and Tag selects the links the template applies to.
+// Used in `GET`, `PUT` and `DELETE /api/user/utm-templates` endpoints.
+type UserUTMTemplate struct {
+	ShortID string            `json:"short_id,omitempty"` // Short identifier of the link the template is bound to
+	Tag     string            `json:"tag,omitempty"`      // Tag the template is bound to
+	Params  map[string]string `json:"params,omitempty"`   // Query parameter values by name, e.g. utm_source; ignored on deletion
+}
+
+// UserUTMTemplatesResponse represents UTM templates of the user:
+// link templates sorted by short ID, followed by tag templates sorted by tag.
+// Returned by `GET /api/user/utm-templates` endpoint.
+//
+//easyjson:json
+type UserUTMTemplatesResponse []UserUTMTemplate
+
+// UserTargetingRule represents a targeting rule of a short URL in requests and responses.
+// Used in `GET` and `PUT /api/user/urls/{id}/rules` endpoints.
+type UserTargetingRule struct {
+	Browser     string `json:"browser,omitempty"`      // User-Agent family of the client: chrome, edge, firefox, opera, safari, samsung or bot
+	OS          string `json:"os,omitempty"`           // Operating system of the client: android, ios, windows, macos, linux or chromeos
+	Language    string `json:"language,omitempty"`     // Most preferred language of Accept-Language, e.g. de or pt-br
+	Header      string `json:"header,omitempty"`       // Name of a request header that must be present
+	HeaderValue string `json:"header_value,omitempty"` // Value the header must have, compared case-insensitively; empty means any value
+	URL         string `json:"url"`                    // Destination for matching clients
+}
+
+// UserTargetingRules represents the ordered targeting rules of a short URL; the first matching rule wins.
+// Accepted and returned by `GET` and `PUT /api/user/urls/{id}/rules` endpoints.
+//
+//easyjson:json
+type UserTargetingRules []UserTargetingRule
+
+// UserSplitVariant represents a variant of the A/B split test of a short URL in requests and responses.
+type UserSplitVariant struct {
+	Name   string `json:"name,omitempty"` // Name of the variant recorded in follow audit events; defaults to a, b, c...
+	URL    string `json:"url"`            // Destination of clients assigned to the variant
+	Weight int    `json:"weight"`         // Relative share of clients assigned to the variant, from 1 to 1000
+}
+
+// UserSplit represents the A/B split test of a short URL.
+// Accepted and returned by `GET` and `PUT /api/user/urls/{id}/split` endpoints.
+//
+//easyjson:json
+type UserSplit struct {
+	Sticky   bool               `json:"sticky,omitempty"` // Whether clients keep the variant assigned on their first follow
+	Variants []UserSplitVariant `json:"variants"`         // Variants among which clients are distributed by weight
+}
+
+// UserCollectionRequest represents the request body for creating or renaming a collection.
+// Accepted by `POST /api/user/collections` and `PATCH /api/user/collections/{id}` endpoints.
+//
+//easyjson:json
+type UserCollectionRequest struct {
+	Name string `json:"name"` // Name of the collection, unique among collections of the user
+}
+
+// UserCollection represents a collection of user's links in responses.
+// Returned by `POST /api/user/collections` and `PATCH /api/user/collections/{id}` endpoints.
+//
+//easyjson:json
+type UserCollection struct {
+	ID        string    `json:"id"`         // Identifier of the collection
+	Name      string    `json:"name"`       // Name of the collection
+	CreatedAt time.Time `json:"created_at"` // Moment of creation
+	Count     int       `json:"count"`      // Amount of live links in the collection
+}
+
+// UserCollectionsResponse represents the collections of the user, sorted by name.
+// Returned by `GET /api/user/collections` endpoint.
+//
+//easyjson:json
+type UserCollectionsResponse []UserCollection
+
+// UserCollectionMoveRequest represents the request body for moving links between collections.
+// Accepted by `POST /api/user/collections/move` endpoint.
+//
+//easyjson:json
+type UserCollectionMoveRequest struct {
+	CollectionID string   `json:"collection_id"` // Identifier of the target collection; empty to take the links out of any collection
+	ShortURLs    []string `json:"short_urls"`    // Short identifiers of the links to move
+}
+
+// UserCollectionDeleteResponse represents the outcome of bulk deletion of the links in a collection.
+// Returned by `DELETE /api/user/collections/{id}/urls` endpoint.
+//
+//easyjson:json
+type UserCollectionDeleteResponse struct {
+	Deleted int `json:"deleted"` // Amount of deleted links
+}
+
+// ImportResultItem represents the outcome of importing a single row of a bulk import.
+// Streamed by `POST /api/shorten/import` endpoint, one item per line.
+//
+//easyjson:json
+type ImportResultItem struct {
+	Row           int    `json:"row,omitempty"`            // Number of the row among data rows of the input, starting from 1
+	CorrelationID string `json:"correlation_id,omitempty"` // Client-provided identifier of the row, if any
+	OrigURL       string `json:"original_url,omitempty"`   // Canonical original URL of the row
+	ShortURL      string `json:"short_url,omitempty"`      // Short URL of the original URL, unless the row failed
+	Status        string `json:"status"`                   // Outcome: created, exists or failed
+	Error         string `json:"error,omitempty"`          // Reason of the failure
+}
+
 // StatsResponse represents the response for statistics operations.
 // Returned by `GET /api/internal/stats` endpoint.
 type StatsResponse struct {
-	URLsCount  int `json:"urls"`  // Total amount of shortened URLs
-	UsersCount int `json:"users"` // Total amount of users
+	URLsCount   int `json:"urls"`   // Total amount of shortened URLs
+	UsersCount  int `json:"users"`  // Total amount of users
+	PurgedCount int `json:"purged"` // Total amount of deleted URLs purged after the retention period
 }
@@ -7,6 +7,7 @@ import (
 	easyjson "github.com/mailru/easyjson"
 	jlexer "github.com/mailru/easyjson/jlexer"
 	jwriter "github.com/mailru/easyjson/jwriter"
+	time "time"
 )
 
 // suppress unused package warning
@@ -17,7 +18,207 @@ var (
 	_ easyjson.Marshaler
 )
 
-func easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel(in *jlexer.Lexer, out *UserURLsGetResponseItem) {
+func easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel(in *jlexer.Lexer, out *UserUTMTemplatesResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(UserUTMTemplatesResponse, 0, 1)
+			} else {
+				*out = UserUTMTemplatesResponse{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v1 UserUTMTemplate
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				(v1).UnmarshalEasyJSON(in)
+			}
+			*out = append(*out, v1)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel(out *jwriter.Writer, in UserUTMTemplatesResponse) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v2, v3 := range in {
+			if v2 > 0 {
+				out.RawByte(',')
+			}
+			(v3).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v UserUTMTemplatesResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v UserUTMTemplatesResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *UserUTMTemplatesResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *UserUTMTemplatesResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel(l, v)
+}
+func easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel1(in *jlexer.Lexer, out *UserUTMTemplate) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "short_id":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.ShortID = string(in.String())
+			}
+		case "tag":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Tag = string(in.String())
+			}
+		case "params":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				if !in.IsDelim('}') {
+					out.Params = make(map[string]string)
+				} else {
+					out.Params = nil
+				}
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v4 string
+					if in.IsNull() {
+						in.Skip()
+					} else {
+						v4 = string(in.String())
+					}
+					(out.Params)[key] = v4
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel1(out *jwriter.Writer, in UserUTMTemplate) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.ShortID != "" {
+		const prefix string = ",\"short_id\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.ShortID))
+	}
+	if in.Tag != "" {
+		const prefix string = ",\"tag\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Tag))
+	}
+	if len(in.Params) != 0 {
+		const prefix string = ",\"params\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('{')
+			v5First := true
+			for v5Name, v5Value := range in.Params {
+				if v5First {
+					v5First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v5Name))
+				out.RawByte(':')
+				out.String(string(v5Value))
+			}
+			out.RawByte('}')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v UserUTMTemplate) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v UserUTMTemplate) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *UserUTMTemplate) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *UserUTMTemplate) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel1(l, v)
+}
+func easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel2(in *jlexer.Lexer, out *UserURLsRestoreResponseItem) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -43,6 +244,12 @@ func easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel(in *jlexe
 			} else {
 				out.OrigURL = string(in.String())
 			}
+		case "status":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Status = string(in.String())
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -53,7 +260,7 @@ func easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel(in *jlexe
 		in.Consumed()
 	}
 }
-func easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel(out *jwriter.Writer, in UserURLsGetResponseItem) {
+func easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel2(out *jwriter.Writer, in UserURLsRestoreResponseItem) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -62,38 +269,43 @@ func easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel(out *jwri
 		out.RawString(prefix[1:])
 		out.String(string(in.ShortURL))
 	}
-	{
+	if in.OrigURL != "" {
 		const prefix string = ",\"original_url\":"
 		out.RawString(prefix)
 		out.String(string(in.OrigURL))
 	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.String(string(in.Status))
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v UserURLsGetResponseItem) MarshalJSON() ([]byte, error) {
+func (v UserURLsRestoreResponseItem) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel(&w, v)
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel2(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v UserURLsGetResponseItem) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel(w, v)
+func (v UserURLsRestoreResponseItem) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel2(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *UserURLsGetResponseItem) UnmarshalJSON(data []byte) error {
+func (v *UserURLsRestoreResponseItem) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel(&r, v)
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel2(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *UserURLsGetResponseItem) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel(l, v)
+func (v *UserURLsRestoreResponseItem) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel2(l, v)
 }
-func easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel1(in *jlexer.Lexer, out *UserURLsGetResponse) {
+func easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel3(in *jlexer.Lexer, out *UserURLsRestoreResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		in.Skip()
@@ -102,21 +314,21 @@ func easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel1(in *jlex
 		in.Delim('[')
 		if *out == nil {
 			if !in.IsDelim(']') {
-				*out = make(UserURLsGetResponse, 0, 2)
+				*out = make(UserURLsRestoreResponse, 0, 1)
 			} else {
-				*out = UserURLsGetResponse{}
+				*out = UserURLsRestoreResponse{}
 			}
 		} else {
 			*out = (*out)[:0]
 		}
 		for !in.IsDelim(']') {
-			var v1 UserURLsGetResponseItem
+			var v6 UserURLsRestoreResponseItem
 			if in.IsNull() {
 				in.Skip()
 			} else {
-				(v1).UnmarshalEasyJSON(in)
+				(v6).UnmarshalEasyJSON(in)
 			}
-			*out = append(*out, v1)
+			*out = append(*out, v6)
 			in.WantComma()
 		}
 		in.Delim(']')
@@ -125,45 +337,45 @@ func easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel1(in *jlex
 		in.Consumed()
 	}
 }
-func easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel1(out *jwriter.Writer, in UserURLsGetResponse) {
+func easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel3(out *jwriter.Writer, in UserURLsRestoreResponse) {
 	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
 		out.RawString("null")
 	} else {
 		out.RawByte('[')
-		for v2, v3 := range in {
-			if v2 > 0 {
+		for v7, v8 := range in {
+			if v7 > 0 {
 				out.RawByte(',')
 			}
-			(v3).MarshalEasyJSON(out)
+			(v8).MarshalEasyJSON(out)
 		}
 		out.RawByte(']')
 	}
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v UserURLsGetResponse) MarshalJSON() ([]byte, error) {
+func (v UserURLsRestoreResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel1(&w, v)
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel3(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v UserURLsGetResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel1(w, v)
+func (v UserURLsRestoreResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel3(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *UserURLsGetResponse) UnmarshalJSON(data []byte) error {
+func (v *UserURLsRestoreResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel1(&r, v)
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel3(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *UserURLsGetResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel1(l, v)
+func (v *UserURLsRestoreResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel3(l, v)
 }
-func easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel2(in *jlexer.Lexer, out *UserURLsDelRequest) {
+func easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel4(in *jlexer.Lexer, out *UserURLsRestoreRequest) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		in.Skip()
@@ -172,21 +384,21 @@ func easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel2(in *jlex
 		in.Delim('[')
 		if *out == nil {
 			if !in.IsDelim(']') {
-				*out = make(UserURLsDelRequest, 0, 4)
+				*out = make(UserURLsRestoreRequest, 0, 4)
 			} else {
-				*out = UserURLsDelRequest{}
+				*out = UserURLsRestoreRequest{}
 			}
 		} else {
 			*out = (*out)[:0]
 		}
 		for !in.IsDelim(']') {
-			var v4 string
+			var v9 string
 			if in.IsNull() {
 				in.Skip()
 			} else {
-				v4 = string(in.String())
+				v9 = string(in.String())
 			}
-			*out = append(*out, v4)
+			*out = append(*out, v9)
 			in.WantComma()
 		}
 		in.Delim(']')
@@ -195,45 +407,1853 @@ func easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel2(in *jlex
 		in.Consumed()
 	}
 }
-func easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel2(out *jwriter.Writer, in UserURLsDelRequest) {
+func easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel4(out *jwriter.Writer, in UserURLsRestoreRequest) {
 	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
 		out.RawString("null")
 	} else {
 		out.RawByte('[')
-		for v5, v6 := range in {
-			if v5 > 0 {
+		for v10, v11 := range in {
+			if v10 > 0 {
 				out.RawByte(',')
 			}
-			out.String(string(v6))
+			out.String(string(v11))
 		}
 		out.RawByte(']')
 	}
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v UserURLsDelRequest) MarshalJSON() ([]byte, error) {
+func (v UserURLsRestoreRequest) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel2(&w, v)
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel4(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v UserURLsDelRequest) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel2(w, v)
+func (v UserURLsRestoreRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel4(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *UserURLsDelRequest) UnmarshalJSON(data []byte) error {
+func (v *UserURLsRestoreRequest) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel2(&r, v)
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel4(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *UserURLsDelRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel2(l, v)
+func (v *UserURLsRestoreRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel4(l, v)
+}
+func easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel5(in *jlexer.Lexer, out *UserURLsGetResponseItem) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "short_url":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.ShortURL = string(in.String())
+			}
+		case "original_url":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.OrigURL = string(in.String())
+			}
+		case "expires_at":
+			if in.IsNull() {
+				in.Skip()
+				out.ExpiresAt = nil
+			} else {
+				if out.ExpiresAt == nil {
+					out.ExpiresAt = new(time.Time)
+				}
+				if in.IsNull() {
+					in.Skip()
+				} else {
+					if data := in.Raw(); in.Ok() {
+						in.AddError((*out.ExpiresAt).UnmarshalJSON(data))
+					}
+				}
+			}
+		case "tags":
+			if in.IsNull() {
+				in.Skip()
+				out.Tags = nil
+			} else {
+				in.Delim('[')
+				if out.Tags == nil {
+					if !in.IsDelim(']') {
+						out.Tags = make([]string, 0, 4)
+					} else {
+						out.Tags = []string{}
+					}
+				} else {
+					out.Tags = (out.Tags)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v12 string
+					if in.IsNull() {
+						in.Skip()
+					} else {
+						v12 = string(in.String())
+					}
+					out.Tags = append(out.Tags, v12)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "redirect_type":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.RedirectType = int(in.Int())
+			}
+		case "passthrough":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Passthrough = bool(in.Bool())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel5(out *jwriter.Writer, in UserURLsGetResponseItem) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"short_url\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.ShortURL))
+	}
+	{
+		const prefix string = ",\"original_url\":"
+		out.RawString(prefix)
+		out.String(string(in.OrigURL))
+	}
+	if in.ExpiresAt != nil {
+		const prefix string = ",\"expires_at\":"
+		out.RawString(prefix)
+		out.Raw((*in.ExpiresAt).MarshalJSON())
+	}
+	if len(in.Tags) != 0 {
+		const prefix string = ",\"tags\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v13, v14 := range in.Tags {
+				if v13 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v14))
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.RedirectType != 0 {
+		const prefix string = ",\"redirect_type\":"
+		out.RawString(prefix)
+		out.Int(int(in.RedirectType))
+	}
+	if in.Passthrough {
+		const prefix string = ",\"passthrough\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Passthrough))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v UserURLsGetResponseItem) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel5(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v UserURLsGetResponseItem) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel5(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *UserURLsGetResponseItem) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel5(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *UserURLsGetResponseItem) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel5(l, v)
+}
+func easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel6(in *jlexer.Lexer, out *UserURLsGetResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(UserURLsGetResponse, 0, 0)
+			} else {
+				*out = UserURLsGetResponse{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v15 UserURLsGetResponseItem
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				(v15).UnmarshalEasyJSON(in)
+			}
+			*out = append(*out, v15)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel6(out *jwriter.Writer, in UserURLsGetResponse) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v16, v17 := range in {
+			if v16 > 0 {
+				out.RawByte(',')
+			}
+			(v17).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v UserURLsGetResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel6(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v UserURLsGetResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel6(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *UserURLsGetResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel6(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *UserURLsGetResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel6(l, v)
+}
+func easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel7(in *jlexer.Lexer, out *UserURLsDelRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(UserURLsDelRequest, 0, 4)
+			} else {
+				*out = UserURLsDelRequest{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v18 string
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				v18 = string(in.String())
+			}
+			*out = append(*out, v18)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel7(out *jwriter.Writer, in UserURLsDelRequest) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v19, v20 := range in {
+			if v19 > 0 {
+				out.RawByte(',')
+			}
+			out.String(string(v20))
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v UserURLsDelRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel7(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v UserURLsDelRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel7(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *UserURLsDelRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel7(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *UserURLsDelRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel7(l, v)
+}
+func easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel8(in *jlexer.Lexer, out *UserURLUpdateRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "original_url":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.OrigURL = string(in.String())
+			}
+		case "tags":
+			if in.IsNull() {
+				in.Skip()
+				out.Tags = nil
+			} else {
+				if out.Tags == nil {
+					out.Tags = new([]string)
+				}
+				if in.IsNull() {
+					in.Skip()
+					*out.Tags = nil
+				} else {
+					in.Delim('[')
+					if *out.Tags == nil {
+						if !in.IsDelim(']') {
+							*out.Tags = make([]string, 0, 4)
+						} else {
+							*out.Tags = []string{}
+						}
+					} else {
+						*out.Tags = (*out.Tags)[:0]
+					}
+					for !in.IsDelim(']') {
+						var v21 string
+						if in.IsNull() {
+							in.Skip()
+						} else {
+							v21 = string(in.String())
+						}
+						*out.Tags = append(*out.Tags, v21)
+						in.WantComma()
+					}
+					in.Delim(']')
+				}
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel8(out *jwriter.Writer, in UserURLUpdateRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.OrigURL != "" {
+		const prefix string = ",\"original_url\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.OrigURL))
+	}
+	if in.Tags != nil {
+		const prefix string = ",\"tags\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if *in.Tags == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v22, v23 := range *in.Tags {
+				if v22 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v23))
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v UserURLUpdateRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel8(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v UserURLUpdateRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel8(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *UserURLUpdateRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel8(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *UserURLUpdateRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel8(l, v)
+}
+func easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel9(in *jlexer.Lexer, out *UserURLRestoreVersionRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "version":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Version = int(in.Int())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel9(out *jwriter.Writer, in UserURLRestoreVersionRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"version\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.Version))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v UserURLRestoreVersionRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel9(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v UserURLRestoreVersionRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel9(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *UserURLRestoreVersionRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel9(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *UserURLRestoreVersionRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel9(l, v)
+}
+func easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel10(in *jlexer.Lexer, out *UserURLHistoryResponseItem) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "version":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Version = int(in.Int())
+			}
+		case "action":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Action = string(in.String())
+			}
+		case "prev_url":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.PrevURL = string(in.String())
+			}
+		case "original_url":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.OrigURL = string(in.String())
+			}
+		case "user_id":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.UserID = string(in.String())
+			}
+		case "changed_at":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.Raw(); in.Ok() {
+					in.AddError((out.ChangedAt).UnmarshalJSON(data))
+				}
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel10(out *jwriter.Writer, in UserURLHistoryResponseItem) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"version\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.Version))
+	}
+	{
+		const prefix string = ",\"action\":"
+		out.RawString(prefix)
+		out.String(string(in.Action))
+	}
+	if in.PrevURL != "" {
+		const prefix string = ",\"prev_url\":"
+		out.RawString(prefix)
+		out.String(string(in.PrevURL))
+	}
+	{
+		const prefix string = ",\"original_url\":"
+		out.RawString(prefix)
+		out.String(string(in.OrigURL))
+	}
+	if in.UserID != "" {
+		const prefix string = ",\"user_id\":"
+		out.RawString(prefix)
+		out.String(string(in.UserID))
+	}
+	{
+		const prefix string = ",\"changed_at\":"
+		out.RawString(prefix)
+		out.Raw((in.ChangedAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v UserURLHistoryResponseItem) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel10(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v UserURLHistoryResponseItem) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel10(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *UserURLHistoryResponseItem) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel10(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *UserURLHistoryResponseItem) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel10(l, v)
+}
+func easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel11(in *jlexer.Lexer, out *UserURLHistoryResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(UserURLHistoryResponse, 0, 0)
+			} else {
+				*out = UserURLHistoryResponse{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v24 UserURLHistoryResponseItem
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				(v24).UnmarshalEasyJSON(in)
+			}
+			*out = append(*out, v24)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel11(out *jwriter.Writer, in UserURLHistoryResponse) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v25, v26 := range in {
+			if v25 > 0 {
+				out.RawByte(',')
+			}
+			(v26).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v UserURLHistoryResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel11(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v UserURLHistoryResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel11(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *UserURLHistoryResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel11(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *UserURLHistoryResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel11(l, v)
+}
+func easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel12(in *jlexer.Lexer, out *UserTargetingRules) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(UserTargetingRules, 0, 0)
+			} else {
+				*out = UserTargetingRules{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v27 UserTargetingRule
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				(v27).UnmarshalEasyJSON(in)
+			}
+			*out = append(*out, v27)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel12(out *jwriter.Writer, in UserTargetingRules) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v28, v29 := range in {
+			if v28 > 0 {
+				out.RawByte(',')
+			}
+			(v29).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v UserTargetingRules) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel12(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v UserTargetingRules) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel12(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *UserTargetingRules) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel12(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *UserTargetingRules) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel12(l, v)
+}
+func easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel13(in *jlexer.Lexer, out *UserTargetingRule) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "browser":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Browser = string(in.String())
+			}
+		case "os":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.OS = string(in.String())
+			}
+		case "language":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Language = string(in.String())
+			}
+		case "header":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Header = string(in.String())
+			}
+		case "header_value":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.HeaderValue = string(in.String())
+			}
+		case "url":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.URL = string(in.String())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel13(out *jwriter.Writer, in UserTargetingRule) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Browser != "" {
+		const prefix string = ",\"browser\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.Browser))
+	}
+	if in.OS != "" {
+		const prefix string = ",\"os\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.OS))
+	}
+	if in.Language != "" {
+		const prefix string = ",\"language\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Language))
+	}
+	if in.Header != "" {
+		const prefix string = ",\"header\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Header))
+	}
+	if in.HeaderValue != "" {
+		const prefix string = ",\"header_value\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.HeaderValue))
+	}
+	{
+		const prefix string = ",\"url\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.URL))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v UserTargetingRule) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel13(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v UserTargetingRule) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel13(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *UserTargetingRule) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel13(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *UserTargetingRule) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel13(l, v)
+}
+func easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel14(in *jlexer.Lexer, out *UserTagsResponseItem) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "tag":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Tag = string(in.String())
+			}
+		case "count":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Count = int(in.Int())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel14(out *jwriter.Writer, in UserTagsResponseItem) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"tag\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Tag))
+	}
+	{
+		const prefix string = ",\"count\":"
+		out.RawString(prefix)
+		out.Int(int(in.Count))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v UserTagsResponseItem) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel14(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v UserTagsResponseItem) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel14(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *UserTagsResponseItem) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel14(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *UserTagsResponseItem) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel14(l, v)
+}
+func easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel15(in *jlexer.Lexer, out *UserTagsResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(UserTagsResponse, 0, 2)
+			} else {
+				*out = UserTagsResponse{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v30 UserTagsResponseItem
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				(v30).UnmarshalEasyJSON(in)
+			}
+			*out = append(*out, v30)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel15(out *jwriter.Writer, in UserTagsResponse) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v31, v32 := range in {
+			if v31 > 0 {
+				out.RawByte(',')
+			}
+			(v32).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v UserTagsResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel15(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v UserTagsResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel15(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *UserTagsResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel15(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *UserTagsResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel15(l, v)
+}
+func easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel16(in *jlexer.Lexer, out *UserSplitVariant) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "name":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Name = string(in.String())
+			}
+		case "url":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.URL = string(in.String())
+			}
+		case "weight":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Weight = int(in.Int())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel16(out *jwriter.Writer, in UserSplitVariant) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Name != "" {
+		const prefix string = ",\"name\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"url\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.URL))
+	}
+	{
+		const prefix string = ",\"weight\":"
+		out.RawString(prefix)
+		out.Int(int(in.Weight))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v UserSplitVariant) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel16(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v UserSplitVariant) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel16(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *UserSplitVariant) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel16(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *UserSplitVariant) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel16(l, v)
+}
+func easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel17(in *jlexer.Lexer, out *UserSplit) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "sticky":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Sticky = bool(in.Bool())
+			}
+		case "variants":
+			if in.IsNull() {
+				in.Skip()
+				out.Variants = nil
+			} else {
+				in.Delim('[')
+				if out.Variants == nil {
+					if !in.IsDelim(']') {
+						out.Variants = make([]UserSplitVariant, 0, 1)
+					} else {
+						out.Variants = []UserSplitVariant{}
+					}
+				} else {
+					out.Variants = (out.Variants)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v33 UserSplitVariant
+					if in.IsNull() {
+						in.Skip()
+					} else {
+						(v33).UnmarshalEasyJSON(in)
+					}
+					out.Variants = append(out.Variants, v33)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel17(out *jwriter.Writer, in UserSplit) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Sticky {
+		const prefix string = ",\"sticky\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.Bool(bool(in.Sticky))
+	}
+	{
+		const prefix string = ",\"variants\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if in.Variants == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v34, v35 := range in.Variants {
+				if v34 > 0 {
+					out.RawByte(',')
+				}
+				(v35).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v UserSplit) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel17(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v UserSplit) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel17(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *UserSplit) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel17(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *UserSplit) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel17(l, v)
+}
+func easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel18(in *jlexer.Lexer, out *UserCollectionsResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(UserCollectionsResponse, 0, 1)
+			} else {
+				*out = UserCollectionsResponse{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v36 UserCollection
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				(v36).UnmarshalEasyJSON(in)
+			}
+			*out = append(*out, v36)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel18(out *jwriter.Writer, in UserCollectionsResponse) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v37, v38 := range in {
+			if v37 > 0 {
+				out.RawByte(',')
+			}
+			(v38).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v UserCollectionsResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel18(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v UserCollectionsResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel18(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *UserCollectionsResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel18(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *UserCollectionsResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel18(l, v)
+}
+func easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel19(in *jlexer.Lexer, out *UserCollectionRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "name":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Name = string(in.String())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel19(out *jwriter.Writer, in UserCollectionRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Name))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v UserCollectionRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel19(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v UserCollectionRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel19(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *UserCollectionRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel19(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *UserCollectionRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel19(l, v)
+}
+func easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel20(in *jlexer.Lexer, out *UserCollectionMoveRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "collection_id":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.CollectionID = string(in.String())
+			}
+		case "short_urls":
+			if in.IsNull() {
+				in.Skip()
+				out.ShortURLs = nil
+			} else {
+				in.Delim('[')
+				if out.ShortURLs == nil {
+					if !in.IsDelim(']') {
+						out.ShortURLs = make([]string, 0, 4)
+					} else {
+						out.ShortURLs = []string{}
+					}
+				} else {
+					out.ShortURLs = (out.ShortURLs)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v39 string
+					if in.IsNull() {
+						in.Skip()
+					} else {
+						v39 = string(in.String())
+					}
+					out.ShortURLs = append(out.ShortURLs, v39)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel20(out *jwriter.Writer, in UserCollectionMoveRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"collection_id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.CollectionID))
+	}
+	{
+		const prefix string = ",\"short_urls\":"
+		out.RawString(prefix)
+		if in.ShortURLs == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v40, v41 := range in.ShortURLs {
+				if v40 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v41))
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v UserCollectionMoveRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel20(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v UserCollectionMoveRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel20(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *UserCollectionMoveRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel20(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *UserCollectionMoveRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel20(l, v)
+}
+func easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel21(in *jlexer.Lexer, out *UserCollectionDeleteResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "deleted":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Deleted = int(in.Int())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel21(out *jwriter.Writer, in UserCollectionDeleteResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"deleted\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.Deleted))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v UserCollectionDeleteResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel21(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v UserCollectionDeleteResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel21(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *UserCollectionDeleteResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel21(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *UserCollectionDeleteResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel21(l, v)
+}
+func easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel22(in *jlexer.Lexer, out *UserCollection) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "id":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.ID = string(in.String())
+			}
+		case "name":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Name = string(in.String())
+			}
+		case "created_at":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				if data := in.Raw(); in.Ok() {
+					in.AddError((out.CreatedAt).UnmarshalJSON(data))
+				}
+			}
+		case "count":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Count = int(in.Int())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel22(out *jwriter.Writer, in UserCollection) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.ID))
+	}
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix)
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.Raw((in.CreatedAt).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"count\":"
+		out.RawString(prefix)
+		out.Int(int(in.Count))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v UserCollection) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel22(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v UserCollection) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel22(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *UserCollection) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel22(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *UserCollection) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel22(l, v)
+}
+func easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel23(in *jlexer.Lexer, out *StatsResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "urls":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.URLsCount = int(in.Int())
+			}
+		case "users":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.UsersCount = int(in.Int())
+			}
+		case "purged":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.PurgedCount = int(in.Int())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel23(out *jwriter.Writer, in StatsResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"urls\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.URLsCount))
+	}
+	{
+		const prefix string = ",\"users\":"
+		out.RawString(prefix)
+		out.Int(int(in.UsersCount))
+	}
+	{
+		const prefix string = ",\"purged\":"
+		out.RawString(prefix)
+		out.Int(int(in.PurgedCount))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v StatsResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel23(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v StatsResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel23(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *StatsResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel23(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *StatsResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel23(l, v)
+}
+func easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel24(in *jlexer.Lexer, out *ShortenResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		switch key {
+		case "result":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.ShortURL = string(in.String())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel24(out *jwriter.Writer, in ShortenResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"result\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.ShortURL))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ShortenResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel24(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ShortenResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel24(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ShortenResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel24(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ShortenResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel24(l, v)
 }
-func easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel3(in *jlexer.Lexer, out *StatsResponse) {
+func easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel25(in *jlexer.Lexer, out *ShortenRequest) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -247,17 +2267,96 @@ func easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel3(in *jlex
 		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		switch key {
-		case "urls":
+		case "url":
 			if in.IsNull() {
 				in.Skip()
 			} else {
-				out.URLsCount = int(in.Int())
+				out.OrigURL = string(in.String())
 			}
-		case "users":
+		case "alias":
 			if in.IsNull() {
 				in.Skip()
 			} else {
-				out.UsersCount = int(in.Int())
+				out.Alias = string(in.String())
+			}
+		case "id_style":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.IDStyle = string(in.String())
+			}
+		case "expires_at":
+			if in.IsNull() {
+				in.Skip()
+				out.ExpiresAt = nil
+			} else {
+				if out.ExpiresAt == nil {
+					out.ExpiresAt = new(time.Time)
+				}
+				if in.IsNull() {
+					in.Skip()
+				} else {
+					if data := in.Raw(); in.Ok() {
+						in.AddError((*out.ExpiresAt).UnmarshalJSON(data))
+					}
+				}
+			}
+		case "ttl":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.TTL = int64(in.Int64())
+			}
+		case "max_clicks":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.MaxClicks = int(in.Int())
+			}
+		case "password":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Password = string(in.String())
+			}
+		case "tags":
+			if in.IsNull() {
+				in.Skip()
+				out.Tags = nil
+			} else {
+				in.Delim('[')
+				if out.Tags == nil {
+					if !in.IsDelim(']') {
+						out.Tags = make([]string, 0, 4)
+					} else {
+						out.Tags = []string{}
+					}
+				} else {
+					out.Tags = (out.Tags)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v42 string
+					if in.IsNull() {
+						in.Skip()
+					} else {
+						v42 = string(in.String())
+					}
+					out.Tags = append(out.Tags, v42)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "redirect_type":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.RedirectType = int(in.Int())
+			}
+		case "passthrough":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Passthrough = bool(in.Bool())
 			}
 		default:
 			in.SkipRecursive()
@@ -269,47 +2368,96 @@ func easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel3(in *jlex
 		in.Consumed()
 	}
 }
-func easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel3(out *jwriter.Writer, in StatsResponse) {
+func easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel25(out *jwriter.Writer, in ShortenRequest) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"urls\":"
+		const prefix string = ",\"url\":"
 		out.RawString(prefix[1:])
-		out.Int(int(in.URLsCount))
+		out.String(string(in.OrigURL))
 	}
-	{
-		const prefix string = ",\"users\":"
+	if in.Alias != "" {
+		const prefix string = ",\"alias\":"
 		out.RawString(prefix)
-		out.Int(int(in.UsersCount))
+		out.String(string(in.Alias))
+	}
+	if in.IDStyle != "" {
+		const prefix string = ",\"id_style\":"
+		out.RawString(prefix)
+		out.String(string(in.IDStyle))
+	}
+	if in.ExpiresAt != nil {
+		const prefix string = ",\"expires_at\":"
+		out.RawString(prefix)
+		out.Raw((*in.ExpiresAt).MarshalJSON())
+	}
+	if in.TTL != 0 {
+		const prefix string = ",\"ttl\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.TTL))
+	}
+	if in.MaxClicks != 0 {
+		const prefix string = ",\"max_clicks\":"
+		out.RawString(prefix)
+		out.Int(int(in.MaxClicks))
+	}
+	if in.Password != "" {
+		const prefix string = ",\"password\":"
+		out.RawString(prefix)
+		out.String(string(in.Password))
+	}
+	if len(in.Tags) != 0 {
+		const prefix string = ",\"tags\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v43, v44 := range in.Tags {
+				if v43 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v44))
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.RedirectType != 0 {
+		const prefix string = ",\"redirect_type\":"
+		out.RawString(prefix)
+		out.Int(int(in.RedirectType))
+	}
+	if in.Passthrough {
+		const prefix string = ",\"passthrough\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Passthrough))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v StatsResponse) MarshalJSON() ([]byte, error) {
+func (v ShortenRequest) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel3(&w, v)
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel25(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v StatsResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel3(w, v)
+func (v ShortenRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel25(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *StatsResponse) UnmarshalJSON(data []byte) error {
+func (v *ShortenRequest) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel3(&r, v)
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel25(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *StatsResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel3(l, v)
+func (v *ShortenRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel25(l, v)
 }
-func easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel4(in *jlexer.Lexer, out *ShortenResponse) {
+func easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel26(in *jlexer.Lexer, out *InspectResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -323,12 +2471,68 @@ func easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel4(in *jlex
 		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		switch key {
-		case "result":
+		case "short_url":
 			if in.IsNull() {
 				in.Skip()
 			} else {
 				out.ShortURL = string(in.String())
 			}
+		case "original_url":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.OrigURL = string(in.String())
+			}
+		case "created_at":
+			if in.IsNull() {
+				in.Skip()
+				out.CreatedAt = nil
+			} else {
+				if out.CreatedAt == nil {
+					out.CreatedAt = new(time.Time)
+				}
+				if in.IsNull() {
+					in.Skip()
+				} else {
+					if data := in.Raw(); in.Ok() {
+						in.AddError((*out.CreatedAt).UnmarshalJSON(data))
+					}
+				}
+			}
+		case "expires_at":
+			if in.IsNull() {
+				in.Skip()
+				out.ExpiresAt = nil
+			} else {
+				if out.ExpiresAt == nil {
+					out.ExpiresAt = new(time.Time)
+				}
+				if in.IsNull() {
+					in.Skip()
+				} else {
+					if data := in.Raw(); in.Ok() {
+						in.AddError((*out.ExpiresAt).UnmarshalJSON(data))
+					}
+				}
+			}
+		case "protected":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Protected = bool(in.Bool())
+			}
+		case "safety":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Safety = string(in.String())
+			}
+		case "passthrough":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Passthrough = bool(in.Bool())
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -339,42 +2543,72 @@ func easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel4(in *jlex
 		in.Consumed()
 	}
 }
-func easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel4(out *jwriter.Writer, in ShortenResponse) {
+func easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel26(out *jwriter.Writer, in InspectResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"result\":"
+		const prefix string = ",\"short_url\":"
 		out.RawString(prefix[1:])
 		out.String(string(in.ShortURL))
 	}
+	if in.OrigURL != "" {
+		const prefix string = ",\"original_url\":"
+		out.RawString(prefix)
+		out.String(string(in.OrigURL))
+	}
+	if in.CreatedAt != nil {
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.Raw((*in.CreatedAt).MarshalJSON())
+	}
+	if in.ExpiresAt != nil {
+		const prefix string = ",\"expires_at\":"
+		out.RawString(prefix)
+		out.Raw((*in.ExpiresAt).MarshalJSON())
+	}
+	if in.Protected {
+		const prefix string = ",\"protected\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Protected))
+	}
+	{
+		const prefix string = ",\"safety\":"
+		out.RawString(prefix)
+		out.String(string(in.Safety))
+	}
+	if in.Passthrough {
+		const prefix string = ",\"passthrough\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Passthrough))
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v ShortenResponse) MarshalJSON() ([]byte, error) {
+func (v InspectResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel4(&w, v)
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel26(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v ShortenResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel4(w, v)
+func (v InspectResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel26(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *ShortenResponse) UnmarshalJSON(data []byte) error {
+func (v *InspectResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel4(&r, v)
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel26(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *ShortenResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel4(l, v)
+func (v *InspectResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel26(l, v)
 }
-func easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel5(in *jlexer.Lexer, out *ShortenRequest) {
+func easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel27(in *jlexer.Lexer, out *ImportResultItem) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -388,12 +2622,42 @@ func easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel5(in *jlex
 		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		switch key {
-		case "url":
+		case "row":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Row = int(in.Int())
+			}
+		case "correlation_id":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.CorrelationID = string(in.String())
+			}
+		case "original_url":
 			if in.IsNull() {
 				in.Skip()
 			} else {
 				out.OrigURL = string(in.String())
 			}
+		case "short_url":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.ShortURL = string(in.String())
+			}
+		case "status":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Status = string(in.String())
+			}
+		case "error":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Error = string(in.String())
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -404,42 +2668,88 @@ func easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel5(in *jlex
 		in.Consumed()
 	}
 }
-func easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel5(out *jwriter.Writer, in ShortenRequest) {
+func easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel27(out *jwriter.Writer, in ImportResultItem) {
 	out.RawByte('{')
 	first := true
 	_ = first
-	{
-		const prefix string = ",\"url\":"
+	if in.Row != 0 {
+		const prefix string = ",\"row\":"
+		first = false
 		out.RawString(prefix[1:])
+		out.Int(int(in.Row))
+	}
+	if in.CorrelationID != "" {
+		const prefix string = ",\"correlation_id\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.CorrelationID))
+	}
+	if in.OrigURL != "" {
+		const prefix string = ",\"original_url\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
 		out.String(string(in.OrigURL))
 	}
+	if in.ShortURL != "" {
+		const prefix string = ",\"short_url\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.ShortURL))
+	}
+	{
+		const prefix string = ",\"status\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Status))
+	}
+	if in.Error != "" {
+		const prefix string = ",\"error\":"
+		out.RawString(prefix)
+		out.String(string(in.Error))
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v ShortenRequest) MarshalJSON() ([]byte, error) {
+func (v ImportResultItem) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel5(&w, v)
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel27(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v ShortenRequest) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel5(w, v)
+func (v ImportResultItem) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel27(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *ShortenRequest) UnmarshalJSON(data []byte) error {
+func (v *ImportResultItem) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel5(&r, v)
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel27(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *ShortenRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel5(l, v)
+func (v *ImportResultItem) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel27(l, v)
 }
-func easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel6(in *jlexer.Lexer, out *BatchShortenResponseItem) {
+func easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel28(in *jlexer.Lexer, out *BatchShortenResponseItem) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -475,7 +2785,7 @@ func easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel6(in *jlex
 		in.Consumed()
 	}
 }
-func easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel6(out *jwriter.Writer, in BatchShortenResponseItem) {
+func easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel28(out *jwriter.Writer, in BatchShortenResponseItem) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -495,27 +2805,27 @@ func easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel6(out *jwr
 // MarshalJSON supports json.Marshaler interface
 func (v BatchShortenResponseItem) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel6(&w, v)
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel28(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v BatchShortenResponseItem) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel6(w, v)
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel28(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *BatchShortenResponseItem) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel6(&r, v)
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel28(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *BatchShortenResponseItem) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel6(l, v)
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel28(l, v)
 }
-func easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel7(in *jlexer.Lexer, out *BatchShortenResponse) {
+func easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel29(in *jlexer.Lexer, out *BatchShortenResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		in.Skip()
@@ -532,13 +2842,13 @@ func easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel7(in *jlex
 			*out = (*out)[:0]
 		}
 		for !in.IsDelim(']') {
-			var v7 BatchShortenResponseItem
+			var v45 BatchShortenResponseItem
 			if in.IsNull() {
 				in.Skip()
 			} else {
-				(v7).UnmarshalEasyJSON(in)
+				(v45).UnmarshalEasyJSON(in)
 			}
-			*out = append(*out, v7)
+			*out = append(*out, v45)
 			in.WantComma()
 		}
 		in.Delim(']')
@@ -547,16 +2857,16 @@ func easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel7(in *jlex
 		in.Consumed()
 	}
 }
-func easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel7(out *jwriter.Writer, in BatchShortenResponse) {
+func easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel29(out *jwriter.Writer, in BatchShortenResponse) {
 	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
 		out.RawString("null")
 	} else {
 		out.RawByte('[')
-		for v8, v9 := range in {
-			if v8 > 0 {
+		for v46, v47 := range in {
+			if v46 > 0 {
 				out.RawByte(',')
 			}
-			(v9).MarshalEasyJSON(out)
+			(v47).MarshalEasyJSON(out)
 		}
 		out.RawByte(']')
 	}
@@ -565,27 +2875,27 @@ func easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel7(out *jwr
 // MarshalJSON supports json.Marshaler interface
 func (v BatchShortenResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel7(&w, v)
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel29(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v BatchShortenResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel7(w, v)
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel29(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *BatchShortenResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel7(&r, v)
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel29(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *BatchShortenResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel7(l, v)
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel29(l, v)
 }
-func easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel8(in *jlexer.Lexer, out *BatchShortenRequestItem) {
+func easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel30(in *jlexer.Lexer, out *BatchShortenRequestItem) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -611,6 +2921,91 @@ func easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel8(in *jlex
 			} else {
 				out.OriginalURL = string(in.String())
 			}
+		case "alias":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Alias = string(in.String())
+			}
+		case "id_style":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.IDStyle = string(in.String())
+			}
+		case "expires_at":
+			if in.IsNull() {
+				in.Skip()
+				out.ExpiresAt = nil
+			} else {
+				if out.ExpiresAt == nil {
+					out.ExpiresAt = new(time.Time)
+				}
+				if in.IsNull() {
+					in.Skip()
+				} else {
+					if data := in.Raw(); in.Ok() {
+						in.AddError((*out.ExpiresAt).UnmarshalJSON(data))
+					}
+				}
+			}
+		case "ttl":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.TTL = int64(in.Int64())
+			}
+		case "max_clicks":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.MaxClicks = int(in.Int())
+			}
+		case "password":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Password = string(in.String())
+			}
+		case "tags":
+			if in.IsNull() {
+				in.Skip()
+				out.Tags = nil
+			} else {
+				in.Delim('[')
+				if out.Tags == nil {
+					if !in.IsDelim(']') {
+						out.Tags = make([]string, 0, 4)
+					} else {
+						out.Tags = []string{}
+					}
+				} else {
+					out.Tags = (out.Tags)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v48 string
+					if in.IsNull() {
+						in.Skip()
+					} else {
+						v48 = string(in.String())
+					}
+					out.Tags = append(out.Tags, v48)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "redirect_type":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.RedirectType = int(in.Int())
+			}
+		case "passthrough":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				out.Passthrough = bool(in.Bool())
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -621,7 +3016,7 @@ func easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel8(in *jlex
 		in.Consumed()
 	}
 }
-func easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel8(out *jwriter.Writer, in BatchShortenRequestItem) {
+func easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel30(out *jwriter.Writer, in BatchShortenRequestItem) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -635,33 +3030,87 @@ func easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel8(out *jwr
 		out.RawString(prefix)
 		out.String(string(in.OriginalURL))
 	}
+	if in.Alias != "" {
+		const prefix string = ",\"alias\":"
+		out.RawString(prefix)
+		out.String(string(in.Alias))
+	}
+	if in.IDStyle != "" {
+		const prefix string = ",\"id_style\":"
+		out.RawString(prefix)
+		out.String(string(in.IDStyle))
+	}
+	if in.ExpiresAt != nil {
+		const prefix string = ",\"expires_at\":"
+		out.RawString(prefix)
+		out.Raw((*in.ExpiresAt).MarshalJSON())
+	}
+	if in.TTL != 0 {
+		const prefix string = ",\"ttl\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.TTL))
+	}
+	if in.MaxClicks != 0 {
+		const prefix string = ",\"max_clicks\":"
+		out.RawString(prefix)
+		out.Int(int(in.MaxClicks))
+	}
+	if in.Password != "" {
+		const prefix string = ",\"password\":"
+		out.RawString(prefix)
+		out.String(string(in.Password))
+	}
+	if len(in.Tags) != 0 {
+		const prefix string = ",\"tags\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v49, v50 := range in.Tags {
+				if v49 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v50))
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.RedirectType != 0 {
+		const prefix string = ",\"redirect_type\":"
+		out.RawString(prefix)
+		out.Int(int(in.RedirectType))
+	}
+	if in.Passthrough {
+		const prefix string = ",\"passthrough\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Passthrough))
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v BatchShortenRequestItem) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel8(&w, v)
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel30(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v BatchShortenRequestItem) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel8(w, v)
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel30(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *BatchShortenRequestItem) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel8(&r, v)
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel30(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *BatchShortenRequestItem) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel8(l, v)
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel30(l, v)
 }
-func easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel9(in *jlexer.Lexer, out *BatchShortenRequest) {
+func easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel31(in *jlexer.Lexer, out *BatchShortenRequest) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		in.Skip()
@@ -670,7 +3119,7 @@ func easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel9(in *jlex
 		in.Delim('[')
 		if *out == nil {
 			if !in.IsDelim(']') {
-				*out = make(BatchShortenRequest, 0, 2)
+				*out = make(BatchShortenRequest, 0, 0)
 			} else {
 				*out = BatchShortenRequest{}
 			}
@@ -678,13 +3127,13 @@ func easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel9(in *jlex
 			*out = (*out)[:0]
 		}
 		for !in.IsDelim(']') {
-			var v10 BatchShortenRequestItem
+			var v51 BatchShortenRequestItem
 			if in.IsNull() {
 				in.Skip()
 			} else {
-				(v10).UnmarshalEasyJSON(in)
+				(v51).UnmarshalEasyJSON(in)
 			}
-			*out = append(*out, v10)
+			*out = append(*out, v51)
 			in.WantComma()
 		}
 		in.Delim(']')
@@ -693,16 +3142,16 @@ func easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel9(in *jlex
 		in.Consumed()
 	}
 }
-func easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel9(out *jwriter.Writer, in BatchShortenRequest) {
+func easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel31(out *jwriter.Writer, in BatchShortenRequest) {
 	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
 		out.RawString("null")
 	} else {
 		out.RawByte('[')
-		for v11, v12 := range in {
-			if v11 > 0 {
+		for v52, v53 := range in {
+			if v52 > 0 {
 				out.RawByte(',')
 			}
-			(v12).MarshalEasyJSON(out)
+			(v53).MarshalEasyJSON(out)
 		}
 		out.RawByte(']')
 	}
@@ -711,23 +3160,23 @@ func easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel9(out *jwr
 // MarshalJSON supports json.Marshaler interface
 func (v BatchShortenRequest) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel9(&w, v)
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel31(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v BatchShortenRequest) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel9(w, v)
+	easyjsonC1cedd36EncodeGithubComAlexStorchakShortenerInternalModel31(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *BatchShortenRequest) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel9(&r, v)
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel31(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *BatchShortenRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel9(l, v)
+	easyjsonC1cedd36DecodeGithubComAlexStorchakShortenerInternalModel31(l, v)
 }
@@ -0,0 +1,49 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Collection represents a named group of user's links.
+// A link belongs to at most one collection; deleting a collection keeps its links.
+type Collection struct {
+	ID        string    `json:"id"`         // Unique identifier of the collection
+	UserUUID  string    `json:"user_uuid"`  // UUID of the user owning the collection
+	Name      string    `json:"name"`       // Name of the collection, unique among collections of the user
+	CreatedAt time.Time `json:"created_at"` // Moment of creation
+	ShortIDs  []string  `json:"short_urls"` // Sorted short identifiers of the links in the collection
+}
+
+// ToJSON serializes the Collection to JSON format.
+//
+// Returns:
+//   - []byte: JSON representation of the collection
+//   - error: nil on success, or JSON marshaling error
+func (c *Collection) ToJSON() ([]byte, error) {
+	return json.Marshal(c)
+}
+
+// FromJSON deserializes JSON data into a Collection.
+//
+// Parameters:
+//   - data: JSON byte data to parse
+//
+// Returns:
+//   - error: nil on success, or JSON unmarshaling error
+func (c *Collection) FromJSON(data []byte) error {
+	return json.Unmarshal(data, c)
+}
+
+// Export formats of collections.
+const (
+	CollectionExportCSV  = "csv"  // Comma-separated values with a header row
+	CollectionExportJSON = "json" // JSON array of links
+)
+
+// CollectionExport represents the links of a collection rendered into a downloadable file.
+type CollectionExport struct {
+	Data        []byte // Content of the file
+	ContentType string // MIME type of the file
+	FileName    string // Suggested name of the file
+}
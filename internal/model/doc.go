@@ -7,20 +7,45 @@
 //   - User: represents system users with unique identifiers
 //   - URLStorageRecord: internal storage structure for URL mappings
 //   - URLToDelete and URLDeleteBatch: for batch deletion operations
+//   - URLToShorten, URLShortenBatch and ShortenOptions: for shortening with per-link options
+//   - ExpandRequest/ExpandResult: for following short URLs, including password-protected ones
+//   - URLInspection: details of a short URL shown instead of following it
+//   - QROptions/QRCode: appearance and rendered image of the QR code of a short URL
+//   - URLHistoryRecord: a single version in the append-only history of a URL
+//   - URLRestoreResult: outcome of restoring a soft-deleted URL
+//   - TagCount: a tag of user's URLs with the amount of URLs labeled with it
+//   - UTMTemplate: campaign query parameters appended to destinations of user's links on redirect
+//   - TargetingRule/TargetingRules: ordered rules sending clients of a link to destinations by device and language
+//   - SplitVariant/Split: weighted destinations of a link in an A/B split test
+//   - SplitChoice: variant of a split test picked for a follow
+//   - Collection: named group of user's links as persisted in storage
+//   - CollectionExport: links of a collection rendered into a downloadable CSV or JSON file
+//   - URLImportRow/URLImportResult: row of a bulk import of links and the outcome of its import
 //
 // # API Models
 //
 // Structured types for REST API communication:
 //   - ShortenRequest/ShortenResponse: for single URL shortening
 //   - BatchShortenRequest/BatchShortenResponse: for batch URL operations
+//   - ImportResultItem: for streaming per-row outcomes of bulk imports of links
+//   - InspectResponse: for previewing the destination and safety status of a short URL
 //   - UserURLsGetResponse: for retrieving user's shortened URLs
 //   - UserURLsDelRequest: for batch URL deletion requests
+//   - UserURLUpdateRequest: for changing the destination or the tags of a short URL
+//   - UserURLHistoryResponse/UserURLRestoreVersionRequest: for short URL version history
+//   - UserURLsRestoreRequest/UserURLsRestoreResponse: for restoring soft-deleted URLs
+//   - UserTagsResponse: for listing user's tags with counts
+//   - UserUTMTemplate/UserUTMTemplatesResponse: for managing UTM templates of user's links
+//   - UserTargetingRule/UserTargetingRules: for managing targeting rules of a short URL
+//   - UserSplitVariant/UserSplit: for managing the A/B split test of a short URL
+//   - UserCollectionRequest/UserCollection/UserCollectionsResponse: for managing collections of user's links
+//   - UserCollectionMoveRequest/UserCollectionDeleteResponse: for moving and bulk deleting links of collections
 //
 // # Audit System
 //
 // Support for request auditing and monitoring:
-//   - AuditEvent: captures action details for analytics
-//   - AuditAction: defines possible audit actions (shorten, follow)
+//   - AuditEvent: captures action details for analytics, including picked split test variants of follows
+//   - AuditAction: defines possible audit actions (shorten, follow, update, block)
 //
 // # JSON Support
 //
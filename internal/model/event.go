@@ -11,15 +11,24 @@ const (
 
 	// AuditActionFollow represents URL following/redirection actions.
 	AuditActionFollow AuditAction = "follow"
+
+	// AuditActionUpdate represents changes of short URL destination.
+	AuditActionUpdate AuditAction = "update"
+
+	// AuditActionBlock represents attempts to shorten or follow URLs matching the blocklist.
+	AuditActionBlock AuditAction = "block"
 )
 
 // AuditEvent represents an audit log entry for tracking system usage.
 // Used for monitoring of URL shortening and following activities.
 type AuditEvent struct {
-	TS      int64       `json:"ts"`                // Unix timestamp of the event
-	Action  AuditAction `json:"action"`            // Type of action: shorten or follow
-	UserID  string      `json:"user_id,omitempty"` // User identifier, if available
-	OrigURL string      `json:"url"`               // Original URL that was processed
+	TS      int64       `json:"ts"`                 // Unix timestamp of the event
+	Action  AuditAction `json:"action"`             // Type of action: shorten, follow, update or block
+	UserID  string      `json:"user_id,omitempty"`  // User identifier, if available
+	OrigURL string      `json:"url"`                // Original URL that was processed
+	PrevURL string      `json:"prev_url,omitempty"` // Previous original URL, for update actions
+	Rule    string      `json:"rule,omitempty"`     // Matched blocklist rule, for block actions
+	Variant string      `json:"variant,omitempty"`  // Picked split test variant, for follow actions
 }
 
 // ToJSON serializes the AuditEvent to JSON format.
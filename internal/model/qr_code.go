@@ -0,0 +1,27 @@
+package model
+
+// Image formats of QR codes.
+const (
+	QRFormatPNG = "png"
+	QRFormatSVG = "svg"
+)
+
+// QROptions defines the appearance of the QR code of a short URL.
+// Zero values select the defaults.
+type QROptions struct {
+	Format     string // Image format: QRFormatPNG or QRFormatSVG; empty for PNG
+	Size       int    // Width and height of the image in pixels; zero for the default size
+	Level      string // Error correction level: L, M, Q or H; empty for M
+	Margin     *int   // Width of the quiet zone around the code in modules; nil for the default margin
+	Foreground string // Color of dark modules in hex notation, e.g. `1a2b3c` or `#000`; empty for black
+	Background string // Color of light modules in hex notation; empty for white
+	// Report links with passthrough enabled with an error instead of rendering their QR codes,
+	// as the '/qr' path suffix of such links belongs to the destination
+	SkipPassthrough bool
+}
+
+// QRCode represents a rendered QR code image of a short URL.
+type QRCode struct {
+	Data        []byte // Encoded image; shared with the cache, so it must not be modified
+	ContentType string // MIME type of the image
+}
@@ -0,0 +1,44 @@
+package model
+
+import "encoding/json"
+
+// SplitVariant represents a destination of a link taking part in its A/B split test.
+type SplitVariant struct {
+	Name   string `json:"name"`   // Name of the variant recorded in follow audit events
+	URL    string `json:"url"`    // Destination of clients assigned to the variant
+	Weight int    `json:"weight"` // Relative share of clients assigned to the variant
+}
+
+// Split represents the A/B split test of a link as persisted in storage.
+type Split struct {
+	ShortID  string         `json:"short_url"` // Short identifier of the link
+	Sticky   bool           `json:"sticky"`    // Whether clients keep the variant assigned on their first follow
+	Variants []SplitVariant `json:"variants"`  // Variants among which clients are distributed by weight
+}
+
+// ToJSON serializes the Split to JSON format.
+//
+// Returns:
+//   - []byte: JSON representation of the split test
+//   - error: nil on success, or JSON marshaling error
+func (s *Split) ToJSON() ([]byte, error) {
+	return json.Marshal(s)
+}
+
+// FromJSON deserializes JSON data into Split.
+//
+// Parameters:
+//   - data: JSON byte data to parse
+//
+// Returns:
+//   - error: nil on success, or JSON unmarshaling error
+func (s *Split) FromJSON(data []byte) error {
+	return json.Unmarshal(data, s)
+}
+
+// SplitChoice represents the variant of a split test picked for a follow of the link.
+type SplitChoice struct {
+	Variant string // Name of the picked variant
+	URL     string // Destination of the picked variant
+	Sticky  bool   // Whether the client has to be kept on the variant in later follows
+}
@@ -0,0 +1,40 @@
+package model
+
+import "encoding/json"
+
+// TargetingRule represents a rule sending matching clients of a link to an alternative destination.
+// A rule matches when all its non-empty conditions match the request; empty conditions match any request.
+type TargetingRule struct {
+	Browser     string `json:"browser,omitempty"`      // User-Agent family of the client, e.g. chrome or safari
+	OS          string `json:"os,omitempty"`           // Operating system of the client, e.g. ios or android
+	Language    string `json:"language,omitempty"`     // Most preferred language of Accept-Language, e.g. de or pt-br
+	Header      string `json:"header,omitempty"`       // Name of a request header that must be present
+	HeaderValue string `json:"header_value,omitempty"` // Value the header must have; empty means any value
+	URL         string `json:"url"`                    // Destination for matching clients
+}
+
+// TargetingRules represents the ordered targeting rules of a link as persisted in storage.
+type TargetingRules struct {
+	ShortID string          `json:"short_url"` // Short identifier of the link
+	Rules   []TargetingRule `json:"rules"`     // Rules in evaluation order; the first matching rule wins
+}
+
+// ToJSON serializes the TargetingRules to JSON format.
+//
+// Returns:
+//   - []byte: JSON representation of the rules
+//   - error: nil on success, or JSON marshaling error
+func (t *TargetingRules) ToJSON() ([]byte, error) {
+	return json.Marshal(t)
+}
+
+// FromJSON deserializes JSON data into TargetingRules.
+//
+// Parameters:
+//   - data: JSON byte data to parse
+//
+// Returns:
+//   - error: nil on success, or JSON unmarshaling error
+func (t *TargetingRules) FromJSON(data []byte) error {
+	return json.Unmarshal(data, t)
+}
@@ -0,0 +1,46 @@
+package model
+
+import (
+	"net/http"
+	"time"
+)
+
+// ExpandRequest represents a request to follow a short URL.
+type ExpandRequest struct {
+	ShortID  string      // Short identifier to expand
+	Password string      // Password of a protected link; empty if not provided
+	Path     string      // Escaped path after the short identifier, starting with '/'; empty if absent
+	RawQuery string      // Raw query of the request without '?'; empty if absent
+	Header   http.Header // Headers of the request matched by targeting rules; nil if absent
+	Variant  string      // Split test variant assigned to the client on a previous follow; empty if absent
+}
+
+// Safety statuses of short URL destinations shown by inspection.
+const (
+	// URLSafetySafe means the destination doesn't match the blocklist.
+	URLSafetySafe = "safe"
+
+	// URLSafetyBlocked means the destination matches the blocklist and the short URL can't be followed.
+	URLSafetyBlocked = "blocked"
+)
+
+// URLInspection represents the details of a short URL shown instead of following it.
+// Inspection neither counts as a click nor is recorded as a follow.
+type URLInspection struct {
+	ShortID     string    // Short identifier of the URL
+	OrigURL     string    // Original URL; empty for password-protected links, whose destination is not disclosed
+	CreatedAt   time.Time // Moment of creation; zero value if unknown
+	ExpiresAt   time.Time // Expiration moment; zero value if the link never expires
+	Protected   bool      // Whether the link can only be followed with a password
+	Safety      string    // Safety status of the destination: URLSafetySafe or URLSafetyBlocked
+	Passthrough bool      // Whether the link forwards path suffixes and queries of requests to the original URL
+}
+
+// ExpandResult represents the outcome of following a short URL.
+type ExpandResult struct {
+	OrigURL      string        // Original URL to redirect to
+	RedirectType int           // HTTP status code of the redirect
+	CacheMaxAge  time.Duration // Time clients may cache the redirect; zero value forbids caching
+	Variant      string        // Split test variant picked for the client; empty if the link has no split test
+	Sticky       bool          // Whether the client has to be kept on Variant in later follows
+}
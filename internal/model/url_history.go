@@ -0,0 +1,89 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// URLHistoryAction defines the type of change recorded in the history of a short URL.
+type URLHistoryAction string
+
+const (
+	// URLHistoryActionCreate represents creation of a short URL.
+	URLHistoryActionCreate URLHistoryAction = "create"
+
+	// URLHistoryActionUpdate represents a change of the short URL destination.
+	URLHistoryActionUpdate URLHistoryAction = "update"
+
+	// URLHistoryActionDelete represents soft deletion of a short URL by its owner.
+	URLHistoryActionDelete URLHistoryAction = "delete"
+
+	// URLHistoryActionRestore represents restoration of a soft-deleted short URL by its owner.
+	URLHistoryActionRestore URLHistoryAction = "restore"
+
+	// URLHistoryActionExpire represents soft deletion of an expired short URL by the sweeper.
+	URLHistoryActionExpire URLHistoryAction = "expire"
+
+	// URLHistoryActionPurge represents physical removal of a soft-deleted short URL after the retention period.
+	// The short identifier of a purged URL is never issued again.
+	URLHistoryActionPurge URLHistoryAction = "purge"
+)
+
+// URLHistoryRecord represents a single version of a short URL in its append-only history.
+type URLHistoryRecord struct {
+	Version  int              `json:"-"`                  // Ordinal number of the version, starting from 1
+	ShortID  string           `json:"short_url"`          // Short identifier of the URL
+	Action   URLHistoryAction `json:"action"`             // Type of change
+	PrevURL  string           `json:"prev_url,omitempty"` // Original URL before the change, if it was changed
+	OrigURL  string           `json:"original_url"`       // Original URL after the change
+	UserUUID string           `json:"user_uuid"`          // UUID of the user who made the change; empty for system changes
+	TS       time.Time        `json:"ts"`                 // Moment of the change
+}
+
+// NewURLHistoryRecord creates a history record of the change applied to the URL storage record.
+//
+// Parameters:
+//   - r: URL storage record after the change
+//   - action: type of change
+//   - prevURL: original URL before the change
+//   - userUUID: UUID of the user who made the change
+//   - ts: moment of the change
+//
+// Returns:
+//   - URLHistoryRecord: history record without version
+func NewURLHistoryRecord(
+	r URLStorageRecord,
+	action URLHistoryAction,
+	prevURL string,
+	userUUID string,
+	ts time.Time,
+) URLHistoryRecord {
+	return URLHistoryRecord{
+		ShortID:  r.ShortID,
+		Action:   action,
+		PrevURL:  prevURL,
+		OrigURL:  r.OrigURL,
+		UserUUID: userUUID,
+		TS:       ts,
+	}
+}
+
+// ToJSON serializes the URLHistoryRecord to JSON format.
+//
+// Returns:
+//   - []byte: JSON representation of the history record
+//   - error: nil on success, or JSON marshaling error
+func (r *URLHistoryRecord) ToJSON() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+// FromJSON deserializes JSON data into a URLHistoryRecord.
+//
+// Parameters:
+//   - data: JSON byte data to parse
+//
+// Returns:
+//   - error: nil on success, or JSON unmarshaling error
+func (r *URLHistoryRecord) FromJSON(data []byte) error {
+	return json.Unmarshal(data, r)
+}
@@ -0,0 +1,33 @@
+package model
+
+// URLImportStatus defines the outcome of importing a single row of a bulk import.
+type URLImportStatus string
+
+const (
+	// URLImportStatusCreated means a new short URL is stored for the row.
+	URLImportStatusCreated URLImportStatus = "created"
+
+	// URLImportStatusExists means the original URL of the row already has a short URL, which is reported instead.
+	URLImportStatusExists URLImportStatus = "exists"
+
+	// URLImportStatusFailed means the row is malformed or rejected and nothing is stored for it.
+	URLImportStatusFailed URLImportStatus = "failed"
+)
+
+// URLImportRow represents a single row read from a bulk import input.
+type URLImportRow struct {
+	Row           int          // Number of the row among data rows of the input, starting from 1
+	CorrelationID string       // Optional client-provided identifier of the row
+	URL           URLToShorten // Original URL with its per-link settings
+	Err           error        // Error of reading the row, if it is malformed
+}
+
+// URLImportResult represents the outcome of importing a single row of a bulk import.
+type URLImportResult struct {
+	Row           int             // Number of the row among data rows of the input, starting from 1
+	CorrelationID string          // Client-provided identifier of the row, if any
+	OrigURL       string          // Canonical original URL, or the URL as read if the row is rejected
+	ShortID       string          // Short identifier of the original URL, unless the row failed
+	Status        URLImportStatus // Outcome of the import of the row
+	Err           error           // Reason of the failure of the row
+}
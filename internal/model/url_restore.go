@@ -0,0 +1,25 @@
+package model
+
+// URLRestoreStatus defines the outcome of restoring a single soft-deleted URL.
+type URLRestoreStatus string
+
+const (
+	// URLRestoreStatusRestored means the URL is undeleted.
+	URLRestoreStatusRestored URLRestoreStatus = "restored"
+
+	// URLRestoreStatusNotFound means the user has no such deleted URL.
+	URLRestoreStatusNotFound URLRestoreStatus = "not_found"
+
+	// URLRestoreStatusConflict means the user already has another live short URL for the same original URL.
+	URLRestoreStatusConflict URLRestoreStatus = "conflict"
+
+	// URLRestoreStatusExpired means the URL has expired and can't be restored.
+	URLRestoreStatusExpired URLRestoreStatus = "expired"
+)
+
+// URLRestoreResult represents the outcome of restoring a single soft-deleted URL.
+type URLRestoreResult struct {
+	ShortID string           // Short identifier of the URL
+	OrigURL string           // Original URL, if the URL is found
+	Status  URLRestoreStatus // Outcome of the restoration
+}
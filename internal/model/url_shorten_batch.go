@@ -0,0 +1,69 @@
+package model
+
+import "time"
+
+// ShortenOptions holds optional per-link settings supplied on shortening.
+type ShortenOptions struct {
+	Alias        string        // Custom short identifier; a generated one is used when empty
+	IDStyle      string        // Style of the generated short identifier, e.g. "words"; ignored when Alias is set
+	ExpiresAt    time.Time     // Absolute expiration moment; zero value means no expiration
+	TTL          time.Duration // Link lifetime counted from the shortening moment; mutually exclusive with ExpiresAt
+	MaxClicks    int           // Allowed amount of follows; zero value means unlimited
+	Password     string        // Password required to follow the link; empty for public links
+	Tags         []string      // Tags labeling the link
+	RedirectType int           // HTTP status code of the link redirect; zero value selects the configured default
+	Passthrough  bool          // Forward path suffix and query of requests to the original URL
+}
+
+// Options builds per-link settings from the optional fields of the request.
+//
+// Returns:
+//   - ShortenOptions: settings to pass to the shortener
+func (r *ShortenRequest) Options() ShortenOptions {
+	return ShortenOptions{
+		Alias:        r.Alias,
+		IDStyle:      r.IDStyle,
+		ExpiresAt:    derefTime(r.ExpiresAt),
+		TTL:          time.Duration(r.TTL) * time.Second,
+		MaxClicks:    r.MaxClicks,
+		Password:     r.Password,
+		Tags:         r.Tags,
+		RedirectType: r.RedirectType,
+		Passthrough:  r.Passthrough,
+	}
+}
+
+// Options builds per-link settings from the optional fields of the batch item.
+//
+// Returns:
+//   - ShortenOptions: settings to pass to the shortener
+func (i *BatchShortenRequestItem) Options() ShortenOptions {
+	return ShortenOptions{
+		Alias:        i.Alias,
+		IDStyle:      i.IDStyle,
+		ExpiresAt:    derefTime(i.ExpiresAt),
+		TTL:          time.Duration(i.TTL) * time.Second,
+		MaxClicks:    i.MaxClicks,
+		Password:     i.Password,
+		Tags:         i.Tags,
+		RedirectType: i.RedirectType,
+		Passthrough:  i.Passthrough,
+	}
+}
+
+// derefTime returns the pointed time, or zero time for nil.
+func derefTime(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return *t
+}
+
+// URLToShorten represents a single URL shortening request with its options.
+type URLToShorten struct {
+	OrigURL string         // Original URL to be shortened
+	Opts    ShortenOptions // Optional per-link settings
+}
+
+// URLShortenBatch represents a collection of URLs to be shortened in batch.
+type URLShortenBatch []URLToShorten
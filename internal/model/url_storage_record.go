@@ -1,13 +1,74 @@
 package model
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
 
 // URLStorageRecord represents the internal storage structure for URL mappings.
 type URLStorageRecord struct {
-	OrigURL   string `json:"original_url"` // Original long URL
-	ShortID   string `json:"short_url"`    // Generated short identifier
-	UserUUID  string `json:"user_uuid"`    // UUID of the user who created the mapping
-	IsDeleted bool   `json:"is_deleted"`   // Soft deletion flag
+	OrigURL      string    `json:"original_url"`            // Original long URL
+	ShortID      string    `json:"short_url"`               // Generated short identifier
+	UserUUID     string    `json:"user_uuid"`               // UUID of the user who created the mapping
+	IsDeleted    bool      `json:"is_deleted"`              // Soft deletion flag
+	DeletedAt    time.Time `json:"deleted_at,omitzero"`     // Moment of the soft deletion; zero value for live links
+	ExpiresAt    time.Time `json:"expires_at,omitzero"`     // Expiration moment; zero value means the link never expires
+	MaxClicks    int       `json:"max_clicks,omitempty"`    // Allowed amount of follows; zero value means unlimited
+	ClicksLeft   int       `json:"clicks_left,omitempty"`   // Remaining amount of follows for click-limited links
+	PassHash     string    `json:"pass_hash,omitempty"`     // Bcrypt hash of the link password; empty for public links
+	Tags         []string  `json:"tags,omitempty"`          // Sorted unique tags labeling the link
+	RedirectType int       `json:"redirect_type,omitempty"` // HTTP status code of the link redirect; zero value for links created before it was stored
+	Passthrough  bool      `json:"passthrough,omitempty"`   // Forward path suffix and query of requests to the original URL
+	CreatedAt    time.Time `json:"created_at,omitzero"`     // Moment of creation; zero value for links created before it was stored
+}
+
+// IsExpired reports whether the record has an expiration moment that is not after now.
+//
+// Parameters:
+//   - now: moment to check expiration against
+//
+// Returns:
+//   - bool: true if the link has expired
+func (r *URLStorageRecord) IsExpired(now time.Time) bool {
+	return !r.ExpiresAt.IsZero() && !now.Before(r.ExpiresAt)
+}
+
+// IsProtected reports whether the record can only be followed with a password.
+//
+// Returns:
+//   - bool: true if the link is password-protected
+func (r *URLStorageRecord) IsProtected() bool {
+	return r.PassHash != ""
+}
+
+// IsExhausted reports whether the record is click-limited and has no follows left.
+//
+// Returns:
+//   - bool: true if the link can't be followed anymore
+func (r *URLStorageRecord) IsExhausted() bool {
+	return r.MaxClicks > 0 && r.ClicksLeft <= 0
+}
+
+// IsPlain reports whether the record never expires, has no click limit and is not password-protected.
+// Only plain links are deduplicated: shortening their original URL again returns the same short URL.
+//
+// Returns:
+//   - bool: true if the link is plain
+func (r *URLStorageRecord) IsPlain() bool {
+	return r.ExpiresAt.IsZero() && r.MaxClicks == 0 && !r.IsProtected()
+}
+
+// Redirect returns the HTTP status code the link redirects with.
+// Links without a stored redirect type keep redirecting with 307 Temporary Redirect.
+//
+// Returns:
+//   - int: HTTP status code of the redirect
+func (r *URLStorageRecord) Redirect() int {
+	if r.RedirectType == 0 {
+		return http.StatusTemporaryRedirect
+	}
+	return r.RedirectType
 }
 
 // ToJSON serializes the URLStorageRecord to JSON format.
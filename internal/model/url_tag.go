@@ -0,0 +1,7 @@
+package model
+
+// TagCount represents a tag of user's short URLs with the amount of URLs labeled with it.
+type TagCount struct {
+	Tag   string // Tag name
+	Count int    // Amount of user's non-deleted URLs with the tag
+}
@@ -0,0 +1,32 @@
+package model
+
+import "encoding/json"
+
+// UTMTemplate represents campaign query parameters appended to destinations of user's links on redirect.
+// A template is bound either to a single link or to a tag, applying to every link of the user labeled with it.
+type UTMTemplate struct {
+	UserUUID string            `json:"user_uuid"`          // UUID of the user owning the template
+	ShortID  string            `json:"short_id,omitempty"` // Short identifier of the link the template is bound to; empty for tag templates
+	Tag      string            `json:"tag,omitempty"`      // Tag the template is bound to; empty for link templates
+	Params   map[string]string `json:"params"`             // Query parameter values by name; values may contain placeholders
+}
+
+// ToJSON serializes the UTMTemplate to JSON format.
+//
+// Returns:
+//   - []byte: JSON representation of the template
+//   - error: nil on success, or JSON marshaling error
+func (t *UTMTemplate) ToJSON() ([]byte, error) {
+	return json.Marshal(t)
+}
+
+// FromJSON deserializes JSON data into a UTMTemplate.
+//
+// Parameters:
+//   - data: JSON byte data to parse
+//
+// Returns:
+//   - error: nil on success, or JSON unmarshaling error
+func (t *UTMTemplate) FromJSON(data []byte) error {
+	return json.Unmarshal(data, t)
+}
@@ -0,0 +1,15 @@
+// Package qrcode provides an in-process encoder of QR codes (ISO/IEC 18004, model 2)
+// and their rendering to PNG and SVG images.
+//
+// Data is encoded in byte mode into the smallest of the 40 versions fitting it at the requested
+// error correction level, and the mask with the lowest penalty score is applied.
+//
+// Usage:
+//
+//	code, err := qrcode.Encode([]byte("https://example.com/abcde"), qrcode.LevelM)
+//	if err != nil {
+//	    // data doesn't fit into a QR code
+//	}
+//	opts := qrcode.RenderOptions{Size: 256, Margin: 4, Foreground: color.Black, Background: color.White}
+//	err = qrcode.WritePNG(w, code, opts)
+package qrcode
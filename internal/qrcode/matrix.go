@@ -0,0 +1,295 @@
+package qrcode
+
+// matrix is the grid of modules of a QR code being built.
+type matrix struct {
+	size       int
+	modules    []bool // Dark modules in row-major order
+	isFunction []bool // Modules of function patterns and format and version information, excluded from masking
+}
+
+// newMatrix creates an empty grid of modules of the version.
+func newMatrix(version int) *matrix {
+	size := version*4 + 17
+	return &matrix{
+		size:       size,
+		modules:    make([]bool, size*size),
+		isFunction: make([]bool, size*size),
+	}
+}
+
+// dark reports whether the module at the column x and the row y is dark.
+func (m *matrix) dark(x, y int) bool {
+	return m.modules[y*m.size+x]
+}
+
+// setFunction sets the color of a function module.
+func (m *matrix) setFunction(x, y int, dark bool) {
+	m.modules[y*m.size+x] = dark
+	m.isFunction[y*m.size+x] = true
+}
+
+// drawFunctionPatterns draws the timing, finder and alignment patterns, reserves the format information
+// and draws the version information.
+func (m *matrix) drawFunctionPatterns() {
+	for i := range m.size {
+		m.setFunction(6, i, i%2 == 0)
+		m.setFunction(i, 6, i%2 == 0)
+	}
+
+	m.drawFinder(3, 3)
+	m.drawFinder(m.size-4, 3)
+	m.drawFinder(3, m.size-4)
+
+	pos := alignmentPositions((m.size - 17) / 4)
+	last := len(pos) - 1
+	for i := range pos {
+		for j := range pos {
+			// Alignment patterns overlapping finder patterns are skipped.
+			if i == 0 && j == 0 || i == 0 && j == last || i == last && j == 0 {
+				continue
+			}
+			m.drawAlignment(pos[i], pos[j])
+		}
+	}
+
+	m.drawFormat(0)
+	m.drawVersion()
+}
+
+// drawFinder draws a finder pattern with its separator centered at the module.
+func (m *matrix) drawFinder(x, y int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			xx, yy := x+dx, y+dy
+			if xx < 0 || yy < 0 || xx >= m.size || yy >= m.size {
+				continue
+			}
+			dist := max(abs(dx), abs(dy))
+			m.setFunction(xx, yy, dist != 2 && dist != 4)
+		}
+	}
+}
+
+// drawAlignment draws an alignment pattern centered at the module.
+func (m *matrix) drawAlignment(x, y int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			m.setFunction(x+dx, y+dy, max(abs(dx), abs(dy)) != 1)
+		}
+	}
+}
+
+// drawFormat draws both copies of the format information of the mask and the dark module.
+// The error correction level is irrelevant while the format information is only reserved.
+func (m *matrix) drawFormat(bits int) {
+	bit := func(i int) bool { return bits>>i&1 != 0 }
+
+	for i := range 6 {
+		m.setFunction(8, i, bit(i))
+	}
+	m.setFunction(8, 7, bit(6))
+	m.setFunction(8, 8, bit(7))
+	m.setFunction(7, 8, bit(8))
+	for i := 9; i < 15; i++ {
+		m.setFunction(14-i, 8, bit(i))
+	}
+
+	for i := range 8 {
+		m.setFunction(m.size-1-i, 8, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		m.setFunction(8, m.size-15+i, bit(i))
+	}
+	m.setFunction(8, m.size-8, true)
+}
+
+// drawVersion draws both copies of the version information of versions 7 and above.
+func (m *matrix) drawVersion() {
+	version := (m.size - 17) / 4
+	if version < 7 {
+		return
+	}
+	bits := versionBits(version)
+	for i := range 18 {
+		dark := bits>>i&1 != 0
+		a, b := m.size-11+i%3, i/3
+		m.setFunction(a, b, dark)
+		m.setFunction(b, a, dark)
+	}
+}
+
+// drawCodewords places the codewords into the modules not taken by function patterns,
+// in the zigzag order of two-module columns from the bottom right corner.
+func (m *matrix) drawCodewords(data []byte) {
+	i := 0
+	for right := m.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			// The vertical timing pattern is skipped.
+			right = 5
+		}
+		upward := (right+1)&2 == 0
+		for vert := range m.size {
+			y := vert
+			if upward {
+				y = m.size - 1 - vert
+			}
+			for j := range 2 {
+				x := right - j
+				if m.isFunction[y*m.size+x] || i >= len(data)*8 {
+					continue
+				}
+				m.modules[y*m.size+x] = data[i>>3]>>(7-i&7)&1 != 0
+				i++
+			}
+		}
+	}
+}
+
+// applyMask inverts the data modules selected by the mask pattern. Applying a mask twice undoes it.
+func (m *matrix) applyMask(mask int) {
+	for y := range m.size {
+		for x := range m.size {
+			if !m.isFunction[y*m.size+x] && maskSelects(mask, x, y) {
+				m.modules[y*m.size+x] = !m.modules[y*m.size+x]
+			}
+		}
+	}
+}
+
+// applyBestMask applies the mask giving the lowest penalty score and draws the format information of it.
+func (m *matrix) applyBestMask(level Level) {
+	best, minPenalty := 0, int(^uint(0)>>1)
+	for mask := range 8 {
+		m.applyMask(mask)
+		m.drawFormat(formatBits(level, mask))
+		if p := m.penalty(); p < minPenalty {
+			best, minPenalty = mask, p
+		}
+		m.applyMask(mask)
+	}
+	m.applyMask(best)
+	m.drawFormat(formatBits(level, best))
+}
+
+// maskSelects reports whether the mask pattern inverts the module at the column x and the row y.
+func maskSelects(mask, x, y int) bool {
+	switch mask {
+	case 0:
+		return (x+y)%2 == 0
+	case 1:
+		return y%2 == 0
+	case 2:
+		return x%3 == 0
+	case 3:
+		return (x+y)%3 == 0
+	case 4:
+		return (x/3+y/2)%2 == 0
+	case 5:
+		return x*y%2+x*y%3 == 0
+	case 6:
+		return (x*y%2+x*y%3)%2 == 0
+	default:
+		return ((x+y)%2+x*y%3)%2 == 0
+	}
+}
+
+// penalty returns the penalty score of the current modules; lower scores are easier to scan.
+func (m *matrix) penalty() int {
+	res := 0
+	for y := range m.size {
+		res += m.linePenalty(func(i int) bool { return m.dark(i, y) })
+	}
+	for x := range m.size {
+		res += m.linePenalty(func(i int) bool { return m.dark(x, i) })
+	}
+
+	for y := range m.size - 1 {
+		for x := range m.size - 1 {
+			c := m.dark(x, y)
+			if c == m.dark(x+1, y) && c == m.dark(x, y+1) && c == m.dark(x+1, y+1) {
+				res += penaltyBlock
+			}
+		}
+	}
+
+	darkCount := 0
+	for _, d := range m.modules {
+		if d {
+			darkCount++
+		}
+	}
+	total := m.size * m.size
+	k := (abs(darkCount*20-total*10)+total-1)/total - 1
+	return res + k*penaltyBalance
+}
+
+// linePenalty returns the penalty score of runs of the same color and finder-like patterns
+// in a row or a column of modules.
+func (m *matrix) linePenalty(dark func(i int) bool) int {
+	res := 0
+	var h runHistory
+	runColor, runLen := false, 0
+	for i := range m.size {
+		if dark(i) == runColor {
+			runLen++
+			if runLen == 5 {
+				res += penaltyRun
+			} else if runLen > 5 {
+				res++
+			}
+			continue
+		}
+		h.add(runLen, m.size)
+		if !runColor {
+			res += h.finderPatterns() * penaltyFinder
+		}
+		runColor, runLen = dark(i), 1
+	}
+	return res + h.terminate(runColor, runLen, m.size)*penaltyFinder
+}
+
+// runHistory holds the lengths of the last seven runs of a line, the latest first.
+type runHistory [7]int
+
+// add records a finished run. The first run is extended by the light quiet zone before the line.
+func (h *runHistory) add(runLen, size int) {
+	if h[0] == 0 {
+		runLen += size
+	}
+	copy(h[1:], h[:6])
+	h[0] = runLen
+}
+
+// finderPatterns returns the amount of finder-like patterns, 1:1:3:1:1 runs with four light modules
+// on either side, ending at the latest light run.
+func (h *runHistory) finderPatterns() int {
+	n := h[1]
+	core := n > 0 && h[2] == n && h[3] == n*3 && h[4] == n && h[5] == n
+	res := 0
+	if core && h[0] >= n*4 && h[6] >= n {
+		res++
+	}
+	if core && h[6] >= n*4 && h[0] >= n {
+		res++
+	}
+	return res
+}
+
+// terminate records the last run of the line followed by the light quiet zone
+// and returns the amount of finder-like patterns at the end of the line.
+func (h *runHistory) terminate(runColor bool, runLen, size int) int {
+	if runColor {
+		h.add(runLen, size)
+		runLen = 0
+	}
+	h.add(runLen+size, size)
+	return h.finderPatterns()
+}
+
+// abs returns the absolute value of x.
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -0,0 +1,337 @@
+package qrcode
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Version limits of QR codes.
+const (
+	minVersion = 1
+	maxVersion = 40
+)
+
+// Weights of the penalty rules used to pick the mask.
+const (
+	penaltyRun     = 3  // Runs of five or more modules of the same color
+	penaltyBlock   = 3  // 2x2 blocks of the same color
+	penaltyFinder  = 40 // Patterns looking like finder patterns
+	penaltyBalance = 10 // Every 5% of deviation from equal amounts of dark and light modules
+)
+
+var (
+	// ErrDataTooLong indicates that the data doesn't fit into the largest QR code at the error correction level.
+	ErrDataTooLong = errors.New("data too long for qr code")
+
+	// ErrInvalidLevel indicates that the error correction level is not one of L, M, Q and H.
+	ErrInvalidLevel = errors.New("invalid error correction level")
+)
+
+// Level is the error correction level of a QR code, trading capacity for resistance to damage.
+type Level int
+
+// Error correction levels.
+const (
+	LevelL Level = iota // Recovers about 7% of damaged codewords
+	LevelM              // Recovers about 15% of damaged codewords
+	LevelQ              // Recovers about 25% of damaged codewords
+	LevelH              // Recovers about 30% of damaged codewords
+)
+
+// levelNames are the names of error correction levels indexed by Level.
+var levelNames = [...]string{"L", "M", "Q", "H"}
+
+// levelFormatBits are the bits of error correction levels in the format information indexed by Level.
+var levelFormatBits = [...]int{1, 0, 3, 2}
+
+// eccCodewordsPerBlock is the amount of error correction codewords in every block indexed by Level and version.
+var eccCodewordsPerBlock = [4][maxVersion + 1]int{
+	{-1, 7, 10, 15, 20, 26, 18, 20, 24, 30, 18, 20, 24, 26, 30, 22, 24, 28, 30, 28, 28, 28, 28, 30, 30, 26, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+	{-1, 10, 16, 26, 18, 24, 16, 18, 22, 22, 26, 30, 22, 22, 24, 24, 28, 28, 26, 26, 26, 26, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28},
+	{-1, 13, 22, 18, 26, 18, 24, 18, 22, 20, 24, 28, 26, 24, 20, 30, 24, 28, 28, 26, 30, 28, 30, 30, 30, 30, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+	{-1, 17, 28, 22, 16, 22, 28, 26, 26, 24, 28, 24, 28, 22, 24, 24, 30, 28, 28, 26, 28, 30, 24, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+}
+
+// eccBlocks is the amount of error correction blocks indexed by Level and version.
+var eccBlocks = [4][maxVersion + 1]int{
+	{-1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 4, 4, 4, 4, 4, 6, 6, 6, 6, 7, 8, 8, 9, 9, 10, 12, 12, 12, 13, 14, 15, 16, 17, 18, 19, 19, 20, 21, 22, 24, 25},
+	{-1, 1, 1, 1, 2, 2, 4, 4, 4, 5, 5, 5, 8, 9, 9, 10, 10, 11, 13, 14, 16, 17, 17, 18, 20, 21, 23, 25, 26, 28, 29, 31, 33, 35, 37, 38, 40, 43, 45, 47, 49},
+	{-1, 1, 1, 2, 2, 4, 4, 6, 6, 8, 8, 8, 10, 12, 16, 12, 17, 16, 18, 21, 20, 23, 23, 25, 27, 29, 34, 34, 35, 38, 40, 43, 45, 48, 51, 53, 56, 59, 62, 65, 68},
+	{-1, 1, 1, 2, 4, 4, 4, 5, 6, 8, 8, 11, 11, 16, 16, 18, 16, 19, 21, 25, 25, 25, 34, 30, 32, 35, 37, 40, 42, 45, 48, 51, 54, 57, 60, 63, 66, 70, 74, 77, 81},
+}
+
+// ParseLevel returns the error correction level by its name, compared case-insensitively.
+//
+// Parameters:
+//   - s: name of the level: L, M, Q or H
+//
+// Returns:
+//   - Level: parsed level
+//   - error: nil on success, or ErrInvalidLevel if the name is unknown
+func ParseLevel(s string) (Level, error) {
+	for i, name := range levelNames {
+		if strings.EqualFold(s, name) {
+			return Level(i), nil
+		}
+	}
+	return 0, fmt.Errorf("%w: `%s`", ErrInvalidLevel, s)
+}
+
+// String returns the name of the error correction level.
+func (l Level) String() string {
+	if l < LevelL || l > LevelH {
+		return fmt.Sprintf("Level(%d)", int(l))
+	}
+	return levelNames[l]
+}
+
+// Code is an encoded QR code: a square grid of dark and light modules without the quiet zone.
+type Code struct {
+	version int
+	size    int
+	modules []bool // Dark modules in row-major order
+}
+
+// Encode encodes the data in byte mode into the smallest QR code fitting it at the error correction level.
+//
+// Parameters:
+//   - data: bytes to encode, e.g. a URL
+//   - level: error correction level
+//
+// Returns:
+//   - *Code: encoded QR code
+//   - error: nil on success, ErrInvalidLevel if the level is unknown,
+//     or ErrDataTooLong if the data doesn't fit into a version 40 QR code
+func Encode(data []byte, level Level) (*Code, error) {
+	if level < LevelL || level > LevelH {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidLevel, int(level))
+	}
+	version := minVersion
+	for ; version <= maxVersion; version++ {
+		if dataBits(version, len(data)) <= numDataCodewords(version, level)*8 {
+			break
+		}
+	}
+	if version > maxVersion {
+		return nil, fmt.Errorf("%w: %d bytes at level %s", ErrDataTooLong, len(data), level)
+	}
+
+	codewords := addECCAndInterleave(encodeData(data, version, level), version, level)
+	m := newMatrix(version)
+	m.drawFunctionPatterns()
+	m.drawCodewords(codewords)
+	m.applyBestMask(level)
+	return &Code{version: version, size: m.size, modules: m.modules}, nil
+}
+
+// Version returns the version of the QR code, from 1 to 40.
+func (c *Code) Version() int {
+	return c.version
+}
+
+// Size returns the amount of modules along each side of the QR code, without the quiet zone.
+func (c *Code) Size() int {
+	return c.size
+}
+
+// Dark reports whether the module at the column x and the row y is dark.
+// Modules outside the QR code are light, as in the quiet zone.
+func (c *Code) Dark(x, y int) bool {
+	if x < 0 || y < 0 || x >= c.size || y >= c.size {
+		return false
+	}
+	return c.modules[y*c.size+x]
+}
+
+// charCountBits returns the length of the character count indicator of byte mode in the version.
+func charCountBits(version int) int {
+	if version <= 9 {
+		return 8
+	}
+	return 16
+}
+
+// dataBits returns the amount of bits taken by n bytes encoded in byte mode in the version.
+func dataBits(version, n int) int {
+	if n >= 1<<charCountBits(version) {
+		return int(^uint(0) >> 1)
+	}
+	return 4 + charCountBits(version) + n*8
+}
+
+// numRawDataModules returns the amount of modules of the version available for data and error correction,
+// i.e. not taken by function patterns and format and version information.
+func numRawDataModules(version int) int {
+	n := (16*version+128)*version + 64
+	if version >= 2 {
+		numAlign := version/7 + 2
+		n -= (25*numAlign-10)*numAlign - 55
+		if version >= 7 {
+			n -= 36
+		}
+	}
+	return n
+}
+
+// numDataCodewords returns the amount of data codewords of the version at the error correction level.
+func numDataCodewords(version int, level Level) int {
+	return numRawDataModules(version)/8 - eccCodewordsPerBlock[level][version]*eccBlocks[level][version]
+}
+
+// encodeData returns the data codewords: the byte mode segment followed by the terminator and padding.
+func encodeData(data []byte, version int, level Level) []byte {
+	capacity := numDataCodewords(version, level) * 8
+	var bb bitBuffer
+	bb.append(0b0100, 4)
+	bb.append(len(data), charCountBits(version))
+	for _, b := range data {
+		bb.append(int(b), 8)
+	}
+	bb.append(0, min(4, capacity-bb.len()))
+	bb.append(0, (8-bb.len()%8)%8)
+	for pad := 0xEC; bb.len() < capacity; pad ^= 0xEC ^ 0x11 {
+		bb.append(pad, 8)
+	}
+	return bb.bytes()
+}
+
+// addECCAndInterleave splits the data codewords into blocks, appends error correction codewords
+// to every block and interleaves the blocks.
+func addECCAndInterleave(data []byte, version int, level Level) []byte {
+	numBlocks := eccBlocks[level][version]
+	blockECCLen := eccCodewordsPerBlock[level][version]
+	rawCodewords := numRawDataModules(version) / 8
+	numShortBlocks := numBlocks - rawCodewords%numBlocks
+	shortBlockLen := rawCodewords / numBlocks
+
+	divisor := rsDivisor(blockECCLen)
+	blocks := make([][]byte, numBlocks)
+	for i, k := 0, 0; i < numBlocks; i++ {
+		datLen := shortBlockLen - blockECCLen
+		if i >= numShortBlocks {
+			datLen++
+		}
+		// Short blocks have a gap before the error correction codewords, skipped on interleaving.
+		block := make([]byte, shortBlockLen+1)
+		copy(block, data[k:k+datLen])
+		copy(block[shortBlockLen+1-blockECCLen:], rsRemainder(data[k:k+datLen], divisor))
+		blocks[i] = block
+		k += datLen
+	}
+
+	result := make([]byte, 0, rawCodewords)
+	for i := range blocks[0] {
+		for j, block := range blocks {
+			if i != shortBlockLen-blockECCLen || j >= numShortBlocks {
+				result = append(result, block[i])
+			}
+		}
+	}
+	return result
+}
+
+// alignmentPositions returns the coordinates of the centers of alignment patterns along each axis.
+func alignmentPositions(version int) []int {
+	if version == 1 {
+		return nil
+	}
+	numAlign := version/7 + 2
+	step := (version*8 + numAlign*3 + 5) / (numAlign*4 - 4) * 2
+	pos := make([]int, numAlign)
+	pos[0] = 6
+	for i, p := numAlign-1, version*4+10; i >= 1; i, p = i-1, p-step {
+		pos[i] = p
+	}
+	return pos
+}
+
+// formatBits returns the 15 bits of the format information of the error correction level and the mask,
+// with the BCH error correction bits and the format mask applied.
+func formatBits(level Level, mask int) int {
+	data := levelFormatBits[level]<<3 | mask
+	rem := data
+	for range 10 {
+		rem = rem<<1 ^ (rem>>9)*0x537
+	}
+	return (data<<10 | rem) ^ 0x5412
+}
+
+// versionBits returns the 18 bits of the version information with the BCH error correction bits.
+func versionBits(version int) int {
+	rem := version
+	for range 12 {
+		rem = rem<<1 ^ (rem>>11)*0x1F25
+	}
+	return version<<12 | rem
+}
+
+// bitBuffer is an appendable sequence of bits.
+type bitBuffer struct {
+	bits []bool
+}
+
+// append appends the n lowest bits of the value, most significant first.
+func (b *bitBuffer) append(val, n int) {
+	for i := n - 1; i >= 0; i-- {
+		b.bits = append(b.bits, val>>i&1 != 0)
+	}
+}
+
+// len returns the amount of bits in the buffer.
+func (b *bitBuffer) len() int {
+	return len(b.bits)
+}
+
+// bytes packs the bits into bytes, most significant bit first.
+func (b *bitBuffer) bytes() []byte {
+	res := make([]byte, (len(b.bits)+7)/8)
+	for i, bit := range b.bits {
+		if bit {
+			res[i>>3] |= 0x80 >> (i & 7)
+		}
+	}
+	return res
+}
+
+// rsDivisor returns the coefficients of the Reed-Solomon generator polynomial of the degree,
+// from the highest power to the lowest, without the leading coefficient 1.
+func rsDivisor(degree int) []byte {
+	res := make([]byte, degree)
+	res[degree-1] = 1
+	root := byte(1)
+	for range degree {
+		for j := range res {
+			res[j] = rsMultiply(res[j], root)
+			if j+1 < len(res) {
+				res[j] ^= res[j+1]
+			}
+		}
+		root = rsMultiply(root, 0x02)
+	}
+	return res
+}
+
+// rsRemainder returns the Reed-Solomon error correction codewords of the data.
+func rsRemainder(data, divisor []byte) []byte {
+	res := make([]byte, len(divisor))
+	for _, b := range data {
+		factor := b ^ res[0]
+		copy(res, res[1:])
+		res[len(res)-1] = 0
+		for i := range res {
+			res[i] ^= rsMultiply(divisor[i], factor)
+		}
+	}
+	return res
+}
+
+// rsMultiply returns the product of the two elements of GF(2^8) modulo the QR code polynomial 0x11D.
+func rsMultiply(x, y byte) byte {
+	z := 0
+	for i := 7; i >= 0; i-- {
+		z = z<<1 ^ (z>>7)*0x11D
+		z ^= int(y>>i&1) * int(x)
+	}
+	return byte(z)
+}
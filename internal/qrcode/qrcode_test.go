@@ -0,0 +1,277 @@
+package qrcode
+
+import (
+	"bytes"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRSRemainder(t *testing.T) {
+	// Data codewords of `HELLO WORLD` in alphanumeric mode, version 1-M, from the ISO/IEC 18004 annex.
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	want := []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}
+	assert.Equal(t, want, rsRemainder(data, rsDivisor(10)))
+}
+
+func TestFormatBits(t *testing.T) {
+	tests := []struct {
+		level Level
+		mask  int
+		want  int
+	}{
+		{LevelL, 0, 0b111011111000100},
+		{LevelL, 7, 0b110100101110110},
+		{LevelM, 0, 0b101010000010010},
+		{LevelM, 5, 0b100000011001110},
+		{LevelQ, 0, 0b011010101011111},
+		{LevelH, 0, 0b001011010001001},
+		{LevelH, 7, 0b000100000111011},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, formatBits(tt.level, tt.mask), "level %s, mask %d", tt.level, tt.mask)
+	}
+}
+
+func TestVersionBits(t *testing.T) {
+	assert.Equal(t, 0b000111110010010100, versionBits(7))
+	assert.Equal(t, 0b101000110001101001, versionBits(40))
+}
+
+func TestAlignmentPositions(t *testing.T) {
+	assert.Empty(t, alignmentPositions(1))
+	assert.Equal(t, []int{6, 18}, alignmentPositions(2))
+	assert.Equal(t, []int{6, 22, 38}, alignmentPositions(7))
+	assert.Equal(t, []int{6, 26, 48, 70}, alignmentPositions(15))
+	assert.Equal(t, []int{6, 34, 60, 86, 112, 138}, alignmentPositions(32))
+	assert.Equal(t, []int{6, 30, 58, 86, 114, 142, 170}, alignmentPositions(40))
+}
+
+func TestNumDataCodewords(t *testing.T) {
+	tests := []struct {
+		version int
+		level   Level
+		want    int
+	}{
+		{1, LevelL, 19},
+		{1, LevelH, 9},
+		{7, LevelQ, 88},
+		{10, LevelM, 216},
+		{40, LevelL, 2956},
+		{40, LevelH, 1276},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, numDataCodewords(tt.version, tt.level), "version %d-%s", tt.version, tt.level)
+	}
+	assert.Equal(t, 26, numRawDataModules(1)/8)
+	assert.Equal(t, 3706, numRawDataModules(40)/8)
+}
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        string
+		level       Level
+		wantVersion int
+	}{
+		{name: "short url", data: "http://localhost:8080/abcde", level: LevelM, wantVersion: 3},
+		{name: "empty data", data: "", level: LevelL, wantVersion: 1},
+		{name: "high level needs larger version", data: "http://localhost:8080/abcde", level: LevelH, wantVersion: 4},
+		{name: "version information", data: strings.Repeat("https://example.com/", 8), level: LevelQ, wantVersion: 11},
+		{name: "two byte character count", data: strings.Repeat("x", 300), level: LevelM, wantVersion: 13},
+		{name: "largest data", data: strings.Repeat("x", 2953), level: LevelL, wantVersion: 40},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := Encode([]byte(tt.data), tt.level)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantVersion, c.Version())
+			assert.Equal(t, tt.wantVersion*4+17, c.Size())
+
+			level, data := decode(t, c)
+			assert.Equal(t, tt.level, level)
+			assert.Equal(t, tt.data, string(data))
+		})
+	}
+
+	_, err := Encode(bytes.Repeat([]byte("x"), 2954), LevelL)
+	require.ErrorIs(t, err, ErrDataTooLong)
+	_, err = Encode([]byte("x"), Level(4))
+	require.ErrorIs(t, err, ErrInvalidLevel)
+}
+
+func TestParseLevel(t *testing.T) {
+	for _, name := range []string{"L", "m", "Q", "h"} {
+		l, err := ParseLevel(name)
+		require.NoError(t, err)
+		assert.Equal(t, strings.ToUpper(name), l.String())
+	}
+	_, err := ParseLevel("X")
+	require.ErrorIs(t, err, ErrInvalidLevel)
+}
+
+func TestWritePNG(t *testing.T) {
+	c, err := Encode([]byte("http://localhost:8080/abcde"), LevelM)
+	require.NoError(t, err)
+	fg := color.NRGBA{R: 0x11, G: 0x22, B: 0x33, A: 0xff}
+	bg := color.NRGBA{R: 0xff, G: 0xee, B: 0xdd, A: 0xff}
+
+	var buf bytes.Buffer
+	require.NoError(t, WritePNG(&buf, c, RenderOptions{Size: 100, Margin: 4, Foreground: fg, Background: bg}))
+	img, err := png.Decode(&buf)
+	require.NoError(t, err)
+
+	// 29 modules and the quiet zone take 37 modules, drawn with 2 pixels each and centered.
+	assert.Equal(t, 100, img.Bounds().Dx())
+	assert.Equal(t, 100, img.Bounds().Dy())
+	offset := (100-37*2)/2 + 4*2
+	assert.Equal(t, bg, color.NRGBAModel.Convert(img.At(offset-1, offset)))
+	assert.Equal(t, fg, color.NRGBAModel.Convert(img.At(offset, offset)))
+	assert.Equal(t, fg, color.NRGBAModel.Convert(img.At(offset+2*7-1, offset+2*7-1)))
+	assert.Equal(t, bg, color.NRGBAModel.Convert(img.At(offset+2*7, offset)))
+
+	buf.Reset()
+	require.NoError(t, WritePNG(&buf, c, RenderOptions{Size: 10, Foreground: fg, Background: bg}))
+	img, err = png.Decode(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, 29, img.Bounds().Dx(), "size is raised to one pixel per module")
+}
+
+func TestWriteSVG(t *testing.T) {
+	c, err := Encode([]byte("http://localhost:8080/abcde"), LevelM)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteSVG(&buf, c, RenderOptions{Size: 256, Margin: 2, Foreground: color.Black, Background: color.White}))
+	svg := buf.String()
+	assert.Contains(t, svg, `width="256" height="256" viewBox="0 0 33 33"`)
+	assert.Contains(t, svg, `<rect width="100%" height="100%" fill="#ffffff"/>`)
+	assert.Contains(t, svg, `<path fill="#000000" d="M2,2h1v1h-1z`)
+	assert.Equal(t, countDark(c), strings.Count(svg, "h1v1h-1z"))
+}
+
+func countDark(c *Code) int {
+	n := 0
+	for y := range c.Size() {
+		for x := range c.Size() {
+			if c.Dark(x, y) {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// decode reads the QR code back: it reads the format information, removes the mask, collects
+// the codewords, checks the error correction codewords of every block and parses the byte mode segment.
+func decode(t *testing.T, c *Code) (Level, []byte) {
+	t.Helper()
+	version := (c.Size() - 17) / 4
+
+	format := 0
+	formatModules := [][2]int{{8, 0}, {8, 1}, {8, 2}, {8, 3}, {8, 4}, {8, 5}, {8, 7}, {8, 8}, {7, 8}}
+	for i := 9; i < 15; i++ {
+		formatModules = append(formatModules, [2]int{14 - i, 8})
+	}
+	for i, p := range formatModules {
+		if c.Dark(p[0], p[1]) {
+			format |= 1 << i
+		}
+	}
+	level, mask := Level(-1), -1
+	for l := LevelL; l <= LevelH; l++ {
+		for m := range 8 {
+			if formatBits(l, m) == format {
+				level, mask = l, m
+			}
+		}
+	}
+	require.NotEqual(t, -1, mask, "format information is damaged")
+
+	if version >= 7 {
+		bits := 0
+		for i := range 18 {
+			if c.Dark(c.Size()-11+i%3, i/3) {
+				bits |= 1 << i
+			}
+		}
+		require.Equal(t, versionBits(version), bits)
+	}
+
+	m := newMatrix(version)
+	m.drawFunctionPatterns()
+	copy(m.modules, c.modules)
+	m.applyMask(mask)
+
+	var bb bitBuffer
+	for right := m.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vert := range m.size {
+			y := vert
+			if (right+1)&2 == 0 {
+				y = m.size - 1 - vert
+			}
+			for j := range 2 {
+				if !m.isFunction[y*m.size+right-j] {
+					bb.bits = append(bb.bits, m.dark(right-j, y))
+				}
+			}
+		}
+	}
+	raw := bb.bytes()[:numRawDataModules(version)/8]
+
+	numBlocks := eccBlocks[level][version]
+	eccLen := eccCodewordsPerBlock[level][version]
+	numShortBlocks := numBlocks - len(raw)%numBlocks
+	shortLen := len(raw) / numBlocks
+	blocks := make([][]byte, numBlocks)
+	k := 0
+	for i := range shortLen - eccLen + 1 {
+		for j := range blocks {
+			if i < shortLen-eccLen || j >= numShortBlocks {
+				blocks[j] = append(blocks[j], raw[k])
+				k++
+			}
+		}
+	}
+	var data []byte
+	for range eccLen {
+		for j := range blocks {
+			blocks[j] = append(blocks[j], raw[k])
+			k++
+		}
+	}
+	for _, b := range blocks {
+		dat := b[:len(b)-eccLen]
+		require.Equal(t, rsRemainder(dat, rsDivisor(eccLen)), b[len(b)-eccLen:], "error correction codewords")
+		data = append(data, dat...)
+	}
+
+	bits := bitBuffer{}
+	for _, b := range data {
+		bits.append(int(b), 8)
+	}
+	read := func(pos, n int) int {
+		v := 0
+		for _, bit := range bits.bits[pos : pos+n] {
+			v <<= 1
+			if bit {
+				v |= 1
+			}
+		}
+		return v
+	}
+	require.Equal(t, 0b0100, read(0, 4), "byte mode")
+	n := read(4, charCountBits(version))
+	res := make([]byte, n)
+	for i := range n {
+		res[i] = byte(read(4+charCountBits(version)+i*8, 8))
+	}
+	return level, res
+}
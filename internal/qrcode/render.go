@@ -0,0 +1,94 @@
+package qrcode
+
+import (
+	"bufio"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+)
+
+// RenderOptions defines the appearance of a rendered QR code.
+type RenderOptions struct {
+	Size       int         // Width and height of the image in pixels; raised to one pixel per module if smaller
+	Margin     int         // Width of the quiet zone around the QR code in modules
+	Foreground color.Color // Color of dark modules
+	Background color.Color // Color of light modules and the quiet zone
+}
+
+// WritePNG renders the QR code as a PNG image of exactly the requested size.
+// Modules are drawn with the same integer amount of pixels, and the pixels left over are added
+// to the quiet zone, so the code stays sharp for scanners.
+//
+// Parameters:
+//   - w: writer receiving the image
+//   - c: QR code to render
+//   - opts: appearance of the image
+//
+// Returns:
+//   - error: nil on success, or error if the image can't be encoded or written
+func WritePNG(w io.Writer, c *Code, opts RenderOptions) error {
+	total := c.size + opts.Margin*2
+	side := max(opts.Size, total)
+	scale := side / total
+	offset := (side-total*scale)/2 + opts.Margin*scale
+
+	img := image.NewPaletted(image.Rect(0, 0, side, side), color.Palette{opts.Background, opts.Foreground})
+	for y := range c.size {
+		for x := range c.size {
+			if !c.Dark(x, y) {
+				continue
+			}
+			for py := range scale {
+				row := img.Pix[(offset+y*scale+py)*img.Stride:]
+				for px := range scale {
+					row[offset+x*scale+px] = 1
+				}
+			}
+		}
+	}
+	enc := png.Encoder{CompressionLevel: png.BestCompression}
+	if err := enc.Encode(w, img); err != nil {
+		return fmt.Errorf("encode png: %w", err)
+	}
+	return nil
+}
+
+// WriteSVG renders the QR code as an SVG image with the requested width and height.
+// Dark modules are drawn as a single path in module units, so the image scales without losing sharpness.
+//
+// Parameters:
+//   - w: writer receiving the image
+//   - c: QR code to render
+//   - opts: appearance of the image
+//
+// Returns:
+//   - error: nil on success, or error if the image can't be written
+func WriteSVG(w io.Writer, c *Code, opts RenderOptions) error {
+	total := c.size + opts.Margin*2
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, `<?xml version="1.0" encoding="UTF-8"?>`+"\n")
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" version="1.1" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`+"\n",
+		opts.Size, opts.Size, total, total)
+	fmt.Fprintf(bw, `<rect width="100%%" height="100%%" fill="%s"/>`+"\n", hexColor(opts.Background))
+	fmt.Fprintf(bw, `<path fill="%s" d="`, hexColor(opts.Foreground))
+	for y := range c.size {
+		for x := range c.size {
+			if c.Dark(x, y) {
+				fmt.Fprintf(bw, "M%d,%dh1v1h-1z", x+opts.Margin, y+opts.Margin)
+			}
+		}
+	}
+	fmt.Fprintf(bw, `"/>`+"\n</svg>\n")
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("write svg: %w", err)
+	}
+	return nil
+}
+
+// hexColor returns the color in the `#rrggbb` notation; transparency is ignored.
+func hexColor(c color.Color) string {
+	n := color.NRGBAModel.Convert(c).(color.NRGBA)
+	return fmt.Sprintf("#%02x%02x%02x", n.R, n.G, n.B)
+}
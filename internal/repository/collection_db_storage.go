@@ -0,0 +1,315 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+
+	"github.com/alex-storchak/shortener/internal/model"
+)
+
+// collectionNameUniqueConstraint is the UNIQUE constraint on collection (user_id, name).
+const collectionNameUniqueConstraint = "collection_user_id_name_key"
+
+// selectCollectionsQuery selects collections of a user with their links aggregated into
+// a comma-separated list; short IDs never contain commas.
+const selectCollectionsQuery = `
+	SELECT c.id, au.user_uuid, c.name, c.created_at,
+		COALESCE((
+			SELECT string_agg(cu.short_id, ',' ORDER BY cu.short_id)
+			FROM collection_url cu
+			WHERE cu.collection_id = c.id
+		), '')
+	FROM collection c
+	JOIN auth_user au ON au.id = c.user_id
+	WHERE au.user_uuid = $1
+`
+
+// DBCollectionStorage provides a PostgreSQL implementation of CollectionStorage.
+// Collections are kept in the collection table and links in them in the collection_url table,
+// which references collections and links with cascade deletion.
+type DBCollectionStorage struct {
+	logger *zap.Logger
+	db     *sql.DB
+}
+
+// NewDBCollectionStorage creates a new database collection storage instance.
+//
+// Parameters:
+//   - logger: structured logger for logging operations
+//   - db: database connection
+//
+// Returns:
+//   - *DBCollectionStorage: configured database collection storage
+func NewDBCollectionStorage(logger *zap.Logger, db *sql.DB) *DBCollectionStorage {
+	return &DBCollectionStorage{
+		logger: logger,
+		db:     db,
+	}
+}
+
+// Close closes the database connection.
+//
+// Returns:
+//   - error: nil on success, or error if connection closure fails
+func (s *DBCollectionStorage) Close() error {
+	return s.db.Close()
+}
+
+// GetByUserUUID retrieves all collections of a specific user from the collection table.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - userUUID: UUID of the user to retrieve collections for
+//
+// Returns:
+//   - []model.Collection: collections sorted by name
+//   - error: nil on success, or error if the query fails
+func (s *DBCollectionStorage) GetByUserUUID(ctx context.Context, userUUID string) ([]model.Collection, error) {
+	rows, err := s.db.QueryContext(ctx, selectCollectionsQuery+" ORDER BY c.name, c.id", userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("query collections from db: %w", err)
+	}
+	defer rows.Close()
+
+	collections := make([]model.Collection, 0)
+	for rows.Next() {
+		c, err := scanCollection(rows)
+		if err != nil {
+			return nil, err
+		}
+		collections = append(collections, *c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("get collections from db: %w", err)
+	}
+	return collections, nil
+}
+
+// Get retrieves a collection of a user from the collection table.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - userUUID: UUID of the user owning the collection
+//   - id: identifier of the collection
+//
+// Returns:
+//   - *model.Collection: found collection
+//   - error: nil on success, DataNotFoundError if the user has no such collection,
+//     or error if the query fails
+func (s *DBCollectionStorage) Get(ctx context.Context, userUUID, id string) (*model.Collection, error) {
+	row := s.db.QueryRowContext(ctx, selectCollectionsQuery+" AND c.id = $2", userUUID, id)
+	c, err := scanCollection(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, NewDataNotFoundError(ErrDataNotFoundInDB)
+	} else if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Create stores a new empty collection in the collection table.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - c: collection with its identifier, owner, name and creation moment
+//
+// Returns:
+//   - error: nil on success, ErrCollectionNameConflict if the user has a collection with the same name,
+//     DataNotFoundError if the user doesn't exist, or error if the insertion fails
+func (s *DBCollectionStorage) Create(ctx context.Context, c model.Collection) error {
+	q := `
+		INSERT INTO collection (id, user_id, name, created_at)
+		SELECT $1, id, $3, $4
+		FROM auth_user
+		WHERE user_uuid = $2
+	`
+	res, err := s.db.ExecContext(ctx, q, c.ID, c.UserUUID, c.Name, c.CreatedAt)
+	if isCollectionNameConflict(err) {
+		return fmt.Errorf("persist collection `%s` to db: %w", c.Name, ErrCollectionNameConflict)
+	} else if err != nil {
+		return fmt.Errorf("persist collection to db: %w", err)
+	}
+	return requireAffected(res, "collection insertion")
+}
+
+// Rename changes the name of a collection of a user in the collection table.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - userUUID: UUID of the user owning the collection
+//   - id: identifier of the collection
+//   - name: new name of the collection
+//
+// Returns:
+//   - error: nil on success, DataNotFoundError if the user has no such collection,
+//     ErrCollectionNameConflict if the user has another collection with the same name,
+//     or error if the update fails
+func (s *DBCollectionStorage) Rename(ctx context.Context, userUUID, id, name string) error {
+	q := `
+		UPDATE collection c
+		SET name = $3
+		FROM auth_user au
+		WHERE au.id = c.user_id
+		AND au.user_uuid = $1
+		AND c.id = $2
+	`
+	res, err := s.db.ExecContext(ctx, q, userUUID, id, name)
+	if isCollectionNameConflict(err) {
+		return fmt.Errorf("rename collection to `%s` in db: %w", name, ErrCollectionNameConflict)
+	} else if err != nil {
+		return fmt.Errorf("rename collection in db: %w", err)
+	}
+	return requireAffected(res, "collection renaming")
+}
+
+// Delete removes a collection of a user from the collection table.
+// Links of the collection are removed from the collection_url table by cascade.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - userUUID: UUID of the user owning the collection
+//   - id: identifier of the collection
+//
+// Returns:
+//   - error: nil on success, DataNotFoundError if the user has no such collection,
+//     or error if the deletion fails
+func (s *DBCollectionStorage) Delete(ctx context.Context, userUUID, id string) error {
+	q := `
+		DELETE FROM collection c
+		USING auth_user au
+		WHERE au.id = c.user_id
+		AND au.user_uuid = $1
+		AND c.id = $2
+	`
+	res, err := s.db.ExecContext(ctx, q, userUUID, id)
+	if err != nil {
+		return fmt.Errorf("delete collection from db: %w", err)
+	}
+	return requireAffected(res, "collection deletion")
+}
+
+// Move puts links into a collection of a user within a single transaction:
+// the links are taken out of the user's collections and inserted into the target one.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - userUUID: UUID of the user owning the collection and the links
+//   - id: identifier of the target collection; empty to take the links out of any collection
+//   - shortIDs: short identifiers of the links to move
+//
+// Returns:
+//   - error: nil on success, DataNotFoundError if the user has no such collection,
+//     or error if transaction fails
+func (s *DBCollectionStorage) Move(ctx context.Context, userUUID, id string, shortIDs []string) error {
+	trx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() {
+		if err := trx.Rollback(); err != nil {
+			if !errors.Is(err, sql.ErrTxDone) {
+				s.logger.Error("failed to rollback transaction", zap.Error(err))
+			}
+		}
+	}()
+
+	if id != "" {
+		q := `
+			SELECT 1
+			FROM collection c
+			JOIN auth_user au ON au.id = c.user_id
+			WHERE au.user_uuid = $1
+			AND c.id = $2
+			FOR UPDATE OF c
+		`
+		var found int
+		err := trx.QueryRowContext(ctx, q, userUUID, id).Scan(&found)
+		if errors.Is(err, sql.ErrNoRows) {
+			return NewDataNotFoundError(ErrDataNotFoundInDB)
+		} else if err != nil {
+			return fmt.Errorf("lock collection in db: %w", err)
+		}
+	}
+
+	ids := strings.Join(shortIDs, tagSeparator)
+	q := `
+		DELETE FROM collection_url cu
+		USING collection c, auth_user au
+		WHERE c.id = cu.collection_id
+		AND au.id = c.user_id
+		AND au.user_uuid = $1
+		AND cu.short_id = ANY(string_to_array(NULLIF($2, ''), ','))
+	`
+	if _, err := trx.ExecContext(ctx, q, userUUID, ids); err != nil {
+		return fmt.Errorf("take links out of collections in db: %w", err)
+	}
+	if id != "" {
+		q := `
+			INSERT INTO collection_url (short_id, collection_id)
+			SELECT unnest(string_to_array(NULLIF($1, ''), ',')), $2
+			ON CONFLICT (short_id) DO NOTHING
+		`
+		if _, err := trx.ExecContext(ctx, q, ids, id); err != nil {
+			return fmt.Errorf("put links into collection in db: %w", err)
+		}
+	}
+
+	if err := trx.Commit(); err != nil {
+		return fmt.Errorf("commiting transaction: %w", err)
+	}
+	return nil
+}
+
+// scanCollection scans a collection selected by selectCollectionsQuery.
+func scanCollection(row rowScanner) (*model.Collection, error) {
+	var (
+		c     model.Collection
+		links string
+	)
+	if err := row.Scan(&c.ID, &c.UserUUID, &c.Name, &c.CreatedAt, &links); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("scan collection from db: %w", err)
+	}
+	c.ShortIDs = make([]string, 0)
+	if links != "" {
+		c.ShortIDs = strings.Split(links, tagSeparator)
+	}
+	return &c, nil
+}
+
+// requireAffected returns DataNotFoundError if the statement changed no rows.
+func requireAffected(res sql.Result, op string) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("get affected rows of %s: %w", op, err)
+	}
+	if n == 0 {
+		return NewDataNotFoundError(ErrDataNotFoundInDB)
+	}
+	return nil
+}
+
+// isCollectionNameConflict reports whether err is a unique violation of the (user_id, name) constraint.
+func isCollectionNameConflict(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) &&
+		pgErr.Code == pgUniqueViolationCode &&
+		pgErr.ConstraintName == collectionNameUniqueConstraint
+}
+
+// DeletePurged takes purged links out of their collections.
+// For database storage, this is a no-op: links are removed from collection_url by cascade when purged.
+//
+// Returns:
+//   - error: always returns nil
+func (s *DBCollectionStorage) DeletePurged(_ context.Context, _ []string) error {
+	return nil
+}
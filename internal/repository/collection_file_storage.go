@@ -0,0 +1,280 @@
+package repository
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/alex-storchak/shortener/internal/model"
+)
+
+// FileCollectionStorage provides a file-based implementation of CollectionStorage.
+// It keeps collections in memory and persists them to their own file in JSON lines format,
+// one collection with its links per line, rewriting the file on every change.
+// Collections are restored from the file on initialization.
+type FileCollectionStorage struct {
+	logger  *zap.Logger
+	fileMgr URLFileManager
+	index   memCollections
+	mu      *sync.Mutex
+}
+
+// NewFileCollectionStorage creates a new file-based collection storage instance.
+// It automatically restores existing collections from the file on initialization.
+//
+// Parameters:
+//   - logger: structured logger for logging operations
+//   - fm: file manager for the collections file
+//
+// Returns:
+//   - *FileCollectionStorage: configured file-based collection storage
+//   - error: nil on success, or error if file restoration fails
+func NewFileCollectionStorage(logger *zap.Logger, fm URLFileManager) (*FileCollectionStorage, error) {
+	storage := &FileCollectionStorage{
+		logger:  logger,
+		fileMgr: fm,
+		index:   newMemCollections(),
+		mu:      &sync.Mutex{},
+	}
+
+	if err := storage.restoreFromFile(false); err != nil {
+		return nil, fmt.Errorf("restore collections from file: %w", err)
+	}
+	return storage, nil
+}
+
+// Close releases file resources used by the storage.
+//
+// Returns:
+//   - error: nil on success, or error if file closure fails
+func (s *FileCollectionStorage) Close() error {
+	return s.fileMgr.Close()
+}
+
+// GetByUserUUID retrieves all collections of a specific user from file storage.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts (not used)
+//   - userUUID: UUID of the user to retrieve collections for
+//
+// Returns:
+//   - []model.Collection: collections sorted by name
+//   - error: always nil
+func (s *FileCollectionStorage) GetByUserUUID(_ context.Context, userUUID string) ([]model.Collection, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.index.selectByUser(userUUID), nil
+}
+
+// Get retrieves a collection of a user from file storage.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts (not used)
+//   - userUUID: UUID of the user owning the collection
+//   - id: identifier of the collection
+//
+// Returns:
+//   - *model.Collection: found collection
+//   - error: nil on success, or DataNotFoundError if the user has no such collection
+func (s *FileCollectionStorage) Get(_ context.Context, userUUID, id string) (*model.Collection, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.index.get(userUUID, id)
+	if !ok {
+		return nil, NewDataNotFoundError(nil)
+	}
+	return &c, nil
+}
+
+// Create stores a new empty collection in file storage.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts (not used)
+//   - c: collection with its identifier, owner, name and creation moment
+//
+// Returns:
+//   - error: nil on success, ErrCollectionNameConflict if the user has a collection with the same name,
+//     or error if file operations fail
+func (s *FileCollectionStorage) Create(_ context.Context, c model.Collection) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.index.nameTaken(c.UserUUID, c.Name, "") {
+		return ErrCollectionNameConflict
+	}
+	c.ShortIDs = nil
+	s.index.collections[c.ID] = c
+	if err := s.saveToFile(); err != nil {
+		delete(s.index.collections, c.ID)
+		return fmt.Errorf("save collections to file: %w", err)
+	}
+	return nil
+}
+
+// Rename changes the name of a collection of a user in file storage.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts (not used)
+//   - userUUID: UUID of the user owning the collection
+//   - id: identifier of the collection
+//   - name: new name of the collection
+//
+// Returns:
+//   - error: nil on success, DataNotFoundError if the user has no such collection,
+//     ErrCollectionNameConflict if the user has another collection with the same name,
+//     or error if file operations fail
+func (s *FileCollectionStorage) Rename(_ context.Context, userUUID, id, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	prev, ok := s.index.collections[id]
+	if !ok || prev.UserUUID != userUUID {
+		return NewDataNotFoundError(nil)
+	}
+	if s.index.nameTaken(userUUID, name, id) {
+		return ErrCollectionNameConflict
+	}
+	c := prev
+	c.Name = name
+	s.index.collections[id] = c
+	if err := s.saveToFile(); err != nil {
+		s.index.collections[id] = prev
+		return fmt.Errorf("save collections to file: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a collection of a user from file storage, keeping its links.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts (not used)
+//   - userUUID: UUID of the user owning the collection
+//   - id: identifier of the collection
+//
+// Returns:
+//   - error: nil on success, DataNotFoundError if the user has no such collection,
+//     or error if file operations fail
+func (s *FileCollectionStorage) Delete(_ context.Context, userUUID, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if c, ok := s.index.collections[id]; !ok || c.UserUUID != userUUID {
+		return NewDataNotFoundError(nil)
+	}
+	prev := s.index.clone()
+	s.index.remove(id)
+	if err := s.saveToFile(); err != nil {
+		s.index = prev
+		return fmt.Errorf("save collections to file: %w", err)
+	}
+	return nil
+}
+
+// Move puts links into a collection of a user in file storage.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts (not used)
+//   - userUUID: UUID of the user owning the collection and the links
+//   - id: identifier of the target collection; empty to take the links out of any collection
+//   - shortIDs: short identifiers of the links to move
+//
+// Returns:
+//   - error: nil on success, DataNotFoundError if the user has no such collection,
+//     or error if file operations fail
+func (s *FileCollectionStorage) Move(_ context.Context, userUUID, id string, shortIDs []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if c, ok := s.index.collections[id]; id != "" && (!ok || c.UserUUID != userUUID) {
+		return NewDataNotFoundError(nil)
+	}
+	prev := s.index.clone()
+	s.index.move(userUUID, id, shortIDs)
+	if err := s.saveToFile(); err != nil {
+		s.index = prev
+		return fmt.Errorf("save collections to file: %w", err)
+	}
+	return nil
+}
+
+// DeletePurged takes purged links out of the collections in file storage.
+// The file is rewritten only if any of the links was in a collection.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts (not used)
+//   - shortIDs: short identifiers of the purged links
+//
+// Returns:
+//   - error: nil on success, or error if file operations fail
+func (s *FileCollectionStorage) DeletePurged(_ context.Context, shortIDs []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	prev := s.index.clone()
+	if !s.index.unlink(shortIDs) {
+		return nil
+	}
+	if err := s.saveToFile(); err != nil {
+		s.index = prev
+		return fmt.Errorf("save collections to file: %w", err)
+	}
+	return nil
+}
+
+// saveToFile writes all collections with their links to the file, overwriting existing content.
+func (s *FileCollectionStorage) saveToFile() error {
+	if _, err := s.fileMgr.OpenForWrite(false); err != nil {
+		return fmt.Errorf("open collections file for write: %w", err)
+	}
+	defer s.fileMgr.Close()
+
+	for _, c := range s.index.selectByUser("") {
+		data, err := c.ToJSON()
+		if err != nil {
+			return fmt.Errorf("convert collection to json for store: %w", err)
+		}
+		if err := s.fileMgr.WriteData(data); err != nil {
+			return fmt.Errorf("mgr persist collection to file: %w", err)
+		}
+	}
+	return nil
+}
+
+// restoreFromFile reads the collections file and rebuilds the in-memory index.
+// Supports fallback to default file if primary file is unavailable.
+func (s *FileCollectionStorage) restoreFromFile(useDefault bool) error {
+	f, err := s.fileMgr.OpenForAppend(useDefault)
+	if err != nil && !useDefault {
+		s.logger.Warn("failed to restore collections from requested file, trying default: ", zap.Error(err))
+		return s.restoreFromFile(true)
+	} else if err != nil {
+		return fmt.Errorf("open default collections file: %w", err)
+	}
+	defer s.fileMgr.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var c model.Collection
+		if err := c.FromJSON(line); err != nil {
+			return fmt.Errorf("parse collection line `%s`: %w", string(line), err)
+		}
+		for _, shortID := range c.ShortIDs {
+			s.index.links[shortID] = c.ID
+		}
+		c.ShortIDs = nil
+		s.index.collections[c.ID] = c
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("scan collections file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/alex-storchak/shortener/internal/file"
+	"github.com/alex-storchak/shortener/internal/model"
+)
+
+func TestFileCollectionStorage(t *testing.T) {
+	lgr := zap.NewNop()
+	path := filepath.Join(t.TempDir(), "file_db_collections.txt")
+	storage, err := NewFileCollectionStorage(lgr, file.NewManager(path, "", lgr))
+	require.NoError(t, err)
+
+	created := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	collections := []model.Collection{
+		{ID: "c1", UserUUID: "userUUID", Name: "work", CreatedAt: created},
+		{ID: "c2", UserUUID: "userUUID", Name: "blog", CreatedAt: created},
+		{ID: "c3", UserUUID: "otherUUID", Name: "work", CreatedAt: created},
+	}
+	for _, c := range collections {
+		require.NoError(t, storage.Create(t.Context(), c))
+	}
+	require.ErrorIs(t, storage.Create(t.Context(), model.Collection{ID: "c4", UserUUID: "userUUID", Name: "blog"}),
+		ErrCollectionNameConflict)
+	require.ErrorIs(t, storage.Rename(t.Context(), "userUUID", "c2", "work"), ErrCollectionNameConflict)
+	require.NoError(t, storage.Rename(t.Context(), "userUUID", "c2", "news"))
+
+	var nfErr *DataNotFoundError
+	require.ErrorAs(t, storage.Rename(t.Context(), "otherUUID", "c2", "mine"), &nfErr)
+	require.ErrorAs(t, storage.Move(t.Context(), "otherUUID", "c1", []string{"xyz"}), &nfErr)
+
+	require.NoError(t, storage.Move(t.Context(), "userUUID", "c1", []string{"abcde", "fghij", "klmno"}))
+	require.NoError(t, storage.Move(t.Context(), "otherUUID", "c3", []string{"other"}))
+	require.NoError(t, storage.Move(t.Context(), "userUUID", "c2", []string{"fghij", "other"}))
+	require.NoError(t, storage.Move(t.Context(), "userUUID", "", []string{"klmno"}))
+
+	got, err := storage.Get(t.Context(), "userUUID", "c1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"abcde"}, got.ShortIDs)
+	_, err = storage.Get(t.Context(), "otherUUID", "c1")
+	require.ErrorAs(t, err, &nfErr)
+
+	require.NoError(t, storage.Delete(t.Context(), "userUUID", "c1"))
+	require.ErrorAs(t, storage.Delete(t.Context(), "userUUID", "c1"), &nfErr)
+
+	restored, err := NewFileCollectionStorage(lgr, file.NewManager(path, "", lgr))
+	require.NoError(t, err)
+	all, err := restored.GetByUserUUID(t.Context(), "userUUID")
+	require.NoError(t, err)
+	assert.Equal(t, []model.Collection{
+		{ID: "c2", UserUUID: "userUUID", Name: "news", CreatedAt: created, ShortIDs: []string{"fghij"}},
+	}, all)
+	other, err := restored.Get(t.Context(), "otherUUID", "c3")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"other"}, other.ShortIDs, "links in collections of other users are not moved")
+}
+
+func TestFileCollectionStorage_DeletePurged(t *testing.T) {
+	lgr := zap.NewNop()
+	path := filepath.Join(t.TempDir(), "file_db_collections.txt")
+	storage, err := NewFileCollectionStorage(lgr, file.NewManager(path, "", lgr))
+	require.NoError(t, err)
+
+	require.NoError(t, storage.Create(t.Context(), model.Collection{ID: "c1", UserUUID: "userUUID", Name: "work"}))
+	require.NoError(t, storage.Create(t.Context(), model.Collection{ID: "c2", UserUUID: "otherUUID", Name: "work"}))
+	require.NoError(t, storage.Move(t.Context(), "userUUID", "c1", []string{"abcde", "fghij"}))
+	require.NoError(t, storage.Move(t.Context(), "otherUUID", "c2", []string{"other"}))
+	require.NoError(t, storage.DeletePurged(t.Context(), []string{"fghij", "other", "gone"}))
+
+	restored, err := NewFileCollectionStorage(lgr, file.NewManager(path, "", lgr))
+	require.NoError(t, err)
+	got, err := restored.Get(t.Context(), "userUUID", "c1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"abcde"}, got.ShortIDs)
+	got, err = restored.Get(t.Context(), "otherUUID", "c2")
+	require.NoError(t, err)
+	assert.Empty(t, got.ShortIDs, "purged links are taken out of collections of any user")
+}
@@ -0,0 +1,184 @@
+package repository
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/alex-storchak/shortener/internal/model"
+)
+
+// MemoryCollectionStorage provides an in-memory implementation of CollectionStorage.
+// It stores collections in synchronized maps and is suitable for testing
+// or single-instance deployments without persistence requirements.
+type MemoryCollectionStorage struct {
+	logger *zap.Logger
+	index  memCollections
+	mu     *sync.Mutex
+}
+
+// NewMemoryCollectionStorage creates a new in-memory collection storage instance.
+//
+// Parameters:
+//   - logger: structured logger for logging operations
+//
+// Returns:
+//   - *MemoryCollectionStorage: configured in-memory collection storage
+func NewMemoryCollectionStorage(logger *zap.Logger) *MemoryCollectionStorage {
+	return &MemoryCollectionStorage{
+		logger: logger,
+		index:  newMemCollections(),
+		mu:     &sync.Mutex{},
+	}
+}
+
+// Close releases resources used by the memory storage.
+// For in-memory storage, this is a no-op but implements the interface.
+//
+// Returns:
+//   - error: always returns nil
+func (s *MemoryCollectionStorage) Close() error {
+	return nil
+}
+
+// GetByUserUUID retrieves all collections of a specific user from memory storage.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts (not used)
+//   - userUUID: UUID of the user to retrieve collections for
+//
+// Returns:
+//   - []model.Collection: collections sorted by name
+//   - error: always nil
+func (s *MemoryCollectionStorage) GetByUserUUID(_ context.Context, userUUID string) ([]model.Collection, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.index.selectByUser(userUUID), nil
+}
+
+// Get retrieves a collection of a user from memory storage.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts (not used)
+//   - userUUID: UUID of the user owning the collection
+//   - id: identifier of the collection
+//
+// Returns:
+//   - *model.Collection: found collection
+//   - error: nil on success, or DataNotFoundError if the user has no such collection
+func (s *MemoryCollectionStorage) Get(_ context.Context, userUUID, id string) (*model.Collection, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.index.get(userUUID, id)
+	if !ok {
+		return nil, NewDataNotFoundError(nil)
+	}
+	return &c, nil
+}
+
+// Create stores a new empty collection in memory storage.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts (not used)
+//   - c: collection with its identifier, owner, name and creation moment
+//
+// Returns:
+//   - error: nil on success, or ErrCollectionNameConflict if the user has a collection with the same name
+func (s *MemoryCollectionStorage) Create(_ context.Context, c model.Collection) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.index.nameTaken(c.UserUUID, c.Name, "") {
+		return ErrCollectionNameConflict
+	}
+	c.ShortIDs = nil
+	s.index.collections[c.ID] = c
+	return nil
+}
+
+// Rename changes the name of a collection of a user in memory storage.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts (not used)
+//   - userUUID: UUID of the user owning the collection
+//   - id: identifier of the collection
+//   - name: new name of the collection
+//
+// Returns:
+//   - error: nil on success, DataNotFoundError if the user has no such collection,
+//     or ErrCollectionNameConflict if the user has another collection with the same name
+func (s *MemoryCollectionStorage) Rename(_ context.Context, userUUID, id, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.index.collections[id]
+	if !ok || c.UserUUID != userUUID {
+		return NewDataNotFoundError(nil)
+	}
+	if s.index.nameTaken(userUUID, name, id) {
+		return ErrCollectionNameConflict
+	}
+	c.Name = name
+	s.index.collections[id] = c
+	return nil
+}
+
+// Delete removes a collection of a user from memory storage, keeping its links.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts (not used)
+//   - userUUID: UUID of the user owning the collection
+//   - id: identifier of the collection
+//
+// Returns:
+//   - error: nil on success, or DataNotFoundError if the user has no such collection
+func (s *MemoryCollectionStorage) Delete(_ context.Context, userUUID, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if c, ok := s.index.collections[id]; !ok || c.UserUUID != userUUID {
+		return NewDataNotFoundError(nil)
+	}
+	s.index.remove(id)
+	return nil
+}
+
+// Move puts links into a collection of a user in memory storage.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts (not used)
+//   - userUUID: UUID of the user owning the collection and the links
+//   - id: identifier of the target collection; empty to take the links out of any collection
+//   - shortIDs: short identifiers of the links to move
+//
+// Returns:
+//   - error: nil on success, or DataNotFoundError if the user has no such collection
+func (s *MemoryCollectionStorage) Move(_ context.Context, userUUID, id string, shortIDs []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if c, ok := s.index.collections[id]; id != "" && (!ok || c.UserUUID != userUUID) {
+		return NewDataNotFoundError(nil)
+	}
+	s.index.move(userUUID, id, shortIDs)
+	return nil
+}
+
+// DeletePurged takes purged links out of the collections in memory storage.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts (not used)
+//   - shortIDs: short identifiers of the purged links
+//
+// Returns:
+//   - error: always nil
+func (s *MemoryCollectionStorage) DeletePurged(_ context.Context, shortIDs []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.index.unlink(shortIDs)
+	return nil
+}
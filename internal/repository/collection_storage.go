@@ -0,0 +1,225 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"maps"
+	"slices"
+
+	"github.com/alex-storchak/shortener/internal/model"
+)
+
+// CollectionStorage defines the interface for persistence of named collections grouping users' links.
+// A link belongs to at most one collection, and names of collections are unique among collections of a user.
+// Implementations can use different storage backends (memory, file, database).
+type CollectionStorage interface {
+	// GetByUserUUID retrieves all collections of a specific user with the links in them.
+	//
+	// Parameters:
+	//   - ctx: context for cancellation and timeouts
+	//   - userUUID: UUID of the user to retrieve collections for
+	//
+	// Returns:
+	//   - []model.Collection: collections sorted by name
+	//   - error: nil on success, or storage error if operation fails
+	GetByUserUUID(ctx context.Context, userUUID string) ([]model.Collection, error)
+
+	// Get retrieves a collection of a user with the links in it.
+	//
+	// Parameters:
+	//   - ctx: context for cancellation and timeouts
+	//   - userUUID: UUID of the user owning the collection
+	//   - id: identifier of the collection
+	//
+	// Returns:
+	//   - *model.Collection: found collection
+	//   - error: nil on success, DataNotFoundError if the user has no such collection,
+	//     or storage error if operation fails
+	Get(ctx context.Context, userUUID, id string) (*model.Collection, error)
+
+	// Create stores a new empty collection; links of the collection are ignored.
+	//
+	// Parameters:
+	//   - ctx: context for cancellation and timeouts
+	//   - c: collection with its identifier, owner, name and creation moment
+	//
+	// Returns:
+	//   - error: nil on success, ErrCollectionNameConflict if the user has a collection with the same name,
+	//     or storage error if operation fails
+	Create(ctx context.Context, c model.Collection) error
+
+	// Rename changes the name of a collection of a user.
+	//
+	// Parameters:
+	//   - ctx: context for cancellation and timeouts
+	//   - userUUID: UUID of the user owning the collection
+	//   - id: identifier of the collection
+	//   - name: new name of the collection
+	//
+	// Returns:
+	//   - error: nil on success, DataNotFoundError if the user has no such collection,
+	//     ErrCollectionNameConflict if the user has another collection with the same name,
+	//     or storage error if operation fails
+	Rename(ctx context.Context, userUUID, id, name string) error
+
+	// Delete removes a collection of a user. Links of the collection are kept and belong to no collection.
+	//
+	// Parameters:
+	//   - ctx: context for cancellation and timeouts
+	//   - userUUID: UUID of the user owning the collection
+	//   - id: identifier of the collection
+	//
+	// Returns:
+	//   - error: nil on success, DataNotFoundError if the user has no such collection,
+	//     or storage error if operation fails
+	Delete(ctx context.Context, userUUID, id string) error
+
+	// Move puts links into a collection of a user, taking them out of the collections they belonged to.
+	// The caller is responsible for checking that the links belong to the user.
+	//
+	// Parameters:
+	//   - ctx: context for cancellation and timeouts
+	//   - userUUID: UUID of the user owning the collection and the links
+	//   - id: identifier of the target collection; empty to take the links out of any collection
+	//   - shortIDs: short identifiers of the links to move
+	//
+	// Returns:
+	//   - error: nil on success, DataNotFoundError if the user has no such collection,
+	//     or storage error if operation fails
+	Move(ctx context.Context, userUUID, id string, shortIDs []string) error
+
+	// DeletePurged takes purged links out of the collections they belonged to.
+	//
+	// Parameters:
+	//   - ctx: context for cancellation and timeouts
+	//   - shortIDs: short identifiers of the purged links
+	//
+	// Returns:
+	//   - error: nil on success, or storage error if operation fails
+	DeletePurged(ctx context.Context, shortIDs []string) error
+
+	// Close releases any resources used by the storage implementation.
+	//
+	// Returns:
+	//   - error: nil on success, or error if cleanup fails
+	Close() error
+}
+
+// ErrCollectionNameConflict is returned when a user already has a collection with the requested name.
+var ErrCollectionNameConflict = errors.New("collection name already exists")
+
+// memCollections is the in-memory index of collections used by memory and file storages.
+type memCollections struct {
+	collections map[string]model.Collection // Collections by identifier, without links
+	links       map[string]string           // Identifiers of collections by short IDs of the links in them
+}
+
+// newMemCollections creates an empty index.
+func newMemCollections() memCollections {
+	return memCollections{
+		collections: make(map[string]model.Collection),
+		links:       make(map[string]string),
+	}
+}
+
+// get returns the collection of the user with its sorted links.
+func (m memCollections) get(userUUID, id string) (model.Collection, bool) {
+	c, ok := m.collections[id]
+	if !ok || c.UserUUID != userUUID {
+		return model.Collection{}, false
+	}
+	c.ShortIDs = m.linksByCollection()[id]
+	if c.ShortIDs == nil {
+		c.ShortIDs = make([]string, 0)
+	}
+	return c, true
+}
+
+// selectByUser returns the collections of the user with their sorted links, sorted by name.
+// An empty user UUID selects collections of all users, sorted by user UUID first.
+func (m memCollections) selectByUser(userUUID string) []model.Collection {
+	links := m.linksByCollection()
+	res := make([]model.Collection, 0)
+	for id, c := range m.collections {
+		if userUUID != "" && c.UserUUID != userUUID {
+			continue
+		}
+		c.ShortIDs = links[id]
+		if c.ShortIDs == nil {
+			c.ShortIDs = make([]string, 0)
+		}
+		res = append(res, c)
+	}
+	slices.SortFunc(res, func(a, b model.Collection) int {
+		return cmp.Or(cmp.Compare(a.UserUUID, b.UserUUID), cmp.Compare(a.Name, b.Name), cmp.Compare(a.ID, b.ID))
+	})
+	return res
+}
+
+// linksByCollection groups short IDs of the links by identifiers of their collections, sorted.
+func (m memCollections) linksByCollection() map[string][]string {
+	res := make(map[string][]string)
+	for shortID, id := range m.links {
+		res[id] = append(res[id], shortID)
+	}
+	for _, ids := range res {
+		slices.Sort(ids)
+	}
+	return res
+}
+
+// nameTaken reports whether the user has a collection other than the excluded one with the name.
+func (m memCollections) nameTaken(userUUID, name, excludeID string) bool {
+	for _, c := range m.collections {
+		if c.UserUUID == userUUID && c.Name == name && c.ID != excludeID {
+			return true
+		}
+	}
+	return false
+}
+
+// move puts the links into the collection, or takes them out of any collection of the user if id is empty.
+// Links in collections of other users are left untouched.
+func (m memCollections) move(userUUID, id string, shortIDs []string) {
+	for _, shortID := range shortIDs {
+		if cid, ok := m.links[shortID]; ok && m.collections[cid].UserUUID != userUUID {
+			continue
+		}
+		if id == "" {
+			delete(m.links, shortID)
+		} else {
+			m.links[shortID] = id
+		}
+	}
+}
+
+// unlink takes the links out of the collections of any user and reports whether any of them was in a collection.
+func (m memCollections) unlink(shortIDs []string) bool {
+	changed := false
+	for _, shortID := range shortIDs {
+		if _, ok := m.links[shortID]; ok {
+			delete(m.links, shortID)
+			changed = true
+		}
+	}
+	return changed
+}
+
+// remove deletes the collection and takes its links out of it.
+func (m memCollections) remove(id string) {
+	delete(m.collections, id)
+	for shortID, cid := range m.links {
+		if cid == id {
+			delete(m.links, shortID)
+		}
+	}
+}
+
+// clone returns a copy of the index, used to roll back failed changes.
+func (m memCollections) clone() memCollections {
+	return memCollections{
+		collections: maps.Clone(m.collections),
+		links:       maps.Clone(m.links),
+	}
+}
@@ -6,6 +6,10 @@
 // Core abstractions for data persistence:
 //   - URLStorage: interface for URL mapping operations (CRUD, batch, user-specific)
 //   - UserStorage: interface for user data management
+//   - UTMTemplateStorage: interface for UTM templates of users' links
+//   - TargetingRuleStorage: interface for ordered targeting rules of links
+//   - SplitStorage: interface for weighted A/B split tests of links
+//   - CollectionStorage: interface for named collections grouping users' links
 //
 // # Storage Implementations
 //
@@ -14,12 +18,25 @@
 //   - FileURLStorage: file-based persistence with JSON serialization
 //   - DBURLStorage: PostgreSQL-based storage with transaction support
 //   - MemoryUserStorage/FileUserStorage/DBUserStorage: corresponding user storage implementations
+//   - MemoryUTMTemplateStorage/FileUTMTemplateStorage/DBUTMTemplateStorage: corresponding UTM template
+//     storage implementations (utm_template table for database, separate templates file for file storage)
+//   - MemoryTargetingRuleStorage/FileTargetingRuleStorage/DBTargetingRuleStorage: corresponding targeting rule
+//     storage implementations (targeting_rule table for database, separate rules file for file storage)
+//   - MemorySplitStorage/FileSplitStorage/DBSplitStorage: corresponding split test storage implementations
+//     (split_test table for database, separate split tests file for file storage)
+//   - MemoryCollectionStorage/FileCollectionStorage/DBCollectionStorage: corresponding collection storage
+//     implementations (collection and collection_url tables for database, separate collections file for file storage)
 //
 // # Common Patterns
 //
 // The package implements several important patterns:
 //   - DataNotFoundError: standardized error handling for missing data
-//   - Soft deletion: URL records are marked deleted rather than removed
+//   - Soft deletion: URL records are marked deleted rather than removed and can be restored
+//   - URL history: every change of a URL is recorded as a new version
+//     (url_history table for database, append-only history file for file storage)
+//   - Tags: labels of URL records (url_tags table for database, stored in the record otherwise)
+//   - Short ID sequence: persistent counter for sequential short IDs
+//     (short_id_seq sequence for database, separate counter file for file storage)
 //   - Batch operations: efficient processing of multiple items
 //
 // # File Storage Support
@@ -33,6 +50,11 @@
 // Consistent error types across implementations:
 //   - DataNotFoundError: when requested data doesn't exist
 //   - ErrDataDeleted: when accessing soft-deleted URLs
+//   - ErrDataExpired: when accessing URLs whose expiration moment has passed
+//   - ErrClicksExhausted: when accessing click-limited URLs with no follows left
+//   - ErrShortIDConflict: when storing a short ID that is already taken
+//   - ErrOrigURLConflict: when pointing a plain URL at an original URL the user has already shortened as plain
+//   - ErrCollectionNameConflict: when naming a collection like another collection of the same user
 //
 // Package repository provides the data access layer with pluggable storage backends,
 // allowing the application to use memory, file, or database storage based on configuration.
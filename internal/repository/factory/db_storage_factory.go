@@ -52,3 +52,47 @@ func (f *DBStorageFactory) MakeUserStorage() (repository.UserStorage, error) {
 	f.logger.Info("db user storage initialized")
 	return storage, nil
 }
+
+// MakeUTMTemplateStorage creates a new database-based UTM template storage instance.
+//
+// Returns:
+//   - repository.UTMTemplateStorage: database UTM template storage implementation
+//   - error: always returns nil for database storage
+func (f *DBStorageFactory) MakeUTMTemplateStorage() (repository.UTMTemplateStorage, error) {
+	storage := repository.NewDBUTMTemplateStorage(f.logger, f.db)
+	f.logger.Info("db utm template storage initialized")
+	return storage, nil
+}
+
+// MakeTargetingRuleStorage creates a new database-based targeting rule storage instance.
+//
+// Returns:
+//   - repository.TargetingRuleStorage: database targeting rule storage implementation
+//   - error: always returns nil for database storage
+func (f *DBStorageFactory) MakeTargetingRuleStorage() (repository.TargetingRuleStorage, error) {
+	storage := repository.NewDBTargetingRuleStorage(f.logger, f.db)
+	f.logger.Info("db targeting rule storage initialized")
+	return storage, nil
+}
+
+// MakeSplitStorage creates a new database-based split test storage instance.
+//
+// Returns:
+//   - repository.SplitStorage: database split test storage implementation
+//   - error: always returns nil for database storage
+func (f *DBStorageFactory) MakeSplitStorage() (repository.SplitStorage, error) {
+	storage := repository.NewDBSplitStorage(f.logger, f.db)
+	f.logger.Info("db split test storage initialized")
+	return storage, nil
+}
+
+// MakeCollectionStorage creates a new database-based collection storage instance.
+//
+// Returns:
+//   - repository.CollectionStorage: database collection storage implementation
+//   - error: always returns nil for database storage
+func (f *DBStorageFactory) MakeCollectionStorage() (repository.CollectionStorage, error) {
+	storage := repository.NewDBCollectionStorage(f.logger, f.db)
+	f.logger.Info("db collection storage initialized")
+	return storage, nil
+}
@@ -6,6 +6,10 @@
 // The core StorageFactory interface:
 //   - MakeURLStorage(): creates URL storage instances
 //   - MakeUserStorage(): creates user storage instances
+//   - MakeUTMTemplateStorage(): creates UTM template storage instances
+//   - MakeTargetingRuleStorage(): creates targeting rule storage instances
+//   - MakeSplitStorage(): creates split test storage instances
+//   - MakeCollectionStorage(): creates collection storage instances
 //
 // # Factory Implementations
 //
@@ -14,6 +14,12 @@ import (
 // JSON serialization and automatic data restoration on startup.
 type FileStorageFactory struct {
 	fm     *file.Manager
+	hm     *file.Manager
+	sm     *file.Manager
+	tm     *file.Manager
+	rm     *file.Manager
+	pm     *file.Manager
+	cm     *file.Manager
 	ufs    *repository.URLFileScanner
 	logger *zap.Logger
 }
@@ -22,6 +28,12 @@ type FileStorageFactory struct {
 //
 // Parameters:
 //   - fm: file manager for file operations
+//   - hm: file manager for the append-only URL history file
+//   - sm: file manager for the sequential short IDs counter file
+//   - tm: file manager for the UTM templates file
+//   - rm: file manager for the targeting rules file
+//   - pm: file manager for the split tests file
+//   - cm: file manager for the collections file
 //   - ufs: URL file scanner for reading stored data
 //   - logger: structured logger for logging operations
 //
@@ -29,11 +41,23 @@ type FileStorageFactory struct {
 //   - *FileStorageFactory: configured file storage factory
 func NewFileStorageFactory(
 	fm *file.Manager,
+	hm *file.Manager,
+	sm *file.Manager,
+	tm *file.Manager,
+	rm *file.Manager,
+	pm *file.Manager,
+	cm *file.Manager,
 	ufs *repository.URLFileScanner,
 	logger *zap.Logger,
 ) *FileStorageFactory {
 	return &FileStorageFactory{
 		fm:     fm,
+		hm:     hm,
+		sm:     sm,
+		tm:     tm,
+		rm:     rm,
+		pm:     pm,
+		cm:     cm,
 		ufs:    ufs,
 		logger: logger,
 	}
@@ -53,7 +77,7 @@ func NewFileStorageFactory(
 //	}
 //	defer urlStorage.Close()
 func (f *FileStorageFactory) MakeURLStorage() (repository.URLStorage, error) {
-	storage, err := repository.NewFileURLStorage(f.logger, f.fm, f.ufs)
+	storage, err := repository.NewFileURLStorage(f.logger, f.fm, f.hm, f.sm, f.ufs)
 	if err != nil {
 		return nil, fmt.Errorf("instantiate file url storage: %w", err)
 	}
@@ -82,3 +106,59 @@ func (f *FileStorageFactory) MakeUserStorage() (repository.UserStorage, error) {
 	f.logger.Info("file user storage initialized")
 	return storage, nil
 }
+
+// MakeUTMTemplateStorage creates a new file-based UTM template storage instance.
+//
+// Returns:
+//   - repository.UTMTemplateStorage: file-based UTM template storage implementation
+//   - error: nil on success, or error if file restoration fails
+func (f *FileStorageFactory) MakeUTMTemplateStorage() (repository.UTMTemplateStorage, error) {
+	storage, err := repository.NewFileUTMTemplateStorage(f.logger, f.tm)
+	if err != nil {
+		return nil, fmt.Errorf("instantiate file utm template storage: %w", err)
+	}
+	f.logger.Info("file utm template storage initialized")
+	return storage, nil
+}
+
+// MakeTargetingRuleStorage creates a new file-based targeting rule storage instance.
+//
+// Returns:
+//   - repository.TargetingRuleStorage: file-based targeting rule storage implementation
+//   - error: nil on success, or error if file restoration fails
+func (f *FileStorageFactory) MakeTargetingRuleStorage() (repository.TargetingRuleStorage, error) {
+	storage, err := repository.NewFileTargetingRuleStorage(f.logger, f.rm)
+	if err != nil {
+		return nil, fmt.Errorf("instantiate file targeting rule storage: %w", err)
+	}
+	f.logger.Info("file targeting rule storage initialized")
+	return storage, nil
+}
+
+// MakeSplitStorage creates a new file-based split test storage instance.
+//
+// Returns:
+//   - repository.SplitStorage: file-based split test storage implementation
+//   - error: nil on success, or error if file restoration fails
+func (f *FileStorageFactory) MakeSplitStorage() (repository.SplitStorage, error) {
+	storage, err := repository.NewFileSplitStorage(f.logger, f.pm)
+	if err != nil {
+		return nil, fmt.Errorf("instantiate file split test storage: %w", err)
+	}
+	f.logger.Info("file split test storage initialized")
+	return storage, nil
+}
+
+// MakeCollectionStorage creates a new file-based collection storage instance.
+//
+// Returns:
+//   - repository.CollectionStorage: file-based collection storage implementation
+//   - error: nil on success, or error if file restoration fails
+func (f *FileStorageFactory) MakeCollectionStorage() (repository.CollectionStorage, error) {
+	storage, err := repository.NewFileCollectionStorage(f.logger, f.cm)
+	if err != nil {
+		return nil, fmt.Errorf("instantiate file collection storage: %w", err)
+	}
+	f.logger.Info("file collection storage initialized")
+	return storage, nil
+}
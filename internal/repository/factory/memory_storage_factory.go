@@ -47,3 +47,47 @@ func (f *MemoryStorageFactory) MakeUserStorage() (repository.UserStorage, error)
 	f.logger.Info("file user storage initialized")
 	return storage, nil
 }
+
+// MakeUTMTemplateStorage creates a new memory-based UTM template storage instance.
+//
+// Returns:
+//   - repository.UTMTemplateStorage: memory UTM template storage implementation
+//   - error: always returns nil for memory storage
+func (f *MemoryStorageFactory) MakeUTMTemplateStorage() (repository.UTMTemplateStorage, error) {
+	storage := repository.NewMemoryUTMTemplateStorage(f.logger)
+	f.logger.Info("memory utm template storage initialized")
+	return storage, nil
+}
+
+// MakeTargetingRuleStorage creates a new memory-based targeting rule storage instance.
+//
+// Returns:
+//   - repository.TargetingRuleStorage: memory targeting rule storage implementation
+//   - error: always returns nil for memory storage
+func (f *MemoryStorageFactory) MakeTargetingRuleStorage() (repository.TargetingRuleStorage, error) {
+	storage := repository.NewMemoryTargetingRuleStorage(f.logger)
+	f.logger.Info("memory targeting rule storage initialized")
+	return storage, nil
+}
+
+// MakeSplitStorage creates a new memory-based split test storage instance.
+//
+// Returns:
+//   - repository.SplitStorage: memory split test storage implementation
+//   - error: always returns nil for memory storage
+func (f *MemoryStorageFactory) MakeSplitStorage() (repository.SplitStorage, error) {
+	storage := repository.NewMemorySplitStorage(f.logger)
+	f.logger.Info("memory split test storage initialized")
+	return storage, nil
+}
+
+// MakeCollectionStorage creates a new memory-based collection storage instance.
+//
+// Returns:
+//   - repository.CollectionStorage: memory collection storage implementation
+//   - error: always returns nil for memory storage
+func (f *MemoryStorageFactory) MakeCollectionStorage() (repository.CollectionStorage, error) {
+	storage := repository.NewMemoryCollectionStorage(f.logger)
+	f.logger.Info("memory collection storage initialized")
+	return storage, nil
+}
@@ -12,8 +12,27 @@ import (
 	"github.com/alex-storchak/shortener/internal/repository"
 )
 
+// historyFileSuffix is appended to the storage file path to get the path of the URL history file.
+const historyFileSuffix = ".history"
+
+// sequenceFileSuffix is appended to the storage file path to get the path of the sequential short IDs counter file.
+const sequenceFileSuffix = ".seq"
+
+// utmTemplatesFileSuffix is appended to the storage file path to get the path of the UTM templates file.
+const utmTemplatesFileSuffix = ".utm"
+
+// targetingRulesFileSuffix is appended to the storage file path to get the path of the targeting rules file.
+const targetingRulesFileSuffix = ".rules"
+
+// splitTestsFileSuffix is appended to the storage file path to get the path of the split tests file.
+const splitTestsFileSuffix = ".split"
+
+// collectionsFileSuffix is appended to the storage file path to get the path of the collections file.
+const collectionsFileSuffix = ".collections"
+
 // StorageFactory defines the interface for creating storage instances.
-// It provides methods for creating both URL and user storage implementations
+// It provides methods for creating URL, user, UTM template, targeting rule, split test and collection
+// storage implementations
 // with consistent configuration and initialization.
 type StorageFactory interface {
 	// MakeURLStorage creates and initializes a URL storage instance.
@@ -29,6 +48,34 @@ type StorageFactory interface {
 	//   - repository.UserStorage: configured user storage implementation
 	//   - error: nil on success, or error if initialization fails
 	MakeUserStorage() (repository.UserStorage, error)
+
+	// MakeUTMTemplateStorage creates and initializes a UTM template storage instance.
+	//
+	// Returns:
+	//   - repository.UTMTemplateStorage: configured UTM template storage implementation
+	//   - error: nil on success, or error if initialization fails
+	MakeUTMTemplateStorage() (repository.UTMTemplateStorage, error)
+
+	// MakeTargetingRuleStorage creates and initializes a targeting rule storage instance.
+	//
+	// Returns:
+	//   - repository.TargetingRuleStorage: configured targeting rule storage implementation
+	//   - error: nil on success, or error if initialization fails
+	MakeTargetingRuleStorage() (repository.TargetingRuleStorage, error)
+
+	// MakeSplitStorage creates and initializes a split test storage instance.
+	//
+	// Returns:
+	//   - repository.SplitStorage: configured split test storage implementation
+	//   - error: nil on success, or error if initialization fails
+	MakeSplitStorage() (repository.SplitStorage, error)
+
+	// MakeCollectionStorage creates and initializes a collection storage instance.
+	//
+	// Returns:
+	//   - repository.CollectionStorage: configured collection storage implementation
+	//   - error: nil on success, or error if initialization fails
+	MakeCollectionStorage() (repository.CollectionStorage, error)
 }
 
 // NewStorageFactory creates the appropriate storage factory based on configuration.
@@ -89,9 +136,39 @@ func initDBStorageFactory(cfg *config.Config, zl *zap.Logger) (*DBStorageFactory
 // initFileStorageFactory initializes a file storage factory with file manager and scanner.
 func initFileStorageFactory(cfg *config.Config, zl *zap.Logger) (*FileStorageFactory, error) {
 	fm := file.NewManager(cfg.Repo.FileStoragePath, config.DefFileStoragePath, zl)
+	hm := file.NewManager(
+		cfg.Repo.FileStoragePath+historyFileSuffix,
+		config.DefFileStoragePath+historyFileSuffix,
+		zl,
+	)
+	sm := file.NewManager(
+		cfg.Repo.FileStoragePath+sequenceFileSuffix,
+		config.DefFileStoragePath+sequenceFileSuffix,
+		zl,
+	)
+	tm := file.NewManager(
+		cfg.Repo.FileStoragePath+utmTemplatesFileSuffix,
+		config.DefFileStoragePath+utmTemplatesFileSuffix,
+		zl,
+	)
+	rm := file.NewManager(
+		cfg.Repo.FileStoragePath+targetingRulesFileSuffix,
+		config.DefFileStoragePath+targetingRulesFileSuffix,
+		zl,
+	)
+	pm := file.NewManager(
+		cfg.Repo.FileStoragePath+splitTestsFileSuffix,
+		config.DefFileStoragePath+splitTestsFileSuffix,
+		zl,
+	)
+	cm := file.NewManager(
+		cfg.Repo.FileStoragePath+collectionsFileSuffix,
+		config.DefFileStoragePath+collectionsFileSuffix,
+		zl,
+	)
 	frp := repository.URLFileRecordParser{}
 	fs := repository.NewFileScanner(zl, frp)
-	sf := NewFileStorageFactory(fm, fs, zl)
+	sf := NewFileStorageFactory(fm, hm, sm, tm, rm, pm, cm, fs, zl)
 	zl.Info("file storage factory initialized")
 	return sf, nil
 }
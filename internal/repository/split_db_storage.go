@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/alex-storchak/shortener/internal/model"
+)
+
+// DBSplitStorage provides a PostgreSQL implementation of SplitStorage.
+// The split test of a link is kept in a single row of the split_test table with variants as a JSONB array.
+// Split tests are removed by cascade when their link is purged from url_storage.
+type DBSplitStorage struct {
+	logger *zap.Logger
+	db     *sql.DB
+}
+
+// NewDBSplitStorage creates a new database split test storage instance.
+//
+// Parameters:
+//   - logger: structured logger for logging operations
+//   - db: database connection
+//
+// Returns:
+//   - *DBSplitStorage: configured database split test storage
+func NewDBSplitStorage(logger *zap.Logger, db *sql.DB) *DBSplitStorage {
+	return &DBSplitStorage{
+		logger: logger,
+		db:     db,
+	}
+}
+
+// Close closes the database connection.
+//
+// Returns:
+//   - error: nil on success, or error if connection closure fails
+func (s *DBSplitStorage) Close() error {
+	return s.db.Close()
+}
+
+// Get retrieves the split test of a link from the split_test table.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - shortID: short identifier of the link
+//
+// Returns:
+//   - model.Split: split test of the link; without variants if the link has no split test
+//   - error: nil on success, or error if the query fails
+func (s *DBSplitStorage) Get(ctx context.Context, shortID string) (model.Split, error) {
+	q := `SELECT sticky, variants FROM split_test WHERE short_id = $1`
+	split := model.Split{ShortID: shortID}
+	var data []byte
+	err := s.db.QueryRowContext(ctx, q, shortID).Scan(&split.Sticky, &data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return split, nil
+	} else if err != nil {
+		return split, fmt.Errorf("query split test from db: %w", err)
+	}
+	if err := json.Unmarshal(data, &split.Variants); err != nil {
+		return split, fmt.Errorf("unmarshal split test variants: %w", err)
+	}
+	return split, nil
+}
+
+// Set replaces the split test of a link in the split_test table. A split test without variants removes it.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - split: split test with the short identifier of the link
+//
+// Returns:
+//   - error: nil on success, or error if the query fails
+func (s *DBSplitStorage) Set(ctx context.Context, split model.Split) error {
+	if len(split.Variants) == 0 {
+		if _, err := s.db.ExecContext(ctx, `DELETE FROM split_test WHERE short_id = $1`, split.ShortID); err != nil {
+			return fmt.Errorf("delete split test from db: %w", err)
+		}
+		return nil
+	}
+
+	data, err := json.Marshal(split.Variants)
+	if err != nil {
+		return fmt.Errorf("marshal split test variants: %w", err)
+	}
+	q := `
+		INSERT INTO split_test (short_id, sticky, variants)
+		VALUES ($1, $2, $3::jsonb)
+		ON CONFLICT (short_id) DO UPDATE
+		SET sticky = EXCLUDED.sticky, variants = EXCLUDED.variants, updated_at = NOW()
+	`
+	if _, err := s.db.ExecContext(ctx, q, split.ShortID, split.Sticky, string(data)); err != nil {
+		return fmt.Errorf("persist split test to db: %w", err)
+	}
+	return nil
+}
+
+// DeletePurged removes the split tests of purged links.
+// For database storage, this is a no-op: split tests are removed by cascade together with their links.
+//
+// Returns:
+//   - error: always returns nil
+func (s *DBSplitStorage) DeletePurged(_ context.Context, _ []string) error {
+	return nil
+}
@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/alex-storchak/shortener/internal/model"
+)
+
+// FileSplitStorage provides a file-based implementation of SplitStorage.
+// It keeps split tests in memory and persists them to their own file in JSON lines format,
+// one line per link, rewriting the file on every change. Split tests are restored from the file on initialization.
+type FileSplitStorage struct {
+	logger  *zap.Logger
+	fileMgr URLFileManager
+	splits  map[string]model.Split
+	mu      *sync.RWMutex
+}
+
+// NewFileSplitStorage creates a new file-based split test storage instance.
+// It automatically restores existing split tests from the file on initialization.
+//
+// Parameters:
+//   - logger: structured logger for logging operations
+//   - fm: file manager for the split tests file
+//
+// Returns:
+//   - *FileSplitStorage: configured file-based split test storage
+//   - error: nil on success, or error if file restoration fails
+func NewFileSplitStorage(logger *zap.Logger, fm URLFileManager) (*FileSplitStorage, error) {
+	storage := &FileSplitStorage{
+		logger:  logger,
+		fileMgr: fm,
+		splits:  make(map[string]model.Split),
+		mu:      &sync.RWMutex{},
+	}
+
+	if err := storage.restoreFromFile(false); err != nil {
+		return nil, fmt.Errorf("restore split tests from file: %w", err)
+	}
+	return storage, nil
+}
+
+// Close releases file resources used by the storage.
+//
+// Returns:
+//   - error: nil on success, or error if file closure fails
+func (s *FileSplitStorage) Close() error {
+	return s.fileMgr.Close()
+}
+
+// Get retrieves the split test of a link from file storage.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts (not used)
+//   - shortID: short identifier of the link
+//
+// Returns:
+//   - model.Split: split test of the link; without variants if the link has no split test
+//   - error: always nil
+func (s *FileSplitStorage) Get(_ context.Context, shortID string) (model.Split, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return getMemSplit(s.splits, shortID), nil
+}
+
+// Set replaces the split test of a link in file storage. A split test without variants removes it.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts (not used)
+//   - split: split test with the short identifier of the link
+//
+// Returns:
+//   - error: nil on success, or error if file operations fail
+func (s *FileSplitStorage) Set(_ context.Context, split model.Split) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	prev, existed := s.splits[split.ShortID]
+	setMemSplit(s.splits, split)
+	if err := s.saveToFile(); err != nil {
+		if existed {
+			s.splits[split.ShortID] = prev
+		} else {
+			delete(s.splits, split.ShortID)
+		}
+		return fmt.Errorf("save split tests to file: %w", err)
+	}
+	return nil
+}
+
+// DeletePurged removes the split tests of purged links from file storage.
+// The file is rewritten only if any of the links had a split test.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts (not used)
+//   - shortIDs: short identifiers of the purged links
+//
+// Returns:
+//   - error: nil on success, or error if file operations fail
+func (s *FileSplitStorage) DeletePurged(_ context.Context, shortIDs []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	prev := make(map[string]model.Split)
+	for _, id := range shortIDs {
+		if split, ok := s.splits[id]; ok {
+			prev[id] = split
+			delete(s.splits, id)
+		}
+	}
+	if len(prev) == 0 {
+		return nil
+	}
+	if err := s.saveToFile(); err != nil {
+		maps.Copy(s.splits, prev)
+		return fmt.Errorf("save split tests to file: %w", err)
+	}
+	return nil
+}
+
+// saveToFile writes the split tests of all links to the file sorted by short ID, overwriting existing content.
+func (s *FileSplitStorage) saveToFile() error {
+	if _, err := s.fileMgr.OpenForWrite(false); err != nil {
+		return fmt.Errorf("open split tests file for write: %w", err)
+	}
+	defer s.fileMgr.Close()
+
+	for _, shortID := range slices.Sorted(maps.Keys(s.splits)) {
+		split := s.splits[shortID]
+		data, err := split.ToJSON()
+		if err != nil {
+			return fmt.Errorf("convert split test to json for store: %w", err)
+		}
+		if err := s.fileMgr.WriteData(data); err != nil {
+			return fmt.Errorf("mgr persist split test to file: %w", err)
+		}
+	}
+	return nil
+}
+
+// restoreFromFile reads the split tests file and rebuilds the in-memory index.
+// Supports fallback to default file if primary file is unavailable.
+func (s *FileSplitStorage) restoreFromFile(useDefault bool) error {
+	f, err := s.fileMgr.OpenForAppend(useDefault)
+	if err != nil && !useDefault {
+		s.logger.Warn("failed to restore split tests from requested file, trying default: ", zap.Error(err))
+		return s.restoreFromFile(true)
+	} else if err != nil {
+		return fmt.Errorf("open default split tests file: %w", err)
+	}
+	defer s.fileMgr.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var split model.Split
+		if err := split.FromJSON(line); err != nil {
+			return fmt.Errorf("parse split test line `%s`: %w", string(line), err)
+		}
+		setMemSplit(s.splits, split)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("scan split tests file: %w", err)
+	}
+	return nil
+}
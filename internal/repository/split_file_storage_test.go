@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/alex-storchak/shortener/internal/file"
+	"github.com/alex-storchak/shortener/internal/model"
+)
+
+func TestFileSplitStorage(t *testing.T) {
+	lgr := zap.NewNop()
+	path := filepath.Join(t.TempDir(), "file_db_split.txt")
+	storage, err := NewFileSplitStorage(lgr, file.NewManager(path, "", lgr))
+	require.NoError(t, err)
+
+	landing := model.Split{
+		ShortID: "landing",
+		Sticky:  true,
+		Variants: []model.SplitVariant{
+			{Name: "a", URL: "https://example.com/a", Weight: 70},
+			{Name: "b", URL: "https://example.com/b", Weight: 30},
+		},
+	}
+	promo := model.Split{
+		ShortID: "promo",
+		Variants: []model.SplitVariant{
+			{Name: "old", URL: "https://example.com/old", Weight: 1},
+			{Name: "new", URL: "https://example.com/new", Weight: 1},
+		},
+	}
+	require.NoError(t, storage.Set(t.Context(), landing))
+	require.NoError(t, storage.Set(t.Context(), promo))
+	require.NoError(t, storage.Set(t.Context(), model.Split{ShortID: "promo"}))
+
+	got, err := storage.Get(t.Context(), "landing")
+	require.NoError(t, err)
+	assert.Equal(t, landing, got)
+
+	restored, err := NewFileSplitStorage(lgr, file.NewManager(path, "", lgr))
+	require.NoError(t, err)
+	got, err = restored.Get(t.Context(), "landing")
+	require.NoError(t, err)
+	assert.Equal(t, landing, got)
+	got, err = restored.Get(t.Context(), "promo")
+	require.NoError(t, err)
+	assert.Equal(t, model.Split{ShortID: "promo"}, got)
+}
+
+func TestFileSplitStorage_DeletePurged(t *testing.T) {
+	lgr := zap.NewNop()
+	path := filepath.Join(t.TempDir(), "file_db_split.txt")
+	storage, err := NewFileSplitStorage(lgr, file.NewManager(path, "", lgr))
+	require.NoError(t, err)
+
+	variants := []model.SplitVariant{
+		{Name: "a", URL: "https://example.com/a", Weight: 1},
+		{Name: "b", URL: "https://example.com/b", Weight: 1},
+	}
+	landing := model.Split{ShortID: "landing", Variants: variants}
+	require.NoError(t, storage.Set(t.Context(), landing))
+	require.NoError(t, storage.Set(t.Context(), model.Split{ShortID: "promo", Variants: variants}))
+	require.NoError(t, storage.DeletePurged(t.Context(), []string{"promo", "gone"}))
+
+	restored, err := NewFileSplitStorage(lgr, file.NewManager(path, "", lgr))
+	require.NoError(t, err)
+	got, err := restored.Get(t.Context(), "promo")
+	require.NoError(t, err)
+	assert.Equal(t, model.Split{ShortID: "promo"}, got)
+	got, err = restored.Get(t.Context(), "landing")
+	require.NoError(t, err)
+	assert.Equal(t, landing, got)
+}
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/alex-storchak/shortener/internal/model"
+)
+
+// MemorySplitStorage provides an in-memory implementation of SplitStorage.
+// It stores split tests in a synchronized map and is suitable for testing
+// or single-instance deployments without persistence requirements.
+type MemorySplitStorage struct {
+	logger *zap.Logger
+	splits map[string]model.Split
+	mu     *sync.RWMutex
+}
+
+// NewMemorySplitStorage creates a new in-memory split test storage instance.
+//
+// Parameters:
+//   - logger: structured logger for logging operations
+//
+// Returns:
+//   - *MemorySplitStorage: configured in-memory split test storage
+func NewMemorySplitStorage(logger *zap.Logger) *MemorySplitStorage {
+	return &MemorySplitStorage{
+		logger: logger,
+		splits: make(map[string]model.Split),
+		mu:     &sync.RWMutex{},
+	}
+}
+
+// Close releases resources used by the memory storage.
+// For in-memory storage, this is a no-op but implements the interface.
+//
+// Returns:
+//   - error: always returns nil
+func (s *MemorySplitStorage) Close() error {
+	return nil
+}
+
+// Get retrieves the split test of a link from memory storage.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts (not used)
+//   - shortID: short identifier of the link
+//
+// Returns:
+//   - model.Split: split test of the link; without variants if the link has no split test
+//   - error: always nil
+func (s *MemorySplitStorage) Get(_ context.Context, shortID string) (model.Split, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return getMemSplit(s.splits, shortID), nil
+}
+
+// Set replaces the split test of a link in memory storage. A split test without variants removes it.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts (not used)
+//   - split: split test with the short identifier of the link
+//
+// Returns:
+//   - error: always nil
+func (s *MemorySplitStorage) Set(_ context.Context, split model.Split) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	setMemSplit(s.splits, split)
+	return nil
+}
+
+// DeletePurged removes the split tests of purged links from memory storage.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts (not used)
+//   - shortIDs: short identifiers of the purged links
+//
+// Returns:
+//   - error: always nil
+func (s *MemorySplitStorage) DeletePurged(_ context.Context, shortIDs []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, id := range shortIDs {
+		delete(s.splits, id)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"slices"
+
+	"github.com/alex-storchak/shortener/internal/model"
+)
+
+// SplitStorage defines the interface for persistence of A/B split tests of links.
+// A link has at most one split test, replaced as a whole.
+// Implementations can use different storage backends (memory, file, database).
+type SplitStorage interface {
+	// Get retrieves the split test of a link.
+	//
+	// Parameters:
+	//   - ctx: context for cancellation and timeouts
+	//   - shortID: short identifier of the link
+	//
+	// Returns:
+	//   - model.Split: split test of the link; without variants if the link has no split test
+	//   - error: nil on success, or storage error if operation fails
+	Get(ctx context.Context, shortID string) (model.Split, error)
+
+	// Set replaces the split test of a link. A split test without variants removes it.
+	//
+	// Parameters:
+	//   - ctx: context for cancellation and timeouts
+	//   - split: split test with the short identifier of the link
+	//
+	// Returns:
+	//   - error: nil on success, or storage error if operation fails
+	Set(ctx context.Context, split model.Split) error
+
+	// DeletePurged removes the split tests of purged links.
+	//
+	// Parameters:
+	//   - ctx: context for cancellation and timeouts
+	//   - shortIDs: short identifiers of the purged links
+	//
+	// Returns:
+	//   - error: nil on success, or storage error if operation fails
+	DeletePurged(ctx context.Context, shortIDs []string) error
+
+	// Close releases any resources used by the storage implementation.
+	//
+	// Returns:
+	//   - error: nil on success, or error if cleanup fails
+	Close() error
+}
+
+// setMemSplit replaces the split test of the link in the map, removing the link if the test has no variants.
+func setMemSplit(splits map[string]model.Split, split model.Split) {
+	if len(split.Variants) == 0 {
+		delete(splits, split.ShortID)
+		return
+	}
+	split.Variants = slices.Clone(split.Variants)
+	splits[split.ShortID] = split
+}
+
+// getMemSplit returns a copy of the split test of the link from the map.
+func getMemSplit(splits map[string]model.Split, shortID string) model.Split {
+	split, ok := splits[shortID]
+	if !ok {
+		return model.Split{ShortID: shortID}
+	}
+	split.Variants = slices.Clone(split.Variants)
+	return split
+}
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/alex-storchak/shortener/internal/model"
+)
+
+// DBTargetingRuleStorage provides a PostgreSQL implementation of TargetingRuleStorage.
+// Rules of a link are kept in a single row of the targeting_rule table as a JSONB array.
+// Rules are removed by cascade when their link is purged from url_storage.
+type DBTargetingRuleStorage struct {
+	logger *zap.Logger
+	db     *sql.DB
+}
+
+// NewDBTargetingRuleStorage creates a new database targeting rule storage instance.
+//
+// Parameters:
+//   - logger: structured logger for logging operations
+//   - db: database connection
+//
+// Returns:
+//   - *DBTargetingRuleStorage: configured database targeting rule storage
+func NewDBTargetingRuleStorage(logger *zap.Logger, db *sql.DB) *DBTargetingRuleStorage {
+	return &DBTargetingRuleStorage{
+		logger: logger,
+		db:     db,
+	}
+}
+
+// Close closes the database connection.
+//
+// Returns:
+//   - error: nil on success, or error if connection closure fails
+func (s *DBTargetingRuleStorage) Close() error {
+	return s.db.Close()
+}
+
+// Get retrieves the targeting rules of a link from the targeting_rule table.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - shortID: short identifier of the link
+//
+// Returns:
+//   - []model.TargetingRule: rules in evaluation order; empty if the link has no rules
+//   - error: nil on success, or error if the query fails
+func (s *DBTargetingRuleStorage) Get(ctx context.Context, shortID string) ([]model.TargetingRule, error) {
+	q := `SELECT rules FROM targeting_rule WHERE short_id = $1`
+	var data []byte
+	err := s.db.QueryRowContext(ctx, q, shortID).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("query targeting rules from db: %w", err)
+	}
+	var rules []model.TargetingRule
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("unmarshal targeting rules: %w", err)
+	}
+	return rules, nil
+}
+
+// Set replaces the targeting rules of a link in the targeting_rule table. An empty list removes the rules.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - shortID: short identifier of the link
+//   - rules: rules in evaluation order
+//
+// Returns:
+//   - error: nil on success, or error if the query fails
+func (s *DBTargetingRuleStorage) Set(ctx context.Context, shortID string, rules []model.TargetingRule) error {
+	if len(rules) == 0 {
+		if _, err := s.db.ExecContext(ctx, `DELETE FROM targeting_rule WHERE short_id = $1`, shortID); err != nil {
+			return fmt.Errorf("delete targeting rules from db: %w", err)
+		}
+		return nil
+	}
+
+	data, err := json.Marshal(rules)
+	if err != nil {
+		return fmt.Errorf("marshal targeting rules: %w", err)
+	}
+	q := `
+		INSERT INTO targeting_rule (short_id, rules)
+		VALUES ($1, $2::jsonb)
+		ON CONFLICT (short_id) DO UPDATE SET rules = EXCLUDED.rules, updated_at = NOW()
+	`
+	if _, err := s.db.ExecContext(ctx, q, shortID, string(data)); err != nil {
+		return fmt.Errorf("persist targeting rules to db: %w", err)
+	}
+	return nil
+}
+
+// DeletePurged removes the targeting rules of purged links.
+// For database storage, this is a no-op: rules are removed by cascade together with their links.
+//
+// Returns:
+//   - error: always returns nil
+func (s *DBTargetingRuleStorage) DeletePurged(_ context.Context, _ []string) error {
+	return nil
+}
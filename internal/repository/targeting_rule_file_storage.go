@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/alex-storchak/shortener/internal/model"
+)
+
+// FileTargetingRuleStorage provides a file-based implementation of TargetingRuleStorage.
+// It keeps rules in memory and persists them to their own file in JSON lines format,
+// one line per link, rewriting the file on every change. Rules are restored from the file on initialization.
+type FileTargetingRuleStorage struct {
+	logger  *zap.Logger
+	fileMgr URLFileManager
+	rules   map[string][]model.TargetingRule
+	mu      *sync.RWMutex
+}
+
+// NewFileTargetingRuleStorage creates a new file-based targeting rule storage instance.
+// It automatically restores existing rules from the file on initialization.
+//
+// Parameters:
+//   - logger: structured logger for logging operations
+//   - fm: file manager for the targeting rules file
+//
+// Returns:
+//   - *FileTargetingRuleStorage: configured file-based targeting rule storage
+//   - error: nil on success, or error if file restoration fails
+func NewFileTargetingRuleStorage(logger *zap.Logger, fm URLFileManager) (*FileTargetingRuleStorage, error) {
+	storage := &FileTargetingRuleStorage{
+		logger:  logger,
+		fileMgr: fm,
+		rules:   make(map[string][]model.TargetingRule),
+		mu:      &sync.RWMutex{},
+	}
+
+	if err := storage.restoreFromFile(false); err != nil {
+		return nil, fmt.Errorf("restore targeting rules from file: %w", err)
+	}
+	return storage, nil
+}
+
+// Close releases file resources used by the storage.
+//
+// Returns:
+//   - error: nil on success, or error if file closure fails
+func (s *FileTargetingRuleStorage) Close() error {
+	return s.fileMgr.Close()
+}
+
+// Get retrieves the targeting rules of a link from file storage.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts (not used)
+//   - shortID: short identifier of the link
+//
+// Returns:
+//   - []model.TargetingRule: rules in evaluation order; empty if the link has no rules
+//   - error: always nil
+func (s *FileTargetingRuleStorage) Get(_ context.Context, shortID string) ([]model.TargetingRule, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return slices.Clone(s.rules[shortID]), nil
+}
+
+// Set replaces the targeting rules of a link in file storage. An empty list removes the rules.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts (not used)
+//   - shortID: short identifier of the link
+//   - rules: rules in evaluation order
+//
+// Returns:
+//   - error: nil on success, or error if file operations fail
+func (s *FileTargetingRuleStorage) Set(_ context.Context, shortID string, rules []model.TargetingRule) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	prev, existed := s.rules[shortID]
+	setMemTargetingRules(s.rules, shortID, rules)
+	if err := s.saveToFile(); err != nil {
+		if existed {
+			s.rules[shortID] = prev
+		} else {
+			delete(s.rules, shortID)
+		}
+		return fmt.Errorf("save targeting rules to file: %w", err)
+	}
+	return nil
+}
+
+// DeletePurged removes the targeting rules of purged links from file storage.
+// The file is rewritten only if any of the links had rules.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts (not used)
+//   - shortIDs: short identifiers of the purged links
+//
+// Returns:
+//   - error: nil on success, or error if file operations fail
+func (s *FileTargetingRuleStorage) DeletePurged(_ context.Context, shortIDs []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	prev := make(map[string][]model.TargetingRule)
+	for _, id := range shortIDs {
+		if rules, ok := s.rules[id]; ok {
+			prev[id] = rules
+			delete(s.rules, id)
+		}
+	}
+	if len(prev) == 0 {
+		return nil
+	}
+	if err := s.saveToFile(); err != nil {
+		maps.Copy(s.rules, prev)
+		return fmt.Errorf("save targeting rules to file: %w", err)
+	}
+	return nil
+}
+
+// saveToFile writes the rules of all links to the file sorted by short ID, overwriting existing content.
+func (s *FileTargetingRuleStorage) saveToFile() error {
+	if _, err := s.fileMgr.OpenForWrite(false); err != nil {
+		return fmt.Errorf("open targeting rules file for write: %w", err)
+	}
+	defer s.fileMgr.Close()
+
+	for _, shortID := range slices.Sorted(maps.Keys(s.rules)) {
+		r := model.TargetingRules{ShortID: shortID, Rules: s.rules[shortID]}
+		data, err := r.ToJSON()
+		if err != nil {
+			return fmt.Errorf("convert targeting rules to json for store: %w", err)
+		}
+		if err := s.fileMgr.WriteData(data); err != nil {
+			return fmt.Errorf("mgr persist targeting rules to file: %w", err)
+		}
+	}
+	return nil
+}
+
+// restoreFromFile reads the targeting rules file and rebuilds the in-memory index.
+// Supports fallback to default file if primary file is unavailable.
+func (s *FileTargetingRuleStorage) restoreFromFile(useDefault bool) error {
+	f, err := s.fileMgr.OpenForAppend(useDefault)
+	if err != nil && !useDefault {
+		s.logger.Warn("failed to restore targeting rules from requested file, trying default: ", zap.Error(err))
+		return s.restoreFromFile(true)
+	} else if err != nil {
+		return fmt.Errorf("open default targeting rules file: %w", err)
+	}
+	defer s.fileMgr.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var r model.TargetingRules
+		if err := r.FromJSON(line); err != nil {
+			return fmt.Errorf("parse targeting rules line `%s`: %w", string(line), err)
+		}
+		setMemTargetingRules(s.rules, r.ShortID, r.Rules)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("scan targeting rules file: %w", err)
+	}
+	return nil
+}
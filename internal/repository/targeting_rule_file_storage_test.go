@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/alex-storchak/shortener/internal/file"
+	"github.com/alex-storchak/shortener/internal/model"
+)
+
+func TestFileTargetingRuleStorage(t *testing.T) {
+	lgr := zap.NewNop()
+	path := filepath.Join(t.TempDir(), "file_db_rules.txt")
+	storage, err := NewFileTargetingRuleStorage(lgr, file.NewManager(path, "", lgr))
+	require.NoError(t, err)
+
+	appRules := []model.TargetingRule{
+		{OS: "ios", URL: "https://apps.apple.com/app"},
+		{OS: "android", URL: "https://play.google.com/app"},
+	}
+	require.NoError(t, storage.Set(t.Context(), "app", appRules))
+	require.NoError(t, storage.Set(t.Context(), "site", []model.TargetingRule{{Language: "de", URL: "https://example.de"}}))
+	require.NoError(t, storage.Set(t.Context(), "promo", []model.TargetingRule{{Browser: "bot", URL: "https://example.com/bot"}}))
+	require.NoError(t, storage.Set(t.Context(), "promo", nil))
+
+	got, err := storage.Get(t.Context(), "app")
+	require.NoError(t, err)
+	assert.Equal(t, appRules, got)
+
+	restored, err := NewFileTargetingRuleStorage(lgr, file.NewManager(path, "", lgr))
+	require.NoError(t, err)
+	got, err = restored.Get(t.Context(), "app")
+	require.NoError(t, err)
+	assert.Equal(t, appRules, got)
+	got, err = restored.Get(t.Context(), "site")
+	require.NoError(t, err)
+	assert.Equal(t, []model.TargetingRule{{Language: "de", URL: "https://example.de"}}, got)
+	got, err = restored.Get(t.Context(), "promo")
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func TestFileTargetingRuleStorage_DeletePurged(t *testing.T) {
+	lgr := zap.NewNop()
+	path := filepath.Join(t.TempDir(), "file_db_rules.txt")
+	storage, err := NewFileTargetingRuleStorage(lgr, file.NewManager(path, "", lgr))
+	require.NoError(t, err)
+
+	siteRules := []model.TargetingRule{{Language: "de", URL: "https://example.de"}}
+	require.NoError(t, storage.Set(t.Context(), "app", []model.TargetingRule{{OS: "ios", URL: "https://apps.apple.com/app"}}))
+	require.NoError(t, storage.Set(t.Context(), "site", siteRules))
+	require.NoError(t, storage.DeletePurged(t.Context(), []string{"app", "gone"}))
+
+	restored, err := NewFileTargetingRuleStorage(lgr, file.NewManager(path, "", lgr))
+	require.NoError(t, err)
+	got, err := restored.Get(t.Context(), "app")
+	require.NoError(t, err)
+	assert.Empty(t, got)
+	got, err = restored.Get(t.Context(), "site")
+	require.NoError(t, err)
+	assert.Equal(t, siteRules, got)
+}
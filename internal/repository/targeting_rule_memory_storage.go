@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/alex-storchak/shortener/internal/model"
+)
+
+// MemoryTargetingRuleStorage provides an in-memory implementation of TargetingRuleStorage.
+// It stores rules in a synchronized map and is suitable for testing
+// or single-instance deployments without persistence requirements.
+type MemoryTargetingRuleStorage struct {
+	logger *zap.Logger
+	rules  map[string][]model.TargetingRule
+	mu     *sync.RWMutex
+}
+
+// NewMemoryTargetingRuleStorage creates a new in-memory targeting rule storage instance.
+//
+// Parameters:
+//   - logger: structured logger for logging operations
+//
+// Returns:
+//   - *MemoryTargetingRuleStorage: configured in-memory targeting rule storage
+func NewMemoryTargetingRuleStorage(logger *zap.Logger) *MemoryTargetingRuleStorage {
+	return &MemoryTargetingRuleStorage{
+		logger: logger,
+		rules:  make(map[string][]model.TargetingRule),
+		mu:     &sync.RWMutex{},
+	}
+}
+
+// Close releases resources used by the memory storage.
+// For in-memory storage, this is a no-op but implements the interface.
+//
+// Returns:
+//   - error: always returns nil
+func (s *MemoryTargetingRuleStorage) Close() error {
+	return nil
+}
+
+// Get retrieves the targeting rules of a link from memory storage.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts (not used)
+//   - shortID: short identifier of the link
+//
+// Returns:
+//   - []model.TargetingRule: rules in evaluation order; empty if the link has no rules
+//   - error: always nil
+func (s *MemoryTargetingRuleStorage) Get(_ context.Context, shortID string) ([]model.TargetingRule, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return slices.Clone(s.rules[shortID]), nil
+}
+
+// Set replaces the targeting rules of a link in memory storage. An empty list removes the rules.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts (not used)
+//   - shortID: short identifier of the link
+//   - rules: rules in evaluation order
+//
+// Returns:
+//   - error: always nil
+func (s *MemoryTargetingRuleStorage) Set(_ context.Context, shortID string, rules []model.TargetingRule) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	setMemTargetingRules(s.rules, shortID, rules)
+	return nil
+}
+
+// DeletePurged removes the targeting rules of purged links from memory storage.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts (not used)
+//   - shortIDs: short identifiers of the purged links
+//
+// Returns:
+//   - error: always nil
+func (s *MemoryTargetingRuleStorage) DeletePurged(_ context.Context, shortIDs []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, id := range shortIDs {
+		delete(s.rules, id)
+	}
+	return nil
+}
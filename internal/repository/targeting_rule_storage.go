@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"slices"
+
+	"github.com/alex-storchak/shortener/internal/model"
+)
+
+// TargetingRuleStorage defines the interface for persistence of targeting rules of links.
+// A link has at most one ordered list of rules, replaced as a whole.
+// Implementations can use different storage backends (memory, file, database).
+type TargetingRuleStorage interface {
+	// Get retrieves the targeting rules of a link.
+	//
+	// Parameters:
+	//   - ctx: context for cancellation and timeouts
+	//   - shortID: short identifier of the link
+	//
+	// Returns:
+	//   - []model.TargetingRule: rules in evaluation order; empty if the link has no rules
+	//   - error: nil on success, or storage error if operation fails
+	Get(ctx context.Context, shortID string) ([]model.TargetingRule, error)
+
+	// Set replaces the targeting rules of a link. An empty list removes the rules.
+	//
+	// Parameters:
+	//   - ctx: context for cancellation and timeouts
+	//   - shortID: short identifier of the link
+	//   - rules: rules in evaluation order
+	//
+	// Returns:
+	//   - error: nil on success, or storage error if operation fails
+	Set(ctx context.Context, shortID string, rules []model.TargetingRule) error
+
+	// DeletePurged removes the targeting rules of purged links.
+	//
+	// Parameters:
+	//   - ctx: context for cancellation and timeouts
+	//   - shortIDs: short identifiers of the purged links
+	//
+	// Returns:
+	//   - error: nil on success, or storage error if operation fails
+	DeletePurged(ctx context.Context, shortIDs []string) error
+
+	// Close releases any resources used by the storage implementation.
+	//
+	// Returns:
+	//   - error: nil on success, or error if cleanup fails
+	Close() error
+}
+
+// setMemTargetingRules replaces the rules of the link in the map, removing the link if there are no rules.
+func setMemTargetingRules(rules map[string][]model.TargetingRule, shortID string, linkRules []model.TargetingRule) {
+	if len(linkRules) == 0 {
+		delete(rules, shortID)
+		return
+	}
+	rules[shortID] = slices.Clone(linkRules)
+}
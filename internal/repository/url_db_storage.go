@@ -5,7 +5,10 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/jackc/pgx/v5/pgconn"
 	"go.uber.org/zap"
 
 	"github.com/alex-storchak/shortener/internal/model"
@@ -17,6 +20,48 @@ var (
 	ErrDataNotFoundInDB = errors.New("data not found in db")
 )
 
+// PostgreSQL error details used to recognize constraint violations.
+const (
+	pgUniqueViolationCode   = "23505"                                // unique_violation error code
+	shortIDUniqueConstraint = "url_storage_short_id_key"             // UNIQUE constraint on url_storage.short_id
+	origURLUniqueIndex      = "idx_url_storage_original_url_user_id" // UNIQUE index on url_storage (original_url, user_id)
+)
+
+// rewriteBatchSize is the maximum amount of URLs read at once by RewriteOrigURLs.
+const rewriteBatchSize = 1000
+
+// urlRecordColumns is the column list scanned by scanURLRecord.
+// Tags are aggregated into a comma-separated list; tags never contain commas.
+const urlRecordColumns = "us.original_url, us.short_id, au.user_uuid, us.is_deleted, us.expires_at, " +
+	"us.max_clicks, us.clicks_left, us.pass_hash, us.deleted_at, us.redirect_type, us.passthrough, us.created_at, " +
+	"(SELECT string_agg(ut.tag, ',' ORDER BY ut.tag) FROM url_tags ut WHERE ut.url_id = us.id)"
+
+// tagSeparator separates tags passed to and read from queries as a single string.
+const tagSeparator = ","
+
+// insertURLQuery stores a URL record together with its tags and the first version of its history.
+const insertURLQuery = `
+	WITH ins AS (
+		INSERT INTO url_storage (original_url, short_id, user_id, expires_at, max_clicks, clicks_left, pass_hash, redirect_type, passthrough) 
+		SELECT $1, $2, id, $4, $5, $5, $6, $9, $10 
+		FROM auth_user 
+		WHERE user_uuid = $3
+		RETURNING id, original_url, user_id
+	), tags AS (
+		INSERT INTO url_tags (url_id, tag)
+		SELECT ins.id, t.tag
+		FROM ins, unnest(string_to_array(NULLIF($8, ''), ',')) AS t(tag)
+	)
+	INSERT INTO url_history (url_id, action, url, user_id)
+	SELECT id, $7, original_url, user_id
+	FROM ins
+`
+
+// rowScanner is implemented by both *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
 // DBURLStorage provides a PostgreSQL implementation of URLStorage.
 // It stores URL mappings in a relational database with proper transaction support,
 // concurrent access handling, and persistence between restarts.
@@ -62,14 +107,17 @@ func (s *DBURLStorage) Ping(ctx context.Context) error {
 	return nil
 }
 
-// getByOriginalURL retrieves a URL record by original URL from the database.
+// getByOriginalURL retrieves a non-deleted plain URL record by original URL from the database.
 func (s *DBURLStorage) getByOriginalURL(ctx context.Context, origURL string) (*model.URLStorageRecord, error) {
 	q := `
-		SELECT us.original_url, us.short_id, au.user_uuid, us.is_deleted 
+		SELECT ` + urlRecordColumns + `
 		FROM url_storage us
 		JOIN auth_user au ON au.id = us.user_id 
 		WHERE us.original_url = $1
 		AND us.is_deleted = FALSE
+		AND us.expires_at IS NULL
+		AND us.max_clicks IS NULL
+		AND us.pass_hash IS NULL
 	`
 	return s.getByQuery(ctx, q, origURL)
 }
@@ -77,7 +125,7 @@ func (s *DBURLStorage) getByOriginalURL(ctx context.Context, origURL string) (*m
 // getByShortID retrieves a URL record by short ID from the database.
 func (s *DBURLStorage) getByShortID(ctx context.Context, shortID string) (*model.URLStorageRecord, error) {
 	q := `
-		SELECT us.original_url, us.short_id, au.user_uuid, us.is_deleted 
+		SELECT ` + urlRecordColumns + `
 		FROM url_storage us
 		JOIN auth_user au ON au.id = us.user_id 
 		WHERE us.short_id = $1
@@ -89,16 +137,80 @@ func (s *DBURLStorage) getByShortID(ctx context.Context, shortID string) (*model
 func (s *DBURLStorage) getByQuery(ctx context.Context, q string, args ...any) (*model.URLStorageRecord, error) {
 	row := s.db.QueryRowContext(ctx, q, args...)
 
-	var r model.URLStorageRecord
-	err := row.Scan(&r.OrigURL, &r.ShortID, &r.UserUUID, &r.IsDeleted)
+	r, err := scanURLRecord(row)
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, ErrDataNotFoundInDB
 	} else if err != nil {
 		return nil, fmt.Errorf("scan query result row: %w", err)
 	}
+	return r, nil
+}
+
+// scanURLRecord scans a row selected with urlRecordColumns into a URLStorageRecord.
+func scanURLRecord(row rowScanner) (*model.URLStorageRecord, error) {
+	var (
+		r                     model.URLStorageRecord
+		expiresAt, deletedAt  sql.NullTime
+		createdAt             sql.NullTime
+		maxClicks, clicksLeft sql.NullInt64
+		passHash, tags        sql.NullString
+	)
+	err := row.Scan(
+		&r.OrigURL, &r.ShortID, &r.UserUUID, &r.IsDeleted, &expiresAt, &maxClicks, &clicksLeft, &passHash, &deletedAt,
+		&r.RedirectType, &r.Passthrough, &createdAt, &tags,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if expiresAt.Valid {
+		r.ExpiresAt = expiresAt.Time
+	}
+	if deletedAt.Valid {
+		r.DeletedAt = deletedAt.Time
+	}
+	if createdAt.Valid {
+		r.CreatedAt = createdAt.Time
+	}
+	r.MaxClicks = int(maxClicks.Int64)
+	r.ClicksLeft = int(clicksLeft.Int64)
+	r.PassHash = passHash.String
+	if tags.String != "" {
+		r.Tags = strings.Split(tags.String, tagSeparator)
+	}
 	return &r, nil
 }
 
+// insertArgs returns the arguments of url_storage INSERT statement for the record.
+func insertArgs(r model.URLStorageRecord) []any {
+	return []any{
+		r.OrigURL,
+		r.ShortID,
+		r.UserUUID,
+		nullTime(r.ExpiresAt),
+		nullInt(r.MaxClicks),
+		nullString(r.PassHash),
+		model.URLHistoryActionCreate,
+		strings.Join(r.Tags, tagSeparator),
+		r.Redirect(),
+		r.Passthrough,
+	}
+}
+
+// nullTime converts zero time to SQL NULL.
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
+
+// nullString converts empty string to SQL NULL.
+func nullString(str string) sql.NullString {
+	return sql.NullString{String: str, Valid: str != ""}
+}
+
+// nullInt converts zero integer to SQL NULL.
+func nullInt(i int) sql.NullInt64 {
+	return sql.NullInt64{Int64: int64(i), Valid: i != 0}
+}
+
 // Get retrieves a URL record from the database based on search type.
 //
 // Parameters:
@@ -108,7 +220,7 @@ func (s *DBURLStorage) getByQuery(ctx context.Context, q string, args ...any) (*
 //
 // Returns:
 //   - *model.URLStorageRecord: found record or nil if not found
-//   - error: nil on success, or error if query fails or URL is deleted
+//   - error: nil on success, or error if query fails, URL is deleted, expired or exhausted
 func (s *DBURLStorage) Get(ctx context.Context, url, searchByType string) (*model.URLStorageRecord, error) {
 	var (
 		r   *model.URLStorageRecord
@@ -121,6 +233,12 @@ func (s *DBURLStorage) Get(ctx context.Context, url, searchByType string) (*mode
 		if err == nil && r.IsDeleted {
 			return nil, ErrDataDeleted
 		}
+		if err == nil && r.IsExpired(time.Now()) {
+			return nil, ErrDataExpired
+		}
+		if err == nil && r.IsExhausted() {
+			return nil, ErrClicksExhausted
+		}
 	}
 	if errors.Is(err, ErrDataNotFoundInDB) {
 		return nil, NewDataNotFoundError(ErrDataNotFoundInDB)
@@ -130,26 +248,70 @@ func (s *DBURLStorage) Get(ctx context.Context, url, searchByType string) (*mode
 	return r, nil
 }
 
-// Set stores a single URL mapping in the database.
+// GetByOrigURLs retrieves non-deleted plain URL records of the original URLs from the database with a single query.
 //
 // Parameters:
 //   - ctx: context for cancellation and timeouts
-//   - origURL: original URL to be shortened
-//   - shortID: generated short identifier
-//   - userUUID: UUID of the user who created the mapping
+//   - origURLs: original URLs to search for
 //
 // Returns:
-//   - error: nil on success, or error if insertion fails
-func (s *DBURLStorage) Set(ctx context.Context, origURL, shortID, userUUID string) error {
+//   - map[string]*model.URLStorageRecord: found records by original URL
+//   - error: nil on success, or error if query fails
+func (s *DBURLStorage) GetByOrigURLs(ctx context.Context, origURLs []string) (map[string]*model.URLStorageRecord, error) {
+	found := make(map[string]*model.URLStorageRecord, len(origURLs))
+	if len(origURLs) == 0 {
+		return found, nil
+	}
+
 	q := `
-		INSERT INTO url_storage (original_url, short_id, user_id) 
-		SELECT $1, $2, id 
-		FROM auth_user 
-		WHERE user_uuid = $3
+		SELECT ` + urlRecordColumns + `
+		FROM url_storage us
+		JOIN auth_user au ON au.id = us.user_id
+		WHERE us.original_url = ANY($1)
+		AND us.is_deleted = FALSE
+		AND us.expires_at IS NULL
+		AND us.max_clicks IS NULL
+		AND us.pass_hash IS NULL
+		ORDER BY us.id
 	`
-	_, err := s.db.ExecContext(ctx, q, origURL, shortID, userUUID)
+	rows, err := s.db.QueryContext(ctx, q, origURLs)
 	if err != nil {
-		return fmt.Errorf("persist binding (%s, %s, %s) to db: %w", origURL, shortID, userUUID, err)
+		return nil, fmt.Errorf("query urls by original urls from db: %w", err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		r, err := scanURLRecord(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan url by original url from db: %w", err)
+		}
+		if _, ok := found[r.OrigURL]; !ok {
+			found[r.OrigURL] = r
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("get urls by original urls from db: %w", err)
+	}
+	return found, nil
+}
+
+// Set stores a single URL mapping in the database.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - r: URL storage record to persist
+//
+// Returns:
+//   - error: nil on success, ErrShortIDConflict if short ID is already in use,
+//     or error if insertion fails
+func (s *DBURLStorage) Set(ctx context.Context, r model.URLStorageRecord) error {
+	_, err := s.db.ExecContext(ctx, insertURLQuery, insertArgs(r)...)
+	if isShortIDConflict(err) {
+		return fmt.Errorf("persist binding with short id `%s` to db: %w", r.ShortID, ErrShortIDConflict)
+	} else if err != nil {
+		return fmt.Errorf("persist binding (%s, %s, %s) to db: %w", r.OrigURL, r.ShortID, r.UserUUID, err)
 	}
 	return nil
 }
@@ -162,14 +324,9 @@ func (s *DBURLStorage) Set(ctx context.Context, origURL, shortID, userUUID strin
 //   - records: slice of URL storage records to persist
 //
 // Returns:
-//   - error: nil on success, or error if transaction fails
+//   - error: nil on success, ErrShortIDConflict if any short ID is already in use,
+//     or error if transaction fails
 func (s *DBURLStorage) BatchSet(ctx context.Context, records []model.URLStorageRecord) error {
-	insertSQL := `
-		INSERT INTO url_storage (original_url, short_id, user_id) 
-		SELECT $1, $2, id 
-		FROM auth_user 
-		WHERE user_uuid = $3
-	`
 	trx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("begin transaction: %w", err)
@@ -182,7 +339,7 @@ func (s *DBURLStorage) BatchSet(ctx context.Context, records []model.URLStorageR
 		}
 	}()
 
-	stmt, err := trx.PrepareContext(ctx, insertSQL)
+	stmt, err := trx.PrepareContext(ctx, insertURLQuery)
 	if err != nil {
 		return fmt.Errorf("prepare statement: %w", err)
 	}
@@ -195,7 +352,10 @@ func (s *DBURLStorage) BatchSet(ctx context.Context, records []model.URLStorageR
 	}()
 
 	for _, b := range records {
-		if _, eErr := stmt.ExecContext(ctx, b.OrigURL, b.ShortID, b.UserUUID); eErr != nil {
+		_, eErr := stmt.ExecContext(ctx, insertArgs(b)...)
+		if isShortIDConflict(eErr) {
+			return fmt.Errorf("persist batch record with short id `%s` to db: %w", b.ShortID, ErrShortIDConflict)
+		} else if eErr != nil {
 			return fmt.Errorf("persist batch record `%v` to db: %w", b, eErr)
 		}
 	}
@@ -206,26 +366,60 @@ func (s *DBURLStorage) BatchSet(ctx context.Context, records []model.URLStorageR
 	return nil
 }
 
-// GetByUserUUID retrieves all non-deleted URL records for a specific user.
+// GetByUserUUID retrieves all non-deleted URL records for a specific user labeled with every given tag.
 //
 // Parameters:
 //   - ctx: context for cancellation and timeouts
 //   - userUUID: UUID of the user to retrieve URLs for
+//   - tags: tags the URLs must have; empty for all URLs of the user
 //
 // Returns:
 //   - []*model.URLStorageRecord: slice of URL records belonging to the user
 //   - error: nil on success, or error if a query fails
-func (s *DBURLStorage) GetByUserUUID(ctx context.Context, userUUID string) ([]*model.URLStorageRecord, error) {
+func (s *DBURLStorage) GetByUserUUID(
+	ctx context.Context,
+	userUUID string,
+	tags []string,
+) ([]*model.URLStorageRecord, error) {
+	return s.getUserURLs(ctx, userUUID, false, tags)
+}
+
+// GetDeletedByUserUUID retrieves all soft-deleted URL records for a specific user.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - userUUID: UUID of the user to retrieve deleted URLs for
+//
+// Returns:
+//   - []*model.URLStorageRecord: slice of deleted URL records belonging to the user
+//   - error: nil on success, or error if a query fails
+func (s *DBURLStorage) GetDeletedByUserUUID(ctx context.Context, userUUID string) ([]*model.URLStorageRecord, error) {
+	return s.getUserURLs(ctx, userUUID, true, nil)
+}
+
+// getUserURLs retrieves URL records of a specific user with the given deletion flag,
+// labeled with every given tag.
+func (s *DBURLStorage) getUserURLs(
+	ctx context.Context,
+	userUUID string,
+	isDeleted bool,
+	tags []string,
+) ([]*model.URLStorageRecord, error) {
 	urls := make([]*model.URLStorageRecord, 0)
 
 	q := `
-		SELECT us.original_url, us.short_id, au.user_uuid, us.is_deleted 
+		SELECT ` + urlRecordColumns + `
 		FROM url_storage us 
 		JOIN auth_user au ON au.id = us.user_id 
 		WHERE user_uuid = $1 
-		AND us.is_deleted = FALSE
+		AND us.is_deleted = $2
+		AND NOT EXISTS (
+			SELECT 1
+			FROM unnest(string_to_array(NULLIF($3, ''), ',')) AS t(tag)
+			WHERE NOT EXISTS (SELECT 1 FROM url_tags ut WHERE ut.url_id = us.id AND ut.tag = t.tag)
+		)
 	`
-	rows, err := s.db.QueryContext(ctx, q, userUUID)
+	rows, err := s.db.QueryContext(ctx, q, userUUID, isDeleted, strings.Join(tags, tagSeparator))
 	if err != nil {
 		return nil, fmt.Errorf("query user urls from db: %w", err)
 	}
@@ -233,12 +427,11 @@ func (s *DBURLStorage) GetByUserUUID(ctx context.Context, userUUID string) ([]*m
 	defer rows.Close()
 
 	for rows.Next() {
-		var r model.URLStorageRecord
-		err = rows.Scan(&r.OrigURL, &r.ShortID, &r.UserUUID, &r.IsDeleted)
+		r, err := scanURLRecord(rows)
 		if err != nil {
 			return nil, fmt.Errorf("scan user url from db: %w", err)
 		}
-		urls = append(urls, &r)
+		urls = append(urls, r)
 	}
 
 	err = rows.Err()
@@ -248,6 +441,124 @@ func (s *DBURLStorage) GetByUserUUID(ctx context.Context, userUUID string) ([]*m
 	return urls, nil
 }
 
+// RestoreBatch undeletes soft-deleted URLs of the user within a single transaction
+// and records the restoration in url_history.
+// A URL is restored only if the user has no other non-deleted URL for the same original URL,
+// so the partial unique index on (original_url, user_id) holds. If a conflicting URL is inserted
+// concurrently, the unique violation fails the whole batch with ErrOrigURLConflict.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - userUUID: UUID of the user owning the URLs
+//   - shortIDs: short identifiers of the URLs to restore
+//
+// Returns:
+//   - []model.URLRestoreResult: outcome for every requested short identifier
+//   - error: nil on success, or error if transaction fails
+func (s *DBURLStorage) RestoreBatch(
+	ctx context.Context,
+	userUUID string,
+	shortIDs []string,
+) ([]model.URLRestoreResult, error) {
+	trx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() {
+		if err := trx.Rollback(); err != nil {
+			if !errors.Is(err, sql.ErrTxDone) {
+				s.logger.Error("failed to rollback transaction", zap.Error(err))
+			}
+		}
+	}()
+
+	results := make([]model.URLRestoreResult, len(shortIDs))
+	for i, shortID := range shortIDs {
+		res, rErr := s.restoreInTx(ctx, trx, userUUID, shortID)
+		if rErr != nil {
+			return nil, fmt.Errorf("restore url `%s`: %w", shortID, rErr)
+		}
+		results[i] = res
+	}
+
+	if cErr := trx.Commit(); cErr != nil {
+		return nil, fmt.Errorf("commiting restore batch transaction: %w", cErr)
+	}
+	return results, nil
+}
+
+// restoreInTx undeletes a single soft-deleted URL of the user within the transaction.
+func (s *DBURLStorage) restoreInTx(
+	ctx context.Context,
+	trx *sql.Tx,
+	userUUID, shortID string,
+) (model.URLRestoreResult, error) {
+	res := model.URLRestoreResult{ShortID: shortID, Status: model.URLRestoreStatusNotFound}
+
+	var (
+		urlID, userID int
+		expiresAt     sql.NullTime
+	)
+	q := `
+		SELECT us.id, us.original_url, us.user_id, us.expires_at
+		FROM url_storage us
+		JOIN auth_user au ON au.id = us.user_id
+		WHERE us.short_id = $1
+		AND au.user_uuid = $2
+		AND us.is_deleted = TRUE
+		FOR UPDATE OF us
+	`
+	err := trx.QueryRowContext(ctx, q, shortID, userUUID).Scan(&urlID, &res.OrigURL, &userID, &expiresAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return res, nil
+	} else if err != nil {
+		return res, fmt.Errorf("select deleted url: %w", err)
+	}
+	if expiresAt.Valid && !time.Now().Before(expiresAt.Time) {
+		res.Status = model.URLRestoreStatusExpired
+		return res, nil
+	}
+
+	q = `
+		WITH res AS (
+			UPDATE url_storage us
+			SET is_deleted = FALSE, deleted_at = NULL
+			WHERE us.id = $1
+			AND (
+				us.expires_at IS NOT NULL OR us.max_clicks IS NOT NULL OR us.pass_hash IS NOT NULL
+				OR NOT EXISTS (
+					SELECT 1 FROM url_storage o
+					WHERE o.original_url = $2
+					AND o.user_id = $3
+					AND o.is_deleted = FALSE
+					AND o.expires_at IS NULL
+					AND o.max_clicks IS NULL
+					AND o.pass_hash IS NULL
+				)
+			)
+			RETURNING us.id
+		), hist AS (
+			INSERT INTO url_history (url_id, action, url, user_id)
+			SELECT id, $4, $2, $3
+			FROM res
+		)
+		SELECT count(*) FROM res
+	`
+	var restored int
+	err = trx.QueryRowContext(ctx, q, urlID, res.OrigURL, userID, model.URLHistoryActionRestore).Scan(&restored)
+	if isOrigURLConflict(err) {
+		return res, fmt.Errorf("undelete url: %w", ErrOrigURLConflict)
+	} else if err != nil {
+		return res, fmt.Errorf("undelete url: %w", err)
+	}
+	if restored == 0 {
+		res.Status = model.URLRestoreStatusConflict
+		return res, nil
+	}
+	res.Status = model.URLRestoreStatusRestored
+	return res, nil
+}
+
 // Count counts the amount of shortened URLs in the database.
 //
 // Parameters:
@@ -268,8 +579,8 @@ func (s *DBURLStorage) Count(ctx context.Context) (int, error) {
 	return count, nil
 }
 
-// DeleteBatch marks multiple URLs as deleted in the database within a transaction.
-// Only URLs belonging to the specified users are deleted.
+// DeleteBatch marks multiple URLs as deleted in the database within a transaction
+// and records the deletion in url_history. Only URLs belonging to the specified users are deleted.
 //
 // Parameters:
 //   - ctx: context for cancellation and timeouts
@@ -284,13 +595,19 @@ func (s *DBURLStorage) DeleteBatch(ctx context.Context, urls model.URLDeleteBatc
 	shortIDs, userUUIDs := s.segregateBatch(urls)
 
 	q := `
-    UPDATE url_storage 
-    SET is_deleted = true
-    WHERE short_id = ANY($1) 
-    AND user_id IN (
-        SELECT id FROM auth_user WHERE user_uuid = ANY($2)
+    WITH del AS (
+        UPDATE url_storage 
+        SET is_deleted = true, deleted_at = NOW()
+        WHERE short_id = ANY($1) 
+        AND user_id IN (
+            SELECT id FROM auth_user WHERE user_uuid = ANY($2)
+        )
+        AND is_deleted = false
+        RETURNING id, original_url, user_id
     )
-    AND is_deleted = false
+    INSERT INTO url_history (url_id, action, url, user_id)
+    SELECT id, $3, original_url, user_id
+    FROM del
 	`
 
 	trx, err := s.db.BeginTx(ctx, nil)
@@ -305,7 +622,7 @@ func (s *DBURLStorage) DeleteBatch(ctx context.Context, urls model.URLDeleteBatc
 		}
 	}()
 
-	_, err = trx.ExecContext(ctx, q, shortIDs, userUUIDs)
+	_, err = trx.ExecContext(ctx, q, shortIDs, userUUIDs, model.URLHistoryActionDelete)
 	if err != nil {
 		return fmt.Errorf("update `is_deleted` field for urls batch: %w", err)
 	}
@@ -316,6 +633,478 @@ func (s *DBURLStorage) DeleteBatch(ctx context.Context, urls model.URLDeleteBatc
 	return nil
 }
 
+// SweepExpired marks all non-deleted URLs whose expiration moment has passed as deleted
+// and records the expiration in url_history.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - now: moment to check expiration against
+//
+// Returns:
+//   - int: amount of URLs marked as deleted
+//   - error: nil on success, or error if the update fails
+func (s *DBURLStorage) SweepExpired(ctx context.Context, now time.Time) (int, error) {
+	q := `
+		WITH swept AS (
+			UPDATE url_storage 
+			SET is_deleted = TRUE, deleted_at = $1
+			WHERE is_deleted = FALSE
+			AND expires_at <= $1
+			RETURNING id, original_url
+		), hist AS (
+			INSERT INTO url_history (url_id, action, url)
+			SELECT id, $2, original_url
+			FROM swept
+		)
+		SELECT count(*) FROM swept
+	`
+	var n int
+	err := s.db.QueryRowContext(ctx, q, now, model.URLHistoryActionExpire).Scan(&n)
+	if err != nil {
+		return 0, fmt.Errorf("mark expired urls as deleted: %w", err)
+	}
+	return n, nil
+}
+
+// PurgeDeleted physically removes URLs soft-deleted not after the given moment.
+// Rows are deleted in batches of at most batchSize in separate statements, so that
+// a large backlog doesn't hold locks for long. Short IDs of the purged URLs are moved
+// to purged_short_id, where a trigger on url_storage rejects them with a unique violation.
+// History of the purged URLs is removed by cascade.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - before: URLs deleted after this moment are kept
+//   - batchSize: maximum amount of URLs removed by a single statement
+//
+// Returns:
+//   - []string: short identifiers of the purged URLs, including the ones purged before an error occurred
+//   - error: nil on success, or error if a delete statement fails
+func (s *DBURLStorage) PurgeDeleted(ctx context.Context, before time.Time, batchSize int) ([]string, error) {
+	if batchSize <= 0 {
+		return nil, fmt.Errorf("invalid purge batch size %d", batchSize)
+	}
+	q := `
+		WITH batch AS (
+			SELECT id, short_id
+			FROM url_storage
+			WHERE is_deleted = TRUE
+			AND deleted_at <= $1
+			ORDER BY id
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		), tomb AS (
+			INSERT INTO purged_short_id (short_id)
+			SELECT short_id FROM batch
+			ON CONFLICT (short_id) DO NOTHING
+		)
+		DELETE FROM url_storage
+		WHERE id IN (SELECT id FROM batch)
+		RETURNING short_id
+	`
+	var purged []string
+	for {
+		ids, err := s.purgeDeletedBatch(ctx, q, before, batchSize)
+		purged = append(purged, ids...)
+		if err != nil {
+			return purged, err
+		}
+		if len(ids) < batchSize {
+			return purged, nil
+		}
+	}
+}
+
+// purgeDeletedBatch runs a single purge statement and collects short IDs of the purged URLs.
+func (s *DBURLStorage) purgeDeletedBatch(ctx context.Context, q string, before time.Time, batchSize int) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, q, before, batchSize)
+	if err != nil {
+		return nil, fmt.Errorf("purge deleted urls batch: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return ids, fmt.Errorf("scan purged short id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return ids, fmt.Errorf("iterate purged short ids: %w", err)
+	}
+	return ids, nil
+}
+
+// CountPurged counts the amount of URLs purged from the database.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//
+// Returns:
+//   - int: total amount of purged URLs
+//   - error: nil on success, or database error if query fails
+func (s *DBURLStorage) CountPurged(ctx context.Context) (int, error) {
+	var count int
+	err := s.db.QueryRowContext(ctx, "SELECT count(*) FROM purged_short_id").Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("scan count purged urls query result row: %w", err)
+	}
+	return count, nil
+}
+
+// NextSequence takes the next value of the database sequence of short IDs.
+// Values of a sequence are never reused, even if the transaction that took them is rolled back.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//
+// Returns:
+//   - uint64: next value of the sequence
+//   - error: nil on success, or database error if query fails
+func (s *DBURLStorage) NextSequence(ctx context.Context) (uint64, error) {
+	var n uint64
+	if err := s.db.QueryRowContext(ctx, "SELECT nextval('short_id_seq')").Scan(&n); err != nil {
+		return 0, fmt.Errorf("scan next short id sequence value: %w", err)
+	}
+	return n, nil
+}
+
+// DecrementClicks consumes one follow of a click-limited URL in the database.
+// The conditional UPDATE makes concurrent follows race-free: the counter never drops below zero.
+// URLs without click limit keep NULL counter and are matched without changes.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - shortID: short identifier of the followed URL
+//
+// Returns:
+//   - error: nil on success, ErrClicksExhausted if no follows left, or error if the update fails
+func (s *DBURLStorage) DecrementClicks(ctx context.Context, shortID string) error {
+	q := `
+		UPDATE url_storage 
+		SET clicks_left = clicks_left - 1
+		WHERE short_id = $1
+		AND (clicks_left IS NULL OR clicks_left > 0)
+	`
+	res, err := s.db.ExecContext(ctx, q, shortID)
+	if err != nil {
+		return fmt.Errorf("decrement clicks of url `%s`: %w", shortID, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("get amount of decremented urls: %w", err)
+	}
+	if n == 0 {
+		return ErrClicksExhausted
+	}
+	return nil
+}
+
+// Update changes the original URL of a user's URL in the database and records
+// the change in url_history within the same statement.
+// Ownership is checked within the statement itself, so the user cannot modify someone else's URL,
+// and deleted rows are left untouched.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - shortID: short identifier of the URL to update
+//   - userUUID: UUID of the user owning the URL
+//   - origURL: new original URL
+//
+// Returns:
+//   - error: nil on success, ErrDataDeleted if URL is deleted, ErrOrigURLConflict
+//     if the URL is plain and the new original URL already has a plain short URL of the user, DataNotFoundError,
+//     or error if the update fails
+func (s *DBURLStorage) Update(ctx context.Context, shortID, userUUID, origURL string) error {
+	q := `
+		WITH prev AS (
+			SELECT us.id, us.original_url, us.user_id, us.is_deleted
+			FROM url_storage us
+			JOIN auth_user au ON au.id = us.user_id
+			WHERE us.short_id = $2
+			AND au.user_uuid = $3
+			FOR UPDATE OF us
+		), upd AS (
+			UPDATE url_storage us
+			SET original_url = $1
+			FROM prev
+			WHERE us.id = prev.id
+			AND prev.is_deleted = FALSE
+			RETURNING us.id
+		), hist AS (
+			INSERT INTO url_history (url_id, action, prev_url, url, user_id)
+			SELECT prev.id, $4, prev.original_url, $1, prev.user_id
+			FROM prev
+			JOIN upd ON upd.id = prev.id
+		)
+		SELECT is_deleted FROM prev
+	`
+	var isDeleted bool
+	err := s.db.QueryRowContext(ctx, q, origURL, shortID, userUUID, model.URLHistoryActionUpdate).Scan(&isDeleted)
+	if errors.Is(err, sql.ErrNoRows) {
+		return NewDataNotFoundError(ErrDataNotFoundInDB)
+	} else if isOrigURLConflict(err) {
+		return fmt.Errorf("update url `%s`: %w", shortID, ErrOrigURLConflict)
+	} else if err != nil {
+		return fmt.Errorf("update url `%s`: %w", shortID, err)
+	}
+	if isDeleted {
+		return ErrDataDeleted
+	}
+	return nil
+}
+
+// SetTags replaces the tags of a user's URL in the url_tags table.
+// Tags missing from the new set are removed and new ones are added in a single statement;
+// tags of deleted rows are left untouched.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - shortID: short identifier of the URL to label
+//   - userUUID: UUID of the user owning the URL
+//   - tags: sorted unique tags without commas; empty to remove all tags
+//
+// Returns:
+//   - error: nil on success, ErrDataDeleted if URL is deleted, DataNotFoundError,
+//     or error if the update fails
+func (s *DBURLStorage) SetTags(ctx context.Context, shortID, userUUID string, tags []string) error {
+	q := `
+		WITH prev AS (
+			SELECT us.id, us.is_deleted
+			FROM url_storage us
+			JOIN auth_user au ON au.id = us.user_id
+			WHERE us.short_id = $1
+			AND au.user_uuid = $2
+			FOR UPDATE OF us
+		), new_tags AS (
+			SELECT COALESCE(string_to_array(NULLIF($3, ''), ','), '{}') AS tags
+		), del AS (
+			DELETE FROM url_tags ut
+			USING prev, new_tags
+			WHERE ut.url_id = prev.id
+			AND prev.is_deleted = FALSE
+			AND ut.tag <> ALL(new_tags.tags)
+		), ins AS (
+			INSERT INTO url_tags (url_id, tag)
+			SELECT prev.id, t.tag
+			FROM prev, new_tags, unnest(new_tags.tags) AS t(tag)
+			WHERE prev.is_deleted = FALSE
+			ON CONFLICT (url_id, tag) DO NOTHING
+		)
+		SELECT is_deleted FROM prev
+	`
+	var isDeleted bool
+	err := s.db.QueryRowContext(ctx, q, shortID, userUUID, strings.Join(tags, tagSeparator)).Scan(&isDeleted)
+	if errors.Is(err, sql.ErrNoRows) {
+		return NewDataNotFoundError(ErrDataNotFoundInDB)
+	} else if err != nil {
+		return fmt.Errorf("set tags of url `%s`: %w", shortID, err)
+	}
+	if isDeleted {
+		return ErrDataDeleted
+	}
+	return nil
+}
+
+// GetTagsByUserUUID counts the non-deleted URLs of a specific user in the url_tags table by tag.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - userUUID: UUID of the user to retrieve tags for
+//
+// Returns:
+//   - []model.TagCount: tags of the user's URLs with their counts, sorted by tag
+//   - error: nil on success, or error if the query fails
+func (s *DBURLStorage) GetTagsByUserUUID(ctx context.Context, userUUID string) ([]model.TagCount, error) {
+	q := `
+		SELECT ut.tag, COUNT(*)
+		FROM url_tags ut
+		JOIN url_storage us ON us.id = ut.url_id
+		JOIN auth_user au ON au.id = us.user_id
+		WHERE au.user_uuid = $1
+		AND us.is_deleted = FALSE
+		GROUP BY ut.tag
+		ORDER BY ut.tag
+	`
+	rows, err := s.db.QueryContext(ctx, q, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("query user tags from db: %w", err)
+	}
+	defer rows.Close()
+
+	tags := make([]model.TagCount, 0)
+	for rows.Next() {
+		var t model.TagCount
+		if err := rows.Scan(&t.Tag, &t.Count); err != nil {
+			return nil, fmt.Errorf("scan user tag from db: %w", err)
+		}
+		tags = append(tags, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("get user tags from db: %w", err)
+	}
+	return tags, nil
+}
+
+// GetHistory retrieves all versions of a user's URL from the url_history table.
+// Versions are numbered in the order the changes were recorded.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - shortID: short identifier of the URL
+//   - userUUID: UUID of the user owning the URL
+//
+// Returns:
+//   - []model.URLHistoryRecord: versions of the URL numbered from 1
+//   - error: nil on success, DataNotFoundError if the user has no such URL,
+//     or error if a query fails
+func (s *DBURLStorage) GetHistory(ctx context.Context, shortID, userUUID string) ([]model.URLHistoryRecord, error) {
+	q := `
+		SELECT ROW_NUMBER() OVER (ORDER BY h.id), us.short_id, h.action, h.prev_url, h.url, hu.user_uuid, h.created_at
+		FROM url_history h
+		JOIN url_storage us ON us.id = h.url_id
+		JOIN auth_user au ON au.id = us.user_id
+		LEFT JOIN auth_user hu ON hu.id = h.user_id
+		WHERE us.short_id = $1
+		AND au.user_uuid = $2
+		ORDER BY h.id
+	`
+	rows, err := s.db.QueryContext(ctx, q, shortID, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("query url history from db: %w", err)
+	}
+	defer rows.Close()
+
+	history := make([]model.URLHistoryRecord, 0)
+	for rows.Next() {
+		var (
+			h                  model.URLHistoryRecord
+			prevURL, changedBy sql.NullString
+		)
+		err := rows.Scan(&h.Version, &h.ShortID, &h.Action, &prevURL, &h.OrigURL, &changedBy, &h.TS)
+		if err != nil {
+			return nil, fmt.Errorf("scan url history from db: %w", err)
+		}
+		h.PrevURL = prevURL.String
+		h.UserUUID = changedBy.String
+		history = append(history, h)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("get url history from db: %w", err)
+	}
+	if len(history) == 0 {
+		return nil, NewDataNotFoundError(ErrDataNotFoundInDB)
+	}
+	return history, nil
+}
+
+// RewriteOrigURLs replaces original URLs of non-deleted URLs with their rewritten form.
+// URLs are read in batches ordered by id; every changed URL is updated by a separate statement
+// together with its history record, so the rewrite can be safely interrupted and repeated.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - rewrite: function returning the new form of an original URL
+//
+// Returns:
+//   - int: amount of URLs changed
+//   - int: amount of URLs left unchanged because of conflicts
+//   - error: nil on success, or error if a query fails
+func (s *DBURLStorage) RewriteOrigURLs(ctx context.Context, rewrite func(origURL string) string) (int, int, error) {
+	q := `
+		WITH upd AS (
+			UPDATE url_storage us
+			SET original_url = $2
+			WHERE us.id = $1
+			AND us.is_deleted = FALSE
+			AND (
+				us.expires_at IS NOT NULL OR us.max_clicks IS NOT NULL OR us.pass_hash IS NOT NULL
+				OR NOT EXISTS (
+					SELECT 1 FROM url_storage o
+					WHERE o.original_url = $2
+					AND o.is_deleted = FALSE
+					AND o.expires_at IS NULL
+					AND o.max_clicks IS NULL
+					AND o.pass_hash IS NULL
+				)
+			)
+			RETURNING us.id
+		), hist AS (
+			INSERT INTO url_history (url_id, action, prev_url, url)
+			SELECT id, $4, $3, $2
+			FROM upd
+		)
+		SELECT count(*) FROM upd
+	`
+	rewritten, skipped, lastID := 0, 0, 0
+	for {
+		batch, err := s.getLiveURLsAfter(ctx, lastID)
+		if err != nil {
+			return rewritten, skipped, fmt.Errorf("get urls batch to rewrite: %w", err)
+		}
+		if len(batch) == 0 {
+			return rewritten, skipped, nil
+		}
+		for _, u := range batch {
+			newURL := rewrite(u.origURL)
+			if newURL == u.origURL {
+				continue
+			}
+			var n int
+			err := s.db.QueryRowContext(ctx, q, u.id, newURL, u.origURL, model.URLHistoryActionUpdate).Scan(&n)
+			if isOrigURLConflict(err) {
+				n = 0
+			} else if err != nil {
+				return rewritten, skipped, fmt.Errorf("rewrite original url of url with id %d: %w", u.id, err)
+			}
+			if n == 0 {
+				skipped++
+			} else {
+				rewritten++
+			}
+		}
+		lastID = batch[len(batch)-1].id
+	}
+}
+
+// liveURL is an id and original URL of a non-deleted URL read by getLiveURLsAfter.
+type liveURL struct {
+	id      int
+	origURL string
+}
+
+// getLiveURLsAfter returns at most rewriteBatchSize non-deleted URLs with id greater than afterID, ordered by id.
+func (s *DBURLStorage) getLiveURLsAfter(ctx context.Context, afterID int) ([]liveURL, error) {
+	q := `
+		SELECT id, original_url
+		FROM url_storage
+		WHERE is_deleted = FALSE
+		AND id > $1
+		ORDER BY id
+		LIMIT $2
+	`
+	rows, err := s.db.QueryContext(ctx, q, afterID, rewriteBatchSize)
+	if err != nil {
+		return nil, fmt.Errorf("select urls: %w", err)
+	}
+	defer rows.Close()
+
+	batch := make([]liveURL, 0, rewriteBatchSize)
+	for rows.Next() {
+		var u liveURL
+		if err := rows.Scan(&u.id, &u.origURL); err != nil {
+			return nil, fmt.Errorf("scan url: %w", err)
+		}
+		batch = append(batch, u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate urls: %w", err)
+	}
+	return batch, nil
+}
+
 // segregateBatch separates URL delete batch into separate slices for short IDs and user UUIDs.
 // This is used to prepare parameters for the batch delete SQL query.
 func (s *DBURLStorage) segregateBatch(urls model.URLDeleteBatch) (shortIds, userUUIDs []string) {
@@ -327,3 +1116,19 @@ func (s *DBURLStorage) segregateBatch(urls model.URLDeleteBatch) (shortIds, user
 	}
 	return
 }
+
+// isShortIDConflict reports whether err is a unique violation of the short_id constraint.
+func isShortIDConflict(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) &&
+		pgErr.Code == pgUniqueViolationCode &&
+		pgErr.ConstraintName == shortIDUniqueConstraint
+}
+
+// isOrigURLConflict reports whether err is a unique violation of the (original_url, user_id) index.
+func isOrigURLConflict(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) &&
+		pgErr.Code == pgUniqueViolationCode &&
+		pgErr.ConstraintName == origURLUniqueIndex
+}
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// stubRowsConnector is a database/sql connector whose queries all return the same canned rows.
+type stubRowsConnector struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (c *stubRowsConnector) Connect(context.Context) (driver.Conn, error) {
+	return &stubRowsConn{c}, nil
+}
+func (c *stubRowsConnector) Driver() driver.Driver { return nil }
+
+type stubRowsConn struct{ c *stubRowsConnector }
+
+func (c *stubRowsConn) Prepare(string) (driver.Stmt, error) { return &stubRowsStmt{c.c}, nil }
+func (c *stubRowsConn) Close() error                        { return nil }
+func (c *stubRowsConn) Begin() (driver.Tx, error)           { return nil, driver.ErrSkip }
+
+type stubRowsStmt struct{ c *stubRowsConnector }
+
+func (s *stubRowsStmt) Close() error  { return nil }
+func (s *stubRowsStmt) NumInput() int { return -1 }
+func (s *stubRowsStmt) Exec([]driver.Value) (driver.Result, error) {
+	return driver.RowsAffected(0), nil
+}
+func (s *stubRowsStmt) Query([]driver.Value) (driver.Rows, error) {
+	return &stubRows{columns: s.c.columns, rows: s.c.rows}, nil
+}
+
+type stubRows struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (r *stubRows) Columns() []string { return r.columns }
+func (r *stubRows) Close() error      { return nil }
+func (r *stubRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+// stubURLRecordColumns returns the names of urlRecordColumns, in order.
+// Columns other than plain column references are named by their whole expression.
+func stubURLRecordColumns() []string {
+	var (
+		columns      []string
+		depth, start int
+	)
+	for i, c := range urlRecordColumns + "," {
+		switch {
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case c == ',' && depth == 0:
+			column := strings.TrimSpace(urlRecordColumns[start:i])
+			if !strings.Contains(column, "(") {
+				column = column[strings.LastIndex(column, ".")+1:]
+			}
+			columns = append(columns, column)
+			start = i + 1
+		}
+	}
+	return columns
+}
+
+// newStubURLRecordDB opens a database whose queries return the URL record given by urlRecordColumns names.
+// Columns missing in the record are NULL.
+func newStubURLRecordDB(t *testing.T, record map[string]driver.Value) *sql.DB {
+	t.Helper()
+	columns := stubURLRecordColumns()
+	row := make([]driver.Value, len(columns))
+	for i, c := range columns {
+		row[i] = record[c]
+	}
+	db := sql.OpenDB(&stubRowsConnector{columns: columns, rows: [][]driver.Value{row}})
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+func TestDBURLStorage_GetByShortID(t *testing.T) {
+	tests := []struct {
+		name       string
+		isDeleted  bool
+		expiresAt  driver.Value
+		maxClicks  driver.Value
+		clicksLeft driver.Value
+		wantErr    error
+	}{
+		{name: "live link", expiresAt: time.Now().Add(time.Minute), maxClicks: int64(2), clicksLeft: int64(1)},
+		{name: "deleted link", isDeleted: true, wantErr: ErrDataDeleted},
+		{name: "expired link", expiresAt: time.Now().Add(-time.Minute), wantErr: ErrDataExpired},
+		{name: "exhausted link", maxClicks: int64(1), clicksLeft: int64(0), wantErr: ErrClicksExhausted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newStubURLRecordDB(t, map[string]driver.Value{
+				"original_url":  "https://example.com",
+				"short_id":      "abc",
+				"user_uuid":     "userUUID",
+				"is_deleted":    tt.isDeleted,
+				"expires_at":    tt.expiresAt,
+				"max_clicks":    tt.maxClicks,
+				"clicks_left":   tt.clicksLeft,
+				"redirect_type": int64(307),
+				"passthrough":   false,
+			})
+			storage := NewDBURLStorage(zap.NewNop(), db)
+
+			r, err := storage.Get(t.Context(), "abc", ShortURLType)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "https://example.com", r.OrigURL)
+		})
+	}
+}
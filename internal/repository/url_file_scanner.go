@@ -34,6 +34,8 @@ func NewFileScanner(logger *zap.Logger, parser URLFileRecordParser) *URLFileScan
 
 // scan reads the provided file and extracts URL records from each line.
 // Skips empty lines and only includes records with non-empty original URLs.
+// Records with a short ID but without an original URL are counters of remaining follows:
+// they update the click-limited URL record with that short ID read earlier.
 //
 // Parameters:
 //   - file: file to scan for URL records
@@ -44,6 +46,7 @@ func NewFileScanner(logger *zap.Logger, parser URLFileRecordParser) *URLFileScan
 func (s *URLFileScanner) scan(file *os.File) ([]model.URLStorageRecord, error) {
 	scanner := bufio.NewScanner(file)
 	var records []model.URLStorageRecord
+	byShortID := make(map[string]int)
 
 	for scanner.Scan() {
 		line := scanner.Bytes()
@@ -56,7 +59,10 @@ func (s *URLFileScanner) scan(file *os.File) ([]model.URLStorageRecord, error) {
 			return nil, fmt.Errorf("parse line as record: %w", err)
 		}
 		if record.OrigURL != "" {
+			byShortID[record.ShortID] = len(records)
 			records = append(records, record)
+		} else if i, ok := byShortID[record.ShortID]; ok {
+			records[i].ClicksLeft = record.ClicksLeft
 		}
 	}
 
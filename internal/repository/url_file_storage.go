@@ -1,10 +1,16 @@
 package repository
 
 import (
+	"bufio"
 	"context"
 	"fmt"
+	"io"
 	"os"
+	"slices"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -26,11 +32,22 @@ type URLFileManager interface {
 //
 // This implementation provides crash recovery by restoring from the storage file
 // and supports fallback to a default file if the primary file is unavailable.
+//
+// Follows of click-limited URLs are appended to the storage file as counter records
+// holding only the short ID and the remaining follows, so following a link never rewrites the file.
+// History of URL changes is kept in a separate append-only file that is never rewritten.
+// Purges of deleted URLs are recorded there too, so purged short IDs stay reserved across restarts.
+// The counter of sequential short IDs is kept in its own file holding the last issued value.
 type FileURLStorage struct {
 	logger   *zap.Logger
 	fileMgr  URLFileManager
+	histMgr  URLFileManager
+	seqMgr   URLFileManager
 	fileScnr *URLFileScanner
 	records  []model.URLStorageRecord
+	history  []model.URLHistoryRecord
+	purged   map[string]struct{}
+	seq      uint64
 	mu       *sync.Mutex
 }
 
@@ -40,6 +57,8 @@ type FileURLStorage struct {
 // Parameters:
 //   - logger: structured logger for logging operations
 //   - fm: file manager for file operations
+//   - hm: file manager for the append-only history file
+//   - sm: file manager for the file of the sequential short IDs counter
 //   - fs: file scanner for reading URL records from file
 //
 // Returns:
@@ -48,18 +67,29 @@ type FileURLStorage struct {
 func NewFileURLStorage(
 	logger *zap.Logger,
 	fm URLFileManager,
+	hm URLFileManager,
+	sm URLFileManager,
 	fs *URLFileScanner,
 ) (*FileURLStorage, error) {
 	storage := &FileURLStorage{
 		logger:   logger,
 		fileMgr:  fm,
+		histMgr:  hm,
+		seqMgr:   sm,
 		fileScnr: fs,
+		purged:   make(map[string]struct{}),
 		mu:       &sync.Mutex{},
 	}
 
 	if err := storage.restoreFromFile(false); err != nil {
 		return nil, fmt.Errorf("restore storage from file: %w", err)
 	}
+	if err := storage.restoreHistoryFromFile(false); err != nil {
+		return nil, fmt.Errorf("restore history from file: %w", err)
+	}
+	if err := storage.restoreSequenceFromFile(false); err != nil {
+		return nil, fmt.Errorf("restore sequence from file: %w", err)
+	}
 	return storage, nil
 }
 
@@ -92,42 +122,42 @@ func (s *FileURLStorage) Ping(_ context.Context) error {
 //
 // Returns:
 //   - *model.URLStorageRecord: found record or nil if not found
-//   - error: nil on success, or ErrDataDeleted if URL is deleted
+//   - error: nil on success, ErrDataDeleted if URL is deleted, ErrDataExpired if URL is expired,
+//     or ErrClicksExhausted if URL has no follows left
 func (s *FileURLStorage) Get(_ context.Context, url, searchByType string) (*model.URLStorageRecord, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	for _, r := range s.records {
-		if searchByType == OrigURLType && r.OrigURL == url && !r.IsDeleted {
-			return &r, nil
-		} else if searchByType == ShortURLType && r.ShortID == url {
-			if r.IsDeleted {
-				return nil, ErrDataDeleted
-			}
-			return &r, nil
-		}
-	}
-	return nil, NewDataNotFoundError(nil)
+	return getMemRecord(s.records, url, searchByType, time.Now())
+}
+
+// GetByOrigURLs retrieves non-deleted plain URL mappings of the original URLs from file storage.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts (not used)
+//   - origURLs: original URLs to search for
+//
+// Returns:
+//   - map[string]*model.URLStorageRecord: found records by original URL
+//   - error: always nil
+func (s *FileURLStorage) GetByOrigURLs(_ context.Context, origURLs []string) (map[string]*model.URLStorageRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return getMemRecordsByOrigURLs(s.records, origURLs), nil
 }
 
 // Set stores a single URL mapping in file storage.
 //
 // Parameters:
 //   - ctx: context for cancellation and timeouts
-//   - OrigURL: original URL to be shortened
-//   - ShortID: generated short identifier
-//   - UserUUID: UUID of the user who created the mapping
+//   - r: URL storage record to persist
 //
 // Returns:
-//   - error: nil on success, or error if file write fails
-func (s *FileURLStorage) Set(ctx context.Context, OrigURL, ShortID, UserUUID string) error {
-	return s.BatchSet(ctx, []model.URLStorageRecord{
-		{
-			OrigURL:  OrigURL,
-			ShortID:  ShortID,
-			UserUUID: UserUUID,
-		},
-	})
+//   - error: nil on success, ErrShortIDConflict if short ID is already in use,
+//     or error if file write fails
+func (s *FileURLStorage) Set(ctx context.Context, r model.URLStorageRecord) error {
+	return s.BatchSet(ctx, []model.URLStorageRecord{r})
 }
 
 // BatchSet stores multiple URL mappings in file storage and persists them to disk.
@@ -138,7 +168,8 @@ func (s *FileURLStorage) Set(ctx context.Context, OrigURL, ShortID, UserUUID str
 //   - binds: slice of URL storage records to persist
 //
 // Returns:
-//   - error: nil on success, or error if file write fails
+//   - error: nil on success, ErrShortIDConflict if any short ID is already in use,
+//     or error if file write fails
 func (s *FileURLStorage) BatchSet(_ context.Context, binds []model.URLStorageRecord) error {
 	if len(binds) == 0 {
 		return nil
@@ -147,37 +178,143 @@ func (s *FileURLStorage) BatchSet(_ context.Context, binds []model.URLStorageRec
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	if err := checkMemShortIDConflict(s.records, s.purged, binds); err != nil {
+		return err
+	}
+
 	s.records = append(s.records, binds...)
 	if err := s.appendToFile(binds); err != nil {
 		// rollback
 		s.records = s.records[:len(s.records)-len(binds)]
 		return fmt.Errorf("persist records batch to file: %w", err)
 	}
+	s.appendHistory(createHistory(binds, time.Now()))
 	return nil
 }
 
-// GetByUserUUID retrieves all non-deleted URL mappings for a specific user.
+// GetByUserUUID retrieves all non-deleted URL mappings for a specific user labeled with every given tag.
 //
 // Parameters:
 //   - ctx: context for cancellation and timeouts (not used)
 //   - userUUID: UUID of the user to retrieve URLs for
+//   - tags: tags the URLs must have; empty for all URLs of the user
 //
 // Returns:
 //   - []*model.URLStorageRecord: slice of URL records belonging to the user
 //   - error: nil on success
-func (s *FileURLStorage) GetByUserUUID(_ context.Context, userUUID string) ([]*model.URLStorageRecord, error) {
+func (s *FileURLStorage) GetByUserUUID(
+	_ context.Context,
+	userUUID string,
+	tags []string,
+) ([]*model.URLStorageRecord, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	records := make([]*model.URLStorageRecord, 0, 100)
 	for _, r := range s.records {
-		if r.UserUUID == userUUID && !r.IsDeleted {
+		if r.UserUUID == userUUID && !r.IsDeleted && hasAllTags(&r, tags) {
 			records = append(records, &r)
 		}
 	}
 	return records, nil
 }
 
+// SetTags replaces the tags of a user's URL and persists the change to disk.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts (not used)
+//   - shortID: short identifier of the URL to label
+//   - userUUID: UUID of the user owning the URL
+//   - tags: sorted unique tags; empty to remove all tags
+//
+// Returns:
+//   - error: nil on success, ErrDataDeleted if URL is deleted, DataNotFoundError if the user has no such URL,
+//     or error if the file can't be saved
+func (s *FileURLStorage) SetTags(_ context.Context, shortID, userUUID string, tags []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i, err := findMemRecordToTag(s.records, shortID, userUUID)
+	if err != nil {
+		return err
+	}
+	r := &s.records[i]
+	prev := r.Tags
+	r.Tags = slices.Clone(tags)
+	if err := s.saveToFile(); err != nil {
+		// rollback
+		r.Tags = prev
+		return fmt.Errorf("save records to file: %w", err)
+	}
+	return nil
+}
+
+// GetTagsByUserUUID counts the non-deleted URLs of a specific user by tag.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts (not used)
+//   - userUUID: UUID of the user to retrieve tags for
+//
+// Returns:
+//   - []model.TagCount: tags of the user's URLs with their counts, sorted by tag
+//   - error: always returns nil
+func (s *FileURLStorage) GetTagsByUserUUID(_ context.Context, userUUID string) ([]model.TagCount, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return countMemTags(s.records, userUUID), nil
+}
+
+// GetDeletedByUserUUID retrieves all soft-deleted URL mappings for a specific user.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts (not used)
+//   - userUUID: UUID of the user to retrieve deleted URLs for
+//
+// Returns:
+//   - []*model.URLStorageRecord: slice of deleted URL records belonging to the user
+//   - error: nil on success
+func (s *FileURLStorage) GetDeletedByUserUUID(_ context.Context, userUUID string) ([]*model.URLStorageRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return getMemDeleted(s.records, userUUID), nil
+}
+
+// RestoreBatch undeletes soft-deleted URLs of the user and persists the changes to disk.
+// The file is rewritten only if at least one URL was restored; restored URLs are deleted
+// again if the file write fails.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts (not used)
+//   - userUUID: UUID of the user owning the URLs
+//   - shortIDs: short identifiers of the URLs to restore
+//
+// Returns:
+//   - []model.URLRestoreResult: outcome for every requested short identifier
+//   - error: nil on success, or error if file write fails
+func (s *FileURLStorage) RestoreBatch(
+	_ context.Context,
+	userUUID string,
+	shortIDs []string,
+) ([]model.URLRestoreResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	prev := slices.Clone(s.records)
+	results, history := processMemRestoreBatch(s.records, userUUID, shortIDs, time.Now())
+	if len(history) == 0 {
+		return results, nil
+	}
+	if err := s.saveToFile(); err != nil {
+		// rollback
+		s.records = prev
+		return nil, fmt.Errorf("save records to file: %w", err)
+	}
+	s.appendHistory(history)
+	return results, nil
+}
+
 // Count counts the amount of shortened URLs in the file storage.
 //
 // Parameters:
@@ -205,13 +342,229 @@ func (s *FileURLStorage) DeleteBatch(_ context.Context, urls model.URLDeleteBatc
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	ProcessMemDeleteBatch(s.records, urls)
+	deleted := ProcessMemDeleteBatch(s.records, urls, time.Now())
+	if err := s.saveToFile(); err != nil {
+		return fmt.Errorf("save records to file: %w", err)
+	}
+	s.appendHistory(deleted)
+	return nil
+}
+
+// SweepExpired marks all non-deleted expired URLs as deleted and persists the changes to disk.
+// The file is rewritten only if at least one URL was swept.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts (not used)
+//   - now: moment to check expiration against
+//
+// Returns:
+//   - int: amount of URLs marked as deleted
+//   - error: nil on success, or error if file write fails
+func (s *FileURLStorage) SweepExpired(_ context.Context, now time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	swept := processMemSweepExpired(s.records, now)
+	if len(swept) == 0 {
+		return 0, nil
+	}
+	if err := s.saveToFile(); err != nil {
+		return 0, fmt.Errorf("save records to file: %w", err)
+	}
+	s.appendHistory(swept)
+	return len(swept), nil
+}
+
+// PurgeDeleted removes URLs soft-deleted not after the given moment and compacts the storage file.
+// Purges are appended to the history file before compaction, so that purged short IDs stay
+// reserved even if the compaction fails; the purged records are kept in memory in that case.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts (not used)
+//   - before: URLs deleted after this moment are kept
+//   - batchSize: not used, the file is compacted at once
+//
+// Returns:
+//   - []string: short identifiers of the purged URLs
+//   - error: nil on success, or error if file write fails
+func (s *FileURLStorage) PurgeDeleted(_ context.Context, before time.Time, _ int) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	kept, purged := purgeMemDeleted(s.records, before, time.Now())
+	if len(purged) == 0 {
+		return nil, nil
+	}
+	if err := s.writeHistory(purged); err != nil {
+		return nil, fmt.Errorf("persist purged short ids: %w", err)
+	}
+	addPurgedIDs(s.purged, purged)
+
+	prev := s.records
+	s.records = kept
+	if err := s.saveToFile(); err != nil {
+		// rollback
+		s.records = prev
+		return nil, fmt.Errorf("compact storage file: %w", err)
+	}
+	s.history = dropPurgedHistory(s.history, s.purged)
+	return purgedShortIDs(purged), nil
+}
+
+// CountPurged counts the amount of URLs purged from the file storage.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts (not used)
+//
+// Returns:
+//   - int: total amount of purged URLs
+//   - error: always returns nil
+func (s *FileURLStorage) CountPurged(_ context.Context) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.purged), nil
+}
+
+// NextSequence increments the counter of sequential short IDs and persists it to disk.
+// The counter is restored if the file write fails, so the value is returned only once it is persisted.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts (not used)
+//
+// Returns:
+//   - uint64: new value of the counter
+//   - error: nil on success, or error if file write fails
+func (s *FileURLStorage) NextSequence(_ context.Context) (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.seq++
+	if err := s.saveSequence(); err != nil {
+		s.seq--
+		return 0, fmt.Errorf("save sequence to file: %w", err)
+	}
+	return s.seq, nil
+}
+
+// DecrementClicks consumes one follow of a click-limited URL and persists the changes to disk.
+// Instead of rewriting the storage file, a counter record holding only the short ID and the remaining follows
+// is appended to it; the counter records are folded into their URL records when the file is restored,
+// and dropped whenever the file is rewritten. The in-memory counter is restored if the file write fails.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts (not used)
+//   - shortID: short identifier of the followed URL
+//
+// Returns:
+//   - error: nil on success, ErrClicksExhausted if no follows left, DataNotFoundError,
+//     or error if file write fails
+func (s *FileURLStorage) DecrementClicks(_ context.Context, shortID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := slices.IndexFunc(s.records, func(r model.URLStorageRecord) bool {
+		return r.ShortID == shortID
+	})
+	if i < 0 {
+		return NewDataNotFoundError(nil)
+	}
+	r := &s.records[i]
+	if r.MaxClicks == 0 {
+		return nil
+	}
+	if err := decrementRecordClicks(r); err != nil {
+		return err
+	}
+	counter := model.URLStorageRecord{ShortID: r.ShortID, ClicksLeft: r.ClicksLeft}
+	if err := s.appendToFile([]model.URLStorageRecord{counter}); err != nil {
+		// rollback
+		r.ClicksLeft++
+		return fmt.Errorf("append clicks counter to file: %w", err)
+	}
+	return nil
+}
+
+// Update changes the original URL of a user's URL and rewrites the storage file.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts (not used)
+//   - shortID: short identifier of the URL to update
+//   - userUUID: UUID of the user owning the URL
+//   - origURL: new original URL
+//
+// Returns:
+//   - error: nil on success, ErrDataDeleted if URL is deleted, ErrOrigURLConflict
+//     if the URL is plain and the new original URL already has a plain short URL of the user, DataNotFoundError,
+//     or error if file operation fails
+func (s *FileURLStorage) Update(_ context.Context, shortID, userUUID, origURL string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i, err := findMemRecordToUpdate(s.records, shortID, userUUID, origURL)
+	if err != nil {
+		return err
+	}
+	r := &s.records[i]
+	h := updateMemRecord(r, userUUID, origURL, time.Now())
 	if err := s.saveToFile(); err != nil {
+		// rollback
+		r.OrigURL = h.PrevURL
 		return fmt.Errorf("save records to file: %w", err)
 	}
+	s.appendHistory([]model.URLHistoryRecord{h})
 	return nil
 }
 
+// GetHistory retrieves all versions of a user's URL restored from the history file.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts (not used)
+//   - shortID: short identifier of the URL
+//   - userUUID: UUID of the user owning the URL
+//
+// Returns:
+//   - []model.URLHistoryRecord: versions of the URL numbered from 1
+//   - error: nil on success, or DataNotFoundError if the user has no such URL
+func (s *FileURLStorage) GetHistory(_ context.Context, shortID, userUUID string) ([]model.URLHistoryRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return getMemHistory(s.records, s.history, shortID, userUUID)
+}
+
+// RewriteOrigURLs replaces original URLs of non-deleted URLs with their rewritten form
+// and persists the changes to disk. The in-memory records are restored if the file write fails.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts (not used)
+//   - rewrite: function returning the new form of an original URL
+//
+// Returns:
+//   - int: amount of URLs changed
+//   - int: amount of URLs left unchanged because of conflicts
+//   - error: nil on success, or error if file write fails
+func (s *FileURLStorage) RewriteOrigURLs(
+	_ context.Context,
+	rewrite func(origURL string) string,
+) (int, int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	prev := slices.Clone(s.records)
+	history, skipped := rewriteMemOrigURLs(s.records, rewrite, time.Now())
+	if len(history) == 0 {
+		return 0, skipped, nil
+	}
+	if err := s.saveToFile(); err != nil {
+		// rollback
+		s.records = prev
+		return 0, 0, fmt.Errorf("save records to file: %w", err)
+	}
+	s.appendHistory(history)
+	return len(history), skipped, nil
+}
+
 // appendToFile appends new records to the storage file.
 func (s *FileURLStorage) appendToFile(records []model.URLStorageRecord) error {
 	_, err := s.fileMgr.OpenForAppend(false)
@@ -291,6 +644,117 @@ func (s *FileURLStorage) restoreFromFile(useDefault bool) error {
 		return fmt.Errorf("scan data from default file: %w", err)
 	}
 
+	// records deleted before the deletion moment was tracked start their retention period now
+	now := time.Now()
+	for i := range records {
+		if records[i].IsDeleted && records[i].DeletedAt.IsZero() {
+			records[i].DeletedAt = now
+		}
+	}
 	s.records = records
 	return nil
 }
+
+// appendHistory adds history records to the in-memory index and appends them to the history file.
+// The change itself is already persisted at this point, so a failed history write is logged
+// instead of being reported to the caller.
+func (s *FileURLStorage) appendHistory(history []model.URLHistoryRecord) {
+	if len(history) == 0 {
+		return
+	}
+	s.history = append(s.history, history...)
+	if err := s.writeHistory(history); err != nil {
+		s.logger.Error("failed to append url history to file", zap.Error(err))
+	}
+}
+
+// writeHistory appends history records to the history file.
+func (s *FileURLStorage) writeHistory(history []model.URLHistoryRecord) error {
+	if _, err := s.histMgr.OpenForAppend(false); err != nil {
+		return fmt.Errorf("open history file for append: %w", err)
+	}
+	defer s.histMgr.Close()
+
+	for _, h := range history {
+		data, err := h.ToJSON()
+		if err != nil {
+			return fmt.Errorf("convert history record to json for store: %w", err)
+		}
+		if err := s.histMgr.WriteData(data); err != nil {
+			return fmt.Errorf("mgr persist history record to file: %w", err)
+		}
+	}
+	return nil
+}
+
+// restoreHistoryFromFile reads the history file and rebuilds the in-memory history index.
+// Supports fallback to default file if primary file is unavailable.
+func (s *FileURLStorage) restoreHistoryFromFile(useDefault bool) error {
+	f, err := s.histMgr.OpenForAppend(useDefault)
+	if err != nil && !useDefault {
+		s.logger.Warn("failed to restore history from requested file, trying default: ", zap.Error(err))
+		return s.restoreHistoryFromFile(true)
+	} else if err != nil {
+		return fmt.Errorf("open default history file: %w", err)
+	}
+	defer s.histMgr.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var h model.URLHistoryRecord
+		if err := h.FromJSON(line); err != nil {
+			return fmt.Errorf("parse history line `%s`: %w", string(line), err)
+		}
+		s.history = append(s.history, h)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("scan history file: %w", err)
+	}
+	addPurgedIDs(s.purged, s.history)
+	s.history = dropPurgedHistory(s.history, s.purged)
+	return nil
+}
+
+// saveSequence overwrites the sequence file with the current value of the counter.
+func (s *FileURLStorage) saveSequence() error {
+	if _, err := s.seqMgr.OpenForWrite(false); err != nil {
+		return fmt.Errorf("open sequence file for write: %w", err)
+	}
+	defer s.seqMgr.Close()
+
+	if err := s.seqMgr.WriteData([]byte(strconv.FormatUint(s.seq, 10))); err != nil {
+		return fmt.Errorf("mgr persist sequence to file: %w", err)
+	}
+	return nil
+}
+
+// restoreSequenceFromFile reads the last issued value of the counter from the sequence file.
+// A missing or empty file means that no sequential short IDs were issued yet.
+// Supports fallback to default file if primary file is unavailable.
+func (s *FileURLStorage) restoreSequenceFromFile(useDefault bool) error {
+	f, err := s.seqMgr.OpenForAppend(useDefault)
+	if err != nil && !useDefault {
+		s.logger.Warn("failed to restore sequence from requested file, trying default: ", zap.Error(err))
+		return s.restoreSequenceFromFile(true)
+	} else if err != nil {
+		return fmt.Errorf("open default sequence file: %w", err)
+	}
+	defer s.seqMgr.Close()
+
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return fmt.Errorf("read sequence file: %w", err)
+	}
+	value := strings.TrimSpace(string(data))
+	if value == "" {
+		return nil
+	}
+	if s.seq, err = strconv.ParseUint(value, 10, 64); err != nil {
+		return fmt.Errorf("parse sequence `%s`: %w", value, err)
+	}
+	return nil
+}
@@ -3,7 +3,10 @@ package repository
 import (
 	"encoding/json"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -72,7 +75,8 @@ func TestFileURLStorage(t *testing.T) {
 			fm := file.NewManager(tt.fileStoragePath, tt.dfltStoragePath, lgr)
 			frp := URLFileRecordParser{}
 			fs := NewFileScanner(lgr, frp)
-			storage, err := NewFileURLStorage(lgr, fm, fs)
+			hm := file.NewManager(historyFilePath(t), "", lgr)
+			storage, err := NewFileURLStorage(lgr, fm, hm, file.NewManager(sequenceFilePath(t), "", lgr), fs)
 			require.NoError(t, err)
 
 			if tt.hasRecord {
@@ -82,19 +86,188 @@ func TestFileURLStorage(t *testing.T) {
 
 			assertStorageDoesNotHaveURL(t, tt, storage)
 
-			err = storage.Set(t.Context(), tt.wantOrigURL, tt.wantShortURL, userUUID)
+			err = storage.Set(t.Context(), model.URLStorageRecord{
+				OrigURL:  tt.wantOrigURL,
+				ShortID:  tt.wantShortURL,
+				UserUUID: userUUID,
+			})
 			require.NoError(t, err)
 
 			assertStorageHasURL(t, tt, storage)
 
 			fm = file.NewManager(tt.fileStoragePath, tt.dfltStoragePath, lgr)
-			newStorage, err := NewFileURLStorage(lgr, fm, fs)
+			newStorage, err := NewFileURLStorage(lgr, fm, hm, file.NewManager(sequenceFilePath(t), "", lgr), fs)
 			require.NoError(t, err)
 			assertStorageHasURL(t, tt, newStorage)
 		})
 	}
 }
 
+func TestFileURLStorage_ShortIDConflict(t *testing.T) {
+	testDBFile := createTmpStorageFile(t)
+	defer os.Remove(testDBFile.Name())
+	fillStorageFile(t, testDBFile)
+
+	lgr := zap.NewNop()
+	fm := file.NewManager(testDBFile.Name(), "", lgr)
+	hm := file.NewManager(historyFilePath(t), "", lgr)
+	storage, err := NewFileURLStorage(lgr, fm, hm, file.NewManager(sequenceFilePath(t), "", lgr), NewFileScanner(lgr, URLFileRecordParser{}))
+	require.NoError(t, err)
+
+	err = storage.Set(t.Context(), model.URLStorageRecord{
+		OrigURL:  "https://other.com",
+		ShortID:  "abcde",
+		UserUUID: "userUUID",
+	})
+	require.ErrorIs(t, err, ErrShortIDConflict)
+
+	err = storage.BatchSet(t.Context(), []model.URLStorageRecord{
+		{OrigURL: "https://one.com", ShortID: "same", UserUUID: "userUUID"},
+		{OrigURL: "https://two.com", ShortID: "same", UserUUID: "userUUID"},
+	})
+	require.ErrorIs(t, err, ErrShortIDConflict)
+
+	cnt, err := storage.Count(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, 1, cnt)
+}
+
+func TestFileURLStorage_Expiration(t *testing.T) {
+	testDBFile := createTmpStorageFile(t)
+	defer os.Remove(testDBFile.Name())
+	fillStorageFile(t, testDBFile)
+
+	lgr := zap.NewNop()
+	fs := NewFileScanner(lgr, URLFileRecordParser{})
+	hm := file.NewManager(historyFilePath(t), "", lgr)
+	storage, err := NewFileURLStorage(lgr, file.NewManager(testDBFile.Name(), "", lgr), hm, file.NewManager(sequenceFilePath(t), "", lgr), fs)
+	require.NoError(t, err)
+
+	expiresAt := time.Now().Add(time.Hour)
+	err = storage.Set(t.Context(), model.URLStorageRecord{
+		OrigURL:   "https://expiring.com",
+		ShortID:   "expiring",
+		UserUUID:  "userUUID",
+		ExpiresAt: expiresAt,
+	})
+	require.NoError(t, err)
+
+	r, err := storage.Get(t.Context(), "expiring", ShortURLType)
+	require.NoError(t, err)
+	assert.True(t, expiresAt.Equal(r.ExpiresAt))
+
+	swept, err := storage.SweepExpired(t.Context(), time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 0, swept)
+
+	later := expiresAt.Add(time.Second)
+	swept, err = storage.SweepExpired(t.Context(), later)
+	require.NoError(t, err)
+	assert.Equal(t, 1, swept)
+
+	restored, err := NewFileURLStorage(lgr, file.NewManager(testDBFile.Name(), "", lgr), hm, file.NewManager(sequenceFilePath(t), "", lgr), fs)
+	require.NoError(t, err)
+	_, err = restored.Get(t.Context(), "expiring", ShortURLType)
+	require.ErrorIs(t, err, ErrDataDeleted)
+	var nfErr *DataNotFoundError
+	_, err = restored.Get(t.Context(), "https://expiring.com", OrigURLType)
+	require.ErrorAs(t, err, &nfErr)
+	_, err = restored.Get(t.Context(), "abcde", ShortURLType)
+	require.NoError(t, err)
+}
+
+func TestFileURLStorage_DecrementClicks(t *testing.T) {
+	testDBFile := createTmpStorageFile(t)
+	defer os.Remove(testDBFile.Name())
+	fillStorageFile(t, testDBFile)
+
+	lgr := zap.NewNop()
+	fs := NewFileScanner(lgr, URLFileRecordParser{})
+	hm := file.NewManager(historyFilePath(t), "", lgr)
+	newStorage := func() *FileURLStorage {
+		storage, err := NewFileURLStorage(lgr, file.NewManager(testDBFile.Name(), "", lgr), hm, file.NewManager(sequenceFilePath(t), "", lgr), fs)
+		require.NoError(t, err)
+		return storage
+	}
+	storage := newStorage()
+
+	err := storage.Set(t.Context(), model.URLStorageRecord{
+		OrigURL:    "https://limited.com",
+		ShortID:    "limited",
+		UserUUID:   "userUUID",
+		MaxClicks:  3,
+		ClicksLeft: 3,
+	})
+	require.NoError(t, err)
+	before, err := os.ReadFile(testDBFile.Name())
+	require.NoError(t, err)
+
+	require.NoError(t, storage.DecrementClicks(t.Context(), "limited"))
+	require.NoError(t, storage.DecrementClicks(t.Context(), "limited"))
+	require.NoError(t, storage.DecrementClicks(t.Context(), "abcde"))
+
+	data, err := os.ReadFile(testDBFile.Name())
+	require.NoError(t, err)
+	assert.Equal(t, string(before), string(data[:len(before)]), "follows are appended without rewriting the file")
+	counters := strings.Split(strings.TrimSpace(string(data[len(before):])), "\n")
+	require.Len(t, counters, 2, "only click-limited links get counter records")
+	var counter model.URLStorageRecord
+	require.NoError(t, counter.FromJSON([]byte(counters[1])))
+	assert.Equal(t, model.URLStorageRecord{ShortID: "limited", ClicksLeft: 1}, counter)
+
+	restored := newStorage()
+	r, err := restored.Get(t.Context(), "limited", ShortURLType)
+	require.NoError(t, err)
+	assert.Equal(t, 1, r.ClicksLeft)
+	count, err := restored.Count(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	require.NoError(t, restored.DecrementClicks(t.Context(), "limited"))
+	require.NoError(t, restored.Update(t.Context(), "abcde", "userUUID", "https://new.com"))
+	data, err = os.ReadFile(testDBFile.Name())
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), `"original_url":""`, "counter records are dropped when the file is rewritten")
+
+	_, err = newStorage().Get(t.Context(), "limited", ShortURLType)
+	require.ErrorIs(t, err, ErrClicksExhausted)
+}
+
+func TestFileURLStorage_History(t *testing.T) {
+	testDBFile := createTmpStorageFile(t)
+	defer os.Remove(testDBFile.Name())
+	fillStorageFile(t, testDBFile)
+
+	lgr := zap.NewNop()
+	fs := NewFileScanner(lgr, URLFileRecordParser{})
+	histPath := historyFilePath(t)
+	storage, err := NewFileURLStorage(lgr, file.NewManager(testDBFile.Name(), "", lgr), file.NewManager(histPath, "", lgr), file.NewManager(sequenceFilePath(t), "", lgr), fs)
+	require.NoError(t, err)
+
+	err = storage.Set(t.Context(), model.URLStorageRecord{OrigURL: "https://v1.com", ShortID: "hist", UserUUID: "userUUID"})
+	require.NoError(t, err)
+	require.NoError(t, storage.Update(t.Context(), "hist", "userUUID", "https://v2.com"))
+	require.NoError(t, storage.DeleteBatch(t.Context(), model.URLDeleteBatch{{ShortID: "hist", UserUUID: "userUUID"}}))
+
+	restored, err := NewFileURLStorage(lgr, file.NewManager(testDBFile.Name(), "", lgr), file.NewManager(histPath, "", lgr), file.NewManager(sequenceFilePath(t), "", lgr), fs)
+	require.NoError(t, err)
+	history, err := restored.GetHistory(t.Context(), "hist", "userUUID")
+	require.NoError(t, err)
+	require.Len(t, history, 3)
+	assert.Equal(t, 1, history[0].Version)
+	assert.Equal(t, model.URLHistoryActionCreate, history[0].Action)
+	assert.Equal(t, "https://v1.com", history[0].OrigURL)
+	assert.Equal(t, model.URLHistoryActionUpdate, history[1].Action)
+	assert.Equal(t, "https://v1.com", history[1].PrevURL)
+	assert.Equal(t, "https://v2.com", history[1].OrigURL)
+	assert.Equal(t, 3, history[2].Version)
+	assert.Equal(t, model.URLHistoryActionDelete, history[2].Action)
+
+	var nfErr *DataNotFoundError
+	_, err = restored.GetHistory(t.Context(), "hist", "anotherUUID")
+	require.ErrorAs(t, err, &nfErr)
+}
+
 func assertStorageHasURL(t *testing.T, tt testCaseData, storage URLStorage) {
 	ou, err := storage.Get(t.Context(), tt.wantShortURL, ShortURLType)
 	require.NoError(t, err)
@@ -113,6 +286,14 @@ func assertStorageDoesNotHaveURL(t *testing.T, tt testCaseData, storage URLStora
 	require.ErrorAs(t, err, &nfErrOrig)
 }
 
+func historyFilePath(t *testing.T) string {
+	return filepath.Join(t.TempDir(), "file_db_history.txt")
+}
+
+func sequenceFilePath(t *testing.T) string {
+	return filepath.Join(t.TempDir(), "file_db_seq.txt")
+}
+
 func createTmpStorageFile(t *testing.T) *os.File {
 	tmpDir := t.TempDir()
 	testDBFile, err := os.CreateTemp(tmpDir, "file_db*.txt")
@@ -140,3 +321,66 @@ func fillBadStorageFile(t *testing.T, testDBFile *os.File) {
 		t.Fatalf("failed to write bad test record to bad storage file: %v", err)
 	}
 }
+
+func TestFileURLStorage_PurgeDeleted(t *testing.T) {
+	testDBFile := createTmpStorageFile(t)
+	defer os.Remove(testDBFile.Name())
+	fillStorageFile(t, testDBFile)
+
+	lgr := zap.NewNop()
+	fs := NewFileScanner(lgr, URLFileRecordParser{})
+	histPath := historyFilePath(t)
+	storage, err := NewFileURLStorage(lgr, file.NewManager(testDBFile.Name(), "", lgr), file.NewManager(histPath, "", lgr), file.NewManager(sequenceFilePath(t), "", lgr), fs)
+	require.NoError(t, err)
+
+	err = storage.Set(t.Context(), model.URLStorageRecord{OrigURL: "https://purged.com", ShortID: "purged", UserUUID: "userUUID"})
+	require.NoError(t, err)
+	require.NoError(t, storage.DeleteBatch(t.Context(), model.URLDeleteBatch{{ShortID: "purged", UserUUID: "userUUID"}}))
+
+	ids, err := storage.PurgeDeleted(t.Context(), time.Now().Add(-time.Hour), 10)
+	require.NoError(t, err)
+	require.Empty(t, ids)
+	ids, err = storage.PurgeDeleted(t.Context(), time.Now(), 10)
+	require.NoError(t, err)
+	require.Equal(t, []string{"purged"}, ids)
+
+	data, err := os.ReadFile(testDBFile.Name())
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "https://purged.com")
+
+	restored, err := NewFileURLStorage(lgr, file.NewManager(testDBFile.Name(), "", lgr), file.NewManager(histPath, "", lgr), file.NewManager(sequenceFilePath(t), "", lgr), fs)
+	require.NoError(t, err)
+	count, err := restored.Count(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	purged, err := restored.CountPurged(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, 1, purged)
+
+	err = restored.Set(t.Context(), model.URLStorageRecord{OrigURL: "https://new.com", ShortID: "purged", UserUUID: "userUUID"})
+	require.ErrorIs(t, err, ErrShortIDConflict)
+}
+
+func TestFileURLStorage_NextSequence(t *testing.T) {
+	testDBFile := createTmpStorageFile(t)
+	defer os.Remove(testDBFile.Name())
+
+	lgr := zap.NewNop()
+	fs := NewFileScanner(lgr, URLFileRecordParser{})
+	histPath := historyFilePath(t)
+	seqPath := sequenceFilePath(t)
+	storage, err := NewFileURLStorage(lgr, file.NewManager(testDBFile.Name(), "", lgr), file.NewManager(histPath, "", lgr), file.NewManager(seqPath, "", lgr), fs)
+	require.NoError(t, err)
+
+	for want := uint64(1); want <= 3; want++ {
+		n, err := storage.NextSequence(t.Context())
+		require.NoError(t, err)
+		require.Equal(t, want, n)
+	}
+
+	restored, err := NewFileURLStorage(lgr, file.NewManager(testDBFile.Name(), "", lgr), file.NewManager(histPath, "", lgr), file.NewManager(seqPath, "", lgr), fs)
+	require.NoError(t, err)
+	n, err := restored.NextSequence(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), n)
+}
@@ -2,7 +2,11 @@ package repository
 
 import (
 	"context"
+	"fmt"
+	"maps"
+	"slices"
 	"sync"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -20,6 +24,9 @@ import (
 type MemoryURLStorage struct {
 	logger  *zap.Logger
 	records []model.URLStorageRecord
+	history []model.URLHistoryRecord
+	purged  map[string]struct{}
+	seq     uint64
 	mu      *sync.Mutex
 }
 
@@ -34,6 +41,7 @@ func NewMemoryURLStorage(logger *zap.Logger) *MemoryURLStorage {
 	return &MemoryURLStorage{
 		logger:  logger,
 		records: make([]model.URLStorageRecord, 0, 250000),
+		purged:  make(map[string]struct{}),
 		mu:      &sync.Mutex{},
 	}
 }
@@ -67,50 +75,48 @@ func (s *MemoryURLStorage) Ping(_ context.Context) error {
 //
 // Returns:
 //   - *model.URLStorageRecord: found record or nil if not found
-//   - error: nil on success, or ErrDataDeleted if URL is deleted
+//   - error: nil on success, ErrDataDeleted if URL is deleted, ErrDataExpired if URL is expired,
+//     or ErrClicksExhausted if URL has no follows left
 func (s *MemoryURLStorage) Get(_ context.Context, url, searchByType string) (*model.URLStorageRecord, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	switch searchByType {
-	case OrigURLType:
-		for _, r := range s.records {
-			if r.OrigURL == url && !r.IsDeleted {
-				return &r, nil
-			}
-		}
-	case ShortURLType:
-		for _, r := range s.records {
-			if r.ShortID == url {
-				if r.IsDeleted {
-					return nil, ErrDataDeleted
-				}
-				return &r, nil
-			}
-		}
-	}
-	return nil, NewDataNotFoundError(nil)
+	return getMemRecord(s.records, url, searchByType, time.Now())
+}
+
+// GetByOrigURLs retrieves non-deleted plain URL mappings of the original URLs from memory storage.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts (not used)
+//   - origURLs: original URLs to search for
+//
+// Returns:
+//   - map[string]*model.URLStorageRecord: found records by original URL
+//   - error: always nil
+func (s *MemoryURLStorage) GetByOrigURLs(_ context.Context, origURLs []string) (map[string]*model.URLStorageRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return getMemRecordsByOrigURLs(s.records, origURLs), nil
 }
 
 // Set stores a single URL mapping in memory storage.
 //
 // Parameters:
 //   - ctx: context for cancellation and timeouts (not used)
-//   - origURL: original URL to be shortened
-//   - shortID: generated short identifier
-//   - userUUID: UUID of the user who created the mapping
+//   - r: URL storage record to persist
 //
 // Returns:
-//   - error: nil on success
-func (s *MemoryURLStorage) Set(_ context.Context, origURL, shortID, userUUID string) error {
+//   - error: nil on success, or ErrShortIDConflict if short ID is already in use
+func (s *MemoryURLStorage) Set(_ context.Context, r model.URLStorageRecord) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	s.records = append(s.records, model.URLStorageRecord{
-		OrigURL:  origURL,
-		ShortID:  shortID,
-		UserUUID: userUUID,
-	})
+	if err := checkMemShortIDConflict(s.records, s.purged, []model.URLStorageRecord{r}); err != nil {
+		return err
+	}
+	s.records = append(s.records, r)
+	s.history = append(s.history, createHistory([]model.URLStorageRecord{r}, time.Now())...)
 	return nil
 }
 
@@ -121,38 +127,124 @@ func (s *MemoryURLStorage) Set(_ context.Context, origURL, shortID, userUUID str
 //   - records: slice of URL storage records to persist
 //
 // Returns:
-//   - error: nil on success
+//   - error: nil on success, or ErrShortIDConflict if any short ID is already in use
 func (s *MemoryURLStorage) BatchSet(_ context.Context, records []model.URLStorageRecord) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	if err := checkMemShortIDConflict(s.records, s.purged, records); err != nil {
+		return err
+	}
 	s.records = append(s.records, records...)
+	s.history = append(s.history, createHistory(records, time.Now())...)
 	return nil
 }
 
-// GetByUserUUID retrieves all non-deleted URL mappings for a specific user.
+// GetByUserUUID retrieves all non-deleted URL mappings for a specific user labeled with every given tag.
 //
 // Parameters:
 //   - ctx: context for cancellation and timeouts (not used)
 //   - userUUID: UUID of the user to retrieve URLs for
+//   - tags: tags the URLs must have; empty for all URLs of the user
 //
 // Returns:
 //   - []*model.URLStorageRecord: slice of URL records belonging to the user
 //   - error: nil on success
-func (s *MemoryURLStorage) GetByUserUUID(_ context.Context, userUUID string) ([]*model.URLStorageRecord, error) {
+func (s *MemoryURLStorage) GetByUserUUID(
+	_ context.Context,
+	userUUID string,
+	tags []string,
+) ([]*model.URLStorageRecord, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	records := make([]*model.URLStorageRecord, 0, 50)
 	for i := range s.records {
 		r := &s.records[i]
-		if r.UserUUID == userUUID && !r.IsDeleted {
+		if r.UserUUID == userUUID && !r.IsDeleted && hasAllTags(r, tags) {
 			records = append(records, r)
 		}
 	}
 	return records, nil
 }
 
+// SetTags replaces the tags of a user's URL in memory storage.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts (not used)
+//   - shortID: short identifier of the URL to label
+//   - userUUID: UUID of the user owning the URL
+//   - tags: sorted unique tags; empty to remove all tags
+//
+// Returns:
+//   - error: nil on success, ErrDataDeleted if URL is deleted, or DataNotFoundError if the user has no such URL
+func (s *MemoryURLStorage) SetTags(_ context.Context, shortID, userUUID string, tags []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i, err := findMemRecordToTag(s.records, shortID, userUUID)
+	if err != nil {
+		return err
+	}
+	s.records[i].Tags = slices.Clone(tags)
+	return nil
+}
+
+// GetTagsByUserUUID counts the non-deleted URLs of a specific user in memory storage by tag.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts (not used)
+//   - userUUID: UUID of the user to retrieve tags for
+//
+// Returns:
+//   - []model.TagCount: tags of the user's URLs with their counts, sorted by tag
+//   - error: always returns nil
+func (s *MemoryURLStorage) GetTagsByUserUUID(_ context.Context, userUUID string) ([]model.TagCount, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return countMemTags(s.records, userUUID), nil
+}
+
+// GetDeletedByUserUUID retrieves all soft-deleted URL mappings for a specific user.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts (not used)
+//   - userUUID: UUID of the user to retrieve deleted URLs for
+//
+// Returns:
+//   - []*model.URLStorageRecord: slice of deleted URL records belonging to the user
+//   - error: nil on success
+func (s *MemoryURLStorage) GetDeletedByUserUUID(_ context.Context, userUUID string) ([]*model.URLStorageRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return getMemDeleted(s.records, userUUID), nil
+}
+
+// RestoreBatch undeletes soft-deleted URLs of the user in memory storage.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts (not used)
+//   - userUUID: UUID of the user owning the URLs
+//   - shortIDs: short identifiers of the URLs to restore
+//
+// Returns:
+//   - []model.URLRestoreResult: outcome for every requested short identifier
+//   - error: always returns nil
+func (s *MemoryURLStorage) RestoreBatch(
+	_ context.Context,
+	userUUID string,
+	shortIDs []string,
+) ([]model.URLRestoreResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	results, history := processMemRestoreBatch(s.records, userUUID, shortIDs, time.Now())
+	s.history = append(s.history, history...)
+	return results, nil
+}
+
 // Count counts the amount of shortened URLs in memory storage.
 //
 // Parameters:
@@ -180,19 +272,183 @@ func (s *MemoryURLStorage) DeleteBatch(_ context.Context, urls model.URLDeleteBa
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	ProcessMemDeleteBatch(s.records, urls)
+	s.history = append(s.history, ProcessMemDeleteBatch(s.records, urls, time.Now())...)
+	return nil
+}
+
+// SweepExpired marks all non-deleted expired URLs in memory storage as deleted.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts (not used)
+//   - now: moment to check expiration against
+//
+// Returns:
+//   - int: amount of URLs marked as deleted
+//   - error: always returns nil
+func (s *MemoryURLStorage) SweepExpired(_ context.Context, now time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	swept := processMemSweepExpired(s.records, now)
+	s.history = append(s.history, swept...)
+	return len(swept), nil
+}
+
+// PurgeDeleted removes URLs soft-deleted not after the given moment from memory storage
+// together with their history. Short IDs of the purged URLs stay reserved.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts (not used)
+//   - before: URLs deleted after this moment are kept
+//   - batchSize: not used, all URLs are purged at once
+//
+// Returns:
+//   - []string: short identifiers of the purged URLs
+//   - error: always returns nil
+func (s *MemoryURLStorage) PurgeDeleted(_ context.Context, before time.Time, _ int) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	kept, purged := purgeMemDeleted(s.records, before, time.Now())
+	if len(purged) == 0 {
+		return nil, nil
+	}
+	s.records = kept
+	addPurgedIDs(s.purged, purged)
+	s.history = dropPurgedHistory(s.history, s.purged)
+	return purgedShortIDs(purged), nil
+}
+
+// CountPurged counts the amount of URLs purged from memory storage.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts (not used)
+//
+// Returns:
+//   - int: total amount of purged URLs
+//   - error: always returns nil
+func (s *MemoryURLStorage) CountPurged(_ context.Context) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.purged), nil
+}
+
+// NextSequence increments the in-memory counter of sequential short IDs.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts (not used)
+//
+// Returns:
+//   - uint64: new value of the counter
+//   - error: always returns nil
+func (s *MemoryURLStorage) NextSequence(_ context.Context) (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.seq++
+	return s.seq, nil
+}
+
+// DecrementClicks consumes one follow of a click-limited URL in memory storage.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts (not used)
+//   - shortID: short identifier of the followed URL
+//
+// Returns:
+//   - error: nil on success, ErrClicksExhausted if no follows left, or DataNotFoundError
+func (s *MemoryURLStorage) DecrementClicks(_ context.Context, shortID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.records {
+		if s.records[i].ShortID == shortID {
+			return decrementRecordClicks(&s.records[i])
+		}
+	}
+	return NewDataNotFoundError(nil)
+}
+
+// Update changes the original URL of a user's URL in memory storage.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts (not used)
+//   - shortID: short identifier of the URL to update
+//   - userUUID: UUID of the user owning the URL
+//   - origURL: new original URL
+//
+// Returns:
+//   - error: nil on success, ErrDataDeleted if URL is deleted, ErrOrigURLConflict
+//     if the URL is plain and the new original URL already has a plain short URL of the user, or DataNotFoundError
+func (s *MemoryURLStorage) Update(_ context.Context, shortID, userUUID, origURL string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i, err := findMemRecordToUpdate(s.records, shortID, userUUID, origURL)
+	if err != nil {
+		return err
+	}
+	s.history = append(s.history, updateMemRecord(&s.records[i], userUUID, origURL, time.Now()))
 	return nil
 }
 
+// GetHistory retrieves all versions of a user's URL from memory storage.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts (not used)
+//   - shortID: short identifier of the URL
+//   - userUUID: UUID of the user owning the URL
+//
+// Returns:
+//   - []model.URLHistoryRecord: versions of the URL numbered from 1
+//   - error: nil on success, or DataNotFoundError if the user has no such URL
+func (s *MemoryURLStorage) GetHistory(_ context.Context, shortID, userUUID string) ([]model.URLHistoryRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return getMemHistory(s.records, s.history, shortID, userUUID)
+}
+
+// RewriteOrigURLs replaces original URLs of non-deleted URLs in memory storage with their rewritten form.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts (not used)
+//   - rewrite: function returning the new form of an original URL
+//
+// Returns:
+//   - int: amount of URLs changed
+//   - int: amount of URLs left unchanged because of conflicts
+//   - error: always returns nil
+func (s *MemoryURLStorage) RewriteOrigURLs(
+	_ context.Context,
+	rewrite func(origURL string) string,
+) (int, int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	history, skipped := rewriteMemOrigURLs(s.records, rewrite, time.Now())
+	s.history = append(s.history, history...)
+	return len(history), skipped, nil
+}
+
 // ProcessMemDeleteBatch processes URL deletion in memory by marking records as deleted.
 // This function is used by both MemoryURLStorage and FileURLStorage implementations.
 //
 // Parameters:
 //   - records: slice of URL storage records to process
 //   - urls: batch of URLs to mark as deleted
-func ProcessMemDeleteBatch(records []model.URLStorageRecord, urls model.URLDeleteBatch) {
+//   - now: moment of the deletion
+//
+// Returns:
+//   - []model.URLHistoryRecord: history records of the URLs that were actually deleted
+func ProcessMemDeleteBatch(
+	records []model.URLStorageRecord,
+	urls model.URLDeleteBatch,
+	now time.Time,
+) []model.URLHistoryRecord {
 	if len(urls) == 0 {
-		return
+		return nil
 	}
 
 	deleteMap := make(map[string]map[string]bool)
@@ -203,12 +459,371 @@ func ProcessMemDeleteBatch(records []model.URLStorageRecord, urls model.URLDelet
 		deleteMap[u.ShortID][u.UserUUID] = true
 	}
 
+	var history []model.URLHistoryRecord
 	for i := range records {
 		r := &records[i]
 		if userMap, exists := deleteMap[r.ShortID]; exists {
-			if userMap[r.UserUUID] {
+			if userMap[r.UserUUID] && !r.IsDeleted {
 				r.IsDeleted = true
+				r.DeletedAt = now
+				history = append(history, model.NewURLHistoryRecord(*r, model.URLHistoryActionDelete, "", r.UserUUID, now))
+			}
+		}
+	}
+	return history
+}
+
+// checkMemShortIDConflict verifies that none of the new records reuses a short ID
+// already present in records (deleted ones included), freed by a purge, or repeated within the batch itself.
+//
+// Parameters:
+//   - records: slice of already stored URL records
+//   - purged: set of short IDs of purged URLs
+//   - binds: slice of URL records about to be stored
+//
+// Returns:
+//   - error: nil if all short IDs are free, or ErrShortIDConflict otherwise
+func checkMemShortIDConflict(
+	records []model.URLStorageRecord,
+	purged map[string]struct{},
+	binds []model.URLStorageRecord,
+) error {
+	newIDs := make(map[string]struct{}, len(binds))
+	for _, b := range binds {
+		if _, exists := newIDs[b.ShortID]; exists {
+			return fmt.Errorf("duplicate short id `%s` in batch: %w", b.ShortID, ErrShortIDConflict)
+		}
+		if _, exists := purged[b.ShortID]; exists {
+			return fmt.Errorf("purged short id `%s`: %w", b.ShortID, ErrShortIDConflict)
+		}
+		newIDs[b.ShortID] = struct{}{}
+	}
+	for _, r := range records {
+		if _, exists := newIDs[r.ShortID]; exists {
+			return fmt.Errorf("short id `%s`: %w", r.ShortID, ErrShortIDConflict)
+		}
+	}
+	return nil
+}
+
+// getMemRecord looks up a record by short ID or original URL.
+// Only non-deleted plain records are found by original URL, since only they are deduplicated;
+// deleted, expired and exhausted records are reported with ErrDataDeleted, ErrDataExpired
+// or ErrClicksExhausted when searching by short ID.
+func getMemRecord(records []model.URLStorageRecord, url, searchByType string, now time.Time) (*model.URLStorageRecord, error) {
+	for _, r := range records {
+		if searchByType == OrigURLType && r.OrigURL == url && !r.IsDeleted && r.IsPlain() {
+			return &r, nil
+		} else if searchByType == ShortURLType && r.ShortID == url {
+			if r.IsDeleted {
+				return nil, ErrDataDeleted
+			}
+			if r.IsExpired(now) {
+				return nil, ErrDataExpired
+			}
+			if r.IsExhausted() {
+				return nil, ErrClicksExhausted
+			}
+			return &r, nil
+		}
+	}
+	return nil, NewDataNotFoundError(nil)
+}
+
+// getMemRecordsByOrigURLs looks up non-deleted plain records by original URLs the same way as getMemRecord.
+func getMemRecordsByOrigURLs(
+	records []model.URLStorageRecord,
+	origURLs []string,
+) map[string]*model.URLStorageRecord {
+	wanted := make(map[string]struct{}, len(origURLs))
+	for _, u := range origURLs {
+		wanted[u] = struct{}{}
+	}
+	found := make(map[string]*model.URLStorageRecord)
+	for _, r := range records {
+		if _, ok := wanted[r.OrigURL]; !ok || r.IsDeleted || !r.IsPlain() {
+			continue
+		}
+		if _, ok := found[r.OrigURL]; !ok {
+			found[r.OrigURL] = &r
+		}
+	}
+	return found
+}
+
+// processMemSweepExpired marks non-deleted expired records as deleted and returns their history records.
+func processMemSweepExpired(records []model.URLStorageRecord, now time.Time) []model.URLHistoryRecord {
+	var swept []model.URLHistoryRecord
+	for i := range records {
+		r := &records[i]
+		if !r.IsDeleted && r.IsExpired(now) {
+			r.IsDeleted = true
+			r.DeletedAt = now
+			swept = append(swept, model.NewURLHistoryRecord(*r, model.URLHistoryActionExpire, "", "", now))
+		}
+	}
+	return swept
+}
+
+// decrementRecordClicks consumes one follow of a click-limited record.
+// Records without click limit are left untouched.
+func decrementRecordClicks(r *model.URLStorageRecord) error {
+	if r.MaxClicks == 0 {
+		return nil
+	}
+	if r.IsExhausted() {
+		return ErrClicksExhausted
+	}
+	r.ClicksLeft--
+	return nil
+}
+
+// findMemRecordToUpdate returns the index of the user's record with the given short ID
+// after checking that it can be pointed at origURL: a plain record can't share it with another plain record.
+func findMemRecordToUpdate(records []model.URLStorageRecord, shortID, userUUID, origURL string) (int, error) {
+	idx := slices.IndexFunc(records, func(r model.URLStorageRecord) bool {
+		return r.ShortID == shortID && r.UserUUID == userUUID
+	})
+	if idx < 0 {
+		return -1, NewDataNotFoundError(nil)
+	}
+	if records[idx].IsDeleted {
+		return -1, ErrDataDeleted
+	}
+	if !records[idx].IsPlain() {
+		return idx, nil
+	}
+	for _, r := range records {
+		if r.UserUUID == userUUID && r.ShortID != shortID && r.OrigURL == origURL && !r.IsDeleted && r.IsPlain() {
+			return -1, fmt.Errorf("short id `%s`: %w", r.ShortID, ErrOrigURLConflict)
+		}
+	}
+	return idx, nil
+}
+
+// hasAllTags reports whether the record is labeled with every one of the tags.
+func hasAllTags(r *model.URLStorageRecord, tags []string) bool {
+	for _, t := range tags {
+		if !slices.Contains(r.Tags, t) {
+			return false
+		}
+	}
+	return true
+}
+
+// findMemRecordToTag returns the index of the user's non-deleted record with the given short ID.
+func findMemRecordToTag(records []model.URLStorageRecord, shortID, userUUID string) (int, error) {
+	idx := slices.IndexFunc(records, func(r model.URLStorageRecord) bool {
+		return r.ShortID == shortID && r.UserUUID == userUUID
+	})
+	if idx < 0 {
+		return -1, NewDataNotFoundError(nil)
+	}
+	if records[idx].IsDeleted {
+		return -1, ErrDataDeleted
+	}
+	return idx, nil
+}
+
+// countMemTags counts the user's non-deleted records by tag.
+func countMemTags(records []model.URLStorageRecord, userUUID string) []model.TagCount {
+	counts := make(map[string]int)
+	for _, r := range records {
+		if r.UserUUID != userUUID || r.IsDeleted {
+			continue
+		}
+		for _, t := range r.Tags {
+			counts[t]++
+		}
+	}
+	res := make([]model.TagCount, 0, len(counts))
+	for _, t := range slices.Sorted(maps.Keys(counts)) {
+		res = append(res, model.TagCount{Tag: t, Count: counts[t]})
+	}
+	return res
+}
+
+// updateMemRecord points the record at origURL and returns the history record of the change.
+func updateMemRecord(r *model.URLStorageRecord, userUUID, origURL string, now time.Time) model.URLHistoryRecord {
+	prevURL := r.OrigURL
+	r.OrigURL = origURL
+	return model.NewURLHistoryRecord(*r, model.URLHistoryActionUpdate, prevURL, userUUID, now)
+}
+
+// createHistory returns the history records of newly stored records.
+func createHistory(records []model.URLStorageRecord, now time.Time) []model.URLHistoryRecord {
+	history := make([]model.URLHistoryRecord, len(records))
+	for i, r := range records {
+		history[i] = model.NewURLHistoryRecord(r, model.URLHistoryActionCreate, "", r.UserUUID, now)
+	}
+	return history
+}
+
+// getMemHistory returns numbered versions of the user's URL with the given short ID.
+// Deleted URLs keep their history, so they are looked up too.
+func getMemHistory(
+	records []model.URLStorageRecord,
+	history []model.URLHistoryRecord,
+	shortID, userUUID string,
+) ([]model.URLHistoryRecord, error) {
+	owned := slices.ContainsFunc(records, func(r model.URLStorageRecord) bool {
+		return r.ShortID == shortID && r.UserUUID == userUUID
+	})
+	if !owned {
+		return nil, NewDataNotFoundError(nil)
+	}
+
+	versions := make([]model.URLHistoryRecord, 0)
+	for _, h := range history {
+		if h.ShortID == shortID {
+			h.Version = len(versions) + 1
+			versions = append(versions, h)
+		}
+	}
+	return versions, nil
+}
+
+// getMemDeleted returns pointers to the soft-deleted records of the user.
+func getMemDeleted(records []model.URLStorageRecord, userUUID string) []*model.URLStorageRecord {
+	deleted := make([]*model.URLStorageRecord, 0)
+	for i := range records {
+		r := records[i]
+		if r.UserUUID == userUUID && r.IsDeleted {
+			deleted = append(deleted, &r)
+		}
+	}
+	return deleted
+}
+
+// processMemRestoreBatch undeletes the user's records with the given short IDs
+// unless they have expired, or they are plain and the user has another live plain record for the same original URL.
+//
+// Returns:
+//   - []model.URLRestoreResult: outcome for every requested short identifier
+//   - []model.URLHistoryRecord: history records of the restored URLs
+func processMemRestoreBatch(
+	records []model.URLStorageRecord,
+	userUUID string,
+	shortIDs []string,
+	now time.Time,
+) ([]model.URLRestoreResult, []model.URLHistoryRecord) {
+	results := make([]model.URLRestoreResult, len(shortIDs))
+	var history []model.URLHistoryRecord
+	for i, shortID := range shortIDs {
+		results[i] = model.URLRestoreResult{ShortID: shortID, Status: model.URLRestoreStatusNotFound}
+		idx := slices.IndexFunc(records, func(r model.URLStorageRecord) bool {
+			return r.ShortID == shortID && r.UserUUID == userUUID && r.IsDeleted
+		})
+		if idx < 0 {
+			continue
+		}
+		r := &records[idx]
+		results[i].OrigURL = r.OrigURL
+		if r.IsExpired(now) {
+			results[i].Status = model.URLRestoreStatusExpired
+			continue
+		}
+		hasLive := r.IsPlain() && slices.ContainsFunc(records, func(o model.URLStorageRecord) bool {
+			return o.OrigURL == r.OrigURL && o.UserUUID == userUUID && !o.IsDeleted && o.IsPlain()
+		})
+		if hasLive {
+			results[i].Status = model.URLRestoreStatusConflict
+			continue
+		}
+		r.IsDeleted = false
+		r.DeletedAt = time.Time{}
+		results[i].Status = model.URLRestoreStatusRestored
+		history = append(history, model.NewURLHistoryRecord(*r, model.URLHistoryActionRestore, "", userUUID, now))
+	}
+	return results, history
+}
+
+// purgeMemDeleted splits records into the kept ones and the ones soft-deleted not after before.
+//
+// Returns:
+//   - []model.URLStorageRecord: records to keep
+//   - []model.URLHistoryRecord: history records of the purged URLs
+func purgeMemDeleted(
+	records []model.URLStorageRecord,
+	before, now time.Time,
+) ([]model.URLStorageRecord, []model.URLHistoryRecord) {
+	kept := make([]model.URLStorageRecord, 0, len(records))
+	var purged []model.URLHistoryRecord
+	for _, r := range records {
+		if r.IsDeleted && !r.DeletedAt.After(before) {
+			purged = append(purged, model.NewURLHistoryRecord(r, model.URLHistoryActionPurge, "", "", now))
+			continue
+		}
+		kept = append(kept, r)
+	}
+	return kept, purged
+}
+
+// addPurgedIDs adds short IDs of the purge history records to the set of purged short IDs.
+func addPurgedIDs(purged map[string]struct{}, history []model.URLHistoryRecord) {
+	for _, h := range history {
+		if h.Action == model.URLHistoryActionPurge {
+			purged[h.ShortID] = struct{}{}
+		}
+	}
+}
+
+// purgedShortIDs returns short IDs of the purge history records.
+func purgedShortIDs(history []model.URLHistoryRecord) []string {
+	ids := make([]string, 0, len(history))
+	for _, h := range history {
+		if h.Action == model.URLHistoryActionPurge {
+			ids = append(ids, h.ShortID)
+		}
+	}
+	return ids
+}
+
+// dropPurgedHistory removes history records of purged URLs, since their versions can't be viewed anymore.
+func dropPurgedHistory(history []model.URLHistoryRecord, purged map[string]struct{}) []model.URLHistoryRecord {
+	return slices.DeleteFunc(history, func(h model.URLHistoryRecord) bool {
+		_, isPurged := purged[h.ShortID]
+		return isPurged
+	})
+}
+
+// rewriteMemOrigURLs replaces original URLs of non-deleted records with their rewritten form,
+// skipping plain records whose rewritten URL is already used by another non-deleted plain record.
+//
+// Returns:
+//   - []model.URLHistoryRecord: history records of the changed URLs
+//   - int: amount of records skipped because of conflicts
+func rewriteMemOrigURLs(
+	records []model.URLStorageRecord,
+	rewrite func(origURL string) string,
+	now time.Time,
+) ([]model.URLHistoryRecord, int) {
+	live := make(map[string]struct{}, len(records))
+	for _, r := range records {
+		if !r.IsDeleted && r.IsPlain() {
+			live[r.OrigURL] = struct{}{}
+		}
+	}
+
+	var history []model.URLHistoryRecord
+	skipped := 0
+	for i := range records {
+		r := &records[i]
+		if r.IsDeleted {
+			continue
+		}
+		newURL := rewrite(r.OrigURL)
+		if newURL == r.OrigURL {
+			continue
+		}
+		if r.IsPlain() {
+			if _, taken := live[newURL]; taken {
+				skipped++
+				continue
 			}
+			live[newURL] = struct{}{}
 		}
+		history = append(history, updateMemRecord(r, "", newURL, now))
 	}
+	return history, skipped
 }
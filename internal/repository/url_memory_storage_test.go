@@ -0,0 +1,221 @@
+package repository
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/alex-storchak/shortener/internal/model"
+)
+
+func TestMemoryURLStorage_GetExpired(t *testing.T) {
+	storage := NewMemoryURLStorage(zap.NewNop())
+	err := storage.BatchSet(t.Context(), []model.URLStorageRecord{
+		{OrigURL: "https://expired.com", ShortID: "expired", UserUUID: "userUUID", ExpiresAt: time.Now().Add(-time.Minute)},
+	})
+	require.NoError(t, err)
+
+	_, err = storage.Get(t.Context(), "expired", ShortURLType)
+	require.ErrorIs(t, err, ErrDataExpired)
+	var nfErr *DataNotFoundError
+	_, err = storage.Get(t.Context(), "https://expired.com", OrigURLType)
+	require.ErrorAs(t, err, &nfErr)
+}
+
+func TestMemoryURLStorage_DecrementClicks(t *testing.T) {
+	storage := NewMemoryURLStorage(zap.NewNop())
+	err := storage.BatchSet(t.Context(), []model.URLStorageRecord{
+		{OrigURL: "https://limited.com", ShortID: "limited", UserUUID: "userUUID", MaxClicks: 2, ClicksLeft: 2},
+		{OrigURL: "https://unlimited.com", ShortID: "unlimited", UserUUID: "userUUID"},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, storage.DecrementClicks(t.Context(), "limited"))
+	require.NoError(t, storage.DecrementClicks(t.Context(), "limited"))
+	require.ErrorIs(t, storage.DecrementClicks(t.Context(), "limited"), ErrClicksExhausted)
+	_, err = storage.Get(t.Context(), "limited", ShortURLType)
+	require.ErrorIs(t, err, ErrClicksExhausted)
+
+	require.NoError(t, storage.DecrementClicks(t.Context(), "unlimited"))
+	_, err = storage.Get(t.Context(), "unlimited", ShortURLType)
+	require.NoError(t, err)
+}
+
+func TestMemoryURLStorage_Update(t *testing.T) {
+	storage := NewMemoryURLStorage(zap.NewNop())
+	err := storage.BatchSet(t.Context(), []model.URLStorageRecord{
+		{OrigURL: "https://one.com", ShortID: "one", UserUUID: "userUUID"},
+		{OrigURL: "https://two.com", ShortID: "two", UserUUID: "userUUID"},
+		{OrigURL: "https://gone.com", ShortID: "gone", UserUUID: "userUUID", IsDeleted: true},
+		{OrigURL: "https://limited.com", ShortID: "limited", UserUUID: "userUUID", MaxClicks: 5, ClicksLeft: 5},
+		{OrigURL: "https://secret.com", ShortID: "secret", UserUUID: "userUUID", PassHash: "hash"},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, storage.Update(t.Context(), "one", "userUUID", "https://new.com"))
+	r, err := storage.Get(t.Context(), "one", ShortURLType)
+	require.NoError(t, err)
+	require.Equal(t, "https://new.com", r.OrigURL)
+
+	require.ErrorIs(t, storage.Update(t.Context(), "one", "userUUID", "https://two.com"), ErrOrigURLConflict)
+	require.NoError(t, storage.Update(t.Context(), "limited", "userUUID", "https://two.com"))
+	r, err = storage.Get(t.Context(), "https://two.com", OrigURLType)
+	require.NoError(t, err)
+	require.Equal(t, "two", r.ShortID, "only plain links are found by original url")
+	require.NoError(t, storage.Update(t.Context(), "two", "userUUID", "https://limited.com"))
+	require.NoError(t, storage.Update(t.Context(), "secret", "userUUID", "https://new.com"))
+	require.NoError(t, storage.Update(t.Context(), "one", "userUUID", "https://secret.com"))
+	require.ErrorIs(t, storage.Update(t.Context(), "gone", "userUUID", "https://other.com"), ErrDataDeleted)
+	var nfErr *DataNotFoundError
+	require.ErrorAs(t, storage.Update(t.Context(), "one", "anotherUUID", "https://other.com"), &nfErr)
+	require.ErrorAs(t, storage.Update(t.Context(), "missing", "userUUID", "https://other.com"), &nfErr)
+}
+
+func TestMemoryURLStorage_Tags(t *testing.T) {
+	storage := NewMemoryURLStorage(zap.NewNop())
+	err := storage.BatchSet(t.Context(), []model.URLStorageRecord{
+		{OrigURL: "https://one.com", ShortID: "one", UserUUID: "userUUID", Tags: []string{"promo", "q1"}},
+		{OrigURL: "https://two.com", ShortID: "two", UserUUID: "userUUID", Tags: []string{"promo"}},
+		{OrigURL: "https://three.com", ShortID: "three", UserUUID: "userUUID"},
+		{OrigURL: "https://gone.com", ShortID: "gone", UserUUID: "userUUID", IsDeleted: true, Tags: []string{"promo"}},
+		{OrigURL: "https://other.com", ShortID: "other", UserUUID: "anotherUUID", Tags: []string{"promo"}},
+	})
+	require.NoError(t, err)
+
+	records, err := storage.GetByUserUUID(t.Context(), "userUUID", nil)
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	records, err = storage.GetByUserUUID(t.Context(), "userUUID", []string{"promo"})
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	records, err = storage.GetByUserUUID(t.Context(), "userUUID", []string{"promo", "q1"})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	require.Equal(t, "one", records[0].ShortID)
+
+	tags, err := storage.GetTagsByUserUUID(t.Context(), "userUUID")
+	require.NoError(t, err)
+	require.Equal(t, []model.TagCount{{Tag: "promo", Count: 2}, {Tag: "q1", Count: 1}}, tags)
+
+	require.NoError(t, storage.SetTags(t.Context(), "three", "userUUID", []string{"q1"}))
+	require.NoError(t, storage.SetTags(t.Context(), "one", "userUUID", nil))
+	tags, err = storage.GetTagsByUserUUID(t.Context(), "userUUID")
+	require.NoError(t, err)
+	require.Equal(t, []model.TagCount{{Tag: "promo", Count: 1}, {Tag: "q1", Count: 1}}, tags)
+
+	require.ErrorIs(t, storage.SetTags(t.Context(), "gone", "userUUID", nil), ErrDataDeleted)
+	var nfErr *DataNotFoundError
+	require.ErrorAs(t, storage.SetTags(t.Context(), "other", "userUUID", nil), &nfErr)
+}
+
+func TestMemoryURLStorage_RestoreBatch(t *testing.T) {
+	storage := NewMemoryURLStorage(zap.NewNop())
+	err := storage.BatchSet(t.Context(), []model.URLStorageRecord{
+		{OrigURL: "https://one.com", ShortID: "one", UserUUID: "userUUID", IsDeleted: true},
+		{OrigURL: "https://dup.com", ShortID: "dup-old", UserUUID: "userUUID", IsDeleted: true},
+		{OrigURL: "https://dup.com", ShortID: "dup-new", UserUUID: "userUUID"},
+		{OrigURL: "https://dup.com", ShortID: "dup-limited", UserUUID: "userUUID", IsDeleted: true, MaxClicks: 1,
+			ClicksLeft: 1},
+		{OrigURL: "https://expired.com", ShortID: "expired", UserUUID: "userUUID", IsDeleted: true,
+			ExpiresAt: time.Now().Add(-time.Minute)},
+		{OrigURL: "https://other.com", ShortID: "other", UserUUID: "anotherUUID", IsDeleted: true},
+	})
+	require.NoError(t, err)
+
+	trash, err := storage.GetDeletedByUserUUID(t.Context(), "userUUID")
+	require.NoError(t, err)
+	require.Len(t, trash, 4)
+
+	results, err := storage.RestoreBatch(t.Context(), "userUUID",
+		[]string{"one", "dup-old", "dup-limited", "expired", "other", "missing"})
+	require.NoError(t, err)
+	statuses := make([]model.URLRestoreStatus, len(results))
+	for i, r := range results {
+		statuses[i] = r.Status
+	}
+	require.Equal(t, []model.URLRestoreStatus{
+		model.URLRestoreStatusRestored,
+		model.URLRestoreStatusConflict,
+		model.URLRestoreStatusRestored,
+		model.URLRestoreStatusExpired,
+		model.URLRestoreStatusNotFound,
+		model.URLRestoreStatusNotFound,
+	}, statuses)
+
+	r, err := storage.Get(t.Context(), "one", ShortURLType)
+	require.NoError(t, err)
+	require.Equal(t, "https://one.com", r.OrigURL)
+	history, err := storage.GetHistory(t.Context(), "one", "userUUID")
+	require.NoError(t, err)
+	require.Equal(t, model.URLHistoryActionRestore, history[len(history)-1].Action)
+	_, err = storage.Get(t.Context(), "other", ShortURLType)
+	require.ErrorIs(t, err, ErrDataDeleted)
+}
+
+func TestMemoryURLStorage_PurgeDeleted(t *testing.T) {
+	storage := NewMemoryURLStorage(zap.NewNop())
+	err := storage.BatchSet(t.Context(), []model.URLStorageRecord{
+		{OrigURL: "https://old.com", ShortID: "old", UserUUID: "userUUID"},
+		{OrigURL: "https://fresh.com", ShortID: "fresh", UserUUID: "userUUID"},
+		{OrigURL: "https://live.com", ShortID: "live", UserUUID: "userUUID"},
+	})
+	require.NoError(t, err)
+	require.NoError(t, storage.DeleteBatch(t.Context(), model.URLDeleteBatch{{ShortID: "old", UserUUID: "userUUID"}}))
+	cutoff := time.Now()
+	require.NoError(t, storage.DeleteBatch(t.Context(), model.URLDeleteBatch{{ShortID: "fresh", UserUUID: "userUUID"}}))
+
+	ids, err := storage.PurgeDeleted(t.Context(), cutoff, 10)
+	require.NoError(t, err)
+	require.Equal(t, []string{"old"}, ids)
+
+	count, err := storage.Count(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+	purged, err := storage.CountPurged(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, 1, purged)
+
+	var nfErr *DataNotFoundError
+	_, err = storage.Get(t.Context(), "old", ShortURLType)
+	require.ErrorAs(t, err, &nfErr)
+	_, err = storage.GetHistory(t.Context(), "old", "userUUID")
+	require.ErrorAs(t, err, &nfErr)
+	_, err = storage.Get(t.Context(), "fresh", ShortURLType)
+	require.ErrorIs(t, err, ErrDataDeleted)
+
+	err = storage.Set(t.Context(), model.URLStorageRecord{OrigURL: "https://new.com", ShortID: "old", UserUUID: "userUUID"})
+	require.ErrorIs(t, err, ErrShortIDConflict)
+}
+
+func TestMemoryURLStorage_RewriteOrigURLs(t *testing.T) {
+	storage := NewMemoryURLStorage(zap.NewNop())
+	err := storage.BatchSet(t.Context(), []model.URLStorageRecord{
+		{OrigURL: "HTTP://One.com", ShortID: "one", UserUUID: "userUUID"},
+		{OrigURL: "http://two.com", ShortID: "two", UserUUID: "userUUID"},
+		{OrigURL: "HTTP://Two.com", ShortID: "two-dup", UserUUID: "anotherUUID"},
+		{OrigURL: "HTTP://Gone.com", ShortID: "gone", UserUUID: "userUUID", IsDeleted: true},
+	})
+	require.NoError(t, err)
+
+	rewritten, skipped, err := storage.RewriteOrigURLs(t.Context(), strings.ToLower)
+	require.NoError(t, err)
+	require.Equal(t, 1, rewritten)
+	require.Equal(t, 1, skipped)
+
+	r, err := storage.Get(t.Context(), "one", ShortURLType)
+	require.NoError(t, err)
+	require.Equal(t, "http://one.com", r.OrigURL)
+	r, err = storage.Get(t.Context(), "two-dup", ShortURLType)
+	require.NoError(t, err)
+	require.Equal(t, "HTTP://Two.com", r.OrigURL)
+
+	history, err := storage.GetHistory(t.Context(), "one", "userUUID")
+	require.NoError(t, err)
+	last := history[len(history)-1]
+	require.Equal(t, model.URLHistoryActionUpdate, last.Action)
+	require.Equal(t, "HTTP://One.com", last.PrevURL)
+	require.Empty(t, last.UserUUID)
+}
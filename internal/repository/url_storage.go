@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/alex-storchak/shortener/internal/model"
 )
@@ -19,6 +20,7 @@ const (
 // with support for different storage backends (memory, file, database).
 type URLStorage interface {
 	// Get retrieves a URL record based on the provided URL and search type.
+	// Only non-deleted plain URL mappings are found by original URL, since only they are deduplicated.
 	//
 	// Parameters:
 	//   - ctx: context for cancellation and timeouts
@@ -30,19 +32,32 @@ type URLStorage interface {
 	//   - error: nil on success, or storage error if operation fails
 	Get(ctx context.Context, url, searchByType string) (*model.URLStorageRecord, error)
 
+	// GetByOrigURLs retrieves the URL mappings of multiple original URLs in a single operation.
+	// Like Get by OrigURLType, it only finds non-deleted plain URL mappings.
+	//
+	// Parameters:
+	//   - ctx: context for cancellation and timeouts
+	//   - origURLs: original URLs to search for
+	//
+	// Returns:
+	//   - map[string]*model.URLStorageRecord: found records by original URL; URLs without records are absent
+	//   - error: nil on success, or storage error if operation fails
+	GetByOrigURLs(ctx context.Context, origURLs []string) (map[string]*model.URLStorageRecord, error)
+
 	// Set stores a new URL mapping in the storage.
+	// Returns ErrShortIDConflict if the short identifier is already in use.
 	//
 	// Parameters:
 	//   - ctx: context for cancellation and timeouts
-	//   - origURL: original URL to be shortened
-	//   - shortID: generated short identifier
-	//   - userUUID: UUID of the user who created the mapping
+	//   - r: URL storage record to persist
 	//
 	// Returns:
 	//   - error: nil on success, or storage error if operation fails
-	Set(ctx context.Context, origURL, shortID, userUUID string) error
+	Set(ctx context.Context, r model.URLStorageRecord) error
 
 	// BatchSet stores multiple URL mappings in a single operation.
+	// Returns ErrShortIDConflict if any short identifier is already in use;
+	// in that case none of the records are stored.
 	//
 	// Parameters:
 	//   - ctx: context for cancellation and timeouts
@@ -68,18 +83,69 @@ type URLStorage interface {
 	Close() error
 
 	// GetByUserUUID retrieves all URL mappings created by a specific user.
+	// If tags are given, only URL mappings labeled with every one of them are retrieved.
 	//
 	// Parameters:
 	//   - ctx: context for cancellation and timeouts
 	//   - userUUID: UUID of the user to retrieve URLs for
+	//   - tags: tags the URLs must have; empty for all URLs of the user
 	//
 	// Returns:
 	//   - []*model.URLStorageRecord: slice of URL records belonging to the user
 	//   - error: nil on success, or storage error if operation fails
-	GetByUserUUID(ctx context.Context, userUUID string) ([]*model.URLStorageRecord, error)
+	GetByUserUUID(ctx context.Context, userUUID string, tags []string) ([]*model.URLStorageRecord, error)
+
+	// SetTags replaces the tags of a non-deleted URL mapping owned by the specified user.
+	// Returns DataNotFoundError if the user has no such URL, or ErrDataDeleted if it is deleted.
+	//
+	// Parameters:
+	//   - ctx: context for cancellation and timeouts
+	//   - shortID: short identifier of the URL to label
+	//   - userUUID: UUID of the user owning the URL
+	//   - tags: sorted unique tags without commas; empty to remove all tags
+	//
+	// Returns:
+	//   - error: nil on success, or storage error if operation fails
+	SetTags(ctx context.Context, shortID, userUUID string, tags []string) error
+
+	// GetTagsByUserUUID counts the non-deleted URL mappings of a specific user labeled with every tag.
+	//
+	// Parameters:
+	//   - ctx: context for cancellation and timeouts
+	//   - userUUID: UUID of the user to retrieve tags for
+	//
+	// Returns:
+	//   - []model.TagCount: tags of the user's URLs with their counts, sorted by tag
+	//   - error: nil on success, or storage error if operation fails
+	GetTagsByUserUUID(ctx context.Context, userUUID string) ([]model.TagCount, error)
 
-	// DeleteBatch marks multiple URLs as deleted in a batch operation.
-	// Only URLs belonging to the specified users can be deleted.
+	// GetDeletedByUserUUID retrieves all soft-deleted URL mappings of a specific user.
+	//
+	// Parameters:
+	//   - ctx: context for cancellation and timeouts
+	//   - userUUID: UUID of the user to retrieve deleted URLs for
+	//
+	// Returns:
+	//   - []*model.URLStorageRecord: slice of deleted URL records belonging to the user
+	//   - error: nil on success, or storage error if operation fails
+	GetDeletedByUserUUID(ctx context.Context, userUUID string) ([]*model.URLStorageRecord, error)
+
+	// RestoreBatch undeletes soft-deleted URLs of the specified user and records
+	// the restoration in the URL history. A URL is not restored if it has expired, or if it is plain
+	// and the user already has another non-deleted plain URL for the same original URL.
+	//
+	// Parameters:
+	//   - ctx: context for cancellation and timeouts
+	//   - userUUID: UUID of the user owning the URLs
+	//   - shortIDs: short identifiers of the URLs to restore
+	//
+	// Returns:
+	//   - []model.URLRestoreResult: outcome for every requested short identifier, in the same order
+	//   - error: nil on success, or storage error if operation fails
+	RestoreBatch(ctx context.Context, userUUID string, shortIDs []string) ([]model.URLRestoreResult, error)
+
+	// DeleteBatch marks multiple URLs as deleted in a batch operation and records
+	// the deletion in the URL history. Only URLs belonging to the specified users can be deleted.
 	//
 	// Parameters:
 	//   - ctx: context for cancellation and timeouts
@@ -98,6 +164,107 @@ type URLStorage interface {
 	//   - int: total amount of shortened URLs in the storage
 	//   - error: nil on success, or storage error if operation fails
 	Count(ctx context.Context) (int, error)
+
+	// SweepExpired marks all non-deleted URLs whose expiration moment is not after now as deleted.
+	//
+	// Parameters:
+	//   - ctx: context for cancellation and timeouts
+	//   - now: moment to check expiration against
+	//
+	// Returns:
+	//   - int: amount of URLs marked as deleted
+	//   - error: nil on success, or storage error if operation fails
+	SweepExpired(ctx context.Context, now time.Time) (int, error)
+
+	// PurgeDeleted physically removes URLs soft-deleted not after the given moment.
+	// Short identifiers of purged URLs are remembered and rejected with ErrShortIDConflict
+	// by subsequent Set and BatchSet calls, so they are never issued again.
+	//
+	// Parameters:
+	//   - ctx: context for cancellation and timeouts
+	//   - before: URLs deleted after this moment are kept
+	//   - batchSize: maximum amount of URLs removed in a single step
+	//
+	// Returns:
+	//   - []string: short identifiers of the purged URLs
+	//   - error: nil on success, or storage error if operation fails
+	PurgeDeleted(ctx context.Context, before time.Time, batchSize int) ([]string, error)
+
+	// CountPurged counts the amount of URLs purged from the storage so far
+	//
+	// Parameters:
+	//   - ctx: context for cancellation and timeouts
+	//
+	// Returns:
+	//   - int: total amount of purged URLs
+	//   - error: nil on success, or storage error if operation fails
+	CountPurged(ctx context.Context) (int, error)
+
+	// DecrementClicks atomically consumes one follow of a click-limited URL.
+	// Returns ErrClicksExhausted if the URL has no follows left.
+	//
+	// Parameters:
+	//   - ctx: context for cancellation and timeouts
+	//   - shortID: short identifier of the followed URL
+	//
+	// Returns:
+	//   - error: nil on success, or storage error if operation fails
+	DecrementClicks(ctx context.Context, shortID string) error
+
+	// Update changes the original URL of a non-deleted URL mapping owned by the specified user
+	// and records the change in the URL history.
+	// Returns DataNotFoundError if the user has no such URL, ErrDataDeleted if it is deleted,
+	// or ErrOrigURLConflict if the URL is plain and the user already has another plain short URL
+	// for the new original URL.
+	//
+	// Parameters:
+	//   - ctx: context for cancellation and timeouts
+	//   - shortID: short identifier of the URL to update
+	//   - userUUID: UUID of the user owning the URL
+	//   - origURL: new original URL
+	//
+	// Returns:
+	//   - error: nil on success, or storage error if operation fails
+	Update(ctx context.Context, shortID, userUUID, origURL string) error
+
+	// GetHistory retrieves all versions of a URL owned by the specified user, oldest first.
+	// Every change of the URL (creation, update, soft deletion) is recorded as a new version.
+	// Returns DataNotFoundError if the user has no such URL.
+	//
+	// Parameters:
+	//   - ctx: context for cancellation and timeouts
+	//   - shortID: short identifier of the URL
+	//   - userUUID: UUID of the user owning the URL
+	//
+	// Returns:
+	//   - []model.URLHistoryRecord: versions of the URL numbered from 1
+	//   - error: nil on success, or storage error if operation fails
+	GetHistory(ctx context.Context, shortID, userUUID string) ([]model.URLHistoryRecord, error)
+
+	// RewriteOrigURLs replaces the original URL of every non-deleted URL mapping with its rewritten form
+	// and records the changes in the URL history as system updates. A plain URL mapping is skipped
+	// if another non-deleted plain URL mapping already has the rewritten original URL.
+	//
+	// Parameters:
+	//   - ctx: context for cancellation and timeouts
+	//   - rewrite: function returning the new form of an original URL
+	//
+	// Returns:
+	//   - rewritten: amount of URL mappings changed
+	//   - skipped: amount of URL mappings left unchanged because of conflicts
+	//   - err: nil on success, or storage error if operation fails
+	RewriteOrigURLs(ctx context.Context, rewrite func(origURL string) string) (rewritten, skipped int, err error)
+
+	// NextSequence increments the persistent counter used for sequential short IDs.
+	// Every value is returned only once, even across restarts of persistent storages.
+	//
+	// Parameters:
+	//   - ctx: context for cancellation and timeouts
+	//
+	// Returns:
+	//   - uint64: new value of the counter, starting from 1
+	//   - error: nil on success, or storage error if operation fails
+	NextSequence(ctx context.Context) (uint64, error)
 }
 
 // DataNotFoundError represents an error when requested data is not found in storage.
@@ -128,4 +295,18 @@ func NewDataNotFoundError(err error) error {
 var (
 	// ErrDataDeleted is returned when attempting to access a URL that has been soft-deleted.
 	ErrDataDeleted = errors.New("data deleted")
+
+	// ErrDataExpired is returned when attempting to access a URL whose expiration moment has passed.
+	ErrDataExpired = errors.New("data expired")
+
+	// ErrClicksExhausted is returned when attempting to follow a click-limited URL that has no follows left.
+	ErrClicksExhausted = errors.New("clicks exhausted")
+
+	// ErrShortIDConflict is returned when attempting to store a short ID that is already in use,
+	// including short IDs of soft-deleted records.
+	ErrShortIDConflict = errors.New("short id already exists")
+
+	// ErrOrigURLConflict is returned when attempting to point a plain URL at an original URL
+	// the same user has already shortened as a plain URL.
+	ErrOrigURLConflict = errors.New("original url already exists")
 )
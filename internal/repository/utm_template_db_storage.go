@@ -0,0 +1,192 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/alex-storchak/shortener/internal/model"
+)
+
+// utmTemplateOrder sorts link templates by short ID first and tag templates by tag after them.
+const utmTemplateOrder = "ORDER BY t.tag <> '', t.short_id, t.tag"
+
+// DBUTMTemplateStorage provides a PostgreSQL implementation of UTMTemplateStorage.
+// Templates are kept in the utm_template table with parameters stored as JSONB.
+// Link templates have an empty tag and tag templates have an empty short ID.
+type DBUTMTemplateStorage struct {
+	logger *zap.Logger
+	db     *sql.DB
+}
+
+// NewDBUTMTemplateStorage creates a new database UTM template storage instance.
+//
+// Parameters:
+//   - logger: structured logger for logging operations
+//   - db: database connection
+//
+// Returns:
+//   - *DBUTMTemplateStorage: configured database UTM template storage
+func NewDBUTMTemplateStorage(logger *zap.Logger, db *sql.DB) *DBUTMTemplateStorage {
+	return &DBUTMTemplateStorage{
+		logger: logger,
+		db:     db,
+	}
+}
+
+// Close closes the database connection.
+//
+// Returns:
+//   - error: nil on success, or error if connection closure fails
+func (s *DBUTMTemplateStorage) Close() error {
+	return s.db.Close()
+}
+
+// GetByUserUUID retrieves all UTM templates of a specific user from the utm_template table.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - userUUID: UUID of the user to retrieve templates for
+//
+// Returns:
+//   - []model.UTMTemplate: link templates sorted by short ID, followed by tag templates sorted by tag
+//   - error: nil on success, or error if the query fails
+func (s *DBUTMTemplateStorage) GetByUserUUID(ctx context.Context, userUUID string) ([]model.UTMTemplate, error) {
+	q := `
+		SELECT au.user_uuid, t.short_id, t.tag, t.params
+		FROM utm_template t
+		JOIN auth_user au ON au.id = t.user_id
+		WHERE au.user_uuid = $1
+	` + utmTemplateOrder
+	return s.query(ctx, q, userUUID)
+}
+
+// GetForURL retrieves the UTM templates of a user applying to a link from the utm_template table.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - userUUID: UUID of the user owning the link
+//   - shortID: short identifier of the link
+//   - tags: tags of the link
+//
+// Returns:
+//   - []model.UTMTemplate: link template first, followed by tag templates sorted by tag
+//   - error: nil on success, or error if the query fails
+func (s *DBUTMTemplateStorage) GetForURL(
+	ctx context.Context,
+	userUUID, shortID string,
+	tags []string,
+) ([]model.UTMTemplate, error) {
+	q := `
+		SELECT au.user_uuid, t.short_id, t.tag, t.params
+		FROM utm_template t
+		JOIN auth_user au ON au.id = t.user_id
+		WHERE au.user_uuid = $1
+		AND (
+			(t.tag = '' AND t.short_id = $2)
+			OR t.tag = ANY(string_to_array(NULLIF($3, ''), ','))
+		)
+	` + utmTemplateOrder
+	return s.query(ctx, q, userUUID, shortID, strings.Join(tags, tagSeparator))
+}
+
+// Set stores a UTM template in the utm_template table, replacing the template bound to the same link or tag.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - t: template bound to either a short ID or a tag
+//
+// Returns:
+//   - error: nil on success, DataNotFoundError if the user doesn't exist,
+//     or error if the insertion fails
+func (s *DBUTMTemplateStorage) Set(ctx context.Context, t model.UTMTemplate) error {
+	params, err := json.Marshal(t.Params)
+	if err != nil {
+		return fmt.Errorf("marshal utm template params: %w", err)
+	}
+	q := `
+		INSERT INTO utm_template (user_id, short_id, tag, params)
+		SELECT id, $2, $3, $4::jsonb
+		FROM auth_user
+		WHERE user_uuid = $1
+		ON CONFLICT (user_id, short_id, tag) DO UPDATE SET params = EXCLUDED.params, updated_at = NOW()
+	`
+	res, err := s.db.ExecContext(ctx, q, t.UserUUID, t.ShortID, t.Tag, string(params))
+	if err != nil {
+		return fmt.Errorf("persist utm template to db: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("get affected rows of utm template insertion: %w", err)
+	}
+	if n == 0 {
+		return NewDataNotFoundError(ErrDataNotFoundInDB)
+	}
+	return nil
+}
+
+// Delete removes the UTM template of a user bound to a link or a tag from the utm_template table.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - userUUID: UUID of the user owning the template
+//   - shortID: short identifier of the link the template is bound to; empty for tag templates
+//   - tag: tag the template is bound to; empty for link templates
+//
+// Returns:
+//   - error: nil on success, DataNotFoundError if there is no such template,
+//     or error if the deletion fails
+func (s *DBUTMTemplateStorage) Delete(ctx context.Context, userUUID, shortID, tag string) error {
+	q := `
+		DELETE FROM utm_template t
+		USING auth_user au
+		WHERE au.id = t.user_id
+		AND au.user_uuid = $1
+		AND t.short_id = $2
+		AND t.tag = $3
+	`
+	res, err := s.db.ExecContext(ctx, q, userUUID, shortID, tag)
+	if err != nil {
+		return fmt.Errorf("delete utm template from db: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("get affected rows of utm template deletion: %w", err)
+	}
+	if n == 0 {
+		return NewDataNotFoundError(ErrDataNotFoundInDB)
+	}
+	return nil
+}
+
+// query runs a query selecting templates and scans them.
+func (s *DBUTMTemplateStorage) query(ctx context.Context, q string, args ...any) ([]model.UTMTemplate, error) {
+	rows, err := s.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query utm templates from db: %w", err)
+	}
+	defer rows.Close()
+
+	templates := make([]model.UTMTemplate, 0)
+	for rows.Next() {
+		var (
+			t      model.UTMTemplate
+			params []byte
+		)
+		if err := rows.Scan(&t.UserUUID, &t.ShortID, &t.Tag, &params); err != nil {
+			return nil, fmt.Errorf("scan utm template from db: %w", err)
+		}
+		if err := json.Unmarshal(params, &t.Params); err != nil {
+			return nil, fmt.Errorf("unmarshal utm template params: %w", err)
+		}
+		templates = append(templates, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("get utm templates from db: %w", err)
+	}
+	return templates, nil
+}
@@ -0,0 +1,200 @@
+package repository
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"maps"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/alex-storchak/shortener/internal/model"
+)
+
+// FileUTMTemplateStorage provides a file-based implementation of UTMTemplateStorage.
+// It keeps templates in memory and persists them to their own file in JSON lines format,
+// rewriting the file on every change. Templates are restored from the file on initialization.
+type FileUTMTemplateStorage struct {
+	logger    *zap.Logger
+	fileMgr   URLFileManager
+	templates map[utmTemplateKey]model.UTMTemplate
+	mu        *sync.Mutex
+}
+
+// NewFileUTMTemplateStorage creates a new file-based UTM template storage instance.
+// It automatically restores existing templates from the file on initialization.
+//
+// Parameters:
+//   - logger: structured logger for logging operations
+//   - fm: file manager for the templates file
+//
+// Returns:
+//   - *FileUTMTemplateStorage: configured file-based UTM template storage
+//   - error: nil on success, or error if file restoration fails
+func NewFileUTMTemplateStorage(logger *zap.Logger, fm URLFileManager) (*FileUTMTemplateStorage, error) {
+	storage := &FileUTMTemplateStorage{
+		logger:    logger,
+		fileMgr:   fm,
+		templates: make(map[utmTemplateKey]model.UTMTemplate),
+		mu:        &sync.Mutex{},
+	}
+
+	if err := storage.restoreFromFile(false); err != nil {
+		return nil, fmt.Errorf("restore utm templates from file: %w", err)
+	}
+	return storage, nil
+}
+
+// Close releases file resources used by the storage.
+//
+// Returns:
+//   - error: nil on success, or error if file closure fails
+func (s *FileUTMTemplateStorage) Close() error {
+	return s.fileMgr.Close()
+}
+
+// GetByUserUUID retrieves all UTM templates of a specific user from file storage.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts (not used)
+//   - userUUID: UUID of the user to retrieve templates for
+//
+// Returns:
+//   - []model.UTMTemplate: link templates sorted by short ID, followed by tag templates sorted by tag
+//   - error: always nil
+func (s *FileUTMTemplateStorage) GetByUserUUID(_ context.Context, userUUID string) ([]model.UTMTemplate, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return selectMemUTMTemplates(s.templates, func(t model.UTMTemplate) bool {
+		return t.UserUUID == userUUID
+	}), nil
+}
+
+// GetForURL retrieves the UTM templates of a user applying to a link from file storage.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts (not used)
+//   - userUUID: UUID of the user owning the link
+//   - shortID: short identifier of the link
+//   - tags: tags of the link
+//
+// Returns:
+//   - []model.UTMTemplate: link template first, followed by tag templates sorted by tag
+//   - error: always nil
+func (s *FileUTMTemplateStorage) GetForURL(
+	_ context.Context,
+	userUUID, shortID string,
+	tags []string,
+) ([]model.UTMTemplate, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return selectMemUTMTemplates(s.templates, matchMemUTMTemplateForURL(userUUID, shortID, tags)), nil
+}
+
+// Set stores a UTM template in file storage, replacing the template bound to the same link or tag.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts (not used)
+//   - t: template bound to either a short ID or a tag
+//
+// Returns:
+//   - error: nil on success, or error if file operations fail
+func (s *FileUTMTemplateStorage) Set(_ context.Context, t model.UTMTemplate) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := newUTMTemplateKey(t)
+	prev, existed := s.templates[key]
+	t.Params = maps.Clone(t.Params)
+	s.templates[key] = t
+	if err := s.saveToFile(); err != nil {
+		if existed {
+			s.templates[key] = prev
+		} else {
+			delete(s.templates, key)
+		}
+		return fmt.Errorf("save utm templates to file: %w", err)
+	}
+	return nil
+}
+
+// Delete removes the UTM template of a user bound to a link or a tag from file storage.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts (not used)
+//   - userUUID: UUID of the user owning the template
+//   - shortID: short identifier of the link the template is bound to; empty for tag templates
+//   - tag: tag the template is bound to; empty for link templates
+//
+// Returns:
+//   - error: nil on success, DataNotFoundError if there is no such template,
+//     or error if file operations fail
+func (s *FileUTMTemplateStorage) Delete(_ context.Context, userUUID, shortID, tag string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := utmTemplateKey{userUUID: userUUID, shortID: shortID, tag: tag}
+	prev, ok := s.templates[key]
+	if !ok {
+		return NewDataNotFoundError(nil)
+	}
+	delete(s.templates, key)
+	if err := s.saveToFile(); err != nil {
+		s.templates[key] = prev
+		return fmt.Errorf("save utm templates to file: %w", err)
+	}
+	return nil
+}
+
+// saveToFile writes all templates to the file, overwriting existing content.
+func (s *FileUTMTemplateStorage) saveToFile() error {
+	if _, err := s.fileMgr.OpenForWrite(false); err != nil {
+		return fmt.Errorf("open utm templates file for write: %w", err)
+	}
+	defer s.fileMgr.Close()
+
+	all := selectMemUTMTemplates(s.templates, func(model.UTMTemplate) bool { return true })
+	for _, t := range all {
+		data, err := t.ToJSON()
+		if err != nil {
+			return fmt.Errorf("convert utm template to json for store: %w", err)
+		}
+		if err := s.fileMgr.WriteData(data); err != nil {
+			return fmt.Errorf("mgr persist utm template to file: %w", err)
+		}
+	}
+	return nil
+}
+
+// restoreFromFile reads the templates file and rebuilds the in-memory index.
+// Supports fallback to default file if primary file is unavailable.
+func (s *FileUTMTemplateStorage) restoreFromFile(useDefault bool) error {
+	f, err := s.fileMgr.OpenForAppend(useDefault)
+	if err != nil && !useDefault {
+		s.logger.Warn("failed to restore utm templates from requested file, trying default: ", zap.Error(err))
+		return s.restoreFromFile(true)
+	} else if err != nil {
+		return fmt.Errorf("open default utm templates file: %w", err)
+	}
+	defer s.fileMgr.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var t model.UTMTemplate
+		if err := t.FromJSON(line); err != nil {
+			return fmt.Errorf("parse utm template line `%s`: %w", string(line), err)
+		}
+		s.templates[newUTMTemplateKey(t)] = t
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("scan utm templates file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/alex-storchak/shortener/internal/file"
+	"github.com/alex-storchak/shortener/internal/model"
+)
+
+func TestFileUTMTemplateStorage(t *testing.T) {
+	lgr := zap.NewNop()
+	path := filepath.Join(t.TempDir(), "file_db_utm.txt")
+	storage, err := NewFileUTMTemplateStorage(lgr, file.NewManager(path, "", lgr))
+	require.NoError(t, err)
+
+	templates := []model.UTMTemplate{
+		{UserUUID: "userUUID", Tag: "promo", Params: map[string]string{"utm_campaign": "{tag}"}},
+		{UserUUID: "userUUID", ShortID: "abcde", Params: map[string]string{"utm_source": "mail"}},
+		{UserUUID: "userUUID", Tag: "blog", Params: map[string]string{"utm_medium": "post"}},
+		{UserUUID: "otherUUID", ShortID: "abcde", Params: map[string]string{"utm_source": "other"}},
+	}
+	for _, tmpl := range templates {
+		require.NoError(t, storage.Set(t.Context(), tmpl))
+	}
+	require.NoError(t, storage.Set(t.Context(), model.UTMTemplate{
+		UserUUID: "userUUID", ShortID: "abcde", Params: map[string]string{"utm_source": "newsletter"},
+	}))
+
+	got, err := storage.GetForURL(t.Context(), "userUUID", "abcde", []string{"blog", "news"})
+	require.NoError(t, err)
+	assert.Equal(t, []model.UTMTemplate{
+		{UserUUID: "userUUID", ShortID: "abcde", Params: map[string]string{"utm_source": "newsletter"}},
+		{UserUUID: "userUUID", Tag: "blog", Params: map[string]string{"utm_medium": "post"}},
+	}, got)
+
+	var nfErr *DataNotFoundError
+	require.NoError(t, storage.Delete(t.Context(), "userUUID", "", "blog"))
+	require.ErrorAs(t, storage.Delete(t.Context(), "userUUID", "", "blog"), &nfErr)
+
+	restored, err := NewFileUTMTemplateStorage(lgr, file.NewManager(path, "", lgr))
+	require.NoError(t, err)
+	got, err = restored.GetByUserUUID(t.Context(), "userUUID")
+	require.NoError(t, err)
+	assert.Equal(t, []model.UTMTemplate{
+		{UserUUID: "userUUID", ShortID: "abcde", Params: map[string]string{"utm_source": "newsletter"}},
+		{UserUUID: "userUUID", Tag: "promo", Params: map[string]string{"utm_campaign": "{tag}"}},
+	}, got)
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"maps"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/alex-storchak/shortener/internal/model"
+)
+
+// MemoryUTMTemplateStorage provides an in-memory implementation of UTMTemplateStorage.
+// It stores templates in a synchronized map and is suitable for testing
+// or single-instance deployments without persistence requirements.
+type MemoryUTMTemplateStorage struct {
+	logger    *zap.Logger
+	templates map[utmTemplateKey]model.UTMTemplate
+	mu        *sync.Mutex
+}
+
+// NewMemoryUTMTemplateStorage creates a new in-memory UTM template storage instance.
+//
+// Parameters:
+//   - logger: structured logger for logging operations
+//
+// Returns:
+//   - *MemoryUTMTemplateStorage: configured in-memory UTM template storage
+func NewMemoryUTMTemplateStorage(logger *zap.Logger) *MemoryUTMTemplateStorage {
+	return &MemoryUTMTemplateStorage{
+		logger:    logger,
+		templates: make(map[utmTemplateKey]model.UTMTemplate),
+		mu:        &sync.Mutex{},
+	}
+}
+
+// Close releases resources used by the memory storage.
+// For in-memory storage, this is a no-op but implements the interface.
+//
+// Returns:
+//   - error: always returns nil
+func (s *MemoryUTMTemplateStorage) Close() error {
+	return nil
+}
+
+// GetByUserUUID retrieves all UTM templates of a specific user from memory storage.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts (not used)
+//   - userUUID: UUID of the user to retrieve templates for
+//
+// Returns:
+//   - []model.UTMTemplate: link templates sorted by short ID, followed by tag templates sorted by tag
+//   - error: always nil
+func (s *MemoryUTMTemplateStorage) GetByUserUUID(_ context.Context, userUUID string) ([]model.UTMTemplate, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return selectMemUTMTemplates(s.templates, func(t model.UTMTemplate) bool {
+		return t.UserUUID == userUUID
+	}), nil
+}
+
+// GetForURL retrieves the UTM templates of a user applying to a link from memory storage.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts (not used)
+//   - userUUID: UUID of the user owning the link
+//   - shortID: short identifier of the link
+//   - tags: tags of the link
+//
+// Returns:
+//   - []model.UTMTemplate: link template first, followed by tag templates sorted by tag
+//   - error: always nil
+func (s *MemoryUTMTemplateStorage) GetForURL(
+	_ context.Context,
+	userUUID, shortID string,
+	tags []string,
+) ([]model.UTMTemplate, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return selectMemUTMTemplates(s.templates, matchMemUTMTemplateForURL(userUUID, shortID, tags)), nil
+}
+
+// Set stores a UTM template in memory storage, replacing the template bound to the same link or tag.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts (not used)
+//   - t: template bound to either a short ID or a tag
+//
+// Returns:
+//   - error: always nil
+func (s *MemoryUTMTemplateStorage) Set(_ context.Context, t model.UTMTemplate) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t.Params = maps.Clone(t.Params)
+	s.templates[newUTMTemplateKey(t)] = t
+	return nil
+}
+
+// Delete removes the UTM template of a user bound to a link or a tag from memory storage.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts (not used)
+//   - userUUID: UUID of the user owning the template
+//   - shortID: short identifier of the link the template is bound to; empty for tag templates
+//   - tag: tag the template is bound to; empty for link templates
+//
+// Returns:
+//   - error: nil on success, or DataNotFoundError if there is no such template
+func (s *MemoryUTMTemplateStorage) Delete(_ context.Context, userUUID, shortID, tag string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := utmTemplateKey{userUUID: userUUID, shortID: shortID, tag: tag}
+	if _, ok := s.templates[key]; !ok {
+		return NewDataNotFoundError(nil)
+	}
+	delete(s.templates, key)
+	return nil
+}
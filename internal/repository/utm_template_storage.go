@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"maps"
+	"slices"
+
+	"github.com/alex-storchak/shortener/internal/model"
+)
+
+// UTMTemplateStorage defines the interface for persistence of users' UTM templates.
+// A user has at most one template per link and one template per tag.
+// Implementations can use different storage backends (memory, file, database).
+type UTMTemplateStorage interface {
+	// GetByUserUUID retrieves all UTM templates of a specific user.
+	//
+	// Parameters:
+	//   - ctx: context for cancellation and timeouts
+	//   - userUUID: UUID of the user to retrieve templates for
+	//
+	// Returns:
+	//   - []model.UTMTemplate: link templates sorted by short ID, followed by tag templates sorted by tag
+	//   - error: nil on success, or storage error if operation fails
+	GetByUserUUID(ctx context.Context, userUUID string) ([]model.UTMTemplate, error)
+
+	// GetForURL retrieves the UTM templates of a user applying to a link:
+	// the template bound to the link and the templates bound to any of its tags.
+	//
+	// Parameters:
+	//   - ctx: context for cancellation and timeouts
+	//   - userUUID: UUID of the user owning the link
+	//   - shortID: short identifier of the link
+	//   - tags: tags of the link
+	//
+	// Returns:
+	//   - []model.UTMTemplate: link template first, followed by tag templates sorted by tag
+	//   - error: nil on success, or storage error if operation fails
+	GetForURL(ctx context.Context, userUUID, shortID string, tags []string) ([]model.UTMTemplate, error)
+
+	// Set stores a UTM template, replacing the template of the user bound to the same link or tag.
+	//
+	// Parameters:
+	//   - ctx: context for cancellation and timeouts
+	//   - t: template bound to either a short ID or a tag
+	//
+	// Returns:
+	//   - error: nil on success, or storage error if operation fails
+	Set(ctx context.Context, t model.UTMTemplate) error
+
+	// Delete removes the UTM template of a user bound to a link or a tag.
+	//
+	// Parameters:
+	//   - ctx: context for cancellation and timeouts
+	//   - userUUID: UUID of the user owning the template
+	//   - shortID: short identifier of the link the template is bound to; empty for tag templates
+	//   - tag: tag the template is bound to; empty for link templates
+	//
+	// Returns:
+	//   - error: nil on success, DataNotFoundError if there is no such template,
+	//     or storage error if operation fails
+	Delete(ctx context.Context, userUUID, shortID, tag string) error
+
+	// Close releases any resources used by the storage implementation.
+	//
+	// Returns:
+	//   - error: nil on success, or error if cleanup fails
+	Close() error
+}
+
+// utmTemplateKey identifies a UTM template of a user in memory and file storages.
+type utmTemplateKey struct {
+	userUUID string
+	shortID  string
+	tag      string
+}
+
+// newUTMTemplateKey returns the key of the template.
+func newUTMTemplateKey(t model.UTMTemplate) utmTemplateKey {
+	return utmTemplateKey{userUUID: t.UserUUID, shortID: t.ShortID, tag: t.Tag}
+}
+
+// selectMemUTMTemplates returns the templates matching the filter in the order defined by UTMTemplateStorage:
+// link templates sorted by short ID, followed by tag templates sorted by tag.
+func selectMemUTMTemplates(
+	templates map[utmTemplateKey]model.UTMTemplate,
+	match func(t model.UTMTemplate) bool,
+) []model.UTMTemplate {
+	res := make([]model.UTMTemplate, 0)
+	for _, t := range templates {
+		if match(t) {
+			t.Params = maps.Clone(t.Params)
+			res = append(res, t)
+		}
+	}
+	slices.SortFunc(res, func(a, b model.UTMTemplate) int {
+		if (a.Tag == "") != (b.Tag == "") {
+			return cmp.Compare(a.Tag, b.Tag) // link templates have empty tags and go first
+		}
+		return cmp.Or(cmp.Compare(a.ShortID, b.ShortID), cmp.Compare(a.Tag, b.Tag))
+	})
+	return res
+}
+
+// matchMemUTMTemplateForURL returns the filter of templates of a user applying to a link.
+func matchMemUTMTemplateForURL(userUUID, shortID string, tags []string) func(t model.UTMTemplate) bool {
+	return func(t model.UTMTemplate) bool {
+		if t.UserUUID != userUUID {
+			return false
+		}
+		if t.Tag == "" {
+			return t.ShortID == shortID
+		}
+		return slices.Contains(tags, t.Tag)
+	}
+}
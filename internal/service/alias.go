@@ -0,0 +1,65 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// maxAliasLen is the maximum allowed length of a custom short identifier.
+const maxAliasLen = 64
+
+// aliasPattern mirrors the `/{id:[a-zA-Z0-9_-]+}` route pattern,
+// so that every accepted alias is reachable through the expand endpoint.
+var aliasPattern = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// reservedAliases contains path segments that are occupied by service routes
+// and therefore cannot be used as custom short identifiers.
+var reservedAliases = map[string]struct{}{
+	"api":   {},
+	"ping":  {},
+	"debug": {},
+}
+
+// validateAlias checks that a custom short identifier can be served by the expand route
+// and doesn't clash with service routes. Reserved words are compared case-insensitively.
+//
+// Parameters:
+//   - alias: custom short identifier requested by the user
+//
+// Returns:
+//   - error: nil if alias is valid, or *ValidationError wrapping ErrInvalidAlias with the reason
+func validateAlias(alias string) error {
+	if len(alias) > maxAliasLen {
+		return NewValidationError(ErrInvalidAlias, fmt.Sprintf("longer than %d characters", maxAliasLen))
+	}
+	if !aliasPattern.MatchString(alias) {
+		return NewValidationError(ErrInvalidAlias, "only latin letters, digits, `_` and `-` are allowed")
+	}
+	if _, reserved := reservedAliases[strings.ToLower(alias)]; reserved {
+		return NewValidationError(ErrInvalidAlias, fmt.Sprintf("`%s` is a reserved word", alias))
+	}
+	return nil
+}
+
+// checkAlias validates the custom short identifier. When short IDs carry check characters,
+// the alias must end with its check character too, otherwise it would never be followed.
+//
+// Parameters:
+//   - alias: custom short identifier requested by the user
+//
+// Returns:
+//   - error: nil if alias is valid, or *ValidationError wrapping ErrInvalidAlias with the reason
+func (s *Shortener) checkAlias(alias string) error {
+	if err := validateAlias(alias); err != nil {
+		return err
+	}
+	if s.checksum.Valid(alias) {
+		return nil
+	}
+	withCheck, err := s.checksum.Append(alias)
+	if err != nil {
+		return NewValidationError(ErrInvalidAlias, err.Error())
+	}
+	return NewValidationError(ErrInvalidAlias, fmt.Sprintf("must end with a check character, e.g. `%s`", withCheck))
+}
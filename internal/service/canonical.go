@@ -0,0 +1,100 @@
+package service
+
+import (
+	"net"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+// defaultPorts maps URL schemes to their default ports, which are dropped from canonical URLs.
+var defaultPorts = map[string]string{
+	"http":  "80",
+	"https": "443",
+}
+
+// URLCanonicalizer brings original URLs to a canonical form, so that equivalent spellings
+// of the same URL are deduplicated into a single short link.
+//
+// The canonical form has lowercase scheme and host, internationalized host names converted
+// to punycode, no default port, "/" instead of an empty path and, optionally, query parameters
+// sorted by name. URLs that can't be parsed or have no host are left unchanged.
+// A nil or disabled canonicalizer returns URLs unchanged.
+type URLCanonicalizer struct {
+	enabled   bool
+	sortQuery bool
+}
+
+// NewURLCanonicalizer creates a new URLCanonicalizer.
+//
+// Parameters:
+//   - enabled: whether URLs are canonicalized at all
+//   - sortQuery: whether query parameters are sorted by name
+//
+// Returns:
+//   - *URLCanonicalizer: configured canonicalizer
+func NewURLCanonicalizer(enabled, sortQuery bool) *URLCanonicalizer {
+	return &URLCanonicalizer{
+		enabled:   enabled,
+		sortQuery: sortQuery,
+	}
+}
+
+// Canonicalize returns the canonical form of the URL.
+//
+// Parameters:
+//   - raw: original URL as provided by the user
+//
+// Returns:
+//   - string: canonical URL, or raw if it can't be canonicalized
+func (c *URLCanonicalizer) Canonicalize(raw string) string {
+	if c == nil || !c.enabled {
+		return raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Opaque != "" || u.Host == "" {
+		return raw
+	}
+
+	u.Scheme = strings.ToLower(u.Scheme)
+	host := canonicalHost(u.Hostname())
+	port := u.Port()
+	if port == defaultPorts[u.Scheme] {
+		port = ""
+	}
+	if port != "" {
+		u.Host = net.JoinHostPort(host, port)
+	} else if strings.Contains(host, ":") {
+		u.Host = "[" + host + "]"
+	} else {
+		u.Host = host
+	}
+
+	if u.Path == "" {
+		u.Path = "/"
+		u.RawPath = ""
+	}
+	if c.sortQuery && u.RawQuery != "" {
+		q, err := url.ParseQuery(u.RawQuery)
+		if err == nil {
+			// Encode sorts parameters by name keeping the order of repeated values
+			u.RawQuery = q.Encode()
+		}
+	}
+	return u.String()
+}
+
+// canonicalHost lowercases the host name and converts internationalized domain names to punycode.
+// IP addresses and names rejected by IDNA lookup rules are only lowercased.
+func canonicalHost(host string) string {
+	host = strings.ToLower(host)
+	if net.ParseIP(host) != nil {
+		return host
+	}
+	ascii, err := idna.Lookup.ToASCII(host)
+	if err != nil {
+		return host
+	}
+	return ascii
+}
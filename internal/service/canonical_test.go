@@ -0,0 +1,83 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestURLCanonicalizer_Canonicalize(t *testing.T) {
+	tests := []struct {
+		name      string
+		sortQuery bool
+		url       string
+		want      string
+	}{
+		{
+			name: "lowercases scheme and host and adds root path",
+			url:  "HTTP://Example.COM",
+			want: "http://example.com/",
+		},
+		{
+			name: "drops default http port",
+			url:  "http://example.com:80/",
+			want: "http://example.com/",
+		},
+		{
+			name: "drops default https port",
+			url:  "https://example.com:443/path",
+			want: "https://example.com/path",
+		},
+		{
+			name: "keeps non-default port",
+			url:  "https://example.com:8443/path",
+			want: "https://example.com:8443/path",
+		},
+		{
+			name: "keeps path case",
+			url:  "https://example.com/Path/To",
+			want: "https://example.com/Path/To",
+		},
+		{
+			name: "converts idn to punycode",
+			url:  "https://Пример.рф/путь",
+			want: "https://xn--e1afmkfd.xn--p1ai/%D0%BF%D1%83%D1%82%D1%8C",
+		},
+		{
+			name: "keeps ipv6 host brackets",
+			url:  "http://[::1]:80",
+			want: "http://[::1]/",
+		},
+		{
+			name: "keeps query order by default",
+			url:  "https://example.com/?b=2&a=1",
+			want: "https://example.com/?b=2&a=1",
+		},
+		{
+			name:      "sorts query when enabled",
+			sortQuery: true,
+			url:       "https://example.com/?b=2&a=1&a=0",
+			want:      "https://example.com/?a=1&a=0&b=2",
+		},
+		{
+			name: "leaves url without host unchanged",
+			url:  "mailto:User@Example.com",
+			want: "mailto:User@Example.com",
+		},
+		{
+			name: "leaves unparsable url unchanged",
+			url:  "http://exa mple.com/%zz",
+			want: "http://exa mple.com/%zz",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewURLCanonicalizer(true, tt.sortQuery)
+			assert.Equal(t, tt.want, c.Canonicalize(tt.url))
+		})
+	}
+
+	assert.Equal(t, "HTTP://Example.com", NewURLCanonicalizer(false, true).Canonicalize("HTTP://Example.com"))
+	var nilCanon *URLCanonicalizer
+	assert.Equal(t, "HTTP://Example.com", nilCanon.Canonicalize("HTTP://Example.com"))
+}
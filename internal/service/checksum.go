@@ -0,0 +1,153 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// checksumAlphabet is the alphabet of the Luhn mod N algorithm: every character allowed in short IDs.
+// The character value is its position in the alphabet.
+const checksumAlphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz_-"
+
+// ErrInvalidChecksum is returned when the check character of a short ID doesn't match the rest of it.
+var ErrInvalidChecksum = errors.New("invalid short id checksum")
+
+// ChecksumError represents a short ID with a wrong check character, most likely mistyped.
+// It wraps ErrInvalidChecksum and carries valid short IDs the user probably meant.
+type ChecksumError struct {
+	ShortID     string   // Requested short ID
+	Suggestions []string // Valid short IDs differing from the requested one by a single typo
+}
+
+// Error returns the error message.
+func (e *ChecksumError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrInvalidChecksum, e.ShortID)
+}
+
+// Unwrap returns ErrInvalidChecksum.
+func (e *ChecksumError) Unwrap() error {
+	return ErrInvalidChecksum
+}
+
+// IDChecksum appends and verifies the check character of short IDs, computed with the Luhn mod N algorithm.
+// It detects every single mistyped character and most swaps of adjacent characters,
+// so mistyped short IDs are recognized without a storage lookup.
+// A nil IDChecksum disables the checks.
+type IDChecksum struct {
+	values map[byte]int
+}
+
+// NewIDChecksum creates a new IDChecksum.
+//
+// Returns:
+//   - *IDChecksum: checksum over all characters allowed in short IDs
+func NewIDChecksum() *IDChecksum {
+	values := make(map[byte]int, len(checksumAlphabet))
+	for i := 0; i < len(checksumAlphabet); i++ {
+		values[checksumAlphabet[i]] = i
+	}
+	return &IDChecksum{values: values}
+}
+
+// checkChar computes the check character of the short ID body.
+// It returns false if the body has characters outside of the alphabet.
+func (c *IDChecksum) checkChar(body string) (byte, bool) {
+	n := len(checksumAlphabet)
+	sum := 0
+	double := true
+	for i := len(body) - 1; i >= 0; i-- {
+		v, ok := c.values[body[i]]
+		if !ok {
+			return 0, false
+		}
+		if double {
+			v *= 2
+			v = v/n + v%n
+		}
+		sum += v
+		double = !double
+	}
+	return checksumAlphabet[(n-sum%n)%n], true
+}
+
+// Append returns the short ID body followed by its check character.
+func (c *IDChecksum) Append(body string) (string, error) {
+	check, ok := c.checkChar(body)
+	if !ok {
+		return "", fmt.Errorf("short id `%s` has characters without checksum value", body)
+	}
+	return body + string(check), nil
+}
+
+// Valid reports whether the last character of the short ID is the check character of the rest of it.
+// Every short ID is valid when the checksum is disabled.
+func (c *IDChecksum) Valid(shortID string) bool {
+	if c == nil {
+		return true
+	}
+	if len(shortID) < 2 {
+		return false
+	}
+	check, ok := c.checkChar(shortID[:len(shortID)-1])
+	return ok && check == shortID[len(shortID)-1]
+}
+
+// Verify checks the short ID and describes the typos it most likely contains.
+//
+// Parameters:
+//   - shortID: short ID to check
+//
+// Returns:
+//   - error: nil if the short ID is valid, or *ChecksumError with suggested corrections
+func (c *IDChecksum) Verify(shortID string) error {
+	if c.Valid(shortID) {
+		return nil
+	}
+	return &ChecksumError{ShortID: shortID, Suggestions: c.suggest(shortID)}
+}
+
+// maxChecksumSuggestions limits the amount of short IDs suggested for a mistyped one.
+const maxChecksumSuggestions = 10
+
+// suggest returns the valid short IDs reachable from the short ID by a single typo, most likely first:
+// a wrong check character, a swap of adjacent characters or a wrong character of the body.
+func (c *IDChecksum) suggest(shortID string) []string {
+	if len(shortID) < 2 {
+		return nil
+	}
+	var res []string
+	seen := make(map[string]struct{})
+	add := func(id string) {
+		if len(res) == maxChecksumSuggestions || id == shortID || !c.Valid(id) {
+			return
+		}
+		if _, ok := seen[id]; ok {
+			return
+		}
+		if _, reserved := reservedAliases[strings.ToLower(id)]; reserved {
+			return
+		}
+		seen[id] = struct{}{}
+		res = append(res, id)
+	}
+
+	if id, err := c.Append(shortID[:len(shortID)-1]); err == nil {
+		add(id)
+	}
+	b := []byte(shortID)
+	for i := 0; i < len(b)-1; i++ {
+		b[i], b[i+1] = b[i+1], b[i]
+		add(string(b))
+		b[i], b[i+1] = b[i+1], b[i]
+	}
+	for i := 0; i < len(b)-1; i++ {
+		orig := b[i]
+		for j := 0; j < len(checksumAlphabet); j++ {
+			b[i] = checksumAlphabet[j]
+			add(string(b))
+		}
+		b[i] = orig
+	}
+	return res
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIDChecksum(t *testing.T) {
+	c := NewIDChecksum()
+	for _, body := range []string{"a", "abc123", "Xy_-Z", "brave-otter-42"} {
+		id, err := c.Append(body)
+		require.NoError(t, err)
+		assert.Len(t, id, len(body)+1)
+		assert.Regexp(t, aliasPattern, id)
+		assert.True(t, c.Valid(id), id)
+		assert.NoError(t, c.Verify(id))
+	}
+
+	_, err := c.Append("ab/c")
+	assert.Error(t, err)
+	assert.False(t, c.Valid(""))
+	assert.False(t, c.Valid("a"))
+
+	var disabled *IDChecksum
+	assert.True(t, disabled.Valid("anything"))
+	assert.NoError(t, disabled.Verify("anything"))
+}
+
+func TestIDChecksum_DetectsTypos(t *testing.T) {
+	c := NewIDChecksum()
+	id, err := c.Append("aZ3k9_Q")
+	require.NoError(t, err)
+
+	b := []byte(id)
+	for i := range b {
+		orig := b[i]
+		for j := 0; j < len(checksumAlphabet); j++ {
+			if checksumAlphabet[j] == orig {
+				continue
+			}
+			b[i] = checksumAlphabet[j]
+			assert.False(t, c.Valid(string(b)), "substitution %s must be detected", string(b))
+		}
+		b[i] = orig
+	}
+}
+
+func TestIDChecksum_Verify(t *testing.T) {
+	c := NewIDChecksum()
+	id, err := c.Append("abc123")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		typed string
+	}{
+		{name: "wrong check character", typed: id[:len(id)-1] + "!"},
+		{name: "wrong character", typed: "abd123" + id[len(id)-1:]},
+		{name: "swapped characters", typed: "bac123" + id[len(id)-1:]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var csErr *ChecksumError
+			require.ErrorAs(t, c.Verify(tt.typed), &csErr)
+			assert.ErrorIs(t, csErr, ErrInvalidChecksum)
+			assert.Equal(t, tt.typed, csErr.ShortID)
+			assert.Contains(t, csErr.Suggestions, id)
+			assert.LessOrEqual(t, len(csErr.Suggestions), maxChecksumSuggestions)
+			for _, s := range csErr.Suggestions {
+				assert.True(t, c.Valid(s), s)
+			}
+		})
+	}
+}
+
+func TestUniqueIDGenerator_WithChecksum(t *testing.T) {
+	c := NewIDChecksum()
+	g, err := NewSequentialIDGenerator(newSequenceStorageStub(), 3)
+	require.NoError(t, err)
+	id, err := g.WithChecksum(c).Generate(t.Context(), "https://example.com")
+	require.NoError(t, err)
+	assert.Len(t, id, 2)
+	assert.True(t, c.Valid(id))
+}
@@ -0,0 +1,15 @@
+package service
+
+// validateMaxClicks checks that a requested click limit can be applied to a link.
+//
+// Parameters:
+//   - maxClicks: requested amount of allowed follows, zero means unlimited
+//
+// Returns:
+//   - error: nil if limit is valid, or *ValidationError wrapping ErrInvalidMaxClicks with the reason
+func validateMaxClicks(maxClicks int) error {
+	if maxClicks < 0 {
+		return NewValidationError(ErrInvalidMaxClicks, "max_clicks must be positive")
+	}
+	return nil
+}
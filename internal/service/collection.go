@@ -0,0 +1,481 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/alex-storchak/shortener/internal/model"
+	repo "github.com/alex-storchak/shortener/internal/repository"
+)
+
+// Limits of collections.
+const (
+	maxCollectionNameLen = 64   // Maximum length of a collection name in characters
+	maxUserCollections   = 100  // Maximum amount of collections of a single user
+	maxCollectionMove    = 1000 // Maximum amount of links moved at once
+)
+
+// collectionExportContentTypes are MIME types of the supported export formats of collections.
+var collectionExportContentTypes = map[string]string{
+	model.CollectionExportCSV:  "text/csv; charset=utf-8",
+	model.CollectionExportJSON: "application/json",
+}
+
+// collectionExportHeader is the header row of collections exported to CSV.
+var collectionExportHeader = []string{
+	"short_url", "original_url", "tags", "redirect_type", "passthrough", "expires_at", "created_at",
+}
+
+// CollectionURLs defines the interface for access to users' links grouped into collections.
+type CollectionURLs interface {
+	GetUserURLs(ctx context.Context, userUUID string, tags []string) ([]*model.URLStorageRecord, error)
+	DeleteBatch(ctx context.Context, urls model.URLDeleteBatch) error
+}
+
+// Collections manages named collections grouping users' links.
+// A link belongs to at most one collection of its owner. Deleted links stay in their collections,
+// so restoring a link brings it back into its collection, but collections only ever list,
+// count, export and delete live links.
+type Collections struct {
+	storage repo.CollectionStorage
+	urls    CollectionURLs
+	ub      *URLBuilder
+	logger  *zap.Logger
+}
+
+// NewCollections creates a new instance of Collections.
+//
+// Parameters:
+//   - storage: storage of collections
+//   - urls: links of users, used to check that moved links belong to their users and to delete links in bulk
+//   - ub: builder of the short URLs in exported collections
+//   - logger: structured logger for logging operations
+//
+// Returns:
+//   - *Collections: configured collections service
+func NewCollections(storage repo.CollectionStorage, urls CollectionURLs, ub *URLBuilder, logger *zap.Logger) *Collections {
+	return &Collections{
+		storage: storage,
+		urls:    urls,
+		ub:      ub,
+		logger:  logger,
+	}
+}
+
+// GetUserCollections retrieves all collections of the user with the live links in them.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - userUUID: UUID of the user
+//
+// Returns:
+//   - []model.Collection: collections sorted by name
+//   - error: nil on success, or storage error if operation fails
+func (s *Collections) GetUserCollections(ctx context.Context, userUUID string) ([]model.Collection, error) {
+	collections, err := s.storage.GetByUserUUID(ctx, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("get user collections from storage: %w", err)
+	}
+	live, err := s.liveURLs(ctx, userUUID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range collections {
+		collections[i].ShortIDs = filterLive(collections[i].ShortIDs, live)
+	}
+	return collections, nil
+}
+
+// Create validates the name and creates an empty collection of the user.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - userUUID: UUID of the user
+//   - name: name of the collection; surrounding whitespace is trimmed
+//
+// Returns:
+//   - *model.Collection: created collection
+//   - error: nil on success, or error if validation or storage operation fails
+//
+// Errors:
+//   - ErrInvalidCollection: when the name is malformed or the user has too many collections
+//   - ErrCollectionNameTaken: when the user already has a collection with the name
+func (s *Collections) Create(ctx context.Context, userUUID, name string) (*model.Collection, error) {
+	name, err := normalizeCollectionName(name)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := s.storage.GetByUserUUID(ctx, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("get user collections from storage: %w", err)
+	}
+	if len(existing) >= maxUserCollections {
+		return nil, NewValidationError(ErrInvalidCollection, fmt.Sprintf("more than %d collections", maxUserCollections))
+	}
+
+	c := model.Collection{
+		ID:        uuid.NewString(),
+		UserUUID:  userUUID,
+		Name:      name,
+		CreatedAt: time.Now().UTC().Truncate(time.Microsecond),
+		ShortIDs:  make([]string, 0),
+	}
+	if err := s.storage.Create(ctx, c); err != nil {
+		return nil, wrapCollectionNameErr("create collection in storage", err)
+	}
+	return &c, nil
+}
+
+// Rename validates the name and renames a collection of the user.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - userUUID: UUID of the user
+//   - id: identifier of the collection
+//   - name: new name of the collection; surrounding whitespace is trimmed
+//
+// Returns:
+//   - *model.Collection: renamed collection with its live links
+//   - error: nil on success, or error if validation or storage operation fails
+//
+// Errors:
+//   - ErrInvalidCollection: when the name is malformed
+//   - ErrCollectionNameTaken: when the user already has another collection with the name
+//   - repo.DataNotFoundError: when the user has no such collection
+func (s *Collections) Rename(ctx context.Context, userUUID, id, name string) (*model.Collection, error) {
+	name, err := normalizeCollectionName(name)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.storage.Rename(ctx, userUUID, id, name); err != nil {
+		return nil, wrapCollectionNameErr("rename collection in storage", err)
+	}
+	return s.Get(ctx, userUUID, id)
+}
+
+// Get retrieves a collection of the user with the live links in it.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - userUUID: UUID of the user
+//   - id: identifier of the collection
+//
+// Returns:
+//   - *model.Collection: found collection
+//   - error: nil on success, repo.DataNotFoundError if the user has no such collection,
+//     or storage error if operation fails
+func (s *Collections) Get(ctx context.Context, userUUID, id string) (*model.Collection, error) {
+	c, err := s.storage.Get(ctx, userUUID, id)
+	if err != nil {
+		return nil, fmt.Errorf("get collection from storage: %w", err)
+	}
+	live, err := s.liveURLs(ctx, userUUID)
+	if err != nil {
+		return nil, err
+	}
+	c.ShortIDs = filterLive(c.ShortIDs, live)
+	return c, nil
+}
+
+// Delete removes a collection of the user. Links of the collection are kept and belong to no collection.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - userUUID: UUID of the user
+//   - id: identifier of the collection
+//
+// Returns:
+//   - error: nil on success, repo.DataNotFoundError if the user has no such collection,
+//     or storage error if operation fails
+func (s *Collections) Delete(ctx context.Context, userUUID, id string) error {
+	if err := s.storage.Delete(ctx, userUUID, id); err != nil {
+		return fmt.Errorf("delete collection from storage: %w", err)
+	}
+	return nil
+}
+
+// Move puts live links of the user into a collection, taking them out of the collections they belonged to.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - userUUID: UUID of the user
+//   - id: identifier of the target collection; empty to take the links out of any collection
+//   - shortIDs: short identifiers of the links to move
+//
+// Returns:
+//   - error: nil on success, or error if validation or storage operation fails
+//
+// Errors:
+//   - ErrInvalidCollection: when no links or too many links are given
+//   - repo.DataNotFoundError: when the user has no such collection or no live link with any of the short IDs
+func (s *Collections) Move(ctx context.Context, userUUID, id string, shortIDs []string) error {
+	if len(shortIDs) == 0 {
+		return NewValidationError(ErrInvalidCollection, "no links to move")
+	}
+	if len(shortIDs) > maxCollectionMove {
+		return NewValidationError(ErrInvalidCollection, fmt.Sprintf("more than %d links to move", maxCollectionMove))
+	}
+	live, err := s.liveURLs(ctx, userUUID)
+	if err != nil {
+		return err
+	}
+	for _, shortID := range shortIDs {
+		if _, ok := live[shortID]; !ok {
+			return fmt.Errorf("get url `%s` of collection: %w", shortID, repo.NewDataNotFoundError(nil))
+		}
+	}
+
+	if err := s.storage.Move(ctx, userUUID, id, shortIDs); err != nil {
+		return fmt.Errorf("move links to collection in storage: %w", err)
+	}
+	return nil
+}
+
+// GetURLs retrieves the live links in a collection of the user.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - userUUID: UUID of the user
+//   - id: identifier of the collection
+//
+// Returns:
+//   - []*model.URLStorageRecord: links in the collection, in the order of the user's links
+//   - error: nil on success, repo.DataNotFoundError if the user has no such collection,
+//     or storage error if operation fails
+func (s *Collections) GetURLs(ctx context.Context, userUUID, id string) ([]*model.URLStorageRecord, error) {
+	_, urls, err := s.collectionURLs(ctx, userUUID, id)
+	return urls, err
+}
+
+// DeleteURLs soft-deletes all live links in a collection of the user. The collection itself is kept,
+// and restored links come back into it.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - userUUID: UUID of the user
+//   - id: identifier of the collection
+//
+// Returns:
+//   - int: amount of deleted links
+//   - error: nil on success, repo.DataNotFoundError if the user has no such collection,
+//     or storage error if operation fails
+func (s *Collections) DeleteURLs(ctx context.Context, userUUID, id string) (int, error) {
+	_, urls, err := s.collectionURLs(ctx, userUUID, id)
+	if err != nil {
+		return 0, err
+	}
+	if len(urls) == 0 {
+		return 0, nil
+	}
+	batch := make(model.URLDeleteBatch, len(urls))
+	for i, u := range urls {
+		batch[i] = model.URLToDelete{UserUUID: userUUID, ShortID: u.ShortID}
+	}
+	if err := s.urls.DeleteBatch(ctx, batch); err != nil {
+		return 0, fmt.Errorf("delete collection urls: %w", err)
+	}
+	s.logger.Debug("collection urls deleted", zap.String("collection", id), zap.Int("count", len(urls)))
+	return len(urls), nil
+}
+
+// Export renders the live links in a collection of the user into a downloadable file.
+// CSV files have a header row and tags joined with commas in a single column;
+// JSON files hold the links in the format of the user URLs listing.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - userUUID: UUID of the user
+//   - id: identifier of the collection
+//   - format: csv or json; empty selects csv
+//
+// Returns:
+//   - *model.CollectionExport: rendered file with its MIME type and suggested name
+//   - error: nil on success, or error if validation or storage operation fails
+//
+// Errors:
+//   - ErrInvalidCollection: when the format is unknown
+//   - repo.DataNotFoundError: when the user has no such collection
+func (s *Collections) Export(ctx context.Context, userUUID, id, format string) (*model.CollectionExport, error) {
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format == "" {
+		format = model.CollectionExportCSV
+	}
+	contentType, ok := collectionExportContentTypes[format]
+	if !ok {
+		return nil, NewValidationError(ErrInvalidCollection, fmt.Sprintf("export format `%s` must be csv or json", format))
+	}
+	c, urls, err := s.collectionURLs(ctx, userUUID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []byte
+	if format == model.CollectionExportJSON {
+		data, err = s.exportJSON(urls)
+	} else {
+		data, err = s.exportCSV(urls)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("export collection to %s: %w", format, err)
+	}
+	return &model.CollectionExport{
+		Data:        data,
+		ContentType: contentType,
+		FileName:    collectionFileName(c.Name) + "." + format,
+	}, nil
+}
+
+// exportCSV renders the links to CSV with a header row.
+func (s *Collections) exportCSV(urls []*model.URLStorageRecord) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(collectionExportHeader); err != nil {
+		return nil, fmt.Errorf("write header: %w", err)
+	}
+	for _, u := range urls {
+		row := []string{
+			s.ub.Build(u.ShortID),
+			u.OrigURL,
+			strings.Join(u.Tags, ","),
+			strconv.Itoa(u.Redirect()),
+			strconv.FormatBool(u.Passthrough),
+			formatExportTime(u.ExpiresAt),
+			formatExportTime(u.CreatedAt),
+		}
+		if err := w.Write(row); err != nil {
+			return nil, fmt.Errorf("write link `%s`: %w", u.ShortID, err)
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, fmt.Errorf("flush csv: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// exportJSON renders the links to a JSON array in the format of the user URLs listing.
+func (s *Collections) exportJSON(urls []*model.URLStorageRecord) ([]byte, error) {
+	items := make(model.UserURLsGetResponse, len(urls))
+	for i, u := range urls {
+		items[i] = model.UserURLsGetResponseItem{
+			ShortURL:     s.ub.Build(u.ShortID),
+			OrigURL:      u.OrigURL,
+			Tags:         u.Tags,
+			RedirectType: u.Redirect(),
+			Passthrough:  u.Passthrough,
+		}
+		if !u.ExpiresAt.IsZero() {
+			expiresAt := u.ExpiresAt
+			items[i].ExpiresAt = &expiresAt
+		}
+	}
+	return items.MarshalJSON()
+}
+
+// collectionURLs returns the collection of the user with its live links, in the order of the user's links.
+func (s *Collections) collectionURLs(
+	ctx context.Context,
+	userUUID, id string,
+) (*model.Collection, []*model.URLStorageRecord, error) {
+	c, err := s.storage.Get(ctx, userUUID, id)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get collection from storage: %w", err)
+	}
+	all, err := s.urls.GetUserURLs(ctx, userUUID, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get user urls of collection: %w", err)
+	}
+	members := make(map[string]struct{}, len(c.ShortIDs))
+	for _, shortID := range c.ShortIDs {
+		members[shortID] = struct{}{}
+	}
+	urls := make([]*model.URLStorageRecord, 0, len(c.ShortIDs))
+	for _, u := range all {
+		if _, ok := members[u.ShortID]; ok {
+			urls = append(urls, u)
+		}
+	}
+	return c, urls, nil
+}
+
+// liveURLs returns the set of short IDs of the live links of the user.
+func (s *Collections) liveURLs(ctx context.Context, userUUID string) (map[string]struct{}, error) {
+	urls, err := s.urls.GetUserURLs(ctx, userUUID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("get user urls of collections: %w", err)
+	}
+	live := make(map[string]struct{}, len(urls))
+	for _, u := range urls {
+		live[u.ShortID] = struct{}{}
+	}
+	return live, nil
+}
+
+// filterLive keeps the short IDs of live links, preserving their order.
+func filterLive(shortIDs []string, live map[string]struct{}) []string {
+	res := make([]string, 0, len(shortIDs))
+	for _, shortID := range shortIDs {
+		if _, ok := live[shortID]; ok {
+			res = append(res, shortID)
+		}
+	}
+	return res
+}
+
+// normalizeCollectionName trims the name and checks its length and characters.
+func normalizeCollectionName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", NewValidationError(ErrInvalidCollection, "name can't be empty")
+	}
+	if utf8.RuneCountInString(name) > maxCollectionNameLen {
+		return "", NewValidationError(ErrInvalidCollection,
+			fmt.Sprintf("name is longer than %d characters", maxCollectionNameLen))
+	}
+	if !utf8.ValidString(name) || strings.ContainsFunc(name, unicode.IsControl) {
+		return "", NewValidationError(ErrInvalidCollection, "name contains control characters")
+	}
+	return name, nil
+}
+
+// wrapCollectionNameErr wraps a storage error, replacing a name conflict with ErrCollectionNameTaken.
+func wrapCollectionNameErr(op string, err error) error {
+	if errors.Is(err, repo.ErrCollectionNameConflict) {
+		return fmt.Errorf("%s: %w", op, ErrCollectionNameTaken)
+	}
+	return fmt.Errorf("%s: %w", op, err)
+}
+
+// collectionFileName derives the name of an exported file from the collection name,
+// replacing characters unsafe in file names with underscores.
+func collectionFileName(name string) string {
+	safe := strings.Map(func(r rune) rune {
+		if r < utf8.RuneSelf && (unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '_' || r == '.') {
+			return r
+		}
+		return '_'
+	}, name)
+	if strings.Trim(safe, "_.") == "" {
+		return "collection"
+	}
+	return safe
+}
+
+// formatExportTime formats a moment in exported files; zero moments are left empty.
+func formatExportTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/alex-storchak/shortener/internal/model"
+	repo "github.com/alex-storchak/shortener/internal/repository"
+)
+
+// memCollectionURLs exposes links of the memory URL storage to collections.
+type memCollectionURLs struct {
+	*repo.MemoryURLStorage
+}
+
+func (u memCollectionURLs) GetUserURLs(ctx context.Context, userUUID string, tags []string) ([]*model.URLStorageRecord, error) {
+	return u.GetByUserUUID(ctx, userUUID, tags)
+}
+
+func newTestCollections(t *testing.T) (*Collections, *repo.MemoryURLStorage) {
+	t.Helper()
+	urls := repo.NewMemoryURLStorage(zap.NewNop())
+	for _, r := range []model.URLStorageRecord{
+		{OrigURL: "http://a.com", ShortID: "aaaaa", UserUUID: "userUUID", Tags: []string{"news", "promo"}},
+		{OrigURL: "http://b.com", ShortID: "bbbbb", UserUUID: "userUUID"},
+		{OrigURL: "http://c.com", ShortID: "ccccc", UserUUID: "userUUID"},
+		{OrigURL: "http://d.com", ShortID: "alien", UserUUID: "otherUUID"},
+	} {
+		require.NoError(t, urls.Set(t.Context(), r))
+	}
+	s := NewCollections(repo.NewMemoryCollectionStorage(zap.NewNop()), memCollectionURLs{urls},
+		NewURLBuilder("http://localhost:8080"), zap.NewNop())
+	return s, urls
+}
+
+func TestCollections_Manage(t *testing.T) {
+	s, _ := newTestCollections(t)
+
+	work, err := s.Create(t.Context(), "userUUID", "  Work ")
+	require.NoError(t, err)
+	assert.Equal(t, "Work", work.Name)
+	assert.NotEmpty(t, work.ID)
+	_, err = s.Create(t.Context(), "userUUID", "Work")
+	require.ErrorIs(t, err, ErrCollectionNameTaken)
+	_, err = s.Create(t.Context(), "otherUUID", "Work")
+	require.NoError(t, err, "names are unique per user")
+	blog, err := s.Create(t.Context(), "userUUID", "Blog")
+	require.NoError(t, err)
+
+	for _, name := range []string{" ", strings.Repeat("x", maxCollectionNameLen+1), "a\tb"} {
+		_, err = s.Create(t.Context(), "userUUID", name)
+		var vErr *ValidationError
+		require.ErrorAs(t, err, &vErr, "name %q", name)
+		require.ErrorIs(t, err, ErrInvalidCollection)
+	}
+
+	require.NoError(t, s.Move(t.Context(), "userUUID", work.ID, []string{"aaaaa", "bbbbb"}))
+	require.NoError(t, s.Move(t.Context(), "userUUID", blog.ID, []string{"bbbbb", "ccccc"}))
+	var nfErr *repo.DataNotFoundError
+	require.ErrorAs(t, s.Move(t.Context(), "userUUID", work.ID, []string{"alien"}), &nfErr)
+	require.ErrorAs(t, s.Move(t.Context(), "otherUUID", work.ID, []string{"alien"}), &nfErr)
+	require.ErrorIs(t, s.Move(t.Context(), "userUUID", work.ID, nil), ErrInvalidCollection)
+
+	renamed, err := s.Rename(t.Context(), "userUUID", blog.ID, "Posts")
+	require.NoError(t, err)
+	assert.Equal(t, "Posts", renamed.Name)
+	assert.Equal(t, []string{"bbbbb", "ccccc"}, renamed.ShortIDs)
+	_, err = s.Rename(t.Context(), "userUUID", blog.ID, "Work")
+	require.ErrorIs(t, err, ErrCollectionNameTaken)
+
+	require.NoError(t, s.Move(t.Context(), "userUUID", "", []string{"ccccc"}))
+	got, err := s.GetUserCollections(t.Context(), "userUUID")
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "Posts", got[0].Name)
+	assert.Equal(t, []string{"bbbbb"}, got[0].ShortIDs)
+	assert.Equal(t, "Work", got[1].Name)
+	assert.Equal(t, []string{"aaaaa"}, got[1].ShortIDs)
+
+	require.NoError(t, s.Delete(t.Context(), "userUUID", work.ID))
+	require.ErrorAs(t, s.Delete(t.Context(), "userUUID", work.ID), &nfErr)
+	_, err = s.GetURLs(t.Context(), "userUUID", work.ID)
+	require.ErrorAs(t, err, &nfErr)
+}
+
+func TestCollections_DeleteURLs(t *testing.T) {
+	s, urls := newTestCollections(t)
+	c, err := s.Create(t.Context(), "userUUID", "Old")
+	require.NoError(t, err)
+	require.NoError(t, s.Move(t.Context(), "userUUID", c.ID, []string{"aaaaa", "bbbbb"}))
+
+	n, err := s.DeleteURLs(t.Context(), "userUUID", c.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	_, err = urls.Get(t.Context(), "aaaaa", repo.ShortURLType)
+	require.ErrorIs(t, err, repo.ErrDataDeleted)
+	_, err = urls.Get(t.Context(), "ccccc", repo.ShortURLType)
+	require.NoError(t, err, "links out of the collection are kept")
+
+	got, err := s.GetURLs(t.Context(), "userUUID", c.ID)
+	require.NoError(t, err)
+	assert.Empty(t, got, "deleted links are not listed")
+
+	_, err = urls.RestoreBatch(t.Context(), "userUUID", []string{"aaaaa"})
+	require.NoError(t, err)
+	got, err = s.GetURLs(t.Context(), "userUUID", c.ID)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "aaaaa", got[0].ShortID, "restored links come back into their collection")
+}
+
+func TestCollections_Export(t *testing.T) {
+	s, _ := newTestCollections(t)
+	c, err := s.Create(t.Context(), "userUUID", "Q3 / promo")
+	require.NoError(t, err)
+	require.NoError(t, s.Move(t.Context(), "userUUID", c.ID, []string{"aaaaa"}))
+
+	exp, err := s.Export(t.Context(), "userUUID", c.ID, "")
+	require.NoError(t, err)
+	assert.Equal(t, "text/csv; charset=utf-8", exp.ContentType)
+	assert.Equal(t, "Q3___promo.csv", exp.FileName)
+	assert.Equal(t, "short_url,original_url,tags,redirect_type,passthrough,expires_at,created_at\n"+
+		"http://localhost:8080/aaaaa,http://a.com,\"news,promo\",307,false,,\n", string(exp.Data))
+
+	exp, err = s.Export(t.Context(), "userUUID", c.ID, "JSON")
+	require.NoError(t, err)
+	assert.Equal(t, "application/json", exp.ContentType)
+	assert.JSONEq(t, `[{"short_url":"http://localhost:8080/aaaaa","original_url":"http://a.com",`+
+		`"tags":["news","promo"],"redirect_type":307}]`, string(exp.Data))
+
+	_, err = s.Export(t.Context(), "userUUID", c.ID, "xml")
+	require.ErrorIs(t, err, ErrInvalidCollection)
+	var nfErr *repo.DataNotFoundError
+	_, err = s.Export(t.Context(), "otherUUID", c.ID, "csv")
+	require.ErrorAs(t, err, &nfErr)
+}
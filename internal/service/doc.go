@@ -6,7 +6,20 @@
 // URL Shortening:
 //   - Shortener: Main service for URL shortening operations
 //   - URLBuilder: Constructs full URLs from short identifiers
+//   - URLCanonicalizer: Brings original URLs to canonical form before deduplication
+//   - URLValidator: Checks original URLs against scheme and domain allow/deny lists
 //   - IDGenerator: Interface for generating unique short IDs
+//   - UniqueIDGenerator: Short ID strategies (shortid, random, sequential, hash, snowflake, words) with collision retries
+//   - IDChecksum: Check characters of short IDs detecting typos without storage lookups
+//   - ExpirySweeper: Background job retiring expired links
+//   - Purger: Background job removing links deleted longer than the retention period together with their settings
+//   - PasswordAttemptLimiter: Per-link limiter of failed password attempts
+//   - UTMTemplates: Per-user UTM templates appended to link destinations on redirect
+//   - Targeting: Ordered per-link rules picking destinations by User-Agent family, OS, language and headers
+//   - Splitter: Per-link A/B split tests picking weighted variants, optionally sticky per client
+//   - QRCodes: PNG and SVG QR codes of short URLs with a cache of rendered images
+//   - Collections: Named per-user collections of links with bulk deletion and CSV or JSON export
+//   - URLImporter: Streaming CSV and NDJSON bulk imports of links stored in chunks
 //
 // Authentication:
 //   - AuthService: JWT token creation and validation
@@ -16,11 +29,30 @@
 // # Key Features
 //
 //   - Shorten individual URLs and batches of URLs
+//   - Bulk import of links from CSV or NDJSON with per-row outcomes
+//   - Custom vanity aliases instead of generated short IDs
+//   - Human-readable word-based short IDs selectable per request, free of profanity
+//   - Optional check characters in short IDs with suggested corrections of mistyped ones
+//   - Per-link expiration by absolute moment or TTL
+//   - Click-limited and one-time links
+//   - Per-link redirect status (301, 302, 307 or 308) with a configurable default
+//   - Opt-in forwarding of the path suffix and query of a short URL request to the original URL
+//   - Password-protected links with bcrypt hashes
+//   - Rejection of original URLs matching the blocklist on shortening and following
 //   - Extract original URLs from short identifiers
+//   - Inspect destinations, creation dates and safety status of short URLs without following them
+//   - QR codes of short URLs rendered in-process, with size, error correction level, margin and colors
 //   - User authentication with JWT tokens
 //   - Automatic token refresh
 //   - User-specific URL management
+//   - Changing the destination of user's short URLs
+//   - Tags on short URLs with filtering of user's URLs and per-tag counts
+//   - Per-user UTM templates bound to links or tags, appended to destinations on redirect
+//   - Device and language targeting rules sending clients of a link to alternative destinations
+//   - Weighted A/B split destinations of a link with optional sticky assignment of clients
+//   - Per-link version history with restoration of previous versions
 //   - Batch URL deletion with soft delete
+//   - Trash view and restoration of soft-deleted URLs
 //   - Health checking and readiness probes
 //
 // # Interfaces
@@ -29,6 +61,8 @@
 //   - URLShortener: Core URL shortening operations
 //   - PingableURLShortener: URL shortener with health checking
 //   - IDGenerator: Short ID generation
+//   - URLBlocker: Blocklist of malicious original URLs
+//   - DestinationChecker: Checking of destination URLs before redirecting to them
 //   - Pinger: Service readiness checking
 //   - UserCreator: User creation
 //
@@ -37,7 +71,34 @@
 // The package defines common errors for consistent error handling:
 //   - ErrURLAlreadyExists: When a URL already has a short identifier
 //   - ErrEmptyInputURL: When an empty URL is provided
+//   - ErrHistoryVersionNotFound: When a restored version is missing in the link history
 //   - ErrEmptyInputBatch: When an empty batch is provided
+//   - ErrInvalidAlias: When a requested custom alias is malformed or reserved
+//   - ErrInvalidIDStyle: When a requested style of generated short IDs is unknown
+//   - ValidationError: Wraps input validation errors with a client-facing reason
+//   - ErrAliasTaken: When a requested custom alias is already in use
+//   - ErrAliasURLExists: When a custom alias is requested for a URL that already has a short identifier
+//   - ErrShortIDExhausted: When every generated short ID candidate collides with existing ones
+//   - ErrInvalidChecksum, ChecksumError: When the check character of a short ID is wrong
+//   - ErrClockSkew: When the clock moved backwards too far for snowflake short IDs
+//   - ErrInvalidExpiry: When requested link expiration is in the past or ambiguous
+//   - ErrInvalidMaxClicks: When requested click limit is negative
+//   - ErrInvalidPassword: When requested link password is too long
+//   - ErrInvalidRedirectType: When requested link redirect type is not a supported redirect status code
+//   - ErrInvalidPassthrough: When a forwarded path suffix or query is malformed or climbs above the original path
+//   - ErrInvalidTags: When requested link tags are malformed or too many
+//   - ErrInvalidUTMTemplate: When a UTM template has no single target or malformed parameters
+//   - ErrInvalidTargetingRule: When targeting rules of a link are malformed or too many
+//   - ErrInvalidSplit: When variants of a split test are malformed, too few or too many
+//   - ErrInvalidQROptions: When requested QR code format, size, level, margin or colors are invalid
+//   - ErrPassthroughLink: When the QR code of a passthrough link is requested where its path suffix is forwarded
+//   - ErrInvalidCollection: When a collection name, moved links or export format are malformed,
+//     or the user has too many collections
+//   - ErrCollectionNameTaken: When the user already has another collection with the requested name
+//   - ErrInvalidImport: When the format, the CSV header or a row of a bulk import is malformed
+//   - ErrPasswordRequired, ErrWrongPassword: When a protected link is followed without the correct password
+//   - ErrTooManyAttempts: When failed password attempts for a link exceed the limit
+//   - ErrURLBlocked, BlockedURLError: When an original URL matches the blocklist
 //   - ErrUnauthorized: When user authentication fails
 //   - ErrAuthInvalidToken: When JWT token validation fails
 //
@@ -47,6 +108,7 @@
 //   - repository package for data persistence
 //   - model package for data structures
 //   - config package for configuration
+//   - qrcode package for encoding QR codes
 //   - zap for structured logging
 //   - jwt for token handling
 package service
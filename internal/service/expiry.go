@@ -0,0 +1,31 @@
+package service
+
+import (
+	"time"
+
+	"github.com/alex-storchak/shortener/internal/model"
+)
+
+// resolveExpiresAt converts the expiration options of a link into an absolute expiration moment.
+// A TTL is counted from now. Zero time is returned when the link doesn't expire.
+//
+// Parameters:
+//   - opts: per-link settings supplied on shortening
+//   - now: moment the link is being shortened at
+//
+// Returns:
+//   - time.Time: expiration moment, or zero time if the link never expires
+//   - error: nil if options are valid, or *ValidationError wrapping ErrInvalidExpiry with the reason
+func resolveExpiresAt(opts model.ShortenOptions, now time.Time) (time.Time, error) {
+	switch {
+	case !opts.ExpiresAt.IsZero() && opts.TTL != 0:
+		return time.Time{}, NewValidationError(ErrInvalidExpiry, "only one of expires_at and ttl can be set")
+	case opts.TTL < 0:
+		return time.Time{}, NewValidationError(ErrInvalidExpiry, "ttl must be positive")
+	case opts.TTL > 0:
+		return now.Add(opts.TTL), nil
+	case !opts.ExpiresAt.IsZero() && !opts.ExpiresAt.After(now):
+		return time.Time{}, NewValidationError(ErrInvalidExpiry, "expires_at must be in the future")
+	}
+	return opts.ExpiresAt, nil
+}
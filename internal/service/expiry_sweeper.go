@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ExpiredURLSweeper defines the interface for storages able to retire expired links.
+type ExpiredURLSweeper interface {
+	SweepExpired(ctx context.Context, now time.Time) (int, error)
+}
+
+// ExpirySweeper periodically marks expired links as deleted, so that they stop
+// blocking deduplication of their original URLs and disappear from user listings.
+// Expired links are rejected on access even before they are swept.
+type ExpirySweeper struct {
+	storage  ExpiredURLSweeper
+	interval time.Duration
+	logger   *zap.Logger
+	closed   chan struct{}
+	wg       sync.WaitGroup
+	once     sync.Once
+}
+
+// NewExpirySweeper creates a new ExpirySweeper.
+//
+// Parameters:
+//   - s: storage to sweep expired links in
+//   - interval: interval between sweeps; non-positive value disables the sweeper
+//   - l: structured logger for logging operations
+//
+// Returns:
+//   - *ExpirySweeper: configured sweeper, not started yet
+func NewExpirySweeper(s ExpiredURLSweeper, interval time.Duration, l *zap.Logger) *ExpirySweeper {
+	return &ExpirySweeper{
+		storage:  s,
+		interval: interval,
+		logger:   l,
+		closed:   make(chan struct{}),
+	}
+}
+
+// Start launches the background sweeping goroutine.
+// It is a no-op if the sweeper is disabled by a non-positive interval.
+func (s *ExpirySweeper) Start() {
+	if s.interval <= 0 {
+		s.logger.Info("expiry sweeper is disabled")
+		return
+	}
+	s.wg.Add(1)
+	go s.run()
+}
+
+// run sweeps expired links on every tick until the sweeper is closed.
+func (s *ExpirySweeper) run() {
+	defer s.wg.Done()
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			s.sweep()
+		case <-s.closed:
+			s.logger.Info("expiry sweeper is closing, finish sweeping")
+			return
+		}
+	}
+}
+
+// sweep performs a single sweep limited in time by the sweep interval.
+func (s *ExpirySweeper) sweep() {
+	ctx, cancel := context.WithTimeout(context.Background(), s.interval)
+	defer cancel()
+
+	n, err := s.storage.SweepExpired(ctx, time.Now())
+	if err != nil {
+		s.logger.Error("failed to sweep expired urls", zap.Error(err))
+		return
+	}
+	if n > 0 {
+		s.logger.Info("expired urls swept", zap.Int("count", n))
+	}
+}
+
+// Close stops the sweeper and waits for the running sweep, if any, to finish.
+func (s *ExpirySweeper) Close() {
+	s.once.Do(func() {
+		close(s.closed)
+		s.wg.Wait()
+		s.logger.Info("expiry sweeper closed")
+	})
+}
@@ -0,0 +1,319 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/teris-io/shortid"
+
+	"github.com/alex-storchak/shortener/internal/config"
+	"github.com/alex-storchak/shortener/internal/model"
+	repo "github.com/alex-storchak/shortener/internal/repository"
+)
+
+// Short ID generation strategies selectable by configuration.
+const (
+	IDStrategyShortID    = "shortid"    // Random IDs of the shortid library
+	IDStrategyRandom     = "random"     // Random IDs of configurable length and alphabet
+	IDStrategySequential = "sequential" // Base62-encoded values of a counter persisted in the storage
+	IDStrategyHash       = "hash"       // Base62-encoded SHA-256 hashes of original URLs
+	IDStrategySnowflake  = "snowflake"  // Base62-encoded time, node ID and sequence, unique across replicas
+)
+
+// ID styles selectable per shortening request.
+const (
+	IDStyleDefault = "default" // Short ID of the configured generation strategy
+	IDStyleWords   = "words"   // Human-readable short ID made of words, e.g. `brave-otter-42`
+)
+
+// base62Alphabet is the alphabet of sequential, hash and snowflake short IDs.
+const base62Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// ShortIDLookup defines the interface for storages able to find records by short ID.
+// It is used to detect collisions of generated short IDs.
+type ShortIDLookup interface {
+	Get(ctx context.Context, url, searchByType string) (*model.URLStorageRecord, error)
+}
+
+// SequenceStorage defines the interface for storages keeping the counter of sequential short IDs.
+type SequenceStorage interface {
+	ShortIDLookup
+	NextSequence(ctx context.Context) (uint64, error)
+}
+
+// idCandidates produces candidate short IDs for the original URL.
+// The attempt number starts from 0 and grows with every collision.
+type idCandidates func(ctx context.Context, origURL string, attempt int) (string, error)
+
+// UniqueIDGenerator implements IDGenerator on top of a generation strategy.
+// Every candidate short ID is checked against the storage and reserved words of service routes;
+// colliding candidates are replaced by the next ones until the attempts are exhausted.
+//
+// The check can't protect from concurrent requests taking the same ID at the same time,
+// such collisions are reported by the storage when the record is stored.
+type UniqueIDGenerator struct {
+	next        idCandidates
+	reject      func(id string) bool // optional filter of unwanted candidates
+	storage     ShortIDLookup
+	maxAttempts int
+}
+
+// NewIDGenerator creates the generator of the configured strategy.
+//
+// Parameters:
+//   - cfg: shortener configuration with the strategy and its settings
+//   - s: storage to check candidates against and to keep the counter of sequential IDs
+//
+// Returns:
+//   - *UniqueIDGenerator: configured generator
+//   - error: nil on success, or error if the strategy is unknown or its settings are invalid
+func NewIDGenerator(cfg config.Shortener, s SequenceStorage) (*UniqueIDGenerator, error) {
+	switch cfg.IDStrategy {
+	case IDStrategyShortID:
+		return NewShortIDGenerator(s, cfg.IDMaxAttempts)
+	case IDStrategyRandom:
+		return NewRandomIDGenerator(s, cfg.IDAlphabet, cfg.IDLength, cfg.IDMaxAttempts)
+	case IDStrategySequential:
+		return NewSequentialIDGenerator(s, cfg.IDMaxAttempts)
+	case IDStrategyHash:
+		return NewHashIDGenerator(s, cfg.IDLength, cfg.IDMaxAttempts)
+	case IDStrategySnowflake:
+		return NewSnowflakeIDGenerator(s, cfg.IDNodeID, cfg.IDMaxAttempts)
+	default:
+		return nil, fmt.Errorf("unknown id strategy `%s`", cfg.IDStrategy)
+	}
+}
+
+// newUniqueIDGenerator checks the common settings and creates a new UniqueIDGenerator.
+func newUniqueIDGenerator(next idCandidates, s ShortIDLookup, maxAttempts int) (*UniqueIDGenerator, error) {
+	if maxAttempts < 1 {
+		return nil, fmt.Errorf("max attempts must be positive, got %d", maxAttempts)
+	}
+	return &UniqueIDGenerator{
+		next:        next,
+		storage:     s,
+		maxAttempts: maxAttempts,
+	}, nil
+}
+
+// WithChecksum returns a copy of the generator appending the check character to every candidate.
+//
+// Parameters:
+//   - c: checksum computing the check characters
+//
+// Returns:
+//   - *UniqueIDGenerator: generator of short IDs with check characters
+func (g *UniqueIDGenerator) WithChecksum(c *IDChecksum) *UniqueIDGenerator {
+	next := g.next
+	wrapped := *g
+	wrapped.next = func(ctx context.Context, origURL string, attempt int) (string, error) {
+		id, err := next(ctx, origURL, attempt)
+		if err != nil {
+			return "", err
+		}
+		return c.Append(id)
+	}
+	return &wrapped
+}
+
+// NewShortIDGenerator creates a generator of random short IDs of the shortid library.
+//
+// Parameters:
+//   - s: storage to check candidates against
+//   - maxAttempts: maximum amount of candidates checked for a single short ID
+//
+// Returns:
+//   - *UniqueIDGenerator: configured generator
+//   - error: nil on success, or error if the settings are invalid
+func NewShortIDGenerator(s ShortIDLookup, maxAttempts int) (*UniqueIDGenerator, error) {
+	sid, err := shortid.New(1, shortid.DefaultABC, 1)
+	if err != nil {
+		return nil, fmt.Errorf("instantiate shortid generator: %w", err)
+	}
+	next := func(_ context.Context, _ string, _ int) (string, error) {
+		return sid.Generate()
+	}
+	return newUniqueIDGenerator(next, s, maxAttempts)
+}
+
+// NewRandomIDGenerator creates a generator of random short IDs made of the alphabet characters.
+// Characters are chosen uniformly with a cryptographically secure random source.
+//
+// Parameters:
+//   - s: storage to check candidates against
+//   - alphabet: characters of short IDs; only latin letters, digits, `_` and `-` are allowed
+//   - length: length of short IDs
+//   - maxAttempts: maximum amount of candidates checked for a single short ID
+//
+// Returns:
+//   - *UniqueIDGenerator: configured generator
+//   - error: nil on success, or error if the settings are invalid
+func NewRandomIDGenerator(s ShortIDLookup, alphabet string, length, maxAttempts int) (*UniqueIDGenerator, error) {
+	if err := validateIDAlphabet(alphabet); err != nil {
+		return nil, err
+	}
+	if length < 1 || length > maxAliasLen {
+		return nil, fmt.Errorf("id length must be between 1 and %d, got %d", maxAliasLen, length)
+	}
+	size := big.NewInt(int64(len(alphabet)))
+	next := func(_ context.Context, _ string, _ int) (string, error) {
+		id := make([]byte, length)
+		for i := range id {
+			n, err := rand.Int(rand.Reader, size)
+			if err != nil {
+				return "", fmt.Errorf("read random: %w", err)
+			}
+			id[i] = alphabet[n.Int64()]
+		}
+		return string(id), nil
+	}
+	return newUniqueIDGenerator(next, s, maxAttempts)
+}
+
+// validateIDAlphabet checks that every short ID made of the alphabet is reachable through the expand route.
+func validateIDAlphabet(alphabet string) error {
+	if !aliasPattern.MatchString(alphabet) {
+		return errors.New("id alphabet may only contain latin letters, digits, `_` and `-`")
+	}
+	seen := make(map[rune]struct{}, len(alphabet))
+	for _, c := range alphabet {
+		if _, ok := seen[c]; ok {
+			return fmt.Errorf("id alphabet has repeated character `%c`", c)
+		}
+		seen[c] = struct{}{}
+	}
+	if len(seen) < 2 {
+		return errors.New("id alphabet must have at least 2 characters")
+	}
+	return nil
+}
+
+// NewSequentialIDGenerator creates a generator of base62-encoded values of the storage counter.
+// Short IDs are as short as possible and grow in length with the amount of links.
+// Counter values taken by colliding candidates are skipped.
+//
+// Parameters:
+//   - s: storage keeping the counter and to check candidates against
+//   - maxAttempts: maximum amount of candidates checked for a single short ID
+//
+// Returns:
+//   - *UniqueIDGenerator: configured generator
+//   - error: nil on success, or error if the settings are invalid
+func NewSequentialIDGenerator(s SequenceStorage, maxAttempts int) (*UniqueIDGenerator, error) {
+	next := func(ctx context.Context, _ string, _ int) (string, error) {
+		n, err := s.NextSequence(ctx)
+		if err != nil {
+			return "", fmt.Errorf("take next sequence value: %w", err)
+		}
+		return encodeBase62(new(big.Int).SetUint64(n), 0), nil
+	}
+	return newUniqueIDGenerator(next, s, maxAttempts)
+}
+
+// NewHashIDGenerator creates a generator of short IDs derived from SHA-256 hashes of original URLs.
+// The same URL always gets the same short ID unless it collides; on collision the attempt number
+// is mixed into the hashed data, so retries are deterministic too.
+//
+// Parameters:
+//   - s: storage to check candidates against
+//   - length: length of short IDs; at most 43 base62 characters are available from a hash
+//   - maxAttempts: maximum amount of candidates checked for a single short ID
+//
+// Returns:
+//   - *UniqueIDGenerator: configured generator
+//   - error: nil on success, or error if the settings are invalid
+func NewHashIDGenerator(s ShortIDLookup, length, maxAttempts int) (*UniqueIDGenerator, error) {
+	const maxHashIDLen = 43 // 62^43 > 2^256
+	if length < 1 || length > maxHashIDLen {
+		return nil, fmt.Errorf("hash id length must be between 1 and %d, got %d", maxHashIDLen, length)
+	}
+	next := func(_ context.Context, origURL string, attempt int) (string, error) {
+		data := origURL
+		if attempt > 0 {
+			data += "\x00" + strconv.Itoa(attempt)
+		}
+		sum := sha256.Sum256([]byte(data))
+		return encodeBase62(new(big.Int).SetBytes(sum[:]), length), nil
+	}
+	return newUniqueIDGenerator(next, s, maxAttempts)
+}
+
+// encodeBase62 encodes the number in base62, least significant digit last.
+// If length is positive, only the length least significant digits are encoded, padded with zeros.
+func encodeBase62(n *big.Int, length int) string {
+	base := big.NewInt(int64(len(base62Alphabet)))
+	var (
+		digits []byte
+		mod    = new(big.Int)
+	)
+	for (length <= 0 && n.Sign() > 0) || len(digits) < length {
+		n.DivMod(n, base, mod)
+		digits = append(digits, base62Alphabet[mod.Int64()])
+	}
+	if len(digits) == 0 {
+		return base62Alphabet[:1]
+	}
+	for i, j := 0, len(digits)-1; i < j; i, j = i+1, j-1 {
+		digits[i], digits[j] = digits[j], digits[i]
+	}
+	return string(digits)
+}
+
+// Generate creates a short ID that is free in the storage.
+//
+// Parameters:
+//   - ctx: context for request cancellation and timeouts
+//   - origURL: original URL the short ID is generated for
+//
+// Returns:
+//   - string: generated short ID
+//   - error: nil on success, or error if generation or storage lookup fails
+//
+// Errors:
+//   - ErrShortIDExhausted: when every candidate collides with existing short IDs
+func (g *UniqueIDGenerator) Generate(ctx context.Context, origURL string) (string, error) {
+	for attempt := 0; attempt < g.maxAttempts; attempt++ {
+		id, err := g.next(ctx, origURL, attempt)
+		if err != nil {
+			return "", fmt.Errorf("generate short id candidate: %w", err)
+		}
+		if _, reserved := reservedAliases[strings.ToLower(id)]; reserved {
+			continue
+		}
+		if g.reject != nil && g.reject(id) {
+			continue
+		}
+		taken, err := g.isTaken(ctx, id)
+		if err != nil {
+			return "", err
+		}
+		if !taken {
+			return id, nil
+		}
+	}
+	return "", fmt.Errorf("%w after %d attempts", ErrShortIDExhausted, g.maxAttempts)
+}
+
+// isTaken reports whether the storage has a record with the short ID, including deleted,
+// expired and exhausted ones.
+func (g *UniqueIDGenerator) isTaken(ctx context.Context, shortID string) (bool, error) {
+	_, err := g.storage.Get(ctx, shortID, repo.ShortURLType)
+	var nfErr *repo.DataNotFoundError
+	switch {
+	case errors.As(err, &nfErr):
+		return false, nil
+	case err == nil,
+		errors.Is(err, repo.ErrDataDeleted),
+		errors.Is(err, repo.ErrDataExpired),
+		errors.Is(err, repo.ErrClicksExhausted):
+		return true, nil
+	default:
+		return false, fmt.Errorf("check short id collision: %w", err)
+	}
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"math/big"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alex-storchak/shortener/internal/config"
+	"github.com/alex-storchak/shortener/internal/model"
+	repo "github.com/alex-storchak/shortener/internal/repository"
+)
+
+type sequenceStorageStub struct {
+	taken map[string]struct{}
+	seq   uint64
+}
+
+func newSequenceStorageStub(taken ...string) *sequenceStorageStub {
+	s := &sequenceStorageStub{taken: make(map[string]struct{})}
+	for _, id := range taken {
+		s.taken[id] = struct{}{}
+	}
+	return s
+}
+
+func (s *sequenceStorageStub) Get(_ context.Context, url, _ string) (*model.URLStorageRecord, error) {
+	if _, ok := s.taken[url]; ok {
+		return &model.URLStorageRecord{ShortID: url}, nil
+	}
+	return nil, repo.NewDataNotFoundError(nil)
+}
+
+func (s *sequenceStorageStub) NextSequence(_ context.Context) (uint64, error) {
+	s.seq++
+	return s.seq, nil
+}
+
+func TestNewIDGenerator(t *testing.T) {
+	cfg := config.Shortener{
+		IDLength:      8,
+		IDAlphabet:    config.DefIDAlphabet,
+		IDMaxAttempts: 3,
+	}
+	for _, strategy := range []string{IDStrategyShortID, IDStrategyRandom, IDStrategySequential, IDStrategyHash, IDStrategySnowflake} {
+		cfg.IDStrategy = strategy
+		g, err := NewIDGenerator(cfg, newSequenceStorageStub())
+		require.NoError(t, err, strategy)
+		id, err := g.Generate(t.Context(), "https://example.com")
+		require.NoError(t, err, strategy)
+		assert.Regexp(t, aliasPattern, id, strategy)
+	}
+
+	cfg.IDStrategy = "unknown"
+	_, err := NewIDGenerator(cfg, newSequenceStorageStub())
+	require.Error(t, err)
+}
+
+func TestNewRandomIDGenerator(t *testing.T) {
+	s := newSequenceStorageStub()
+	g, err := NewRandomIDGenerator(s, "ab", 12, 3)
+	require.NoError(t, err)
+	id, err := g.Generate(t.Context(), "https://example.com")
+	require.NoError(t, err)
+	assert.Regexp(t, regexp.MustCompile(`^[ab]{12}$`), id)
+
+	for _, alphabet := range []string{"", "a", "aba", "ab/"} {
+		_, err = NewRandomIDGenerator(s, alphabet, 8, 3)
+		assert.Error(t, err, alphabet)
+	}
+	_, err = NewRandomIDGenerator(s, "ab", 0, 3)
+	assert.Error(t, err)
+	_, err = NewRandomIDGenerator(s, "ab", 8, 0)
+	assert.Error(t, err)
+}
+
+func TestSequentialIDGenerator_Generate(t *testing.T) {
+	// "2" is taken by an alias, so its counter value is skipped
+	s := newSequenceStorageStub("2")
+	g, err := NewSequentialIDGenerator(s, 3)
+	require.NoError(t, err)
+
+	var ids []string
+	for range 3 {
+		id, err := g.Generate(t.Context(), "")
+		require.NoError(t, err)
+		ids = append(ids, id)
+	}
+	assert.Equal(t, []string{"1", "3", "4"}, ids)
+}
+
+func TestHashIDGenerator_Generate(t *testing.T) {
+	g, err := NewHashIDGenerator(newSequenceStorageStub(), 8, 3)
+	require.NoError(t, err)
+	first, err := g.Generate(t.Context(), "https://example.com")
+	require.NoError(t, err)
+	assert.Len(t, first, 8)
+	again, err := g.Generate(t.Context(), "https://example.com")
+	require.NoError(t, err)
+	assert.Equal(t, first, again)
+	other, err := g.Generate(t.Context(), "https://example.org")
+	require.NoError(t, err)
+	assert.NotEqual(t, first, other)
+
+	g, err = NewHashIDGenerator(newSequenceStorageStub(first), 8, 3)
+	require.NoError(t, err)
+	retried, err := g.Generate(t.Context(), "https://example.com")
+	require.NoError(t, err)
+	assert.NotEqual(t, first, retried)
+
+	_, err = NewHashIDGenerator(newSequenceStorageStub(), 44, 3)
+	assert.Error(t, err)
+}
+
+func TestUniqueIDGenerator_Exhausted(t *testing.T) {
+	g, err := NewRandomIDGenerator(newSequenceStorageStub("a", "b"), "ab", 1, 5)
+	require.NoError(t, err)
+	_, err = g.Generate(t.Context(), "https://example.com")
+	require.ErrorIs(t, err, ErrShortIDExhausted)
+}
+
+func TestEncodeBase62(t *testing.T) {
+	tests := []struct {
+		n      int64
+		length int
+		want   string
+	}{
+		{n: 0, want: "0"},
+		{n: 61, want: "z"},
+		{n: 62, want: "10"},
+		{n: 3843, want: "zz"},
+		{n: 62, length: 4, want: "0010"},
+		{n: 3843 + 62*62, length: 2, want: "zz"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, encodeBase62(big.NewInt(tt.n), tt.length))
+	}
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// joinPassthrough forwards the path suffix and the query of a short URL request to the destination.
+//
+// The suffix is appended to the destination path, so `/{id}/extra/path` of a link to `https://host/docs`
+// leads to `https://host/docs/extra/path`. Suffix segments are kept escaped as requested. Segments `.` and `..`
+// and segments with escaped slashes are rejected, so the suffix can't climb above the destination path.
+//
+// Query parameters are merged with the destination ones: parameters set in the destination take precedence,
+// other request parameters are appended in the request order, including repeated ones.
+// Raw encoding of both queries is preserved. The destination fragment is kept at the end.
+//
+// Parameters:
+//   - dest: original URL of the link
+//   - suffix: escaped path after the short identifier, starting with `/`; empty if absent
+//   - rawQuery: raw query of the request without `?`; empty if absent
+//
+// Returns:
+//   - string: destination with the forwarded suffix and query
+//   - error: nil on success, or *ValidationError wrapping ErrInvalidPassthrough if the suffix or query is unsafe
+func joinPassthrough(dest, suffix, rawQuery string) (string, error) {
+	if suffix == "" && rawQuery == "" {
+		return dest, nil
+	}
+	u, err := url.Parse(dest)
+	if err != nil {
+		return "", fmt.Errorf("parse destination url: %w", err)
+	}
+	if suffix != "" {
+		if err := joinPath(u, suffix); err != nil {
+			return "", err
+		}
+	}
+	if rawQuery != "" {
+		if u.RawQuery, err = mergeQuery(u.RawQuery, rawQuery); err != nil {
+			return "", err
+		}
+	}
+	return u.String(), nil
+}
+
+// joinPath appends the escaped suffix to the path of the destination.
+func joinPath(u *url.URL, suffix string) error {
+	if !strings.HasPrefix(suffix, "/") {
+		return NewValidationError(ErrInvalidPassthrough, "path suffix must start with `/`")
+	}
+	for _, seg := range strings.Split(suffix[1:], "/") {
+		dec, err := url.PathUnescape(seg)
+		if err != nil {
+			return NewValidationError(ErrInvalidPassthrough, fmt.Sprintf("malformed path segment `%s`", seg))
+		}
+		if dec == "." || dec == ".." || strings.ContainsAny(dec, `/\`) {
+			return NewValidationError(ErrInvalidPassthrough, fmt.Sprintf("path segment `%s` is not allowed", seg))
+		}
+	}
+
+	escaped := strings.TrimSuffix(u.EscapedPath(), "/") + suffix
+	path, err := url.PathUnescape(escaped)
+	if err != nil {
+		return NewValidationError(ErrInvalidPassthrough, "malformed path suffix")
+	}
+	u.Path = path
+	u.RawPath = escaped
+	return nil
+}
+
+// mergeQuery appends the request query parameters missing in the destination query.
+// Both queries are handled in their raw form, so their encoding and order are kept.
+func mergeQuery(destQuery, reqQuery string) (string, error) {
+	taken := make(map[string]struct{})
+	for _, pair := range strings.Split(destQuery, "&") {
+		if pair == "" {
+			continue
+		}
+		key, err := queryKey(pair)
+		if err != nil {
+			// The destination was accepted on shortening; an odd key just can't be overridden.
+			continue
+		}
+		taken[key] = struct{}{}
+	}
+
+	merged := destQuery
+	for _, pair := range strings.Split(reqQuery, "&") {
+		if pair == "" {
+			continue
+		}
+		key, err := queryKey(pair)
+		if err != nil || strings.ContainsFunc(pair, isUnsafeQueryRune) {
+			return "", NewValidationError(ErrInvalidPassthrough, fmt.Sprintf("malformed query parameter `%s`", pair))
+		}
+		if _, ok := taken[key]; ok {
+			continue
+		}
+		if merged != "" {
+			merged += "&"
+		}
+		merged += pair
+	}
+	return merged, nil
+}
+
+// isUnsafeQueryRune reports whether the rune can't appear unescaped in a raw query:
+// it would end the query, like `#`, or break the URL, like spaces and control characters.
+func isUnsafeQueryRune(r rune) bool {
+	return r == '#' || r == ' ' || r < 0x20 || r == 0x7f
+}
+
+// queryKey returns the unescaped name of the raw query parameter.
+func queryKey(pair string) (string, error) {
+	key, _, _ := strings.Cut(pair, "=")
+	return url.QueryUnescape(key)
+}
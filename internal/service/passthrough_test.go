@@ -0,0 +1,135 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJoinPassthrough(t *testing.T) {
+	tests := []struct {
+		name     string
+		dest     string
+		suffix   string
+		rawQuery string
+		want     string
+		wantErr  bool
+	}{
+		{
+			name: "keeps destination without suffix and query",
+			dest: "https://example.com/docs?a=1#top",
+			want: "https://example.com/docs?a=1#top",
+		},
+		{
+			name:   "appends suffix to destination path",
+			dest:   "https://example.com/docs",
+			suffix: "/extra/path",
+			want:   "https://example.com/docs/extra/path",
+		},
+		{
+			name:   "doesn't double slash of destination with trailing slash",
+			dest:   "https://example.com/docs/",
+			suffix: "/extra",
+			want:   "https://example.com/docs/extra",
+		},
+		{
+			name:   "appends suffix to destination without path",
+			dest:   "https://example.com",
+			suffix: "/extra/",
+			want:   "https://example.com/extra/",
+		},
+		{
+			name:   "keeps escaped suffix segments",
+			dest:   "https://example.com/docs",
+			suffix: "/a%20b/%D0%BF",
+			want:   "https://example.com/docs/a%20b/%D0%BF",
+		},
+		{
+			name:   "keeps destination fragment after suffix",
+			dest:   "https://example.com/docs#intro",
+			suffix: "/extra",
+			want:   "https://example.com/docs/extra#intro",
+		},
+		{
+			name:     "appends query to destination without query",
+			dest:     "https://example.com/docs",
+			rawQuery: "utm_source=mail&b=2",
+			want:     "https://example.com/docs?utm_source=mail&b=2",
+		},
+		{
+			name:     "destination parameters take precedence",
+			dest:     "https://example.com/docs?a=1",
+			rawQuery: "a=2&b=3",
+			want:     "https://example.com/docs?a=1&b=3",
+		},
+		{
+			name:     "keeps repeated request parameters and their encoding",
+			dest:     "https://example.com/docs",
+			rawQuery: "tag=a&tag=b%20c&flag",
+			want:     "https://example.com/docs?tag=a&tag=b%20c&flag",
+		},
+		{
+			name:     "merges query and suffix before destination fragment",
+			dest:     "https://example.com/docs?a=1#intro",
+			suffix:   "/extra",
+			rawQuery: "b=2",
+			want:     "https://example.com/docs/extra?a=1&b=2#intro",
+		},
+		{
+			name:    "rejects dot segment",
+			dest:    "https://example.com/docs",
+			suffix:  "/../admin",
+			wantErr: true,
+		},
+		{
+			name:    "rejects escaped dot segment",
+			dest:    "https://example.com/docs",
+			suffix:  "/%2e%2E/admin",
+			wantErr: true,
+		},
+		{
+			name:    "rejects escaped slash",
+			dest:    "https://example.com/docs",
+			suffix:  "/a%2Fb",
+			wantErr: true,
+		},
+		{
+			name:    "rejects malformed escape in suffix",
+			dest:    "https://example.com/docs",
+			suffix:  "/%zz",
+			wantErr: true,
+		},
+		{
+			name:     "rejects malformed query key",
+			dest:     "https://example.com/docs",
+			rawQuery: "%zz=1",
+			wantErr:  true,
+		},
+		{
+			name:     "rejects fragment in query",
+			dest:     "https://example.com/docs",
+			rawQuery: "a=1#x",
+			wantErr:  true,
+		},
+		{
+			name:     "rejects space in query",
+			dest:     "https://example.com/docs",
+			rawQuery: "a=1 2",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := joinPassthrough(tt.dest, tt.suffix, tt.rawQuery)
+			if tt.wantErr {
+				var vErr *ValidationError
+				require.ErrorAs(t, err, &vErr)
+				require.ErrorIs(t, err, ErrInvalidPassthrough)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
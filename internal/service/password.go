@@ -0,0 +1,49 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// maxPasswordLen is the maximum length of a link password in bytes, limited by bcrypt.
+const maxPasswordLen = 72
+
+// hashPassword validates a link password and returns its bcrypt hash.
+//
+// Parameters:
+//   - password: plain link password requested by the user
+//
+// Returns:
+//   - string: bcrypt hash of the password
+//   - error: nil on success, *ValidationError wrapping ErrInvalidPassword with the reason,
+//     or error if hashing fails
+func hashPassword(password string) (string, error) {
+	if len(password) > maxPasswordLen {
+		return "", NewValidationError(ErrInvalidPassword, fmt.Sprintf("longer than %d bytes", maxPasswordLen))
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", fmt.Errorf("hash password: %w", err)
+	}
+	return string(hash), nil
+}
+
+// checkPassword compares a plain password with the stored bcrypt hash.
+//
+// Parameters:
+//   - hash: stored bcrypt hash of the link password
+//   - password: plain password supplied by the visitor
+//
+// Returns:
+//   - error: nil if password matches, ErrWrongPassword if it doesn't, or error if comparison fails
+func checkPassword(hash, password string) error {
+	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return ErrWrongPassword
+	} else if err != nil {
+		return fmt.Errorf("compare password with hash: %w", err)
+	}
+	return nil
+}
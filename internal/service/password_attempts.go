@@ -0,0 +1,134 @@
+package service
+
+import (
+	"sync"
+	"time"
+)
+
+// attemptsWindow holds failed password attempts of a single link.
+type attemptsWindow struct {
+	start    time.Time // Moment of the first failed attempt in the window
+	failures int       // Amount of failed attempts in the window
+}
+
+// windowStart identifies the attempts window of a link started at the given moment.
+type windowStart struct {
+	shortID string
+	start   time.Time
+}
+
+// PasswordAttemptLimiter limits failed password attempts per link using fixed time windows.
+// Once the limit is reached, all attempts for the link are rejected until the window is over.
+// A nil limiter allows every attempt.
+type PasswordAttemptLimiter struct {
+	maxAttempts int
+	window      time.Duration
+	attempts    map[string]attemptsWindow
+	starts      []windowStart // Started windows in order of their start, to drop the ones that are over
+	mu          sync.Mutex
+}
+
+// NewPasswordAttemptLimiter creates a new PasswordAttemptLimiter.
+//
+// Parameters:
+//   - maxAttempts: allowed amount of failed attempts per link within the window
+//   - window: duration of the window counted from the first failed attempt
+//
+// Returns:
+//   - *PasswordAttemptLimiter: configured limiter
+func NewPasswordAttemptLimiter(maxAttempts int, window time.Duration) *PasswordAttemptLimiter {
+	return &PasswordAttemptLimiter{
+		maxAttempts: maxAttempts,
+		window:      window,
+		attempts:    make(map[string]attemptsWindow),
+	}
+}
+
+// Reserve counts a password attempt for the link as failed in advance, unless the link has reached
+// the limit of failed attempts in the current window. Checking the limit and counting the attempt is a single
+// step, so concurrent attempts can't exceed the limit. An attempt that turns out to be successful resets
+// the attempts of the link with Reset; an attempt that fails for another reason than a wrong password
+// is given back with Release. Entries of windows that are over are dropped in order of their start
+// to keep memory usage bounded without scanning every link on each attempt.
+//
+// Parameters:
+//   - shortID: short identifier of the protected link
+//   - now: moment of the attempt
+//
+// Returns:
+//   - bool: false if the link has reached the limit of failed attempts in the current window
+func (l *PasswordAttemptLimiter) Reserve(shortID string, now time.Time) bool {
+	if l == nil || l.maxAttempts <= 0 {
+		return true
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.dropOver(now)
+	w, ok := l.attempts[shortID]
+	if !ok || l.isOver(w.start, now) {
+		w = attemptsWindow{start: now}
+		l.starts = append(l.starts, windowStart{shortID: shortID, start: now})
+	}
+	if w.failures >= l.maxAttempts {
+		return false
+	}
+	w.failures++
+	l.attempts[shortID] = w
+	return true
+}
+
+// Release gives back an attempt reserved for the link which didn't fail because of a wrong password.
+//
+// Parameters:
+//   - shortID: short identifier of the protected link
+func (l *PasswordAttemptLimiter) Release(shortID string) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	w, ok := l.attempts[shortID]
+	if !ok {
+		return
+	}
+	w.failures--
+	if w.failures <= 0 {
+		delete(l.attempts, shortID)
+		return
+	}
+	l.attempts[shortID] = w
+}
+
+// Reset forgets failed password attempts of the link after a successful one.
+//
+// Parameters:
+//   - shortID: short identifier of the protected link
+func (l *PasswordAttemptLimiter) Reset(shortID string) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.attempts, shortID)
+}
+
+// dropOver forgets the windows that are over by now, starting from the oldest one.
+// Windows reset or released in the meantime are skipped, as they are no longer tracked.
+func (l *PasswordAttemptLimiter) dropOver(now time.Time) {
+	for len(l.starts) > 0 && l.isOver(l.starts[0].start, now) {
+		ws := l.starts[0]
+		if w, ok := l.attempts[ws.shortID]; ok && w.start.Equal(ws.start) {
+			delete(l.attempts, ws.shortID)
+		}
+		l.starts[0] = windowStart{}
+		l.starts = l.starts[1:]
+	}
+}
+
+// isOver reports whether the attempts window started at the given moment is finished by now.
+func (l *PasswordAttemptLimiter) isOver(start, now time.Time) bool {
+	return !now.Before(start.Add(l.window))
+}
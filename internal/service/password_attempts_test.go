@@ -0,0 +1,55 @@
+package service
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPasswordAttemptLimiter(t *testing.T) {
+	now := time.Now()
+	l := NewPasswordAttemptLimiter(2, time.Minute)
+
+	assert.True(t, l.Reserve("abc", now))
+	assert.True(t, l.Reserve("abc", now.Add(time.Second)))
+	assert.False(t, l.Reserve("abc", now.Add(2*time.Second)), "limit reached within the window")
+	assert.True(t, l.Reserve("other", now), "limit is counted per link")
+	assert.True(t, l.Reserve("abc", now.Add(time.Minute)), "window is over")
+	assert.NotContains(t, l.attempts, "other", "windows that are over are dropped")
+
+	l.Reset("abc")
+	assert.True(t, l.Reserve("abc", now.Add(time.Minute)))
+	l.Release("abc")
+	assert.True(t, l.Reserve("abc", now.Add(time.Minute)))
+	assert.True(t, l.Reserve("abc", now.Add(time.Minute)), "released attempt is not counted")
+	assert.False(t, l.Reserve("abc", now.Add(time.Minute)))
+
+	var nilLimiter *PasswordAttemptLimiter
+	nilLimiter.Release("abc")
+	assert.True(t, nilLimiter.Reserve("abc", now))
+}
+
+func TestPasswordAttemptLimiter_Concurrent(t *testing.T) {
+	const maxAttempts = 3
+	now := time.Now()
+	l := NewPasswordAttemptLimiter(maxAttempts, time.Minute)
+
+	var (
+		wg      sync.WaitGroup
+		allowed atomic.Int32
+	)
+	for range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if l.Reserve("abc", now) {
+				allowed.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(maxAttempts), allowed.Load())
+}
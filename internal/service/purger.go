@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// DeletedURLPurger defines the interface for storages able to physically remove deleted links.
+type DeletedURLPurger interface {
+	PurgeDeleted(ctx context.Context, before time.Time, batchSize int) ([]string, error)
+	CountPurged(ctx context.Context) (int, error)
+}
+
+// PurgedLinkCleaner defines the interface for storages of link settings kept apart from the links,
+// which have to be removed when their links are purged.
+type PurgedLinkCleaner interface {
+	DeletePurged(ctx context.Context, shortIDs []string) error
+}
+
+// Purger periodically removes links that have stayed soft-deleted longer than the retention period.
+// Until then deleted links can be viewed in the trash and restored by their owners.
+// Short IDs of purged links are reserved by the storage and never issued again.
+// Settings of purged links are removed from the cleaners added to the purger.
+type Purger struct {
+	storage   DeletedURLPurger
+	cleaners  []PurgedLinkCleaner
+	retention time.Duration
+	interval  time.Duration
+	batchSize int
+	logger    *zap.Logger
+	closed    chan struct{}
+	wg        sync.WaitGroup
+	once      sync.Once
+}
+
+// NewPurger creates a new Purger.
+//
+// Parameters:
+//   - s: storage to purge deleted links from
+//   - retention: time deleted links are kept; non-positive value disables the purger
+//   - interval: interval between purges; non-positive value disables the purger
+//   - batchSize: maximum amount of links removed from the storage in a single step
+//   - l: structured logger for logging operations
+//
+// Returns:
+//   - *Purger: configured purger, not started yet
+func NewPurger(s DeletedURLPurger, retention, interval time.Duration, batchSize int, l *zap.Logger) *Purger {
+	return &Purger{
+		storage:   s,
+		retention: retention,
+		interval:  interval,
+		batchSize: batchSize,
+		logger:    l,
+		closed:    make(chan struct{}),
+	}
+}
+
+// AddCleaners adds storages to remove settings of purged links from.
+// It must be called before Start.
+//
+// Parameters:
+//   - c: storages of link settings
+func (p *Purger) AddCleaners(c ...PurgedLinkCleaner) {
+	p.cleaners = append(p.cleaners, c...)
+}
+
+// Start launches the background purging goroutine.
+// It is a no-op if the purger is disabled by a non-positive retention or interval.
+func (p *Purger) Start() {
+	if p.retention <= 0 || p.interval <= 0 {
+		p.logger.Info("purger of deleted urls is disabled")
+		return
+	}
+	p.wg.Add(1)
+	go p.run()
+}
+
+// run purges deleted links on every tick until the purger is closed.
+func (p *Purger) run() {
+	defer p.wg.Done()
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			p.purge(time.Now())
+		case <-p.closed:
+			p.logger.Info("purger is closing, finish purging")
+			return
+		}
+	}
+}
+
+// purge performs a single purge of links deleted before the retention period started,
+// limited in time by the purge interval.
+func (p *Purger) purge(now time.Time) {
+	ctx, cancel := context.WithTimeout(context.Background(), p.interval)
+	defer cancel()
+
+	shortIDs, err := p.storage.PurgeDeleted(ctx, now.Add(-p.retention), p.batchSize)
+	if len(shortIDs) > 0 {
+		p.logger.Info("deleted urls purged", zap.Int("count", len(shortIDs)))
+		p.clean(ctx, shortIDs)
+	}
+	if err != nil {
+		p.logger.Error("failed to purge deleted urls", zap.Error(err))
+	}
+}
+
+// clean removes settings of the purged links from all cleaners.
+// A failing cleaner doesn't prevent the others from being cleaned.
+func (p *Purger) clean(ctx context.Context, shortIDs []string) {
+	for _, c := range p.cleaners {
+		if err := c.DeletePurged(ctx, shortIDs); err != nil {
+			p.logger.Error("failed to delete settings of purged urls", zap.Error(err))
+		}
+	}
+}
+
+// Count counts the amount of links purged so far.
+// It allows the purger to be used as a statistics counter.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//
+// Returns:
+//   - int: total amount of purged links
+//   - error: nil on success, or storage error if the count fails
+func (p *Purger) Count(ctx context.Context) (int, error) {
+	n, err := p.storage.CountPurged(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("count purged urls: %w", err)
+	}
+	return n, nil
+}
+
+// Close stops the purger and waits for the running purge, if any, to finish.
+func (p *Purger) Close() {
+	p.once.Do(func() {
+		close(p.closed)
+		p.wg.Wait()
+		p.logger.Info("purger closed")
+	})
+}
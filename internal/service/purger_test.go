@@ -0,0 +1,41 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/alex-storchak/shortener/internal/model"
+	repo "github.com/alex-storchak/shortener/internal/repository"
+)
+
+func TestPurger_Purge(t *testing.T) {
+	urls := repo.NewMemoryURLStorage(zap.NewNop())
+	require.NoError(t, urls.BatchSet(t.Context(), []model.URLStorageRecord{
+		{OrigURL: "https://old.com", ShortID: "old", UserUUID: "userUUID"},
+		{OrigURL: "https://live.com", ShortID: "live", UserUUID: "userUUID"},
+	}))
+	require.NoError(t, urls.DeleteBatch(t.Context(), model.URLDeleteBatch{{ShortID: "old", UserUUID: "userUUID"}}))
+
+	rules := repo.NewMemoryTargetingRuleStorage(zap.NewNop())
+	linkRules := []model.TargetingRule{{OS: OSIOS, URL: "https://apps.apple.com/app"}}
+	require.NoError(t, rules.Set(t.Context(), "old", linkRules))
+	require.NoError(t, rules.Set(t.Context(), "live", linkRules))
+
+	p := NewPurger(urls, time.Hour, time.Minute, 10, zap.NewNop())
+	p.AddCleaners(rules)
+	p.purge(time.Now().Add(time.Hour))
+
+	count, err := p.Count(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	got, err := rules.Get(t.Context(), "old")
+	require.NoError(t, err)
+	assert.Empty(t, got, "rules of purged link are deleted")
+	got, err = rules.Get(t.Context(), "live")
+	require.NoError(t, err)
+	assert.Equal(t, linkRules, got)
+}
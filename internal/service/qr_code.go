@@ -0,0 +1,279 @@
+package service
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image/color"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/alex-storchak/shortener/internal/model"
+	"github.com/alex-storchak/shortener/internal/qrcode"
+	repo "github.com/alex-storchak/shortener/internal/repository"
+)
+
+// Limits and defaults of QR codes.
+const (
+	defQRSize   = 256  // Default width and height of the image in pixels
+	minQRSize   = 32   // Minimum width and height of the image in pixels
+	maxQRSize   = 2048 // Maximum width and height of the image in pixels
+	defQRMargin = 4    // Default width of the quiet zone in modules, as required by the standard
+	maxQRMargin = 32   // Maximum width of the quiet zone in modules
+	defQRLevel  = "M"  // Default error correction level
+)
+
+var (
+	defQRFg = color.NRGBA{A: 0xff}                            // Default color of dark modules
+	defQRBg = color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff} // Default color of light modules
+)
+
+// qrContentTypes are MIME types of the supported image formats of QR codes.
+var qrContentTypes = map[string]string{
+	model.QRFormatPNG: "image/png",
+	model.QRFormatSVG: "image/svg+xml",
+}
+
+// QRCodes renders QR codes of short URLs and keeps the recently rendered images in memory.
+// A QR code encodes the full short URL, so following it counts as a usual follow of the link.
+// Images are cached by short ID and options: the short URL of a short ID never changes,
+// so cached images never get stale.
+type QRCodes struct {
+	urls   ShortIDLookup
+	ub     *URLBuilder
+	cache  *qrCache
+	logger *zap.Logger
+}
+
+// NewQRCodes creates a new instance of QRCodes.
+//
+// Parameters:
+//   - urls: URL storage used to check that links exist and can be followed
+//   - ub: builder of the short URLs encoded into QR codes
+//   - cacheSize: maximum amount of images kept in memory; zero disables caching
+//   - logger: structured logger for logging operations
+//
+// Returns:
+//   - *QRCodes: configured QR code service
+func NewQRCodes(urls ShortIDLookup, ub *URLBuilder, cacheSize int, logger *zap.Logger) *QRCodes {
+	s := &QRCodes{
+		urls:   urls,
+		ub:     ub,
+		logger: logger,
+	}
+	if cacheSize > 0 {
+		s.cache = newQRCache(cacheSize)
+	}
+	return s
+}
+
+// qrSpec holds validated QR code options with the defaults applied.
+type qrSpec struct {
+	format string
+	size   int
+	level  qrcode.Level
+	margin int
+	fg, bg color.NRGBA
+}
+
+// key returns the cache key of the image of the short ID rendered by the spec.
+func (s qrSpec) key(shortID string) string {
+	return fmt.Sprintf("%s|%s|%d|%s|%d|%02x%02x%02x|%02x%02x%02x", shortID, s.format, s.size, s.level, s.margin,
+		s.fg.R, s.fg.G, s.fg.B, s.bg.R, s.bg.G, s.bg.B)
+}
+
+// Generate renders the QR code of a short URL, or returns the image rendered before with the same options.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - shortID: short identifier of the link
+//   - opts: appearance of the QR code; zero values select the defaults
+//
+// Returns:
+//   - *model.QRCode: rendered image with its MIME type
+//   - error: nil on success, or storage error if URL not found, deleted, expired or has no follows left
+//
+// Errors:
+//   - ErrInvalidQROptions: when the format, size, level, margin or colors are invalid; returned as *ValidationError
+//   - ErrPassthroughLink: when opts.SkipPassthrough is set and the link has passthrough enabled
+func (s *QRCodes) Generate(ctx context.Context, shortID string, opts model.QROptions) (*model.QRCode, error) {
+	r, err := s.urls.Get(ctx, shortID, repo.ShortURLType)
+	if err != nil {
+		return nil, fmt.Errorf("get url `%s` of qr code: %w", shortID, err)
+	}
+	if opts.SkipPassthrough && r.Passthrough {
+		return nil, ErrPassthroughLink
+	}
+	spec, err := parseQROptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	key := spec.key(shortID)
+	if code, ok := s.cache.get(key); ok {
+		return code, nil
+	}
+	code, err := s.render(shortID, spec)
+	if err != nil {
+		return nil, err
+	}
+	s.cache.put(key, code)
+	return code, nil
+}
+
+// render encodes the short URL of the short ID and renders the image by the spec.
+func (s *QRCodes) render(shortID string, spec qrSpec) (*model.QRCode, error) {
+	c, err := qrcode.Encode([]byte(s.ub.Build(shortID)), spec.level)
+	if err != nil {
+		return nil, fmt.Errorf("encode qr code: %w", err)
+	}
+	ro := qrcode.RenderOptions{
+		Size:       spec.size,
+		Margin:     spec.margin,
+		Foreground: spec.fg,
+		Background: spec.bg,
+	}
+	var buf bytes.Buffer
+	if spec.format == model.QRFormatSVG {
+		err = qrcode.WriteSVG(&buf, c, ro)
+	} else {
+		err = qrcode.WritePNG(&buf, c, ro)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("render qr code: %w", err)
+	}
+	return &model.QRCode{Data: buf.Bytes(), ContentType: qrContentTypes[spec.format]}, nil
+}
+
+// parseQROptions validates the options and applies the defaults.
+func parseQROptions(opts model.QROptions) (qrSpec, error) {
+	spec := qrSpec{
+		format: strings.ToLower(strings.TrimSpace(opts.Format)),
+		size:   opts.Size,
+		margin: defQRMargin,
+	}
+	if spec.format == "" {
+		spec.format = model.QRFormatPNG
+	}
+	if _, ok := qrContentTypes[spec.format]; !ok {
+		return spec, NewValidationError(ErrInvalidQROptions, fmt.Sprintf("format `%s` must be png or svg", opts.Format))
+	}
+
+	if spec.size == 0 {
+		spec.size = defQRSize
+	}
+	if spec.size < minQRSize || spec.size > maxQRSize {
+		return spec, NewValidationError(ErrInvalidQROptions, fmt.Sprintf("size must be from %d to %d pixels", minQRSize, maxQRSize))
+	}
+
+	level := strings.TrimSpace(opts.Level)
+	if level == "" {
+		level = defQRLevel
+	}
+	var err error
+	if spec.level, err = qrcode.ParseLevel(level); err != nil {
+		return spec, NewValidationError(ErrInvalidQROptions, fmt.Sprintf("level `%s` must be one of L, M, Q, H", opts.Level))
+	}
+
+	if opts.Margin != nil {
+		spec.margin = *opts.Margin
+	}
+	if spec.margin < 0 || spec.margin > maxQRMargin {
+		return spec, NewValidationError(ErrInvalidQROptions, fmt.Sprintf("margin must be from 0 to %d modules", maxQRMargin))
+	}
+
+	if spec.fg, err = parseHexColor(opts.Foreground, defQRFg); err != nil {
+		return spec, NewValidationError(ErrInvalidQROptions, fmt.Sprintf("foreground: %s", err))
+	}
+	if spec.bg, err = parseHexColor(opts.Background, defQRBg); err != nil {
+		return spec, NewValidationError(ErrInvalidQROptions, fmt.Sprintf("background: %s", err))
+	}
+	if spec.fg == spec.bg {
+		return spec, NewValidationError(ErrInvalidQROptions, "foreground and background must differ")
+	}
+	return spec, nil
+}
+
+// errInvalidHexColor is the reason of rejecting a malformed color.
+var errInvalidHexColor = errors.New("color must be in `rrggbb` or `rgb` hex notation, optionally prefixed with `#`")
+
+// parseHexColor parses an opaque color in `#rrggbb`, `rrggbb`, `#rgb` or `rgb` notation.
+// An empty color is replaced with the default one.
+func parseHexColor(s string, def color.NRGBA) (color.NRGBA, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "#")
+	if s == "" {
+		return def, nil
+	}
+	if len(s) == 3 {
+		s = string([]byte{s[0], s[0], s[1], s[1], s[2], s[2]})
+	}
+	b, err := hex.DecodeString(s)
+	if err != nil || len(b) != 3 {
+		return def, errInvalidHexColor
+	}
+	return color.NRGBA{R: b[0], G: b[1], B: b[2], A: 0xff}, nil
+}
+
+// qrCache is a least recently used cache of rendered QR codes.
+// A nil cache keeps nothing.
+type qrCache struct {
+	mu    sync.Mutex
+	size  int
+	ll    *list.List // Entries from the most recently used
+	items map[string]*list.Element
+}
+
+// qrCacheEntry is a cached image with its key.
+type qrCacheEntry struct {
+	key  string
+	code *model.QRCode
+}
+
+// newQRCache creates a cache keeping up to size images.
+func newQRCache(size int) *qrCache {
+	return &qrCache{
+		size:  size,
+		ll:    list.New(),
+		items: make(map[string]*list.Element, size),
+	}
+}
+
+// get returns the cached image by the key and marks it as recently used.
+func (c *qrCache) get(key string) (*model.QRCode, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	c.ll.MoveToFront(el)
+	return el.Value.(*qrCacheEntry).code, true
+}
+
+// put caches the image by the key, evicting the least recently used image if the cache is full.
+func (c *qrCache) put(key string, code *model.QRCode) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[key]; ok {
+		el.Value.(*qrCacheEntry).code = code
+		c.ll.MoveToFront(el)
+		return
+	}
+	c.items[key] = c.ll.PushFront(&qrCacheEntry{key: key, code: code})
+	if c.ll.Len() > c.size {
+		oldest := c.ll.Back()
+		c.ll.Remove(oldest)
+		delete(c.items, oldest.Value.(*qrCacheEntry).key)
+	}
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/alex-storchak/shortener/internal/model"
+	repo "github.com/alex-storchak/shortener/internal/repository"
+)
+
+func newTestQRCodes(t *testing.T, cacheSize int) *QRCodes {
+	t.Helper()
+	urls := repo.NewMemoryURLStorage(zap.NewNop())
+	require.NoError(t, urls.Set(t.Context(), model.URLStorageRecord{OrigURL: "http://a.com", ShortID: "abcde", UserUUID: "userUUID"}))
+	require.NoError(t, urls.Set(t.Context(), model.URLStorageRecord{OrigURL: "http://b.com", ShortID: "fghij", UserUUID: "userUUID"}))
+	require.NoError(t, urls.Set(t.Context(), model.URLStorageRecord{
+		OrigURL: "http://c.com", ShortID: "klmno", UserUUID: "userUUID", Passthrough: true,
+	}))
+	return NewQRCodes(urls, NewURLBuilder("http://localhost:8080"), cacheSize, zap.NewNop())
+}
+
+func TestQRCodes_Generate(t *testing.T) {
+	s := newTestQRCodes(t, 10)
+
+	code, err := s.Generate(t.Context(), "abcde", model.QROptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "image/png", code.ContentType)
+	img, err := png.Decode(bytes.NewReader(code.Data))
+	require.NoError(t, err)
+	assert.Equal(t, defQRSize, img.Bounds().Dx())
+
+	margin := 0
+	code, err = s.Generate(t.Context(), "abcde", model.QROptions{
+		Format: "SVG", Size: 512, Level: "h", Margin: &margin, Foreground: "#1A2B3C", Background: "fed",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "image/svg+xml", code.ContentType)
+	assert.Contains(t, string(code.Data), `width="512" height="512"`)
+	assert.Contains(t, string(code.Data), `fill="#1a2b3c"`)
+	assert.Contains(t, string(code.Data), `fill="#ffeedd"`)
+
+	_, err = s.Generate(t.Context(), "missing", model.QROptions{})
+	var nfErr *repo.DataNotFoundError
+	require.ErrorAs(t, err, &nfErr)
+
+	_, err = s.Generate(t.Context(), "klmno", model.QROptions{})
+	require.NoError(t, err, "passthrough link is rendered unless skipped")
+	_, err = s.Generate(t.Context(), "klmno", model.QROptions{Size: maxQRSize + 1, SkipPassthrough: true})
+	require.ErrorIs(t, err, ErrPassthroughLink)
+	_, err = s.Generate(t.Context(), "abcde", model.QROptions{SkipPassthrough: true})
+	require.NoError(t, err)
+}
+
+func TestQRCodes_InvalidOptions(t *testing.T) {
+	s := newTestQRCodes(t, 0)
+	negative, tooWide := -1, maxQRMargin+1
+	tests := []struct {
+		name string
+		opts model.QROptions
+	}{
+		{name: "unknown format", opts: model.QROptions{Format: "gif"}},
+		{name: "too small", opts: model.QROptions{Size: minQRSize - 1}},
+		{name: "too large", opts: model.QROptions{Size: maxQRSize + 1}},
+		{name: "unknown level", opts: model.QROptions{Level: "X"}},
+		{name: "negative margin", opts: model.QROptions{Margin: &negative}},
+		{name: "too wide margin", opts: model.QROptions{Margin: &tooWide}},
+		{name: "malformed foreground", opts: model.QROptions{Foreground: "black"}},
+		{name: "malformed background", opts: model.QROptions{Background: "#fffffff"}},
+		{name: "same colors", opts: model.QROptions{Foreground: "#fff", Background: "FFFFFF"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.Generate(t.Context(), "abcde", tt.opts)
+			var vErr *ValidationError
+			require.ErrorAs(t, err, &vErr)
+			require.ErrorIs(t, err, ErrInvalidQROptions)
+		})
+	}
+}
+
+func TestQRCodes_Cache(t *testing.T) {
+	s := newTestQRCodes(t, 1)
+
+	first, err := s.Generate(t.Context(), "abcde", model.QROptions{})
+	require.NoError(t, err)
+	cached, err := s.Generate(t.Context(), "abcde", model.QROptions{Level: "m", Foreground: "000"})
+	require.NoError(t, err)
+	assert.Same(t, first, cached, "same options after defaults are served from cache")
+
+	other, err := s.Generate(t.Context(), "fghij", model.QROptions{})
+	require.NoError(t, err)
+	assert.NotEqual(t, first.Data, other.Data)
+
+	evicted, err := s.Generate(t.Context(), "abcde", model.QROptions{})
+	require.NoError(t, err)
+	assert.NotSame(t, first, evicted, "least recently used image is evicted")
+	assert.Equal(t, first.Data, evicted.Data)
+
+	uncached := newTestQRCodes(t, 0)
+	a, err := uncached.Generate(t.Context(), "abcde", model.QROptions{})
+	require.NoError(t, err)
+	b, err := uncached.Generate(t.Context(), "abcde", model.QROptions{})
+	require.NoError(t, err)
+	assert.NotSame(t, a, b)
+}
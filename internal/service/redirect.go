@@ -0,0 +1,39 @@
+package service
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/alex-storchak/shortener/internal/model"
+)
+
+// ValidateRedirectType checks that links can redirect with the HTTP status code.
+// Supported codes are 301 and 308 for permanent links and 302 and 307 for temporary ones.
+//
+// Parameters:
+//   - code: requested HTTP status code of the redirect
+//
+// Returns:
+//   - error: nil if the code is supported, or *ValidationError wrapping ErrInvalidRedirectType with the reason
+func ValidateRedirectType(code int) error {
+	switch code {
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+		return nil
+	}
+	return NewValidationError(ErrInvalidRedirectType, fmt.Sprintf("redirect_type must be 301, 302, 307 or 308, got %d", code))
+}
+
+// resolveRedirectType returns the requested redirect type, or the default one if none is requested.
+//
+// Returns:
+//   - int: HTTP status code of the link redirect; zero if there is neither requested nor default type
+//   - error: nil on success, or *ValidationError wrapping ErrInvalidRedirectType if the requested type is unsupported
+func (s *Shortener) resolveRedirectType(opts model.ShortenOptions) (int, error) {
+	if opts.RedirectType == 0 {
+		return s.redirectType, nil
+	}
+	if err := ValidateRedirectType(opts.RedirectType); err != nil {
+		return 0, err
+	}
+	return opts.RedirectType, nil
+}
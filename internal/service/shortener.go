@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -12,8 +13,9 @@ import (
 )
 
 // IDGenerator defines the interface for generating unique short identifiers.
+// Strategies may derive the identifier from the original URL it is generated for.
 type IDGenerator interface {
-	Generate() (string, error)
+	Generate(ctx context.Context, origURL string) (string, error)
 }
 
 // Pinger defines the interface for checking service readiness.
@@ -25,14 +27,32 @@ type Pinger interface {
 // It provides methods for shortening URLs, extracting original URLs,
 // batch operations, and user-specific URL management.
 type URLShortener interface {
-	Shorten(ctx context.Context, userUUID string, url string) (shortID string, err error)
-	Extract(ctx context.Context, shortID string) (OrigURL string, err error)
-	ShortenBatch(ctx context.Context, userUUID string, urls []string) ([]string, error)
-	GetUserURLs(ctx context.Context, userUUID string) ([]*model.URLStorageRecord, error)
+	Shorten(ctx context.Context, userUUID string, url string, opts model.ShortenOptions) (shortID string, err error)
+	Extract(ctx context.Context, req model.ExpandRequest) (*model.URLStorageRecord, error)
+	Inspect(ctx context.Context, shortID string) (*model.URLInspection, error)
+	ShortenBatch(ctx context.Context, userUUID string, urls model.URLShortenBatch) ([]string, error)
+	ImportBatch(ctx context.Context, userUUID string, rows []model.URLImportRow) ([]model.URLImportResult, error)
+	GetUserURLs(ctx context.Context, userUUID string, tags []string) ([]*model.URLStorageRecord, error)
+	GetUserTags(ctx context.Context, userUUID string) ([]model.TagCount, error)
+	Update(ctx context.Context, userUUID, shortID, url string) (prev *model.URLStorageRecord, stored string, err error)
+	SetTags(ctx context.Context, userUUID, shortID string, tags []string) (*model.URLStorageRecord, error)
+	GetHistory(ctx context.Context, userUUID, shortID string) ([]model.URLHistoryRecord, error)
+	RestoreVersion(ctx context.Context, userUUID, shortID string, version int) (prev *model.URLStorageRecord, url string, err error)
 	DeleteBatch(ctx context.Context, urls model.URLDeleteBatch) error
+	GetUserTrash(ctx context.Context, userUUID string) ([]*model.URLStorageRecord, error)
+	RestoreBatch(ctx context.Context, userUUID string, shortIDs []string) ([]model.URLRestoreResult, error)
 	Count(ctx context.Context) (int, error)
 }
 
+// URLBlocker defines the interface for checking original URLs against a blocklist of malicious URLs.
+type URLBlocker interface {
+	Match(url string) (rule string, blocked bool)
+}
+
+// maxStoreAttempts is the maximum amount of attempts to store new links whose generated short IDs
+// were concurrently taken by other replicas or requests. Every attempt generates new short IDs.
+const maxStoreAttempts = 3
+
 // PingableURLShortener combines URL shortening functionality with health checking capability.
 type PingableURLShortener interface {
 	URLShortener
@@ -41,100 +61,380 @@ type PingableURLShortener interface {
 
 // Shortener implements the URLShortener interface and provides URL shortening services.
 // It uses a storage backend for persistence and an ID generator for creating short identifiers.
+// Original URLs are canonicalized, validated and checked against the blocklist before deduplication and storing.
 type Shortener struct {
-	urlStorage repo.URLStorage
-	generator  IDGenerator
-	logger     *zap.Logger
+	urlStorage   repo.URLStorage
+	generator    IDGenerator
+	styles       map[string]IDGenerator
+	attempts     *PasswordAttemptLimiter
+	canon        *URLCanonicalizer
+	validator    *URLValidator
+	blocker      URLBlocker
+	checksum     *IDChecksum
+	redirectType int
+	logger       *zap.Logger
 }
 
 // NewShortener creates a new instance of Shortener with the specified dependencies.
 //
 // Parameters:
 //   - idGenerator: generator for creating unique short IDs
+//   - styles: additional generators selectable per request by ID style, e.g. IDStyleWords
 //   - urlStorage: storage backend for URL persistence
+//   - attempts: limiter of failed password attempts for protected links
+//   - canon: canonicalizer of original URLs
+//   - validator: validator of original URLs
+//   - blocker: blocklist of malicious original URLs; nil blocks nothing
+//   - checksum: checksum of short IDs verified before storage lookups; nil disables the checks
+//   - redirectType: HTTP status code of redirects of links created without a requested redirect type
 //   - logger: structured logger for logging operations
 //
 // Returns:
 //   - *Shortener: configured Shortener instance
 func NewShortener(
 	idGenerator IDGenerator,
+	styles map[string]IDGenerator,
 	urlStorage repo.URLStorage,
+	attempts *PasswordAttemptLimiter,
+	canon *URLCanonicalizer,
+	validator *URLValidator,
+	blocker URLBlocker,
+	checksum *IDChecksum,
+	redirectType int,
 	logger *zap.Logger,
 ) *Shortener {
 	return &Shortener{
-		urlStorage: urlStorage,
-		generator:  idGenerator,
-		logger:     logger,
+		urlStorage:   urlStorage,
+		generator:    idGenerator,
+		styles:       styles,
+		attempts:     attempts,
+		canon:        canon,
+		validator:    validator,
+		blocker:      blocker,
+		checksum:     checksum,
+		redirectType: redirectType,
+		logger:       logger,
 	}
 }
 
 // Shorten creates a short URL for the provided original URL and associates it with a user.
-// If the URL already exists in storage, it returns the existing short ID with ErrURLAlreadyExists.
+// The URL is canonicalized first, so equivalent spellings of a URL share the same short URL.
+// Only plain links, which never expire, have no click limit and no password, are deduplicated:
+// if the URL already has a plain short URL in storage, it returns the existing short ID with ErrURLAlreadyExists.
+// A requested alias can't be created in that case, so ErrAliasURLExists is returned without the short ID.
 //
 // Parameters:
 //   - ctx: context for request cancellation and timeouts
 //   - userUUID: unique identifier of the user making the request
 //   - url: original URL to be shortened
+//   - opts: optional per-link settings (e.g. custom alias, ID style, expiration, click limit, password, tags,
+//     redirect type)
 //
 // Returns:
-//   - string: generated short identifier
+//   - string: generated short identifier, or the requested alias
 //   - error: nil on success, or one of the service errors
 //
 // Errors:
 //   - ErrEmptyInputURL: when provided URL is empty
+//   - ErrInvalidURL: when provided URL is malformed, relative, or its scheme or domain is not allowed
+//   - ErrURLBlocked: when provided URL matches the blocklist; returned as *BlockedURLError
+//   - ErrInvalidAlias: when requested alias doesn't match the route pattern or is reserved
+//   - ErrInvalidIDStyle: when requested ID style is unknown
+//   - ErrInvalidExpiry: when requested expiration is in the past or ambiguous
+//   - ErrInvalidMaxClicks: when requested click limit is negative
+//   - ErrInvalidPassword: when requested password is too long
+//   - ErrInvalidTags: when requested tags are malformed or too many
+//   - ErrInvalidRedirectType: when requested redirect type is not a supported redirect status code
 //   - ErrURLAlreadyExists: when URL already has a short identifier in storage
-func (s *Shortener) Shorten(ctx context.Context, userUUID string, url string) (string, error) {
+//   - ErrAliasURLExists: when alias is requested for URL that already has a short identifier in storage
+//   - ErrAliasTaken: when requested alias is already used by another link
+func (s *Shortener) Shorten(ctx context.Context, userUUID string, url string, opts model.ShortenOptions) (string, error) {
 	if len(url) == 0 {
 		return "", ErrEmptyInputURL
 	}
+	url = s.canon.Canonicalize(url)
+	if err := s.validator.Validate(url); err != nil {
+		return "", err
+	}
+	if err := s.checkBlocked(url); err != nil {
+		return "", err
+	}
+	if opts.Alias != "" {
+		if err := s.checkAlias(opts.Alias); err != nil {
+			return "", err
+		}
+	}
+	if _, err := s.generatorFor(opts.IDStyle); err != nil {
+		return "", err
+	}
+	now := time.Now()
+	expiresAt, err := resolveExpiresAt(opts, now)
+	if err != nil {
+		return "", err
+	}
+	if err := validateMaxClicks(opts.MaxClicks); err != nil {
+		return "", err
+	}
+	tags, err := NormalizeTags(opts.Tags)
+	if err != nil {
+		return "", err
+	}
+	redirectType, err := s.resolveRedirectType(opts)
+	if err != nil {
+		return "", err
+	}
 
-	var (
-		r   *model.URLStorageRecord
-		err error
-	)
-	r, err = s.urlStorage.Get(ctx, url, repo.OrigURLType)
-	if err == nil {
+	record := model.URLStorageRecord{
+		OrigURL:      url,
+		UserUUID:     userUUID,
+		ExpiresAt:    expiresAt,
+		MaxClicks:    opts.MaxClicks,
+		ClicksLeft:   opts.MaxClicks,
+		Tags:         tags,
+		RedirectType: redirectType,
+		Passthrough:  opts.Passthrough,
+		CreatedAt:    now,
+	}
+	record.PassHash, err = s.resolvePassHash(opts)
+	if err != nil {
+		return "", err
+	}
+	r, err := s.findDuplicate(ctx, record)
+	if err != nil {
+		return "", err
+	}
+	if r != nil && opts.Alias != "" {
+		return "", ErrAliasURLExists
+	} else if r != nil {
 		return r.ShortID, ErrURLAlreadyExists
 	}
-	var nfErr *repo.DataNotFoundError
-	if !errors.As(err, &nfErr) {
-		return "", fmt.Errorf("retrieve url from storage: %w", err)
+	for attempt := 1; ; attempt++ {
+		record.ShortID, err = s.resolveShortID(ctx, url, opts)
+		if err != nil {
+			return "", fmt.Errorf("resolve short id: %w", err)
+		}
+		err = s.urlStorage.Set(ctx, record)
+		if !errors.Is(err, repo.ErrShortIDConflict) {
+			break
+		}
+		if opts.Alias != "" {
+			return "", ErrAliasTaken
+		}
+		if attempt == maxStoreAttempts {
+			break
+		}
+		s.logger.Warn("generated short id is taken, retrying", zap.String("short_id", record.ShortID), zap.Int("attempt", attempt))
 	}
+	if err != nil {
+		return "", fmt.Errorf("set url binding in storage: %w", err)
+	}
+	return record.ShortID, nil
+}
 
-	shortID, err := s.generator.Generate()
+// resolveShortID returns the requested alias if any, or a short ID newly generated in the requested style.
+func (s *Shortener) resolveShortID(ctx context.Context, url string, opts model.ShortenOptions) (string, error) {
+	if opts.Alias != "" {
+		return opts.Alias, nil
+	}
+	g, err := s.generatorFor(opts.IDStyle)
 	if err != nil {
-		return "", fmt.Errorf("generate short id: %w", err)
+		return "", err
 	}
-	if err := s.urlStorage.Set(ctx, url, shortID, userUUID); err != nil {
-		return "", fmt.Errorf("set url binding in storage: %w", err)
+	shortID, err := g.Generate(ctx, url)
+	if err != nil {
+		return "", fmt.Errorf("generate short id: %w", err)
 	}
 	return shortID, nil
 }
 
-// Extract retrieves the original URL for a given short identifier.
+// generatorFor returns the generator of the ID style. Empty style selects the default generator.
+//
+// Returns:
+//   - IDGenerator: generator of the style
+//   - error: nil on success, or *ValidationError wrapping ErrInvalidIDStyle if the style is unknown
+func (s *Shortener) generatorFor(style string) (IDGenerator, error) {
+	if style == "" || style == IDStyleDefault {
+		return s.generator, nil
+	}
+	if g, ok := s.styles[style]; ok {
+		return g, nil
+	}
+	return nil, NewValidationError(ErrInvalidIDStyle, fmt.Sprintf("unknown id_style `%s`", style))
+}
+
+// resolvePassHash returns the hash of the requested password, or empty string for public links.
+func (s *Shortener) resolvePassHash(opts model.ShortenOptions) (string, error) {
+	if opts.Password == "" {
+		return "", nil
+	}
+	return hashPassword(opts.Password)
+}
+
+// Extract retrieves the URL record of a given short identifier to follow it.
+// When short IDs carry check characters, mistyped short IDs are rejected without a storage lookup.
+// Links in passthrough mode forward the path suffix and the query of the request to the original URL;
+// other links ignore the query and can't be followed with a path suffix.
+// URLs matching the blocklist are never extracted, even if they were shortened before being blocked.
+// Protected URLs are only extracted with the correct password; failed attempts
+// are limited per link. Every successful extraction of a click-limited URL consumes one of its follows.
+//
+// Parameters:
+//   - ctx: context for request cancellation and timeouts
+//   - req: request with the short identifier to look up, the password of a protected URL
+//     and the path suffix and query to forward
+//
+// Returns:
+//   - *model.URLStorageRecord: URL record with the redirect settings of the link and the original URL
+//     to redirect to, including the forwarded path suffix and query
+//   - error: nil on success, or storage error if URL not found, deleted, expired
+//     or has no follows left (repository.ErrClicksExhausted)
+//
+// Errors:
+//   - ErrInvalidChecksum: when the check character of the short ID is wrong; returned as *ChecksumError
+//   - ErrInvalidPassthrough: when the forwarded path suffix or query is unsafe
+//   - ErrPasswordRequired: when URL is protected and password is empty
+//   - ErrWrongPassword: when password doesn't match
+//   - ErrTooManyAttempts: when the limit of failed password attempts for the URL is reached
+//   - ErrURLBlocked: when the original URL matches the blocklist; returned as *BlockedURLError
+func (s *Shortener) Extract(ctx context.Context, req model.ExpandRequest) (*model.URLStorageRecord, error) {
+	if err := s.checksum.Verify(req.ShortID); err != nil {
+		return nil, err
+	}
+	r, err := s.urlStorage.Get(ctx, req.ShortID, repo.ShortURLType)
+	if err != nil {
+		return nil, fmt.Errorf("retrieve short url from storage: %w", err)
+	}
+	dest := r.OrigURL
+	if r.Passthrough {
+		if dest, err = joinPassthrough(r.OrigURL, req.Path, req.RawQuery); err != nil {
+			return nil, err
+		}
+	} else if req.Path != "" {
+		return nil, fmt.Errorf("path suffix of short url `%s` without passthrough: %w", req.ShortID, repo.NewDataNotFoundError(nil))
+	}
+	if err := s.checkBlocked(dest); err != nil {
+		return nil, err
+	}
+	if r.IsProtected() {
+		if err := s.unlock(r, req.Password); err != nil {
+			return nil, err
+		}
+	}
+	if r.MaxClicks > 0 {
+		if err := s.urlStorage.DecrementClicks(ctx, req.ShortID); err != nil {
+			return nil, fmt.Errorf("consume click of short url: %w", err)
+		}
+	}
+	r.OrigURL = dest
+	return r, nil
+}
+
+// Inspect retrieves the details of a short identifier to show them instead of following it.
+// Unlike Extract, it neither consumes follows of click-limited URLs nor requires the password
+// of protected URLs, whose original URL is not disclosed. URLs matching the blocklist are inspected
+// with the blocked safety status.
 //
 // Parameters:
 //   - ctx: context for request cancellation and timeouts
 //   - shortID: short identifier to look up
 //
 // Returns:
-//   - string: original URL associated with the short identifier
-//   - error: nil on success, or storage error if URL not found or deleted
-func (s *Shortener) Extract(ctx context.Context, shortID string) (string, error) {
+//   - *model.URLInspection: details of the URL with the safety status of its destination
+//   - error: nil on success, or storage error if URL not found, deleted, expired
+//     or has no follows left (repository.ErrClicksExhausted)
+//
+// Errors:
+//   - ErrInvalidChecksum: when the check character of the short ID is wrong; returned as *ChecksumError
+func (s *Shortener) Inspect(ctx context.Context, shortID string) (*model.URLInspection, error) {
+	if err := s.checksum.Verify(shortID); err != nil {
+		return nil, err
+	}
 	r, err := s.urlStorage.Get(ctx, shortID, repo.ShortURLType)
 	if err != nil {
-		return "", fmt.Errorf("retrieve short url from storage: %w", err)
+		return nil, fmt.Errorf("retrieve short url from storage: %w", err)
+	}
+	res := &model.URLInspection{
+		ShortID:     r.ShortID,
+		CreatedAt:   r.CreatedAt,
+		ExpiresAt:   r.ExpiresAt,
+		Protected:   r.IsProtected(),
+		Safety:      model.URLSafetySafe,
+		Passthrough: r.Passthrough,
+	}
+	if !res.Protected {
+		res.OrigURL = r.OrigURL
+	}
+	if err := s.checkBlocked(r.OrigURL); err != nil {
+		res.Safety = model.URLSafetyBlocked
+	}
+	return res, nil
+}
+
+// checkBlocked returns *BlockedURLError if the URL matches the blocklist.
+func (s *Shortener) checkBlocked(url string) error {
+	if s.blocker == nil {
+		return nil
+	}
+	if rule, blocked := s.blocker.Match(url); blocked {
+		return &BlockedURLError{OrigURL: url, Rule: rule}
+	}
+	return nil
+}
+
+// CheckDestination canonicalizes a destination URL and checks it the same way as original URLs in Shorten.
+//
+// Parameters:
+//   - url: destination URL to check
+//
+// Returns:
+//   - string: canonical form of the URL
+//   - error: nil if the URL can be redirected to, or error describing the rejection
+//
+// Errors:
+//   - ErrEmptyInputURL: when provided URL is empty
+//   - ErrInvalidURL: when provided URL is invalid
+//   - ErrURLBlocked: when provided URL matches the blocklist; returned as *BlockedURLError
+func (s *Shortener) CheckDestination(url string) (string, error) {
+	if len(url) == 0 {
+		return "", ErrEmptyInputURL
+	}
+	url = s.canon.Canonicalize(url)
+	if err := s.validator.Validate(url); err != nil {
+		return "", err
+	}
+	if err := s.checkBlocked(url); err != nil {
+		return "", err
 	}
-	return r.OrigURL, nil
+	return url, nil
+}
+
+// unlock verifies the password of a protected URL respecting the limit of failed attempts.
+func (s *Shortener) unlock(r *model.URLStorageRecord, password string) error {
+	if password == "" {
+		return ErrPasswordRequired
+	}
+	if !s.attempts.Reserve(r.ShortID, time.Now()) {
+		return ErrTooManyAttempts
+	}
+	if err := checkPassword(r.PassHash, password); err != nil {
+		if !errors.Is(err, ErrWrongPassword) {
+			s.attempts.Release(r.ShortID)
+		}
+		return err
+	}
+	s.attempts.Reset(r.ShortID)
+	return nil
 }
 
 // ShortenBatch creates short URLs for multiple original URLs in a single operation.
 // It efficiently handles existing URLs by reusing their short identifiers.
+// The batch is persisted atomically: if any requested alias is taken, nothing is stored.
+// If generated short IDs turn out to be taken when the batch is stored, it is retried with new short IDs.
 //
 // Parameters:
 //   - ctx: context for request cancellation and timeouts
 //   - userUUID: unique identifier of the user making the request
-//   - urls: slice of original URLs to be shortened
+//   - urls: batch of original URLs to be shortened with their options
 //
 // Returns:
 //   - []string: slice of short identifiers corresponding to input URLs
@@ -143,61 +443,181 @@ func (s *Shortener) Extract(ctx context.Context, shortID string) (string, error)
 // Errors:
 //   - ErrEmptyInputBatch: when provided URL slice is empty
 //   - ErrEmptyInputURL: when any URL in the batch is empty
-func (s *Shortener) ShortenBatch(ctx context.Context, userUUID string, urls []string) ([]string, error) {
+//   - ErrInvalidURL: when any URL in the batch is invalid
+//   - ErrURLBlocked: when any URL in the batch matches the blocklist; returned as *BlockedURLError
+//   - ErrInvalidAlias: when any requested alias is invalid
+//   - ErrInvalidIDStyle: when any requested ID style is unknown
+//   - ErrInvalidExpiry: when any requested expiration is invalid
+//   - ErrInvalidMaxClicks: when any requested click limit is invalid
+//   - ErrInvalidPassword: when any requested password is invalid
+//   - ErrInvalidTags: when any requested tags are invalid
+//   - ErrInvalidRedirectType: when any requested redirect type is unsupported
+//   - ErrAliasTaken: when any requested alias is already in use or repeated in the batch
+//   - ErrAliasURLExists: when any alias is requested for URL that already has a short identifier in storage
+func (s *Shortener) ShortenBatch(ctx context.Context, userUUID string, urls model.URLShortenBatch) ([]string, error) {
 	if len(urls) == 0 {
 		return nil, ErrEmptyInputBatch
 	}
 
-	res, toPersist, err := s.segregateBatch(ctx, userUUID, urls)
-	if err != nil {
-		return nil, fmt.Errorf("segregate batch: %w", err)
-	}
-	if len(toPersist) > 0 {
-		if err := s.urlStorage.BatchSet(ctx, toPersist); err != nil {
+	for attempt := 1; ; attempt++ {
+		res, toPersist, err := s.segregateBatch(ctx, userUUID, urls)
+		if err != nil {
+			return nil, fmt.Errorf("segregate batch: %w", err)
+		}
+		if len(toPersist) == 0 {
+			return res, nil
+		}
+		err = s.urlStorage.BatchSet(ctx, toPersist)
+		if err == nil {
+			return res, nil
+		}
+		if !errors.Is(err, repo.ErrShortIDConflict) {
 			return nil, fmt.Errorf("set url bindings batch in storage: %w", err)
 		}
+		if hasAlias(urls) {
+			return nil, ErrAliasTaken
+		}
+		if attempt == maxStoreAttempts {
+			return nil, fmt.Errorf("set url bindings batch in storage: %w", err)
+		}
+		s.logger.Warn("generated short id of batch is taken, retrying", zap.Int("attempt", attempt))
 	}
-	return res, nil
 }
 
 // segregateBatch processes a batch of URLs, separating existing URLs from new ones.
+// URLs are canonicalized before looking them up, the same way as in Shorten.
 // It returns short identifiers for all URLs and records that need to be persisted.
-func (s *Shortener) segregateBatch(ctx context.Context, userUUID string, urls []string) ([]string, []model.URLStorageRecord, error) {
+func (s *Shortener) segregateBatch(
+	ctx context.Context,
+	userUUID string,
+	urls model.URLShortenBatch,
+) ([]string, []model.URLStorageRecord, error) {
 	res := make([]string, len(urls))
 	toPersist := make([]model.URLStorageRecord, 0)
+	now := time.Now()
 
 	for i, u := range urls {
-		if u == "" {
-			return nil, nil, ErrEmptyInputURL
+		record, err := s.prepareBatchItem(userUUID, u, now)
+		if err != nil {
+			return nil, nil, err
 		}
 
-		r, err := s.urlStorage.Get(ctx, u, repo.OrigURLType)
-		if err == nil {
+		r, err := s.findDuplicate(ctx, record)
+		if err != nil {
+			return nil, nil, err
+		}
+		if r != nil && u.Opts.Alias != "" {
+			return nil, nil, ErrAliasURLExists
+		} else if r != nil {
 			res[i] = r.ShortID
 			continue
 		}
-		var nfErr *repo.DataNotFoundError
-		if !errors.As(err, &nfErr) {
-			return nil, nil, fmt.Errorf("retrieve url from storage: %w", err)
-		}
 
-		urlBindItem, err := s.prepareURLBindToPersistItem(userUUID, u)
-		if err != nil {
-			return nil, nil, fmt.Errorf("prepare url bind to persist item: %w", err)
+		if err := s.completeBatchItem(ctx, &record, u.Opts); err != nil {
+			return nil, nil, fmt.Errorf("complete url bind to persist item: %w", err)
 		}
-		toPersist = append(toPersist, urlBindItem)
-		res[i] = urlBindItem.ShortID
+		toPersist = append(toPersist, record)
+		res[i] = record.ShortID
 	}
 	return res, toPersist, nil
 }
 
-// prepareURLBindToPersistItem creates a URLStorageRecord with a generated short ID.
-func (s *Shortener) prepareURLBindToPersistItem(userUUID string, origURL string) (model.URLStorageRecord, error) {
-	shortID, err := s.generator.Generate()
+// findDuplicate looks up the live short URL a new record is deduplicated onto.
+// Only plain records are deduplicated, so links that expire, are click-limited or protected are always created anew.
+//
+// Returns:
+//   - *model.URLStorageRecord: plain record of the same original URL, or nil if the new record must be created
+//   - error: nil on success, or storage error if the lookup fails
+func (s *Shortener) findDuplicate(ctx context.Context, record model.URLStorageRecord) (*model.URLStorageRecord, error) {
+	if !record.IsPlain() {
+		return nil, nil
+	}
+	r, err := s.urlStorage.Get(ctx, record.OrigURL, repo.OrigURLType)
+	var nfErr *repo.DataNotFoundError
+	if errors.As(err, &nfErr) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("retrieve url from storage: %w", err)
+	}
+	return r, nil
+}
+
+// prepareBatchItem canonicalizes and validates the original URL and the options of a batch item,
+// the same way as in Shorten, and builds its record without the short ID.
+//
+// Returns:
+//   - model.URLStorageRecord: record of the item with the canonical original URL
+//   - error: nil on success, or one of the validation errors of Shorten
+func (s *Shortener) prepareBatchItem(userUUID string, u model.URLToShorten, now time.Time) (model.URLStorageRecord, error) {
+	if u.OrigURL == "" {
+		return model.URLStorageRecord{}, ErrEmptyInputURL
+	}
+	origURL := s.canon.Canonicalize(u.OrigURL)
+	if err := s.validator.Validate(origURL); err != nil {
+		return model.URLStorageRecord{}, err
+	}
+	if err := s.checkBlocked(origURL); err != nil {
+		return model.URLStorageRecord{}, err
+	}
+	if u.Opts.Alias != "" {
+		if err := s.checkAlias(u.Opts.Alias); err != nil {
+			return model.URLStorageRecord{}, err
+		}
+	}
+	if _, err := s.generatorFor(u.Opts.IDStyle); err != nil {
+		return model.URLStorageRecord{}, err
+	}
+	expiresAt, err := resolveExpiresAt(u.Opts, now)
 	if err != nil {
-		return model.URLStorageRecord{}, fmt.Errorf("batch. generate short id: %w", err)
+		return model.URLStorageRecord{}, err
+	}
+	if err := validateMaxClicks(u.Opts.MaxClicks); err != nil {
+		return model.URLStorageRecord{}, err
+	}
+	tags, err := NormalizeTags(u.Opts.Tags)
+	if err != nil {
+		return model.URLStorageRecord{}, err
+	}
+	redirectType, err := s.resolveRedirectType(u.Opts)
+	if err != nil {
+		return model.URLStorageRecord{}, err
+	}
+	passHash, err := s.resolvePassHash(u.Opts)
+	if err != nil {
+		return model.URLStorageRecord{}, err
+	}
+	return model.URLStorageRecord{
+		OrigURL:      origURL,
+		UserUUID:     userUUID,
+		ExpiresAt:    expiresAt,
+		MaxClicks:    u.Opts.MaxClicks,
+		ClicksLeft:   u.Opts.MaxClicks,
+		PassHash:     passHash,
+		Tags:         tags,
+		RedirectType: redirectType,
+		Passthrough:  u.Opts.Passthrough,
+		CreatedAt:    now,
+	}, nil
+}
+
+// completeBatchItem sets the requested alias or a generated short ID of a new record prepared by prepareBatchItem.
+func (s *Shortener) completeBatchItem(ctx context.Context, record *model.URLStorageRecord, opts model.ShortenOptions) error {
+	shortID, err := s.resolveShortID(ctx, record.OrigURL, opts)
+	if err != nil {
+		return fmt.Errorf("batch. resolve short id: %w", err)
+	}
+	record.ShortID = shortID
+	return nil
+}
+
+// hasAlias reports whether any item of the batch requests a custom alias.
+func hasAlias(urls model.URLShortenBatch) bool {
+	for _, u := range urls {
+		if u.Opts.Alias != "" {
+			return true
+		}
 	}
-	return model.URLStorageRecord{OrigURL: origURL, ShortID: shortID, UserUUID: userUUID}, nil
+	return false
 }
 
 // IsReady checks if the service is ready to handle requests by pinging the storage backend.
@@ -212,6 +632,7 @@ func (s *Shortener) IsReady(ctx context.Context) error {
 }
 
 // GetUserURLs retrieves all URLs shortened by a specific user.
+// If tags are given, only URLs labeled with every one of them are retrieved.
 //
 // Parameters:
 //   - ctx: context for request cancellation and timeouts
@@ -220,8 +641,171 @@ func (s *Shortener) IsReady(ctx context.Context) error {
 // Returns:
 //   - []*model.URLStorageRecord: slice of URL records belonging to the user
 //   - error: nil on success, or storage error if operation fails
-func (s *Shortener) GetUserURLs(ctx context.Context, userUUID string) ([]*model.URLStorageRecord, error) {
-	return s.urlStorage.GetByUserUUID(ctx, userUUID)
+func (s *Shortener) GetUserURLs(ctx context.Context, userUUID string, tags []string) ([]*model.URLStorageRecord, error) {
+	tags, err := NormalizeTags(tags)
+	if err != nil {
+		return nil, err
+	}
+	return s.urlStorage.GetByUserUUID(ctx, userUUID, tags)
+}
+
+// GetUserTags retrieves the tags of URLs shortened by a specific user with the amount of URLs labeled with each.
+//
+// Parameters:
+//   - ctx: context for request cancellation and timeouts
+//   - userUUID: unique identifier of the user
+//
+// Returns:
+//   - []model.TagCount: tags of the user's URLs with their counts, sorted by tag
+//   - error: nil on success, or storage error if operation fails
+func (s *Shortener) GetUserTags(ctx context.Context, userUUID string) ([]model.TagCount, error) {
+	return s.urlStorage.GetTagsByUserUUID(ctx, userUUID)
+}
+
+// SetTags replaces the tags of a short URL owned by the user.
+// Tags are normalized the same way as in Shorten; empty tags remove all tags of the URL.
+//
+// Parameters:
+//   - ctx: context for request cancellation and timeouts
+//   - userUUID: unique identifier of the user making the request
+//   - shortID: short identifier of the URL to label
+//   - tags: new tags of the URL
+//
+// Returns:
+//   - *model.URLStorageRecord: state of the URL record after the change
+//   - error: nil on success, or storage error if URL is not found among user's URLs,
+//     deleted, expired or has no follows left
+//
+// Errors:
+//   - ErrInvalidTags: when tags are malformed or too many
+func (s *Shortener) SetTags(ctx context.Context, userUUID, shortID string, tags []string) (*model.URLStorageRecord, error) {
+	tags, err := NormalizeTags(tags)
+	if err != nil {
+		return nil, err
+	}
+	r, err := s.urlStorage.Get(ctx, shortID, repo.ShortURLType)
+	if err != nil {
+		return nil, fmt.Errorf("retrieve short url from storage: %w", err)
+	}
+	if r.UserUUID != userUUID {
+		return nil, fmt.Errorf("short url `%s` of another user: %w", shortID, repo.NewDataNotFoundError(nil))
+	}
+	if err := s.urlStorage.SetTags(ctx, shortID, userUUID, tags); err != nil {
+		return nil, fmt.Errorf("set url tags in storage: %w", err)
+	}
+	updated := *r
+	updated.Tags = tags
+	return &updated, nil
+}
+
+// Update changes the original URL of a short URL owned by the user.
+// The dedupe rule of Shorten is kept: an original URL can't be bound to more than one plain short URL,
+// so pointing a plain link at a URL that already has a plain short URL is rejected. Setting the same URL is a no-op.
+// The new URL is canonicalized the same way as in Shorten.
+//
+// Parameters:
+//   - ctx: context for request cancellation and timeouts
+//   - userUUID: unique identifier of the user making the request
+//   - shortID: short identifier of the URL to update
+//   - url: new original URL
+//
+// Returns:
+//   - *model.URLStorageRecord: state of the URL record before the update
+//   - string: canonical original URL the short URL points at after the update
+//   - error: nil on success, or storage error if URL is not found among user's URLs,
+//     deleted, expired or has no follows left
+//
+// Errors:
+//   - ErrEmptyInputURL: when provided URL is empty
+//   - ErrInvalidURL: when provided URL is invalid
+//   - ErrURLBlocked: when provided URL matches the blocklist; returned as *BlockedURLError
+//   - ErrURLAlreadyExists: when the new URL already has a short identifier in storage
+func (s *Shortener) Update(ctx context.Context, userUUID, shortID, url string) (*model.URLStorageRecord, string, error) {
+	url, err := s.CheckDestination(url)
+	if err != nil {
+		return nil, "", err
+	}
+
+	prev, err := s.urlStorage.Get(ctx, shortID, repo.ShortURLType)
+	if err != nil {
+		return nil, "", fmt.Errorf("retrieve short url from storage: %w", err)
+	}
+	if prev.UserUUID != userUUID {
+		return nil, "", fmt.Errorf("short url `%s` of another user: %w", shortID, repo.NewDataNotFoundError(nil))
+	}
+	if prev.OrigURL == url {
+		return prev, url, nil
+	}
+
+	next := *prev
+	next.OrigURL = url
+	r, err := s.findDuplicate(ctx, next)
+	if err != nil {
+		return nil, "", err
+	}
+	if r != nil {
+		return nil, "", ErrURLAlreadyExists
+	}
+
+	err = s.urlStorage.Update(ctx, shortID, userUUID, url)
+	if errors.Is(err, repo.ErrOrigURLConflict) {
+		return nil, "", ErrURLAlreadyExists
+	} else if err != nil {
+		return nil, "", fmt.Errorf("update url binding in storage: %w", err)
+	}
+	return prev, url, nil
+}
+
+// GetHistory retrieves all versions of a short URL owned by the user, oldest first.
+//
+// Parameters:
+//   - ctx: context for request cancellation and timeouts
+//   - userUUID: unique identifier of the user making the request
+//   - shortID: short identifier of the URL
+//
+// Returns:
+//   - []model.URLHistoryRecord: versions of the URL numbered from 1
+//   - error: nil on success, or storage error if URL is not found among user's URLs
+func (s *Shortener) GetHistory(ctx context.Context, userUUID, shortID string) ([]model.URLHistoryRecord, error) {
+	return s.urlStorage.GetHistory(ctx, shortID, userUUID)
+}
+
+// RestoreVersion points a short URL owned by the user back at the original URL of one of its versions.
+// The restoration is an ordinary update, so it follows the same rules and adds a new version to the history.
+//
+// Parameters:
+//   - ctx: context for request cancellation and timeouts
+//   - userUUID: unique identifier of the user making the request
+//   - shortID: short identifier of the URL
+//   - version: number of the version to restore
+//
+// Returns:
+//   - *model.URLStorageRecord: state of the URL record before the restoration
+//   - string: restored original URL
+//   - error: nil on success, or storage error if URL is not found among user's URLs,
+//     deleted, expired or has no follows left
+//
+// Errors:
+//   - ErrHistoryVersionNotFound: when the URL has no such version
+//   - ErrURLAlreadyExists: when the restored URL already has another short identifier in storage
+func (s *Shortener) RestoreVersion(
+	ctx context.Context,
+	userUUID, shortID string,
+	version int,
+) (*model.URLStorageRecord, string, error) {
+	history, err := s.urlStorage.GetHistory(ctx, shortID, userUUID)
+	if err != nil {
+		return nil, "", fmt.Errorf("retrieve url history from storage: %w", err)
+	}
+	if version < 1 || version > len(history) {
+		return nil, "", ErrHistoryVersionNotFound
+	}
+
+	prev, url, err := s.Update(ctx, userUUID, shortID, history[version-1].OrigURL)
+	if err != nil {
+		return nil, "", fmt.Errorf("restore url version %d: %w", version, err)
+	}
+	return prev, url, nil
 }
 
 // DeleteBatch marks multiple URLs as deleted in a batch operation.
@@ -237,6 +821,45 @@ func (s *Shortener) DeleteBatch(ctx context.Context, urls model.URLDeleteBatch)
 	return s.urlStorage.DeleteBatch(ctx, urls)
 }
 
+// GetUserTrash retrieves all soft-deleted URLs of a specific user.
+//
+// Parameters:
+//   - ctx: context for request cancellation and timeouts
+//   - userUUID: unique identifier of the user
+//
+// Returns:
+//   - []*model.URLStorageRecord: slice of deleted URL records belonging to the user
+//   - error: nil on success, or storage error if operation fails
+func (s *Shortener) GetUserTrash(ctx context.Context, userUUID string) ([]*model.URLStorageRecord, error) {
+	return s.urlStorage.GetDeletedByUserUUID(ctx, userUUID)
+}
+
+// RestoreBatch undeletes soft-deleted URLs of a specific user.
+// Only URLs belonging to the user are restored; a URL stays deleted if the user
+// already has another short URL for the same original URL or if it has expired.
+//
+// Parameters:
+//   - ctx: context for request cancellation and timeouts
+//   - userUUID: unique identifier of the user
+//   - shortIDs: short identifiers of the URLs to restore
+//
+// Returns:
+//   - []model.URLRestoreResult: outcome for every requested short identifier, in the same order
+//   - error: nil on success, or storage error if operation fails
+//
+// Errors:
+//   - ErrEmptyInputBatch: when provided slice of short identifiers is empty
+func (s *Shortener) RestoreBatch(ctx context.Context, userUUID string, shortIDs []string) ([]model.URLRestoreResult, error) {
+	if len(shortIDs) == 0 {
+		return nil, ErrEmptyInputBatch
+	}
+	results, err := s.urlStorage.RestoreBatch(ctx, userUUID, shortIDs)
+	if err != nil {
+		return nil, fmt.Errorf("restore urls in storage: %w", err)
+	}
+	return results, nil
+}
+
 // Count counts the amount of shortened URLs in storage.
 //
 // Parameters:
@@ -249,14 +872,134 @@ func (s *Shortener) Count(ctx context.Context) (int, error) {
 	return s.urlStorage.Count(ctx)
 }
 
+// ValidationError represents rejected user input.
+// It wraps one of the service sentinel errors and carries a human-readable reason
+// that is safe to return to the client.
+type ValidationError struct {
+	Err    error
+	Reason string
+}
+
+// Error returns the formatted error message.
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%v: %s", e.Err, e.Reason)
+}
+
+// Unwrap returns the underlying sentinel error.
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// NewValidationError creates a new ValidationError for the sentinel error with the specified reason.
+func NewValidationError(err error, reason string) error {
+	return &ValidationError{Err: err, Reason: reason}
+}
+
+// BlockedURLError represents an original URL rejected by the blocklist.
+// It wraps ErrURLBlocked and carries the blocked URL with the matched rule for auditing.
+type BlockedURLError struct {
+	OrigURL string
+	Rule    string
+}
+
+// Error returns the formatted error message.
+func (e *BlockedURLError) Error() string {
+	return fmt.Sprintf("%v: `%s` matches %s", ErrURLBlocked, e.OrigURL, e.Rule)
+}
+
+// Unwrap returns ErrURLBlocked.
+func (e *BlockedURLError) Unwrap() error {
+	return ErrURLBlocked
+}
+
 // Common service errors
 var (
-	// ErrURLAlreadyExists is returned when attempting to shorten a URL that already exists in storage.
+	// ErrURLAlreadyExists is returned when attempting to shorten a URL that already exists in storage,
+	// or to point an existing short URL at it.
 	ErrURLAlreadyExists = errors.New("url already exists")
 
+	// ErrHistoryVersionNotFound is returned when restoring a version that is missing in the URL history.
+	ErrHistoryVersionNotFound = errors.New("history version not found")
+
 	// ErrEmptyInputURL is returned when an empty URL is provided for shortening.
 	ErrEmptyInputURL = errors.New("empty url in the input")
 
+	// ErrInvalidURL is returned when an original URL is malformed or not allowed to be shortened.
+	ErrInvalidURL = errors.New("invalid url")
+
+	// ErrURLBlocked is returned when an original URL matches the blocklist of malicious URLs.
+	ErrURLBlocked = errors.New("url is blocked")
+
 	// ErrEmptyInputBatch is returned when an empty batch is provided for batch operations.
 	ErrEmptyInputBatch = errors.New("empty batch provided")
+
+	// ErrInvalidAlias is returned when a requested custom alias can't be used as a short identifier.
+	ErrInvalidAlias = errors.New("invalid alias")
+
+	// ErrInvalidPassthrough is returned when the path suffix or query forwarded to the original URL is unsafe.
+	ErrInvalidPassthrough = errors.New("invalid passthrough")
+
+	// ErrInvalidRedirectType is returned when a requested redirect type is not a supported redirect status code.
+	ErrInvalidRedirectType = errors.New("invalid redirect type")
+
+	// ErrInvalidTags is returned when requested link tags are malformed or too many.
+	ErrInvalidTags = errors.New("invalid tags")
+
+	// ErrInvalidUTMTemplate is returned when a UTM template has no single target or malformed parameters.
+	ErrInvalidUTMTemplate = errors.New("invalid utm template")
+
+	// ErrInvalidTargetingRule is returned when targeting rules of a link are malformed or too many.
+	ErrInvalidTargetingRule = errors.New("invalid targeting rule")
+
+	// ErrInvalidSplit is returned when variants of a split test are malformed, too few or too many.
+	ErrInvalidSplit = errors.New("invalid split test")
+
+	// ErrInvalidQROptions is returned when requested QR code format, size, level, margin or colors are invalid.
+	ErrInvalidQROptions = errors.New("invalid qr code options")
+
+	// ErrPassthroughLink is returned when the QR code of a link with passthrough enabled is requested
+	// by a route whose path suffix belongs to the destination of such links.
+	ErrPassthroughLink = errors.New("link has passthrough enabled")
+
+	// ErrInvalidCollection is returned when a collection name, the links moved into a collection
+	// or its export format are malformed, or the user has too many collections.
+	ErrInvalidCollection = errors.New("invalid collection")
+
+	// ErrCollectionNameTaken is returned when the user already has another collection with the requested name.
+	ErrCollectionNameTaken = errors.New("collection name already taken")
+
+	// ErrInvalidImport is returned when the format of a bulk import is unknown, its CSV header
+	// or one of its rows is malformed.
+	ErrInvalidImport = errors.New("invalid import")
+
+	// ErrInvalidIDStyle is returned when a requested style of generated short IDs is unknown.
+	ErrInvalidIDStyle = errors.New("invalid id style")
+
+	// ErrShortIDExhausted is returned when every generated candidate collides with existing short IDs.
+	ErrShortIDExhausted = errors.New("no free short id found")
+
+	// ErrAliasTaken is returned when a requested custom alias is already used by another link.
+	ErrAliasTaken = errors.New("alias already taken")
+
+	// ErrAliasURLExists is returned when a custom alias is requested for an original URL
+	// that already has a short URL, so the alias can't be created.
+	ErrAliasURLExists = errors.New("url already exists, alias not created")
+
+	// ErrInvalidExpiry is returned when requested link expiration settings can't be applied.
+	ErrInvalidExpiry = errors.New("invalid expiration")
+
+	// ErrInvalidMaxClicks is returned when a requested click limit can't be applied.
+	ErrInvalidMaxClicks = errors.New("invalid max clicks")
+
+	// ErrInvalidPassword is returned when a requested link password can't be used.
+	ErrInvalidPassword = errors.New("invalid password")
+
+	// ErrPasswordRequired is returned when a protected link is followed without a password.
+	ErrPasswordRequired = errors.New("password required")
+
+	// ErrWrongPassword is returned when a protected link is followed with a wrong password.
+	ErrWrongPassword = errors.New("wrong password")
+
+	// ErrTooManyAttempts is returned when the limit of failed password attempts for a link is reached.
+	ErrTooManyAttempts = errors.New("too many password attempts")
 )
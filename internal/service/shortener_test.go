@@ -3,7 +3,11 @@ package service
 import (
 	"context"
 	"errors"
+	"net/http"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -23,7 +27,7 @@ func newIDGeneratorStub(generateMethodShouldFail bool) *idGeneratorStub {
 	}
 }
 
-func (d *idGeneratorStub) Generate() (string, error) {
+func (d *idGeneratorStub) Generate(_ context.Context, _ string) (string, error) {
 	if d.generateMethodShouldFail {
 		return "", errors.New("generate method should fail")
 	}
@@ -34,6 +38,7 @@ type urlStorageStub struct {
 	setMethodShouldFail      bool
 	setBatchMethodShouldFail bool
 	storage                  []model.URLStorageRecord
+	takenShortIDs            []string
 }
 
 func newURLStorageStub(
@@ -45,13 +50,36 @@ func newURLStorageStub(
 		setBatchMethodShouldFail: setBatchMethodShouldFail,
 		storage: []model.URLStorageRecord{
 			{
-				OrigURL: "http://existing.com",
-				ShortID: "abcde",
+				OrigURL:  "http://existing.com",
+				ShortID:  "abcde",
+				UserUUID: "userUUID",
+			},
+			{
+				OrigURL:    "http://one-time.com",
+				ShortID:    "once",
+				MaxClicks:  1,
+				ClicksLeft: 1,
+			},
+			{
+				OrigURL:  "http://popular.com",
+				ShortID:  "fghij",
+				UserUUID: "anotherUUID",
+			},
+			{
+				OrigURL:  "http://secret.com",
+				ShortID:  "klmno",
+				UserUUID: "anotherUUID",
+				PassHash: "hash",
 			},
 		},
+		takenShortIDs: []string{"taken"},
 	}
 }
 
+func (d *urlStorageStub) isTaken(shortID string) bool {
+	return slices.Contains(d.takenShortIDs, shortID)
+}
+
 func (d *urlStorageStub) Close() error {
 	return nil
 }
@@ -61,29 +89,67 @@ func (d *urlStorageStub) Ping(_ context.Context) error {
 }
 
 func (d *urlStorageStub) Get(_ context.Context, url, searchByType string) (*model.URLStorageRecord, error) {
-	if searchByType == repo.OrigURLType && d.storage[0].OrigURL == url {
-		return &d.storage[0], nil
-	} else if searchByType == repo.ShortURLType && d.storage[0].ShortID == url {
-		return &d.storage[0], nil
+	for _, r := range d.storage {
+		if searchByType == repo.OrigURLType && r.OrigURL == url && r.IsPlain() {
+			return &r, nil
+		} else if searchByType == repo.ShortURLType && r.ShortID == url {
+			if r.IsExhausted() {
+				return nil, repo.ErrClicksExhausted
+			}
+			return &r, nil
+		}
 	}
 	return nil, repo.NewDataNotFoundError(nil)
 }
 
-func (d *urlStorageStub) Set(_ context.Context, _, _, _ string) error {
+func (d *urlStorageStub) GetByOrigURLs(ctx context.Context, origURLs []string) (map[string]*model.URLStorageRecord, error) {
+	found := make(map[string]*model.URLStorageRecord)
+	for _, u := range origURLs {
+		if r, err := d.Get(ctx, u, repo.OrigURLType); err == nil {
+			found[u] = r
+		}
+	}
+	return found, nil
+}
+
+func (d *urlStorageStub) Set(_ context.Context, r model.URLStorageRecord) error {
 	if d.setMethodShouldFail {
 		return errors.New("set method should fail")
 	}
+	if d.isTaken(r.ShortID) {
+		return repo.ErrShortIDConflict
+	}
 	return nil
 }
 
-func (d *urlStorageStub) BatchSet(_ context.Context, _ []model.URLStorageRecord) error {
+func (d *urlStorageStub) BatchSet(_ context.Context, records []model.URLStorageRecord) error {
 	if d.setBatchMethodShouldFail {
 		return errors.New("set batch method should fail")
 	}
+	for _, r := range records {
+		if d.isTaken(r.ShortID) {
+			return repo.ErrShortIDConflict
+		}
+	}
 	return nil
 }
 
-func (d *urlStorageStub) GetByUserUUID(_ context.Context, _ string) ([]*model.URLStorageRecord, error) {
+func (d *urlStorageStub) GetByUserUUID(_ context.Context, _ string, _ []string) ([]*model.URLStorageRecord, error) {
+	return nil, nil
+}
+
+func (d *urlStorageStub) SetTags(_ context.Context, shortID, userUUID string, tags []string) error {
+	for i := range d.storage {
+		r := &d.storage[i]
+		if r.ShortID == shortID && r.UserUUID == userUUID {
+			r.Tags = tags
+			return nil
+		}
+	}
+	return repo.NewDataNotFoundError(nil)
+}
+
+func (d *urlStorageStub) GetTagsByUserUUID(_ context.Context, _ string) ([]model.TagCount, error) {
 	return nil, nil
 }
 
@@ -95,9 +161,84 @@ func (d *urlStorageStub) Count(_ context.Context) (int, error) {
 	return len(d.storage), nil
 }
 
+func (d *urlStorageStub) DecrementClicks(_ context.Context, shortID string) error {
+	for i := range d.storage {
+		r := &d.storage[i]
+		if r.ShortID != shortID {
+			continue
+		}
+		if r.IsExhausted() {
+			return repo.ErrClicksExhausted
+		}
+		r.ClicksLeft--
+		return nil
+	}
+	return repo.NewDataNotFoundError(nil)
+}
+
+func (d *urlStorageStub) Update(_ context.Context, shortID, userUUID, origURL string) error {
+	for i := range d.storage {
+		r := &d.storage[i]
+		if r.ShortID == shortID && r.UserUUID == userUUID {
+			r.OrigURL = origURL
+			return nil
+		}
+	}
+	return repo.NewDataNotFoundError(nil)
+}
+
+func (d *urlStorageStub) GetHistory(_ context.Context, shortID, userUUID string) ([]model.URLHistoryRecord, error) {
+	for _, r := range d.storage {
+		if r.ShortID == shortID && r.UserUUID == userUUID {
+			return []model.URLHistoryRecord{
+				{Version: 1, ShortID: shortID, Action: model.URLHistoryActionCreate, OrigURL: "http://first.com"},
+				{Version: 2, ShortID: shortID, Action: model.URLHistoryActionUpdate, OrigURL: r.OrigURL},
+			}, nil
+		}
+	}
+	return nil, repo.NewDataNotFoundError(nil)
+}
+
+func (d *urlStorageStub) GetDeletedByUserUUID(_ context.Context, _ string) ([]*model.URLStorageRecord, error) {
+	return nil, nil
+}
+
+func (d *urlStorageStub) RestoreBatch(_ context.Context, _ string, shortIDs []string) ([]model.URLRestoreResult, error) {
+	results := make([]model.URLRestoreResult, len(shortIDs))
+	for i, shortID := range shortIDs {
+		results[i] = model.URLRestoreResult{ShortID: shortID, Status: model.URLRestoreStatusRestored}
+	}
+	return results, nil
+}
+
+func (d *urlStorageStub) SweepExpired(_ context.Context, _ time.Time) (int, error) {
+	return 0, nil
+}
+
+func (d *urlStorageStub) PurgeDeleted(_ context.Context, _ time.Time, _ int) ([]string, error) {
+	return nil, nil
+}
+
+func (d *urlStorageStub) CountPurged(_ context.Context) (int, error) {
+	return 0, nil
+}
+
+func (d *urlStorageStub) RewriteOrigURLs(_ context.Context, _ func(string) string) (int, int, error) {
+	return 0, 0, nil
+}
+
+func (d *urlStorageStub) NextSequence(_ context.Context) (uint64, error) {
+	return 0, nil
+}
+
 func TestShortener_Shorten(t *testing.T) {
 	type args struct {
-		url string
+		url       string
+		alias     string
+		ttl       time.Duration
+		expiresAt time.Time
+		maxClicks int
+		password  string
 	}
 	userUUID := "userUUID"
 
@@ -146,6 +287,176 @@ func TestShortener_Shorten(t *testing.T) {
 			want:              "",
 			wantErr:           true,
 		},
+		{
+			name: "returns requested alias instead of generated short id",
+			args: args{
+				url:   "https://non-existing.com",
+				alias: "spring-sale",
+			},
+			want:    "spring-sale",
+			wantErr: false,
+		},
+		{
+			name: "returns ErrAliasURLExists without short id if url with alias already exists",
+			args: args{
+				url:   "http://existing.com",
+				alias: "spring-sale",
+			},
+			err:     ErrAliasURLExists,
+			want:    "",
+			wantErr: true,
+		},
+		{
+			name: "returns ErrInvalidAlias if alias doesn't match route pattern",
+			args: args{
+				url:   "https://non-existing.com",
+				alias: "spring/sale",
+			},
+			err:     ErrInvalidAlias,
+			want:    "",
+			wantErr: true,
+		},
+		{
+			name: "returns ErrInvalidAlias if alias is a reserved word",
+			args: args{
+				url:   "https://non-existing.com",
+				alias: "API",
+			},
+			err:     ErrInvalidAlias,
+			want:    "",
+			wantErr: true,
+		},
+		{
+			name: "returns ErrAliasTaken if alias is already in use",
+			args: args{
+				url:   "https://non-existing.com",
+				alias: "taken",
+			},
+			err:     ErrAliasTaken,
+			want:    "",
+			wantErr: true,
+		},
+		{
+			name: "returns new short id for link with ttl",
+			args: args{
+				url: "https://non-existing.com",
+				ttl: time.Hour,
+			},
+			want:    "abcde",
+			wantErr: false,
+		},
+		{
+			name: "returns new short id for link with expiration in the future",
+			args: args{
+				url:       "https://non-existing.com",
+				expiresAt: time.Now().Add(time.Hour),
+			},
+			want:    "abcde",
+			wantErr: false,
+		},
+		{
+			name: "returns new short id for link with ttl if url already exists",
+			args: args{
+				url: "http://existing.com",
+				ttl: time.Hour,
+			},
+			want:    "abcde",
+			wantErr: false,
+		},
+		{
+			name: "returns new short id for click-limited link if url already exists",
+			args: args{
+				url:       "http://existing.com",
+				maxClicks: 1,
+			},
+			want:    "abcde",
+			wantErr: false,
+		},
+		{
+			name: "returns new short id for password-protected link if url already exists",
+			args: args{
+				url:      "http://existing.com",
+				password: "secret",
+			},
+			want:    "abcde",
+			wantErr: false,
+		},
+		{
+			name: "returns new short id if url is only shortened as password-protected link",
+			args: args{
+				url: "http://secret.com",
+			},
+			want:    "abcde",
+			wantErr: false,
+		},
+		{
+			name: "returns new short id if url is only shortened as click-limited link",
+			args: args{
+				url: "http://one-time.com",
+			},
+			want:    "abcde",
+			wantErr: false,
+		},
+		{
+			name: "returns ErrInvalidExpiry if expiration is in the past",
+			args: args{
+				url:       "https://non-existing.com",
+				expiresAt: time.Now().Add(-time.Hour),
+			},
+			err:     ErrInvalidExpiry,
+			want:    "",
+			wantErr: true,
+		},
+		{
+			name: "returns ErrInvalidExpiry if ttl is negative",
+			args: args{
+				url: "https://non-existing.com",
+				ttl: -time.Second,
+			},
+			err:     ErrInvalidExpiry,
+			want:    "",
+			wantErr: true,
+		},
+		{
+			name: "returns ErrInvalidExpiry if both ttl and expiration are set",
+			args: args{
+				url:       "https://non-existing.com",
+				ttl:       time.Hour,
+				expiresAt: time.Now().Add(time.Hour),
+			},
+			err:     ErrInvalidExpiry,
+			want:    "",
+			wantErr: true,
+		},
+		{
+			name: "returns ErrInvalidMaxClicks if max clicks is negative",
+			args: args{
+				url:       "https://non-existing.com",
+				maxClicks: -1,
+			},
+			err:     ErrInvalidMaxClicks,
+			want:    "",
+			wantErr: true,
+		},
+		{
+			name: "returns new short id for password-protected link",
+			args: args{
+				url:      "https://non-existing.com",
+				password: "secret",
+			},
+			want:    "abcde",
+			wantErr: false,
+		},
+		{
+			name: "returns ErrInvalidPassword if password is too long",
+			args: args{
+				url:      "https://non-existing.com",
+				password: strings.Repeat("p", maxPasswordLen+1),
+			},
+			err:     ErrInvalidPassword,
+			want:    "",
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -155,7 +466,14 @@ func TestShortener_Shorten(t *testing.T) {
 				logger:     zap.NewNop(),
 			}
 
-			got, err := s.Shorten(t.Context(), userUUID, tt.args.url)
+			opts := model.ShortenOptions{
+				Alias:     tt.args.alias,
+				TTL:       tt.args.ttl,
+				ExpiresAt: tt.args.expiresAt,
+				MaxClicks: tt.args.maxClicks,
+				Password:  tt.args.password,
+			}
+			got, err := s.Shorten(t.Context(), userUUID, tt.args.url, opts)
 
 			if !tt.wantErr {
 				require.NoError(t, err)
@@ -211,26 +529,455 @@ func TestShortener_Extract(t *testing.T) {
 				logger:     zap.NewNop(),
 			}
 
-			got, err := s.Extract(t.Context(), tt.args.shortID)
+			got, err := s.Extract(t.Context(), model.ExpandRequest{ShortID: tt.args.shortID})
 
 			if !tt.wantErr {
 				require.NoError(t, err)
-				assert.Equal(t, tt.want, got)
+				assert.Equal(t, tt.want, got.OrigURL)
 			} else {
 				require.Error(t, err)
 				assert.ErrorAs(t, err, tt.wantErrAs)
-				assert.Equal(t, tt.want, got)
+				assert.Nil(t, got)
 			}
 		})
 	}
 }
 
+func TestShortener_ExtractOneTime(t *testing.T) {
+	s := Shortener{
+		urlStorage: newURLStorageStub(false, false),
+		generator:  newIDGeneratorStub(false),
+		logger:     zap.NewNop(),
+	}
+
+	got, err := s.Extract(t.Context(), model.ExpandRequest{ShortID: "once"})
+	require.NoError(t, err)
+	assert.Equal(t, "http://one-time.com", got.OrigURL)
+
+	got, err = s.Extract(t.Context(), model.ExpandRequest{ShortID: "once"})
+	require.ErrorIs(t, err, repo.ErrClicksExhausted)
+	assert.Nil(t, got)
+}
+
+func TestShortener_ExtractProtected(t *testing.T) {
+	hash, err := hashPassword("secret")
+	require.NoError(t, err)
+	stub := newURLStorageStub(false, false)
+	stub.storage = append(stub.storage, model.URLStorageRecord{
+		OrigURL:  "http://protected.com",
+		ShortID:  "protected",
+		PassHash: hash,
+	})
+	s := Shortener{
+		urlStorage: stub,
+		generator:  newIDGeneratorStub(false),
+		attempts:   NewPasswordAttemptLimiter(2, time.Minute),
+		logger:     zap.NewNop(),
+	}
+
+	_, err = s.Extract(t.Context(), model.ExpandRequest{ShortID: "protected"})
+	require.ErrorIs(t, err, ErrPasswordRequired)
+
+	got, err := s.Extract(t.Context(), model.ExpandRequest{ShortID: "protected", Password: "secret"})
+	require.NoError(t, err)
+	assert.Equal(t, "http://protected.com", got.OrigURL)
+
+	_, err = s.Extract(t.Context(), model.ExpandRequest{ShortID: "protected", Password: "guess"})
+	require.ErrorIs(t, err, ErrWrongPassword)
+	_, err = s.Extract(t.Context(), model.ExpandRequest{ShortID: "protected", Password: "guess"})
+	require.ErrorIs(t, err, ErrWrongPassword)
+	_, err = s.Extract(t.Context(), model.ExpandRequest{ShortID: "protected", Password: "secret"})
+	require.ErrorIs(t, err, ErrTooManyAttempts)
+}
+
+func TestShortener_Inspect(t *testing.T) {
+	stub := newURLStorageStub(false, false)
+	stub.storage = append(stub.storage, model.URLStorageRecord{
+		OrigURL:  "http://protected.com",
+		ShortID:  "protected",
+		PassHash: "hash",
+	})
+	s := Shortener{
+		urlStorage: stub,
+		generator:  newIDGeneratorStub(false),
+		blocker:    urlBlockerStub{blocked: "existing.com"},
+		logger:     zap.NewNop(),
+	}
+
+	got, err := s.Inspect(t.Context(), "once")
+	require.NoError(t, err)
+	assert.Equal(t, &model.URLInspection{ShortID: "once", OrigURL: "http://one-time.com", Safety: model.URLSafetySafe}, got)
+	_, err = s.Extract(t.Context(), model.ExpandRequest{ShortID: "once"})
+	require.NoError(t, err, "inspection must not consume the click")
+
+	got, err = s.Inspect(t.Context(), "protected")
+	require.NoError(t, err)
+	assert.True(t, got.Protected)
+	assert.Empty(t, got.OrigURL)
+
+	got, err = s.Inspect(t.Context(), "abcde")
+	require.NoError(t, err)
+	assert.Equal(t, model.URLSafetyBlocked, got.Safety)
+
+	_, err = s.Inspect(t.Context(), "once")
+	require.ErrorIs(t, err, repo.ErrClicksExhausted)
+}
+
+type urlBlockerStub struct {
+	blocked string
+}
+
+func (b urlBlockerStub) Match(url string) (string, bool) {
+	if strings.Contains(url, b.blocked) {
+		return "host " + b.blocked, true
+	}
+	return "", false
+}
+
+func TestShortener_Blocked(t *testing.T) {
+	s := Shortener{
+		urlStorage: newURLStorageStub(false, false),
+		generator:  newIDGeneratorStub(false),
+		blocker:    urlBlockerStub{blocked: "existing.com"},
+		logger:     zap.NewNop(),
+	}
+	var bErr *BlockedURLError
+
+	_, err := s.Shorten(t.Context(), "userUUID", "http://existing.com", model.ShortenOptions{})
+	require.ErrorAs(t, err, &bErr)
+	assert.Equal(t, "http://existing.com", bErr.OrigURL)
+	assert.Equal(t, "host existing.com", bErr.Rule)
+
+	_, err = s.ShortenBatch(t.Context(), "userUUID", model.URLShortenBatch{
+		{OrigURL: "http://new.com"},
+		{OrigURL: "http://existing.com/path"},
+	})
+	require.ErrorIs(t, err, ErrURLBlocked)
+
+	got, err := s.Extract(t.Context(), model.ExpandRequest{ShortID: "abcde"})
+	require.ErrorIs(t, err, ErrURLBlocked)
+	assert.Nil(t, got)
+
+	got, err = s.Extract(t.Context(), model.ExpandRequest{ShortID: "once"})
+	require.NoError(t, err)
+	assert.Equal(t, "http://one-time.com", got.OrigURL)
+}
+
+func TestShortener_IDStyle(t *testing.T) {
+	s := Shortener{
+		urlStorage: newURLStorageStub(false, false),
+		generator:  &idSequenceStub{ids: []string{"default"}},
+		styles:     map[string]IDGenerator{IDStyleWords: &idSequenceStub{ids: []string{"brave-otter-42", "calm-fox-07"}}},
+		logger:     zap.NewNop(),
+	}
+	got, err := s.Shorten(t.Context(), "userUUID", "http://new.com", model.ShortenOptions{IDStyle: IDStyleWords})
+	require.NoError(t, err)
+	assert.Equal(t, "brave-otter-42", got)
+
+	got, err = s.Shorten(t.Context(), "userUUID", "http://new.com", model.ShortenOptions{IDStyle: IDStyleDefault})
+	require.NoError(t, err)
+	assert.Equal(t, "default", got)
+
+	got, err = s.Shorten(t.Context(), "userUUID", "http://new.com", model.ShortenOptions{IDStyle: IDStyleWords, Alias: "my-alias"})
+	require.NoError(t, err)
+	assert.Equal(t, "my-alias", got)
+
+	ids, err := s.ShortenBatch(t.Context(), "userUUID", model.URLShortenBatch{
+		{OrigURL: "http://new.com", Opts: model.ShortenOptions{IDStyle: IDStyleWords}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"calm-fox-07"}, ids)
+
+	var vErr *ValidationError
+	_, err = s.Shorten(t.Context(), "userUUID", "http://new.com", model.ShortenOptions{IDStyle: "emoji"})
+	require.ErrorAs(t, err, &vErr)
+	require.ErrorIs(t, err, ErrInvalidIDStyle)
+	_, err = s.ShortenBatch(t.Context(), "userUUID", model.URLShortenBatch{
+		{OrigURL: "http://new.com", Opts: model.ShortenOptions{IDStyle: "emoji"}},
+	})
+	require.ErrorIs(t, err, ErrInvalidIDStyle)
+}
+
+func TestShortener_Checksum(t *testing.T) {
+	c := NewIDChecksum()
+	s := Shortener{
+		urlStorage: newURLStorageStub(false, false),
+		generator:  newIDGeneratorStub(false),
+		checksum:   c,
+		logger:     zap.NewNop(),
+	}
+
+	var csErr *ChecksumError
+	_, err := s.Extract(t.Context(), model.ExpandRequest{ShortID: "abcde"})
+	require.ErrorAs(t, err, &csErr)
+	assert.NotEmpty(t, csErr.Suggestions)
+
+	var vErr *ValidationError
+	_, err = s.Shorten(t.Context(), "userUUID", "http://new.com", model.ShortenOptions{Alias: "promo"})
+	require.ErrorAs(t, err, &vErr)
+	require.ErrorIs(t, err, ErrInvalidAlias)
+
+	alias, err := c.Append("promo")
+	require.NoError(t, err)
+	got, err := s.Shorten(t.Context(), "userUUID", "http://new.com", model.ShortenOptions{Alias: alias})
+	require.NoError(t, err)
+	assert.Equal(t, alias, got)
+}
+
+func TestShortener_SetTags(t *testing.T) {
+	var nfErr *repo.DataNotFoundError
+	s := Shortener{
+		urlStorage: newURLStorageStub(false, false),
+		logger:     zap.NewNop(),
+	}
+
+	got, err := s.SetTags(t.Context(), "userUUID", "abcde", []string{" Promo ", "q1", "promo"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"promo", "q1"}, got.Tags)
+	assert.Equal(t, "http://existing.com", got.OrigURL)
+
+	got, err = s.SetTags(t.Context(), "userUUID", "abcde", nil)
+	require.NoError(t, err)
+	assert.Empty(t, got.Tags)
+
+	_, err = s.SetTags(t.Context(), "anotherUUID", "abcde", []string{"promo"})
+	require.ErrorAs(t, err, &nfErr)
+
+	_, err = s.SetTags(t.Context(), "userUUID", "abcde", []string{"a,b"})
+	require.ErrorIs(t, err, ErrInvalidTags)
+}
+
+func TestShortener_RedirectType(t *testing.T) {
+	storage := repo.NewMemoryURLStorage(zap.NewNop())
+	s := Shortener{
+		urlStorage:   storage,
+		generator:    &idSequenceStub{ids: []string{"default", "explicit", "batch"}},
+		redirectType: http.StatusPermanentRedirect,
+		logger:       zap.NewNop(),
+	}
+
+	_, err := s.Shorten(t.Context(), "userUUID", "http://default.com", model.ShortenOptions{})
+	require.NoError(t, err)
+	_, err = s.Shorten(t.Context(), "userUUID", "http://explicit.com", model.ShortenOptions{RedirectType: http.StatusFound})
+	require.NoError(t, err)
+	_, err = s.ShortenBatch(t.Context(), "userUUID", model.URLShortenBatch{
+		{OrigURL: "http://batch.com", Opts: model.ShortenOptions{RedirectType: http.StatusMovedPermanently}},
+	})
+	require.NoError(t, err)
+
+	for shortID, want := range map[string]int{
+		"default":  http.StatusPermanentRedirect,
+		"explicit": http.StatusFound,
+		"batch":    http.StatusMovedPermanently,
+	} {
+		r, err := s.Extract(t.Context(), model.ExpandRequest{ShortID: shortID})
+		require.NoError(t, err)
+		assert.Equal(t, want, r.Redirect(), shortID)
+	}
+
+	var vErr *ValidationError
+	_, err = s.Shorten(t.Context(), "userUUID", "http://new.com", model.ShortenOptions{RedirectType: http.StatusSeeOther})
+	require.ErrorAs(t, err, &vErr)
+	require.ErrorIs(t, err, ErrInvalidRedirectType)
+	_, err = s.ShortenBatch(t.Context(), "userUUID", model.URLShortenBatch{
+		{OrigURL: "http://new.com", Opts: model.ShortenOptions{RedirectType: 200}},
+	})
+	require.ErrorIs(t, err, ErrInvalidRedirectType)
+}
+
+func TestShortener_Passthrough(t *testing.T) {
+	storage := repo.NewMemoryURLStorage(zap.NewNop())
+	s := Shortener{
+		urlStorage: storage,
+		generator:  &idSequenceStub{ids: []string{"fwd", "plain", "once"}},
+		blocker:    urlBlockerStub{blocked: "/private"},
+		logger:     zap.NewNop(),
+	}
+
+	_, err := s.Shorten(t.Context(), "userUUID", "http://docs.com/guide?lang=en", model.ShortenOptions{Passthrough: true})
+	require.NoError(t, err)
+	_, err = s.Shorten(t.Context(), "userUUID", "http://plain.com", model.ShortenOptions{})
+	require.NoError(t, err)
+	_, err = s.ShortenBatch(t.Context(), "userUUID", model.URLShortenBatch{
+		{OrigURL: "http://once.com", Opts: model.ShortenOptions{Passthrough: true, MaxClicks: 1}},
+	})
+	require.NoError(t, err)
+
+	got, err := s.Extract(t.Context(), model.ExpandRequest{ShortID: "fwd", Path: "/intro", RawQuery: "lang=de&page=2"})
+	require.NoError(t, err)
+	assert.Equal(t, "http://docs.com/guide/intro?lang=en&page=2", got.OrigURL)
+
+	got, err = s.Extract(t.Context(), model.ExpandRequest{ShortID: "plain", RawQuery: "a=1"})
+	require.NoError(t, err)
+	assert.Equal(t, "http://plain.com", got.OrigURL)
+
+	var nfErr *repo.DataNotFoundError
+	_, err = s.Extract(t.Context(), model.ExpandRequest{ShortID: "plain", Path: "/extra"})
+	require.ErrorAs(t, err, &nfErr)
+
+	_, err = s.Extract(t.Context(), model.ExpandRequest{ShortID: "fwd", Path: "/private/keys"})
+	require.ErrorIs(t, err, ErrURLBlocked)
+
+	_, err = s.Extract(t.Context(), model.ExpandRequest{ShortID: "once", Path: "/../admin"})
+	require.ErrorIs(t, err, ErrInvalidPassthrough)
+	got, err = s.Extract(t.Context(), model.ExpandRequest{ShortID: "once", Path: "/ok"})
+	require.NoError(t, err, "rejected suffix must not consume a click")
+	assert.Equal(t, "http://once.com/ok", got.OrigURL)
+}
+
+func TestShortener_Update(t *testing.T) {
+	var nfErr *repo.DataNotFoundError
+
+	tests := []struct {
+		name      string
+		userUUID  string
+		shortID   string
+		url       string
+		canon     bool
+		wantPrev  string
+		wantURL   string
+		wantErr   error
+		wantErrAs any
+	}{
+		{
+			name:     "changes original url of user's short url",
+			userUUID: "userUUID",
+			shortID:  "abcde",
+			url:      "http://new.com",
+			wantPrev: "http://existing.com",
+			wantURL:  "http://new.com",
+		},
+		{
+			name:     "stores and returns canonical form of new url",
+			userUUID: "userUUID",
+			shortID:  "abcde",
+			url:      "HTTP://New.com:80/path",
+			canon:    true,
+			wantPrev: "http://existing.com",
+			wantURL:  "http://new.com/path",
+		},
+		{
+			name:     "same original url is a no-op",
+			userUUID: "userUUID",
+			shortID:  "abcde",
+			url:      "http://existing.com",
+			wantPrev: "http://existing.com",
+			wantURL:  "http://existing.com",
+		},
+		{
+			name:     "returns ErrEmptyInputURL for empty url",
+			userUUID: "userUUID",
+			shortID:  "abcde",
+			url:      "",
+			wantErr:  ErrEmptyInputURL,
+		},
+		{
+			name:     "returns ErrURLAlreadyExists if new url is already shortened",
+			userUUID: "userUUID",
+			shortID:  "abcde",
+			url:      "http://popular.com",
+			wantErr:  ErrURLAlreadyExists,
+		},
+		{
+			name:     "new url only shortened as click-limited link is not deduplicated",
+			userUUID: "userUUID",
+			shortID:  "abcde",
+			url:      "http://one-time.com",
+			wantPrev: "http://existing.com",
+			wantURL:  "http://one-time.com",
+		},
+		{
+			name:      "returns not found for short url of another user",
+			userUUID:  "anotherUUID",
+			shortID:   "abcde",
+			url:       "http://new.com",
+			wantErrAs: &nfErr,
+		},
+		{
+			name:      "returns not found for non-existing short url",
+			userUUID:  "userUUID",
+			shortID:   "non-existing",
+			url:       "http://new.com",
+			wantErrAs: &nfErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := newURLStorageStub(false, false)
+			s := Shortener{
+				urlStorage: stub,
+				generator:  newIDGeneratorStub(false),
+				canon:      NewURLCanonicalizer(tt.canon, false),
+				logger:     zap.NewNop(),
+			}
+
+			prev, url, err := s.Update(t.Context(), tt.userUUID, tt.shortID, tt.url)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			} else if tt.wantErrAs != nil {
+				require.ErrorAs(t, err, tt.wantErrAs)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantPrev, prev.OrigURL)
+			assert.Equal(t, tt.wantURL, url)
+			got, err := stub.Get(t.Context(), tt.shortID, repo.ShortURLType)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantURL, got.OrigURL)
+		})
+	}
+}
+
+func TestShortener_RestoreVersion(t *testing.T) {
+	stub := newURLStorageStub(false, false)
+	s := Shortener{
+		urlStorage: stub,
+		generator:  newIDGeneratorStub(false),
+		logger:     zap.NewNop(),
+	}
+
+	_, _, err := s.RestoreVersion(t.Context(), "userUUID", "abcde", 3)
+	require.ErrorIs(t, err, ErrHistoryVersionNotFound)
+	_, _, err = s.RestoreVersion(t.Context(), "userUUID", "abcde", 0)
+	require.ErrorIs(t, err, ErrHistoryVersionNotFound)
+	var nfErr *repo.DataNotFoundError
+	_, _, err = s.RestoreVersion(t.Context(), "anotherUUID", "abcde", 1)
+	require.ErrorAs(t, err, &nfErr)
+
+	prev, url, err := s.RestoreVersion(t.Context(), "userUUID", "abcde", 1)
+	require.NoError(t, err)
+	assert.Equal(t, "http://existing.com", prev.OrigURL)
+	assert.Equal(t, "http://first.com", url)
+	got, err := stub.Get(t.Context(), "abcde", repo.ShortURLType)
+	require.NoError(t, err)
+	assert.Equal(t, "http://first.com", got.OrigURL)
+}
+
+func TestShortener_RestoreBatch(t *testing.T) {
+	s := Shortener{
+		urlStorage: newURLStorageStub(false, false),
+		generator:  newIDGeneratorStub(false),
+		logger:     zap.NewNop(),
+	}
+
+	_, err := s.RestoreBatch(t.Context(), "userUUID", nil)
+	require.ErrorIs(t, err, ErrEmptyInputBatch)
+
+	results, err := s.RestoreBatch(t.Context(), "userUUID", []string{"abcde"})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, model.URLRestoreStatusRestored, results[0].Status)
+}
+
 func TestShortener_ShortenBatch(t *testing.T) {
 	userUUID := "userUUID"
 
 	tests := []struct {
 		name                  string
-		urls                  []string
+		urls                  model.URLShortenBatch
 		idGeneratorShouldFail bool
 		batchSetShouldFail    bool
 		want                  []string
@@ -239,27 +986,60 @@ func TestShortener_ShortenBatch(t *testing.T) {
 	}{
 		{
 			name: "success returns ids for existing and new",
-			urls: []string{"http://existing.com", "https://non-existing.com"},
+			urls: model.URLShortenBatch{{OrigURL: "http://existing.com"}, {OrigURL: "https://non-existing.com"}},
 			want: []string{"abcde", "abcde"},
 		},
 		{
 			name:    "returns ErrEmptyInputURL when any url is empty",
-			urls:    []string{""},
+			urls:    model.URLShortenBatch{{OrigURL: ""}},
 			wantErr: true,
 			err:     ErrEmptyInputURL,
 		},
 		{
 			name:                  "returns ErrShortenerGenerationShortIDFailed when generator fails",
-			urls:                  []string{"https://non-existing.com"},
+			urls:                  model.URLShortenBatch{{OrigURL: "https://non-existing.com"}},
 			idGeneratorShouldFail: true,
 			wantErr:               true,
 		},
 		{
 			name:               "returns ErrShortenerSetBindingURLStorageFailed when BatchSet fails",
-			urls:               []string{"https://non-existing.com"},
+			urls:               model.URLShortenBatch{{OrigURL: "https://non-existing.com"}},
 			batchSetShouldFail: true,
 			wantErr:            true,
 		},
+		{
+			name: "success returns aliases for new urls with alias",
+			urls: model.URLShortenBatch{
+				{OrigURL: "https://non-existing.com", Opts: model.ShortenOptions{Alias: "spring-sale"}},
+				{OrigURL: "https://other-non-existing.com"},
+			},
+			want: []string{"spring-sale", "abcde"},
+		},
+		{
+			name: "returns ErrInvalidAlias when any alias is invalid",
+			urls: model.URLShortenBatch{
+				{OrigURL: "https://non-existing.com", Opts: model.ShortenOptions{Alias: "ping"}},
+			},
+			wantErr: true,
+			err:     ErrInvalidAlias,
+		},
+		{
+			name: "returns ErrAliasTaken when any alias is already in use",
+			urls: model.URLShortenBatch{
+				{OrigURL: "https://non-existing.com", Opts: model.ShortenOptions{Alias: "taken"}},
+			},
+			wantErr: true,
+			err:     ErrAliasTaken,
+		},
+		{
+			name: "returns ErrAliasURLExists when any alias is requested for existing url",
+			urls: model.URLShortenBatch{
+				{OrigURL: "https://non-existing.com"},
+				{OrigURL: "http://existing.com", Opts: model.ShortenOptions{Alias: "spring-sale"}},
+			},
+			wantErr: true,
+			err:     ErrAliasURLExists,
+		},
 	}
 
 	for _, tt := range tests {
@@ -287,3 +1067,33 @@ func TestShortener_ShortenBatch(t *testing.T) {
 		})
 	}
 }
+
+type idSequenceStub struct {
+	ids []string
+}
+
+func (d *idSequenceStub) Generate(_ context.Context, _ string) (string, error) {
+	id := d.ids[0]
+	d.ids = d.ids[1:]
+	return id, nil
+}
+
+func TestShortener_RetryTakenShortID(t *testing.T) {
+	s := Shortener{
+		urlStorage: newURLStorageStub(false, false),
+		generator:  &idSequenceStub{ids: []string{"taken", "fresh"}},
+		logger:     zap.NewNop(),
+	}
+	got, err := s.Shorten(t.Context(), "userUUID", "http://new.com", model.ShortenOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "fresh", got)
+
+	s.generator = &idSequenceStub{ids: []string{"taken", "taken", "fresh"}}
+	ids, err := s.ShortenBatch(t.Context(), "userUUID", model.URLShortenBatch{{OrigURL: "http://new.com"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"fresh"}, ids)
+
+	s.generator = &idSequenceStub{ids: slices.Repeat([]string{"taken"}, maxStoreAttempts)}
+	_, err = s.Shorten(t.Context(), "userUUID", "http://new.com", model.ShortenOptions{})
+	require.ErrorIs(t, err, repo.ErrShortIDConflict)
+}
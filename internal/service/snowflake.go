@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+)
+
+// Layout of snowflake IDs: 41 bits of milliseconds since the epoch, 10 bits of node ID
+// and 12 bits of per-millisecond sequence. The IDs fit into 63 bits and take at most
+// 11 base62 characters.
+const (
+	snowflakeNodeBits     = 10
+	snowflakeSequenceBits = 12
+	snowflakeMaxNodeID    = 1<<snowflakeNodeBits - 1
+	snowflakeSequenceMask = 1<<snowflakeSequenceBits - 1
+	snowflakeTimeShift    = snowflakeNodeBits + snowflakeSequenceBits
+)
+
+// snowflakeEpoch is the start of snowflake time, keeping IDs short for decades.
+var snowflakeEpoch = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// snowflakeMaxClockSkew is the maximum time the generator may run ahead of the wall clock,
+// either because the clock moved backwards or because the sequence of a millisecond is exhausted.
+const snowflakeMaxClockSkew = time.Second
+
+// ErrClockSkew is returned when the wall clock moved backwards further than the generator can tolerate.
+var ErrClockSkew = errors.New("clock moved backwards")
+
+// snowflake produces unique 63-bit IDs from time, node ID and sequence.
+// IDs of different nodes never collide, so every replica sharing the storage needs its own node ID.
+//
+// The generator never goes back in time: if the wall clock moves backwards, IDs keep being issued
+// from the last used millisecond, and if the sequence of a millisecond is exhausted, the next millisecond
+// is taken in advance. Running ahead of the wall clock is limited by snowflakeMaxClockSkew.
+type snowflake struct {
+	nodeID   uint64
+	now      func() time.Time
+	mu       sync.Mutex
+	lastMS   int64
+	sequence uint64
+}
+
+// newSnowflake creates a new snowflake with the node ID and the source of the wall clock.
+func newSnowflake(nodeID int, now func() time.Time) (*snowflake, error) {
+	if nodeID < 0 || nodeID > snowflakeMaxNodeID {
+		return nil, fmt.Errorf("node id must be between 0 and %d, got %d", snowflakeMaxNodeID, nodeID)
+	}
+	return &snowflake{
+		nodeID: uint64(nodeID),
+		now:    now,
+	}, nil
+}
+
+// next returns the next ID.
+func (s *snowflake) next() (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	nowMS := s.now().Sub(snowflakeEpoch).Milliseconds()
+	ms := max(nowMS, s.lastMS)
+	if ms == s.lastMS {
+		s.sequence = (s.sequence + 1) & snowflakeSequenceMask
+		if s.sequence == 0 {
+			ms++
+		}
+	} else {
+		s.sequence = 0
+	}
+	if skew := time.Duration(ms-nowMS) * time.Millisecond; skew > snowflakeMaxClockSkew {
+		return 0, fmt.Errorf("%w: generator is %v ahead of the clock", ErrClockSkew, skew)
+	}
+	s.lastMS = ms
+	return uint64(ms)<<snowflakeTimeShift | s.nodeID<<snowflakeSequenceBits | s.sequence, nil
+}
+
+// NewSnowflakeIDGenerator creates a generator of base62-encoded snowflake IDs
+// made of the current time, the node ID and a per-millisecond sequence.
+// IDs are unique across replicas with different node IDs without any coordination.
+//
+// Parameters:
+//   - s: storage to check candidates against
+//   - nodeID: ID of the replica, between 0 and 1023
+//   - maxAttempts: maximum amount of candidates checked for a single short ID
+//
+// Returns:
+//   - *UniqueIDGenerator: configured generator
+//   - error: nil on success, or error if the settings are invalid
+func NewSnowflakeIDGenerator(s ShortIDLookup, nodeID, maxAttempts int) (*UniqueIDGenerator, error) {
+	sf, err := newSnowflake(nodeID, time.Now)
+	if err != nil {
+		return nil, err
+	}
+	next := func(_ context.Context, _ string, _ int) (string, error) {
+		id, err := sf.next()
+		if err != nil {
+			return "", err
+		}
+		return encodeBase62(new(big.Int).SetUint64(id), 0), nil
+	}
+	return newUniqueIDGenerator(next, s, maxAttempts)
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type clockStub struct {
+	now time.Time
+}
+
+func (c *clockStub) Now() time.Time {
+	return c.now
+}
+
+func TestSnowflake_Next(t *testing.T) {
+	clock := &clockStub{now: snowflakeEpoch.Add(time.Hour)}
+	sf, err := newSnowflake(5, clock.Now)
+	require.NoError(t, err)
+
+	first, err := sf.next()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(time.Hour.Milliseconds()), first>>snowflakeTimeShift)
+	assert.Equal(t, uint64(5), first>>snowflakeSequenceBits&snowflakeMaxNodeID)
+
+	second, err := sf.next()
+	require.NoError(t, err)
+	assert.Equal(t, first+1, second, "ids of the same millisecond differ by sequence")
+
+	other, err := newSnowflake(6, clock.Now)
+	require.NoError(t, err)
+	otherID, err := other.next()
+	require.NoError(t, err)
+	assert.NotEqual(t, first, otherID, "ids of different nodes never collide")
+}
+
+func TestSnowflake_SequenceOverflow(t *testing.T) {
+	clock := &clockStub{now: snowflakeEpoch.Add(time.Hour)}
+	sf, err := newSnowflake(0, clock.Now)
+	require.NoError(t, err)
+
+	seen := make(map[uint64]struct{})
+	for range snowflakeSequenceMask + 2 {
+		id, err := sf.next()
+		require.NoError(t, err)
+		seen[id] = struct{}{}
+	}
+	assert.Len(t, seen, snowflakeSequenceMask+2)
+	assert.Equal(t, time.Hour.Milliseconds()+1, sf.lastMS, "next millisecond is taken in advance")
+}
+
+func TestSnowflake_ClockSkew(t *testing.T) {
+	clock := &clockStub{now: snowflakeEpoch.Add(time.Hour)}
+	sf, err := newSnowflake(0, clock.Now)
+	require.NoError(t, err)
+	last, err := sf.next()
+	require.NoError(t, err)
+
+	clock.now = clock.now.Add(-snowflakeMaxClockSkew / 2)
+	id, err := sf.next()
+	require.NoError(t, err)
+	assert.Greater(t, id, last, "ids keep growing while the clock is slightly behind")
+
+	clock.now = clock.now.Add(-snowflakeMaxClockSkew)
+	_, err = sf.next()
+	require.ErrorIs(t, err, ErrClockSkew)
+
+	clock.now = snowflakeEpoch.Add(2 * time.Hour)
+	_, err = sf.next()
+	require.NoError(t, err)
+}
+
+func TestNewSnowflake_InvalidNodeID(t *testing.T) {
+	_, err := newSnowflake(-1, time.Now)
+	require.Error(t, err)
+	_, err = newSnowflake(snowflakeMaxNodeID+1, time.Now)
+	require.Error(t, err)
+}
@@ -0,0 +1,255 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"regexp"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/alex-storchak/shortener/internal/model"
+	repo "github.com/alex-storchak/shortener/internal/repository"
+)
+
+// Limits of split tests.
+const (
+	minSplitVariants   = 2    // Minimum amount of variants of a split test
+	maxSplitVariants   = 10   // Maximum amount of variants of a split test
+	maxSplitWeight     = 1000 // Maximum weight of a variant
+	maxSplitVariantLen = 32   // Maximum length of a variant name in characters
+)
+
+// splitVariantPattern matches allowed variant names: latin letters, digits, `_` and `-`.
+var splitVariantPattern = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// Splitter manages A/B split tests of links and picks the variant of a follow.
+// Clients following a link with a split test are sent to one of its variants picked at random
+// in proportion to the variant weights, instead of the original URL of the link.
+// Sticky split tests keep clients on the variant assigned on their first follow.
+type Splitter struct {
+	storage repo.SplitStorage
+	urls    ShortIDLookup
+	dest    DestinationChecker
+	logger  *zap.Logger
+	intN    func(n int) int
+}
+
+// NewSplitter creates a new instance of Splitter.
+//
+// Parameters:
+//   - storage: storage of split tests
+//   - urls: URL storage used to check that links belong to the users managing their split tests
+//   - dest: checker of variant destinations against the URL validation settings and the blocklist
+//   - logger: structured logger for logging operations
+//
+// Returns:
+//   - *Splitter: configured split test service
+func NewSplitter(
+	storage repo.SplitStorage,
+	urls ShortIDLookup,
+	dest DestinationChecker,
+	logger *zap.Logger,
+) *Splitter {
+	return &Splitter{
+		storage: storage,
+		urls:    urls,
+		dest:    dest,
+		logger:  logger,
+		intN:    rand.IntN,
+	}
+}
+
+// GetSplit retrieves the split test of a link owned by the user.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - userUUID: UUID of the user
+//   - shortID: short identifier of the link
+//
+// Returns:
+//   - model.Split: split test of the link; without variants if the link has no split test
+//   - error: nil on success, or storage error if URL is not found among user's URLs,
+//     deleted, expired or has no follows left
+func (s *Splitter) GetSplit(ctx context.Context, userUUID, shortID string) (model.Split, error) {
+	if err := s.checkOwner(ctx, userUUID, shortID); err != nil {
+		return model.Split{}, err
+	}
+	split, err := s.storage.Get(ctx, shortID)
+	if err != nil {
+		return model.Split{}, fmt.Errorf("get split test from storage: %w", err)
+	}
+	return split, nil
+}
+
+// SetSplit validates and stores the split test of a link owned by the user, replacing its previous split test.
+// A split test without variants removes it. Variants without names are named a, b, c... by their position,
+// and destinations are canonicalized and checked the same way as original URLs.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - userUUID: UUID of the user
+//   - split: split test with the short identifier of the link
+//
+// Returns:
+//   - model.Split: stored split test
+//   - error: nil on success, or storage error if URL is not found among user's URLs,
+//     deleted, expired or has no follows left
+//
+// Errors:
+//   - ErrInvalidSplit: when variants are too few or too many, or a variant has a malformed or duplicate name
+//     or a weight out of range
+//   - ErrInvalidURL: when the URL of a variant is empty or invalid
+//   - ErrURLBlocked: when the URL of a variant matches the blocklist; returned as *BlockedURLError
+func (s *Splitter) SetSplit(ctx context.Context, userUUID string, split model.Split) (model.Split, error) {
+	if len(split.Variants) > 0 {
+		variants, err := s.normalizeVariants(split.Variants)
+		if err != nil {
+			return model.Split{}, err
+		}
+		split.Variants = variants
+	} else {
+		split.Sticky = false
+	}
+	if err := s.checkOwner(ctx, userUUID, split.ShortID); err != nil {
+		return model.Split{}, err
+	}
+
+	if err := s.storage.Set(ctx, split); err != nil {
+		return model.Split{}, fmt.Errorf("set split test in storage: %w", err)
+	}
+	return split, nil
+}
+
+// Pick picks the variant of the split test of the link for the follow.
+// Clients of sticky split tests keep the variant they were assigned before, as long as it still exists;
+// other clients get a variant picked at random in proportion to the variant weights.
+// The path suffix and query of the request are forwarded to it for links with passthrough enabled.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - r: followed link
+//   - req: expand request with the variant assigned to the client before
+//
+// Returns:
+//   - *model.SplitChoice: picked variant with its destination; nil if the link has no split test
+//   - error: nil on success, or error if the split test can't be retrieved or the URL of the picked variant
+//     can't be redirected to anymore, e.g. it was blocked after the split test was stored
+func (s *Splitter) Pick(ctx context.Context, r *model.URLStorageRecord, req model.ExpandRequest) (*model.SplitChoice, error) {
+	split, err := s.storage.Get(ctx, r.ShortID)
+	if err != nil {
+		return nil, fmt.Errorf("get split test of url from storage: %w", err)
+	}
+	if len(split.Variants) == 0 {
+		return nil, nil
+	}
+
+	v, ok := s.assigned(split, req.Variant)
+	if !ok {
+		v = s.pickWeighted(split.Variants)
+	}
+	dest := v.URL
+	if r.Passthrough {
+		if dest, err = joinPassthrough(v.URL, req.Path, req.RawQuery); err != nil {
+			return nil, fmt.Errorf("forward passthrough to url of variant `%s`: %w", v.Name, err)
+		}
+	}
+	if _, err := s.dest.CheckDestination(dest); err != nil {
+		return nil, fmt.Errorf("check url of variant `%s`: %w", v.Name, err)
+	}
+	return &model.SplitChoice{Variant: v.Name, URL: dest, Sticky: split.Sticky}, nil
+}
+
+// assigned returns the variant of a sticky split test assigned to the client before, if it still exists.
+func (s *Splitter) assigned(split model.Split, name string) (model.SplitVariant, bool) {
+	if !split.Sticky || name == "" {
+		return model.SplitVariant{}, false
+	}
+	for _, v := range split.Variants {
+		if v.Name == name {
+			return v, true
+		}
+	}
+	return model.SplitVariant{}, false
+}
+
+// pickWeighted picks a variant at random with the probability proportional to its weight.
+func (s *Splitter) pickWeighted(variants []model.SplitVariant) model.SplitVariant {
+	total := 0
+	for _, v := range variants {
+		total += v.Weight
+	}
+	n := s.intN(total)
+	for _, v := range variants {
+		if n < v.Weight {
+			return v
+		}
+		n -= v.Weight
+	}
+	return variants[len(variants)-1]
+}
+
+// checkOwner checks that the link exists and belongs to the user.
+func (s *Splitter) checkOwner(ctx context.Context, userUUID, shortID string) error {
+	r, err := s.urls.Get(ctx, shortID, repo.ShortURLType)
+	if err != nil {
+		return fmt.Errorf("get url `%s` of split test: %w", shortID, err)
+	}
+	if r.UserUUID != userUUID {
+		return fmt.Errorf("get url `%s` of split test: %w", shortID, repo.NewDataNotFoundError(nil))
+	}
+	return nil
+}
+
+// normalizeVariants names unnamed variants, validates the variants and checks their URLs.
+func (s *Splitter) normalizeVariants(variants []model.SplitVariant) ([]model.SplitVariant, error) {
+	if len(variants) < minSplitVariants || len(variants) > maxSplitVariants {
+		return nil, NewValidationError(ErrInvalidSplit, fmt.Sprintf(
+			"from %d to %d variants are required", minSplitVariants, maxSplitVariants,
+		))
+	}
+	normalized := make([]model.SplitVariant, len(variants))
+	names := make(map[string]struct{}, len(variants))
+	for i, v := range variants {
+		n, err := s.normalizeVariant(v, i)
+		var vErr *ValidationError
+		if errors.As(err, &vErr) {
+			return nil, NewValidationError(vErr.Err, fmt.Sprintf("variant %d: %s", i+1, vErr.Reason))
+		} else if err != nil {
+			return nil, fmt.Errorf("check variant %d: %w", i+1, err)
+		}
+		if _, ok := names[n.Name]; ok {
+			return nil, NewValidationError(ErrInvalidSplit, fmt.Sprintf("variant %d: duplicate name `%s`", i+1, n.Name))
+		}
+		names[n.Name] = struct{}{}
+		normalized[i] = n
+	}
+	return normalized, nil
+}
+
+// normalizeVariant names the variant by its position if it has no name, validates it and checks its URL.
+func (s *Splitter) normalizeVariant(v model.SplitVariant, pos int) (model.SplitVariant, error) {
+	v.Name = strings.TrimSpace(v.Name)
+	if v.Name == "" {
+		v.Name = string(rune('a' + pos))
+	}
+	if len(v.Name) > maxSplitVariantLen || !splitVariantPattern.MatchString(v.Name) {
+		return v, NewValidationError(ErrInvalidSplit, fmt.Sprintf(
+			"name `%s` must be up to %d latin letters, digits, `_` and `-`", v.Name, maxSplitVariantLen,
+		))
+	}
+	if v.Weight < 1 || v.Weight > maxSplitWeight {
+		return v, NewValidationError(ErrInvalidSplit, fmt.Sprintf("weight must be from 1 to %d", maxSplitWeight))
+	}
+
+	url, err := s.dest.CheckDestination(strings.TrimSpace(v.URL))
+	if errors.Is(err, ErrEmptyInputURL) {
+		return v, NewValidationError(ErrInvalidURL, "url can't be empty")
+	} else if err != nil {
+		return v, err
+	}
+	v.URL = url
+	return v, nil
+}
@@ -0,0 +1,209 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/alex-storchak/shortener/internal/model"
+	repo "github.com/alex-storchak/shortener/internal/repository"
+)
+
+func TestSplitter_SetSplit(t *testing.T) {
+	urls := repo.NewMemoryURLStorage(zap.NewNop())
+	require.NoError(t, urls.Set(t.Context(), model.URLStorageRecord{OrigURL: "http://a.com", ShortID: "own", UserUUID: "userUUID"}))
+	require.NoError(t, urls.Set(t.Context(), model.URLStorageRecord{OrigURL: "http://b.com", ShortID: "alien", UserUUID: "otherUUID"}))
+	s := NewSplitter(repo.NewMemorySplitStorage(zap.NewNop()), urls, &destCheckerStub{blocked: "evil"}, zap.NewNop())
+
+	tests := []struct {
+		name      string
+		split     model.Split
+		want      model.Split
+		wantErr   error
+		wantErrAs any
+	}{
+		{
+			name: "names unnamed variants by position",
+			split: model.Split{ShortID: "own", Sticky: true, Variants: []model.SplitVariant{
+				{URL: " https://example.com/a ", Weight: 70},
+				{Name: "new-landing", URL: "https://example.com/b", Weight: 30},
+			}},
+			want: model.Split{ShortID: "own", Sticky: true, Variants: []model.SplitVariant{
+				{Name: "a", URL: "https://example.com/a", Weight: 70},
+				{Name: "new-landing", URL: "https://example.com/b", Weight: 30},
+			}},
+		},
+		{
+			name:  "no variants remove split test",
+			split: model.Split{ShortID: "own", Sticky: true},
+			want:  model.Split{ShortID: "own"},
+		},
+		{
+			name: "rejects single variant",
+			split: model.Split{ShortID: "own", Variants: []model.SplitVariant{
+				{URL: "https://example.com/a", Weight: 1},
+			}},
+			wantErr: ErrInvalidSplit,
+		},
+		{
+			name: "rejects zero weight",
+			split: model.Split{ShortID: "own", Variants: []model.SplitVariant{
+				{URL: "https://example.com/a", Weight: 1},
+				{URL: "https://example.com/b"},
+			}},
+			wantErr: ErrInvalidSplit,
+		},
+		{
+			name: "rejects duplicate names",
+			split: model.Split{ShortID: "own", Variants: []model.SplitVariant{
+				{Name: "b", URL: "https://example.com/a", Weight: 1},
+				{URL: "https://example.com/b", Weight: 1},
+			}},
+			wantErr: ErrInvalidSplit,
+		},
+		{
+			name: "rejects malformed name",
+			split: model.Split{ShortID: "own", Variants: []model.SplitVariant{
+				{Name: "new landing", URL: "https://example.com/a", Weight: 1},
+				{URL: "https://example.com/b", Weight: 1},
+			}},
+			wantErr: ErrInvalidSplit,
+		},
+		{
+			name: "rejects empty url",
+			split: model.Split{ShortID: "own", Variants: []model.SplitVariant{
+				{URL: "https://example.com/a", Weight: 1},
+				{Weight: 1},
+			}},
+			wantErr: ErrInvalidURL,
+		},
+		{
+			name: "rejects blocked url",
+			split: model.Split{ShortID: "own", Variants: []model.SplitVariant{
+				{URL: "https://example.com/a", Weight: 1},
+				{URL: "https://evil.com", Weight: 1},
+			}},
+			wantErrAs: new(*BlockedURLError),
+		},
+		{
+			name: "rejects link of another user",
+			split: model.Split{ShortID: "alien", Variants: []model.SplitVariant{
+				{URL: "https://example.com/a", Weight: 1},
+				{URL: "https://example.com/b", Weight: 1},
+			}},
+			wantErrAs: new(*repo.DataNotFoundError),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.SetSplit(t.Context(), "userUUID", tt.split)
+			if tt.wantErr != nil {
+				var vErr *ValidationError
+				require.ErrorAs(t, err, &vErr)
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			if tt.wantErrAs != nil {
+				require.ErrorAs(t, err, tt.wantErrAs)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+
+			stored, err := s.GetSplit(t.Context(), "userUUID", tt.split.ShortID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, stored)
+		})
+	}
+}
+
+func TestSplitter_Pick(t *testing.T) {
+	storage := repo.NewMemorySplitStorage(zap.NewNop())
+	variants := []model.SplitVariant{
+		{Name: "a", URL: "https://example.com/a", Weight: 70},
+		{Name: "b", URL: "https://example.com/b", Weight: 20},
+		{Name: "c", URL: "https://evil.com/c", Weight: 10},
+	}
+	require.NoError(t, storage.Set(t.Context(), model.Split{ShortID: "sticky", Sticky: true, Variants: variants}))
+	require.NoError(t, storage.Set(t.Context(), model.Split{ShortID: "plain", Variants: variants}))
+	s := NewSplitter(storage, repo.NewMemoryURLStorage(zap.NewNop()), &destCheckerStub{blocked: "evil"}, zap.NewNop())
+
+	tests := []struct {
+		name    string
+		record  model.URLStorageRecord
+		variant string
+		roll    int
+		path    string
+		want    *model.SplitChoice
+		wantErr bool
+	}{
+		{
+			name:   "first variant by weight",
+			record: model.URLStorageRecord{ShortID: "plain"},
+			roll:   69,
+			want:   &model.SplitChoice{Variant: "a", URL: "https://example.com/a"},
+		},
+		{
+			name:   "second variant by weight",
+			record: model.URLStorageRecord{ShortID: "plain"},
+			roll:   70,
+			want:   &model.SplitChoice{Variant: "b", URL: "https://example.com/b"},
+		},
+		{
+			name:    "assigned variant is ignored by non-sticky split test",
+			record:  model.URLStorageRecord{ShortID: "plain"},
+			variant: "b",
+			roll:    0,
+			want:    &model.SplitChoice{Variant: "a", URL: "https://example.com/a"},
+		},
+		{
+			name:    "assigned variant is kept by sticky split test",
+			record:  model.URLStorageRecord{ShortID: "sticky"},
+			variant: "b",
+			roll:    0,
+			want:    &model.SplitChoice{Variant: "b", URL: "https://example.com/b", Sticky: true},
+		},
+		{
+			name:    "unknown assigned variant is picked again",
+			record:  model.URLStorageRecord{ShortID: "sticky"},
+			variant: "removed",
+			roll:    80,
+			want:    &model.SplitChoice{Variant: "b", URL: "https://example.com/b", Sticky: true},
+		},
+		{
+			name:   "passthrough is forwarded to variant url",
+			record: model.URLStorageRecord{ShortID: "plain", Passthrough: true},
+			path:   "/pricing",
+			roll:   0,
+			want:   &model.SplitChoice{Variant: "a", URL: "https://example.com/a/pricing"},
+		},
+		{
+			name:   "link without split test has no variant",
+			record: model.URLStorageRecord{ShortID: "none"},
+		},
+		{
+			name:    "blocked variant url is an error",
+			record:  model.URLStorageRecord{ShortID: "plain"},
+			roll:    95,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s.intN = func(n int) int {
+				require.Equal(t, 100, n)
+				return tt.roll
+			}
+			req := model.ExpandRequest{ShortID: tt.record.ShortID, Path: tt.path, Variant: tt.variant}
+			got, err := s.Pick(t.Context(), &tt.record, req)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrURLBlocked)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Limits of link tags.
+const (
+	maxTags   = 20 // Maximum amount of tags of a single link
+	maxTagLen = 64 // Maximum length of a tag in characters
+)
+
+// tagPattern matches allowed tags: letters, digits, `_`, `-`, `.` and `:`.
+var tagPattern = regexp.MustCompile(`^[\p{L}\p{N}_.:-]+$`)
+
+// NormalizeTags brings tags to canonical form: trimmed, lowercased, unique and sorted.
+//
+// Parameters:
+//   - tags: tags requested by the user
+//
+// Returns:
+//   - []string: normalized tags, nil if there are none
+//   - error: nil if tags are valid, or *ValidationError wrapping ErrInvalidTags with the reason
+func NormalizeTags(tags []string) ([]string, error) {
+	if len(tags) == 0 {
+		return nil, nil
+	}
+	res := make([]string, 0, len(tags))
+	for _, t := range tags {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if t == "" {
+			return nil, NewValidationError(ErrInvalidTags, "tags can't be empty")
+		}
+		if len([]rune(t)) > maxTagLen {
+			return nil, NewValidationError(ErrInvalidTags, fmt.Sprintf("tag longer than %d characters", maxTagLen))
+		}
+		if !tagPattern.MatchString(t) {
+			return nil, NewValidationError(ErrInvalidTags, fmt.Sprintf(
+				"tag `%s` may only contain letters, digits, `_`, `-`, `.` and `:`", t,
+			))
+		}
+		res = append(res, t)
+	}
+	slices.Sort(res)
+	res = slices.Compact(res)
+	if len(res) > maxTags {
+		return nil, NewValidationError(ErrInvalidTags, fmt.Sprintf("more than %d tags", maxTags))
+	}
+	return res, nil
+}
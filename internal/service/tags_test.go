@@ -0,0 +1,57 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func manyTags(n int) []string {
+	tags := make([]string, n)
+	for i := range tags {
+		tags[i] = fmt.Sprintf("tag%d", i)
+	}
+	return tags
+}
+
+func manyTagsSorted(n int) []string {
+	tags := manyTags(n)
+	slices.Sort(tags)
+	return tags
+}
+
+func TestNormalizeTags(t *testing.T) {
+	tests := []struct {
+		name    string
+		tags    []string
+		want    []string
+		wantErr bool
+	}{
+		{name: "no tags", tags: nil, want: nil},
+		{name: "sorted, lowercased and unique", tags: []string{"Q1", " promo", "q1", "email:spring"}, want: []string{"email:spring", "promo", "q1"}},
+		{name: "unicode letters", tags: []string{"Весна-2025"}, want: []string{"весна-2025"}},
+		{name: "empty tag", tags: []string{"promo", " "}, wantErr: true},
+		{name: "comma", tags: []string{"a,b"}, wantErr: true},
+		{name: "space inside", tags: []string{"spring sale"}, wantErr: true},
+		{name: "too long", tags: []string{strings.Repeat("a", maxTagLen+1)}, wantErr: true},
+		{name: "too many", tags: manyTags(maxTags + 1), wantErr: true},
+		{name: "duplicates don't count", tags: append(manyTags(maxTags), "tag0"), want: manyTagsSorted(maxTags)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeTags(tt.tags)
+			if tt.wantErr {
+				var vErr *ValidationError
+				require.ErrorAs(t, err, &vErr)
+				assert.ErrorIs(t, err, ErrInvalidTags)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
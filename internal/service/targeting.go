@@ -0,0 +1,283 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/alex-storchak/shortener/internal/model"
+	repo "github.com/alex-storchak/shortener/internal/repository"
+)
+
+// Limits of targeting rules.
+const (
+	maxTargetingRules        = 20  // Maximum amount of rules of a single link
+	maxTargetingHeaderLen    = 64  // Maximum length of a header name in characters
+	maxTargetingHeaderValLen = 256 // Maximum length of a header value in characters
+	maxTargetingLanguageLen  = 35  // Maximum length of a language tag in characters
+)
+
+var (
+	// targetingLanguagePattern matches language tags in lower case, e.g. `de` or `pt-br`.
+	targetingLanguagePattern = regexp.MustCompile(`^[a-z]{2,3}(-[a-z0-9]{1,8})*$`)
+
+	// targetingHeaderPattern matches allowed header names: latin letters, digits and `-`.
+	targetingHeaderPattern = regexp.MustCompile(`^[A-Za-z0-9-]+$`)
+
+	// targetingBrowsers are User-Agent families allowed in targeting rules.
+	targetingBrowsers = []string{
+		BrowserChrome, BrowserEdge, BrowserFirefox, BrowserOpera, BrowserSafari, BrowserSamsung, BrowserBot,
+	}
+
+	// targetingOSes are operating systems allowed in targeting rules.
+	targetingOSes = []string{OSAndroid, OSIOS, OSWindows, OSMacOS, OSLinux, OSChromeOS}
+)
+
+// DestinationChecker defines the interface for checking destination URLs before redirecting to them.
+type DestinationChecker interface {
+	CheckDestination(url string) (string, error)
+}
+
+// Targeting manages targeting rules of links and picks the destination of a follow by them.
+// Rules of a link are evaluated in order on every follow: the first rule whose conditions
+// all match the request sends the client to its URL, and clients matching no rule
+// are sent to the split test variants or the original URL of the link.
+type Targeting struct {
+	storage repo.TargetingRuleStorage
+	urls    ShortIDLookup
+	dest    DestinationChecker
+	logger  *zap.Logger
+}
+
+// NewTargeting creates a new instance of Targeting.
+//
+// Parameters:
+//   - storage: storage of targeting rules
+//   - urls: URL storage used to check that links belong to the users managing their rules
+//   - dest: checker of rule destinations against the URL validation settings and the blocklist
+//   - logger: structured logger for logging operations
+//
+// Returns:
+//   - *Targeting: configured targeting service
+func NewTargeting(
+	storage repo.TargetingRuleStorage,
+	urls ShortIDLookup,
+	dest DestinationChecker,
+	logger *zap.Logger,
+) *Targeting {
+	return &Targeting{
+		storage: storage,
+		urls:    urls,
+		dest:    dest,
+		logger:  logger,
+	}
+}
+
+// GetRules retrieves the targeting rules of a link owned by the user.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - userUUID: UUID of the user
+//   - shortID: short identifier of the link
+//
+// Returns:
+//   - []model.TargetingRule: rules in evaluation order; empty if the link has no rules
+//   - error: nil on success, or storage error if URL is not found among user's URLs,
+//     deleted, expired or has no follows left
+func (s *Targeting) GetRules(ctx context.Context, userUUID, shortID string) ([]model.TargetingRule, error) {
+	if err := s.checkOwner(ctx, userUUID, shortID); err != nil {
+		return nil, err
+	}
+	rules, err := s.storage.Get(ctx, shortID)
+	if err != nil {
+		return nil, fmt.Errorf("get targeting rules from storage: %w", err)
+	}
+	return rules, nil
+}
+
+// SetRules validates and stores the targeting rules of a link owned by the user, replacing its previous rules.
+// An empty list removes the rules. Conditions are normalized and destinations are canonicalized
+// and checked the same way as original URLs.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - userUUID: UUID of the user
+//   - shortID: short identifier of the link
+//   - rules: rules in evaluation order
+//
+// Returns:
+//   - []model.TargetingRule: stored rules
+//   - error: nil on success, or storage error if URL is not found among user's URLs,
+//     deleted, expired or has no follows left
+//
+// Errors:
+//   - ErrInvalidTargetingRule: when rules are too many or a rule has no conditions or malformed ones
+//   - ErrInvalidURL: when the URL of a rule is empty or invalid
+//   - ErrURLBlocked: when the URL of a rule matches the blocklist; returned as *BlockedURLError
+func (s *Targeting) SetRules(
+	ctx context.Context,
+	userUUID, shortID string,
+	rules []model.TargetingRule,
+) ([]model.TargetingRule, error) {
+	if len(rules) > maxTargetingRules {
+		return nil, NewValidationError(ErrInvalidTargetingRule, fmt.Sprintf("more than %d rules", maxTargetingRules))
+	}
+	normalized := make([]model.TargetingRule, len(rules))
+	for i, r := range rules {
+		n, err := s.normalizeRule(r)
+		var vErr *ValidationError
+		if errors.As(err, &vErr) {
+			return nil, NewValidationError(vErr.Err, fmt.Sprintf("rule %d: %s", i+1, vErr.Reason))
+		} else if err != nil {
+			return nil, fmt.Errorf("check rule %d: %w", i+1, err)
+		}
+		normalized[i] = n
+	}
+	if err := s.checkOwner(ctx, userUUID, shortID); err != nil {
+		return nil, err
+	}
+
+	if err := s.storage.Set(ctx, shortID, normalized); err != nil {
+		return nil, fmt.Errorf("set targeting rules in storage: %w", err)
+	}
+	return normalized, nil
+}
+
+// Target returns the destination of the first targeting rule of the link matching the request.
+// The path suffix and query of the request are forwarded to it for links with passthrough enabled.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - r: followed link
+//   - req: expand request with the headers of the client
+//
+// Returns:
+//   - string: URL of the matching rule; empty if no rule matches
+//   - bool: true if the link has rules, so its destination depends on the client
+//   - error: nil on success, or error if rules can't be retrieved or the URL of the matching rule
+//     can't be redirected to anymore, e.g. it was blocked after the rule was stored
+func (s *Targeting) Target(
+	ctx context.Context,
+	r *model.URLStorageRecord,
+	req model.ExpandRequest,
+) (string, bool, error) {
+	rules, err := s.storage.Get(ctx, r.ShortID)
+	if err != nil {
+		return "", false, fmt.Errorf("get targeting rules of url from storage: %w", err)
+	}
+	if len(rules) == 0 {
+		return "", false, nil
+	}
+
+	c := newClient(req.Header)
+	for i, rule := range rules {
+		if !c.matches(rule) {
+			continue
+		}
+		dest := rule.URL
+		if r.Passthrough {
+			if dest, err = joinPassthrough(rule.URL, req.Path, req.RawQuery); err != nil {
+				return "", true, fmt.Errorf("forward passthrough to url of rule %d: %w", i+1, err)
+			}
+		}
+		if _, err := s.dest.CheckDestination(dest); err != nil {
+			return "", true, fmt.Errorf("check url of rule %d: %w", i+1, err)
+		}
+		return dest, true, nil
+	}
+	return "", true, nil
+}
+
+// checkOwner checks that the link exists and belongs to the user.
+func (s *Targeting) checkOwner(ctx context.Context, userUUID, shortID string) error {
+	r, err := s.urls.Get(ctx, shortID, repo.ShortURLType)
+	if err != nil {
+		return fmt.Errorf("get url `%s` of targeting rules: %w", shortID, err)
+	}
+	if r.UserUUID != userUUID {
+		return fmt.Errorf("get url `%s` of targeting rules: %w", shortID, repo.NewDataNotFoundError(nil))
+	}
+	return nil
+}
+
+// normalizeRule brings conditions of the rule to canonical form, validates them and checks its URL.
+func (s *Targeting) normalizeRule(r model.TargetingRule) (model.TargetingRule, error) {
+	r.Browser = strings.ToLower(strings.TrimSpace(r.Browser))
+	r.OS = strings.ToLower(strings.TrimSpace(r.OS))
+	r.Language = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(r.Language), "_", "-"))
+	r.Header = http.CanonicalHeaderKey(strings.TrimSpace(r.Header))
+	r.HeaderValue = strings.TrimSpace(r.HeaderValue)
+
+	if r.Browser == "" && r.OS == "" && r.Language == "" && r.Header == "" {
+		return r, NewValidationError(ErrInvalidTargetingRule, "at least one of browser, os, language and header must be set")
+	}
+	if r.Browser != "" && !slices.Contains(targetingBrowsers, r.Browser) {
+		return r, NewValidationError(ErrInvalidTargetingRule, fmt.Sprintf(
+			"browser `%s` is not one of %s", r.Browser, strings.Join(targetingBrowsers, ", "),
+		))
+	}
+	if r.OS != "" && !slices.Contains(targetingOSes, r.OS) {
+		return r, NewValidationError(ErrInvalidTargetingRule, fmt.Sprintf(
+			"os `%s` is not one of %s", r.OS, strings.Join(targetingOSes, ", "),
+		))
+	}
+	if r.Language != "" && (len(r.Language) > maxTargetingLanguageLen || !targetingLanguagePattern.MatchString(r.Language)) {
+		return r, NewValidationError(ErrInvalidTargetingRule, fmt.Sprintf(
+			"language `%s` must be a language tag like `de` or `pt-br`", r.Language,
+		))
+	}
+	if r.Header != "" && (len(r.Header) > maxTargetingHeaderLen || !targetingHeaderPattern.MatchString(r.Header)) {
+		return r, NewValidationError(ErrInvalidTargetingRule, fmt.Sprintf(
+			"header `%s` must be up to %d latin letters, digits and `-`", r.Header, maxTargetingHeaderLen,
+		))
+	}
+	if r.HeaderValue != "" && r.Header == "" {
+		return r, NewValidationError(ErrInvalidTargetingRule, "header_value requires header")
+	}
+	if len([]rune(r.HeaderValue)) > maxTargetingHeaderValLen {
+		return r, NewValidationError(ErrInvalidTargetingRule, fmt.Sprintf(
+			"header_value must be up to %d characters", maxTargetingHeaderValLen,
+		))
+	}
+
+	url, err := s.dest.CheckDestination(strings.TrimSpace(r.URL))
+	if errors.Is(err, ErrEmptyInputURL) {
+		return r, NewValidationError(ErrInvalidURL, "url can't be empty")
+	} else if err != nil {
+		return r, err
+	}
+	r.URL = url
+	return r, nil
+}
+
+// matches reports whether all conditions of the rule match the client.
+func (c client) matches(r model.TargetingRule) bool {
+	if r.Browser != "" && r.Browser != c.browser {
+		return false
+	}
+	if r.OS != "" && r.OS != c.os {
+		return false
+	}
+	if r.Language != "" && c.language != r.Language &&
+		!strings.HasPrefix(c.language, r.Language+"-") {
+		return false
+	}
+	if r.Header != "" {
+		values := c.header.Values(r.Header)
+		if len(values) == 0 {
+			return false
+		}
+		if r.HeaderValue != "" && !slices.ContainsFunc(values, func(v string) bool {
+			return strings.EqualFold(strings.TrimSpace(v), r.HeaderValue)
+		}) {
+			return false
+		}
+	}
+	return true
+}
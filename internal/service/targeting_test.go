@@ -0,0 +1,311 @@
+package service
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/alex-storchak/shortener/internal/model"
+	repo "github.com/alex-storchak/shortener/internal/repository"
+)
+
+const (
+	uaIPhoneSafari  = "Mozilla/5.0 (iPhone; CPU iPhone OS 17_4 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.4 Mobile/15E148 Safari/604.1"
+	uaAndroidChrome = "Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Mobile Safari/537.36"
+	uaWindowsEdge   = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36 Edg/124.0.0.0"
+	uaMacFirefox    = "Mozilla/5.0 (Macintosh; Intel Mac OS X 14.4; rv:125.0) Gecko/20100101 Firefox/125.0"
+	uaGooglebot     = "Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)"
+)
+
+// destCheckerStub accepts every destination except the ones containing blocked.
+type destCheckerStub struct {
+	blocked string
+}
+
+func (s *destCheckerStub) CheckDestination(url string) (string, error) {
+	if url == "" {
+		return "", ErrEmptyInputURL
+	}
+	if !strings.HasPrefix(url, "http") {
+		return "", NewValidationError(ErrInvalidURL, "absolute url with a host is required")
+	}
+	if s.blocked != "" && strings.Contains(url, s.blocked) {
+		return "", &BlockedURLError{OrigURL: url, Rule: s.blocked}
+	}
+	return url, nil
+}
+
+func TestNewClient(t *testing.T) {
+	tests := []struct {
+		name           string
+		userAgent      string
+		acceptLanguage string
+		wantBrowser    string
+		wantOS         string
+		wantLanguage   string
+	}{
+		{
+			name:           "iphone safari",
+			userAgent:      uaIPhoneSafari,
+			acceptLanguage: "de-DE,de;q=0.9,en;q=0.8",
+			wantBrowser:    BrowserSafari,
+			wantOS:         OSIOS,
+			wantLanguage:   "de-de",
+		},
+		{
+			name:           "android chrome with weighted languages",
+			userAgent:      uaAndroidChrome,
+			acceptLanguage: "en;q=0.5, pt-BR;q=0.9, *",
+			wantBrowser:    BrowserChrome,
+			wantOS:         OSAndroid,
+			wantLanguage:   "pt-br",
+		},
+		{
+			name:        "windows edge",
+			userAgent:   uaWindowsEdge,
+			wantBrowser: BrowserEdge,
+			wantOS:      OSWindows,
+		},
+		{
+			name:           "macos firefox skips rejected languages",
+			userAgent:      uaMacFirefox,
+			acceptLanguage: "fr;q=0, es",
+			wantBrowser:    BrowserFirefox,
+			wantOS:         OSMacOS,
+			wantLanguage:   "es",
+		},
+		{
+			name:        "crawler",
+			userAgent:   uaGooglebot,
+			wantBrowser: BrowserBot,
+		},
+		{
+			name: "no headers",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := http.Header{}
+			h.Set("User-Agent", tt.userAgent)
+			h.Set("Accept-Language", tt.acceptLanguage)
+
+			c := newClient(h)
+			assert.Equal(t, tt.wantBrowser, c.browser)
+			assert.Equal(t, tt.wantOS, c.os)
+			assert.Equal(t, tt.wantLanguage, c.language)
+		})
+	}
+}
+
+func TestTargeting_SetRules(t *testing.T) {
+	urls := repo.NewMemoryURLStorage(zap.NewNop())
+	require.NoError(t, urls.Set(t.Context(), model.URLStorageRecord{OrigURL: "http://a.com", ShortID: "own", UserUUID: "userUUID"}))
+	require.NoError(t, urls.Set(t.Context(), model.URLStorageRecord{OrigURL: "http://b.com", ShortID: "alien", UserUUID: "otherUUID"}))
+	s := NewTargeting(repo.NewMemoryTargetingRuleStorage(zap.NewNop()), urls, &destCheckerStub{blocked: "evil"}, zap.NewNop())
+
+	tests := []struct {
+		name      string
+		shortID   string
+		rules     []model.TargetingRule
+		want      []model.TargetingRule
+		wantErr   error
+		wantErrAs any
+	}{
+		{
+			name:    "normalizes conditions",
+			shortID: "own",
+			rules: []model.TargetingRule{
+				{OS: " iOS ", Language: "pt_BR", URL: "https://apps.apple.com/app"},
+				{Browser: "Chrome", Header: "x-platform", HeaderValue: " tv ", URL: "https://tv.example.com"},
+			},
+			want: []model.TargetingRule{
+				{OS: "ios", Language: "pt-br", URL: "https://apps.apple.com/app"},
+				{Browser: "chrome", Header: "X-Platform", HeaderValue: "tv", URL: "https://tv.example.com"},
+			},
+		},
+		{
+			name:    "empty list removes rules",
+			shortID: "own",
+			want:    []model.TargetingRule{},
+		},
+		{
+			name:    "rejects rule without conditions",
+			shortID: "own",
+			rules:   []model.TargetingRule{{URL: "https://a.com"}},
+			wantErr: ErrInvalidTargetingRule,
+		},
+		{
+			name:    "rejects unknown browser",
+			shortID: "own",
+			rules:   []model.TargetingRule{{Browser: "netscape", URL: "https://a.com"}},
+			wantErr: ErrInvalidTargetingRule,
+		},
+		{
+			name:    "rejects unknown os",
+			shortID: "own",
+			rules:   []model.TargetingRule{{OS: "symbian", URL: "https://a.com"}},
+			wantErr: ErrInvalidTargetingRule,
+		},
+		{
+			name:    "rejects malformed language",
+			shortID: "own",
+			rules:   []model.TargetingRule{{Language: "german", URL: "https://a.com"}},
+			wantErr: ErrInvalidTargetingRule,
+		},
+		{
+			name:    "rejects malformed header",
+			shortID: "own",
+			rules:   []model.TargetingRule{{Header: "X Platform", URL: "https://a.com"}},
+			wantErr: ErrInvalidTargetingRule,
+		},
+		{
+			name:    "rejects header value without header",
+			shortID: "own",
+			rules:   []model.TargetingRule{{OS: "ios", HeaderValue: "tv", URL: "https://a.com"}},
+			wantErr: ErrInvalidTargetingRule,
+		},
+		{
+			name:    "rejects empty url",
+			shortID: "own",
+			rules:   []model.TargetingRule{{OS: "ios"}},
+			wantErr: ErrInvalidURL,
+		},
+		{
+			name:    "rejects invalid url",
+			shortID: "own",
+			rules:   []model.TargetingRule{{OS: "ios", URL: "apps.apple.com"}},
+			wantErr: ErrInvalidURL,
+		},
+		{
+			name:      "rejects blocked url",
+			shortID:   "own",
+			rules:     []model.TargetingRule{{OS: "ios", URL: "https://evil.com"}},
+			wantErrAs: new(*BlockedURLError),
+		},
+		{
+			name:      "rejects link of another user",
+			shortID:   "alien",
+			rules:     []model.TargetingRule{{OS: "ios", URL: "https://a.com"}},
+			wantErrAs: new(*repo.DataNotFoundError),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.SetRules(t.Context(), "userUUID", tt.shortID, tt.rules)
+			if tt.wantErr != nil {
+				var vErr *ValidationError
+				require.ErrorAs(t, err, &vErr)
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			if tt.wantErrAs != nil {
+				require.ErrorAs(t, err, tt.wantErrAs)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+
+			stored, err := s.GetRules(t.Context(), "userUUID", tt.shortID)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tt.want, stored)
+		})
+	}
+}
+
+func TestTargeting_Target(t *testing.T) {
+	storage := repo.NewMemoryTargetingRuleStorage(zap.NewNop())
+	require.NoError(t, storage.Set(t.Context(), "app", []model.TargetingRule{
+		{OS: OSIOS, Language: "de", URL: "https://apps.apple.com/de/app"},
+		{OS: OSIOS, URL: "https://apps.apple.com/app"},
+		{OS: OSAndroid, URL: "https://play.google.com/app"},
+		{Header: "X-Platform", HeaderValue: "tv", URL: "https://tv.example.com"},
+		{Browser: BrowserBot, URL: "https://evil.com"},
+	}))
+	s := NewTargeting(storage, repo.NewMemoryURLStorage(zap.NewNop()), &destCheckerStub{blocked: "evil"}, zap.NewNop())
+
+	tests := []struct {
+		name         string
+		record       model.URLStorageRecord
+		header       http.Header
+		path         string
+		want         string
+		wantTargeted bool
+		wantErr      bool
+	}{
+		{
+			name:         "first matching rule wins",
+			record:       model.URLStorageRecord{OrigURL: "https://example.com", ShortID: "app"},
+			header:       http.Header{"User-Agent": {uaIPhoneSafari}, "Accept-Language": {"de-AT,en;q=0.5"}},
+			wantTargeted: true,
+			want:         "https://apps.apple.com/de/app",
+		},
+		{
+			name:         "language of another client falls to next rule",
+			record:       model.URLStorageRecord{OrigURL: "https://example.com", ShortID: "app"},
+			header:       http.Header{"User-Agent": {uaIPhoneSafari}, "Accept-Language": {"en-US,de;q=0.5"}},
+			wantTargeted: true,
+			want:         "https://apps.apple.com/app",
+		},
+		{
+			name:         "android",
+			record:       model.URLStorageRecord{OrigURL: "https://example.com", ShortID: "app"},
+			header:       http.Header{"User-Agent": {uaAndroidChrome}},
+			wantTargeted: true,
+			want:         "https://play.google.com/app",
+		},
+		{
+			name:         "header value is compared case-insensitively",
+			record:       model.URLStorageRecord{OrigURL: "https://example.com", ShortID: "app"},
+			header:       http.Header{"User-Agent": {uaWindowsEdge}, "X-Platform": {"TV"}},
+			wantTargeted: true,
+			want:         "https://tv.example.com",
+		},
+		{
+			name:         "no matching rule returns no destination",
+			record:       model.URLStorageRecord{OrigURL: "https://example.com", ShortID: "app"},
+			header:       http.Header{"User-Agent": {uaWindowsEdge}},
+			wantTargeted: true,
+		},
+		{
+			name:         "no headers returns no destination",
+			record:       model.URLStorageRecord{OrigURL: "https://example.com", ShortID: "app"},
+			wantTargeted: true,
+		},
+		{
+			name:         "passthrough is forwarded to rule url",
+			record:       model.URLStorageRecord{OrigURL: "https://example.com/x", ShortID: "app", Passthrough: true},
+			header:       http.Header{"User-Agent": {uaAndroidChrome}},
+			path:         "/details",
+			wantTargeted: true,
+			want:         "https://play.google.com/app/details",
+		},
+		{
+			name:   "link without rules returns no destination",
+			record: model.URLStorageRecord{OrigURL: "https://example.com", ShortID: "plain"},
+			header: http.Header{"User-Agent": {uaIPhoneSafari}},
+		},
+		{
+			name:    "blocked rule url is an error",
+			record:  model.URLStorageRecord{OrigURL: "https://example.com", ShortID: "app"},
+			header:  http.Header{"User-Agent": {uaGooglebot}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := model.ExpandRequest{ShortID: tt.record.ShortID, Path: tt.path, Header: tt.header}
+			got, targeted, err := s.Target(t.Context(), &tt.record, req)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrURLBlocked)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantTargeted, targeted)
+		})
+	}
+}
@@ -0,0 +1,506 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/alex-storchak/shortener/internal/model"
+	repo "github.com/alex-storchak/shortener/internal/repository"
+)
+
+// Formats of bulk import inputs.
+const (
+	ImportFormatCSV    = "csv"    // CSV with a header row naming the columns
+	ImportFormatNDJSON = "ndjson" // One JSON object per line, with the fields of a batch shortening item
+)
+
+// importChunkSize is the amount of rows stored with a single batch operation during a bulk import.
+const importChunkSize = 500
+
+// maxImportLineSize is the maximum length in bytes of a single line of an NDJSON import.
+const maxImportLineSize = 64 * 1024
+
+// BatchImporter defines the interface for storing a chunk of rows of a bulk import.
+type BatchImporter interface {
+	ImportBatch(ctx context.Context, userUUID string, rows []model.URLImportRow) ([]model.URLImportResult, error)
+}
+
+// URLImporter streams bulk imports of links in CSV or NDJSON format.
+// Rows are read one by one and stored in chunks, so inputs of any size are imported
+// without holding them in memory, and the outcome of every chunk is reported as soon as it is stored.
+type URLImporter struct {
+	importer  BatchImporter
+	chunkSize int
+	logger    *zap.Logger
+}
+
+// NewURLImporter creates a new URLImporter instance.
+//
+// Parameters:
+//   - importer: service storing chunks of rows, usually the Shortener
+//   - logger: structured logger for logging operations
+//
+// Returns:
+//   - *URLImporter: configured URLImporter instance
+func NewURLImporter(importer BatchImporter, logger *zap.Logger) *URLImporter {
+	return &URLImporter{
+		importer:  importer,
+		chunkSize: importChunkSize,
+		logger:    logger,
+	}
+}
+
+// Import reads rows of the input and stores them in chunks on behalf of the user.
+// Malformed and rejected rows don't stop the import; they are reported as failed.
+//
+// CSV inputs start with a header row. The original_url column is required; correlation_id, alias, id_style,
+// expires_at (RFC 3339), ttl (seconds), max_clicks, password, tags (comma-separated), redirect_type
+// and passthrough are optional, and other columns are ignored, so exported collections can be imported back.
+// NDJSON inputs contain one object per line with the fields of a batch shortening item.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - userUUID: unique identifier of the user importing the links
+//   - format: ImportFormatCSV or ImportFormatNDJSON
+//   - r: input to read rows from
+//   - emit: callback receiving the outcome of every chunk, in input order; its error stops the import
+//
+// Returns:
+//   - error: nil on success, or service error if the input can't be read or the import fails
+//
+// Errors:
+//   - ErrInvalidImport: when the format is unknown, the CSV header is malformed or an NDJSON line is too long
+//   - ErrEmptyInputBatch: when the input has no rows
+func (i *URLImporter) Import(
+	ctx context.Context,
+	userUUID, format string,
+	r io.Reader,
+	emit func([]model.URLImportResult) error,
+) error {
+	rows, err := newImportRowReader(format, r)
+	if err != nil {
+		return err
+	}
+
+	chunk := make([]model.URLImportRow, 0, i.chunkSize)
+	imported := 0
+	for {
+		row, err := rows.next()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return fmt.Errorf("read import row: %w", err)
+		}
+		chunk = append(chunk, row)
+		if len(chunk) < i.chunkSize {
+			continue
+		}
+		if err := i.importChunk(ctx, userUUID, chunk, emit); err != nil {
+			return err
+		}
+		imported += len(chunk)
+		chunk = chunk[:0]
+	}
+	if len(chunk) == 0 && imported == 0 {
+		return ErrEmptyInputBatch
+	}
+	if len(chunk) > 0 {
+		if err := i.importChunk(ctx, userUUID, chunk, emit); err != nil {
+			return err
+		}
+		imported += len(chunk)
+	}
+	i.logger.Debug("links imported", zap.String("user_uuid", userUUID), zap.Int("rows", imported))
+	return nil
+}
+
+// importChunk stores a chunk of rows and reports its outcome.
+func (i *URLImporter) importChunk(
+	ctx context.Context,
+	userUUID string,
+	chunk []model.URLImportRow,
+	emit func([]model.URLImportResult) error,
+) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("import chunk: %w", err)
+	}
+	results, err := i.importer.ImportBatch(ctx, userUUID, chunk)
+	if err != nil {
+		return fmt.Errorf("import chunk: %w", err)
+	}
+	if err := emit(results); err != nil {
+		return fmt.Errorf("emit import results: %w", err)
+	}
+	return nil
+}
+
+// ImportBatch stores a chunk of rows of a bulk import on behalf of the user.
+// Unlike ShortenBatch, rows are validated and stored independently: a rejected row doesn't fail the chunk.
+// Only plain links are deduplicated, the same way as in Shorten: their existing original URLs are looked up
+// with a single storage operation and reported with their short IDs, like repeated original URLs within the chunk;
+// rows requesting an alias for such URLs fail with ErrAliasURLExists. New links are stored with a single batch operation;
+// if any of their short IDs is taken, they are stored one by one, so only the rows with taken aliases fail.
+//
+// Parameters:
+//   - ctx: context for request cancellation and timeouts
+//   - userUUID: unique identifier of the user importing the links
+//   - rows: rows of the chunk
+//
+// Returns:
+//   - []model.URLImportResult: outcome for every row, in the same order
+//   - error: nil on success, or storage error if operation fails
+func (s *Shortener) ImportBatch(
+	ctx context.Context,
+	userUUID string,
+	rows []model.URLImportRow,
+) ([]model.URLImportResult, error) {
+	results := make([]model.URLImportResult, len(rows))
+	records := make([]model.URLStorageRecord, len(rows))
+	valid := make([]int, 0, len(rows))
+	origURLs := make([]string, 0, len(rows))
+	now := time.Now()
+
+	for i, row := range rows {
+		results[i] = model.URLImportResult{Row: row.Row, CorrelationID: row.CorrelationID, OrigURL: row.URL.OrigURL}
+		if row.Err != nil {
+			failImportRow(&results[i], row.Err)
+			continue
+		}
+		record, err := s.prepareBatchItem(userUUID, row.URL, now)
+		if err != nil {
+			failImportRow(&results[i], err)
+			continue
+		}
+		records[i] = record
+		results[i].OrigURL = record.OrigURL
+		valid = append(valid, i)
+		if record.IsPlain() {
+			origURLs = append(origURLs, record.OrigURL)
+		}
+	}
+
+	existing, err := s.urlStorage.GetByOrigURLs(ctx, origURLs)
+	if err != nil {
+		return nil, fmt.Errorf("retrieve urls from storage: %w", err)
+	}
+
+	toPersist := make([]int, 0, len(valid))
+	creators := make(map[string]int)
+	repeats := make(map[int]int)
+	aliases := make(map[string]struct{})
+	for _, i := range valid {
+		opts := rows[i].URL.Opts
+		plain := records[i].IsPlain()
+		r, exists := existing[records[i].OrigURL]
+		j, repeated := creators[records[i].OrigURL]
+		exists, repeated = exists && plain, repeated && plain
+		if (exists || repeated) && opts.Alias != "" {
+			failImportRow(&results[i], ErrAliasURLExists)
+			continue
+		}
+		if exists {
+			results[i].ShortID = r.ShortID
+			results[i].Status = model.URLImportStatusExists
+			continue
+		}
+		if repeated {
+			repeats[i] = j
+			continue
+		}
+		if opts.Alias != "" {
+			if _, taken := aliases[opts.Alias]; taken {
+				failImportRow(&results[i], ErrAliasTaken)
+				continue
+			}
+			aliases[opts.Alias] = struct{}{}
+		}
+		if err := s.completeBatchItem(ctx, &records[i], opts); err != nil {
+			var vErr *ValidationError
+			if !errors.As(err, &vErr) {
+				return nil, fmt.Errorf("complete url bind to persist item: %w", err)
+			}
+			failImportRow(&results[i], err)
+			continue
+		}
+		if plain {
+			creators[records[i].OrigURL] = i
+		}
+		toPersist = append(toPersist, i)
+	}
+
+	if err := s.storeImport(ctx, rows, records, toPersist, results); err != nil {
+		return nil, err
+	}
+	for i, j := range repeats {
+		if results[j].Status == model.URLImportStatusCreated {
+			results[i].ShortID = results[j].ShortID
+			results[i].Status = model.URLImportStatusExists
+		} else {
+			failImportRow(&results[i], results[j].Err)
+		}
+	}
+	return results, nil
+}
+
+// storeImport stores new records of an import chunk with a single batch operation,
+// falling back to storing them one by one when any of their short IDs is taken.
+func (s *Shortener) storeImport(
+	ctx context.Context,
+	rows []model.URLImportRow,
+	records []model.URLStorageRecord,
+	toPersist []int,
+	results []model.URLImportResult,
+) error {
+	if len(toPersist) == 0 {
+		return nil
+	}
+	batch := make([]model.URLStorageRecord, len(toPersist))
+	for k, i := range toPersist {
+		batch[k] = records[i]
+	}
+	err := s.urlStorage.BatchSet(ctx, batch)
+	if err == nil {
+		for _, i := range toPersist {
+			results[i].ShortID = records[i].ShortID
+			results[i].Status = model.URLImportStatusCreated
+		}
+		return nil
+	}
+	if !errors.Is(err, repo.ErrShortIDConflict) {
+		return fmt.Errorf("set url bindings batch in storage: %w", err)
+	}
+
+	s.logger.Warn("short id of import chunk is taken, storing rows one by one", zap.Int("rows", len(toPersist)))
+	for _, i := range toPersist {
+		if err := s.storeImportItem(ctx, &records[i], rows[i].URL.Opts, &results[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// storeImportItem stores a single new record of an import chunk, generating new short IDs
+// while the generated ones are taken. A taken alias fails the row.
+func (s *Shortener) storeImportItem(
+	ctx context.Context,
+	record *model.URLStorageRecord,
+	opts model.ShortenOptions,
+	result *model.URLImportResult,
+) error {
+	for attempt := 1; ; attempt++ {
+		err := s.urlStorage.Set(ctx, *record)
+		if err == nil {
+			result.ShortID = record.ShortID
+			result.Status = model.URLImportStatusCreated
+			return nil
+		}
+		if errors.Is(err, repo.ErrShortIDConflict) && opts.Alias != "" {
+			failImportRow(result, ErrAliasTaken)
+			return nil
+		}
+		if !errors.Is(err, repo.ErrShortIDConflict) || attempt == maxStoreAttempts {
+			return fmt.Errorf("set url binding in storage: %w", err)
+		}
+		if record.ShortID, err = s.resolveShortID(ctx, record.OrigURL, opts); err != nil {
+			return fmt.Errorf("resolve short id: %w", err)
+		}
+	}
+}
+
+// failImportRow marks the outcome of an import row as failed with the reason.
+func failImportRow(result *model.URLImportResult, err error) {
+	result.ShortID = ""
+	result.Status = model.URLImportStatusFailed
+	result.Err = err
+}
+
+// importRowReader reads rows of a bulk import input one by one.
+type importRowReader interface {
+	// next returns the next row, or io.EOF when the input is over.
+	next() (model.URLImportRow, error)
+}
+
+// newImportRowReader creates a reader of rows of the input in the format.
+// CSV inputs have their header row read right away.
+func newImportRowReader(format string, r io.Reader) (importRowReader, error) {
+	switch strings.ToLower(format) {
+	case ImportFormatCSV:
+		return newCSVImportReader(r)
+	case ImportFormatNDJSON:
+		s := bufio.NewScanner(r)
+		s.Buffer(make([]byte, 0, 4096), maxImportLineSize)
+		return &ndjsonImportReader{scanner: s}, nil
+	}
+	return nil, NewValidationError(ErrInvalidImport, fmt.Sprintf("format `%s` must be csv or ndjson", format))
+}
+
+// csvImportColumns are the columns of CSV imports; original_url is required.
+var csvImportColumns = []string{
+	"correlation_id", "original_url", "alias", "id_style", "expires_at", "ttl",
+	"max_clicks", "password", "tags", "redirect_type", "passthrough",
+}
+
+// csvImportReader reads rows of CSV imports.
+type csvImportReader struct {
+	reader  *csv.Reader
+	columns map[string]int
+	row     int
+}
+
+// newCSVImportReader creates a reader of CSV import rows and reads the header row of the input.
+func newCSVImportReader(r io.Reader) (*csvImportReader, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, ErrEmptyInputBatch
+	} else if err != nil {
+		return nil, NewValidationError(ErrInvalidImport, fmt.Sprintf("malformed header row: %v", err))
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if _, ok := columns[name]; !ok {
+			columns[name] = i
+		}
+	}
+	if _, ok := columns["original_url"]; !ok {
+		return nil, NewValidationError(ErrInvalidImport, "header row has no `original_url` column")
+	}
+	return &csvImportReader{reader: reader, columns: columns}, nil
+}
+
+// next reads the next data row of the CSV input.
+func (c *csvImportReader) next() (model.URLImportRow, error) {
+	record, err := c.reader.Read()
+	if errors.Is(err, io.EOF) {
+		return model.URLImportRow{}, io.EOF
+	}
+	c.row++
+	row := model.URLImportRow{Row: c.row}
+	var pErr *csv.ParseError
+	if errors.As(err, &pErr) {
+		row.Err = NewValidationError(ErrInvalidImport, fmt.Sprintf("malformed csv row: %v", pErr.Err))
+		return row, nil
+	} else if err != nil {
+		return model.URLImportRow{}, fmt.Errorf("read csv row: %w", err)
+	}
+
+	cells := make(map[string]string, len(csvImportColumns))
+	for _, name := range csvImportColumns {
+		if i, ok := c.columns[name]; ok && i < len(record) {
+			cells[name] = strings.TrimSpace(record[i])
+		}
+	}
+	item, err := parseCSVImportItem(cells)
+	row.CorrelationID = item.CorrelationID
+	row.URL = model.URLToShorten{OrigURL: item.OriginalURL, Opts: item.Options()}
+	row.Err = err
+	return row, nil
+}
+
+// parseCSVImportItem converts cells of a CSV import row to a batch shortening item.
+func parseCSVImportItem(cells map[string]string) (model.BatchShortenRequestItem, error) {
+	item := model.BatchShortenRequestItem{
+		CorrelationID: cells["correlation_id"],
+		OriginalURL:   cells["original_url"],
+		Alias:         cells["alias"],
+		IDStyle:       cells["id_style"],
+		Password:      cells["password"],
+	}
+	for _, tag := range strings.Split(cells["tags"], ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			item.Tags = append(item.Tags, tag)
+		}
+	}
+
+	var err error
+	if v := cells["expires_at"]; v != "" {
+		t, pErr := time.Parse(time.RFC3339, v)
+		if pErr == nil {
+			item.ExpiresAt = &t
+		}
+		err = errors.Join(err, wrapImportCellErr("expires_at", pErr))
+	}
+	if v := cells["ttl"]; v != "" {
+		var pErr error
+		item.TTL, pErr = strconv.ParseInt(v, 10, 64)
+		err = errors.Join(err, wrapImportCellErr("ttl", pErr))
+	}
+	if v := cells["max_clicks"]; v != "" {
+		var pErr error
+		item.MaxClicks, pErr = strconv.Atoi(v)
+		err = errors.Join(err, wrapImportCellErr("max_clicks", pErr))
+	}
+	if v := cells["redirect_type"]; v != "" {
+		var pErr error
+		item.RedirectType, pErr = strconv.Atoi(v)
+		err = errors.Join(err, wrapImportCellErr("redirect_type", pErr))
+	}
+	if v := cells["passthrough"]; v != "" {
+		var pErr error
+		item.Passthrough, pErr = strconv.ParseBool(v)
+		err = errors.Join(err, wrapImportCellErr("passthrough", pErr))
+	}
+	if err != nil {
+		return item, NewValidationError(ErrInvalidImport, err.Error())
+	}
+	return item, nil
+}
+
+// wrapImportCellErr names the column of a malformed cell of a CSV import row; nil errors stay nil.
+func wrapImportCellErr(column string, err error) error {
+	if err == nil {
+		return nil
+	}
+	var nErr *strconv.NumError
+	if errors.As(err, &nErr) {
+		err = nErr.Err
+	}
+	return fmt.Errorf("malformed `%s`: %w", column, err)
+}
+
+// ndjsonImportReader reads rows of NDJSON imports. Blank lines are skipped.
+type ndjsonImportReader struct {
+	scanner *bufio.Scanner
+	row     int
+}
+
+// next reads the next non-blank line of the NDJSON input.
+func (n *ndjsonImportReader) next() (model.URLImportRow, error) {
+	for n.scanner.Scan() {
+		line := bytes.TrimSpace(n.scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		n.row++
+		row := model.URLImportRow{Row: n.row}
+		var item model.BatchShortenRequestItem
+		if err := item.UnmarshalJSON(line); err != nil {
+			row.Err = NewValidationError(ErrInvalidImport, fmt.Sprintf("malformed json: %v", err))
+			return row, nil
+		}
+		row.CorrelationID = item.CorrelationID
+		row.URL = model.URLToShorten{OrigURL: item.OriginalURL, Opts: item.Options()}
+		return row, nil
+	}
+	if err := n.scanner.Err(); errors.Is(err, bufio.ErrTooLong) {
+		return model.URLImportRow{}, NewValidationError(ErrInvalidImport,
+			fmt.Sprintf("row %d is longer than %d bytes", n.row+1, maxImportLineSize))
+	} else if err != nil {
+		return model.URLImportRow{}, fmt.Errorf("read ndjson line: %w", err)
+	}
+	return model.URLImportRow{}, io.EOF
+}
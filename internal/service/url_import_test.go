@@ -0,0 +1,208 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/alex-storchak/shortener/internal/model"
+	repo "github.com/alex-storchak/shortener/internal/repository"
+)
+
+func newTestURLImporter(t *testing.T, chunkSize int) (*URLImporter, *repo.MemoryURLStorage) {
+	t.Helper()
+	urls := repo.NewMemoryURLStorage(zap.NewNop())
+	for _, r := range []model.URLStorageRecord{
+		{OrigURL: "http://existing.com", ShortID: "exist", UserUUID: "otherUUID"},
+		{OrigURL: "http://taken.com", ShortID: "taken", UserUUID: "otherUUID"},
+		{OrigURL: "http://secret.com", ShortID: "secret", UserUUID: "otherUUID", PassHash: "hash"},
+	} {
+		require.NoError(t, urls.Set(t.Context(), r))
+	}
+	s := &Shortener{
+		urlStorage: urls,
+		generator:  &idSequenceStub{ids: []string{"gen1", "gen2", "gen3", "gen4"}},
+		logger:     zap.NewNop(),
+	}
+	i := NewURLImporter(s, zap.NewNop())
+	i.chunkSize = chunkSize
+	return i, urls
+}
+
+func importAll(t *testing.T, i *URLImporter, format, input string) ([]model.URLImportResult, int, error) {
+	t.Helper()
+	var (
+		results []model.URLImportResult
+		chunks  int
+	)
+	err := i.Import(t.Context(), "userUUID", format, strings.NewReader(input), func(rs []model.URLImportResult) error {
+		results = append(results, rs...)
+		chunks++
+		return nil
+	})
+	return results, chunks, err
+}
+
+func TestURLImporter_CSV(t *testing.T) {
+	i, urls := newTestURLImporter(t, 2)
+	input := "\ufeffshort_url,Original_URL,alias,tags,ttl\n" +
+		"http://localhost/old,http://a.com,promo,\"news, Promo\",\n" +
+		",http://existing.com,,,\n" +
+		",http://b.com,,,abc\n" +
+		",http://a.com,,,\n" +
+		",http://c.com,taken,,\n" +
+		",http://d.com,,,\n" +
+		",http://e.com,,,60\n" +
+		",http://e.com,,,\n" +
+		",http://existing.com,vip,,\n" +
+		",http://existing.com,,,60\n"
+
+	results, chunks, err := importAll(t, i, ImportFormatCSV, input)
+	require.NoError(t, err)
+	assert.Equal(t, 5, chunks)
+	require.Len(t, results, 10)
+
+	type outcome struct {
+		row     int
+		shortID string
+		status  model.URLImportStatus
+		err     error
+	}
+	want := []outcome{
+		{1, "promo", model.URLImportStatusCreated, nil},
+		{2, "exist", model.URLImportStatusExists, nil},
+		{3, "", model.URLImportStatusFailed, ErrInvalidImport},
+		{4, "promo", model.URLImportStatusExists, nil},
+		{5, "", model.URLImportStatusFailed, ErrAliasTaken},
+		{6, "gen1", model.URLImportStatusCreated, nil},
+		{7, "gen2", model.URLImportStatusCreated, nil},
+		{8, "gen3", model.URLImportStatusCreated, nil},
+		{9, "", model.URLImportStatusFailed, ErrAliasURLExists},
+		{10, "gen4", model.URLImportStatusCreated, nil},
+	}
+	for k, w := range want {
+		got := results[k]
+		assert.Equal(t, w.row, got.Row)
+		assert.Equal(t, w.shortID, got.ShortID, "row %d", w.row)
+		assert.Equal(t, w.status, got.Status, "row %d", w.row)
+		if w.err != nil {
+			assert.ErrorIs(t, got.Err, w.err, "row %d", w.row)
+		} else {
+			assert.NoError(t, got.Err, "row %d", w.row)
+		}
+	}
+
+	r, err := urls.Get(t.Context(), "promo", repo.ShortURLType)
+	require.NoError(t, err)
+	assert.Equal(t, "http://a.com", r.OrigURL)
+	assert.Equal(t, "userUUID", r.UserUUID)
+	assert.Equal(t, []string{"news", "promo"}, r.Tags)
+	r, err = urls.Get(t.Context(), "gen2", repo.ShortURLType)
+	require.NoError(t, err)
+	assert.NotNil(t, r.ExpiresAt)
+	_, err = urls.Get(t.Context(), "gen1", repo.ShortURLType)
+	require.NoError(t, err, "rows of a chunk with a taken alias are stored one by one")
+}
+
+func TestURLImporter_NDJSON(t *testing.T) {
+	i, _ := newTestURLImporter(t, 10)
+	input := `{"correlation_id":"1","original_url":"http://a.com","tags":["x"]}` + "\n\n" +
+		`{"correlation_id":"2","original_url":` + "\n" +
+		`{"correlation_id":"3","original_url":"","alias":"z"}`
+
+	results, chunks, err := importAll(t, i, "NDJSON", input)
+	require.NoError(t, err)
+	assert.Equal(t, 1, chunks)
+	require.Len(t, results, 3)
+
+	assert.Equal(t, model.URLImportResult{
+		Row: 1, CorrelationID: "1", OrigURL: "http://a.com", ShortID: "gen1", Status: model.URLImportStatusCreated,
+	}, results[0])
+	assert.Equal(t, 2, results[1].Row)
+	assert.Equal(t, model.URLImportStatusFailed, results[1].Status)
+	assert.ErrorIs(t, results[1].Err, ErrInvalidImport)
+	assert.Equal(t, "3", results[2].CorrelationID)
+	assert.ErrorIs(t, results[2].Err, ErrEmptyInputURL)
+}
+
+func TestURLImporter_ProtectedRows(t *testing.T) {
+	i, urls := newTestURLImporter(t, 10)
+	input := `{"original_url":"http://existing.com","password":"pw"}` + "\n" +
+		`{"original_url":"http://secret.com"}` + "\n" +
+		`{"original_url":"http://secret.com"}` + "\n" +
+		`{"original_url":"http://existing.com"}` + "\n" +
+		`{"original_url":"http://existing.com","password":"pw"}`
+
+	results, _, err := importAll(t, i, ImportFormatNDJSON, input)
+	require.NoError(t, err)
+	require.Len(t, results, 5)
+
+	want := []struct {
+		shortID string
+		status  model.URLImportStatus
+	}{
+		{"gen1", model.URLImportStatusCreated},
+		{"gen2", model.URLImportStatusCreated},
+		{"gen2", model.URLImportStatusExists},
+		{"exist", model.URLImportStatusExists},
+		{"gen3", model.URLImportStatusCreated},
+	}
+	for k, w := range want {
+		require.NoError(t, results[k].Err, "row %d", k+1)
+		assert.Equal(t, w.shortID, results[k].ShortID, "row %d", k+1)
+		assert.Equal(t, w.status, results[k].Status, "row %d", k+1)
+	}
+
+	r, err := urls.Get(t.Context(), "gen1", repo.ShortURLType)
+	require.NoError(t, err)
+	assert.True(t, r.IsProtected())
+	r, err = urls.Get(t.Context(), "gen2", repo.ShortURLType)
+	require.NoError(t, err)
+	assert.False(t, r.IsProtected())
+}
+
+func TestURLImporter_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		input   string
+		wantErr error
+	}{
+		{name: "unknown format", format: "xml", input: "<a/>", wantErr: ErrInvalidImport},
+		{name: "csv without original_url column", format: ImportFormatCSV, input: "url\nhttp://a.com\n", wantErr: ErrInvalidImport},
+		{name: "empty csv", format: ImportFormatCSV, input: "", wantErr: ErrEmptyInputBatch},
+		{name: "csv without data rows", format: ImportFormatCSV, input: "original_url\n", wantErr: ErrEmptyInputBatch},
+		{name: "empty ndjson", format: ImportFormatNDJSON, input: "\n \n", wantErr: ErrEmptyInputBatch},
+		{
+			name:    "too long ndjson line",
+			format:  ImportFormatNDJSON,
+			input:   `{"original_url":"http://a.com/` + strings.Repeat("a", maxImportLineSize) + `"}`,
+			wantErr: ErrInvalidImport,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i, _ := newTestURLImporter(t, 10)
+			_, chunks, err := importAll(t, i, tt.format, tt.input)
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Zero(t, chunks)
+		})
+	}
+
+	t.Run("error of emit stops import", func(t *testing.T) {
+		i, _ := newTestURLImporter(t, 1)
+		errEmit := errors.New("client is gone")
+		calls := 0
+		err := i.Import(t.Context(), "userUUID", ImportFormatCSV, strings.NewReader("original_url\nhttp://a.com\nhttp://b.com\n"),
+			func([]model.URLImportResult) error {
+				calls++
+				return errEmit
+			})
+		require.ErrorIs(t, err, errEmit)
+		assert.Equal(t, 1, calls)
+	})
+}
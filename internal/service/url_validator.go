@@ -0,0 +1,119 @@
+package service
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// URLValidator checks that original URLs are safe to redirect to.
+// A valid URL is absolute, has a host, uses one of the allowed schemes
+// and its host matches none of the denied domain patterns and, if any allowed
+// domain patterns are configured, at least one of them.
+//
+// Domain patterns are matched against the whole host name case-insensitively and support
+// shell-like wildcards: `*.example.com` matches every subdomain of example.com at any depth,
+// but not example.com itself. Internationalized domain names are matched in punycode.
+// A nil validator accepts every URL.
+type URLValidator struct {
+	schemes map[string]struct{}
+	allowed []string
+	denied  []string
+}
+
+// NewURLValidator creates a new URLValidator.
+//
+// Parameters:
+//   - schemes: schemes allowed in original URLs
+//   - allowed: domain patterns allowed in original URLs; empty list allows every domain that is not denied
+//   - denied: domain patterns rejected in original URLs; checked before the allowed ones
+//
+// Returns:
+//   - *URLValidator: configured validator
+//   - error: nil on success, or error if any domain pattern is malformed
+func NewURLValidator(schemes, allowed, denied []string) (*URLValidator, error) {
+	v := &URLValidator{
+		schemes: make(map[string]struct{}, len(schemes)),
+	}
+	for _, s := range schemes {
+		v.schemes[strings.ToLower(s)] = struct{}{}
+	}
+	var err error
+	if v.allowed, err = compileDomainPatterns(allowed); err != nil {
+		return nil, fmt.Errorf("allowed domains: %w", err)
+	}
+	if v.denied, err = compileDomainPatterns(denied); err != nil {
+		return nil, fmt.Errorf("denied domains: %w", err)
+	}
+	return v, nil
+}
+
+// compileDomainPatterns brings domain patterns to the form of canonical hosts and checks their syntax.
+// Labels without wildcards are converted to punycode separately, since IDNA rejects wildcard characters.
+func compileDomainPatterns(patterns []string) ([]string, error) {
+	compiled := make([]string, 0, len(patterns))
+	for _, p := range patterns {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		labels := strings.Split(p, ".")
+		for i, l := range labels {
+			if strings.ContainsAny(l, `*?[\`) {
+				labels[i] = strings.ToLower(l)
+			} else {
+				labels[i] = canonicalHost(l)
+			}
+		}
+		p = strings.Join(labels, ".")
+		if _, err := path.Match(p, ""); err != nil {
+			return nil, fmt.Errorf("pattern `%s`: %w", p, err)
+		}
+		compiled = append(compiled, p)
+	}
+	return compiled, nil
+}
+
+// Validate checks the original URL.
+//
+// Parameters:
+//   - raw: original URL to check
+//
+// Returns:
+//   - error: nil if the URL is valid, or *ValidationError wrapping ErrInvalidURL with the reason
+func (v *URLValidator) Validate(raw string) error {
+	if v == nil {
+		return nil
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return NewValidationError(ErrInvalidURL, "malformed url")
+	}
+	if !u.IsAbs() || u.Opaque != "" || u.Host == "" {
+		return NewValidationError(ErrInvalidURL, "absolute url with a host is required")
+	}
+	scheme := strings.ToLower(u.Scheme)
+	if _, ok := v.schemes[scheme]; !ok {
+		return NewValidationError(ErrInvalidURL, fmt.Sprintf("scheme `%s` is not allowed", scheme))
+	}
+	host := canonicalHost(u.Hostname())
+	if matchDomain(v.denied, host) {
+		return NewValidationError(ErrInvalidURL, fmt.Sprintf("domain `%s` is denied", host))
+	}
+	if len(v.allowed) > 0 && !matchDomain(v.allowed, host) {
+		return NewValidationError(ErrInvalidURL, fmt.Sprintf("domain `%s` is not allowed", host))
+	}
+	return nil
+}
+
+// matchDomain reports whether the host matches any of the domain patterns.
+func matchDomain(patterns []string, host string) bool {
+	for _, p := range patterns {
+		// patterns are checked in NewURLValidator, so matching can't fail
+		if ok, _ := path.Match(p, host); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestURLValidator_Validate(t *testing.T) {
+	v, err := NewURLValidator(
+		[]string{"http", "HTTPS"},
+		[]string{"example.com", "*.example.com", "*.пример.рф", "trusted.org"},
+		[]string{"evil.example.com"},
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		url     string
+		wantErr bool
+	}{
+		{name: "allowed domain", url: "https://example.com/path"},
+		{name: "allowed subdomain by wildcard", url: "http://a.b.example.com"},
+		{name: "allowed idn subdomain by wildcard", url: "https://xn--80a.xn--e1afmkfd.xn--p1ai/"},
+		{name: "allowed domain in upper case", url: "HTTPS://Trusted.ORG"},
+		{name: "denied domain", url: "https://evil.example.com", wantErr: true},
+		{name: "domain not in allowed list", url: "https://other.com", wantErr: true},
+		{name: "wildcard doesn't match the domain suffix", url: "https://badexample.com", wantErr: true},
+		{name: "javascript scheme", url: "javascript:alert(1)", wantErr: true},
+		{name: "ftp scheme", url: "ftp://example.com/file", wantErr: true},
+		{name: "relative url", url: "/relative/path", wantErr: true},
+		{name: "url without scheme", url: "example.com", wantErr: true},
+		{name: "malformed url", url: "http://example.com/%zz", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.Validate(tt.url)
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+			var vErr *ValidationError
+			require.ErrorAs(t, err, &vErr)
+			assert.ErrorIs(t, err, ErrInvalidURL)
+			assert.NotEmpty(t, vErr.Reason)
+		})
+	}
+
+	_, err = NewURLValidator([]string{"http"}, []string{"[a-"}, nil)
+	require.Error(t, err)
+
+	var nilValidator *URLValidator
+	assert.NoError(t, nilValidator.Validate("javascript:alert(1)"))
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"cmp"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// User-Agent families of clients matched by targeting rules.
+const (
+	BrowserChrome  = "chrome"
+	BrowserEdge    = "edge"
+	BrowserFirefox = "firefox"
+	BrowserOpera   = "opera"
+	BrowserSafari  = "safari"
+	BrowserSamsung = "samsung"
+	BrowserBot     = "bot"
+)
+
+// Operating systems of clients matched by targeting rules.
+const (
+	OSAndroid  = "android"
+	OSIOS      = "ios"
+	OSWindows  = "windows"
+	OSMacOS    = "macos"
+	OSLinux    = "linux"
+	OSChromeOS = "chromeos"
+)
+
+// userAgentToken binds a User-Agent substring to the family or the operating system it identifies.
+type userAgentToken struct {
+	substr string
+	name   string
+}
+
+// browserTokens identify User-Agent families. Browsers based on other engines mention them too,
+// e.g. every Chromium browser claims to be Chrome and Safari, so more specific tokens go first.
+var browserTokens = []userAgentToken{
+	{"bot", BrowserBot},
+	{"crawler", BrowserBot},
+	{"spider", BrowserBot},
+	{"edg/", BrowserEdge},
+	{"edga/", BrowserEdge},
+	{"edgios/", BrowserEdge},
+	{"opr/", BrowserOpera},
+	{"opera", BrowserOpera},
+	{"samsungbrowser/", BrowserSamsung},
+	{"firefox/", BrowserFirefox},
+	{"fxios/", BrowserFirefox},
+	{"chrome/", BrowserChrome},
+	{"crios/", BrowserChrome},
+	{"chromium/", BrowserChrome},
+	{"safari/", BrowserSafari},
+}
+
+// osTokens identify operating systems. Mobile systems mention desktop ones,
+// e.g. Android claims to be Linux and iOS claims to be like Mac OS X, so they go first.
+var osTokens = []userAgentToken{
+	{"iphone", OSIOS},
+	{"ipad", OSIOS},
+	{"ipod", OSIOS},
+	{"android", OSAndroid},
+	{"cros", OSChromeOS},
+	{"windows", OSWindows},
+	{"macintosh", OSMacOS},
+	{"mac os x", OSMacOS},
+	{"linux", OSLinux},
+}
+
+// client describes the client following a link as seen by targeting rules.
+type client struct {
+	browser  string      // User-Agent family; empty if unknown
+	os       string      // Operating system; empty if unknown
+	language string      // Most preferred language in lower case; empty if not specified
+	header   http.Header // Headers of the request
+}
+
+// newClient detects the client from the headers of its request.
+func newClient(h http.Header) client {
+	ua := strings.ToLower(h.Get("User-Agent"))
+	return client{
+		browser:  matchUserAgent(ua, browserTokens),
+		os:       matchUserAgent(ua, osTokens),
+		language: preferredLanguage(h.Get("Accept-Language")),
+		header:   h,
+	}
+}
+
+// matchUserAgent returns the name bound to the first token found in the lowercased User-Agent.
+func matchUserAgent(ua string, tokens []userAgentToken) string {
+	for _, t := range tokens {
+		if strings.Contains(ua, t.substr) {
+			return t.name
+		}
+	}
+	return ""
+}
+
+// preferredLanguage returns the language of Accept-Language with the highest quality in lower case.
+// Languages of equal quality keep the order of the header; the wildcard and rejected languages are skipped.
+func preferredLanguage(acceptLanguage string) string {
+	type weighted struct {
+		tag string
+		q   float64
+	}
+	var langs []weighted
+	for part := range strings.SplitSeq(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || tag == "*" {
+			continue
+		}
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			var err error
+			if q, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+		if q > 0 {
+			langs = append(langs, weighted{tag: tag, q: q})
+		}
+	}
+	if len(langs) == 0 {
+		return ""
+	}
+	slices.SortStableFunc(langs, func(a, b weighted) int {
+		return cmp.Compare(b.q, a.q)
+	})
+	return langs[0].tag
+}
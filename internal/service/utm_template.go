@@ -0,0 +1,246 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/alex-storchak/shortener/internal/model"
+	repo "github.com/alex-storchak/shortener/internal/repository"
+)
+
+// Placeholders substituted in values of UTM template parameters on redirect.
+const (
+	UTMPlaceholderShortID = "{short_id}" // Short identifier of the followed link
+	UTMPlaceholderTag     = "{tag}"      // Tag the template is bound to; allowed in tag templates only
+	UTMPlaceholderDate    = "{date}"     // Date of the follow in UTC, formatted as YYYY-MM-DD
+)
+
+// Limits of UTM templates.
+const (
+	maxUTMParams        = 20  // Maximum amount of parameters of a single template
+	maxUTMParamNameLen  = 64  // Maximum length of a parameter name in characters
+	maxUTMParamValueLen = 256 // Maximum length of a parameter value in characters, before substitution
+)
+
+var (
+	// utmParamNamePattern matches allowed parameter names: latin letters, digits, `_`, `-` and `.`.
+	utmParamNamePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+	// utmPlaceholderPattern matches placeholders in parameter values.
+	utmPlaceholderPattern = regexp.MustCompile(`\{[^{}]*\}`)
+)
+
+// UTMTemplates manages UTM templates of users and applies them to link destinations on redirect.
+// A template is bound either to a single link of the user or to a tag, applying to every link
+// of the user labeled with it. Parameters of the link template take precedence over the ones
+// of tag templates, tag templates are applied in tag order, and parameters already present
+// in the destination are never overridden.
+type UTMTemplates struct {
+	storage repo.UTMTemplateStorage
+	urls    ShortIDLookup
+	logger  *zap.Logger
+}
+
+// NewUTMTemplates creates a new instance of UTMTemplates.
+//
+// Parameters:
+//   - storage: storage of UTM templates
+//   - urls: URL storage used to check that the links templates are bound to belong to their users
+//   - logger: structured logger for logging operations
+//
+// Returns:
+//   - *UTMTemplates: configured UTM templates service
+func NewUTMTemplates(storage repo.UTMTemplateStorage, urls ShortIDLookup, logger *zap.Logger) *UTMTemplates {
+	return &UTMTemplates{
+		storage: storage,
+		urls:    urls,
+		logger:  logger,
+	}
+}
+
+// GetUserTemplates retrieves all UTM templates of the user.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - userUUID: UUID of the user
+//
+// Returns:
+//   - []model.UTMTemplate: link templates sorted by short ID, followed by tag templates sorted by tag
+//   - error: nil on success, or storage error if operation fails
+func (s *UTMTemplates) GetUserTemplates(ctx context.Context, userUUID string) ([]model.UTMTemplate, error) {
+	templates, err := s.storage.GetByUserUUID(ctx, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("get user utm templates from storage: %w", err)
+	}
+	return templates, nil
+}
+
+// Set validates and stores the UTM template, replacing the template of the user bound to the same link or tag.
+// The tag is normalized like link tags. A link template can only be bound to a live link of the user.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - t: template of the user bound to either a short ID or a tag
+//
+// Returns:
+//   - *model.UTMTemplate: stored template with the normalized tag
+//   - error: nil on success, or error if validation or storage operation fails
+//
+// Errors:
+//   - ErrInvalidUTMTemplate: when the template has no single target or its parameters are malformed
+//   - ErrInvalidTags: when the tag is malformed
+//   - repo.DataNotFoundError: when the user has no link with the short ID
+//   - repo.ErrDataDeleted, repo.ErrDataExpired, repo.ErrClicksExhausted: when the link can't be followed anymore
+func (s *UTMTemplates) Set(ctx context.Context, t model.UTMTemplate) (*model.UTMTemplate, error) {
+	shortID, tag, err := normalizeUTMTarget(t.ShortID, t.Tag)
+	if err != nil {
+		return nil, err
+	}
+	t.ShortID, t.Tag = shortID, tag
+	if err := validateUTMParams(t.Params, tag != ""); err != nil {
+		return nil, err
+	}
+	if shortID != "" {
+		r, err := s.urls.Get(ctx, shortID, repo.ShortURLType)
+		if err != nil {
+			return nil, fmt.Errorf("get url `%s` of utm template: %w", shortID, err)
+		}
+		if r.UserUUID != t.UserUUID {
+			return nil, fmt.Errorf("get url `%s` of utm template: %w", shortID, repo.NewDataNotFoundError(nil))
+		}
+	}
+
+	if err := s.storage.Set(ctx, t); err != nil {
+		return nil, fmt.Errorf("set utm template in storage: %w", err)
+	}
+	return &t, nil
+}
+
+// Delete removes the UTM template of the user bound to a link or a tag.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - userUUID: UUID of the user
+//   - shortID: short identifier of the link the template is bound to; empty for tag templates
+//   - tag: tag the template is bound to; empty for link templates
+//
+// Returns:
+//   - error: nil on success, *ValidationError if there is no single target,
+//     repo.DataNotFoundError if there is no such template, or storage error if operation fails
+func (s *UTMTemplates) Delete(ctx context.Context, userUUID, shortID, tag string) error {
+	shortID, tag, err := normalizeUTMTarget(shortID, tag)
+	if err != nil {
+		return err
+	}
+	if err := s.storage.Delete(ctx, userUUID, shortID, tag); err != nil {
+		return fmt.Errorf("delete utm template from storage: %w", err)
+	}
+	return nil
+}
+
+// Apply appends the parameters of the UTM templates applying to the link to its destination.
+// Placeholders in parameter values are substituted and the values are query-escaped.
+// Links without an owner have no templates.
+//
+// Parameters:
+//   - ctx: context for cancellation and timeouts
+//   - r: followed link with its destination in OrigURL
+//   - now: moment of the follow
+//
+// Returns:
+//   - string: destination with the template parameters
+//   - error: nil on success, or error if templates can't be retrieved or the destination can't be parsed
+func (s *UTMTemplates) Apply(ctx context.Context, r *model.URLStorageRecord, now time.Time) (string, error) {
+	if r.UserUUID == "" {
+		return r.OrigURL, nil
+	}
+	templates, err := s.storage.GetForURL(ctx, r.UserUUID, r.ShortID, r.Tags)
+	if err != nil {
+		return "", fmt.Errorf("get utm templates of url from storage: %w", err)
+	}
+	if len(templates) == 0 {
+		return r.OrigURL, nil
+	}
+
+	seen := make(map[string]struct{})
+	var pairs []string
+	for _, t := range templates {
+		replacer := strings.NewReplacer(
+			UTMPlaceholderShortID, r.ShortID,
+			UTMPlaceholderTag, t.Tag,
+			UTMPlaceholderDate, now.UTC().Format(time.DateOnly),
+		)
+		for _, name := range slices.Sorted(maps.Keys(t.Params)) {
+			if _, ok := seen[name]; ok {
+				continue
+			}
+			seen[name] = struct{}{}
+			pairs = append(pairs, url.QueryEscape(name)+"="+url.QueryEscape(replacer.Replace(t.Params[name])))
+		}
+	}
+
+	u, err := url.Parse(r.OrigURL)
+	if err != nil {
+		return "", fmt.Errorf("parse destination url: %w", err)
+	}
+	if u.RawQuery, err = mergeQuery(u.RawQuery, strings.Join(pairs, "&")); err != nil {
+		return "", fmt.Errorf("merge utm parameters: %w", err)
+	}
+	return u.String(), nil
+}
+
+// normalizeUTMTarget checks that exactly one of the short ID and the tag is set and normalizes the tag.
+func normalizeUTMTarget(shortID, tag string) (string, string, error) {
+	shortID = strings.TrimSpace(shortID)
+	if (shortID == "") == (strings.TrimSpace(tag) == "") {
+		return "", "", NewValidationError(ErrInvalidUTMTemplate, "exactly one of short_id and tag must be set")
+	}
+	if shortID != "" {
+		return shortID, "", nil
+	}
+	tags, err := NormalizeTags([]string{tag})
+	if err != nil {
+		return "", "", err
+	}
+	return "", tags[0], nil
+}
+
+// validateUTMParams checks names and values of template parameters and placeholders in the values.
+func validateUTMParams(params map[string]string, tagTemplate bool) error {
+	if len(params) == 0 {
+		return NewValidationError(ErrInvalidUTMTemplate, "params can't be empty")
+	}
+	if len(params) > maxUTMParams {
+		return NewValidationError(ErrInvalidUTMTemplate, fmt.Sprintf("more than %d params", maxUTMParams))
+	}
+	for _, name := range slices.Sorted(maps.Keys(params)) {
+		if len(name) > maxUTMParamNameLen || !utmParamNamePattern.MatchString(name) {
+			return NewValidationError(ErrInvalidUTMTemplate, fmt.Sprintf(
+				"param name `%s` must be up to %d latin letters, digits, `_`, `-` and `.`", name, maxUTMParamNameLen,
+			))
+		}
+		value := params[name]
+		if value == "" || len([]rune(value)) > maxUTMParamValueLen {
+			return NewValidationError(ErrInvalidUTMTemplate, fmt.Sprintf(
+				"value of param `%s` must be from 1 to %d characters", name, maxUTMParamValueLen,
+			))
+		}
+		for _, ph := range utmPlaceholderPattern.FindAllString(value, -1) {
+			known := ph == UTMPlaceholderShortID || ph == UTMPlaceholderDate || (ph == UTMPlaceholderTag && tagTemplate)
+			if !known {
+				return NewValidationError(ErrInvalidUTMTemplate, fmt.Sprintf(
+					"placeholder `%s` of param `%s` is not supported", ph, name,
+				))
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/alex-storchak/shortener/internal/model"
+	repo "github.com/alex-storchak/shortener/internal/repository"
+)
+
+func TestUTMTemplates_Set(t *testing.T) {
+	urls := repo.NewMemoryURLStorage(zap.NewNop())
+	require.NoError(t, urls.Set(t.Context(), model.URLStorageRecord{OrigURL: "http://a.com", ShortID: "own", UserUUID: "userUUID"}))
+	require.NoError(t, urls.Set(t.Context(), model.URLStorageRecord{OrigURL: "http://b.com", ShortID: "alien", UserUUID: "otherUUID"}))
+	s := NewUTMTemplates(repo.NewMemoryUTMTemplateStorage(zap.NewNop()), urls, zap.NewNop())
+
+	tests := []struct {
+		name      string
+		template  model.UTMTemplate
+		want      *model.UTMTemplate
+		wantErr   error
+		wantErrAs any
+	}{
+		{
+			name:     "stores link template",
+			template: model.UTMTemplate{ShortID: "own", Params: map[string]string{"utm_source": "{short_id}-{date}"}},
+			want:     &model.UTMTemplate{UserUUID: "userUUID", ShortID: "own", Params: map[string]string{"utm_source": "{short_id}-{date}"}},
+		},
+		{
+			name:     "normalizes tag of tag template",
+			template: model.UTMTemplate{Tag: " Promo ", Params: map[string]string{"utm_campaign": "{tag}"}},
+			want:     &model.UTMTemplate{UserUUID: "userUUID", Tag: "promo", Params: map[string]string{"utm_campaign": "{tag}"}},
+		},
+		{
+			name:     "rejects template without target",
+			template: model.UTMTemplate{Params: map[string]string{"utm_source": "mail"}},
+			wantErr:  ErrInvalidUTMTemplate,
+		},
+		{
+			name:     "rejects template with both targets",
+			template: model.UTMTemplate{ShortID: "own", Tag: "promo", Params: map[string]string{"utm_source": "mail"}},
+			wantErr:  ErrInvalidUTMTemplate,
+		},
+		{
+			name:     "rejects malformed tag",
+			template: model.UTMTemplate{Tag: "a,b", Params: map[string]string{"utm_source": "mail"}},
+			wantErr:  ErrInvalidTags,
+		},
+		{
+			name:     "rejects empty params",
+			template: model.UTMTemplate{ShortID: "own"},
+			wantErr:  ErrInvalidUTMTemplate,
+		},
+		{
+			name:     "rejects malformed param name",
+			template: model.UTMTemplate{ShortID: "own", Params: map[string]string{"utm source": "mail"}},
+			wantErr:  ErrInvalidUTMTemplate,
+		},
+		{
+			name:     "rejects empty param value",
+			template: model.UTMTemplate{ShortID: "own", Params: map[string]string{"utm_source": ""}},
+			wantErr:  ErrInvalidUTMTemplate,
+		},
+		{
+			name:     "rejects unknown placeholder",
+			template: model.UTMTemplate{ShortID: "own", Params: map[string]string{"utm_source": "{user}"}},
+			wantErr:  ErrInvalidUTMTemplate,
+		},
+		{
+			name:     "rejects tag placeholder in link template",
+			template: model.UTMTemplate{ShortID: "own", Params: map[string]string{"utm_campaign": "{tag}"}},
+			wantErr:  ErrInvalidUTMTemplate,
+		},
+		{
+			name:      "rejects link of another user",
+			template:  model.UTMTemplate{ShortID: "alien", Params: map[string]string{"utm_source": "mail"}},
+			wantErrAs: new(*repo.DataNotFoundError),
+		},
+		{
+			name:      "rejects unknown link",
+			template:  model.UTMTemplate{ShortID: "missing", Params: map[string]string{"utm_source": "mail"}},
+			wantErrAs: new(*repo.DataNotFoundError),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.template.UserUUID = "userUUID"
+			got, err := s.Set(t.Context(), tt.template)
+			if tt.wantErr != nil {
+				var vErr *ValidationError
+				require.ErrorAs(t, err, &vErr)
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			if tt.wantErrAs != nil {
+				require.ErrorAs(t, err, tt.wantErrAs)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestUTMTemplates_Apply(t *testing.T) {
+	urls := repo.NewMemoryURLStorage(zap.NewNop())
+	require.NoError(t, urls.Set(t.Context(), model.URLStorageRecord{OrigURL: "http://a.com", ShortID: "own", UserUUID: "userUUID"}))
+	s := NewUTMTemplates(repo.NewMemoryUTMTemplateStorage(zap.NewNop()), urls, zap.NewNop())
+	for _, tmpl := range []model.UTMTemplate{
+		{UserUUID: "userUUID", ShortID: "own", Params: map[string]string{"utm_source": "link {short_id}"}},
+		{UserUUID: "userUUID", Tag: "blog", Params: map[string]string{"utm_source": "blog", "utm_medium": "{tag}"}},
+		{UserUUID: "userUUID", Tag: "promo", Params: map[string]string{"utm_medium": "promo", "utm_campaign": "{date}"}},
+	} {
+		_, err := s.Set(t.Context(), tmpl)
+		require.NoError(t, err)
+	}
+	now := time.Date(2026, 3, 1, 23, 30, 0, 0, time.FixedZone("UTC+3", 3*60*60))
+
+	tests := []struct {
+		name   string
+		record model.URLStorageRecord
+		want   string
+	}{
+		{
+			name:   "link template takes precedence over tag templates applied in tag order",
+			record: model.URLStorageRecord{OrigURL: "http://a.com/path#top", ShortID: "own", UserUUID: "userUUID", Tags: []string{"blog", "promo"}},
+			want:   "http://a.com/path?utm_source=link+own&utm_medium=blog&utm_campaign=2026-03-01#top",
+		},
+		{
+			name:   "destination parameters are not overridden",
+			record: model.URLStorageRecord{OrigURL: "http://b.com/?utm_medium=qr", ShortID: "other", UserUUID: "userUUID", Tags: []string{"promo"}},
+			want:   "http://b.com/?utm_medium=qr&utm_campaign=2026-03-01",
+		},
+		{
+			name:   "link without templates is kept",
+			record: model.URLStorageRecord{OrigURL: "http://c.com", ShortID: "plain", UserUUID: "userUUID", Tags: []string{"news"}},
+			want:   "http://c.com",
+		},
+		{
+			name:   "templates of another user are not applied",
+			record: model.URLStorageRecord{OrigURL: "http://d.com", ShortID: "own", UserUUID: "otherUUID", Tags: []string{"blog"}},
+			want:   "http://d.com",
+		},
+		{
+			name:   "link without owner is kept",
+			record: model.URLStorageRecord{OrigURL: "http://e.com", ShortID: "own"},
+			want:   "http://e.com",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.Apply(t.Context(), &tt.record, now)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	var nfErr *repo.DataNotFoundError
+	require.NoError(t, s.Delete(t.Context(), "userUUID", "", "BLOG"))
+	require.ErrorAs(t, s.Delete(t.Context(), "userUUID", "", "blog"), &nfErr)
+	require.ErrorIs(t, s.Delete(t.Context(), "userUUID", "", ""), ErrInvalidUTMTemplate)
+}
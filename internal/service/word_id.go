@@ -0,0 +1,165 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"embed"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+//go:embed wordlist/*.txt
+var wordLists embed.FS
+
+// wordDictionary contains the words of word-based short IDs and the terms they must never contain.
+type wordDictionary struct {
+	adjectives []string
+	nouns      []string
+	profanity  []string
+}
+
+// loadWordDictionary reads the embedded word lists. Words containing profanity are dropped.
+func loadWordDictionary() (*wordDictionary, error) {
+	profanity, err := readWordList("wordlist/profanity.txt")
+	if err != nil {
+		return nil, err
+	}
+	d := &wordDictionary{profanity: profanity}
+	if d.adjectives, err = readWordList("wordlist/adjectives.txt"); err != nil {
+		return nil, err
+	}
+	if d.nouns, err = readWordList("wordlist/nouns.txt"); err != nil {
+		return nil, err
+	}
+	d.adjectives = d.clean(d.adjectives)
+	d.nouns = d.clean(d.nouns)
+	if len(d.adjectives) == 0 || len(d.nouns) == 0 {
+		return nil, fmt.Errorf("word lists are empty")
+	}
+	return d, nil
+}
+
+// readWordList reads lowercase words from the embedded file, skipping empty lines and `#` comments.
+func readWordList(name string) ([]string, error) {
+	f, err := wordLists.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("open word list `%s`: %w", name, err)
+	}
+	defer f.Close()
+
+	var words []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		w := strings.ToLower(strings.TrimSpace(scanner.Text()))
+		if w == "" || strings.HasPrefix(w, "#") {
+			continue
+		}
+		if !aliasPattern.MatchString(w) {
+			return nil, fmt.Errorf("word list `%s`: word `%s` can't be used in short ids", name, w)
+		}
+		words = append(words, w)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("scan word list `%s`: %w", name, err)
+	}
+	return words, nil
+}
+
+// clean returns the words that contain no profanity.
+func (d *wordDictionary) clean(words []string) []string {
+	cleaned := make([]string, 0, len(words))
+	for _, w := range words {
+		if !d.isProfane(w) {
+			cleaned = append(cleaned, w)
+		}
+	}
+	return cleaned
+}
+
+// isProfane reports whether the letters of the ID contain any profanity term.
+// Separators and digits are ignored, so terms formed across word boundaries are caught too.
+func (d *wordDictionary) isProfane(id string) bool {
+	letters := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' {
+			return r
+		}
+		return -1
+	}, strings.ToLower(id))
+	for _, term := range d.profanity {
+		if strings.Contains(letters, term) {
+			return true
+		}
+	}
+	return false
+}
+
+// NewWordIDGenerator creates a generator of human-readable short IDs like `brave-otter-42`:
+// adjectives followed by a noun and an optional number, joined with the separator.
+// Words come from the embedded lists; IDs containing profanity are skipped as collisions.
+//
+// Parameters:
+//   - s: storage to check candidates against
+//   - count: amount of words in short IDs, at least 1
+//   - separator: separator of words; only `-`, `_` or empty separator is allowed
+//   - digits: amount of digits of the random number appended to short IDs; 0 disables the number
+//   - maxAttempts: maximum amount of candidates checked for a single short ID
+//
+// Returns:
+//   - *UniqueIDGenerator: configured generator
+//   - error: nil on success, or error if the settings are invalid
+func NewWordIDGenerator(s ShortIDLookup, count int, separator string, digits, maxAttempts int) (*UniqueIDGenerator, error) {
+	if count < 1 {
+		return nil, fmt.Errorf("word count must be positive, got %d", count)
+	}
+	if separator != "" && separator != "-" && separator != "_" {
+		return nil, fmt.Errorf("word separator must be `-`, `_` or empty, got `%s`", separator)
+	}
+	if digits < 0 || digits > 9 {
+		return nil, fmt.Errorf("word id digits must be between 0 and 9, got %d", digits)
+	}
+	d, err := loadWordDictionary()
+	if err != nil {
+		return nil, fmt.Errorf("load word dictionary: %w", err)
+	}
+
+	next := func(_ context.Context, _ string, _ int) (string, error) {
+		parts := make([]string, 0, count+1)
+		for i := 0; i < count-1; i++ {
+			w, err := randomItem(d.adjectives)
+			if err != nil {
+				return "", err
+			}
+			parts = append(parts, w)
+		}
+		noun, err := randomItem(d.nouns)
+		if err != nil {
+			return "", err
+		}
+		parts = append(parts, noun)
+		if digits > 0 {
+			n, err := rand.Int(rand.Reader, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(digits)), nil))
+			if err != nil {
+				return "", fmt.Errorf("read random: %w", err)
+			}
+			parts = append(parts, fmt.Sprintf("%0*d", digits, n.Int64()))
+		}
+		return strings.Join(parts, separator), nil
+	}
+	g, err := newUniqueIDGenerator(next, s, maxAttempts)
+	if err != nil {
+		return nil, err
+	}
+	g.reject = d.isProfane
+	return g, nil
+}
+
+// randomItem returns a uniformly chosen item of the list.
+func randomItem(list []string) (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(len(list))))
+	if err != nil {
+		return "", fmt.Errorf("read random: %w", err)
+	}
+	return list[n.Int64()], nil
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWordIDGenerator(t *testing.T) {
+	tests := []struct {
+		name      string
+		count     int
+		separator string
+		digits    int
+		want      *regexp.Regexp
+	}{
+		{name: "default format", count: 2, separator: "-", digits: 2, want: regexp.MustCompile(`^[a-z]+-[a-z]+-\d{2}$`)},
+		{name: "single noun without number", count: 1, separator: "-", digits: 0, want: regexp.MustCompile(`^[a-z]+$`)},
+		{name: "underscore separator", count: 3, separator: "_", digits: 3, want: regexp.MustCompile(`^[a-z]+_[a-z]+_[a-z]+_\d{3}$`)},
+		{name: "no separator", count: 2, separator: "", digits: 1, want: regexp.MustCompile(`^[a-z]+\d$`)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := NewWordIDGenerator(newSequenceStorageStub(), tt.count, tt.separator, tt.digits, 3)
+			require.NoError(t, err)
+			id, err := g.Generate(t.Context(), "https://example.com")
+			require.NoError(t, err)
+			assert.Regexp(t, tt.want, id)
+			assert.Regexp(t, aliasPattern, id)
+		})
+	}
+
+	s := newSequenceStorageStub()
+	_, err := NewWordIDGenerator(s, 0, "-", 2, 3)
+	assert.Error(t, err)
+	_, err = NewWordIDGenerator(s, 2, "/", 2, 3)
+	assert.Error(t, err)
+	_, err = NewWordIDGenerator(s, 2, "-", 10, 3)
+	assert.Error(t, err)
+	_, err = NewWordIDGenerator(s, 2, "-", 2, 0)
+	assert.Error(t, err)
+}
+
+func TestWordDictionary(t *testing.T) {
+	d, err := loadWordDictionary()
+	require.NoError(t, err)
+	require.NotEmpty(t, d.profanity)
+	for _, w := range append(d.adjectives, d.nouns...) {
+		assert.False(t, d.isProfane(w), w)
+	}
+
+	term := d.profanity[0]
+	assert.True(t, d.isProfane(term))
+	assert.True(t, d.isProfane(term[:1]+"-"+term[1:]+"-42"), "terms across word boundaries must be caught")
+	assert.True(t, d.isProfane("Brave-"+term), "check must be case-insensitive")
+}
+
+func TestWordIDGenerator_SkipsTakenAndProfane(t *testing.T) {
+	d, err := loadWordDictionary()
+	require.NoError(t, err)
+	g, err := NewWordIDGenerator(newSequenceStorageStub("taken-otter"), 2, "-", 0, 3)
+	require.NoError(t, err)
+
+	candidates := []string{d.profanity[0], "taken-otter", "brave-otter"}
+	g.next = func(_ context.Context, _ string, attempt int) (string, error) {
+		return candidates[attempt], nil
+	}
+	id, err := g.Generate(t.Context(), "https://example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "brave-otter", id)
+
+	g.maxAttempts = 2
+	_, err = g.Generate(t.Context(), "https://example.com")
+	require.ErrorIs(t, err, ErrShortIDExhausted)
+}
@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP INDEX IF EXISTS idx_url_storage_expires_at;
+
+ALTER TABLE url_storage DROP COLUMN IF EXISTS expires_at;
+
+COMMIT;
\ No newline at end of file
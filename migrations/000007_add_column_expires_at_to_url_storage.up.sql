@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE url_storage ADD COLUMN IF NOT EXISTS expires_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_url_storage_expires_at ON url_storage (expires_at) WHERE is_deleted = FALSE;
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE url_storage DROP COLUMN IF EXISTS clicks_left;
+ALTER TABLE url_storage DROP COLUMN IF EXISTS max_clicks;
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE url_storage ADD COLUMN IF NOT EXISTS max_clicks INTEGER;
+ALTER TABLE url_storage ADD COLUMN IF NOT EXISTS clicks_left INTEGER;
+
+COMMIT;
\ No newline at end of file
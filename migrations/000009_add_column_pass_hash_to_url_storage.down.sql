@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE url_storage DROP COLUMN IF EXISTS pass_hash;
+
+COMMIT;
\ No newline at end of file
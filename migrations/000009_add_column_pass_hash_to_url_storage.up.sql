@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE url_storage ADD COLUMN IF NOT EXISTS pass_hash TEXT;
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,19 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS url_history (
+    id         BIGINT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    url_id     INTEGER     NOT NULL REFERENCES url_storage (id) ON DELETE CASCADE,
+    action     VARCHAR(16) NOT NULL,
+    prev_url   TEXT,
+    url        TEXT        NOT NULL,
+    user_id    INTEGER,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_url_history_url_id ON url_history (url_id);
+
+INSERT INTO url_history (url_id, action, url, user_id, created_at)
+SELECT id, 'create', original_url, user_id, COALESCE(created_at, NOW())
+FROM url_storage;
+
+COMMIT;
\ No newline at end of file
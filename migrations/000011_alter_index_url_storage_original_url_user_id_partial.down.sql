@@ -0,0 +1,16 @@
+BEGIN;
+
+-- Deleted links may share the original URL with another link of the user; keep the non-deleted one, or the newest.
+DELETE FROM url_storage us
+WHERE EXISTS (
+    SELECT 1 FROM url_storage o
+    WHERE o.original_url = us.original_url
+    AND o.user_id = us.user_id
+    AND (o.is_deleted, -o.id) < (us.is_deleted, -us.id)
+);
+
+DROP INDEX IF EXISTS idx_url_storage_original_url_user_id;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_url_storage_original_url_user_id ON url_storage (original_url, user_id);
+
+COMMIT;
\ No newline at end of file
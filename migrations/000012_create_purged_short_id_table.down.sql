@@ -0,0 +1,13 @@
+BEGIN;
+
+DROP TRIGGER IF EXISTS trg_url_storage_reject_purged_short_id ON url_storage;
+
+DROP FUNCTION IF EXISTS reject_purged_short_id();
+
+DROP TABLE IF EXISTS purged_short_id;
+
+DROP INDEX IF EXISTS idx_url_storage_deleted_at;
+
+ALTER TABLE url_storage DROP COLUMN IF EXISTS deleted_at;
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,30 @@
+BEGIN;
+
+ALTER TABLE url_storage ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+
+UPDATE url_storage SET deleted_at = NOW() WHERE is_deleted = TRUE AND deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_url_storage_deleted_at ON url_storage (deleted_at) WHERE is_deleted = TRUE;
+
+CREATE TABLE IF NOT EXISTS purged_short_id (
+    short_id  VARCHAR(255) PRIMARY KEY,
+    purged_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE OR REPLACE FUNCTION reject_purged_short_id() RETURNS TRIGGER AS $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM purged_short_id WHERE short_id = NEW.short_id) THEN
+        RAISE EXCEPTION 'short id % was purged', NEW.short_id
+            USING ERRCODE = 'unique_violation', CONSTRAINT = 'url_storage_short_id_key';
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_url_storage_reject_purged_short_id ON url_storage;
+
+CREATE TRIGGER trg_url_storage_reject_purged_short_id
+    BEFORE INSERT ON url_storage
+    FOR EACH ROW EXECUTE FUNCTION reject_purged_short_id();
+
+COMMIT;
\ No newline at end of file
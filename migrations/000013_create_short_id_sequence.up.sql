@@ -0,0 +1,5 @@
+BEGIN;
+
+CREATE SEQUENCE IF NOT EXISTS short_id_seq AS BIGINT START WITH 1;
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP TABLE IF EXISTS url_tags;
+
+COMMIT;
\ No newline at end of file
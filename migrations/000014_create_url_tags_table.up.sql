@@ -0,0 +1,11 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS url_tags (
+    url_id INTEGER     NOT NULL REFERENCES url_storage (id) ON DELETE CASCADE,
+    tag    VARCHAR(64) NOT NULL,
+    PRIMARY KEY (url_id, tag)
+);
+
+CREATE INDEX IF NOT EXISTS idx_url_tags_tag ON url_tags (tag);
+
+COMMIT;
\ No newline at end of file
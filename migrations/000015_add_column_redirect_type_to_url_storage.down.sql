@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE url_storage DROP COLUMN IF EXISTS redirect_type;
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE url_storage ADD COLUMN IF NOT EXISTS redirect_type SMALLINT NOT NULL DEFAULT 307;
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP TABLE IF EXISTS utm_template;
+
+COMMIT;
\ No newline at end of file
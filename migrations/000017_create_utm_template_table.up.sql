@@ -0,0 +1,13 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS utm_template (
+    id         INTEGER PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    user_id    INTEGER      NOT NULL,
+    short_id   VARCHAR(255) NOT NULL DEFAULT '',
+    tag        VARCHAR(64)  NOT NULL DEFAULT '',
+    params     JSONB        NOT NULL,
+    updated_at TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, short_id, tag)
+);
+
+COMMIT;
\ No newline at end of file
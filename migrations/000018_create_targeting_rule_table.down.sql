@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP TABLE IF EXISTS targeting_rule;
+
+COMMIT;
\ No newline at end of file
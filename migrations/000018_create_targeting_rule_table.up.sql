@@ -0,0 +1,9 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS targeting_rule (
+    short_id   VARCHAR(255) PRIMARY KEY,
+    rules      JSONB        NOT NULL,
+    updated_at TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+COMMIT;
\ No newline at end of file
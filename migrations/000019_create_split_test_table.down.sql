@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP TABLE IF EXISTS split_test;
+
+COMMIT;
\ No newline at end of file
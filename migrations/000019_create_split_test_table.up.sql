@@ -0,0 +1,10 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS split_test (
+    short_id   VARCHAR(255) PRIMARY KEY,
+    sticky     BOOLEAN      NOT NULL DEFAULT FALSE,
+    variants   JSONB        NOT NULL,
+    updated_at TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+COMMIT;
\ No newline at end of file
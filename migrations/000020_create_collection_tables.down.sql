@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP TABLE IF EXISTS collection_url;
+
+DROP TABLE IF EXISTS collection;
+
+COMMIT;
\ No newline at end of file
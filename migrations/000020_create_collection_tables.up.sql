@@ -0,0 +1,18 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS collection (
+    id         VARCHAR(36)  PRIMARY KEY,
+    user_id    INTEGER      NOT NULL,
+    name       VARCHAR(64)  NOT NULL,
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS collection_url (
+    short_id      VARCHAR(255) PRIMARY KEY,
+    collection_id VARCHAR(36)  NOT NULL REFERENCES collection (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_collection_url_collection_id ON collection_url (collection_id);
+
+COMMIT;
\ No newline at end of file
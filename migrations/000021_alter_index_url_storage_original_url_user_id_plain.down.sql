@@ -0,0 +1,20 @@
+BEGIN;
+
+-- Expiring and click-limited links may share the original URL with another live link of the user;
+-- keep the plain one, or the oldest.
+DELETE FROM url_storage us
+WHERE us.is_deleted = FALSE
+AND EXISTS (
+    SELECT 1 FROM url_storage o
+    WHERE o.original_url = us.original_url
+    AND o.user_id = us.user_id
+    AND o.is_deleted = FALSE
+    AND (o.expires_at IS NOT NULL OR o.max_clicks IS NOT NULL, o.id)
+        < (us.expires_at IS NOT NULL OR us.max_clicks IS NOT NULL, us.id)
+);
+
+DROP INDEX IF EXISTS idx_url_storage_original_url_user_id;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_url_storage_original_url_user_id ON url_storage (original_url, user_id) WHERE is_deleted = FALSE;
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,23 @@
+BEGIN;
+
+-- Protected links may share the original URL with another live plain link of the user; keep the public one, or the oldest.
+DELETE FROM url_storage us
+WHERE us.is_deleted = FALSE
+AND us.expires_at IS NULL
+AND us.max_clicks IS NULL
+AND EXISTS (
+    SELECT 1 FROM url_storage o
+    WHERE o.original_url = us.original_url
+    AND o.user_id = us.user_id
+    AND o.is_deleted = FALSE
+    AND o.expires_at IS NULL
+    AND o.max_clicks IS NULL
+    AND (o.pass_hash IS NOT NULL, o.id) < (us.pass_hash IS NOT NULL, us.id)
+);
+
+DROP INDEX IF EXISTS idx_url_storage_original_url_user_id;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_url_storage_original_url_user_id ON url_storage (original_url, user_id)
+WHERE is_deleted = FALSE AND expires_at IS NULL AND max_clicks IS NULL;
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,8 @@
+BEGIN;
+
+DROP INDEX IF EXISTS idx_url_storage_original_url_user_id;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_url_storage_original_url_user_id ON url_storage (original_url, user_id)
+WHERE is_deleted = FALSE AND expires_at IS NULL AND max_clicks IS NULL AND pass_hash IS NULL;
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE targeting_rule DROP CONSTRAINT IF EXISTS targeting_rule_short_id_fkey;
+
+COMMIT;
\ No newline at end of file
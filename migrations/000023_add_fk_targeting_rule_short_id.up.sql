@@ -0,0 +1,10 @@
+BEGIN;
+
+DELETE FROM targeting_rule
+WHERE short_id NOT IN (SELECT short_id FROM url_storage);
+
+ALTER TABLE targeting_rule
+    ADD CONSTRAINT targeting_rule_short_id_fkey
+    FOREIGN KEY (short_id) REFERENCES url_storage (short_id) ON DELETE CASCADE;
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE split_test DROP CONSTRAINT IF EXISTS split_test_short_id_fkey;
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE collection_url DROP CONSTRAINT IF EXISTS collection_url_short_id_fkey;
+
+COMMIT;
\ No newline at end of file